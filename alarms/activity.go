@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const maxCommentLength = 4096
+
+var (
+	errCommentContent = errors.New("comment content is required")
+	errCommentLength  = errors.New("comment content is too long")
+	errCommentLink    = errors.New("comment link must be an absolute http or https URL")
+)
+
+// ActivityType is the type of an alarm activity.
+type ActivityType string
+
+const (
+	// CreateActivity is the creation of the alarm. It is not stored, the
+	// timeline derives it from the alarm.
+	CreateActivity ActivityType = "create"
+	// CommentActivity is a free-text comment of an operator.
+	CommentActivity ActivityType = "comment"
+	// StatusActivity is a change of the alarm status.
+	StatusActivity ActivityType = "status"
+	// AssignActivity is an assignment of the alarm.
+	AssignActivity ActivityType = "assign"
+	// AcknowledgeActivity is the acknowledgement of the alarm.
+	AcknowledgeActivity ActivityType = "acknowledge"
+	// ResolveActivity is the resolution of the alarm.
+	ResolveActivity ActivityType = "resolve"
+	// ShelveActivity is the shelving of the alarm.
+	ShelveActivity ActivityType = "shelve"
+	// UnshelveActivity is the early end of the alarm shelving.
+	UnshelveActivity ActivityType = "unshelve"
+)
+
+// Activity is an entry of the append-only activity log of an alarm.
+type Activity struct {
+	ID        string         `json:"id"`
+	AlarmID   string         `json:"alarm_id"`
+	DomainID  string         `json:"domain_id"`
+	Type      ActivityType   `json:"type"`
+	Content   string         `json:"content,omitempty"`
+	Links     []Link         `json:"links,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	CreatedBy string         `json:"created_by,omitempty"`
+}
+
+// Link is a reference attached to a comment, such as a ticket or a runbook.
+type Link struct {
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+}
+
+// Comment is a comment to add to an alarm.
+type Comment struct {
+	Content string `json:"content"`
+	Links   []Link `json:"links,omitempty"`
+}
+
+func (c Comment) Validate() error {
+	if c.Content == "" {
+		return errCommentContent
+	}
+	if len(c.Content) > maxCommentLength {
+		return errCommentLength
+	}
+	for _, l := range c.Links {
+		u, err := url.Parse(l.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errCommentLink
+		}
+	}
+
+	return nil
+}
+
+type ActivitiesPage struct {
+	Offset     uint64     `json:"offset"`
+	Limit      uint64     `json:"limit"`
+	Total      uint64     `json:"total"`
+	Activities []Activity `json:"activities"`
+}
+
+// ActivityPageMeta selects the activities of an alarm. An empty Type selects
+// the activities of all types.
+type ActivityPageMeta struct {
+	Offset   uint64       `json:"offset"    db:"offset"`
+	Limit    uint64       `json:"limit"     db:"limit"`
+	AlarmID  string       `json:"alarm_id"  db:"alarm_id"`
+	DomainID string       `json:"domain_id" db:"domain_id"`
+	Type     ActivityType `json:"type"      db:"type"`
+}
+
+// ActivitiesFunc returns the activities of the alarms changed by an update.
+// The repository records them in the transaction of the update, so every
+// committed change has its entries in the activity log.
+type ActivitiesFunc func(changed []Alarm) ([]Activity, error)
+
+// updateActivities returns the activities of the update of an alarm, made
+// by the update request.
+func updateActivities(session authn.Session, update, updated Alarm) []Activity {
+	var acts []Activity
+	add := func(t ActivityType, details map[string]any) {
+		acts = append(acts, Activity{
+			AlarmID:   updated.ID,
+			DomainID:  session.DomainID,
+			Type:      t,
+			Details:   details,
+			CreatedAt: update.UpdatedAt,
+			CreatedBy: session.UserID,
+		})
+	}
+	if update.Status != ActiveStatus {
+		add(StatusActivity, map[string]any{"status": updated.Status.String()})
+	}
+	if update.AssigneeID != "" {
+		add(AssignActivity, map[string]any{"assignee_id": updated.AssigneeID})
+	}
+	if update.AcknowledgedBy != "" {
+		add(AcknowledgeActivity, nil)
+	}
+	if update.ResolvedBy != "" {
+		add(ResolveActivity, nil)
+	}
+
+	return acts
+}
+
+// activities returns the ActivitiesFunc of the activities which the
+// function returns for each changed alarm.
+func (s *service) activities(f func(changed Alarm) []Activity) ActivitiesFunc {
+	return func(changed []Alarm) ([]Activity, error) {
+		var acts []Activity
+		for _, a := range changed {
+			acts = append(acts, f(a)...)
+		}
+		for i := range acts {
+			id, err := s.idp.ID()
+			if err != nil {
+				return nil, errors.Wrap(errRecordActivity, err)
+			}
+			acts[i].ID = id
+		}
+
+		return acts, nil
+	}
+}
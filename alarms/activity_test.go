@@ -0,0 +1,167 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/mocks"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommentValidate(t *testing.T) {
+	cases := []struct {
+		desc    string
+		comment alarms.Comment
+		err     bool
+	}{
+		{
+			desc:    "valid comment",
+			comment: alarms.Comment{Content: "checked the sensor"},
+		},
+		{
+			desc:    "valid comment with links",
+			comment: alarms.Comment{Content: "opened a ticket", Links: []alarms.Link{{Title: "ticket", URL: "https://example.com/tickets/1"}}},
+		},
+		{
+			desc:    "comment without content",
+			comment: alarms.Comment{},
+			err:     true,
+		},
+		{
+			desc:    "comment with too long content",
+			comment: alarms.Comment{Content: strings.Repeat("a", 4097)},
+			err:     true,
+		},
+		{
+			desc:    "comment with relative link",
+			comment: alarms.Comment{Content: "runbook", Links: []alarms.Link{{URL: "/runbooks/1"}}},
+			err:     true,
+		},
+		{
+			desc:    "comment with non http link",
+			comment: alarms.Comment{Content: "runbook", Links: []alarms.Link{{URL: "ftp://example.com/runbook"}}},
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.comment.Validate()
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		})
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+	comment := alarms.Comment{Content: "checked the sensor", Links: []alarms.Link{{URL: "https://example.com/tickets/1"}}}
+
+	cases := []struct {
+		desc    string
+		alarmID string
+		viewErr error
+		repoErr error
+		err     error
+	}{
+		{
+			desc:    "add comment successfully",
+			alarmID: "alarm-id",
+			err:     nil,
+		},
+		{
+			desc:    "add comment to non existing alarm",
+			alarmID: "alarm-id",
+			viewErr: repoerr.ErrNotFound,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "add comment with failed repo",
+			alarmID: "alarm-id",
+			repoErr: repoerr.ErrCreateEntity,
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ViewAlarm", context.Background(), tc.alarmID, session.DomainID).Return(alarms.Alarm{ID: tc.alarmID}, tc.viewErr)
+			repoCall1 := repo.On("AddActivities", context.Background(), mock.Anything).Return(tc.repoErr)
+			act, err := svc.AddComment(context.Background(), session, tc.alarmID, comment)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, act.ID, fmt.Sprintf("%s: expected activity id to be set", tc.desc))
+				assert.Equal(t, alarms.CommentActivity, act.Type, fmt.Sprintf("%s: expected type %s got %s\n", tc.desc, alarms.CommentActivity, act.Type))
+				assert.Equal(t, comment.Content, act.Content, fmt.Sprintf("%s: expected content %s got %s\n", tc.desc, comment.Content, act.Content))
+				assert.Equal(t, session.UserID, act.CreatedBy, fmt.Sprintf("%s: expected author %s got %s\n", tc.desc, session.UserID, act.CreatedBy))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestListComments(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+
+	var pm alarms.ActivityPageMeta
+	repoCall := repo.On("ListActivities", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+		pm = args.Get(1).(alarms.ActivityPageMeta)
+	}).Return(alarms.ActivitiesPage{}, nil)
+	defer repoCall.Unset()
+
+	_, err := svc.ListComments(context.Background(), session, "alarm-id", alarms.ActivityPageMeta{Limit: 10, Type: alarms.StatusActivity})
+	assert.Nil(t, err, fmt.Sprintf("list comments: unexpected error %v", err))
+	assert.Equal(t, "alarm-id", pm.AlarmID, fmt.Sprintf("list comments: expected alarm alarm-id got %s", pm.AlarmID))
+	assert.Equal(t, session.DomainID, pm.DomainID, fmt.Sprintf("list comments: expected domain %s got %s", session.DomainID, pm.DomainID))
+	assert.Equal(t, alarms.CommentActivity, pm.Type, fmt.Sprintf("list comments: expected type %s got %s", alarms.CommentActivity, pm.Type))
+}
+
+func TestAlarmTimeline(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+
+	cases := []struct {
+		desc    string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "view alarm timeline successfully",
+			err:  nil,
+		},
+		{
+			desc:    "view alarm timeline with failed repo",
+			repoErr: repoerr.ErrViewEntity,
+			err:     repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var pm alarms.ActivityPageMeta
+			repoCall := repo.On("AlarmTimeline", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				pm = args.Get(1).(alarms.ActivityPageMeta)
+			}).Return(alarms.ActivitiesPage{}, tc.repoErr)
+			_, err := svc.AlarmTimeline(context.Background(), session, "alarm-id", alarms.ActivityPageMeta{Limit: 10})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, "alarm-id", pm.AlarmID, fmt.Sprintf("%s: expected alarm alarm-id got %s\n", tc.desc, pm.AlarmID))
+			assert.Equal(t, session.DomainID, pm.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, session.DomainID, pm.DomainID))
+			repoCall.Unset()
+		})
+	}
+}
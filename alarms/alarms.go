@@ -26,12 +26,14 @@ type Alarm struct {
 	ClientID       string    `json:"client_id"`
 	Subtopic       string    `json:"subtopic"`
 	Status         Status    `json:"status"`
+	State          State     `json:"state"`
 	Measurement    string    `json:"measurement"`
 	Value          string    `json:"value"`
 	Unit           string    `json:"unit"`
 	Threshold      string    `json:"threshold"`
 	Cause          string    `json:"cause"`
 	Severity       uint8     `json:"severity"`
+	Flapping       bool      `json:"flapping"`
 	AssigneeID     string    `json:"assignee_id"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
@@ -42,6 +44,10 @@ type Alarm struct {
 	AcknowledgedBy string    `json:"acknowledged_by,omitempty"`
 	ResolvedAt     time.Time `json:"resolved_at,omitempty"`
 	ResolvedBy     string    `json:"resolved_by,omitempty"`
+	ClearedAt      time.Time `json:"cleared_at,omitempty"`
+	ShelvedUntil   time.Time `json:"shelved_until,omitempty"`
+	ShelvedAt      time.Time `json:"shelved_at,omitempty"`
+	ShelvedBy      string    `json:"shelved_by,omitempty"`
 	Metadata       Metadata  `json:"metadata,omitempty"`
 }
 
@@ -65,6 +71,7 @@ type PageMetadata struct {
 	Dir            string    `json:"dir"             db:"dir"`
 	Order          string    `json:"order"           db:"order"`
 	Status         Status    `json:"status"          db:"status"`
+	State          State     `json:"state"           db:"state"`
 	CreatedFrom    time.Time `json:"created_from"    db:"created_from"`
 	CreatedTo      time.Time `json:"created_to"      db:"created_to"`
 	AssigneeID     string    `json:"assignee_id"     db:"assignee_id"`
@@ -74,20 +81,12 @@ type PageMetadata struct {
 	AcknowledgedBy string    `json:"acknowledged_by" db:"acknowledged_by"`
 	ResolvedBy     string    `json:"resolved_by"     db:"resolved_by"`
 	UserID         string    `json:"user_id"         db:"user_id"`
+	AlarmIDs       []string  `json:"alarm_ids"       db:"alarm_ids"`
 }
 
 func (a Alarm) Validate() error {
-	if a.RuleID == "" {
-		return errors.New("rule_id is required")
-	}
-	if a.DomainID == "" {
-		return errors.New("domain_id is required")
-	}
-	if a.ChannelID == "" {
-		return errors.New("channel_id is required")
-	}
-	if a.ClientID == "" {
-		return errors.New("client_id is required")
+	if err := a.ValidateSource(); err != nil {
+		return err
 	}
 	if a.Measurement == "" {
 		return errors.New("measurement is required")
@@ -105,6 +104,24 @@ func (a Alarm) Validate() error {
 	return nil
 }
 
+// ValidateSource validates the rule and the message which raised the alarm.
+func (a Alarm) ValidateSource() error {
+	if a.RuleID == "" {
+		return errors.New("rule_id is required")
+	}
+	if a.DomainID == "" {
+		return errors.New("domain_id is required")
+	}
+	if a.ChannelID == "" {
+		return errors.New("channel_id is required")
+	}
+	if a.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+
+	return nil
+}
+
 // Service specifies an API that must be fulfilled by the domain service.
 type Service interface {
 	CreateAlarm(ctx context.Context, alarm Alarm) (Alarm, error)
@@ -112,12 +129,108 @@ type Service interface {
 	ViewAlarm(ctx context.Context, session authn.Session, id string) (Alarm, error)
 	ListAlarms(ctx context.Context, session authn.Session, pm PageMetadata) (AlarmsPage, error)
 	DeleteAlarm(ctx context.Context, session authn.Session, id string) error
+	// ShelveAlarm hides the alarm from operators and holds back its
+	// escalation until the shelving expires.
+	ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve Shelve) (Alarm, error)
+	UnshelveAlarm(ctx context.Context, session authn.Session, id string) (Alarm, error)
+	// ClearAlarms clears the alarms of the source of the alarm once its
+	// condition returns to normal. An empty measurement clears the alarms of
+	// all the source measurements.
+	ClearAlarms(ctx context.Context, alarm Alarm) ([]Alarm, error)
+	// BulkUpdateAlarms acknowledges, resolves, assigns or deletes the domain
+	// alarms selected by the request and reports the outcome of each alarm.
+	BulkUpdateAlarms(ctx context.Context, session authn.Session, req BulkRequest) (BulkResult, error)
+	// StreamAlarms returns the changes of the domain alarms matching the filter
+	// until the context is done.
+	StreamAlarms(ctx context.Context, session authn.Session, filter StreamFilter) (<-chan Event, error)
+	// AlarmStats returns the statistics of the domain alarms created within the time range.
+	AlarmStats(ctx context.Context, session authn.Session, pm StatsPageMeta) (Stats, error)
+
+	// AddComment appends a comment to the alarm activity log.
+	AddComment(ctx context.Context, session authn.Session, alarmID string, comment Comment) (Activity, error)
+	ListComments(ctx context.Context, session authn.Session, alarmID string, pm ActivityPageMeta) (ActivitiesPage, error)
+	// AlarmTimeline returns the alarm creation followed by the alarm activities
+	// in chronological order.
+	AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm ActivityPageMeta) (ActivitiesPage, error)
+
+	CreateEscalationPolicy(ctx context.Context, session authn.Session, policy EscalationPolicy) (EscalationPolicy, error)
+	ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (EscalationPolicy, error)
+	ListEscalationPolicies(ctx context.Context, session authn.Session, pm EscalationPolicyPageMeta) (EscalationPoliciesPage, error)
+	UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy EscalationPolicy) (EscalationPolicy, error)
+	DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error
+
+	CreateSuppressionWindow(ctx context.Context, session authn.Session, window SuppressionWindow) (SuppressionWindow, error)
+	ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (SuppressionWindow, error)
+	ListSuppressionWindows(ctx context.Context, session authn.Session, pm SuppressionWindowPageMeta) (SuppressionWindowsPage, error)
+	UpdateSuppressionWindow(ctx context.Context, session authn.Session, window SuppressionWindow) (SuppressionWindow, error)
+	DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error
 }
 
 type Repository interface {
-	CreateAlarm(ctx context.Context, alarm Alarm) (Alarm, error)
-	UpdateAlarm(ctx context.Context, alarm Alarm) (Alarm, error)
+	// CreateAlarm saves the alarm and its escalations in one transaction.
+	CreateAlarm(ctx context.Context, alarm Alarm, escalations []Escalation) (Alarm, error)
+	// UpdateAlarm updates the alarm and records the activities of the update
+	// in the same transaction. The alarm is updated only if its state allows
+	// the requested transitions, otherwise ErrInvalidTransition is returned.
+	UpdateAlarm(ctx context.Context, alarm Alarm, activities ActivitiesFunc) (Alarm, error)
 	ViewAlarm(ctx context.Context, alarmID, domainID string) (Alarm, error)
 	ListAllAlarms(ctx context.Context, pm PageMetadata) (AlarmsPage, error)
 	DeleteAlarm(ctx context.Context, id string) error
+	// UpdateAlarms applies the update to at most pm.Limit of the alarms matching
+	// the page metadata, oldest first, in a single transaction. Only the
+	// alarms whose state allows it are acknowledged or resolved, the
+	// escalations of the acknowledged or resolved alarms are cancelled and
+	// the activities of the updated alarms are recorded.
+	UpdateAlarms(ctx context.Context, pm PageMetadata, update Alarm, activities ActivitiesFunc) ([]Alarm, error)
+	// DeleteAlarms deletes at most pm.Limit of the alarms matching the page
+	// metadata, oldest first, in a single transaction and returns them.
+	DeleteAlarms(ctx context.Context, pm PageMetadata) ([]Alarm, error)
+	UpdateAlarmSeverity(ctx context.Context, id string, severity uint8) (Alarm, error)
+	// ShelveAlarm sets the shelving of the alarm and records the activities
+	// of the change in the same transaction. A zero ShelvedUntil unshelves
+	// the alarm. ErrInvalidTransition is returned if the alarm state doesn't
+	// allow the change.
+	ShelveAlarm(ctx context.Context, alarm Alarm, activities ActivitiesFunc) (Alarm, error)
+	// ClearAlarms clears the latest alarm of each measurement of the source
+	// of the alarm, or of its measurement if it is set, unless it is already
+	// cleared, records the activities of the cleared alarms in the same
+	// transaction and returns the cleared alarms.
+	ClearAlarms(ctx context.Context, alarm Alarm, activities ActivitiesFunc) ([]Alarm, error)
+	// CountStateChanges returns the number of alarms raised and cleared for
+	// the source of the alarm since the given time. Only the state changes of
+	// a source are stored, so it is the number of its state changes.
+	CountStateChanges(ctx context.Context, alarm Alarm, since time.Time) (uint64, error)
+	// MarkFlapping marks the latest alarm of the source of the alarm as flapping.
+	MarkFlapping(ctx context.Context, alarm Alarm) error
+	AlarmStats(ctx context.Context, pm StatsPageMeta) (Stats, error)
+
+	AddActivities(ctx context.Context, activities []Activity) error
+	ListActivities(ctx context.Context, pm ActivityPageMeta) (ActivitiesPage, error)
+	// AlarmTimeline returns the activities of the alarm, preceded by its
+	// creation, in chronological order.
+	AlarmTimeline(ctx context.Context, pm ActivityPageMeta) (ActivitiesPage, error)
+
+	CreateEscalationPolicy(ctx context.Context, policy EscalationPolicy) (EscalationPolicy, error)
+	ViewEscalationPolicy(ctx context.Context, id, domainID string) (EscalationPolicy, error)
+	ListEscalationPolicies(ctx context.Context, pm EscalationPolicyPageMeta) (EscalationPoliciesPage, error)
+	UpdateEscalationPolicy(ctx context.Context, policy EscalationPolicy) (EscalationPolicy, error)
+	DeleteEscalationPolicy(ctx context.Context, id, domainID string) error
+	// MatchEscalationPolicies returns the escalation policies of the alarm domain that match the alarm.
+	MatchEscalationPolicies(ctx context.Context, alarm Alarm) ([]EscalationPolicy, error)
+	// ClaimEscalations returns the escalations due by the due time and postpones
+	// them until the until time, so concurrent runs do not claim them again.
+	ClaimEscalations(ctx context.Context, due, until time.Time, limit uint64) ([]Escalation, error)
+	UpdateEscalation(ctx context.Context, escalation Escalation) error
+	RemoveEscalation(ctx context.Context, alarmID, policyID string) error
+	// RemoveAlarmEscalations cancels all the escalations of the alarm.
+	RemoveAlarmEscalations(ctx context.Context, alarmID string) error
+
+	CreateSuppressionWindow(ctx context.Context, window SuppressionWindow) (SuppressionWindow, error)
+	ViewSuppressionWindow(ctx context.Context, id, domainID string) (SuppressionWindow, error)
+	ListSuppressionWindows(ctx context.Context, pm SuppressionWindowPageMeta) (SuppressionWindowsPage, error)
+	UpdateSuppressionWindow(ctx context.Context, window SuppressionWindow) (SuppressionWindow, error)
+	DeleteSuppressionWindow(ctx context.Context, id, domainID string) error
+	// MatchSuppressionWindows returns the suppression windows of the alarm domain
+	// that cover the alarm channel or client at the alarm creation time.
+	MatchSuppressionWindows(ctx context.Context, alarm Alarm) ([]SuppressionWindow, error)
 }
@@ -83,6 +83,27 @@ func listAlarmsEndpoint(svc alarms.Service) endpoint.Endpoint {
 	}
 }
 
+func alarmStatsEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(alarmStatsReq)
+		if err := req.validate(); err != nil {
+			return alarmStatsRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return alarmStatsRes{}, svcerr.ErrAuthorization
+		}
+
+		stats, err := svc.AlarmStats(ctx, session, req.StatsPageMeta)
+		if err != nil {
+			return alarmStatsRes{}, err
+		}
+
+		return alarmStatsRes{Stats: stats}, nil
+	}
+}
+
 func deleteAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request any) (any, error) {
 		req := request.(alarmReq)
@@ -102,3 +123,343 @@ func deleteAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
 		return alarmRes{deleted: true}, nil
 	}
 }
+
+func bulkUpdateAlarmsEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(bulkReq)
+		if err := req.validate(); err != nil {
+			return bulkRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return bulkRes{}, svcerr.ErrAuthorization
+		}
+
+		res, err := svc.BulkUpdateAlarms(ctx, session, req.BulkRequest)
+		if err != nil {
+			return bulkRes{}, err
+		}
+
+		return bulkRes{BulkResult: res}, nil
+	}
+}
+
+func shelveAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(shelveAlarmReq)
+		if err := req.validate(); err != nil {
+			return alarmRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return alarmRes{}, svcerr.ErrAuthorization
+		}
+
+		alarm, err := svc.ShelveAlarm(ctx, session, req.alarmID, req.Shelve)
+		if err != nil {
+			return alarmRes{}, err
+		}
+
+		return alarmRes{Alarm: alarm}, nil
+	}
+}
+
+func unshelveAlarmEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(alarmReq)
+		if err := req.validate(); err != nil {
+			return alarmRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return alarmRes{}, svcerr.ErrAuthorization
+		}
+
+		alarm, err := svc.UnshelveAlarm(ctx, session, req.ID)
+		if err != nil {
+			return alarmRes{}, err
+		}
+
+		return alarmRes{Alarm: alarm}, nil
+	}
+}
+
+func addCommentEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(addCommentReq)
+		if err := req.validate(); err != nil {
+			return commentRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return commentRes{}, svcerr.ErrAuthorization
+		}
+
+		comment, err := svc.AddComment(ctx, session, req.alarmID, req.Comment)
+		if err != nil {
+			return commentRes{}, err
+		}
+
+		return commentRes{Activity: comment}, nil
+	}
+}
+
+func listCommentsEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listActivitiesReq)
+		if err := req.validate(); err != nil {
+			return activitiesPageRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return activitiesPageRes{}, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListComments(ctx, session, req.alarmID, req.ActivityPageMeta)
+		if err != nil {
+			return activitiesPageRes{}, err
+		}
+
+		return activitiesPageRes{ActivitiesPage: page}, nil
+	}
+}
+
+func alarmTimelineEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listActivitiesReq)
+		if err := req.validate(); err != nil {
+			return activitiesPageRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return activitiesPageRes{}, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.AlarmTimeline(ctx, session, req.alarmID, req.ActivityPageMeta)
+		if err != nil {
+			return activitiesPageRes{}, err
+		}
+
+		return activitiesPageRes{ActivitiesPage: page}, nil
+	}
+}
+
+func createEscalationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(escalationPolicyReq)
+		if err := req.validate(); err != nil {
+			return escalationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return escalationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		policy, err := svc.CreateEscalationPolicy(ctx, session, req.EscalationPolicy)
+		if err != nil {
+			return escalationPolicyRes{}, err
+		}
+
+		return escalationPolicyRes{EscalationPolicy: policy, created: true}, nil
+	}
+}
+
+func viewEscalationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(escalationPolicyIDReq)
+		if err := req.validate(); err != nil {
+			return escalationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return escalationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		policy, err := svc.ViewEscalationPolicy(ctx, session, req.id)
+		if err != nil {
+			return escalationPolicyRes{}, err
+		}
+
+		return escalationPolicyRes{EscalationPolicy: policy}, nil
+	}
+}
+
+func listEscalationPoliciesEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listEscalationPoliciesReq)
+		if err := req.validate(); err != nil {
+			return escalationPoliciesPageRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return escalationPoliciesPageRes{}, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListEscalationPolicies(ctx, session, req.EscalationPolicyPageMeta)
+		if err != nil {
+			return escalationPoliciesPageRes{}, err
+		}
+
+		return escalationPoliciesPageRes{EscalationPoliciesPage: page}, nil
+	}
+}
+
+func updateEscalationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(escalationPolicyReq)
+		if req.ID == "" {
+			return escalationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID)
+		}
+		if err := req.validate(); err != nil {
+			return escalationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return escalationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		policy, err := svc.UpdateEscalationPolicy(ctx, session, req.EscalationPolicy)
+		if err != nil {
+			return escalationPolicyRes{}, err
+		}
+
+		return escalationPolicyRes{EscalationPolicy: policy}, nil
+	}
+}
+
+func deleteEscalationPolicyEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(escalationPolicyIDReq)
+		if err := req.validate(); err != nil {
+			return escalationPolicyRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return escalationPolicyRes{}, svcerr.ErrAuthorization
+		}
+
+		if err := svc.DeleteEscalationPolicy(ctx, session, req.id); err != nil {
+			return escalationPolicyRes{}, err
+		}
+
+		return escalationPolicyRes{deleted: true}, nil
+	}
+}
+
+func createSuppressionWindowEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(suppressionWindowReq)
+		if err := req.validate(); err != nil {
+			return suppressionWindowRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return suppressionWindowRes{}, svcerr.ErrAuthorization
+		}
+
+		window, err := svc.CreateSuppressionWindow(ctx, session, req.SuppressionWindow)
+		if err != nil {
+			return suppressionWindowRes{}, err
+		}
+
+		return suppressionWindowRes{SuppressionWindow: window, created: true}, nil
+	}
+}
+
+func viewSuppressionWindowEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(suppressionWindowIDReq)
+		if err := req.validate(); err != nil {
+			return suppressionWindowRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return suppressionWindowRes{}, svcerr.ErrAuthorization
+		}
+
+		window, err := svc.ViewSuppressionWindow(ctx, session, req.id)
+		if err != nil {
+			return suppressionWindowRes{}, err
+		}
+
+		return suppressionWindowRes{SuppressionWindow: window}, nil
+	}
+}
+
+func listSuppressionWindowsEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(listSuppressionWindowsReq)
+		if err := req.validate(); err != nil {
+			return suppressionWindowsPageRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return suppressionWindowsPageRes{}, svcerr.ErrAuthorization
+		}
+
+		page, err := svc.ListSuppressionWindows(ctx, session, req.SuppressionWindowPageMeta)
+		if err != nil {
+			return suppressionWindowsPageRes{}, err
+		}
+
+		return suppressionWindowsPageRes{SuppressionWindowsPage: page}, nil
+	}
+}
+
+func updateSuppressionWindowEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(suppressionWindowReq)
+		if req.ID == "" {
+			return suppressionWindowRes{}, errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingID)
+		}
+		if err := req.validate(); err != nil {
+			return suppressionWindowRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return suppressionWindowRes{}, svcerr.ErrAuthorization
+		}
+
+		window, err := svc.UpdateSuppressionWindow(ctx, session, req.SuppressionWindow)
+		if err != nil {
+			return suppressionWindowRes{}, err
+		}
+
+		return suppressionWindowRes{SuppressionWindow: window}, nil
+	}
+}
+
+func deleteSuppressionWindowEndpoint(svc alarms.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		req := request.(suppressionWindowIDReq)
+		if err := req.validate(); err != nil {
+			return suppressionWindowRes{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return suppressionWindowRes{}, svcerr.ErrAuthorization
+		}
+
+		if err := svc.DeleteSuppressionWindow(ctx, session, req.id); err != nil {
+			return suppressionWindowRes{}, err
+		}
+
+		return suppressionWindowRes{deleted: true}, nil
+	}
+}
@@ -5,6 +5,7 @@ package api
 
 import (
 	"errors"
+	"time"
 
 	"github.com/absmach/magistrala/alarms"
 	api "github.com/absmach/magistrala/api/http"
@@ -38,6 +39,19 @@ func (req updateAlarmReq) validate() error {
 	return nil
 }
 
+type shelveAlarmReq struct {
+	alarmID string
+	alarms.Shelve
+}
+
+func (req shelveAlarmReq) validate() error {
+	if req.alarmID == "" {
+		return errors.New("missing alarm id")
+	}
+
+	return req.Shelve.Validate(time.Now())
+}
+
 type listAlarmsReq struct {
 	alarms.PageMetadata
 }
@@ -57,3 +71,130 @@ func (req listAlarmsReq) validate() error {
 
 	return nil
 }
+
+type escalationPolicyReq struct {
+	alarms.EscalationPolicy
+}
+
+func (req escalationPolicyReq) validate() error {
+	return req.EscalationPolicy.Validate()
+}
+
+type escalationPolicyIDReq struct {
+	id string
+}
+
+func (req escalationPolicyIDReq) validate() error {
+	if req.id == "" {
+		return errors.New("missing escalation policy id")
+	}
+
+	return nil
+}
+
+type listEscalationPoliciesReq struct {
+	alarms.EscalationPolicyPageMeta
+}
+
+func (req listEscalationPoliciesReq) validate() error {
+	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type suppressionWindowReq struct {
+	alarms.SuppressionWindow
+}
+
+func (req suppressionWindowReq) validate() error {
+	return req.SuppressionWindow.Validate()
+}
+
+type suppressionWindowIDReq struct {
+	id string
+}
+
+func (req suppressionWindowIDReq) validate() error {
+	if req.id == "" {
+		return errors.New("missing suppression window id")
+	}
+
+	return nil
+}
+
+type listSuppressionWindowsReq struct {
+	alarms.SuppressionWindowPageMeta
+}
+
+func (req listSuppressionWindowsReq) validate() error {
+	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+const (
+	maxStatsBuckets = 1000
+	maxStatsTop     = 100
+)
+
+type alarmStatsReq struct {
+	alarms.StatsPageMeta
+}
+
+func (req alarmStatsReq) validate() error {
+	if !req.To.After(req.From) {
+		return errors.New("stats to must be after from")
+	}
+	if req.Interval < time.Second {
+		return errors.New("stats interval must be at least a second")
+	}
+	if req.To.Sub(req.From)/req.Interval > maxStatsBuckets {
+		return errors.New("stats interval is too short for the time range")
+	}
+	if req.Top < 1 || req.Top > maxStatsTop {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type bulkReq struct {
+	alarms.BulkRequest
+}
+
+func (req bulkReq) validate() error {
+	return req.BulkRequest.Validate()
+}
+
+type addCommentReq struct {
+	alarmID string
+	alarms.Comment
+}
+
+func (req addCommentReq) validate() error {
+	if req.alarmID == "" {
+		return errors.New("missing alarm id")
+	}
+
+	return req.Comment.Validate()
+}
+
+type listActivitiesReq struct {
+	alarmID string
+	alarms.ActivityPageMeta
+}
+
+func (req listActivitiesReq) validate() error {
+	if req.alarmID == "" {
+		return errors.New("missing alarm id")
+	}
+	if req.Limit > api.MaxLimitSize || req.Limit < 1 {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
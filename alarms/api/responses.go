@@ -14,6 +14,10 @@ import (
 var (
 	_ magistrala.Response = (*alarmRes)(nil)
 	_ magistrala.Response = (*alarmsPageRes)(nil)
+	_ magistrala.Response = (*escalationPolicyRes)(nil)
+	_ magistrala.Response = (*escalationPoliciesPageRes)(nil)
+	_ magistrala.Response = (*suppressionWindowRes)(nil)
+	_ magistrala.Response = (*suppressionWindowsPageRes)(nil)
 )
 
 type alarmRes struct {
@@ -68,3 +72,163 @@ func (res alarmsPageRes) Code() int {
 func (res alarmsPageRes) Empty() bool {
 	return false
 }
+
+type alarmStatsRes struct {
+	alarms.Stats `json:",inline"`
+}
+
+func (res alarmStatsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res alarmStatsRes) Code() int {
+	return http.StatusOK
+}
+
+func (res alarmStatsRes) Empty() bool {
+	return false
+}
+
+type bulkRes struct {
+	alarms.BulkResult `json:",inline"`
+}
+
+func (res bulkRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bulkRes) Code() int {
+	return http.StatusOK
+}
+
+func (res bulkRes) Empty() bool {
+	return false
+}
+
+type commentRes struct {
+	alarms.Activity `json:",inline"`
+}
+
+func (res commentRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res commentRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res commentRes) Empty() bool {
+	return false
+}
+
+type activitiesPageRes struct {
+	alarms.ActivitiesPage `json:",inline"`
+}
+
+func (res activitiesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res activitiesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res activitiesPageRes) Empty() bool {
+	return false
+}
+
+type escalationPolicyRes struct {
+	alarms.EscalationPolicy `json:",inline"`
+	created                 bool
+	deleted                 bool
+}
+
+func (res escalationPolicyRes) Headers() map[string]string {
+	switch {
+	case res.created:
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/alarms/escalation-policies/%s", res.DomainID, res.ID),
+		}
+	default:
+		return map[string]string{}
+	}
+}
+
+func (res escalationPolicyRes) Code() int {
+	switch {
+	case res.created:
+		return http.StatusCreated
+	case res.deleted:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+func (res escalationPolicyRes) Empty() bool {
+	return res.deleted
+}
+
+type escalationPoliciesPageRes struct {
+	alarms.EscalationPoliciesPage `json:",inline"`
+}
+
+func (res escalationPoliciesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res escalationPoliciesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res escalationPoliciesPageRes) Empty() bool {
+	return false
+}
+
+type suppressionWindowRes struct {
+	alarms.SuppressionWindow `json:",inline"`
+	created                  bool
+	deleted                  bool
+}
+
+func (res suppressionWindowRes) Headers() map[string]string {
+	switch {
+	case res.created:
+		return map[string]string{
+			"Location": fmt.Sprintf("/%s/alarms/suppression-windows/%s", res.DomainID, res.ID),
+		}
+	default:
+		return map[string]string{}
+	}
+}
+
+func (res suppressionWindowRes) Code() int {
+	switch {
+	case res.created:
+		return http.StatusCreated
+	case res.deleted:
+		return http.StatusNoContent
+	default:
+		return http.StatusOK
+	}
+}
+
+func (res suppressionWindowRes) Empty() bool {
+	return res.deleted
+}
+
+type suppressionWindowsPageRes struct {
+	alarms.SuppressionWindowsPage `json:",inline"`
+}
+
+func (res suppressionWindowsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res suppressionWindowsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res suppressionWindowsPageRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	api "github.com/absmach/magistrala/api/http"
+	apiutil "github.com/absmach/magistrala/api/http/util"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	heartbeatInterval      = 30 * time.Second
+)
+
+var errStreamingUnsupported = errors.New("streaming is not supported")
+
+// streamAlarmsHandler streams the alarm changes as server-sent events. Each event
+// is named by the change type and carries the alarm state after the change.
+func streamAlarmsHandler(svc alarms.Service, logger *slog.Logger) http.HandlerFunc {
+	encodeError := apiutil.LoggingErrorEncoder(logger, api.EncodeError)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		filter, err := decodeStreamAlarmsReq(r)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			encodeError(ctx, svcerr.ErrAuthorization, w)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			encodeError(ctx, errStreamingUnsupported, w)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		events, err := svc.StreamAlarms(ctx, session, filter)
+		if err != nil {
+			encodeError(ctx, err, w)
+			return
+		}
+
+		// The stream is long lived, so it must outlive the server write timeout.
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			logger.Warn(fmt.Sprintf("failed to clear alarms stream write deadline: %s", err))
+		}
+		w.Header().Set("Content-Type", eventStreamContentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// Disables the response buffering of the nginx proxy.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err := writeEvent(w, event); err != nil {
+					return
+				}
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event alarms.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+
+	return err
+}
+
+func decodeStreamAlarmsReq(r *http.Request) (alarms.StreamFilter, error) {
+	channelID, err := apiutil.ReadStringQuery(r, "channel_id", "")
+	if err != nil {
+		return alarms.StreamFilter{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	ruleID, err := apiutil.ReadStringQuery(r, "rule_id", "")
+	if err != nil {
+		return alarms.StreamFilter{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	severity, err := apiutil.ReadNumQuery(r, "severity", uint64(0))
+	if err != nil {
+		return alarms.StreamFilter{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	if severity > uint64(alarms.SeverityMax) {
+		return alarms.StreamFilter{}, errors.Wrap(apiutil.ErrValidation, alarms.ErrInvalidSeverity)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, alarms.All)
+	if err != nil {
+		return alarms.StreamFilter{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := alarms.ToStatus(s)
+	if err != nil {
+		return alarms.StreamFilter{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return alarms.StreamFilter{
+		ChannelID:   channelID,
+		RuleID:      ruleID,
+		MinSeverity: uint8(severity),
+		Status:      status,
+	}, nil
+}
@@ -24,6 +24,12 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+const (
+	defStatsRange    = 24 * time.Hour
+	defStatsInterval = time.Hour
+	defStatsTop      = 10
+)
+
 func MakeHandler(svc alarms.Service, logger *slog.Logger, idp magistrala.IDProvider, instanceID string, authn smqauthn.AuthNMiddleware) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
@@ -42,6 +48,107 @@ func MakeHandler(svc alarms.Service, logger *slog.Logger, idp magistrala.IDProvi
 				api.EncodeResponse,
 				opts...,
 			), "list_alarms").ServeHTTP)
+			r.Get("/stream", otelhttp.NewHandler(streamAlarmsHandler(svc, logger), "stream_alarms").ServeHTTP)
+			r.Get("/stats", otelhttp.NewHandler(kithttp.NewServer(
+				alarmStatsEndpoint(svc),
+				decodeAlarmStatsReq,
+				api.EncodeResponse,
+				opts...,
+			), "alarm_stats").ServeHTTP)
+			r.Route("/bulk", func(r chi.Router) {
+				r.Post("/acknowledge", otelhttp.NewHandler(kithttp.NewServer(
+					bulkUpdateAlarmsEndpoint(svc),
+					decodeBulkReq(alarms.AcknowledgeAction),
+					api.EncodeResponse,
+					opts...,
+				), "bulk_acknowledge_alarms").ServeHTTP)
+				r.Post("/resolve", otelhttp.NewHandler(kithttp.NewServer(
+					bulkUpdateAlarmsEndpoint(svc),
+					decodeBulkReq(alarms.ResolveAction),
+					api.EncodeResponse,
+					opts...,
+				), "bulk_resolve_alarms").ServeHTTP)
+				r.Post("/assign", otelhttp.NewHandler(kithttp.NewServer(
+					bulkUpdateAlarmsEndpoint(svc),
+					decodeBulkReq(alarms.AssignAction),
+					api.EncodeResponse,
+					opts...,
+				), "bulk_assign_alarms").ServeHTTP)
+				r.Post("/delete", otelhttp.NewHandler(kithttp.NewServer(
+					bulkUpdateAlarmsEndpoint(svc),
+					decodeBulkReq(alarms.DeleteAction),
+					api.EncodeResponse,
+					opts...,
+				), "bulk_delete_alarms").ServeHTTP)
+			})
+			r.Route("/escalation-policies", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createEscalationPolicyEndpoint(svc),
+					decodeEscalationPolicyReq,
+					api.EncodeResponse,
+					opts...,
+				), "create_escalation_policy").ServeHTTP)
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listEscalationPoliciesEndpoint(svc),
+					decodeListEscalationPoliciesReq,
+					api.EncodeResponse,
+					opts...,
+				), "list_escalation_policies").ServeHTTP)
+				r.Route("/{policyID}", func(r chi.Router) {
+					r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+						viewEscalationPolicyEndpoint(svc),
+						decodeEscalationPolicyIDReq,
+						api.EncodeResponse,
+						opts...,
+					), "view_escalation_policy").ServeHTTP)
+					r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+						updateEscalationPolicyEndpoint(svc),
+						decodeEscalationPolicyReq,
+						api.EncodeResponse,
+						opts...,
+					), "update_escalation_policy").ServeHTTP)
+					r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+						deleteEscalationPolicyEndpoint(svc),
+						decodeEscalationPolicyIDReq,
+						api.EncodeResponse,
+						opts...,
+					), "delete_escalation_policy").ServeHTTP)
+				})
+			})
+			r.Route("/suppression-windows", func(r chi.Router) {
+				r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+					createSuppressionWindowEndpoint(svc),
+					decodeSuppressionWindowReq,
+					api.EncodeResponse,
+					opts...,
+				), "create_suppression_window").ServeHTTP)
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listSuppressionWindowsEndpoint(svc),
+					decodeListSuppressionWindowsReq,
+					api.EncodeResponse,
+					opts...,
+				), "list_suppression_windows").ServeHTTP)
+				r.Route("/{windowID}", func(r chi.Router) {
+					r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+						viewSuppressionWindowEndpoint(svc),
+						decodeSuppressionWindowIDReq,
+						api.EncodeResponse,
+						opts...,
+					), "view_suppression_window").ServeHTTP)
+					r.Put("/", otelhttp.NewHandler(kithttp.NewServer(
+						updateSuppressionWindowEndpoint(svc),
+						decodeSuppressionWindowReq,
+						api.EncodeResponse,
+						opts...,
+					), "update_suppression_window").ServeHTTP)
+					r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+						deleteSuppressionWindowEndpoint(svc),
+						decodeSuppressionWindowIDReq,
+						api.EncodeResponse,
+						opts...,
+					), "delete_suppression_window").ServeHTTP)
+				})
+			})
 			r.Route("/{alarmID}", func(r chi.Router) {
 				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 					viewAlarmEndpoint(svc),
@@ -61,6 +168,36 @@ func MakeHandler(svc alarms.Service, logger *slog.Logger, idp magistrala.IDProvi
 					api.EncodeResponse,
 					opts...,
 				), "delete_alarm").ServeHTTP)
+				r.Post("/shelve", otelhttp.NewHandler(kithttp.NewServer(
+					shelveAlarmEndpoint(svc),
+					decodeShelveAlarmReq,
+					api.EncodeResponse,
+					opts...,
+				), "shelve_alarm").ServeHTTP)
+				r.Post("/unshelve", otelhttp.NewHandler(kithttp.NewServer(
+					unshelveAlarmEndpoint(svc),
+					decodeAlarmReq,
+					api.EncodeResponse,
+					opts...,
+				), "unshelve_alarm").ServeHTTP)
+				r.Post("/comments", otelhttp.NewHandler(kithttp.NewServer(
+					addCommentEndpoint(svc),
+					decodeAddCommentReq,
+					api.EncodeResponse,
+					opts...,
+				), "add_alarm_comment").ServeHTTP)
+				r.Get("/comments", otelhttp.NewHandler(kithttp.NewServer(
+					listCommentsEndpoint(svc),
+					decodeListActivitiesReq,
+					api.EncodeResponse,
+					opts...,
+				), "list_alarm_comments").ServeHTTP)
+				r.Get("/timeline", otelhttp.NewHandler(kithttp.NewServer(
+					alarmTimelineEndpoint(svc),
+					decodeListActivitiesReq,
+					api.EncodeResponse,
+					opts...,
+				), "view_alarm_timeline").ServeHTTP)
 			})
 		})
 	})
@@ -108,6 +245,16 @@ func decodeListAlarmsReq(_ context.Context, r *http.Request) (any, error) {
 	if err != nil {
 		return listAlarmsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
+	st, err := apiutil.ReadStringQuery(r, "state", "")
+	if err != nil {
+		return listAlarmsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	var state alarms.State
+	if st != "" {
+		if state, err = alarms.ToState(st); err != nil {
+			return listAlarmsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+	}
 	assigneeID, err := apiutil.ReadStringQuery(r, "assignee_id", "")
 	if err != nil {
 		return listAlarmsReq{}, errors.Wrap(apiutil.ErrValidation, err)
@@ -171,6 +318,7 @@ func decodeListAlarmsReq(_ context.Context, r *http.Request) (any, error) {
 			Subtopic:       subtopic,
 			RuleID:         ruleID,
 			Status:         status,
+			State:          state,
 			AssigneeID:     assigneeID,
 			ResolvedBy:     resolvedBy,
 			Severity:       uint8(serverity),
@@ -185,6 +333,66 @@ func decodeListAlarmsReq(_ context.Context, r *http.Request) (any, error) {
 	}, nil
 }
 
+func decodeAlarmStatsReq(_ context.Context, r *http.Request) (any, error) {
+	ruleID, err := apiutil.ReadStringQuery(r, "rule_id", "")
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	channelID, err := apiutil.ReadStringQuery(r, "channel_id", "")
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	clientID, err := apiutil.ReadStringQuery(r, "client_id", "")
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	f, err := apiutil.ReadStringQuery(r, "from", "")
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	t, err := apiutil.ReadStringQuery(r, "to", "")
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	i, err := apiutil.ReadStringQuery(r, "interval", defStatsInterval.String())
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	top, err := apiutil.ReadNumQuery[uint64](r, "top", defStatsTop)
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	to := time.Now().UTC()
+	if t != "" {
+		if to, err = time.Parse(time.RFC3339, t); err != nil {
+			return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+	}
+	from := to.Add(-defStatsRange)
+	if f != "" {
+		if from, err = time.Parse(time.RFC3339, f); err != nil {
+			return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
+	}
+	interval, err := time.ParseDuration(i)
+	if err != nil {
+		return alarmStatsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return alarmStatsReq{
+		StatsPageMeta: alarms.StatsPageMeta{
+			RuleID:    ruleID,
+			ChannelID: channelID,
+			ClientID:  clientID,
+			From:      from,
+			To:        to,
+			Interval:  interval,
+			Top:       top,
+		},
+	}, nil
+}
+
 func decodeAlarmReq(_ context.Context, r *http.Request) (any, error) {
 	return alarmReq{
 		Alarm: alarms.Alarm{
@@ -193,6 +401,67 @@ func decodeAlarmReq(_ context.Context, r *http.Request) (any, error) {
 	}, nil
 }
 
+func decodeShelveAlarmReq(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return shelveAlarmReq{}, apiutil.ErrUnsupportedContentType
+	}
+
+	req := shelveAlarmReq{alarmID: chi.URLParam(r, "alarmID")}
+	if err := json.NewDecoder(r.Body).Decode(&req.Shelve); err != nil {
+		return shelveAlarmReq{}, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeAddCommentReq(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return addCommentReq{}, apiutil.ErrUnsupportedContentType
+	}
+
+	req := addCommentReq{alarmID: chi.URLParam(r, "alarmID")}
+	if err := json.NewDecoder(r.Body).Decode(&req.Comment); err != nil {
+		return addCommentReq{}, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
+func decodeBulkReq(action alarms.BulkAction) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (any, error) {
+		if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+			return bulkReq{}, apiutil.ErrUnsupportedContentType
+		}
+
+		req := bulkReq{BulkRequest: alarms.NewBulkRequest(action)}
+		if err := json.NewDecoder(r.Body).Decode(&req.BulkRequest); err != nil {
+			return bulkReq{}, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+		}
+		req.Action = action
+
+		return req, nil
+	}
+}
+
+func decodeListActivitiesReq(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return listActivitiesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return listActivitiesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listActivitiesReq{
+		alarmID: chi.URLParam(r, "alarmID"),
+		ActivityPageMeta: alarms.ActivityPageMeta{
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
 func decodeUpdateAlarmReq(_ context.Context, r *http.Request) (any, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return updateAlarmReq{}, apiutil.ErrUnsupportedContentType
@@ -207,3 +476,94 @@ func decodeUpdateAlarmReq(_ context.Context, r *http.Request) (any, error) {
 
 	return req, nil
 }
+
+func decodeEscalationPolicyReq(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return escalationPolicyReq{}, apiutil.ErrUnsupportedContentType
+	}
+
+	// Policies match alarms of any severity unless the range is narrowed.
+	req := escalationPolicyReq{
+		EscalationPolicy: alarms.EscalationPolicy{
+			Match: alarms.EscalationMatch{MaxSeverity: alarms.SeverityMax},
+		},
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.EscalationPolicy); err != nil {
+		return escalationPolicyReq{}, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+	req.ID = chi.URLParam(r, "policyID")
+
+	return req, nil
+}
+
+func decodeEscalationPolicyIDReq(_ context.Context, r *http.Request) (any, error) {
+	return escalationPolicyIDReq{
+		id: chi.URLParam(r, "policyID"),
+	}, nil
+}
+
+func decodeListEscalationPoliciesReq(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return listEscalationPoliciesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return listEscalationPoliciesReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listEscalationPoliciesReq{
+		EscalationPolicyPageMeta: alarms.EscalationPolicyPageMeta{
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func decodeSuppressionWindowReq(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return suppressionWindowReq{}, apiutil.ErrUnsupportedContentType
+	}
+
+	var req suppressionWindowReq
+	if err := json.NewDecoder(r.Body).Decode(&req.SuppressionWindow); err != nil {
+		return suppressionWindowReq{}, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+	req.ID = chi.URLParam(r, "windowID")
+
+	return req, nil
+}
+
+func decodeSuppressionWindowIDReq(_ context.Context, r *http.Request) (any, error) {
+	return suppressionWindowIDReq{
+		id: chi.URLParam(r, "windowID"),
+	}, nil
+}
+
+func decodeListSuppressionWindowsReq(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return listSuppressionWindowsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return listSuppressionWindowsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	channelID, err := apiutil.ReadStringQuery(r, "channel_id", "")
+	if err != nil {
+		return listSuppressionWindowsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	clientID, err := apiutil.ReadStringQuery(r, "client_id", "")
+	if err != nil {
+		return listSuppressionWindowsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listSuppressionWindowsReq{
+		SuppressionWindowPageMeta: alarms.SuppressionWindowPageMeta{
+			Offset:    offset,
+			Limit:     limit,
+			ChannelID: channelID,
+			ClientID:  clientID,
+		},
+	}, nil
+}
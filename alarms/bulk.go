@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/authn"
+)
+
+// MaxBulkAlarms is the maximum number of alarms changed by a bulk operation.
+// The alarms selected by a filter are changed in batches of MaxBulkAlarms,
+// oldest first.
+const MaxBulkAlarms = 1000
+
+var (
+	errBulkAction   = errors.New("invalid bulk action")
+	errBulkAssignee = errors.New("assignee_id is required to assign alarms")
+	errBulkTarget   = errors.New("bulk operation requires alarm_ids or a filter")
+	errBulkIDs      = errors.New("too many alarm_ids")
+
+	// ErrBulkNotApplied indicates that the alarm was not changed, because it
+	// does not exist or its state does not allow the change.
+	ErrBulkNotApplied = errors.New("alarm not found or its state does not allow the change")
+)
+
+// BulkAction is the change made by a bulk operation.
+type BulkAction string
+
+const (
+	AcknowledgeAction BulkAction = "acknowledge"
+	ResolveAction     BulkAction = "resolve"
+	AssignAction      BulkAction = "assign"
+	DeleteAction      BulkAction = "delete"
+)
+
+// EventType returns the type of the alarm change events of the action.
+func (a BulkAction) EventType() EventType {
+	switch a {
+	case AcknowledgeAction:
+		return AcknowledgeEvent
+	case ResolveAction:
+		return ResolveEvent
+	case AssignAction:
+		return AssignEvent
+	case DeleteAction:
+		return DeleteEvent
+	default:
+		return UpdateEvent
+	}
+}
+
+// BulkRequest is a bulk operation over the domain alarms. It selects the
+// alarms by their IDs, by a filter, or by the IDs matching the filter.
+type BulkRequest struct {
+	Action     BulkAction   `json:"-"`
+	AlarmIDs   []string     `json:"alarm_ids,omitempty"`
+	Filter     PageMetadata `json:"filter"`
+	AssigneeID string       `json:"assignee_id,omitempty"`
+}
+
+// NewBulkRequest returns a bulk request of the action with a filter which
+// matches all the alarms.
+func NewBulkRequest(action BulkAction) BulkRequest {
+	return BulkRequest{
+		Action: action,
+		Filter: PageMetadata{Status: AllStatus, Severity: math.MaxUint8},
+	}
+}
+
+func (r BulkRequest) Validate() error {
+	switch r.Action {
+	case AcknowledgeAction, ResolveAction, DeleteAction:
+	case AssignAction:
+		if r.AssigneeID == "" {
+			return errBulkAssignee
+		}
+	default:
+		return errBulkAction
+	}
+	if len(r.AlarmIDs) > MaxBulkAlarms {
+		return errBulkIDs
+	}
+	if r.Filter.State != "" {
+		if _, err := ToState(string(r.Filter.State)); err != nil {
+			return err
+		}
+	}
+	if len(r.AlarmIDs) == 0 && !r.filtered() {
+		return errBulkTarget
+	}
+
+	return nil
+}
+
+// filtered reports whether the filter narrows the domain alarms, so a bulk
+// operation never changes all of them by accident.
+func (r BulkRequest) filtered() bool {
+	f := r.Filter
+	return f.RuleID != "" || len(f.RuleIDs) > 0 || f.ChannelID != "" || f.ClientID != "" ||
+		f.Subtopic != "" || f.Measurement != "" || f.Status != AllStatus || f.State != "" || f.Severity != math.MaxUint8 ||
+		f.AssigneeID != "" || f.UpdatedBy != "" || f.AssignedBy != "" || f.AcknowledgedBy != "" ||
+		f.ResolvedBy != "" || !f.CreatedFrom.IsZero() || !f.CreatedTo.IsZero()
+}
+
+// update returns the alarm update applied by the request.
+func (r BulkRequest) update(session authn.Session, at time.Time) Alarm {
+	update := Alarm{UpdatedAt: at, UpdatedBy: session.UserID}
+	switch r.Action {
+	case AcknowledgeAction:
+		update.AcknowledgedAt = at
+		update.AcknowledgedBy = session.UserID
+	case ResolveAction:
+		update.ResolvedAt = at
+		update.ResolvedBy = session.UserID
+	case AssignAction:
+		update.AssigneeID = r.AssigneeID
+		update.AssignedAt = at
+		update.AssignedBy = session.UserID
+	}
+
+	return update
+}
+
+// BulkFailure is an alarm which a bulk operation failed to change.
+type BulkFailure struct {
+	AlarmID string `json:"alarm_id"`
+	Error   string `json:"error"`
+}
+
+// BulkResult summarizes a bulk operation.
+type BulkResult struct {
+	Action    BulkAction    `json:"action"`
+	Total     uint64        `json:"total"`
+	Succeeded []string      `json:"succeeded"`
+	Failed    []BulkFailure `json:"failed"`
+	// Alarms are the changed alarms, as they are after an update or as they
+	// were before a delete.
+	Alarms []Alarm `json:"-"`
+}
+
+func newBulkResult(req BulkRequest, changed []Alarm) BulkResult {
+	res := BulkResult{
+		Action:    req.Action,
+		Succeeded: []string{},
+		Failed:    []BulkFailure{},
+		Alarms:    changed,
+	}
+	done := make(map[string]bool, len(changed))
+	for _, a := range changed {
+		done[a.ID] = true
+		res.Succeeded = append(res.Succeeded, a.ID)
+	}
+	for _, id := range req.AlarmIDs {
+		if !done[id] {
+			done[id] = true
+			res.Failed = append(res.Failed, BulkFailure{AlarmID: id, Error: ErrBulkNotApplied.Error()})
+		}
+	}
+	res.Total = uint64(len(res.Succeeded) + len(res.Failed))
+
+	return res
+}
@@ -0,0 +1,191 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/mocks"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulkRequestValidate(t *testing.T) {
+	withIDs := func(action alarms.BulkAction) alarms.BulkRequest {
+		req := alarms.NewBulkRequest(action)
+		req.AlarmIDs = []string{"alarm-id"}
+		return req
+	}
+
+	cases := []struct {
+		desc string
+		req  alarms.BulkRequest
+		err  bool
+	}{
+		{
+			desc: "acknowledge alarms by ids",
+			req:  withIDs(alarms.AcknowledgeAction),
+		},
+		{
+			desc: "resolve alarms by filter",
+			req: func() alarms.BulkRequest {
+				req := alarms.NewBulkRequest(alarms.ResolveAction)
+				req.Filter.ChannelID = "channel-id"
+				return req
+			}(),
+		},
+		{
+			desc: "assign alarms",
+			req: func() alarms.BulkRequest {
+				req := withIDs(alarms.AssignAction)
+				req.AssigneeID = "assignee-id"
+				return req
+			}(),
+		},
+		{
+			desc: "assign alarms without assignee",
+			req:  withIDs(alarms.AssignAction),
+			err:  true,
+		},
+		{
+			desc: "invalid action",
+			req:  withIDs(alarms.BulkAction("clear")),
+			err:  true,
+		},
+		{
+			desc: "delete alarms without ids and filter",
+			req:  alarms.NewBulkRequest(alarms.DeleteAction),
+			err:  true,
+		},
+		{
+			desc: "acknowledge too many alarms",
+			req: func() alarms.BulkRequest {
+				req := alarms.NewBulkRequest(alarms.AcknowledgeAction)
+				req.AlarmIDs = make([]string, alarms.MaxBulkAlarms+1)
+				return req
+			}(),
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.req.Validate()
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		})
+	}
+}
+
+func TestBulkUpdateAlarms(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+
+	acknowledge := alarms.NewBulkRequest(alarms.AcknowledgeAction)
+	acknowledge.AlarmIDs = []string{"alarm-1", "alarm-2"}
+	assign := alarms.NewBulkRequest(alarms.AssignAction)
+	assign.Filter.ChannelID = "channel-id"
+	assign.AssigneeID = "assignee-id"
+	del := alarms.NewBulkRequest(alarms.DeleteAction)
+	del.AlarmIDs = []string{"alarm-1"}
+
+	cases := []struct {
+		desc       string
+		req        alarms.BulkRequest
+		changed    []alarms.Alarm
+		repoErr    error
+		activities int
+		succeeded  []string
+		failed     []string
+		err        error
+	}{
+		{
+			desc:       "acknowledge alarms with a missing alarm",
+			req:        acknowledge,
+			changed:    []alarms.Alarm{{ID: "alarm-1"}},
+			activities: 1,
+			succeeded:  []string{"alarm-1"},
+			failed:     []string{"alarm-2"},
+		},
+		{
+			desc:       "assign alarms by filter",
+			req:        assign,
+			changed:    []alarms.Alarm{{ID: "alarm-1"}, {ID: "alarm-2"}},
+			activities: 2,
+			succeeded:  []string{"alarm-1", "alarm-2"},
+			failed:     []string{},
+		},
+		{
+			desc:      "delete alarms",
+			req:       del,
+			changed:   []alarms.Alarm{{ID: "alarm-1"}},
+			succeeded: []string{"alarm-1"},
+			failed:    []string{},
+		},
+		{
+			desc:    "acknowledge alarms with failed repo",
+			req:     acknowledge,
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+		{
+			desc:    "delete alarms with failed repo",
+			req:     del,
+			repoErr: repoerr.ErrRemoveEntity,
+			err:     repoerr.ErrRemoveEntity,
+		},
+		{
+			desc:       "acknowledge alarms with failed activity record",
+			req:        acknowledge,
+			changed:    []alarms.Alarm{{ID: "alarm-1"}},
+			repoErr:    repoerr.ErrCreateEntity,
+			activities: 1,
+			err:        repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var pm alarms.PageMetadata
+			capture := func(args mock.Arguments) {
+				pm = args.Get(1).(alarms.PageMetadata)
+			}
+			var recorded []alarms.Activity
+			repoCall := repo.On("UpdateAlarms", context.Background(), mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				capture(args)
+				acts, err := args.Get(3).(alarms.ActivitiesFunc)(tc.changed)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				recorded = acts
+			}).Return(tc.changed, tc.repoErr)
+			repoCall1 := repo.On("DeleteAlarms", context.Background(), mock.Anything).Run(capture).Return(tc.changed, tc.repoErr)
+			res, err := svc.BulkUpdateAlarms(context.Background(), session, tc.req)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, session.DomainID, pm.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, session.DomainID, pm.DomainID))
+			assert.Equal(t, uint64(alarms.MaxBulkAlarms), pm.Limit, fmt.Sprintf("%s: expected limit %d got %d\n", tc.desc, alarms.MaxBulkAlarms, pm.Limit))
+			assert.Equal(t, tc.req.AlarmIDs, pm.AlarmIDs, fmt.Sprintf("%s: expected alarm ids %v got %v\n", tc.desc, tc.req.AlarmIDs, pm.AlarmIDs))
+			assert.Len(t, recorded, tc.activities, fmt.Sprintf("%s: expected %d activities got %d\n", tc.desc, tc.activities, len(recorded)))
+			if err == nil {
+				var failed []string
+				for _, f := range res.Failed {
+					failed = append(failed, f.AlarmID)
+				}
+				if failed == nil {
+					failed = []string{}
+				}
+				assert.Equal(t, tc.req.Action, res.Action, fmt.Sprintf("%s: expected action %s got %s\n", tc.desc, tc.req.Action, res.Action))
+				assert.Equal(t, tc.succeeded, res.Succeeded, fmt.Sprintf("%s: expected succeeded %v got %v\n", tc.desc, tc.succeeded, res.Succeeded))
+				assert.Equal(t, tc.failed, failed, fmt.Sprintf("%s: expected failed %v got %v\n", tc.desc, tc.failed, failed))
+				assert.Equal(t, uint64(len(tc.succeeded)+len(tc.failed)), res.Total, fmt.Sprintf("%s: unexpected total %d\n", tc.desc, res.Total))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
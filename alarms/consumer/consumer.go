@@ -44,6 +44,13 @@ func (h handler) Handle(msg *messaging.Message) (err error) {
 	alarm.Subtopic = msg.GetSubtopic()
 	alarm.CreatedAt = time.Unix(0, int64(msg.GetCreated()))
 
+	// A cleared alarm reports that the condition of its source returned to
+	// normal, so it clears the alarms of the source instead of raising one.
+	if alarm.Status == alarms.ClearedStatus {
+		_, err = h.svc.ClearAlarms(context.Background(), alarm)
+		return err
+	}
+
 	if err := alarm.Validate(); err != nil {
 		return err
 	}
@@ -0,0 +1,387 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"time"
+
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/ticker"
+)
+
+const (
+	// escalationBatch is the maximum number of escalations run on a single tick.
+	escalationBatch = 100
+	// escalationLease is how long claimed escalations are hidden from other
+	// runs, so the same step is not run concurrently.
+	escalationLease = 5 * time.Minute
+)
+
+var (
+	errEscalationName  = errors.New("escalation policy name is required")
+	errEscalationSteps = errors.New("escalation policy must have at least one step")
+	errSeverityRange   = errors.New("min_severity must not be greater than max_severity")
+	errStepAfter       = errors.New("step after must not be negative")
+	errStepOrder       = errors.New("escalation steps must be ordered by after")
+	errStepAction      = errors.New("invalid escalation step action")
+	errStepContacts    = errors.New("notify step must have at least one contact")
+	errStepAssignee    = errors.New("reassign step must have assignee_id")
+	errStepSeverity    = errors.New("bump_severity step must have severity between 1 and 100")
+	errContactType     = errors.New("invalid contact type")
+	errContactAddress  = errors.New("invalid contact address")
+)
+
+// ContactType is the channel used to notify an escalation contact.
+type ContactType string
+
+const (
+	EmailContact   ContactType = "email"
+	SMSContact     ContactType = "sms"
+	WebhookContact ContactType = "webhook"
+)
+
+// Contact is the recipient of an escalation notification.
+type Contact struct {
+	Type    ContactType `json:"type"`
+	Address string      `json:"address"`
+}
+
+func (c Contact) Validate() error {
+	switch c.Type {
+	case EmailContact:
+		if _, err := mail.ParseAddress(c.Address); err != nil {
+			return fmt.Errorf("%w: %s", errContactAddress, c.Address)
+		}
+	case SMSContact:
+		if c.Address == "" {
+			return errContactAddress
+		}
+	case WebhookContact:
+		u, err := url.Parse(c.Address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %s", errContactAddress, c.Address)
+		}
+	default:
+		return fmt.Errorf("%w: %s", errContactType, c.Type)
+	}
+
+	return nil
+}
+
+// EscalationAction is the action taken by an escalation step.
+type EscalationAction string
+
+const (
+	// NotifyAction notifies the step contacts.
+	NotifyAction EscalationAction = "notify"
+	// ReassignAction assigns the alarm to the step assignee.
+	ReassignAction EscalationAction = "reassign"
+	// SeverityAction raises the alarm severity to the step severity.
+	SeverityAction EscalationAction = "bump_severity"
+)
+
+// EscalationStep is a timed step of an escalation policy.
+type EscalationStep struct {
+	// After is the time since the alarm creation when the step runs.
+	After      time.Duration    `json:"after"`
+	Action     EscalationAction `json:"action"`
+	Contacts   []Contact        `json:"contacts,omitempty"`
+	AssigneeID string           `json:"assignee_id,omitempty"`
+	Severity   uint8            `json:"severity,omitempty"`
+}
+
+func (s EscalationStep) MarshalJSON() ([]byte, error) {
+	type step EscalationStep
+	return json.Marshal(struct {
+		step
+		After string `json:"after"`
+	}{
+		step:  step(s),
+		After: s.After.String(),
+	})
+}
+
+func (s *EscalationStep) UnmarshalJSON(data []byte) error {
+	type step EscalationStep
+	var raw struct {
+		step
+		After string `json:"after"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	after, err := time.ParseDuration(raw.After)
+	if err != nil {
+		return fmt.Errorf("invalid step after: %w", err)
+	}
+	*s = EscalationStep(raw.step)
+	s.After = after
+
+	return nil
+}
+
+func (s EscalationStep) Validate() error {
+	if s.After < 0 {
+		return errStepAfter
+	}
+	switch s.Action {
+	case NotifyAction:
+		if len(s.Contacts) == 0 {
+			return errStepContacts
+		}
+		for _, c := range s.Contacts {
+			if err := c.Validate(); err != nil {
+				return err
+			}
+		}
+	case ReassignAction:
+		if s.AssigneeID == "" {
+			return errStepAssignee
+		}
+	case SeverityAction:
+		if s.Severity == 0 || s.Severity > SeverityMax {
+			return errStepSeverity
+		}
+	default:
+		return fmt.Errorf("%w: %s", errStepAction, s.Action)
+	}
+
+	return nil
+}
+
+// EscalationMatch selects the alarms an escalation policy applies to.
+// Empty fields match any value.
+type EscalationMatch struct {
+	RuleID      string `json:"rule_id,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	Measurement string `json:"measurement,omitempty"`
+	MinSeverity uint8  `json:"min_severity"`
+	MaxSeverity uint8  `json:"max_severity"`
+}
+
+// Escalable reports whether the alarm is still waiting for a response,
+// so its escalation goes on.
+func (a Alarm) Escalable() bool {
+	return a.Status == ActiveStatus &&
+		a.AcknowledgedBy == "" && a.AcknowledgedAt.IsZero() &&
+		a.ResolvedBy == "" && a.ResolvedAt.IsZero()
+}
+
+// EscalationPolicy escalates the matched alarms which are neither
+// acknowledged nor resolved in time.
+type EscalationPolicy struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	DomainID  string           `json:"domain_id"`
+	Match     EscalationMatch  `json:"match"`
+	Steps     []EscalationStep `json:"steps"`
+	CreatedAt time.Time        `json:"created_at"`
+	CreatedBy string           `json:"created_by"`
+	UpdatedAt time.Time        `json:"updated_at,omitempty"`
+	UpdatedBy string           `json:"updated_by,omitempty"`
+}
+
+func (p EscalationPolicy) Validate() error {
+	if p.Name == "" {
+		return errEscalationName
+	}
+	if p.Match.MaxSeverity > SeverityMax {
+		return ErrInvalidSeverity
+	}
+	if p.Match.MinSeverity > p.Match.MaxSeverity {
+		return errSeverityRange
+	}
+	if len(p.Steps) == 0 {
+		return errEscalationSteps
+	}
+	for i, s := range p.Steps {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		if i > 0 && s.After < p.Steps[i-1].After {
+			return errStepOrder
+		}
+	}
+
+	return nil
+}
+
+type EscalationPoliciesPage struct {
+	Offset   uint64             `json:"offset"`
+	Limit    uint64             `json:"limit"`
+	Total    uint64             `json:"total"`
+	Policies []EscalationPolicy `json:"escalation_policies"`
+}
+
+type EscalationPolicyPageMeta struct {
+	Offset   uint64 `json:"offset"    db:"offset"`
+	Limit    uint64 `json:"limit"     db:"limit"`
+	DomainID string `json:"domain_id" db:"domain_id"`
+}
+
+// Escalation tracks the progress of an escalation policy for an alarm.
+type Escalation struct {
+	AlarmID  string
+	PolicyID string
+	DomainID string
+	// Step is the index of the next step to run.
+	Step   uint
+	NextAt time.Time
+}
+
+// Notifier notifies escalation contacts about alarms.
+type Notifier interface {
+	// Notify sends the alarm notification to the contact.
+	Notify(ctx context.Context, contact Contact, alarm Alarm) error
+}
+
+// escalations returns the escalations of the alarm by the matching policies.
+func (s *service) escalations(ctx context.Context, alarm Alarm) ([]Escalation, error) {
+	policies, err := s.repo.MatchEscalationPolicies(ctx, alarm)
+	if err != nil {
+		return nil, err
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	escs := make([]Escalation, len(policies))
+	for i, p := range policies {
+		escs[i] = Escalation{
+			AlarmID:  alarm.ID,
+			PolicyID: p.ID,
+			DomainID: alarm.DomainID,
+			NextAt:   alarm.CreatedAt.Add(p.Steps[0].After),
+		}
+	}
+
+	return escs, nil
+}
+
+// StartEscalations runs the due escalation steps on every tick until the context is done.
+func StartEscalations(ctx context.Context, repo Repository, notifier Notifier, tck ticker.Ticker, logger *slog.Logger) error {
+	defer tck.Stop()
+	e := escalator{repo: repo, notifier: notifier, logger: logger}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tck.Tick():
+			e.escalate(ctx)
+		}
+	}
+}
+
+type escalator struct {
+	repo     Repository
+	notifier Notifier
+	logger   *slog.Logger
+}
+
+// escalate runs the due escalation steps. Escalations are claimed in
+// batches, so the ones left over run on the next tick.
+func (e escalator) escalate(ctx context.Context) {
+	now := time.Now().UTC()
+	escs, err := e.repo.ClaimEscalations(ctx, now, now.Add(escalationLease), escalationBatch)
+	if err != nil {
+		e.logger.Error(fmt.Sprintf("failed to claim alarm escalations: %s", err))
+		return
+	}
+	for _, esc := range escs {
+		if err := e.runStep(ctx, esc); err != nil {
+			e.logger.Error(fmt.Sprintf("failed to escalate alarm: %s", err),
+				slog.String("alarm_id", esc.AlarmID),
+				slog.String("policy_id", esc.PolicyID),
+				slog.Uint64("step", uint64(esc.Step)),
+			)
+		}
+	}
+}
+
+// runStep runs the next step of the escalation and schedules the one after it.
+// The escalation ends once the alarm is acknowledged, resolved or cleared,
+// or the policy has no steps left, and it is held back while the alarm is
+// shelved.
+func (e escalator) runStep(ctx context.Context, esc Escalation) error {
+	alarm, err := e.repo.ViewAlarm(ctx, esc.AlarmID, esc.DomainID)
+	if err != nil {
+		return e.stop(ctx, esc, err)
+	}
+	if !alarm.Escalable() {
+		return e.repo.RemoveEscalation(ctx, esc.AlarmID, esc.PolicyID)
+	}
+	// Shelved alarms resume their escalation once the shelving expires.
+	if alarm.Shelved(time.Now()) {
+		esc.NextAt = alarm.ShelvedUntil
+		return e.repo.UpdateEscalation(ctx, esc)
+	}
+	p, err := e.repo.ViewEscalationPolicy(ctx, esc.PolicyID, esc.DomainID)
+	if err != nil {
+		return e.stop(ctx, esc, err)
+	}
+	if esc.Step >= uint(len(p.Steps)) {
+		return e.repo.RemoveEscalation(ctx, esc.AlarmID, esc.PolicyID)
+	}
+
+	// A failed step is reported, but does not hold back the later steps.
+	stepErr := e.apply(ctx, alarm, p.Steps[esc.Step])
+
+	esc.Step++
+	if esc.Step >= uint(len(p.Steps)) {
+		err = e.repo.RemoveEscalation(ctx, esc.AlarmID, esc.PolicyID)
+	} else {
+		esc.NextAt = alarm.CreatedAt.Add(p.Steps[esc.Step].After)
+		err = e.repo.UpdateEscalation(ctx, esc)
+	}
+	if stepErr != nil {
+		return stepErr
+	}
+
+	return err
+}
+
+// stop removes the escalation of the missing alarm or policy.
+func (e escalator) stop(ctx context.Context, esc Escalation, err error) error {
+	if errors.Is(err, repoerr.ErrNotFound) {
+		return e.repo.RemoveEscalation(ctx, esc.AlarmID, esc.PolicyID)
+	}
+
+	return err
+}
+
+func (e escalator) apply(ctx context.Context, alarm Alarm, step EscalationStep) error {
+	switch step.Action {
+	case NotifyAction:
+		var errs []error
+		for _, c := range step.Contacts {
+			if err := e.notifier.Notify(ctx, c, alarm); err != nil {
+				errs = append(errs, fmt.Errorf("failed to notify %s contact %s: %w", c.Type, c.Address, err))
+			}
+		}
+		return errors.Join(errs...)
+	case ReassignAction:
+		now := time.Now().UTC()
+		_, err := e.repo.UpdateAlarm(ctx, Alarm{
+			ID:         alarm.ID,
+			AssigneeID: step.AssigneeID,
+			AssignedAt: now,
+			UpdatedAt:  now,
+		}, nil)
+		return err
+	case SeverityAction:
+		if alarm.Severity >= step.Severity {
+			return nil
+		}
+		_, err := e.repo.UpdateAlarmSeverity(ctx, alarm.ID, step.Severity)
+		return err
+	default:
+		return fmt.Errorf("%w: %s", errStepAction, step.Action)
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	tmocks "github.com/absmach/magistrala/pkg/ticker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	emailContact   = alarms.Contact{Type: alarms.EmailContact, Address: "oncall@example.com"}
+	webhookContact = alarms.Contact{Type: alarms.WebhookContact, Address: "https://example.com/hooks/alarms"}
+	errNotify      = errors.New("failed to notify")
+)
+
+func TestEscalationPolicyValidate(t *testing.T) {
+	notify := alarms.EscalationStep{Action: alarms.NotifyAction, Contacts: []alarms.Contact{emailContact}}
+
+	cases := []struct {
+		desc   string
+		policy alarms.EscalationPolicy
+		err    bool
+	}{
+		{
+			desc: "valid policy",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MinSeverity: 50, MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{
+					notify,
+					{After: 10 * time.Minute, Action: alarms.ReassignAction, AssigneeID: "assignee-id"},
+					{After: 30 * time.Minute, Action: alarms.SeverityAction, Severity: 100},
+				},
+			},
+		},
+		{
+			desc:   "policy without name",
+			policy: alarms.EscalationPolicy{Match: alarms.EscalationMatch{MaxSeverity: 100}, Steps: []alarms.EscalationStep{notify}},
+			err:    true,
+		},
+		{
+			desc:   "policy with max severity out of range",
+			policy: alarms.EscalationPolicy{Name: "policy", Match: alarms.EscalationMatch{MaxSeverity: 101}, Steps: []alarms.EscalationStep{notify}},
+			err:    true,
+		},
+		{
+			desc:   "policy with min severity greater than max severity",
+			policy: alarms.EscalationPolicy{Name: "policy", Match: alarms.EscalationMatch{MinSeverity: 60, MaxSeverity: 50}, Steps: []alarms.EscalationStep{notify}},
+			err:    true,
+		},
+		{
+			desc:   "policy without steps",
+			policy: alarms.EscalationPolicy{Name: "policy", Match: alarms.EscalationMatch{MaxSeverity: 100}},
+			err:    true,
+		},
+		{
+			desc: "policy with unordered steps",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{
+					{After: time.Hour, Action: alarms.ReassignAction, AssigneeID: "assignee-id"},
+					notify,
+				},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with negative step after",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{After: -time.Minute, Action: alarms.NotifyAction, Contacts: notify.Contacts}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with invalid step action",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: "escalate"}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with notify step without contacts",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: alarms.NotifyAction}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with invalid email contact",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: alarms.NotifyAction, Contacts: []alarms.Contact{{Type: alarms.EmailContact, Address: "oncall"}}}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with invalid webhook contact",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: alarms.NotifyAction, Contacts: []alarms.Contact{{Type: alarms.WebhookContact, Address: "ftp://example.com"}}}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with invalid contact type",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: alarms.NotifyAction, Contacts: []alarms.Contact{{Type: "pager", Address: "1234"}}}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with reassign step without assignee",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: alarms.ReassignAction}},
+			},
+			err: true,
+		},
+		{
+			desc: "policy with severity step out of range",
+			policy: alarms.EscalationPolicy{
+				Name:  "policy",
+				Match: alarms.EscalationMatch{MaxSeverity: 100},
+				Steps: []alarms.EscalationStep{{Action: alarms.SeverityAction, Severity: 101}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.policy.Validate()
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestEscalationStepJSON(t *testing.T) {
+	cases := []struct {
+		desc string
+		data string
+		step alarms.EscalationStep
+		err  bool
+	}{
+		{
+			desc: "step with duration",
+			data: `{"after":"15m0s","action":"notify","contacts":[{"type":"email","address":"oncall@example.com"}]}`,
+			step: alarms.EscalationStep{After: 15 * time.Minute, Action: alarms.NotifyAction, Contacts: []alarms.Contact{emailContact}},
+		},
+		{
+			desc: "step with severity",
+			data: `{"after":"1h0m0s","action":"bump_severity","severity":90}`,
+			step: alarms.EscalationStep{After: time.Hour, Action: alarms.SeverityAction, Severity: 90},
+		},
+		{
+			desc: "step with invalid duration",
+			data: `{"after":"soon","action":"notify"}`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var step alarms.EscalationStep
+			err := json.Unmarshal([]byte(tc.data), &step)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+			if tc.err {
+				return
+			}
+			assert.Equal(t, tc.step, step, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.step, step))
+			data, err := json.Marshal(step)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.JSONEq(t, tc.data, string(data), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.data, data))
+		})
+	}
+}
+
+func TestStartEscalations(t *testing.T) {
+	created := time.Now().UTC().Add(-time.Hour)
+	alarm := alarms.Alarm{
+		ID:        "alarm-id",
+		RuleID:    "rule-id",
+		DomainID:  "domain-id",
+		Severity:  50,
+		CreatedAt: created,
+	}
+	acknowledged := alarm
+	acknowledged.AcknowledgedBy = "user-id"
+	acknowledged.AcknowledgedAt = time.Now()
+	shelved := alarm
+	shelved.ShelvedUntil = time.Now().Add(time.Hour).Round(0)
+	policy := alarms.EscalationPolicy{
+		ID:       "policy-id",
+		DomainID: alarm.DomainID,
+		Steps: []alarms.EscalationStep{
+			{Action: alarms.NotifyAction, Contacts: []alarms.Contact{emailContact, webhookContact}},
+			{After: 10 * time.Minute, Action: alarms.SeverityAction, Severity: 90},
+			{After: 30 * time.Minute, Action: alarms.ReassignAction, AssigneeID: "assignee-id"},
+		},
+	}
+	esc := alarms.Escalation{AlarmID: alarm.ID, PolicyID: policy.ID, DomainID: alarm.DomainID}
+	nextStep := func(step uint) alarms.Escalation {
+		e := esc
+		e.Step = step
+		e.NextAt = created.Add(policy.Steps[step].After)
+		return e
+	}
+
+	cases := []struct {
+		desc      string
+		esc       alarms.Escalation
+		claimErr  error
+		alarm     alarms.Alarm
+		viewErr   error
+		notifyErr error
+		update    *alarms.Escalation
+		remove    bool
+		severity  uint8
+		assignee  string
+	}{
+		{
+			desc:   "notify contacts",
+			esc:    esc,
+			alarm:  alarm,
+			update: func() *alarms.Escalation { e := nextStep(1); return &e }(),
+		},
+		{
+			desc:      "notify contacts with failed notification",
+			esc:       esc,
+			alarm:     alarm,
+			notifyErr: errNotify,
+			update:    func() *alarms.Escalation { e := nextStep(1); return &e }(),
+		},
+		{
+			desc:     "bump alarm severity",
+			esc:      nextStep(1),
+			alarm:    alarm,
+			severity: 90,
+			update:   func() *alarms.Escalation { e := nextStep(2); return &e }(),
+		},
+		{
+			desc:     "reassign alarm on last step",
+			esc:      nextStep(2),
+			alarm:    alarm,
+			assignee: "assignee-id",
+			remove:   true,
+		},
+		{
+			desc:   "stop escalation of acknowledged alarm",
+			esc:    esc,
+			alarm:  acknowledged,
+			remove: true,
+		},
+		{
+			desc:   "hold escalation of shelved alarm",
+			esc:    esc,
+			alarm:  shelved,
+			update: &alarms.Escalation{AlarmID: esc.AlarmID, PolicyID: esc.PolicyID, DomainID: esc.DomainID, NextAt: shelved.ShelvedUntil},
+		},
+		{
+			desc:    "stop escalation of deleted alarm",
+			esc:     esc,
+			viewErr: repoerr.ErrNotFound,
+			remove:  true,
+		},
+		{
+			desc:     "failed to claim escalations",
+			claimErr: repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			notifier := new(mocks.Notifier)
+			tck := new(tmocks.Ticker)
+
+			var claimed []alarms.Escalation
+			if tc.claimErr == nil {
+				claimed = []alarms.Escalation{tc.esc}
+				repo.On("ViewAlarm", mock.Anything, tc.esc.AlarmID, tc.esc.DomainID).Return(tc.alarm, tc.viewErr)
+				if tc.viewErr == nil && tc.alarm.Escalable() && !tc.alarm.Shelved(time.Now()) {
+					repo.On("ViewEscalationPolicy", mock.Anything, tc.esc.PolicyID, tc.esc.DomainID).Return(policy, nil)
+				}
+			}
+			var due, until time.Time
+			repo.On("ClaimEscalations", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				due = args.Get(1).(time.Time)
+				until = args.Get(2).(time.Time)
+			}).Return(claimed, tc.claimErr).Once()
+			repo.On("ClaimEscalations", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]alarms.Escalation{}, nil)
+			if tc.update != nil {
+				repo.On("UpdateEscalation", mock.Anything, *tc.update).Return(nil)
+			}
+			if tc.remove {
+				repo.On("RemoveEscalation", mock.Anything, tc.esc.AlarmID, tc.esc.PolicyID).Return(nil)
+			}
+			if tc.severity != 0 {
+				repo.On("UpdateAlarmSeverity", mock.Anything, tc.alarm.ID, tc.severity).Return(tc.alarm, nil)
+			}
+			if tc.assignee != "" {
+				repo.On("UpdateAlarm", mock.Anything, mock.MatchedBy(func(a alarms.Alarm) bool {
+					return a.ID == tc.alarm.ID && a.AssigneeID == tc.assignee && !a.AssignedAt.IsZero()
+				}), mock.Anything).Return(tc.alarm, nil)
+			}
+			if tc.claimErr == nil && tc.viewErr == nil && tc.esc.Step == 0 && tc.alarm.Escalable() && !tc.alarm.Shelved(time.Now()) {
+				for _, c := range policy.Steps[0].Contacts {
+					notifier.On("Notify", mock.Anything, c, tc.alarm).Return(tc.notifyErr)
+				}
+			}
+			tickChan := make(chan time.Time)
+			tck.On("Tick").Return((<-chan time.Time)(tickChan))
+			tck.On("Stop").Return()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- alarms.StartEscalations(ctx, repo, notifier, tck, mglog.NewMock())
+			}()
+
+			// The second tick is received only after the first one is handled.
+			tickChan <- time.Now()
+			tickChan <- time.Now()
+			cancel()
+			err := <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+			assert.True(t, until.After(due), fmt.Sprintf("%s: expected claimed escalations to be leased", tc.desc))
+
+			repo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+			tck.AssertCalled(t, "Stop")
+		})
+	}
+}
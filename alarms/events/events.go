@@ -0,0 +1,112 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"encoding/json"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/events"
+)
+
+const (
+	alarmPrefix      = "alarm."
+	alarmCreate      = alarmPrefix + string(alarms.CreateEvent)
+	alarmUpdate      = alarmPrefix + string(alarms.UpdateEvent)
+	alarmAssign      = alarmPrefix + string(alarms.AssignEvent)
+	alarmAcknowledge = alarmPrefix + string(alarms.AcknowledgeEvent)
+	alarmResolve     = alarmPrefix + string(alarms.ResolveEvent)
+	alarmClear       = alarmPrefix + string(alarms.ClearEvent)
+	alarmShelve      = alarmPrefix + string(alarms.ShelveEvent)
+	alarmUnshelve    = alarmPrefix + string(alarms.UnshelveEvent)
+	alarmDelete      = alarmPrefix + string(alarms.DeleteEvent)
+)
+
+var _ events.Event = (*alarmEvent)(nil)
+
+type baseAlarmEvent struct {
+	session   authn.Session
+	requestID string
+}
+
+func newBaseAlarmEvent(session authn.Session, requestID string) baseAlarmEvent {
+	return baseAlarmEvent{
+		session:   session,
+		requestID: requestID,
+	}
+}
+
+func (bae baseAlarmEvent) Encode() map[string]any {
+	return map[string]any{
+		"domain":      bae.session.DomainID,
+		"user_id":     bae.session.UserID,
+		"token_type":  bae.session.Type.String(),
+		"super_admin": bae.session.SuperAdmin,
+		"request_id":  bae.requestID,
+	}
+}
+
+// alarmEvent carries the alarm state after the change, so the subscribers
+// do not need to fetch the alarm from the service.
+type alarmEvent struct {
+	alarm     alarms.Alarm
+	eventType alarms.EventType
+	baseAlarmEvent
+}
+
+func (ae alarmEvent) Encode() (map[string]any, error) {
+	val := ae.baseAlarmEvent.Encode()
+	// Alarms created by the rules engine have no session domain.
+	if ae.alarm.DomainID != "" {
+		val["domain"] = ae.alarm.DomainID
+	}
+	alarm, err := encodeAlarm(ae.alarm)
+	if err != nil {
+		return map[string]any{}, err
+	}
+	val["alarm"] = alarm
+	val["operation"] = alarmPrefix + string(ae.eventType)
+
+	return val, nil
+}
+
+func encodeAlarm(a alarms.Alarm) (map[string]any, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func decodeAlarm(data map[string]any) (alarms.Alarm, error) {
+	var a alarms.Alarm
+	b, err := json.Marshal(events.Read(data, "alarm", map[string]any{}))
+	if err != nil {
+		return a, err
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return alarms.Alarm{}, err
+	}
+
+	return a, nil
+}
+
+// eventTypes maps the event operations to the alarm event types.
+var eventTypes = map[string]alarms.EventType{
+	alarmCreate:      alarms.CreateEvent,
+	alarmUpdate:      alarms.UpdateEvent,
+	alarmAssign:      alarms.AssignEvent,
+	alarmAcknowledge: alarms.AcknowledgeEvent,
+	alarmResolve:     alarms.ResolveEvent,
+	alarmClear:       alarms.ClearEvent,
+	alarmShelve:      alarms.ShelveEvent,
+	alarmUnshelve:    alarms.UnshelveEvent,
+	alarmDelete:      alarms.DeleteEvent,
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const (
+	allStream = "events." + magistralaPrefix + alarmPrefix + "*"
+	// streamInactiveThreshold is the time after which the consumer of a
+	// stopped service instance is removed from the event store.
+	streamInactiveThreshold = 10 * time.Minute
+)
+
+var _ events.EventHandler = (*streamHandler)(nil)
+
+type streamHandler struct {
+	stream *alarms.Stream
+}
+
+// SubscribeStream subscribes to alarm events and publishes them to the alarms stream,
+// so the stream subscribers of any service instance receive changes made through all of them.
+// The consumer must be unique per service instance, since each instance needs all the events.
+// Consumers of stopped instances are removed once they stop consuming.
+func SubscribeStream(ctx context.Context, stream *alarms.Stream, esURL, consumer string, logger *slog.Logger) (events.Subscriber, error) {
+	subscriber, err := store.NewSubscriber(ctx, esURL, "alarms-stream-es-sub", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := events.SubscriberConfig{
+		Stream:            allStream,
+		Consumer:          consumer,
+		Handler:           NewStreamHandler(stream),
+		DeliveryPolicy:    messaging.DeliverNewPolicy,
+		InactiveThreshold: streamInactiveThreshold,
+	}
+	if err := subscriber.Subscribe(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	return subscriber, nil
+}
+
+// NewStreamHandler returns an event handler that publishes
+// the alarm events to the alarms stream.
+func NewStreamHandler(stream *alarms.Stream) events.EventHandler {
+	return &streamHandler{stream: stream}
+}
+
+func (h *streamHandler) Handle(_ context.Context, event events.Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	eventType, ok := eventTypes[events.Read(data, "operation", "")]
+	if !ok {
+		return nil
+	}
+	alarm, err := decodeAlarm(data)
+	if err != nil {
+		return err
+	}
+	occurredAt := time.Now().UTC()
+	if ts := events.Read(data, "occurred_at", float64(0)); ts > 0 {
+		occurredAt = time.Unix(0, int64(ts)).UTC()
+	}
+
+	h.stream.Publish(alarms.Event{
+		Type:       eventType,
+		Alarm:      alarm,
+		OccurredAt: occurredAt,
+	})
+
+	return nil
+}
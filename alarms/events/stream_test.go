@@ -0,0 +1,77 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent map[string]any
+
+func (te testEvent) Encode() (map[string]any, error) {
+	return te, nil
+}
+
+func TestStreamHandler(t *testing.T) {
+	data, err := json.Marshal(validAlarm)
+	require.Nil(t, err, fmt.Sprintf("marshal alarm: unexpected error %s", err))
+	var alarm map[string]any
+	require.Nil(t, json.Unmarshal(data, &alarm), "unmarshal alarm: unexpected error")
+	occurredAt := time.Now().UTC().Truncate(time.Second)
+
+	cases := []struct {
+		desc  string
+		event testEvent
+		resp  *alarms.Event
+	}{
+		{
+			desc: "publish acknowledge event",
+			event: testEvent{
+				"operation":   "alarm.acknowledge",
+				"domain":      validAlarm.DomainID,
+				"alarm":       alarm,
+				"occurred_at": float64(occurredAt.UnixNano()),
+			},
+			resp: &alarms.Event{Type: alarms.AcknowledgeEvent, Alarm: validAlarm, OccurredAt: occurredAt},
+		},
+		{
+			desc: "skip event of other operation",
+			event: testEvent{
+				"operation": "rule.create",
+				"alarm":     alarm,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			stream := alarms.NewStream(1)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			sub := stream.Subscribe(ctx, alarms.StreamFilter{Status: alarms.AllStatus})
+
+			err := events.NewStreamHandler(stream).Handle(ctx, tc.event)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case got := <-sub:
+				require.NotNil(t, tc.resp, fmt.Sprintf("%s: unexpected event %v", tc.desc, got))
+				assert.Equal(t, tc.resp.Type, got.Type, fmt.Sprintf("%s: expected type %s got %s", tc.desc, tc.resp.Type, got.Type))
+				assert.Equal(t, tc.resp.Alarm.ID, got.Alarm.ID, fmt.Sprintf("%s: expected alarm %s got %s", tc.desc, tc.resp.Alarm.ID, got.Alarm.ID))
+				assert.True(t, tc.resp.OccurredAt.Equal(got.OccurredAt), fmt.Sprintf("%s: expected occurred at %s got %s", tc.desc, tc.resp.OccurredAt, got.OccurredAt))
+			default:
+				assert.Nil(t, tc.resp, fmt.Sprintf("%s: expected event", tc.desc))
+			}
+		})
+	}
+}
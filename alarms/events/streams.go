@@ -0,0 +1,247 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	magistralaPrefix  = "magistrala."
+	CreateStream      = magistralaPrefix + alarmCreate
+	UpdateStream      = magistralaPrefix + alarmUpdate
+	AssignStream      = magistralaPrefix + alarmAssign
+	AcknowledgeStream = magistralaPrefix + alarmAcknowledge
+	ResolveStream     = magistralaPrefix + alarmResolve
+	ClearStream       = magistralaPrefix + alarmClear
+	ShelveStream      = magistralaPrefix + alarmShelve
+	UnshelveStream    = magistralaPrefix + alarmUnshelve
+	DeleteStream      = magistralaPrefix + alarmDelete
+)
+
+var _ alarms.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc alarms.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around alarms service that sends
+// alarm change events to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc alarms.Service, url string) (alarms.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, "alarms-es-pub")
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+	created, err := es.svc.CreateAlarm(ctx, alarm)
+	if err != nil {
+		return created, err
+	}
+	// Duplicates of the active alarm are not stored.
+	if created.ID == "" {
+		return created, nil
+	}
+	event := alarmEvent{
+		alarm:          created,
+		eventType:      alarms.CreateEvent,
+		baseAlarmEvent: newBaseAlarmEvent(authn.Session{}, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, CreateStream, event); err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+func (es *eventStore) UpdateAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error) {
+	updated, err := es.svc.UpdateAlarm(ctx, session, alarm)
+	if err != nil {
+		return updated, err
+	}
+	eventType := alarms.UpdateEventType(alarm)
+	event := alarmEvent{
+		alarm:          updated,
+		eventType:      eventType,
+		baseAlarmEvent: newBaseAlarmEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, magistralaPrefix+alarmPrefix+string(eventType), event); err != nil {
+		return updated, err
+	}
+
+	return updated, nil
+}
+
+func (es *eventStore) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
+	// The alarm is viewed first, so the subscribers can filter the event.
+	alarm, err := es.svc.ViewAlarm(ctx, session, id)
+	if err != nil {
+		return err
+	}
+	if err := es.svc.DeleteAlarm(ctx, session, id); err != nil {
+		return err
+	}
+	event := alarmEvent{
+		alarm:          alarm,
+		eventType:      alarms.DeleteEvent,
+		baseAlarmEvent: newBaseAlarmEvent(session, middleware.GetReqID(ctx)),
+	}
+
+	return es.Publish(ctx, DeleteStream, event)
+}
+
+func (es *eventStore) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (alarms.Alarm, error) {
+	shelved, err := es.svc.ShelveAlarm(ctx, session, id, shelve)
+	if err != nil {
+		return shelved, err
+	}
+	event := alarmEvent{
+		alarm:          shelved,
+		eventType:      alarms.ShelveEvent,
+		baseAlarmEvent: newBaseAlarmEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, ShelveStream, event); err != nil {
+		return shelved, err
+	}
+
+	return shelved, nil
+}
+
+func (es *eventStore) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	unshelved, err := es.svc.UnshelveAlarm(ctx, session, id)
+	if err != nil {
+		return unshelved, err
+	}
+	event := alarmEvent{
+		alarm:          unshelved,
+		eventType:      alarms.UnshelveEvent,
+		baseAlarmEvent: newBaseAlarmEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, UnshelveStream, event); err != nil {
+		return unshelved, err
+	}
+
+	return unshelved, nil
+}
+
+func (es *eventStore) ClearAlarms(ctx context.Context, alarm alarms.Alarm) ([]alarms.Alarm, error) {
+	cleared, err := es.svc.ClearAlarms(ctx, alarm)
+	if err != nil {
+		return cleared, err
+	}
+	for _, a := range cleared {
+		event := alarmEvent{
+			alarm:          a,
+			eventType:      alarms.ClearEvent,
+			baseAlarmEvent: newBaseAlarmEvent(authn.Session{}, middleware.GetReqID(ctx)),
+		}
+		if err := es.Publish(ctx, ClearStream, event); err != nil {
+			return cleared, err
+		}
+	}
+
+	return cleared, nil
+}
+
+func (es *eventStore) BulkUpdateAlarms(ctx context.Context, session authn.Session, req alarms.BulkRequest) (alarms.BulkResult, error) {
+	res, err := es.svc.BulkUpdateAlarms(ctx, session, req)
+	if err != nil {
+		return res, err
+	}
+	// Each changed alarm gets its own event, so the subscribers can filter them.
+	eventType := req.Action.EventType()
+	for _, alarm := range res.Alarms {
+		event := alarmEvent{
+			alarm:          alarm,
+			eventType:      eventType,
+			baseAlarmEvent: newBaseAlarmEvent(session, middleware.GetReqID(ctx)),
+		}
+		if err := es.Publish(ctx, magistralaPrefix+alarmPrefix+string(eventType), event); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+func (es *eventStore) ViewAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	return es.svc.ViewAlarm(ctx, session, id)
+}
+
+func (es *eventStore) ListAlarms(ctx context.Context, session authn.Session, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	return es.svc.ListAlarms(ctx, session, pm)
+}
+
+func (es *eventStore) AlarmStats(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	return es.svc.AlarmStats(ctx, session, pm)
+}
+
+func (es *eventStore) AddComment(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (alarms.Activity, error) {
+	return es.svc.AddComment(ctx, session, alarmID, comment)
+}
+
+func (es *eventStore) ListComments(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	return es.svc.ListComments(ctx, session, alarmID, pm)
+}
+
+func (es *eventStore) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	return es.svc.AlarmTimeline(ctx, session, alarmID, pm)
+}
+
+func (es *eventStore) StreamAlarms(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (<-chan alarms.Event, error) {
+	return es.svc.StreamAlarms(ctx, session, filter)
+}
+
+func (es *eventStore) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	return es.svc.CreateEscalationPolicy(ctx, session, policy)
+}
+
+func (es *eventStore) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (alarms.EscalationPolicy, error) {
+	return es.svc.ViewEscalationPolicy(ctx, session, id)
+}
+
+func (es *eventStore) ListEscalationPolicies(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	return es.svc.ListEscalationPolicies(ctx, session, pm)
+}
+
+func (es *eventStore) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	return es.svc.UpdateEscalationPolicy(ctx, session, policy)
+}
+
+func (es *eventStore) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error {
+	return es.svc.DeleteEscalationPolicy(ctx, session, id)
+}
+
+func (es *eventStore) CreateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	return es.svc.CreateSuppressionWindow(ctx, session, window)
+}
+
+func (es *eventStore) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (alarms.SuppressionWindow, error) {
+	return es.svc.ViewSuppressionWindow(ctx, session, id)
+}
+
+func (es *eventStore) ListSuppressionWindows(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	return es.svc.ListSuppressionWindows(ctx, session, pm)
+}
+
+func (es *eventStore) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	return es.svc.UpdateSuppressionWindow(ctx, session, window)
+}
+
+func (es *eventStore) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error {
+	return es.svc.DeleteSuppressionWindow(ctx, session, id)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/events"
+	"github.com/absmach/magistrala/alarms/mocks"
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	storeClient  *redis.Client
+	storeURL     string
+	validSession = authn.Session{
+		DomainID: testsutil.GenerateUUID(&testing.T{}),
+		UserID:   testsutil.GenerateUUID(&testing.T{}),
+	}
+	validAlarm = alarms.Alarm{
+		ID:          testsutil.GenerateUUID(&testing.T{}),
+		RuleID:      testsutil.GenerateUUID(&testing.T{}),
+		DomainID:    validSession.DomainID,
+		ChannelID:   testsutil.GenerateUUID(&testing.T{}),
+		ClientID:    testsutil.GenerateUUID(&testing.T{}),
+		Measurement: "temperature",
+		Value:       "42",
+		Cause:       "temperature above threshold",
+		Severity:    80,
+		CreatedAt:   time.Now().UTC().Truncate(time.Millisecond),
+	}
+)
+
+func newEventStoreMiddleware(t *testing.T) (*mocks.Service, alarms.Service) {
+	svc := new(mocks.Service)
+	nsvc, err := events.NewEventStoreMiddleware(context.Background(), svc, storeURL)
+	require.Nil(t, err, fmt.Sprintf("create events store middleware failed with unexpected error: %s", err))
+
+	return svc, nsvc
+}
+
+func TestMain(m *testing.M) {
+	code := testsutil.RunRedisTest(m, &storeClient, &storeURL)
+	os.Exit(code)
+}
+
+func TestCreateAlarm(t *testing.T) {
+	svc, nsvc := newEventStoreMiddleware(t)
+
+	validCtx := context.WithValue(context.Background(), middleware.RequestIDKey, testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc   string
+		alarm  alarms.Alarm
+		svcRes alarms.Alarm
+		svcErr error
+		resp   alarms.Alarm
+		err    error
+	}{
+		{
+			desc:   "publish successfully",
+			alarm:  validAlarm,
+			svcRes: validAlarm,
+			resp:   validAlarm,
+		},
+		{
+			desc:   "skip publishing duplicate alarm",
+			alarm:  validAlarm,
+			svcRes: alarms.Alarm{},
+			resp:   alarms.Alarm{},
+		},
+		{
+			desc:   "failed to publish with service error",
+			alarm:  validAlarm,
+			svcRes: alarms.Alarm{},
+			svcErr: svcerr.ErrCreateEntity,
+			resp:   alarms.Alarm{},
+			err:    svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("CreateAlarm", validCtx, tc.alarm).Return(tc.svcRes, tc.svcErr)
+			resp, err := nsvc.CreateAlarm(validCtx, tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestUpdateAlarm(t *testing.T) {
+	svc, nsvc := newEventStoreMiddleware(t)
+
+	validCtx := context.WithValue(context.Background(), middleware.RequestIDKey, testsutil.GenerateUUID(t))
+	acknowledged := validAlarm
+	acknowledged.AcknowledgedBy = validSession.UserID
+	acknowledged.AcknowledgedAt = time.Now().UTC()
+
+	cases := []struct {
+		desc   string
+		alarm  alarms.Alarm
+		svcRes alarms.Alarm
+		svcErr error
+		resp   alarms.Alarm
+		err    error
+	}{
+		{
+			desc:   "publish successfully",
+			alarm:  acknowledged,
+			svcRes: acknowledged,
+			resp:   acknowledged,
+		},
+		{
+			desc:   "failed to publish with service error",
+			alarm:  acknowledged,
+			svcRes: alarms.Alarm{},
+			svcErr: svcerr.ErrUpdateEntity,
+			resp:   alarms.Alarm{},
+			err:    svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("UpdateAlarm", validCtx, validSession, tc.alarm).Return(tc.svcRes, tc.svcErr)
+			resp, err := nsvc.UpdateAlarm(validCtx, validSession, tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestDeleteAlarm(t *testing.T) {
+	svc, nsvc := newEventStoreMiddleware(t)
+
+	validCtx := context.WithValue(context.Background(), middleware.RequestIDKey, testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc      string
+		id        string
+		viewRes   alarms.Alarm
+		viewErr   error
+		deleteErr error
+		err       error
+	}{
+		{
+			desc:    "publish successfully",
+			id:      validAlarm.ID,
+			viewRes: validAlarm,
+		},
+		{
+			desc:    "failed to publish with view error",
+			id:      validAlarm.ID,
+			viewErr: svcerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:      "failed to publish with delete error",
+			id:        validAlarm.ID,
+			viewRes:   validAlarm,
+			deleteErr: svcerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			viewCall := svc.On("ViewAlarm", validCtx, validSession, tc.id).Return(tc.viewRes, tc.viewErr)
+			deleteCall := svc.On("DeleteAlarm", validCtx, validSession, tc.id).Return(tc.deleteErr)
+			err := nsvc.DeleteAlarm(validCtx, validSession, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			viewCall.Unset()
+			deleteCall.Unset()
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/absmach/magistrala/alarms"
 	"github.com/absmach/magistrala/alarms/operations"
+	api "github.com/absmach/magistrala/api/http"
 	"github.com/absmach/magistrala/auth"
 	"github.com/absmach/magistrala/internal/atom"
 	"github.com/absmach/magistrala/pkg/authn"
@@ -22,6 +23,8 @@ var (
 	errDomainUpdateAlarms = errors.New("not authorized to update alarms in domain")
 	errDomainDeleteAlarms = errors.New("not authorized to delete alarms in domain")
 	errDomainViewAlarms   = errors.New("not authorized to view alarms in domain")
+	errDomainEscalations  = errors.New("not authorized to manage escalation policies in domain")
+	errDomainSuppressions = errors.New("not authorized to manage suppression windows in domain")
 )
 
 type authorizationMiddleware struct {
@@ -71,6 +74,34 @@ func (am *authorizationMiddleware) CreateAlarm(ctx context.Context, alarm alarms
 	return am.svc.CreateAlarm(ctx, alarm)
 }
 
+func (am *authorizationMiddleware) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (alarms.Alarm, error) {
+	alarm, err := am.svc.ViewAlarm(ctx, session, id)
+	if err != nil {
+		return alarms.Alarm{}, err
+	}
+	if err := am.authorizeAlarmOrRule(ctx, operations.OpShelveAlarm, session, alarm); err != nil {
+		return alarms.Alarm{}, errors.Wrap(errDomainUpdateAlarms, err)
+	}
+
+	return am.svc.ShelveAlarm(ctx, session, id, shelve)
+}
+
+func (am *authorizationMiddleware) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	alarm, err := am.svc.ViewAlarm(ctx, session, id)
+	if err != nil {
+		return alarms.Alarm{}, err
+	}
+	if err := am.authorizeAlarmOrRule(ctx, operations.OpUnshelveAlarm, session, alarm); err != nil {
+		return alarms.Alarm{}, errors.Wrap(errDomainUpdateAlarms, err)
+	}
+
+	return am.svc.UnshelveAlarm(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ClearAlarms(ctx context.Context, alarm alarms.Alarm) ([]alarms.Alarm, error) {
+	return am.svc.ClearAlarms(ctx, alarm)
+}
+
 func (am *authorizationMiddleware) UpdateAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error) {
 	current, err := am.svc.ViewAlarm(ctx, session, alarm.ID)
 	if err != nil {
@@ -87,19 +118,8 @@ func (am *authorizationMiddleware) UpdateAlarm(ctx context.Context, session auth
 		if err := am.authorizeAlarmOrRule(ctx, operations.OpAssignAlarm, session, current); err != nil {
 			return alarms.Alarm{}, errors.Wrap(errDomainUpdateAlarms, err)
 		}
-		if am.atomAuthz == nil {
-			domainUserID := auth.EncodeDomainUserID(session.DomainID, alarm.AssigneeID)
-			if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
-				Domain:      session.DomainID,
-				SubjectType: policies.UserType,
-				SubjectKind: policies.UsersKind,
-				Subject:     domainUserID,
-				Permission:  policies.MembershipPermission,
-				ObjectType:  policies.DomainType,
-				Object:      session.DomainID,
-			}, nil); err != nil {
-				return alarms.Alarm{}, err
-			}
+		if err := am.authorizeAssignee(ctx, session, alarm.AssigneeID); err != nil {
+			return alarms.Alarm{}, err
 		}
 	}
 
@@ -130,6 +150,163 @@ func (am *authorizationMiddleware) DeleteAlarm(ctx context.Context, session auth
 	return am.svc.DeleteAlarm(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) BulkUpdateAlarms(ctx context.Context, session authn.Session, req alarms.BulkRequest) (alarms.BulkResult, error) {
+	op, wrapper := bulkOperation(req.Action)
+	if req.Action == alarms.AssignAction {
+		if err := am.authorizeAssignee(ctx, session, req.AssigneeID); err != nil {
+			return alarms.BulkResult{}, err
+		}
+	}
+
+	switch err := am.checkSuperAdmin(ctx, session); {
+	case err == nil:
+		return am.svc.BulkUpdateAlarms(ctx, session, req)
+	case !errors.Contains(err, svcerr.ErrSuperAdminAction):
+		return alarms.BulkResult{}, err
+	}
+	tenantErr := am.authorizeTenantAlarm(ctx, op, session)
+	if tenantErr == nil {
+		return am.svc.BulkUpdateAlarms(ctx, session, req)
+	}
+
+	// Without the domain permission, each alarm is authorized by its rule.
+	targets, err := am.bulkTargets(ctx, session, req)
+	if err != nil {
+		return alarms.BulkResult{}, err
+	}
+	rules := make(map[string]bool)
+	found := make(map[string]bool, len(targets))
+	var ids []string
+	var denied []alarms.BulkFailure
+	for _, alarm := range targets {
+		found[alarm.ID] = true
+		allowed, ok := rules[alarm.RuleID]
+		if !ok {
+			allowed = am.authorizeAlarmRule(ctx, op, session, alarm) == nil
+			rules[alarm.RuleID] = allowed
+		}
+		if !allowed {
+			denied = append(denied, alarms.BulkFailure{AlarmID: alarm.ID, Error: wrapper.Error()})
+			continue
+		}
+		ids = append(ids, alarm.ID)
+	}
+	if len(ids) == 0 {
+		return alarms.BulkResult{}, errors.Wrap(wrapper, tenantErr)
+	}
+	// The missing alarms are passed on, so they are reported as not found.
+	for _, id := range req.AlarmIDs {
+		if !found[id] {
+			ids = append(ids, id)
+		}
+	}
+
+	req.AlarmIDs = ids
+	res, err := am.svc.BulkUpdateAlarms(ctx, session, req)
+	if err != nil {
+		return alarms.BulkResult{}, err
+	}
+	res.Failed = append(res.Failed, denied...)
+	res.Total += uint64(len(denied))
+
+	return res, nil
+}
+
+// bulkTargets returns the alarms selected by the bulk request.
+func (am *authorizationMiddleware) bulkTargets(ctx context.Context, session authn.Session, req alarms.BulkRequest) ([]alarms.Alarm, error) {
+	pm := req.Filter
+	pm.AlarmIDs = req.AlarmIDs
+	pm.Limit = api.MaxLimitSize
+
+	var targets []alarms.Alarm
+	for pm.Offset = 0; pm.Offset < alarms.MaxBulkAlarms; pm.Offset += pm.Limit {
+		page, err := am.svc.ListAlarms(ctx, session, pm)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, page.Alarms...)
+		if pm.Offset+pm.Limit >= page.Total {
+			break
+		}
+	}
+
+	return targets, nil
+}
+
+func bulkOperation(action alarms.BulkAction) (permissions.Operation, error) {
+	switch action {
+	case alarms.AcknowledgeAction:
+		return operations.OpAcknowledgeAlarm, errDomainUpdateAlarms
+	case alarms.ResolveAction:
+		return operations.OpResolveAlarm, errDomainUpdateAlarms
+	case alarms.AssignAction:
+		return operations.OpAssignAlarm, errDomainUpdateAlarms
+	default:
+		return operations.OpDeleteAlarm, errDomainDeleteAlarms
+	}
+}
+
+func (am *authorizationMiddleware) AddComment(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (alarms.Activity, error) {
+	alarm, err := am.svc.ViewAlarm(ctx, session, alarmID)
+	if err != nil {
+		return alarms.Activity{}, err
+	}
+	if err := am.authorizeAlarmOrRule(ctx, operations.OpAddAlarmComment, session, alarm); err != nil {
+		return alarms.Activity{}, errors.Wrap(errDomainUpdateAlarms, err)
+	}
+
+	return am.svc.AddComment(ctx, session, alarmID, comment)
+}
+
+func (am *authorizationMiddleware) ListComments(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	alarm, err := am.svc.ViewAlarm(ctx, session, alarmID)
+	if err != nil {
+		return alarms.ActivitiesPage{}, err
+	}
+	if err := am.authorizeAlarmOrRule(ctx, operations.OpListAlarmComments, session, alarm); err != nil {
+		return alarms.ActivitiesPage{}, errors.Wrap(errDomainViewAlarms, err)
+	}
+
+	return am.svc.ListComments(ctx, session, alarmID, pm)
+}
+
+func (am *authorizationMiddleware) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	alarm, err := am.svc.ViewAlarm(ctx, session, alarmID)
+	if err != nil {
+		return alarms.ActivitiesPage{}, err
+	}
+	if err := am.authorizeAlarmOrRule(ctx, operations.OpViewAlarmTimeline, session, alarm); err != nil {
+		return alarms.ActivitiesPage{}, errors.Wrap(errDomainViewAlarms, err)
+	}
+
+	return am.svc.AlarmTimeline(ctx, session, alarmID, pm)
+}
+
+func (am *authorizationMiddleware) StreamAlarms(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (<-chan alarms.Event, error) {
+	switch err := am.checkSuperAdmin(ctx, session); {
+	case err == nil:
+		session.SuperAdmin = true
+	case errors.Contains(err, svcerr.ErrSuperAdminAction):
+		if err := am.authorizeTenantAlarm(ctx, operations.OpStreamAlarms, session); err != nil {
+			if filter.RuleID != "" {
+				if ruleErr := am.authorizeRuleAlarmRead(ctx, session, filter.RuleID); ruleErr != nil {
+					return nil, errors.Wrap(errDomainViewAlarms, err)
+				}
+				break
+			}
+			ruleIDs, ruleErr := am.authorizedReadableRuleIDs(ctx, session)
+			if ruleErr != nil || len(ruleIDs) == 0 {
+				return nil, errors.Wrap(errDomainViewAlarms, err)
+			}
+			filter.RuleIDs = ruleIDs
+		}
+	default:
+		return nil, err
+	}
+
+	return am.svc.StreamAlarms(ctx, session, filter)
+}
+
 func (am *authorizationMiddleware) ListAlarms(ctx context.Context, session authn.Session, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
 	if pm.DomainID == "" {
 		pm.DomainID = session.DomainID
@@ -166,6 +343,31 @@ func (am *authorizationMiddleware) ListAlarms(ctx context.Context, session authn
 	return am.svc.ListAlarms(ctx, session, pm)
 }
 
+func (am *authorizationMiddleware) AlarmStats(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	switch err := am.checkSuperAdmin(ctx, session); {
+	case err == nil:
+		session.SuperAdmin = true
+	case errors.Contains(err, svcerr.ErrSuperAdminAction):
+		if err := am.authorizeTenantAlarm(ctx, operations.OpAlarmStats, session); err != nil {
+			if pm.RuleID != "" {
+				if ruleErr := am.authorizeRuleAlarmRead(ctx, session, pm.RuleID); ruleErr != nil {
+					return alarms.Stats{}, errors.Wrap(errDomainViewAlarms, err)
+				}
+				break
+			}
+			ruleIDs, ruleErr := am.authorizedReadableRuleIDs(ctx, session)
+			if ruleErr != nil || len(ruleIDs) == 0 {
+				return alarms.Stats{}, errors.Wrap(errDomainViewAlarms, err)
+			}
+			pm.RuleIDs = ruleIDs
+		}
+	default:
+		return alarms.Stats{}, err
+	}
+
+	return am.svc.AlarmStats(ctx, session, pm)
+}
+
 func (am *authorizationMiddleware) ViewAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
 	alarm, err := am.svc.ViewAlarm(ctx, session, id)
 	if err != nil {
@@ -183,15 +385,37 @@ func (am *authorizationMiddleware) authorizeAlarmOrRule(ctx context.Context, op
 	if tenantErr == nil {
 		return nil
 	}
-	if alarm.RuleID == "" {
-		return tenantErr
-	}
-	if err := am.authorize(ctx, op, session, policies.RulesType, alarm.RuleID, atom.KindRule); err != nil {
+	if err := am.authorizeAlarmRule(ctx, op, session, alarm); err != nil {
 		return tenantErr
 	}
 	return nil
 }
 
+func (am *authorizationMiddleware) authorizeAlarmRule(ctx context.Context, op permissions.Operation, session authn.Session, alarm alarms.Alarm) error {
+	if alarm.RuleID == "" {
+		return svcerr.ErrAuthorization
+	}
+	return am.authorize(ctx, op, session, policies.RulesType, alarm.RuleID, atom.KindRule)
+}
+
+// authorizeAssignee checks that the assignee is a member of the domain. Atom
+// checks the membership itself.
+func (am *authorizationMiddleware) authorizeAssignee(ctx context.Context, session authn.Session, assigneeID string) error {
+	if am.atomAuthz != nil {
+		return nil
+	}
+	domainUserID := auth.EncodeDomainUserID(session.DomainID, assigneeID)
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     domainUserID,
+		Permission:  policies.MembershipPermission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}, nil)
+}
+
 func (am *authorizationMiddleware) authorizeViewAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) error {
 	tenantErr := am.authorizeTenantAlarm(ctx, operations.OpViewAlarm, session)
 	if tenantErr == nil {
@@ -320,3 +544,83 @@ func (am *authorizationMiddleware) checkSuperAdmin(ctx context.Context, session
 	}
 	return nil
 }
+
+func (am *authorizationMiddleware) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpCreateEscalationPolicy, session); err != nil {
+		return alarms.EscalationPolicy{}, errors.Wrap(errDomainEscalations, err)
+	}
+
+	return am.svc.CreateEscalationPolicy(ctx, session, policy)
+}
+
+func (am *authorizationMiddleware) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (alarms.EscalationPolicy, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpViewEscalationPolicy, session); err != nil {
+		return alarms.EscalationPolicy{}, errors.Wrap(errDomainViewAlarms, err)
+	}
+
+	return am.svc.ViewEscalationPolicy(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListEscalationPolicies(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpListEscalationPolicies, session); err != nil {
+		return alarms.EscalationPoliciesPage{}, errors.Wrap(errDomainViewAlarms, err)
+	}
+
+	return am.svc.ListEscalationPolicies(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpUpdateEscalationPolicy, session); err != nil {
+		return alarms.EscalationPolicy{}, errors.Wrap(errDomainEscalations, err)
+	}
+
+	return am.svc.UpdateEscalationPolicy(ctx, session, policy)
+}
+
+func (am *authorizationMiddleware) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpDeleteEscalationPolicy, session); err != nil {
+		return errors.Wrap(errDomainEscalations, err)
+	}
+
+	return am.svc.DeleteEscalationPolicy(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) CreateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpCreateSuppressionWindow, session); err != nil {
+		return alarms.SuppressionWindow{}, errors.Wrap(errDomainSuppressions, err)
+	}
+
+	return am.svc.CreateSuppressionWindow(ctx, session, window)
+}
+
+func (am *authorizationMiddleware) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (alarms.SuppressionWindow, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpViewSuppressionWindow, session); err != nil {
+		return alarms.SuppressionWindow{}, errors.Wrap(errDomainViewAlarms, err)
+	}
+
+	return am.svc.ViewSuppressionWindow(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListSuppressionWindows(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpListSuppressionWindows, session); err != nil {
+		return alarms.SuppressionWindowsPage{}, errors.Wrap(errDomainViewAlarms, err)
+	}
+
+	return am.svc.ListSuppressionWindows(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpUpdateSuppressionWindow, session); err != nil {
+		return alarms.SuppressionWindow{}, errors.Wrap(errDomainSuppressions, err)
+	}
+
+	return am.svc.UpdateSuppressionWindow(ctx, session, window)
+}
+
+func (am *authorizationMiddleware) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error {
+	if err := am.authorizeTenantAlarm(ctx, operations.OpDeleteSuppressionWindow, session); err != nil {
+		return errors.Wrap(errDomainSuppressions, err)
+	}
+
+	return am.svc.DeleteSuppressionWindow(ctx, session, id)
+}
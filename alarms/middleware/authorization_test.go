@@ -6,12 +6,14 @@ package middleware
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/alarms"
 	"github.com/absmach/magistrala/alarms/mocks"
 	"github.com/absmach/magistrala/alarms/operations"
 	"github.com/absmach/magistrala/internal/atom"
 	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/permissions"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -187,6 +189,351 @@ func TestAcknowledgeAlarmAuthorizesRuleAlarmActionWhenTenantDenied(t *testing.T)
 	}, authz.reqs[1])
 }
 
+func TestBulkAcknowledgeAuthorizesTenantAlarmAcknowledge(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	req := alarms.NewBulkRequest(alarms.AcknowledgeAction)
+	req.AlarmIDs = []string{"alarm-1", "alarm-2"}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("BulkUpdateAlarms", mock.Anything, session, req).Return(alarms.BulkResult{Total: 2}, nil).Once()
+	res, err := wrapped.BulkUpdateAlarms(context.Background(), session, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), res.Total)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_acknowledge", authz.reqs[0].Action)
+	assert.Equal(t, "tenant", authz.reqs[0].ObjectKind)
+}
+
+func TestBulkAcknowledgeAuthorizesEachAlarmRuleWhenTenantDenied(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	req := alarms.NewBulkRequest(alarms.AcknowledgeAction)
+	req.AlarmIDs = []string{"alarm-1", "alarm-2", "alarm-3", "alarm-4"}
+	targets := []alarms.Alarm{
+		{ID: "alarm-1", RuleID: "rule-1"},
+		{ID: "alarm-2", RuleID: "rule-2"},
+		{ID: "alarm-3", RuleID: "rule-1"},
+	}
+	expectedReq := req
+	expectedReq.AlarmIDs = []string{"alarm-1", "alarm-3", "alarm-4"}
+	authz := &recordingAtomAuthorizer{
+		allow: func(req atom.AuthzRequest) bool {
+			return req.Action == "alarm_acknowledge" && req.ObjectKind == "resource" && req.ObjectID == "rule-1"
+		},
+	}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ListAlarms", mock.Anything, session, mock.Anything).Return(alarms.AlarmsPage{Total: 3, Alarms: targets}, nil).Once()
+	svc.On("BulkUpdateAlarms", mock.Anything, session, expectedReq).Return(alarms.BulkResult{
+		Action:    alarms.AcknowledgeAction,
+		Total:     3,
+		Succeeded: []string{"alarm-1", "alarm-3"},
+		Failed:    []alarms.BulkFailure{{AlarmID: "alarm-4", Error: alarms.ErrBulkNotApplied.Error()}},
+	}, nil).Once()
+	res, err := wrapped.BulkUpdateAlarms(context.Background(), session, req)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), res.Total)
+	assert.Equal(t, []string{"alarm-1", "alarm-3"}, res.Succeeded)
+	require.Len(t, res.Failed, 2)
+	assert.Equal(t, "alarm-2", res.Failed[1].AlarmID)
+	// The rule of the alarms is authorized once.
+	require.Len(t, authz.reqs, 3)
+}
+
+func TestBulkDeleteDeniedWithoutAuthorizedAlarms(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	req := alarms.NewBulkRequest(alarms.DeleteAction)
+	req.Filter.ChannelID = "channel-1"
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ListAlarms", mock.Anything, session, mock.Anything).Return(alarms.AlarmsPage{Total: 1, Alarms: []alarms.Alarm{{ID: "alarm-1", RuleID: "rule-1"}}}, nil).Once()
+	_, err = wrapped.BulkUpdateAlarms(context.Background(), session, req)
+
+	require.Error(t, err)
+	require.Len(t, authz.reqs, 2)
+	assert.Equal(t, "alarm_delete", authz.reqs[0].Action)
+	assert.Equal(t, "alarm_delete", authz.reqs[1].Action)
+}
+
+func TestAddCommentAuthorizesTenantAlarmUpdate(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	current := alarms.Alarm{ID: "alarm-1", RuleID: "rule-1", DomainID: "domain-1"}
+	comment := alarms.Comment{Content: "checked the sensor"}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ViewAlarm", mock.Anything, session, "alarm-1").Return(current, nil).Once()
+	svc.On("AddComment", mock.Anything, session, "alarm-1", comment).Return(alarms.Activity{ID: "activity-1"}, nil).Once()
+	_, err = wrapped.AddComment(context.Background(), session, "alarm-1", comment)
+
+	require.NoError(t, err)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_update", authz.reqs[0].Action)
+	assert.Equal(t, "domain-1", authz.reqs[0].ObjectID)
+}
+
+func TestAlarmTimelineAuthorizesRuleReadWhenTenantDenied(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	current := alarms.Alarm{ID: "alarm-1", RuleID: "rule-1", DomainID: "domain-1"}
+	pm := alarms.ActivityPageMeta{Limit: 10}
+	authz := &recordingAtomAuthorizer{
+		allow: func(req atom.AuthzRequest) bool {
+			return req.Action == "alarm_read" && req.ObjectKind == "resource" && req.ObjectID == "rule-1"
+		},
+	}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ViewAlarm", mock.Anything, session, "alarm-1").Return(current, nil).Once()
+	svc.On("AlarmTimeline", mock.Anything, session, "alarm-1", pm).Return(alarms.ActivitiesPage{Total: 1}, nil).Once()
+	page, err := wrapped.AlarmTimeline(context.Background(), session, "alarm-1", pm)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), page.Total)
+	require.Len(t, authz.reqs, 2)
+	assert.Equal(t, "tenant", authz.reqs[0].ObjectKind)
+	assert.Equal(t, "rule-1", authz.reqs[1].ObjectID)
+}
+
+func TestListCommentsDeniedWithoutAlarmRead(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	current := alarms.Alarm{ID: "alarm-1", RuleID: "rule-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ViewAlarm", mock.Anything, session, "alarm-1").Return(current, nil).Once()
+	_, err = wrapped.ListComments(context.Background(), session, "alarm-1", alarms.ActivityPageMeta{Limit: 10})
+
+	require.Error(t, err)
+	require.Len(t, authz.reqs, 2)
+	assert.Equal(t, "alarm_read", authz.reqs[0].Action)
+	assert.Equal(t, "alarm_read", authz.reqs[1].Action)
+}
+
+func TestShelveAlarmAuthorizesTenantAlarmUpdate(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	current := alarms.Alarm{ID: "alarm-1", RuleID: "rule-1", DomainID: "domain-1"}
+	shelve := alarms.Shelve{Until: time.Now().Add(time.Hour)}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ViewAlarm", mock.Anything, session, "alarm-1").Return(current, nil).Once()
+	svc.On("ShelveAlarm", mock.Anything, session, "alarm-1", shelve).Return(current, nil).Once()
+	_, err = wrapped.ShelveAlarm(context.Background(), session, "alarm-1", shelve)
+
+	require.NoError(t, err)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_update", authz.reqs[0].Action)
+	assert.Equal(t, "domain-1", authz.reqs[0].ObjectID)
+}
+
+func TestUnshelveAlarmDeniedWithoutAlarmUpdate(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	current := alarms.Alarm{ID: "alarm-1", RuleID: "rule-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("ViewAlarm", mock.Anything, session, "alarm-1").Return(current, nil).Once()
+	_, err = wrapped.UnshelveAlarm(context.Background(), session, "alarm-1")
+
+	require.Error(t, err)
+	require.Len(t, authz.reqs, 2)
+	assert.Equal(t, "alarm_update", authz.reqs[0].Action)
+	assert.Equal(t, "rule-1", authz.reqs[1].ObjectID)
+}
+
+func TestCreateEscalationPolicyAuthorizesTenantAlarmUpdate(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	policy := alarms.EscalationPolicy{Name: "policy"}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("CreateEscalationPolicy", mock.Anything, session, policy).Return(policy, nil).Once()
+	_, err = wrapped.CreateEscalationPolicy(context.Background(), session, policy)
+
+	require.NoError(t, err)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, atom.AuthzRequest{
+		SubjectID:  "user-1",
+		Action:     "alarm_update",
+		ResourceID: "",
+		ObjectKind: "tenant",
+		ObjectID:   "domain-1",
+		Context: map[string]any{
+			"domain_id":          "domain-1",
+			"legacy_object_type": "domain",
+		},
+	}, authz.reqs[0])
+}
+
+func TestListEscalationPoliciesDeniedWithoutTenantAlarmRead(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	_, err = wrapped.ListEscalationPolicies(context.Background(), session, alarms.EscalationPolicyPageMeta{Limit: 10})
+
+	require.Error(t, err)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_read", authz.reqs[0].Action)
+}
+
+func TestCreateSuppressionWindowAuthorizesTenantAlarmUpdate(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	window := alarms.SuppressionWindow{Name: "maintenance", ChannelID: "channel-1"}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("CreateSuppressionWindow", mock.Anything, session, window).Return(window, nil).Once()
+	_, err = wrapped.CreateSuppressionWindow(context.Background(), session, window)
+
+	require.NoError(t, err)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_update", authz.reqs[0].Action)
+	assert.Equal(t, "domain-1", authz.reqs[0].ObjectID)
+}
+
+func TestListSuppressionWindowsDeniedWithoutTenantAlarmRead(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	_, err = wrapped.ListSuppressionWindows(context.Background(), session, alarms.SuppressionWindowPageMeta{Limit: 10})
+
+	require.Error(t, err)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_read", authz.reqs[0].Action)
+}
+
+func TestAlarmStatsAuthorizesTenantAlarmReader(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	pm := alarms.StatsPageMeta{Interval: time.Hour, Top: 10}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("AlarmStats", mock.Anything, session, pm).Return(alarms.Stats{Total: 1}, nil).Once()
+	stats, err := wrapped.AlarmStats(context.Background(), session, pm)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.Total)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_read", authz.reqs[0].Action)
+	assert.Equal(t, "tenant", authz.reqs[0].ObjectKind)
+}
+
+func TestAlarmStatsFiltersToReadableRulesWhenTenantAlarmReadDenied(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	pm := alarms.StatsPageMeta{Interval: time.Hour, Top: 10}
+	expectedPM := pm
+	expectedPM.RuleIDs = []string{"rule-1"}
+	authz := &recordingAtomAuthorizer{
+		allowed:    false,
+		authorized: atom.AuthorizedObjectIDs{IDs: []string{"rule-1"}, Total: 1},
+	}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("AlarmStats", mock.Anything, session, expectedPM).Return(alarms.Stats{}, nil).Once()
+	_, err = wrapped.AlarmStats(context.Background(), session, pm)
+
+	require.NoError(t, err)
+	require.Len(t, authz.queries, 1)
+}
+
+func TestAlarmStatsDeniedWithoutReadableRules(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	_, err = wrapped.AlarmStats(context.Background(), session, alarms.StatsPageMeta{Interval: time.Hour, Top: 10})
+
+	require.Error(t, err)
+}
+
+func TestStreamAlarmsAuthorizesTenantAlarmReader(t *testing.T) {
+	svc := mocks.NewService(t)
+	filter := alarms.StreamFilter{Status: alarms.AllStatus}
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: true}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	events := make(<-chan alarms.Event)
+	svc.On("StreamAlarms", mock.Anything, session, filter).Return(events, nil).Once()
+	stream, err := wrapped.StreamAlarms(context.Background(), session, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, events, stream)
+	require.Len(t, authz.reqs, 1)
+	assert.Equal(t, "alarm_read", authz.reqs[0].Action)
+	assert.Equal(t, "tenant", authz.reqs[0].ObjectKind)
+}
+
+func TestStreamAlarmsFiltersToReadableRulesWhenTenantAlarmReadDenied(t *testing.T) {
+	svc := mocks.NewService(t)
+	filter := alarms.StreamFilter{Status: alarms.AllStatus}
+	expected := filter
+	expected.RuleIDs = []string{"rule-1", "rule-2"}
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{
+		allowed:    false,
+		authorized: atom.AuthorizedObjectIDs{IDs: []string{"rule-1", "rule-2"}, Total: 2},
+	}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	svc.On("StreamAlarms", mock.Anything, session, expected).Return(make(<-chan alarms.Event), nil).Once()
+	_, err = wrapped.StreamAlarms(context.Background(), session, filter)
+
+	require.NoError(t, err)
+	require.Len(t, authz.queries, 1)
+	assert.Equal(t, "resource:rule", authz.queries[0].ObjectType)
+}
+
+func TestStreamAlarmsDeniedWithoutReadableRules(t *testing.T) {
+	svc := mocks.NewService(t)
+	session := authn.Session{UserID: "user-1", DomainID: "domain-1"}
+	authz := &recordingAtomAuthorizer{allowed: false}
+	wrapped, err := NewAtomAuthorizationMiddleware(svc, authz, testEntitiesOps(t))
+	require.NoError(t, err)
+
+	_, err = wrapped.StreamAlarms(context.Background(), session, alarms.StreamFilter{Status: alarms.AllStatus})
+
+	assert.True(t, errors.Contains(err, errDomainViewAlarms))
+	svc.AssertNotCalled(t, "StreamAlarms", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func testEntitiesOps(t *testing.T) permissions.EntitiesOperations[permissions.Operation] {
 	t.Helper()
 	details := operations.OperationDetails()
@@ -206,9 +553,13 @@ func testEntitiesOps(t *testing.T) permissions.EntitiesOperations[permissions.Op
 
 func testPermission(op permissions.Operation, fallback string) permissions.Permission {
 	switch op {
-	case operations.OpViewAlarm, operations.OpListAlarms:
+	case operations.OpViewAlarm, operations.OpListAlarms, operations.OpStreamAlarms, operations.OpViewEscalationPolicy, operations.OpListEscalationPolicies,
+		operations.OpViewSuppressionWindow, operations.OpListSuppressionWindows, operations.OpAlarmStats,
+		operations.OpListAlarmComments, operations.OpViewAlarmTimeline:
 		return "alarm_read_permission"
-	case operations.OpUpdateAlarm:
+	case operations.OpUpdateAlarm, operations.OpCreateEscalationPolicy, operations.OpUpdateEscalationPolicy, operations.OpDeleteEscalationPolicy,
+		operations.OpCreateSuppressionWindow, operations.OpUpdateSuppressionWindow, operations.OpDeleteSuppressionWindow,
+		operations.OpAddAlarmComment, operations.OpShelveAlarm, operations.OpUnshelveAlarm:
 		return "alarm_update_permission"
 	case operations.OpDeleteAlarm:
 		return "alarm_delete_permission"
@@ -136,6 +136,30 @@ func (lm *loggingMiddleware) ListAlarms(ctx context.Context, session authn.Sessi
 	return lm.service.ListAlarms(ctx, session, pm)
 }
 
+func (lm *loggingMiddleware) AlarmStats(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (stats alarms.Stats, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", pm.RuleID),
+			slog.String("channel_id", pm.ChannelID),
+			slog.String("client_id", pm.ClientID),
+			slog.Time("from", pm.From),
+			slog.Time("to", pm.To),
+			slog.String("interval", pm.Interval.String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Alarm stats failed", args...)
+			return
+		}
+		lm.logger.Info("Alarm stats completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.AlarmStats(ctx, session, pm)
+}
+
 func (lm *loggingMiddleware) DeleteAlarm(ctx context.Context, session authn.Session, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -153,3 +177,381 @@ func (lm *loggingMiddleware) DeleteAlarm(ctx context.Context, session authn.Sess
 
 	return lm.service.DeleteAlarm(ctx, session, id)
 }
+
+func (lm *loggingMiddleware) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (shelved alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("id", id),
+			slog.Time("until", shelve.Until),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Shelve alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Shelve alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ShelveAlarm(ctx, session, id, shelve)
+}
+
+func (lm *loggingMiddleware) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (unshelved alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unshelve alarm failed", args...)
+			return
+		}
+		lm.logger.Info("Unshelve alarm completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.UnshelveAlarm(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ClearAlarms(ctx context.Context, alarm alarms.Alarm) (cleared []alarms.Alarm, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Group("alarm",
+				slog.String("rule_id", alarm.RuleID),
+				slog.String("domain_id", alarm.DomainID),
+				slog.String("channel_id", alarm.ChannelID),
+				slog.String("client_id", alarm.ClientID),
+				slog.String("subtopic", alarm.Subtopic),
+				slog.String("measurement", alarm.Measurement),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Clear alarms failed", args...)
+			return
+		}
+		if len(cleared) > 0 {
+			args = append(args, slog.Int("cleared", len(cleared)))
+			lm.logger.Info("Clear alarms completed successfully", args...)
+		}
+	}(time.Now())
+
+	return lm.service.ClearAlarms(ctx, alarm)
+}
+
+func (lm *loggingMiddleware) BulkUpdateAlarms(ctx context.Context, session authn.Session, req alarms.BulkRequest) (res alarms.BulkResult, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("action", string(req.Action)),
+			slog.Int("alarm_ids", len(req.AlarmIDs)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Bulk update alarms failed", args...)
+			return
+		}
+		args = append(args,
+			slog.Int("succeeded", len(res.Succeeded)),
+			slog.Int("failed", len(res.Failed)),
+		)
+		lm.logger.Info("Bulk update alarms completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.BulkUpdateAlarms(ctx, session, req)
+}
+
+func (lm *loggingMiddleware) AddComment(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (act alarms.Activity, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", alarmID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add alarm comment failed", args...)
+			return
+		}
+		args = append(args, slog.String("comment_id", act.ID))
+		lm.logger.Info("Add alarm comment completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.AddComment(ctx, session, alarmID, comment)
+}
+
+func (lm *loggingMiddleware) ListComments(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (page alarms.ActivitiesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", alarmID),
+			slog.Uint64("offset", pm.Offset),
+			slog.Uint64("limit", pm.Limit),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List alarm comments failed", args...)
+			return
+		}
+		lm.logger.Info("List alarm comments completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ListComments(ctx, session, alarmID, pm)
+}
+
+func (lm *loggingMiddleware) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (page alarms.ActivitiesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("alarm_id", alarmID),
+			slog.Uint64("offset", pm.Offset),
+			slog.Uint64("limit", pm.Limit),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View alarm timeline failed", args...)
+			return
+		}
+		lm.logger.Info("View alarm timeline completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.AlarmTimeline(ctx, session, alarmID, pm)
+}
+
+func (lm *loggingMiddleware) StreamAlarms(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (events <-chan alarms.Event, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", filter.RuleID),
+			slog.String("channel_id", filter.ChannelID),
+			slog.String("status", filter.Status.String()),
+			slog.Uint64("severity", uint64(filter.MinSeverity)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Stream alarms failed", args...)
+			return
+		}
+		lm.logger.Info("Stream alarms started successfully", args...)
+	}(time.Now())
+
+	return lm.service.StreamAlarms(ctx, session, filter)
+}
+
+func (lm *loggingMiddleware) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (p alarms.EscalationPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Group("escalation_policy",
+				slog.String("id", p.ID),
+				slog.String("name", policy.Name),
+				slog.Int("steps", len(policy.Steps)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create escalation policy failed", args...)
+			return
+		}
+		lm.logger.Info("Create escalation policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.CreateEscalationPolicy(ctx, session, policy)
+}
+
+func (lm *loggingMiddleware) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (p alarms.EscalationPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View escalation policy failed", args...)
+			return
+		}
+		lm.logger.Info("View escalation policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ViewEscalationPolicy(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListEscalationPolicies(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (page alarms.EscalationPoliciesPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Int("offset", int(pm.Offset)),
+			slog.Int("limit", int(pm.Limit)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List escalation policies failed", args...)
+			return
+		}
+		lm.logger.Info("List escalation policies completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ListEscalationPolicies(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (p alarms.EscalationPolicy, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Group("escalation_policy",
+				slog.String("id", policy.ID),
+				slog.String("name", policy.Name),
+				slog.Int("steps", len(policy.Steps)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update escalation policy failed", args...)
+			return
+		}
+		lm.logger.Info("Update escalation policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.UpdateEscalationPolicy(ctx, session, policy)
+}
+
+func (lm *loggingMiddleware) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete escalation policy failed", args...)
+			return
+		}
+		lm.logger.Info("Delete escalation policy completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.DeleteEscalationPolicy(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) CreateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (w alarms.SuppressionWindow, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Group("suppression_window",
+				slog.String("id", w.ID),
+				slog.String("name", window.Name),
+				slog.String("channel_id", window.ChannelID),
+				slog.String("client_id", window.ClientID),
+				slog.Time("starts_at", window.StartsAt),
+				slog.Time("ends_at", window.EndsAt),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create suppression window failed", args...)
+			return
+		}
+		lm.logger.Info("Create suppression window completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.CreateSuppressionWindow(ctx, session, window)
+}
+
+func (lm *loggingMiddleware) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (w alarms.SuppressionWindow, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View suppression window failed", args...)
+			return
+		}
+		lm.logger.Info("View suppression window completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ViewSuppressionWindow(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListSuppressionWindows(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (page alarms.SuppressionWindowsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Int("offset", int(pm.Offset)),
+			slog.Int("limit", int(pm.Limit)),
+			slog.String("channel_id", pm.ChannelID),
+			slog.String("client_id", pm.ClientID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List suppression windows failed", args...)
+			return
+		}
+		lm.logger.Info("List suppression windows completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.ListSuppressionWindows(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (w alarms.SuppressionWindow, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Group("suppression_window",
+				slog.String("id", window.ID),
+				slog.String("name", window.Name),
+				slog.String("channel_id", window.ChannelID),
+				slog.String("client_id", window.ClientID),
+				slog.Time("starts_at", window.StartsAt),
+				slog.Time("ends_at", window.EndsAt),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update suppression window failed", args...)
+			return
+		}
+		lm.logger.Info("Update suppression window completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.UpdateSuppressionWindow(ctx, session, window)
+}
+
+func (lm *loggingMiddleware) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.String("id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Delete suppression window failed", args...)
+			return
+		}
+		lm.logger.Info("Delete suppression window completed successfully", args...)
+	}(time.Now())
+
+	return lm.service.DeleteSuppressionWindow(ctx, session, id)
+}
@@ -64,6 +64,15 @@ func (mm *metricsMiddleware) ListAlarms(ctx context.Context, session authn.Sessi
 	return mm.service.ListAlarms(ctx, session, pm)
 }
 
+func (mm *metricsMiddleware) AlarmStats(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "alarm_stats").Add(1)
+		mm.latency.With("method", "alarm_stats").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.AlarmStats(ctx, session, pm)
+}
+
 func (mm *metricsMiddleware) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "delete_alarm").Add(1)
@@ -72,3 +81,165 @@ func (mm *metricsMiddleware) DeleteAlarm(ctx context.Context, session authn.Sess
 
 	return mm.service.DeleteAlarm(ctx, session, id)
 }
+
+func (mm *metricsMiddleware) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "shelve_alarm").Add(1)
+		mm.latency.With("method", "shelve_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ShelveAlarm(ctx, session, id, shelve)
+}
+
+func (mm *metricsMiddleware) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unshelve_alarm").Add(1)
+		mm.latency.With("method", "unshelve_alarm").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.UnshelveAlarm(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ClearAlarms(ctx context.Context, alarm alarms.Alarm) ([]alarms.Alarm, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "clear_alarms").Add(1)
+		mm.latency.With("method", "clear_alarms").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ClearAlarms(ctx, alarm)
+}
+
+func (mm *metricsMiddleware) BulkUpdateAlarms(ctx context.Context, session authn.Session, req alarms.BulkRequest) (alarms.BulkResult, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "bulk_update_alarms").Add(1)
+		mm.latency.With("method", "bulk_update_alarms").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.BulkUpdateAlarms(ctx, session, req)
+}
+
+func (mm *metricsMiddleware) AddComment(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (alarms.Activity, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "add_alarm_comment").Add(1)
+		mm.latency.With("method", "add_alarm_comment").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.AddComment(ctx, session, alarmID, comment)
+}
+
+func (mm *metricsMiddleware) ListComments(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_alarm_comments").Add(1)
+		mm.latency.With("method", "list_alarm_comments").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ListComments(ctx, session, alarmID, pm)
+}
+
+func (mm *metricsMiddleware) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_alarm_timeline").Add(1)
+		mm.latency.With("method", "view_alarm_timeline").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.AlarmTimeline(ctx, session, alarmID, pm)
+}
+
+func (mm *metricsMiddleware) StreamAlarms(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (<-chan alarms.Event, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "stream_alarms").Add(1)
+		mm.latency.With("method", "stream_alarms").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.StreamAlarms(ctx, session, filter)
+}
+
+func (mm *metricsMiddleware) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_escalation_policy").Add(1)
+		mm.latency.With("method", "create_escalation_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.CreateEscalationPolicy(ctx, session, policy)
+}
+
+func (mm *metricsMiddleware) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (alarms.EscalationPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_escalation_policy").Add(1)
+		mm.latency.With("method", "view_escalation_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ViewEscalationPolicy(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListEscalationPolicies(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_escalation_policies").Add(1)
+		mm.latency.With("method", "list_escalation_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ListEscalationPolicies(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_escalation_policy").Add(1)
+		mm.latency.With("method", "update_escalation_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.UpdateEscalationPolicy(ctx, session, policy)
+}
+
+func (mm *metricsMiddleware) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_escalation_policy").Add(1)
+		mm.latency.With("method", "delete_escalation_policy").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.DeleteEscalationPolicy(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) CreateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_suppression_window").Add(1)
+		mm.latency.With("method", "create_suppression_window").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.CreateSuppressionWindow(ctx, session, window)
+}
+
+func (mm *metricsMiddleware) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (alarms.SuppressionWindow, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_suppression_window").Add(1)
+		mm.latency.With("method", "view_suppression_window").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ViewSuppressionWindow(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListSuppressionWindows(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_suppression_windows").Add(1)
+		mm.latency.With("method", "list_suppression_windows").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.ListSuppressionWindows(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_suppression_window").Add(1)
+		mm.latency.With("method", "update_suppression_window").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.UpdateSuppressionWindow(ctx, session, window)
+}
+
+func (mm *metricsMiddleware) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "delete_suppression_window").Add(1)
+		mm.latency.With("method", "delete_suppression_window").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.DeleteSuppressionWindow(ctx, session, id)
+}
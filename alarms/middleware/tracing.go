@@ -74,6 +74,17 @@ func (tm *tracingMiddleware) ListAlarms(ctx context.Context, session authn.Sessi
 	return tm.svc.ListAlarms(ctx, session, pm)
 }
 
+func (tm *tracingMiddleware) AlarmStats(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "alarm_stats", trace.WithAttributes(
+		attribute.String("from", pm.From.String()),
+		attribute.String("to", pm.To.String()),
+		attribute.String("interval", pm.Interval.String()),
+	))
+	defer span.End()
+
+	return tm.svc.AlarmStats(ctx, session, pm)
+}
+
 func (tm *tracingMiddleware) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
 	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "delete_alarm", trace.WithAttributes(
 		attribute.String("id", id),
@@ -82,3 +93,185 @@ func (tm *tracingMiddleware) DeleteAlarm(ctx context.Context, session authn.Sess
 
 	return tm.svc.DeleteAlarm(ctx, session, id)
 }
+
+func (tm *tracingMiddleware) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (alarms.Alarm, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "shelve_alarm", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("until", shelve.Until.String()),
+	))
+	defer span.End()
+
+	return tm.svc.ShelveAlarm(ctx, session, id, shelve)
+}
+
+func (tm *tracingMiddleware) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "unshelve_alarm", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.UnshelveAlarm(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) ClearAlarms(ctx context.Context, alarm alarms.Alarm) ([]alarms.Alarm, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "clear_alarms", trace.WithAttributes(
+		attribute.String("rule_id", alarm.RuleID),
+		attribute.String("channel_id", alarm.ChannelID),
+		attribute.String("client_id", alarm.ClientID),
+		attribute.String("measurement", alarm.Measurement),
+	))
+	defer span.End()
+
+	return tm.svc.ClearAlarms(ctx, alarm)
+}
+
+func (tm *tracingMiddleware) BulkUpdateAlarms(ctx context.Context, session authn.Session, req alarms.BulkRequest) (alarms.BulkResult, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "bulk_update_alarms", trace.WithAttributes(
+		attribute.String("action", string(req.Action)),
+		attribute.Int("alarm_ids", len(req.AlarmIDs)),
+	))
+	defer span.End()
+
+	return tm.svc.BulkUpdateAlarms(ctx, session, req)
+}
+
+func (tm *tracingMiddleware) AddComment(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (alarms.Activity, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "add_alarm_comment", trace.WithAttributes(
+		attribute.String("alarm_id", alarmID),
+	))
+	defer span.End()
+
+	return tm.svc.AddComment(ctx, session, alarmID, comment)
+}
+
+func (tm *tracingMiddleware) ListComments(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_alarm_comments", trace.WithAttributes(
+		attribute.String("alarm_id", alarmID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListComments(ctx, session, alarmID, pm)
+}
+
+func (tm *tracingMiddleware) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "view_alarm_timeline", trace.WithAttributes(
+		attribute.String("alarm_id", alarmID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.AlarmTimeline(ctx, session, alarmID, pm)
+}
+
+func (tm *tracingMiddleware) StreamAlarms(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (<-chan alarms.Event, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "stream_alarms", trace.WithAttributes(
+		attribute.String("rule_id", filter.RuleID),
+		attribute.String("channel_id", filter.ChannelID),
+		attribute.String("status", filter.Status.String()),
+		attribute.Int("severity", int(filter.MinSeverity)),
+	))
+	defer span.End()
+
+	return tm.svc.StreamAlarms(ctx, session, filter)
+}
+
+func (tm *tracingMiddleware) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "create_escalation_policy", trace.WithAttributes(
+		attribute.String("name", policy.Name),
+		attribute.Int("steps", len(policy.Steps)),
+	))
+	defer span.End()
+
+	return tm.svc.CreateEscalationPolicy(ctx, session, policy)
+}
+
+func (tm *tracingMiddleware) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (alarms.EscalationPolicy, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "view_escalation_policy", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewEscalationPolicy(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) ListEscalationPolicies(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_escalation_policies", trace.WithAttributes(
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListEscalationPolicies(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "update_escalation_policy", trace.WithAttributes(
+		attribute.String("id", policy.ID),
+		attribute.String("name", policy.Name),
+		attribute.Int("steps", len(policy.Steps)),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateEscalationPolicy(ctx, session, policy)
+}
+
+func (tm *tracingMiddleware) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "delete_escalation_policy", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.DeleteEscalationPolicy(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) CreateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "create_suppression_window", trace.WithAttributes(
+		attribute.String("name", window.Name),
+		attribute.String("channel_id", window.ChannelID),
+		attribute.String("client_id", window.ClientID),
+	))
+	defer span.End()
+
+	return tm.svc.CreateSuppressionWindow(ctx, session, window)
+}
+
+func (tm *tracingMiddleware) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (alarms.SuppressionWindow, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "view_suppression_window", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewSuppressionWindow(ctx, session, id)
+}
+
+func (tm *tracingMiddleware) ListSuppressionWindows(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_suppression_windows", trace.WithAttributes(
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListSuppressionWindows(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "update_suppression_window", trace.WithAttributes(
+		attribute.String("id", window.ID),
+		attribute.String("name", window.Name),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateSuppressionWindow(ctx, session, window)
+}
+
+func (tm *tracingMiddleware) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "delete_suppression_window", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.DeleteSuppressionWindow(ctx, session, id)
+}
@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/alarms"
 	mock "github.com/stretchr/testify/mock"
@@ -42,110 +43,1787 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// AddActivities provides a mock function for the type Repository
+func (_mock *Repository) AddActivities(ctx context.Context, activities []alarms.Activity) error {
+	ret := _mock.Called(ctx, activities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddActivities")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []alarms.Activity) error); ok {
+		r0 = returnFunc(ctx, activities)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AddActivities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddActivities'
+type Repository_AddActivities_Call struct {
+	*mock.Call
+}
+
+// AddActivities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - activities []alarms.Activity
+func (_e *Repository_Expecter) AddActivities(ctx interface{}, activities interface{}) *Repository_AddActivities_Call {
+	return &Repository_AddActivities_Call{Call: _e.mock.On("AddActivities", ctx, activities)}
+}
+
+func (_c *Repository_AddActivities_Call) Run(run func(ctx context.Context, activities []alarms.Activity)) *Repository_AddActivities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []alarms.Activity
+		if args[1] != nil {
+			arg1 = args[1].([]alarms.Activity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddActivities_Call) Return(err error) *Repository_AddActivities_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AddActivities_Call) RunAndReturn(run func(ctx context.Context, activities []alarms.Activity) error) *Repository_AddActivities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlarmStats provides a mock function for the type Repository
+func (_mock *Repository) AlarmStats(ctx context.Context, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmStats")
+	}
+
+	var r0 alarms.Stats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.StatsPageMeta) (alarms.Stats, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.StatsPageMeta) alarms.Stats); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.Stats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.StatsPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_AlarmStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmStats'
+type Repository_AlarmStats_Call struct {
+	*mock.Call
+}
+
+// AlarmStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.StatsPageMeta
+func (_e *Repository_Expecter) AlarmStats(ctx interface{}, pm interface{}) *Repository_AlarmStats_Call {
+	return &Repository_AlarmStats_Call{Call: _e.mock.On("AlarmStats", ctx, pm)}
+}
+
+func (_c *Repository_AlarmStats_Call) Run(run func(ctx context.Context, pm alarms.StatsPageMeta)) *Repository_AlarmStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.StatsPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(alarms.StatsPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AlarmStats_Call) Return(stats alarms.Stats, err error) *Repository_AlarmStats_Call {
+	_c.Call.Return(stats, err)
+	return _c
+}
+
+func (_c *Repository_AlarmStats_Call) RunAndReturn(run func(ctx context.Context, pm alarms.StatsPageMeta) (alarms.Stats, error)) *Repository_AlarmStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlarmTimeline provides a mock function for the type Repository
+func (_mock *Repository) AlarmTimeline(ctx context.Context, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmTimeline")
+	}
+
+	var r0 alarms.ActivitiesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.ActivityPageMeta) alarms.ActivitiesPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.ActivitiesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.ActivityPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_AlarmTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmTimeline'
+type Repository_AlarmTimeline_Call struct {
+	*mock.Call
+}
+
+// AlarmTimeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.ActivityPageMeta
+func (_e *Repository_Expecter) AlarmTimeline(ctx interface{}, pm interface{}) *Repository_AlarmTimeline_Call {
+	return &Repository_AlarmTimeline_Call{Call: _e.mock.On("AlarmTimeline", ctx, pm)}
+}
+
+func (_c *Repository_AlarmTimeline_Call) Run(run func(ctx context.Context, pm alarms.ActivityPageMeta)) *Repository_AlarmTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.ActivityPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(alarms.ActivityPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AlarmTimeline_Call) Return(page alarms.ActivitiesPage, err error) *Repository_AlarmTimeline_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Repository_AlarmTimeline_Call) RunAndReturn(run func(ctx context.Context, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)) *Repository_AlarmTimeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimEscalations provides a mock function for the type Repository
+func (_mock *Repository) ClaimEscalations(ctx context.Context, due time.Time, until time.Time, limit uint64) ([]alarms.Escalation, error) {
+	ret := _mock.Called(ctx, due, until, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimEscalations")
+	}
+
+	var r0 []alarms.Escalation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64) ([]alarms.Escalation, error)); ok {
+		return returnFunc(ctx, due, until, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64) []alarms.Escalation); ok {
+		r0 = returnFunc(ctx, due, until, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Escalation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint64) error); ok {
+		r1 = returnFunc(ctx, due, until, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ClaimEscalations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimEscalations'
+type Repository_ClaimEscalations_Call struct {
+	*mock.Call
+}
+
+// ClaimEscalations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - due time.Time
+//   - until time.Time
+//   - limit uint64
+func (_e *Repository_Expecter) ClaimEscalations(ctx interface{}, due interface{}, until interface{}, limit interface{}) *Repository_ClaimEscalations_Call {
+	return &Repository_ClaimEscalations_Call{Call: _e.mock.On("ClaimEscalations", ctx, due, until, limit)}
+}
+
+func (_c *Repository_ClaimEscalations_Call) Run(run func(ctx context.Context, due time.Time, until time.Time, limit uint64)) *Repository_ClaimEscalations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ClaimEscalations_Call) Return(escs []alarms.Escalation, err error) *Repository_ClaimEscalations_Call {
+	_c.Call.Return(escs, err)
+	return _c
+}
+
+func (_c *Repository_ClaimEscalations_Call) RunAndReturn(run func(ctx context.Context, due time.Time, until time.Time, limit uint64) ([]alarms.Escalation, error)) *Repository_ClaimEscalations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearAlarms provides a mock function for the type Repository
+func (_mock *Repository) ClearAlarms(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) ([]alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm, activities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearAlarms")
+	}
+
+	var r0 []alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) ([]alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm, activities)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) []alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm, activities)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Alarm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) error); ok {
+		r1 = returnFunc(ctx, alarm, activities)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ClearAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearAlarms'
+type Repository_ClearAlarms_Call struct {
+	*mock.Call
+}
+
+// ClearAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+//   - activities alarms.ActivitiesFunc
+func (_e *Repository_Expecter) ClearAlarms(ctx interface{}, alarm interface{}, activities interface{}) *Repository_ClearAlarms_Call {
+	return &Repository_ClearAlarms_Call{Call: _e.mock.On("ClearAlarms", ctx, alarm, activities)}
+}
+
+func (_c *Repository_ClearAlarms_Call) Run(run func(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc)) *Repository_ClearAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		var arg2 alarms.ActivitiesFunc
+		if args[2] != nil {
+			arg2 = args[2].(alarms.ActivitiesFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ClearAlarms_Call) Return(alarms1 []alarms.Alarm, err error) *Repository_ClearAlarms_Call {
+	_c.Call.Return(alarms1, err)
+	return _c
+}
+
+func (_c *Repository_ClearAlarms_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) ([]alarms.Alarm, error)) *Repository_ClearAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountStateChanges provides a mock function for the type Repository
+func (_mock *Repository) CountStateChanges(ctx context.Context, alarm alarms.Alarm, since time.Time) (uint64, error) {
+	ret := _mock.Called(ctx, alarm, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountStateChanges")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, time.Time) (uint64, error)); ok {
+		return returnFunc(ctx, alarm, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, time.Time) uint64); ok {
+		r0 = returnFunc(ctx, alarm, since)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm, time.Time) error); ok {
+		r1 = returnFunc(ctx, alarm, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_CountStateChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountStateChanges'
+type Repository_CountStateChanges_Call struct {
+	*mock.Call
+}
+
+// CountStateChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+//   - since time.Time
+func (_e *Repository_Expecter) CountStateChanges(ctx interface{}, alarm interface{}, since interface{}) *Repository_CountStateChanges_Call {
+	return &Repository_CountStateChanges_Call{Call: _e.mock.On("CountStateChanges", ctx, alarm, since)}
+}
+
+func (_c *Repository_CountStateChanges_Call) Run(run func(ctx context.Context, alarm alarms.Alarm, since time.Time)) *Repository_CountStateChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_CountStateChanges_Call) Return(count uint64, err error) *Repository_CountStateChanges_Call {
+	_c.Call.Return(count, err)
+	return _c
+}
+
+func (_c *Repository_CountStateChanges_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm, since time.Time) (uint64, error)) *Repository_CountStateChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAlarm provides a mock function for the type Repository
-func (_mock *Repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+func (_mock *Repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm, escalations []alarms.Escalation) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm, escalations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAlarm")
+	}
+
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, []alarms.Escalation) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm, escalations)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, []alarms.Escalation) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm, escalations)
+	} else {
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm, []alarms.Escalation) error); ok {
+		r1 = returnFunc(ctx, alarm, escalations)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_CreateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlarm'
+type Repository_CreateAlarm_Call struct {
+	*mock.Call
+}
+
+// CreateAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+//   - escalations []alarms.Escalation
+func (_e *Repository_Expecter) CreateAlarm(ctx interface{}, alarm interface{}, escalations interface{}) *Repository_CreateAlarm_Call {
+	return &Repository_CreateAlarm_Call{Call: _e.mock.On("CreateAlarm", ctx, alarm, escalations)}
+}
+
+func (_c *Repository_CreateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm, escalations []alarms.Escalation)) *Repository_CreateAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		var arg2 []alarms.Escalation
+		if args[2] != nil {
+			arg2 = args[2].([]alarms.Escalation)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_CreateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_CreateAlarm_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Repository_CreateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm, escalations []alarms.Escalation) (alarms.Alarm, error)) *Repository_CreateAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEscalationPolicy provides a mock function for the type Repository
+func (_mock *Repository) CreateEscalationPolicy(ctx context.Context, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEscalationPolicy")
+	}
+
+	var r0 alarms.EscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.EscalationPolicy) (alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.EscalationPolicy) alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, policy)
+	} else {
+		r0 = ret.Get(0).(alarms.EscalationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.EscalationPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_CreateEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEscalationPolicy'
+type Repository_CreateEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// CreateEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy alarms.EscalationPolicy
+func (_e *Repository_Expecter) CreateEscalationPolicy(ctx interface{}, policy interface{}) *Repository_CreateEscalationPolicy_Call {
+	return &Repository_CreateEscalationPolicy_Call{Call: _e.mock.On("CreateEscalationPolicy", ctx, policy)}
+}
+
+func (_c *Repository_CreateEscalationPolicy_Call) Run(run func(ctx context.Context, policy alarms.EscalationPolicy)) *Repository_CreateEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.EscalationPolicy
+		if args[1] != nil {
+			arg1 = args[1].(alarms.EscalationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_CreateEscalationPolicy_Call) Return(p alarms.EscalationPolicy, err error) *Repository_CreateEscalationPolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *Repository_CreateEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error)) *Repository_CreateEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSuppressionWindow provides a mock function for the type Repository
+func (_mock *Repository) CreateSuppressionWindow(ctx context.Context, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	ret := _mock.Called(ctx, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSuppressionWindow")
+	}
+
+	var r0 alarms.SuppressionWindow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.SuppressionWindow) (alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, window)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.SuppressionWindow) alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, window)
+	} else {
+		r0 = ret.Get(0).(alarms.SuppressionWindow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.SuppressionWindow) error); ok {
+		r1 = returnFunc(ctx, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_CreateSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSuppressionWindow'
+type Repository_CreateSuppressionWindow_Call struct {
+	*mock.Call
+}
+
+// CreateSuppressionWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - window alarms.SuppressionWindow
+func (_e *Repository_Expecter) CreateSuppressionWindow(ctx interface{}, window interface{}) *Repository_CreateSuppressionWindow_Call {
+	return &Repository_CreateSuppressionWindow_Call{Call: _e.mock.On("CreateSuppressionWindow", ctx, window)}
+}
+
+func (_c *Repository_CreateSuppressionWindow_Call) Run(run func(ctx context.Context, window alarms.SuppressionWindow)) *Repository_CreateSuppressionWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.SuppressionWindow
+		if args[1] != nil {
+			arg1 = args[1].(alarms.SuppressionWindow)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_CreateSuppressionWindow_Call) Return(w alarms.SuppressionWindow, err error) *Repository_CreateSuppressionWindow_Call {
+	_c.Call.Return(w, err)
+	return _c
+}
+
+func (_c *Repository_CreateSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error)) *Repository_CreateSuppressionWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlarm provides a mock function for the type Repository
+func (_mock *Repository) DeleteAlarm(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlarm")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_DeleteAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarm'
+type Repository_DeleteAlarm_Call struct {
+	*mock.Call
+}
+
+// DeleteAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *Repository_Expecter) DeleteAlarm(ctx interface{}, id interface{}) *Repository_DeleteAlarm_Call {
+	return &Repository_DeleteAlarm_Call{Call: _e.mock.On("DeleteAlarm", ctx, id)}
+}
+
+func (_c *Repository_DeleteAlarm_Call) Run(run func(ctx context.Context, id string)) *Repository_DeleteAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteAlarm_Call) Return(err error) *Repository_DeleteAlarm_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteAlarm_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Repository_DeleteAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlarms provides a mock function for the type Repository
+func (_mock *Repository) DeleteAlarms(ctx context.Context, pm alarms.PageMetadata) ([]alarms.Alarm, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlarms")
+	}
+
+	var r0 []alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) ([]alarms.Alarm, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) []alarms.Alarm); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Alarm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.PageMetadata) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_DeleteAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarms'
+type Repository_DeleteAlarms_Call struct {
+	*mock.Call
+}
+
+// DeleteAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.PageMetadata
+func (_e *Repository_Expecter) DeleteAlarms(ctx interface{}, pm interface{}) *Repository_DeleteAlarms_Call {
+	return &Repository_DeleteAlarms_Call{Call: _e.mock.On("DeleteAlarms", ctx, pm)}
+}
+
+func (_c *Repository_DeleteAlarms_Call) Run(run func(ctx context.Context, pm alarms.PageMetadata)) *Repository_DeleteAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.PageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(alarms.PageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteAlarms_Call) Return(alarms1 []alarms.Alarm, err error) *Repository_DeleteAlarms_Call {
+	_c.Call.Return(alarms1, err)
+	return _c
+}
+
+func (_c *Repository_DeleteAlarms_Call) RunAndReturn(run func(ctx context.Context, pm alarms.PageMetadata) ([]alarms.Alarm, error)) *Repository_DeleteAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEscalationPolicy provides a mock function for the type Repository
+func (_mock *Repository) DeleteEscalationPolicy(ctx context.Context, id string, domainID string) error {
+	ret := _mock.Called(ctx, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEscalationPolicy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_DeleteEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEscalationPolicy'
+type Repository_DeleteEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// DeleteEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+func (_e *Repository_Expecter) DeleteEscalationPolicy(ctx interface{}, id interface{}, domainID interface{}) *Repository_DeleteEscalationPolicy_Call {
+	return &Repository_DeleteEscalationPolicy_Call{Call: _e.mock.On("DeleteEscalationPolicy", ctx, id, domainID)}
+}
+
+func (_c *Repository_DeleteEscalationPolicy_Call) Run(run func(ctx context.Context, id string, domainID string)) *Repository_DeleteEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteEscalationPolicy_Call) Return(err error) *Repository_DeleteEscalationPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string) error) *Repository_DeleteEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSuppressionWindow provides a mock function for the type Repository
+func (_mock *Repository) DeleteSuppressionWindow(ctx context.Context, id string, domainID string) error {
+	ret := _mock.Called(ctx, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuppressionWindow")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, domainID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_DeleteSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuppressionWindow'
+type Repository_DeleteSuppressionWindow_Call struct {
+	*mock.Call
+}
+
+// DeleteSuppressionWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+func (_e *Repository_Expecter) DeleteSuppressionWindow(ctx interface{}, id interface{}, domainID interface{}) *Repository_DeleteSuppressionWindow_Call {
+	return &Repository_DeleteSuppressionWindow_Call{Call: _e.mock.On("DeleteSuppressionWindow", ctx, id, domainID)}
+}
+
+func (_c *Repository_DeleteSuppressionWindow_Call) Run(run func(ctx context.Context, id string, domainID string)) *Repository_DeleteSuppressionWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteSuppressionWindow_Call) Return(err error) *Repository_DeleteSuppressionWindow_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_DeleteSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string) error) *Repository_DeleteSuppressionWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActivities provides a mock function for the type Repository
+func (_mock *Repository) ListActivities(ctx context.Context, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActivities")
+	}
+
+	var r0 alarms.ActivitiesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.ActivityPageMeta) alarms.ActivitiesPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.ActivitiesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.ActivityPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListActivities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActivities'
+type Repository_ListActivities_Call struct {
+	*mock.Call
+}
+
+// ListActivities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.ActivityPageMeta
+func (_e *Repository_Expecter) ListActivities(ctx interface{}, pm interface{}) *Repository_ListActivities_Call {
+	return &Repository_ListActivities_Call{Call: _e.mock.On("ListActivities", ctx, pm)}
+}
+
+func (_c *Repository_ListActivities_Call) Run(run func(ctx context.Context, pm alarms.ActivityPageMeta)) *Repository_ListActivities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.ActivityPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(alarms.ActivityPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListActivities_Call) Return(page alarms.ActivitiesPage, err error) *Repository_ListActivities_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Repository_ListActivities_Call) RunAndReturn(run func(ctx context.Context, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)) *Repository_ListActivities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllAlarms provides a mock function for the type Repository
+func (_mock *Repository) ListAllAlarms(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllAlarms")
+	}
+
+	var r0 alarms.AlarmsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) alarms.AlarmsPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.AlarmsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.PageMetadata) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListAllAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllAlarms'
+type Repository_ListAllAlarms_Call struct {
+	*mock.Call
+}
+
+// ListAllAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.PageMetadata
+func (_e *Repository_Expecter) ListAllAlarms(ctx interface{}, pm interface{}) *Repository_ListAllAlarms_Call {
+	return &Repository_ListAllAlarms_Call{Call: _e.mock.On("ListAllAlarms", ctx, pm)}
+}
+
+func (_c *Repository_ListAllAlarms_Call) Run(run func(ctx context.Context, pm alarms.PageMetadata)) *Repository_ListAllAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.PageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(alarms.PageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListAllAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Repository_ListAllAlarms_Call {
+	_c.Call.Return(alarmsPage, err)
+	return _c
+}
+
+func (_c *Repository_ListAllAlarms_Call) RunAndReturn(run func(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Repository_ListAllAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEscalationPolicies provides a mock function for the type Repository
+func (_mock *Repository) ListEscalationPolicies(ctx context.Context, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEscalationPolicies")
+	}
+
+	var r0 alarms.EscalationPoliciesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.EscalationPolicyPageMeta) alarms.EscalationPoliciesPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.EscalationPoliciesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.EscalationPolicyPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListEscalationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEscalationPolicies'
+type Repository_ListEscalationPolicies_Call struct {
+	*mock.Call
+}
+
+// ListEscalationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.EscalationPolicyPageMeta
+func (_e *Repository_Expecter) ListEscalationPolicies(ctx interface{}, pm interface{}) *Repository_ListEscalationPolicies_Call {
+	return &Repository_ListEscalationPolicies_Call{Call: _e.mock.On("ListEscalationPolicies", ctx, pm)}
+}
+
+func (_c *Repository_ListEscalationPolicies_Call) Run(run func(ctx context.Context, pm alarms.EscalationPolicyPageMeta)) *Repository_ListEscalationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.EscalationPolicyPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(alarms.EscalationPolicyPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListEscalationPolicies_Call) Return(page alarms.EscalationPoliciesPage, err error) *Repository_ListEscalationPolicies_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Repository_ListEscalationPolicies_Call) RunAndReturn(run func(ctx context.Context, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error)) *Repository_ListEscalationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuppressionWindows provides a mock function for the type Repository
+func (_mock *Repository) ListSuppressionWindows(ctx context.Context, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuppressionWindows")
+	}
+
+	var r0 alarms.SuppressionWindowsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.SuppressionWindowPageMeta) alarms.SuppressionWindowsPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.SuppressionWindowsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.SuppressionWindowPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListSuppressionWindows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuppressionWindows'
+type Repository_ListSuppressionWindows_Call struct {
+	*mock.Call
+}
+
+// ListSuppressionWindows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.SuppressionWindowPageMeta
+func (_e *Repository_Expecter) ListSuppressionWindows(ctx interface{}, pm interface{}) *Repository_ListSuppressionWindows_Call {
+	return &Repository_ListSuppressionWindows_Call{Call: _e.mock.On("ListSuppressionWindows", ctx, pm)}
+}
+
+func (_c *Repository_ListSuppressionWindows_Call) Run(run func(ctx context.Context, pm alarms.SuppressionWindowPageMeta)) *Repository_ListSuppressionWindows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.SuppressionWindowPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(alarms.SuppressionWindowPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListSuppressionWindows_Call) Return(page alarms.SuppressionWindowsPage, err error) *Repository_ListSuppressionWindows_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Repository_ListSuppressionWindows_Call) RunAndReturn(run func(ctx context.Context, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error)) *Repository_ListSuppressionWindows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFlapping provides a mock function for the type Repository
+func (_mock *Repository) MarkFlapping(ctx context.Context, alarm alarms.Alarm) error {
+	ret := _mock.Called(ctx, alarm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFlapping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) error); ok {
+		r0 = returnFunc(ctx, alarm)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_MarkFlapping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFlapping'
+type Repository_MarkFlapping_Call struct {
+	*mock.Call
+}
+
+// MarkFlapping is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) MarkFlapping(ctx interface{}, alarm interface{}) *Repository_MarkFlapping_Call {
+	return &Repository_MarkFlapping_Call{Call: _e.mock.On("MarkFlapping", ctx, alarm)}
+}
+
+func (_c *Repository_MarkFlapping_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_MarkFlapping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_MarkFlapping_Call) Return(err error) *Repository_MarkFlapping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_MarkFlapping_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) error) *Repository_MarkFlapping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MatchEscalationPolicies provides a mock function for the type Repository
+func (_mock *Repository) MatchEscalationPolicies(ctx context.Context, alarm alarms.Alarm) ([]alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, alarm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MatchEscalationPolicies")
+	}
+
+	var r0 []alarms.EscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) ([]alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, alarm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) []alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, alarm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.EscalationPolicy)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_MatchEscalationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MatchEscalationPolicies'
+type Repository_MatchEscalationPolicies_Call struct {
+	*mock.Call
+}
+
+// MatchEscalationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) MatchEscalationPolicies(ctx interface{}, alarm interface{}) *Repository_MatchEscalationPolicies_Call {
+	return &Repository_MatchEscalationPolicies_Call{Call: _e.mock.On("MatchEscalationPolicies", ctx, alarm)}
+}
+
+func (_c *Repository_MatchEscalationPolicies_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_MatchEscalationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_MatchEscalationPolicies_Call) Return(policies []alarms.EscalationPolicy, err error) *Repository_MatchEscalationPolicies_Call {
+	_c.Call.Return(policies, err)
+	return _c
+}
+
+func (_c *Repository_MatchEscalationPolicies_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) ([]alarms.EscalationPolicy, error)) *Repository_MatchEscalationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MatchSuppressionWindows provides a mock function for the type Repository
+func (_mock *Repository) MatchSuppressionWindows(ctx context.Context, alarm alarms.Alarm) ([]alarms.SuppressionWindow, error) {
 	ret := _mock.Called(ctx, alarm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAlarm")
+		panic("no return value specified for MatchSuppressionWindows")
+	}
+
+	var r0 []alarms.SuppressionWindow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) ([]alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, alarm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) []alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, alarm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.SuppressionWindow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_MatchSuppressionWindows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MatchSuppressionWindows'
+type Repository_MatchSuppressionWindows_Call struct {
+	*mock.Call
+}
+
+// MatchSuppressionWindows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Repository_Expecter) MatchSuppressionWindows(ctx interface{}, alarm interface{}) *Repository_MatchSuppressionWindows_Call {
+	return &Repository_MatchSuppressionWindows_Call{Call: _e.mock.On("MatchSuppressionWindows", ctx, alarm)}
+}
+
+func (_c *Repository_MatchSuppressionWindows_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_MatchSuppressionWindows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_MatchSuppressionWindows_Call) Return(windows []alarms.SuppressionWindow, err error) *Repository_MatchSuppressionWindows_Call {
+	_c.Call.Return(windows, err)
+	return _c
+}
+
+func (_c *Repository_MatchSuppressionWindows_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) ([]alarms.SuppressionWindow, error)) *Repository_MatchSuppressionWindows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveAlarmEscalations provides a mock function for the type Repository
+func (_mock *Repository) RemoveAlarmEscalations(ctx context.Context, alarmID string) error {
+	ret := _mock.Called(ctx, alarmID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAlarmEscalations")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, alarmID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_RemoveAlarmEscalations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAlarmEscalations'
+type Repository_RemoveAlarmEscalations_Call struct {
+	*mock.Call
+}
+
+// RemoveAlarmEscalations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarmID string
+func (_e *Repository_Expecter) RemoveAlarmEscalations(ctx interface{}, alarmID interface{}) *Repository_RemoveAlarmEscalations_Call {
+	return &Repository_RemoveAlarmEscalations_Call{Call: _e.mock.On("RemoveAlarmEscalations", ctx, alarmID)}
+}
+
+func (_c *Repository_RemoveAlarmEscalations_Call) Run(run func(ctx context.Context, alarmID string)) *Repository_RemoveAlarmEscalations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveAlarmEscalations_Call) Return(err error) *Repository_RemoveAlarmEscalations_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RemoveAlarmEscalations_Call) RunAndReturn(run func(ctx context.Context, alarmID string) error) *Repository_RemoveAlarmEscalations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveEscalation provides a mock function for the type Repository
+func (_mock *Repository) RemoveEscalation(ctx context.Context, alarmID string, policyID string) error {
+	ret := _mock.Called(ctx, alarmID, policyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveEscalation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, alarmID, policyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_RemoveEscalation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveEscalation'
+type Repository_RemoveEscalation_Call struct {
+	*mock.Call
+}
+
+// RemoveEscalation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarmID string
+//   - policyID string
+func (_e *Repository_Expecter) RemoveEscalation(ctx interface{}, alarmID interface{}, policyID interface{}) *Repository_RemoveEscalation_Call {
+	return &Repository_RemoveEscalation_Call{Call: _e.mock.On("RemoveEscalation", ctx, alarmID, policyID)}
+}
+
+func (_c *Repository_RemoveEscalation_Call) Run(run func(ctx context.Context, alarmID string, policyID string)) *Repository_RemoveEscalation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RemoveEscalation_Call) Return(err error) *Repository_RemoveEscalation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_RemoveEscalation_Call) RunAndReturn(run func(ctx context.Context, alarmID string, policyID string) error) *Repository_RemoveEscalation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ShelveAlarm provides a mock function for the type Repository
+func (_mock *Repository) ShelveAlarm(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm, activities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShelveAlarm")
+	}
+
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm, activities)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm, activities)
+	} else {
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) error); ok {
+		r1 = returnFunc(ctx, alarm, activities)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ShelveAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShelveAlarm'
+type Repository_ShelveAlarm_Call struct {
+	*mock.Call
+}
+
+// ShelveAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+//   - activities alarms.ActivitiesFunc
+func (_e *Repository_Expecter) ShelveAlarm(ctx interface{}, alarm interface{}, activities interface{}) *Repository_ShelveAlarm_Call {
+	return &Repository_ShelveAlarm_Call{Call: _e.mock.On("ShelveAlarm", ctx, alarm, activities)}
+}
+
+func (_c *Repository_ShelveAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc)) *Repository_ShelveAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		var arg2 alarms.ActivitiesFunc
+		if args[2] != nil {
+			arg2 = args[2].(alarms.ActivitiesFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ShelveAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_ShelveAlarm_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Repository_ShelveAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) (alarms.Alarm, error)) *Repository_ShelveAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAlarm provides a mock function for the type Repository
+func (_mock *Repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm, activities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAlarm")
+	}
+
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm, activities)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm, activities)
+	} else {
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm, alarms.ActivitiesFunc) error); ok {
+		r1 = returnFunc(ctx, alarm, activities)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_UpdateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarm'
+type Repository_UpdateAlarm_Call struct {
+	*mock.Call
+}
+
+// UpdateAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+//   - activities alarms.ActivitiesFunc
+func (_e *Repository_Expecter) UpdateAlarm(ctx interface{}, alarm interface{}, activities interface{}) *Repository_UpdateAlarm_Call {
+	return &Repository_UpdateAlarm_Call{Call: _e.mock.On("UpdateAlarm", ctx, alarm, activities)}
+}
+
+func (_c *Repository_UpdateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc)) *Repository_UpdateAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		var arg2 alarms.ActivitiesFunc
+		if args[2] != nil {
+			arg2 = args[2].(alarms.ActivitiesFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_UpdateAlarm_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Repository_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) (alarms.Alarm, error)) *Repository_UpdateAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAlarmSeverity provides a mock function for the type Repository
+func (_mock *Repository) UpdateAlarmSeverity(ctx context.Context, id string, severity uint8) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, id, severity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAlarmSeverity")
 	}
 
 	var r0 alarms.Alarm
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
-		return returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint8) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, id, severity)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
-		r0 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint8) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, id, severity)
 	} else {
 		r0 = ret.Get(0).(alarms.Alarm)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
-		r1 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint8) error); ok {
+		r1 = returnFunc(ctx, id, severity)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_CreateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlarm'
-type Repository_CreateAlarm_Call struct {
+// Repository_UpdateAlarmSeverity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarmSeverity'
+type Repository_UpdateAlarmSeverity_Call struct {
 	*mock.Call
 }
 
-// CreateAlarm is a helper method to define mock.On call
+// UpdateAlarmSeverity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alarm alarms.Alarm
-func (_e *Repository_Expecter) CreateAlarm(ctx interface{}, alarm interface{}) *Repository_CreateAlarm_Call {
-	return &Repository_CreateAlarm_Call{Call: _e.mock.On("CreateAlarm", ctx, alarm)}
+//   - id string
+//   - severity uint8
+func (_e *Repository_Expecter) UpdateAlarmSeverity(ctx interface{}, id interface{}, severity interface{}) *Repository_UpdateAlarmSeverity_Call {
+	return &Repository_UpdateAlarmSeverity_Call{Call: _e.mock.On("UpdateAlarmSeverity", ctx, id, severity)}
 }
 
-func (_c *Repository_CreateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_CreateAlarm_Call {
+func (_c *Repository_UpdateAlarmSeverity_Call) Run(run func(ctx context.Context, id string, severity uint8)) *Repository_UpdateAlarmSeverity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.Alarm
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(alarms.Alarm)
+			arg1 = args[1].(string)
+		}
+		var arg2 uint8
+		if args[2] != nil {
+			arg2 = args[2].(uint8)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Repository_CreateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_CreateAlarm_Call {
-	_c.Call.Return(alarm1, err)
+func (_c *Repository_UpdateAlarmSeverity_Call) Return(alarm alarms.Alarm, err error) *Repository_UpdateAlarmSeverity_Call {
+	_c.Call.Return(alarm, err)
 	return _c
 }
 
-func (_c *Repository_CreateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_CreateAlarm_Call {
+func (_c *Repository_UpdateAlarmSeverity_Call) RunAndReturn(run func(ctx context.Context, id string, severity uint8) (alarms.Alarm, error)) *Repository_UpdateAlarmSeverity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAlarm provides a mock function for the type Repository
-func (_mock *Repository) DeleteAlarm(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// UpdateAlarms provides a mock function for the type Repository
+func (_mock *Repository) UpdateAlarms(ctx context.Context, pm alarms.PageMetadata, update alarms.Alarm, activities alarms.ActivitiesFunc) ([]alarms.Alarm, error) {
+	ret := _mock.Called(ctx, pm, update, activities)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAlarm")
+		panic("no return value specified for UpdateAlarms")
+	}
+
+	var r0 []alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata, alarms.Alarm, alarms.ActivitiesFunc) ([]alarms.Alarm, error)); ok {
+		return returnFunc(ctx, pm, update, activities)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata, alarms.Alarm, alarms.ActivitiesFunc) []alarms.Alarm); ok {
+		r0 = returnFunc(ctx, pm, update, activities)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Alarm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.PageMetadata, alarms.Alarm, alarms.ActivitiesFunc) error); ok {
+		r1 = returnFunc(ctx, pm, update, activities)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_UpdateAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarms'
+type Repository_UpdateAlarms_Call struct {
+	*mock.Call
+}
+
+// UpdateAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm alarms.PageMetadata
+//   - update alarms.Alarm
+//   - activities alarms.ActivitiesFunc
+func (_e *Repository_Expecter) UpdateAlarms(ctx interface{}, pm interface{}, update interface{}, activities interface{}) *Repository_UpdateAlarms_Call {
+	return &Repository_UpdateAlarms_Call{Call: _e.mock.On("UpdateAlarms", ctx, pm, update, activities)}
+}
+
+func (_c *Repository_UpdateAlarms_Call) Run(run func(ctx context.Context, pm alarms.PageMetadata, update alarms.Alarm, activities alarms.ActivitiesFunc)) *Repository_UpdateAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.PageMetadata
+		if args[1] != nil {
+			arg1 = args[1].(alarms.PageMetadata)
+		}
+		var arg2 alarms.Alarm
+		if args[2] != nil {
+			arg2 = args[2].(alarms.Alarm)
+		}
+		var arg3 alarms.ActivitiesFunc
+		if args[3] != nil {
+			arg3 = args[3].(alarms.ActivitiesFunc)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateAlarms_Call) Return(alarms1 []alarms.Alarm, err error) *Repository_UpdateAlarms_Call {
+	_c.Call.Return(alarms1, err)
+	return _c
+}
+
+func (_c *Repository_UpdateAlarms_Call) RunAndReturn(run func(ctx context.Context, pm alarms.PageMetadata, update alarms.Alarm, activities alarms.ActivitiesFunc) ([]alarms.Alarm, error)) *Repository_UpdateAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEscalation provides a mock function for the type Repository
+func (_mock *Repository) UpdateEscalation(ctx context.Context, escalation alarms.Escalation) error {
+	ret := _mock.Called(ctx, escalation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEscalation")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Escalation) error); ok {
+		r0 = returnFunc(ctx, escalation)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// Repository_DeleteAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarm'
-type Repository_DeleteAlarm_Call struct {
+// Repository_UpdateEscalation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEscalation'
+type Repository_UpdateEscalation_Call struct {
 	*mock.Call
 }
 
-// DeleteAlarm is a helper method to define mock.On call
+// UpdateEscalation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *Repository_Expecter) DeleteAlarm(ctx interface{}, id interface{}) *Repository_DeleteAlarm_Call {
-	return &Repository_DeleteAlarm_Call{Call: _e.mock.On("DeleteAlarm", ctx, id)}
+//   - escalation alarms.Escalation
+func (_e *Repository_Expecter) UpdateEscalation(ctx interface{}, escalation interface{}) *Repository_UpdateEscalation_Call {
+	return &Repository_UpdateEscalation_Call{Call: _e.mock.On("UpdateEscalation", ctx, escalation)}
 }
 
-func (_c *Repository_DeleteAlarm_Call) Run(run func(ctx context.Context, id string)) *Repository_DeleteAlarm_Call {
+func (_c *Repository_UpdateEscalation_Call) Run(run func(ctx context.Context, escalation alarms.Escalation)) *Repository_UpdateEscalation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 alarms.Escalation
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(alarms.Escalation)
 		}
 		run(
 			arg0,
@@ -155,63 +1833,63 @@ func (_c *Repository_DeleteAlarm_Call) Run(run func(ctx context.Context, id stri
 	return _c
 }
 
-func (_c *Repository_DeleteAlarm_Call) Return(err error) *Repository_DeleteAlarm_Call {
+func (_c *Repository_UpdateEscalation_Call) Return(err error) *Repository_UpdateEscalation_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_DeleteAlarm_Call) RunAndReturn(run func(ctx context.Context, id string) error) *Repository_DeleteAlarm_Call {
+func (_c *Repository_UpdateEscalation_Call) RunAndReturn(run func(ctx context.Context, escalation alarms.Escalation) error) *Repository_UpdateEscalation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAllAlarms provides a mock function for the type Repository
-func (_mock *Repository) ListAllAlarms(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
-	ret := _mock.Called(ctx, pm)
+// UpdateEscalationPolicy provides a mock function for the type Repository
+func (_mock *Repository) UpdateEscalationPolicy(ctx context.Context, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, policy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAllAlarms")
+		panic("no return value specified for UpdateEscalationPolicy")
 	}
 
-	var r0 alarms.AlarmsPage
+	var r0 alarms.EscalationPolicy
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
-		return returnFunc(ctx, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.EscalationPolicy) (alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, policy)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.PageMetadata) alarms.AlarmsPage); ok {
-		r0 = returnFunc(ctx, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.EscalationPolicy) alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, policy)
 	} else {
-		r0 = ret.Get(0).(alarms.AlarmsPage)
+		r0 = ret.Get(0).(alarms.EscalationPolicy)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.PageMetadata) error); ok {
-		r1 = returnFunc(ctx, pm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.EscalationPolicy) error); ok {
+		r1 = returnFunc(ctx, policy)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_ListAllAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllAlarms'
-type Repository_ListAllAlarms_Call struct {
+// Repository_UpdateEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEscalationPolicy'
+type Repository_UpdateEscalationPolicy_Call struct {
 	*mock.Call
 }
 
-// ListAllAlarms is a helper method to define mock.On call
+// UpdateEscalationPolicy is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pm alarms.PageMetadata
-func (_e *Repository_Expecter) ListAllAlarms(ctx interface{}, pm interface{}) *Repository_ListAllAlarms_Call {
-	return &Repository_ListAllAlarms_Call{Call: _e.mock.On("ListAllAlarms", ctx, pm)}
+//   - policy alarms.EscalationPolicy
+func (_e *Repository_Expecter) UpdateEscalationPolicy(ctx interface{}, policy interface{}) *Repository_UpdateEscalationPolicy_Call {
+	return &Repository_UpdateEscalationPolicy_Call{Call: _e.mock.On("UpdateEscalationPolicy", ctx, policy)}
 }
 
-func (_c *Repository_ListAllAlarms_Call) Run(run func(ctx context.Context, pm alarms.PageMetadata)) *Repository_ListAllAlarms_Call {
+func (_c *Repository_UpdateEscalationPolicy_Call) Run(run func(ctx context.Context, policy alarms.EscalationPolicy)) *Repository_UpdateEscalationPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.PageMetadata
+		var arg1 alarms.EscalationPolicy
 		if args[1] != nil {
-			arg1 = args[1].(alarms.PageMetadata)
+			arg1 = args[1].(alarms.EscalationPolicy)
 		}
 		run(
 			arg0,
@@ -221,63 +1899,63 @@ func (_c *Repository_ListAllAlarms_Call) Run(run func(ctx context.Context, pm al
 	return _c
 }
 
-func (_c *Repository_ListAllAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Repository_ListAllAlarms_Call {
-	_c.Call.Return(alarmsPage, err)
+func (_c *Repository_UpdateEscalationPolicy_Call) Return(p alarms.EscalationPolicy, err error) *Repository_UpdateEscalationPolicy_Call {
+	_c.Call.Return(p, err)
 	return _c
 }
 
-func (_c *Repository_ListAllAlarms_Call) RunAndReturn(run func(ctx context.Context, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Repository_ListAllAlarms_Call {
+func (_c *Repository_UpdateEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error)) *Repository_UpdateEscalationPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAlarm provides a mock function for the type Repository
-func (_mock *Repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
-	ret := _mock.Called(ctx, alarm)
+// UpdateSuppressionWindow provides a mock function for the type Repository
+func (_mock *Repository) UpdateSuppressionWindow(ctx context.Context, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	ret := _mock.Called(ctx, window)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAlarm")
+		panic("no return value specified for UpdateSuppressionWindow")
 	}
 
-	var r0 alarms.Alarm
+	var r0 alarms.SuppressionWindow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
-		return returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.SuppressionWindow) (alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, window)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
-		r0 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.SuppressionWindow) alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, window)
 	} else {
-		r0 = ret.Get(0).(alarms.Alarm)
+		r0 = ret.Get(0).(alarms.SuppressionWindow)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
-		r1 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.SuppressionWindow) error); ok {
+		r1 = returnFunc(ctx, window)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Repository_UpdateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarm'
-type Repository_UpdateAlarm_Call struct {
+// Repository_UpdateSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSuppressionWindow'
+type Repository_UpdateSuppressionWindow_Call struct {
 	*mock.Call
 }
 
-// UpdateAlarm is a helper method to define mock.On call
+// UpdateSuppressionWindow is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alarm alarms.Alarm
-func (_e *Repository_Expecter) UpdateAlarm(ctx interface{}, alarm interface{}) *Repository_UpdateAlarm_Call {
-	return &Repository_UpdateAlarm_Call{Call: _e.mock.On("UpdateAlarm", ctx, alarm)}
+//   - window alarms.SuppressionWindow
+func (_e *Repository_Expecter) UpdateSuppressionWindow(ctx interface{}, window interface{}) *Repository_UpdateSuppressionWindow_Call {
+	return &Repository_UpdateSuppressionWindow_Call{Call: _e.mock.On("UpdateSuppressionWindow", ctx, window)}
 }
 
-func (_c *Repository_UpdateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Repository_UpdateAlarm_Call {
+func (_c *Repository_UpdateSuppressionWindow_Call) Run(run func(ctx context.Context, window alarms.SuppressionWindow)) *Repository_UpdateSuppressionWindow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.Alarm
+		var arg1 alarms.SuppressionWindow
 		if args[1] != nil {
-			arg1 = args[1].(alarms.Alarm)
+			arg1 = args[1].(alarms.SuppressionWindow)
 		}
 		run(
 			arg0,
@@ -287,12 +1965,12 @@ func (_c *Repository_UpdateAlarm_Call) Run(run func(ctx context.Context, alarm a
 	return _c
 }
 
-func (_c *Repository_UpdateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Repository_UpdateAlarm_Call {
-	_c.Call.Return(alarm1, err)
+func (_c *Repository_UpdateSuppressionWindow_Call) Return(w alarms.SuppressionWindow, err error) *Repository_UpdateSuppressionWindow_Call {
+	_c.Call.Return(w, err)
 	return _c
 }
 
-func (_c *Repository_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Repository_UpdateAlarm_Call {
+func (_c *Repository_UpdateSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error)) *Repository_UpdateSuppressionWindow_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -368,3 +2046,147 @@ func (_c *Repository_ViewAlarm_Call) RunAndReturn(run func(ctx context.Context,
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewEscalationPolicy provides a mock function for the type Repository
+func (_mock *Repository) ViewEscalationPolicy(ctx context.Context, id string, domainID string) (alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewEscalationPolicy")
+	}
+
+	var r0 alarms.EscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, id, domainID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, id, domainID)
+	} else {
+		r0 = ret.Get(0).(alarms.EscalationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ViewEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewEscalationPolicy'
+type Repository_ViewEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// ViewEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+func (_e *Repository_Expecter) ViewEscalationPolicy(ctx interface{}, id interface{}, domainID interface{}) *Repository_ViewEscalationPolicy_Call {
+	return &Repository_ViewEscalationPolicy_Call{Call: _e.mock.On("ViewEscalationPolicy", ctx, id, domainID)}
+}
+
+func (_c *Repository_ViewEscalationPolicy_Call) Run(run func(ctx context.Context, id string, domainID string)) *Repository_ViewEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ViewEscalationPolicy_Call) Return(p alarms.EscalationPolicy, err error) *Repository_ViewEscalationPolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *Repository_ViewEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string) (alarms.EscalationPolicy, error)) *Repository_ViewEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewSuppressionWindow provides a mock function for the type Repository
+func (_mock *Repository) ViewSuppressionWindow(ctx context.Context, id string, domainID string) (alarms.SuppressionWindow, error) {
+	ret := _mock.Called(ctx, id, domainID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewSuppressionWindow")
+	}
+
+	var r0 alarms.SuppressionWindow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, id, domainID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, id, domainID)
+	} else {
+		r0 = ret.Get(0).(alarms.SuppressionWindow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, domainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ViewSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewSuppressionWindow'
+type Repository_ViewSuppressionWindow_Call struct {
+	*mock.Call
+}
+
+// ViewSuppressionWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+func (_e *Repository_Expecter) ViewSuppressionWindow(ctx interface{}, id interface{}, domainID interface{}) *Repository_ViewSuppressionWindow_Call {
+	return &Repository_ViewSuppressionWindow_Call{Call: _e.mock.On("ViewSuppressionWindow", ctx, id, domainID)}
+}
+
+func (_c *Repository_ViewSuppressionWindow_Call) Run(run func(ctx context.Context, id string, domainID string)) *Repository_ViewSuppressionWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ViewSuppressionWindow_Call) Return(w alarms.SuppressionWindow, err error) *Repository_ViewSuppressionWindow_Call {
+	_c.Call.Return(w, err)
+	return _c
+}
+
+func (_c *Repository_ViewSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string) (alarms.SuppressionWindow, error)) *Repository_ViewSuppressionWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
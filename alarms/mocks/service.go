@@ -43,103 +43,1331 @@ func (_m *Service) EXPECT() *Service_Expecter {
 	return &Service_Expecter{mock: &_m.Mock}
 }
 
+// AddComment provides a mock function for the type Service
+func (_mock *Service) AddComment(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (alarms.Activity, error) {
+	ret := _mock.Called(ctx, session, alarmID, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddComment")
+	}
+
+	var r0 alarms.Activity
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.Comment) (alarms.Activity, error)); ok {
+		return returnFunc(ctx, session, alarmID, comment)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.Comment) alarms.Activity); ok {
+		r0 = returnFunc(ctx, session, alarmID, comment)
+	} else {
+		r0 = ret.Get(0).(alarms.Activity)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, alarms.Comment) error); ok {
+		r1 = returnFunc(ctx, session, alarmID, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AddComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddComment'
+type Service_AddComment_Call struct {
+	*mock.Call
+}
+
+// AddComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - alarmID string
+//   - comment alarms.Comment
+func (_e *Service_Expecter) AddComment(ctx interface{}, session interface{}, alarmID interface{}, comment interface{}) *Service_AddComment_Call {
+	return &Service_AddComment_Call{Call: _e.mock.On("AddComment", ctx, session, alarmID, comment)}
+}
+
+func (_c *Service_AddComment_Call) Run(run func(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment)) *Service_AddComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 alarms.Comment
+		if args[3] != nil {
+			arg3 = args[3].(alarms.Comment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AddComment_Call) Return(activity alarms.Activity, err error) *Service_AddComment_Call {
+	_c.Call.Return(activity, err)
+	return _c
+}
+
+func (_c *Service_AddComment_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, alarmID string, comment alarms.Comment) (alarms.Activity, error)) *Service_AddComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlarmStats provides a mock function for the type Service
+func (_mock *Service) AlarmStats(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmStats")
+	}
+
+	var r0 alarms.Stats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.StatsPageMeta) (alarms.Stats, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.StatsPageMeta) alarms.Stats); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.Stats)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.StatsPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AlarmStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmStats'
+type Service_AlarmStats_Call struct {
+	*mock.Call
+}
+
+// AlarmStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm alarms.StatsPageMeta
+func (_e *Service_Expecter) AlarmStats(ctx interface{}, session interface{}, pm interface{}) *Service_AlarmStats_Call {
+	return &Service_AlarmStats_Call{Call: _e.mock.On("AlarmStats", ctx, session, pm)}
+}
+
+func (_c *Service_AlarmStats_Call) Run(run func(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta)) *Service_AlarmStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.StatsPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(alarms.StatsPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AlarmStats_Call) Return(stats alarms.Stats, err error) *Service_AlarmStats_Call {
+	_c.Call.Return(stats, err)
+	return _c
+}
+
+func (_c *Service_AlarmStats_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm alarms.StatsPageMeta) (alarms.Stats, error)) *Service_AlarmStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AlarmTimeline provides a mock function for the type Service
+func (_mock *Service) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	ret := _mock.Called(ctx, session, alarmID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmTimeline")
+	}
+
+	var r0 alarms.ActivitiesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)); ok {
+		return returnFunc(ctx, session, alarmID, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.ActivityPageMeta) alarms.ActivitiesPage); ok {
+		r0 = returnFunc(ctx, session, alarmID, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.ActivitiesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, alarms.ActivityPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, alarmID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_AlarmTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmTimeline'
+type Service_AlarmTimeline_Call struct {
+	*mock.Call
+}
+
+// AlarmTimeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - alarmID string
+//   - pm alarms.ActivityPageMeta
+func (_e *Service_Expecter) AlarmTimeline(ctx interface{}, session interface{}, alarmID interface{}, pm interface{}) *Service_AlarmTimeline_Call {
+	return &Service_AlarmTimeline_Call{Call: _e.mock.On("AlarmTimeline", ctx, session, alarmID, pm)}
+}
+
+func (_c *Service_AlarmTimeline_Call) Run(run func(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta)) *Service_AlarmTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 alarms.ActivityPageMeta
+		if args[3] != nil {
+			arg3 = args[3].(alarms.ActivityPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_AlarmTimeline_Call) Return(page alarms.ActivitiesPage, err error) *Service_AlarmTimeline_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Service_AlarmTimeline_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)) *Service_AlarmTimeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUpdateAlarms provides a mock function for the type Service
+func (_mock *Service) BulkUpdateAlarms(ctx context.Context, session authn.Session, req alarms.BulkRequest) (alarms.BulkResult, error) {
+	ret := _mock.Called(ctx, session, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUpdateAlarms")
+	}
+
+	var r0 alarms.BulkResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.BulkRequest) (alarms.BulkResult, error)); ok {
+		return returnFunc(ctx, session, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.BulkRequest) alarms.BulkResult); ok {
+		r0 = returnFunc(ctx, session, req)
+	} else {
+		r0 = ret.Get(0).(alarms.BulkResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.BulkRequest) error); ok {
+		r1 = returnFunc(ctx, session, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_BulkUpdateAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpdateAlarms'
+type Service_BulkUpdateAlarms_Call struct {
+	*mock.Call
+}
+
+// BulkUpdateAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - req alarms.BulkRequest
+func (_e *Service_Expecter) BulkUpdateAlarms(ctx interface{}, session interface{}, req interface{}) *Service_BulkUpdateAlarms_Call {
+	return &Service_BulkUpdateAlarms_Call{Call: _e.mock.On("BulkUpdateAlarms", ctx, session, req)}
+}
+
+func (_c *Service_BulkUpdateAlarms_Call) Run(run func(ctx context.Context, session authn.Session, req alarms.BulkRequest)) *Service_BulkUpdateAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.BulkRequest
+		if args[2] != nil {
+			arg2 = args[2].(alarms.BulkRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_BulkUpdateAlarms_Call) Return(bulkResult alarms.BulkResult, err error) *Service_BulkUpdateAlarms_Call {
+	_c.Call.Return(bulkResult, err)
+	return _c
+}
+
+func (_c *Service_BulkUpdateAlarms_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, req alarms.BulkRequest) (alarms.BulkResult, error)) *Service_BulkUpdateAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearAlarms provides a mock function for the type Service
+func (_mock *Service) ClearAlarms(ctx context.Context, alarm alarms.Alarm) ([]alarms.Alarm, error) {
+	ret := _mock.Called(ctx, alarm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearAlarms")
+	}
+
+	var r0 []alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) ([]alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) []alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]alarms.Alarm)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ClearAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearAlarms'
+type Service_ClearAlarms_Call struct {
+	*mock.Call
+}
+
+// ClearAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Service_Expecter) ClearAlarms(ctx interface{}, alarm interface{}) *Service_ClearAlarms_Call {
+	return &Service_ClearAlarms_Call{Call: _e.mock.On("ClearAlarms", ctx, alarm)}
+}
+
+func (_c *Service_ClearAlarms_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Service_ClearAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ClearAlarms_Call) Return(alarms1 []alarms.Alarm, err error) *Service_ClearAlarms_Call {
+	_c.Call.Return(alarms1, err)
+	return _c
+}
+
+func (_c *Service_ClearAlarms_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) ([]alarms.Alarm, error)) *Service_ClearAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAlarm provides a mock function for the type Service
 func (_mock *Service) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
 	ret := _mock.Called(ctx, alarm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateAlarm")
+		panic("no return value specified for CreateAlarm")
+	}
+
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, alarm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, alarm)
+	} else {
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_CreateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlarm'
+type Service_CreateAlarm_Call struct {
+	*mock.Call
+}
+
+// CreateAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarm alarms.Alarm
+func (_e *Service_Expecter) CreateAlarm(ctx interface{}, alarm interface{}) *Service_CreateAlarm_Call {
+	return &Service_CreateAlarm_Call{Call: _e.mock.On("CreateAlarm", ctx, alarm)}
+}
+
+func (_c *Service_CreateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Service_CreateAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 alarms.Alarm
+		if args[1] != nil {
+			arg1 = args[1].(alarms.Alarm)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_CreateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Service_CreateAlarm_Call {
+	_c.Call.Return(alarm1, err)
+	return _c
+}
+
+func (_c *Service_CreateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Service_CreateAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEscalationPolicy provides a mock function for the type Service
+func (_mock *Service) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, session, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEscalationPolicy")
+	}
+
+	var r0 alarms.EscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.EscalationPolicy) (alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, session, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.EscalationPolicy) alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, session, policy)
+	} else {
+		r0 = ret.Get(0).(alarms.EscalationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.EscalationPolicy) error); ok {
+		r1 = returnFunc(ctx, session, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_CreateEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEscalationPolicy'
+type Service_CreateEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// CreateEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - policy alarms.EscalationPolicy
+func (_e *Service_Expecter) CreateEscalationPolicy(ctx interface{}, session interface{}, policy interface{}) *Service_CreateEscalationPolicy_Call {
+	return &Service_CreateEscalationPolicy_Call{Call: _e.mock.On("CreateEscalationPolicy", ctx, session, policy)}
+}
+
+func (_c *Service_CreateEscalationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy)) *Service_CreateEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.EscalationPolicy
+		if args[2] != nil {
+			arg2 = args[2].(alarms.EscalationPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_CreateEscalationPolicy_Call) Return(p alarms.EscalationPolicy, err error) *Service_CreateEscalationPolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *Service_CreateEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error)) *Service_CreateEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSuppressionWindow provides a mock function for the type Service
+func (_mock *Service) CreateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	ret := _mock.Called(ctx, session, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSuppressionWindow")
+	}
+
+	var r0 alarms.SuppressionWindow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.SuppressionWindow) (alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, session, window)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.SuppressionWindow) alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, session, window)
+	} else {
+		r0 = ret.Get(0).(alarms.SuppressionWindow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.SuppressionWindow) error); ok {
+		r1 = returnFunc(ctx, session, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_CreateSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSuppressionWindow'
+type Service_CreateSuppressionWindow_Call struct {
+	*mock.Call
+}
+
+// CreateSuppressionWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - window alarms.SuppressionWindow
+func (_e *Service_Expecter) CreateSuppressionWindow(ctx interface{}, session interface{}, window interface{}) *Service_CreateSuppressionWindow_Call {
+	return &Service_CreateSuppressionWindow_Call{Call: _e.mock.On("CreateSuppressionWindow", ctx, session, window)}
+}
+
+func (_c *Service_CreateSuppressionWindow_Call) Run(run func(ctx context.Context, session authn.Session, window alarms.SuppressionWindow)) *Service_CreateSuppressionWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.SuppressionWindow
+		if args[2] != nil {
+			arg2 = args[2].(alarms.SuppressionWindow)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_CreateSuppressionWindow_Call) Return(w alarms.SuppressionWindow, err error) *Service_CreateSuppressionWindow_Call {
+	_c.Call.Return(w, err)
+	return _c
+}
+
+func (_c *Service_CreateSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error)) *Service_CreateSuppressionWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAlarm provides a mock function for the type Service
+func (_mock *Service) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAlarm")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_DeleteAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarm'
+type Service_DeleteAlarm_Call struct {
+	*mock.Call
+}
+
+// DeleteAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) DeleteAlarm(ctx interface{}, session interface{}, id interface{}) *Service_DeleteAlarm_Call {
+	return &Service_DeleteAlarm_Call{Call: _e.mock.On("DeleteAlarm", ctx, session, id)}
+}
+
+func (_c *Service_DeleteAlarm_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_DeleteAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DeleteAlarm_Call) Return(err error) *Service_DeleteAlarm_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_DeleteAlarm_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_DeleteAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEscalationPolicy provides a mock function for the type Service
+func (_mock *Service) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEscalationPolicy")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_DeleteEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEscalationPolicy'
+type Service_DeleteEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// DeleteEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) DeleteEscalationPolicy(ctx interface{}, session interface{}, id interface{}) *Service_DeleteEscalationPolicy_Call {
+	return &Service_DeleteEscalationPolicy_Call{Call: _e.mock.On("DeleteEscalationPolicy", ctx, session, id)}
+}
+
+func (_c *Service_DeleteEscalationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_DeleteEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DeleteEscalationPolicy_Call) Return(err error) *Service_DeleteEscalationPolicy_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_DeleteEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_DeleteEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSuppressionWindow provides a mock function for the type Service
+func (_mock *Service) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuppressionWindow")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_DeleteSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuppressionWindow'
+type Service_DeleteSuppressionWindow_Call struct {
+	*mock.Call
+}
+
+// DeleteSuppressionWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) DeleteSuppressionWindow(ctx interface{}, session interface{}, id interface{}) *Service_DeleteSuppressionWindow_Call {
+	return &Service_DeleteSuppressionWindow_Call{Call: _e.mock.On("DeleteSuppressionWindow", ctx, session, id)}
+}
+
+func (_c *Service_DeleteSuppressionWindow_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_DeleteSuppressionWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DeleteSuppressionWindow_Call) Return(err error) *Service_DeleteSuppressionWindow_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_DeleteSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_DeleteSuppressionWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAlarms provides a mock function for the type Service
+func (_mock *Service) ListAlarms(ctx context.Context, session authn.Session, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlarms")
+	}
+
+	var r0 alarms.AlarmsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.PageMetadata) alarms.AlarmsPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.AlarmsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.PageMetadata) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlarms'
+type Service_ListAlarms_Call struct {
+	*mock.Call
+}
+
+// ListAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm alarms.PageMetadata
+func (_e *Service_Expecter) ListAlarms(ctx interface{}, session interface{}, pm interface{}) *Service_ListAlarms_Call {
+	return &Service_ListAlarms_Call{Call: _e.mock.On("ListAlarms", ctx, session, pm)}
+}
+
+func (_c *Service_ListAlarms_Call) Run(run func(ctx context.Context, session authn.Session, pm alarms.PageMetadata)) *Service_ListAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(alarms.PageMetadata)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Service_ListAlarms_Call {
+	_c.Call.Return(alarmsPage, err)
+	return _c
+}
+
+func (_c *Service_ListAlarms_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Service_ListAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListComments provides a mock function for the type Service
+func (_mock *Service) ListComments(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	ret := _mock.Called(ctx, session, alarmID, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListComments")
+	}
+
+	var r0 alarms.ActivitiesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)); ok {
+		return returnFunc(ctx, session, alarmID, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.ActivityPageMeta) alarms.ActivitiesPage); ok {
+		r0 = returnFunc(ctx, session, alarmID, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.ActivitiesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, alarms.ActivityPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, alarmID, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListComments'
+type Service_ListComments_Call struct {
+	*mock.Call
+}
+
+// ListComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - alarmID string
+//   - pm alarms.ActivityPageMeta
+func (_e *Service_Expecter) ListComments(ctx interface{}, session interface{}, alarmID interface{}, pm interface{}) *Service_ListComments_Call {
+	return &Service_ListComments_Call{Call: _e.mock.On("ListComments", ctx, session, alarmID, pm)}
+}
+
+func (_c *Service_ListComments_Call) Run(run func(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta)) *Service_ListComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 alarms.ActivityPageMeta
+		if args[3] != nil {
+			arg3 = args[3].(alarms.ActivityPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListComments_Call) Return(page alarms.ActivitiesPage, err error) *Service_ListComments_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Service_ListComments_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, alarmID string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error)) *Service_ListComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEscalationPolicies provides a mock function for the type Service
+func (_mock *Service) ListEscalationPolicies(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEscalationPolicies")
+	}
+
+	var r0 alarms.EscalationPoliciesPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.EscalationPolicyPageMeta) alarms.EscalationPoliciesPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.EscalationPoliciesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.EscalationPolicyPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListEscalationPolicies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEscalationPolicies'
+type Service_ListEscalationPolicies_Call struct {
+	*mock.Call
+}
+
+// ListEscalationPolicies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm alarms.EscalationPolicyPageMeta
+func (_e *Service_Expecter) ListEscalationPolicies(ctx interface{}, session interface{}, pm interface{}) *Service_ListEscalationPolicies_Call {
+	return &Service_ListEscalationPolicies_Call{Call: _e.mock.On("ListEscalationPolicies", ctx, session, pm)}
+}
+
+func (_c *Service_ListEscalationPolicies_Call) Run(run func(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta)) *Service_ListEscalationPolicies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.EscalationPolicyPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(alarms.EscalationPolicyPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListEscalationPolicies_Call) Return(page alarms.EscalationPoliciesPage, err error) *Service_ListEscalationPolicies_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Service_ListEscalationPolicies_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error)) *Service_ListEscalationPolicies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuppressionWindows provides a mock function for the type Service
+func (_mock *Service) ListSuppressionWindows(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuppressionWindows")
+	}
+
+	var r0 alarms.SuppressionWindowsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.SuppressionWindowPageMeta) alarms.SuppressionWindowsPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(alarms.SuppressionWindowsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.SuppressionWindowPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListSuppressionWindows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuppressionWindows'
+type Service_ListSuppressionWindows_Call struct {
+	*mock.Call
+}
+
+// ListSuppressionWindows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm alarms.SuppressionWindowPageMeta
+func (_e *Service_Expecter) ListSuppressionWindows(ctx interface{}, session interface{}, pm interface{}) *Service_ListSuppressionWindows_Call {
+	return &Service_ListSuppressionWindows_Call{Call: _e.mock.On("ListSuppressionWindows", ctx, session, pm)}
+}
+
+func (_c *Service_ListSuppressionWindows_Call) Run(run func(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta)) *Service_ListSuppressionWindows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.SuppressionWindowPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(alarms.SuppressionWindowPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListSuppressionWindows_Call) Return(page alarms.SuppressionWindowsPage, err error) *Service_ListSuppressionWindows_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Service_ListSuppressionWindows_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error)) *Service_ListSuppressionWindows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ShelveAlarm provides a mock function for the type Service
+func (_mock *Service) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, session, id, shelve)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShelveAlarm")
+	}
+
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.Shelve) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, session, id, shelve)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, alarms.Shelve) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, session, id, shelve)
+	} else {
+		r0 = ret.Get(0).(alarms.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, alarms.Shelve) error); ok {
+		r1 = returnFunc(ctx, session, id, shelve)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ShelveAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShelveAlarm'
+type Service_ShelveAlarm_Call struct {
+	*mock.Call
+}
+
+// ShelveAlarm is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+//   - shelve alarms.Shelve
+func (_e *Service_Expecter) ShelveAlarm(ctx interface{}, session interface{}, id interface{}, shelve interface{}) *Service_ShelveAlarm_Call {
+	return &Service_ShelveAlarm_Call{Call: _e.mock.On("ShelveAlarm", ctx, session, id, shelve)}
+}
+
+func (_c *Service_ShelveAlarm_Call) Run(run func(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve)) *Service_ShelveAlarm_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 alarms.Shelve
+		if args[3] != nil {
+			arg3 = args[3].(alarms.Shelve)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ShelveAlarm_Call) Return(alarm alarms.Alarm, err error) *Service_ShelveAlarm_Call {
+	_c.Call.Return(alarm, err)
+	return _c
+}
+
+func (_c *Service_ShelveAlarm_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string, shelve alarms.Shelve) (alarms.Alarm, error)) *Service_ShelveAlarm_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamAlarms provides a mock function for the type Service
+func (_mock *Service) StreamAlarms(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (<-chan alarms.Event, error) {
+	ret := _mock.Called(ctx, session, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamAlarms")
+	}
+
+	var r0 <-chan alarms.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.StreamFilter) (<-chan alarms.Event, error)); ok {
+		return returnFunc(ctx, session, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.StreamFilter) <-chan alarms.Event); ok {
+		r0 = returnFunc(ctx, session, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan alarms.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.StreamFilter) error); ok {
+		r1 = returnFunc(ctx, session, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_StreamAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamAlarms'
+type Service_StreamAlarms_Call struct {
+	*mock.Call
+}
+
+// StreamAlarms is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - filter alarms.StreamFilter
+func (_e *Service_Expecter) StreamAlarms(ctx interface{}, session interface{}, filter interface{}) *Service_StreamAlarms_Call {
+	return &Service_StreamAlarms_Call{Call: _e.mock.On("StreamAlarms", ctx, session, filter)}
+}
+
+func (_c *Service_StreamAlarms_Call) Run(run func(ctx context.Context, session authn.Session, filter alarms.StreamFilter)) *Service_StreamAlarms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 alarms.StreamFilter
+		if args[2] != nil {
+			arg2 = args[2].(alarms.StreamFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StreamAlarms_Call) Return(events <-chan alarms.Event, err error) *Service_StreamAlarms_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *Service_StreamAlarms_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, filter alarms.StreamFilter) (<-chan alarms.Event, error)) *Service_StreamAlarms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnshelveAlarm provides a mock function for the type Service
+func (_mock *Service) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnshelveAlarm")
 	}
 
 	var r0 alarms.Alarm
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) (alarms.Alarm, error)); ok {
-		return returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, session, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, alarms.Alarm) alarms.Alarm); ok {
-		r0 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, session, id)
 	} else {
 		r0 = ret.Get(0).(alarms.Alarm)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, alarms.Alarm) error); ok {
-		r1 = returnFunc(ctx, alarm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Service_CreateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAlarm'
-type Service_CreateAlarm_Call struct {
+// Service_UnshelveAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnshelveAlarm'
+type Service_UnshelveAlarm_Call struct {
 	*mock.Call
 }
 
-// CreateAlarm is a helper method to define mock.On call
+// UnshelveAlarm is a helper method to define mock.On call
 //   - ctx context.Context
-//   - alarm alarms.Alarm
-func (_e *Service_Expecter) CreateAlarm(ctx interface{}, alarm interface{}) *Service_CreateAlarm_Call {
-	return &Service_CreateAlarm_Call{Call: _e.mock.On("CreateAlarm", ctx, alarm)}
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) UnshelveAlarm(ctx interface{}, session interface{}, id interface{}) *Service_UnshelveAlarm_Call {
+	return &Service_UnshelveAlarm_Call{Call: _e.mock.On("UnshelveAlarm", ctx, session, id)}
 }
 
-func (_c *Service_CreateAlarm_Call) Run(run func(ctx context.Context, alarm alarms.Alarm)) *Service_CreateAlarm_Call {
+func (_c *Service_UnshelveAlarm_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_UnshelveAlarm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 alarms.Alarm
+		var arg1 authn.Session
 		if args[1] != nil {
-			arg1 = args[1].(alarms.Alarm)
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *Service_CreateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Service_CreateAlarm_Call {
-	_c.Call.Return(alarm1, err)
+func (_c *Service_UnshelveAlarm_Call) Return(alarm alarms.Alarm, err error) *Service_UnshelveAlarm_Call {
+	_c.Call.Return(alarm, err)
 	return _c
 }
 
-func (_c *Service_CreateAlarm_Call) RunAndReturn(run func(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error)) *Service_CreateAlarm_Call {
+func (_c *Service_UnshelveAlarm_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (alarms.Alarm, error)) *Service_UnshelveAlarm_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAlarm provides a mock function for the type Service
-func (_mock *Service) DeleteAlarm(ctx context.Context, session authn.Session, id string) error {
-	ret := _mock.Called(ctx, session, id)
+// UpdateAlarm provides a mock function for the type Service
+func (_mock *Service) UpdateAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error) {
+	ret := _mock.Called(ctx, session, alarm)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAlarm")
+		panic("no return value specified for UpdateAlarm")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
-		r0 = returnFunc(ctx, session, id)
+	var r0 alarms.Alarm
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.Alarm) (alarms.Alarm, error)); ok {
+		return returnFunc(ctx, session, alarm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.Alarm) alarms.Alarm); ok {
+		r0 = returnFunc(ctx, session, alarm)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(alarms.Alarm)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.Alarm) error); ok {
+		r1 = returnFunc(ctx, session, alarm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// Service_DeleteAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAlarm'
-type Service_DeleteAlarm_Call struct {
+// Service_UpdateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarm'
+type Service_UpdateAlarm_Call struct {
 	*mock.Call
 }
 
-// DeleteAlarm is a helper method to define mock.On call
+// UpdateAlarm is a helper method to define mock.On call
 //   - ctx context.Context
 //   - session authn.Session
-//   - id string
-func (_e *Service_Expecter) DeleteAlarm(ctx interface{}, session interface{}, id interface{}) *Service_DeleteAlarm_Call {
-	return &Service_DeleteAlarm_Call{Call: _e.mock.On("DeleteAlarm", ctx, session, id)}
+//   - alarm alarms.Alarm
+func (_e *Service_Expecter) UpdateAlarm(ctx interface{}, session interface{}, alarm interface{}) *Service_UpdateAlarm_Call {
+	return &Service_UpdateAlarm_Call{Call: _e.mock.On("UpdateAlarm", ctx, session, alarm)}
 }
 
-func (_c *Service_DeleteAlarm_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_DeleteAlarm_Call {
+func (_c *Service_UpdateAlarm_Call) Run(run func(ctx context.Context, session authn.Session, alarm alarms.Alarm)) *Service_UpdateAlarm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -149,9 +1377,9 @@ func (_c *Service_DeleteAlarm_Call) Run(run func(ctx context.Context, session au
 		if args[1] != nil {
 			arg1 = args[1].(authn.Session)
 		}
-		var arg2 string
+		var arg2 alarms.Alarm
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(alarms.Alarm)
 		}
 		run(
 			arg0,
@@ -162,56 +1390,56 @@ func (_c *Service_DeleteAlarm_Call) Run(run func(ctx context.Context, session au
 	return _c
 }
 
-func (_c *Service_DeleteAlarm_Call) Return(err error) *Service_DeleteAlarm_Call {
-	_c.Call.Return(err)
+func (_c *Service_UpdateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Service_UpdateAlarm_Call {
+	_c.Call.Return(alarm1, err)
 	return _c
 }
 
-func (_c *Service_DeleteAlarm_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) error) *Service_DeleteAlarm_Call {
+func (_c *Service_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error)) *Service_UpdateAlarm_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAlarms provides a mock function for the type Service
-func (_mock *Service) ListAlarms(ctx context.Context, session authn.Session, pm alarms.PageMetadata) (alarms.AlarmsPage, error) {
-	ret := _mock.Called(ctx, session, pm)
+// UpdateEscalationPolicy provides a mock function for the type Service
+func (_mock *Service) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, session, policy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAlarms")
+		panic("no return value specified for UpdateEscalationPolicy")
 	}
 
-	var r0 alarms.AlarmsPage
+	var r0 alarms.EscalationPolicy
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.PageMetadata) (alarms.AlarmsPage, error)); ok {
-		return returnFunc(ctx, session, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.EscalationPolicy) (alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, session, policy)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.PageMetadata) alarms.AlarmsPage); ok {
-		r0 = returnFunc(ctx, session, pm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.EscalationPolicy) alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, session, policy)
 	} else {
-		r0 = ret.Get(0).(alarms.AlarmsPage)
+		r0 = ret.Get(0).(alarms.EscalationPolicy)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.PageMetadata) error); ok {
-		r1 = returnFunc(ctx, session, pm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.EscalationPolicy) error); ok {
+		r1 = returnFunc(ctx, session, policy)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Service_ListAlarms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlarms'
-type Service_ListAlarms_Call struct {
+// Service_UpdateEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEscalationPolicy'
+type Service_UpdateEscalationPolicy_Call struct {
 	*mock.Call
 }
 
-// ListAlarms is a helper method to define mock.On call
+// UpdateEscalationPolicy is a helper method to define mock.On call
 //   - ctx context.Context
 //   - session authn.Session
-//   - pm alarms.PageMetadata
-func (_e *Service_Expecter) ListAlarms(ctx interface{}, session interface{}, pm interface{}) *Service_ListAlarms_Call {
-	return &Service_ListAlarms_Call{Call: _e.mock.On("ListAlarms", ctx, session, pm)}
+//   - policy alarms.EscalationPolicy
+func (_e *Service_Expecter) UpdateEscalationPolicy(ctx interface{}, session interface{}, policy interface{}) *Service_UpdateEscalationPolicy_Call {
+	return &Service_UpdateEscalationPolicy_Call{Call: _e.mock.On("UpdateEscalationPolicy", ctx, session, policy)}
 }
 
-func (_c *Service_ListAlarms_Call) Run(run func(ctx context.Context, session authn.Session, pm alarms.PageMetadata)) *Service_ListAlarms_Call {
+func (_c *Service_UpdateEscalationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy)) *Service_UpdateEscalationPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -221,9 +1449,9 @@ func (_c *Service_ListAlarms_Call) Run(run func(ctx context.Context, session aut
 		if args[1] != nil {
 			arg1 = args[1].(authn.Session)
 		}
-		var arg2 alarms.PageMetadata
+		var arg2 alarms.EscalationPolicy
 		if args[2] != nil {
-			arg2 = args[2].(alarms.PageMetadata)
+			arg2 = args[2].(alarms.EscalationPolicy)
 		}
 		run(
 			arg0,
@@ -234,56 +1462,56 @@ func (_c *Service_ListAlarms_Call) Run(run func(ctx context.Context, session aut
 	return _c
 }
 
-func (_c *Service_ListAlarms_Call) Return(alarmsPage alarms.AlarmsPage, err error) *Service_ListAlarms_Call {
-	_c.Call.Return(alarmsPage, err)
+func (_c *Service_UpdateEscalationPolicy_Call) Return(p alarms.EscalationPolicy, err error) *Service_UpdateEscalationPolicy_Call {
+	_c.Call.Return(p, err)
 	return _c
 }
 
-func (_c *Service_ListAlarms_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm alarms.PageMetadata) (alarms.AlarmsPage, error)) *Service_ListAlarms_Call {
+func (_c *Service_UpdateEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error)) *Service_UpdateEscalationPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAlarm provides a mock function for the type Service
-func (_mock *Service) UpdateAlarm(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error) {
-	ret := _mock.Called(ctx, session, alarm)
+// UpdateSuppressionWindow provides a mock function for the type Service
+func (_mock *Service) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	ret := _mock.Called(ctx, session, window)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAlarm")
+		panic("no return value specified for UpdateSuppressionWindow")
 	}
 
-	var r0 alarms.Alarm
+	var r0 alarms.SuppressionWindow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.Alarm) (alarms.Alarm, error)); ok {
-		return returnFunc(ctx, session, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.SuppressionWindow) (alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, session, window)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.Alarm) alarms.Alarm); ok {
-		r0 = returnFunc(ctx, session, alarm)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, alarms.SuppressionWindow) alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, session, window)
 	} else {
-		r0 = ret.Get(0).(alarms.Alarm)
+		r0 = ret.Get(0).(alarms.SuppressionWindow)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.Alarm) error); ok {
-		r1 = returnFunc(ctx, session, alarm)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, alarms.SuppressionWindow) error); ok {
+		r1 = returnFunc(ctx, session, window)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// Service_UpdateAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAlarm'
-type Service_UpdateAlarm_Call struct {
+// Service_UpdateSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSuppressionWindow'
+type Service_UpdateSuppressionWindow_Call struct {
 	*mock.Call
 }
 
-// UpdateAlarm is a helper method to define mock.On call
+// UpdateSuppressionWindow is a helper method to define mock.On call
 //   - ctx context.Context
 //   - session authn.Session
-//   - alarm alarms.Alarm
-func (_e *Service_Expecter) UpdateAlarm(ctx interface{}, session interface{}, alarm interface{}) *Service_UpdateAlarm_Call {
-	return &Service_UpdateAlarm_Call{Call: _e.mock.On("UpdateAlarm", ctx, session, alarm)}
+//   - window alarms.SuppressionWindow
+func (_e *Service_Expecter) UpdateSuppressionWindow(ctx interface{}, session interface{}, window interface{}) *Service_UpdateSuppressionWindow_Call {
+	return &Service_UpdateSuppressionWindow_Call{Call: _e.mock.On("UpdateSuppressionWindow", ctx, session, window)}
 }
 
-func (_c *Service_UpdateAlarm_Call) Run(run func(ctx context.Context, session authn.Session, alarm alarms.Alarm)) *Service_UpdateAlarm_Call {
+func (_c *Service_UpdateSuppressionWindow_Call) Run(run func(ctx context.Context, session authn.Session, window alarms.SuppressionWindow)) *Service_UpdateSuppressionWindow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -293,9 +1521,9 @@ func (_c *Service_UpdateAlarm_Call) Run(run func(ctx context.Context, session au
 		if args[1] != nil {
 			arg1 = args[1].(authn.Session)
 		}
-		var arg2 alarms.Alarm
+		var arg2 alarms.SuppressionWindow
 		if args[2] != nil {
-			arg2 = args[2].(alarms.Alarm)
+			arg2 = args[2].(alarms.SuppressionWindow)
 		}
 		run(
 			arg0,
@@ -306,12 +1534,12 @@ func (_c *Service_UpdateAlarm_Call) Run(run func(ctx context.Context, session au
 	return _c
 }
 
-func (_c *Service_UpdateAlarm_Call) Return(alarm1 alarms.Alarm, err error) *Service_UpdateAlarm_Call {
-	_c.Call.Return(alarm1, err)
+func (_c *Service_UpdateSuppressionWindow_Call) Return(w alarms.SuppressionWindow, err error) *Service_UpdateSuppressionWindow_Call {
+	_c.Call.Return(w, err)
 	return _c
 }
 
-func (_c *Service_UpdateAlarm_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, alarm alarms.Alarm) (alarms.Alarm, error)) *Service_UpdateAlarm_Call {
+func (_c *Service_UpdateSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error)) *Service_UpdateSuppressionWindow_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -387,3 +1615,147 @@ func (_c *Service_ViewAlarm_Call) RunAndReturn(run func(ctx context.Context, ses
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewEscalationPolicy provides a mock function for the type Service
+func (_mock *Service) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (alarms.EscalationPolicy, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewEscalationPolicy")
+	}
+
+	var r0 alarms.EscalationPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (alarms.EscalationPolicy, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) alarms.EscalationPolicy); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(alarms.EscalationPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewEscalationPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewEscalationPolicy'
+type Service_ViewEscalationPolicy_Call struct {
+	*mock.Call
+}
+
+// ViewEscalationPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) ViewEscalationPolicy(ctx interface{}, session interface{}, id interface{}) *Service_ViewEscalationPolicy_Call {
+	return &Service_ViewEscalationPolicy_Call{Call: _e.mock.On("ViewEscalationPolicy", ctx, session, id)}
+}
+
+func (_c *Service_ViewEscalationPolicy_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_ViewEscalationPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewEscalationPolicy_Call) Return(p alarms.EscalationPolicy, err error) *Service_ViewEscalationPolicy_Call {
+	_c.Call.Return(p, err)
+	return _c
+}
+
+func (_c *Service_ViewEscalationPolicy_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (alarms.EscalationPolicy, error)) *Service_ViewEscalationPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewSuppressionWindow provides a mock function for the type Service
+func (_mock *Service) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (alarms.SuppressionWindow, error) {
+	ret := _mock.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewSuppressionWindow")
+	}
+
+	var r0 alarms.SuppressionWindow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) (alarms.SuppressionWindow, error)); ok {
+		return returnFunc(ctx, session, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string) alarms.SuppressionWindow); ok {
+		r0 = returnFunc(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(alarms.SuppressionWindow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = returnFunc(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewSuppressionWindow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewSuppressionWindow'
+type Service_ViewSuppressionWindow_Call struct {
+	*mock.Call
+}
+
+// ViewSuppressionWindow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - id string
+func (_e *Service_Expecter) ViewSuppressionWindow(ctx interface{}, session interface{}, id interface{}) *Service_ViewSuppressionWindow_Call {
+	return &Service_ViewSuppressionWindow_Call{Call: _e.mock.On("ViewSuppressionWindow", ctx, session, id)}
+}
+
+func (_c *Service_ViewSuppressionWindow_Call) Run(run func(ctx context.Context, session authn.Session, id string)) *Service_ViewSuppressionWindow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewSuppressionWindow_Call) Return(w alarms.SuppressionWindow, err error) *Service_ViewSuppressionWindow_Call {
+	_c.Call.Return(w, err)
+	return _c
+}
+
+func (_c *Service_ViewSuppressionWindow_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, id string) (alarms.SuppressionWindow, error)) *Service_ViewSuppressionWindow_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notifiers contains the notifier used to send alarm escalation
+// notifications by email, SMS and webhook.
+package notifiers
@@ -0,0 +1,111 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/emailer"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const (
+	subjectTemplate = "Alarm escalation: %s on channel %s"
+	contentTemplate = "Alarm %s raised by rule %s was not acknowledged.\n" +
+		"Measurement: %s\nValue: %s %s\nThreshold: %s\nCause: %s\nSeverity: %d\nCreated at: %s"
+	footer = "Sent by Magistrala Alarms"
+)
+
+var (
+	errEmailNotConfigured = errors.New("email notifications are not configured")
+	errSMSNotConfigured   = errors.New("sms notifications are not configured")
+	errUnsupportedContact = errors.New("unsupported contact type")
+	errWebhookStatus      = errors.New("unexpected webhook response status")
+)
+
+// Config contains the notifier configuration.
+type Config struct {
+	SMSFrom        string        `env:"MG_ALARMS_SMS_FROM"        envDefault:""`
+	WebhookTimeout time.Duration `env:"MG_ALARMS_WEBHOOK_TIMEOUT" envDefault:"10s"`
+}
+
+var _ alarms.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	emailer emailer.Emailer
+	sms     consumers.Notifier
+	smsFrom string
+	client  *http.Client
+}
+
+// New returns the escalation notifier. Email and SMS notifications fail
+// if the emailer or the SMS notifier are not provided.
+func New(cfg Config, e emailer.Emailer, sms consumers.Notifier) alarms.Notifier {
+	return &notifier{
+		emailer: e,
+		sms:     sms,
+		smsFrom: cfg.SMSFrom,
+		client:  &http.Client{Timeout: cfg.WebhookTimeout},
+	}
+}
+
+func (n *notifier) Notify(ctx context.Context, contact alarms.Contact, alarm alarms.Alarm) error {
+	switch contact.Type {
+	case alarms.EmailContact:
+		if n.emailer == nil {
+			return errEmailNotConfigured
+		}
+		subject := fmt.Sprintf(subjectTemplate, alarm.Measurement, alarm.ChannelID)
+		return n.emailer.SendEmailNotification([]string{contact.Address}, "", subject, "", "", content(alarm), footer, map[string][]byte{})
+	case alarms.SMSContact:
+		if n.sms == nil {
+			return errSMSNotConfigured
+		}
+		msg := &messaging.Message{
+			Domain:  alarm.DomainID,
+			Channel: alarm.ChannelID,
+			Payload: []byte(content(alarm)),
+			Created: time.Now().UnixNano(),
+		}
+		return n.sms.Notify(ctx, n.smsFrom, []string{contact.Address}, msg)
+	case alarms.WebhookContact:
+		return n.post(ctx, contact.Address, alarm)
+	default:
+		return errors.Wrap(errUnsupportedContact, fmt.Errorf("%s", contact.Type))
+	}
+}
+
+func (n *notifier) post(ctx context.Context, url string, alarm alarms.Alarm) error {
+	body, err := json.Marshal(alarm)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return errors.Wrap(errWebhookStatus, fmt.Errorf("%s", res.Status))
+	}
+
+	return nil
+}
+
+func content(a alarms.Alarm) string {
+	return fmt.Sprintf(contentTemplate, a.ID, a.RuleID, a.Measurement, a.Value, a.Unit, a.Threshold, a.Cause, a.Severity, a.CreatedAt.Format(time.RFC3339))
+}
@@ -0,0 +1,184 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/notifiers"
+	cmocks "github.com/absmach/magistrala/consumers/mocks"
+	emocks "github.com/absmach/magistrala/pkg/emailer/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	alarm = alarms.Alarm{
+		ID:          "alarm-id",
+		RuleID:      "rule-id",
+		DomainID:    "domain-id",
+		ChannelID:   "channel-id",
+		Measurement: "temperature",
+		Value:       "42",
+		Unit:        "C",
+		Cause:       "temperature above threshold",
+		Severity:    80,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	errSend = errors.New("failed to send")
+)
+
+func TestNotifyEmail(t *testing.T) {
+	em := new(emocks.Emailer)
+	n := notifiers.New(notifiers.Config{}, em, nil)
+	contact := alarms.Contact{Type: alarms.EmailContact, Address: "oncall@example.com"}
+
+	cases := []struct {
+		desc    string
+		sendErr error
+		err     error
+	}{
+		{
+			desc: "notify email contact successfully",
+		},
+		{
+			desc:    "notify email contact with failed email",
+			sendErr: errSend,
+			err:     errSend,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			emCall := em.On("SendEmailNotification", []string{contact.Address}, "", mock.Anything, "", "", mock.Anything, mock.Anything, mock.Anything).Return(tc.sendErr)
+			err := n.Notify(context.Background(), contact, alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			emCall.Unset()
+		})
+	}
+}
+
+func TestNotifySMS(t *testing.T) {
+	sms := new(cmocks.Notifier)
+	n := notifiers.New(notifiers.Config{SMSFrom: "magistrala"}, nil, sms)
+	contact := alarms.Contact{Type: alarms.SMSContact, Address: "+38111222333"}
+
+	cases := []struct {
+		desc    string
+		sendErr error
+		err     error
+	}{
+		{
+			desc: "notify sms contact successfully",
+		},
+		{
+			desc:    "notify sms contact with failed sms",
+			sendErr: errSend,
+			err:     errSend,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var msg *messaging.Message
+			smsCall := sms.On("Notify", mock.Anything, "magistrala", []string{contact.Address}, mock.Anything).Run(func(args mock.Arguments) {
+				msg = args.Get(3).(*messaging.Message)
+			}).Return(tc.sendErr)
+			err := n.Notify(context.Background(), contact, alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, alarm.ChannelID, msg.Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, alarm.ChannelID, msg.Channel))
+			assert.NotEmpty(t, msg.Payload, fmt.Sprintf("%s: expected message payload", tc.desc))
+			smsCall.Unset()
+		})
+	}
+}
+
+func TestNotifyWebhook(t *testing.T) {
+	var received alarms.Alarm
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	n := notifiers.New(notifiers.Config{WebhookTimeout: time.Second}, nil, nil)
+
+	cases := []struct {
+		desc    string
+		address string
+		status  int
+		err     bool
+	}{
+		{
+			desc:    "notify webhook contact successfully",
+			address: ts.URL,
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "notify webhook contact with error response",
+			address: ts.URL,
+			status:  http.StatusInternalServerError,
+			err:     true,
+		},
+		{
+			desc:    "notify unreachable webhook contact",
+			address: "http://127.0.0.1:0",
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			received = alarms.Alarm{}
+			status = tc.status
+			err := n.Notify(context.Background(), alarms.Contact{Type: alarms.WebhookContact, Address: tc.address}, alarm)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+			if tc.address == ts.URL {
+				assert.Equal(t, alarm.ID, received.ID, fmt.Sprintf("%s: expected alarm %s got %s\n", tc.desc, alarm.ID, received.ID))
+			}
+		})
+	}
+}
+
+func TestNotifyNotConfigured(t *testing.T) {
+	n := notifiers.New(notifiers.Config{}, nil, nil)
+
+	cases := []struct {
+		desc    string
+		contact alarms.Contact
+	}{
+		{
+			desc:    "notify email contact without emailer",
+			contact: alarms.Contact{Type: alarms.EmailContact, Address: "oncall@example.com"},
+		},
+		{
+			desc:    "notify sms contact without sms notifier",
+			contact: alarms.Contact{Type: alarms.SMSContact, Address: "+38111222333"},
+		},
+		{
+			desc:    "notify contact of unsupported type",
+			contact: alarms.Contact{Type: "pager", Address: "1234"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := n.Notify(context.Background(), tc.contact, alarm)
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error got nil", tc.desc))
+		})
+	}
+}
@@ -16,6 +16,23 @@ const (
 	OpAcknowledgeAlarm
 	OpResolveAlarm
 	OpUpdateAlarm
+	OpCreateEscalationPolicy
+	OpListEscalationPolicies
+	OpViewEscalationPolicy
+	OpUpdateEscalationPolicy
+	OpDeleteEscalationPolicy
+	OpStreamAlarms
+	OpCreateSuppressionWindow
+	OpListSuppressionWindows
+	OpViewSuppressionWindow
+	OpUpdateSuppressionWindow
+	OpDeleteSuppressionWindow
+	OpAlarmStats
+	OpAddAlarmComment
+	OpListAlarmComments
+	OpViewAlarmTimeline
+	OpShelveAlarm
+	OpUnshelveAlarm
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -48,5 +65,73 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "update",
 			PermissionRequired: true,
 		},
+		OpCreateEscalationPolicy: {
+			Name:               "create_escalation_policy",
+			PermissionRequired: true,
+		},
+		OpListEscalationPolicies: {
+			Name:               "list_escalation_policies",
+			PermissionRequired: true,
+		},
+		OpViewEscalationPolicy: {
+			Name:               "view_escalation_policy",
+			PermissionRequired: true,
+		},
+		OpUpdateEscalationPolicy: {
+			Name:               "update_escalation_policy",
+			PermissionRequired: true,
+		},
+		OpDeleteEscalationPolicy: {
+			Name:               "delete_escalation_policy",
+			PermissionRequired: true,
+		},
+		OpStreamAlarms: {
+			Name:               "stream",
+			PermissionRequired: true,
+		},
+		OpCreateSuppressionWindow: {
+			Name:               "create_suppression_window",
+			PermissionRequired: true,
+		},
+		OpListSuppressionWindows: {
+			Name:               "list_suppression_windows",
+			PermissionRequired: true,
+		},
+		OpViewSuppressionWindow: {
+			Name:               "view_suppression_window",
+			PermissionRequired: true,
+		},
+		OpUpdateSuppressionWindow: {
+			Name:               "update_suppression_window",
+			PermissionRequired: true,
+		},
+		OpDeleteSuppressionWindow: {
+			Name:               "delete_suppression_window",
+			PermissionRequired: true,
+		},
+		OpAlarmStats: {
+			Name:               "stats",
+			PermissionRequired: true,
+		},
+		OpAddAlarmComment: {
+			Name:               "add_comment",
+			PermissionRequired: true,
+		},
+		OpListAlarmComments: {
+			Name:               "list_comments",
+			PermissionRequired: true,
+		},
+		OpViewAlarmTimeline: {
+			Name:               "view_timeline",
+			PermissionRequired: true,
+		},
+		OpShelveAlarm: {
+			Name:               "shelve",
+			PermissionRequired: true,
+		},
+		OpUnshelveAlarm: {
+			Name:               "unshelve",
+			PermissionRequired: true,
+		},
 	}
 }
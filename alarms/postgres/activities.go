@@ -0,0 +1,187 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+const activityColumns = `id, alarm_id, domain_id, type, content, links, details, created_at, created_by`
+
+func (r *repository) AddActivities(ctx context.Context, activities []alarms.Activity) error {
+	return insertActivities(ctx, r.db, activities)
+}
+
+// recordActivities inserts the activities of the changed alarms in the
+// transaction of the change.
+func recordActivities(ctx context.Context, tx *sqlx.Tx, changed []alarms.Alarm, activities alarms.ActivitiesFunc) error {
+	if activities == nil || len(changed) == 0 {
+		return nil
+	}
+	acts, err := activities(changed)
+	if err != nil {
+		return err
+	}
+
+	return insertActivities(ctx, tx, acts)
+}
+
+func insertActivities(ctx context.Context, db sqlx.ExtContext, activities []alarms.Activity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+	dbas := make([]dbActivity, len(activities))
+	for i, a := range activities {
+		dba, err := toDBActivity(a)
+		if err != nil {
+			return errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+		dbas[i] = dba
+	}
+
+	q := `INSERT INTO alarm_activities (` + activityColumns + `)
+		VALUES (:id, :alarm_id, :domain_id, :type, :content, :links, :details, :created_at, :created_by);`
+	if _, err := sqlx.NamedExecContext(ctx, db, q, dbas); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (r *repository) ListActivities(ctx context.Context, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	where := "alarm_id = :alarm_id AND domain_id = :domain_id"
+	if pm.Type != "" {
+		where += " AND type = :type"
+	}
+	query := fmt.Sprintf(`SELECT %s FROM alarm_activities WHERE %s`, activityColumns, where)
+
+	return r.activitiesPage(ctx, query, pm)
+}
+
+func (r *repository) AlarmTimeline(ctx context.Context, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	// The creation of the alarm is derived from the alarm itself, so the
+	// timeline starts with it even though it is not in the activity log.
+	query := fmt.Sprintf(`SELECT %s FROM alarm_activities WHERE alarm_id = :alarm_id AND domain_id = :domain_id
+		UNION ALL
+		SELECT id, id, domain_id, '%s', '', NULL,
+			jsonb_build_object('severity', severity, 'measurement', measurement, 'value', value,
+				'unit', unit, 'threshold', threshold, 'cause', cause),
+			created_at, ''
+		FROM alarms WHERE id = :alarm_id AND domain_id = :domain_id`, activityColumns, alarms.CreateActivity)
+
+	return r.activitiesPage(ctx, query, pm)
+}
+
+// activitiesPage returns a page of the activities selected by the query in
+// chronological order. The creation of an alarm precedes its activities.
+func (r *repository) activitiesPage(ctx context.Context, query string, pm alarms.ActivityPageMeta) (alarms.ActivitiesPage, error) {
+	q := fmt.Sprintf(`SELECT * FROM (%s) AS activities
+		ORDER BY created_at, type <> '%s', id LIMIT :limit OFFSET :offset;`, query, alarms.CreateActivity)
+	rows, err := r.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return alarms.ActivitiesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	activities := []alarms.Activity{}
+	for rows.Next() {
+		var dba dbActivity
+		if err := rows.StructScan(&dba); err != nil {
+			return alarms.ActivitiesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		a, err := toActivity(dba)
+		if err != nil {
+			return alarms.ActivitiesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		activities = append(activities, a)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM (%s) AS activities;`, query)
+	total, err := postgres.Total(ctx, r.db, cq, pm)
+	if err != nil {
+		return alarms.ActivitiesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return alarms.ActivitiesPage{
+		Total:      total,
+		Offset:     pm.Offset,
+		Limit:      pm.Limit,
+		Activities: activities,
+	}, nil
+}
+
+type dbActivity struct {
+	ID        string    `db:"id"`
+	AlarmID   string    `db:"alarm_id"`
+	DomainID  string    `db:"domain_id"`
+	Type      string    `db:"type"`
+	Content   string    `db:"content"`
+	Links     []byte    `db:"links"`
+	Details   []byte    `db:"details"`
+	CreatedAt time.Time `db:"created_at"`
+	CreatedBy string    `db:"created_by"`
+}
+
+func toDBActivity(a alarms.Activity) (dbActivity, error) {
+	var links, details []byte
+	if len(a.Links) > 0 {
+		b, err := json.Marshal(a.Links)
+		if err != nil {
+			return dbActivity{}, err
+		}
+		links = b
+	}
+	if len(a.Details) > 0 {
+		b, err := json.Marshal(a.Details)
+		if err != nil {
+			return dbActivity{}, err
+		}
+		details = b
+	}
+
+	return dbActivity{
+		ID:        a.ID,
+		AlarmID:   a.AlarmID,
+		DomainID:  a.DomainID,
+		Type:      string(a.Type),
+		Content:   a.Content,
+		Links:     links,
+		Details:   details,
+		CreatedAt: a.CreatedAt,
+		CreatedBy: a.CreatedBy,
+	}, nil
+}
+
+func toActivity(dba dbActivity) (alarms.Activity, error) {
+	a := alarms.Activity{
+		ID:        dba.ID,
+		AlarmID:   dba.AlarmID,
+		DomainID:  dba.DomainID,
+		Type:      alarms.ActivityType(dba.Type),
+		Content:   dba.Content,
+		CreatedAt: dba.CreatedAt.UTC(),
+		CreatedBy: dba.CreatedBy,
+	}
+	if len(dba.Links) > 0 {
+		if err := json.Unmarshal(dba.Links, &a.Links); err != nil {
+			return alarms.Activity{}, err
+		}
+	}
+	if len(dba.Details) > 0 {
+		if err := json.Unmarshal(dba.Details, &a.Details); err != nil {
+			return alarms.Activity{}, err
+		}
+	}
+
+	return a, nil
+}
@@ -0,0 +1,212 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddActivities(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	alarm := createActivityAlarm(t, repo)
+	comment := newActivity(t, alarm, alarms.CommentActivity, alarm.CreatedAt.Add(time.Second))
+
+	cases := []struct {
+		desc       string
+		activities []alarms.Activity
+		err        error
+	}{
+		{
+			desc:       "add activities successfully",
+			activities: []alarms.Activity{comment, newActivity(t, alarm, alarms.AcknowledgeActivity, alarm.CreatedAt.Add(time.Second))},
+		},
+		{
+			desc:       "add no activities",
+			activities: []alarms.Activity{},
+		},
+		{
+			desc:       "add activity with existing id",
+			activities: []alarms.Activity{comment},
+			err:        repoerr.ErrConflict,
+		},
+		{
+			desc: "add activity of non existing alarm",
+			activities: func() []alarms.Activity {
+				a := newActivity(t, alarm, alarms.CommentActivity, alarm.CreatedAt)
+				a.AlarmID = generateUUID(t)
+				return []alarms.Activity{a}
+			}(),
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.AddActivities(context.Background(), tc.activities)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestListActivities(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	alarm := createActivityAlarm(t, repo)
+
+	num := 10
+	var comments []alarms.Activity
+	var all []alarms.Activity
+	for i := range num {
+		typ := alarms.CommentActivity
+		if i%2 == 1 {
+			typ = alarms.AssignActivity
+		}
+		a := newActivity(t, alarm, typ, alarm.CreatedAt.Add(time.Duration(i+1)*time.Second))
+		all = append(all, a)
+		if typ == alarms.CommentActivity {
+			comments = append(comments, a)
+		}
+	}
+	err := repo.AddActivities(context.Background(), all)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		pm       alarms.ActivityPageMeta
+		response alarms.ActivitiesPage
+	}{
+		{
+			desc: "list all activities",
+			pm:   alarms.ActivityPageMeta{Limit: 100, AlarmID: alarm.ID, DomainID: alarm.DomainID},
+			response: alarms.ActivitiesPage{
+				Limit:      100,
+				Total:      uint64(num),
+				Activities: all,
+			},
+		},
+		{
+			desc: "list comments",
+			pm:   alarms.ActivityPageMeta{Limit: 100, AlarmID: alarm.ID, DomainID: alarm.DomainID, Type: alarms.CommentActivity},
+			response: alarms.ActivitiesPage{
+				Limit:      100,
+				Total:      uint64(len(comments)),
+				Activities: comments,
+			},
+		},
+		{
+			desc: "list activities with offset and limit",
+			pm:   alarms.ActivityPageMeta{Offset: 2, Limit: 3, AlarmID: alarm.ID, DomainID: alarm.DomainID},
+			response: alarms.ActivitiesPage{
+				Offset:     2,
+				Limit:      3,
+				Total:      uint64(num),
+				Activities: all[2:5],
+			},
+		},
+		{
+			desc: "list activities of another domain",
+			pm:   alarms.ActivityPageMeta{Limit: 100, AlarmID: alarm.ID, DomainID: generateUUID(t)},
+			response: alarms.ActivitiesPage{
+				Limit:      100,
+				Activities: []alarms.Activity{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListActivities(context.Background(), tc.pm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+		})
+	}
+}
+
+func TestAlarmTimeline(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	alarm := createActivityAlarm(t, repo)
+	activities := []alarms.Activity{
+		newActivity(t, alarm, alarms.AcknowledgeActivity, alarm.CreatedAt.Add(2*time.Second)),
+		newActivity(t, alarm, alarms.CommentActivity, alarm.CreatedAt.Add(time.Second)),
+	}
+	err := repo.AddActivities(context.Background(), activities)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	page, err := repo.AlarmTimeline(context.Background(), alarms.ActivityPageMeta{Limit: 100, AlarmID: alarm.ID, DomainID: alarm.DomainID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Activities, 3)
+	assert.Equal(t, uint64(3), page.Total)
+	assert.Equal(t, alarms.CreateActivity, page.Activities[0].Type)
+	assert.Equal(t, alarm.CreatedAt, page.Activities[0].CreatedAt)
+	assert.Equal(t, alarm.Measurement, page.Activities[0].Details["measurement"])
+	assert.Equal(t, activities[1], page.Activities[1])
+	assert.Equal(t, activities[0], page.Activities[2])
+
+	page, err = repo.AlarmTimeline(context.Background(), alarms.ActivityPageMeta{Limit: 100, AlarmID: alarm.ID, DomainID: generateUUID(t)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, page.Activities)
+}
+
+func createActivityAlarm(t *testing.T, repo alarms.Repository) alarms.Alarm {
+	alarm := alarms.Alarm{
+		ID:          generateUUID(t),
+		RuleID:      generateUUID(t),
+		DomainID:    generateUUID(t),
+		ChannelID:   generateUUID(t),
+		ClientID:    generateUUID(t),
+		Measurement: namegen.Generate(),
+		Value:       "100",
+		Unit:        "C",
+		Threshold:   "50",
+		Cause:       "high temperature",
+		Status:      alarms.ActiveStatus,
+		Severity:    10,
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond),
+	}
+	alarm, err := repo.CreateAlarm(context.Background(), alarm, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return alarm
+}
+
+func newActivity(t *testing.T, alarm alarms.Alarm, typ alarms.ActivityType, at time.Time) alarms.Activity {
+	a := alarms.Activity{
+		ID:        generateUUID(t),
+		AlarmID:   alarm.ID,
+		DomainID:  alarm.DomainID,
+		Type:      typ,
+		CreatedAt: at,
+		CreatedBy: generateUUID(t),
+	}
+	if typ == alarms.CommentActivity {
+		a.Content = namegen.Generate()
+		a.Links = []alarms.Link{{Title: "ticket", URL: "https://example.com/tickets/1"}}
+	}
+
+	return a
+}
@@ -21,8 +21,9 @@ import (
 )
 
 const alarmColumns = `alarms.id, alarms.rule_id, alarms.domain_id, alarms.channel_id, alarms.client_id, alarms.subtopic, alarms.measurement, alarms.value, alarms.unit,
-alarms.threshold, alarms.cause, alarms.status, alarms.severity, alarms.assignee_id, alarms.created_at, alarms.updated_at, alarms.updated_by, alarms.assigned_at,
-alarms.assigned_by, alarms.acknowledged_at, alarms.acknowledged_by, alarms.resolved_at, alarms.resolved_by, alarms.metadata`
+alarms.threshold, alarms.cause, alarms.status, alarms.severity, alarms.flapping, alarms.assignee_id, alarms.created_at, alarms.updated_at, alarms.updated_by, alarms.assigned_at,
+alarms.assigned_by, alarms.acknowledged_at, alarms.acknowledged_by, alarms.resolved_at, alarms.resolved_by, alarms.cleared_at, alarms.shelved_until,
+alarms.shelved_at, alarms.shelved_by, alarms.metadata`
 
 type repository struct {
 	db *sqlx.DB
@@ -34,7 +35,7 @@ func NewAlarmsRepo(db *sqlx.DB) alarms.Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+func (r *repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm, escalations []alarms.Escalation) (alarms.Alarm, error) {
 	query := `
 	WITH existing AS (
 		SELECT status, severity
@@ -64,15 +65,15 @@ func (r *repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 		EXISTS (
 			SELECT 1 FROM existing
 			WHERE existing.status IS DISTINCT FROM :status
-			OR (:status = 0 AND existing.status = 0 AND existing.severity IS DISTINCT FROM :severity)
+			OR (:status <> 1 AND existing.status = :status AND existing.severity IS DISTINCT FROM :severity)
 		)
 		OR (
-			NOT EXISTS (SELECT 1 FROM existing) AND :status = 0
+			NOT EXISTS (SELECT 1 FROM existing) AND :status <> 1
 		)
 	)
 	RETURNING
 		id, rule_id, domain_id, channel_id, client_id, subtopic, measurement,
-		value, unit, threshold, cause, status, severity, created_at,
+		value, unit, threshold, cause, status, severity, flapping, created_at,
 		assignee_id, updated_at, updated_by, assigned_at, assigned_by,
 		acknowledged_at, acknowledged_by, resolved_at, resolved_by, metadata
 	;
@@ -81,25 +82,39 @@ func (r *repository) CreateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 	if err != nil {
 		return alarms.Alarm{}, errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
-	row, err := r.db.NamedQueryContext(ctx, query, dba)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
-	defer row.Close()
-
+	row, err := sqlx.NamedQueryContext(ctx, tx, query, dba)
+	if err != nil {
+		return alarms.Alarm{}, rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+	}
 	if !row.Next() {
-		return alarms.Alarm{}, repoerr.ErrNotFound
+		row.Close()
+		if err := row.Err(); err != nil {
+			return alarms.Alarm{}, rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+		}
+		return alarms.Alarm{}, rollback(tx, repoerr.ErrNotFound)
 	}
-
 	dba = dbAlarm{}
-	if err := row.StructScan(&dba); err != nil {
-		return alarms.Alarm{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	err = row.StructScan(&dba)
+	row.Close()
+	if err != nil {
+		return alarms.Alarm{}, rollback(tx, errors.Wrap(repoerr.ErrCreateEntity, err))
+	}
+
+	if err := addEscalations(ctx, tx, escalations); err != nil {
+		return alarms.Alarm{}, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
 
 	return toAlarm(dba)
 }
 
-func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarms.Alarm, error) {
+func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) (alarms.Alarm, error) {
 	var query []string
 	var upq string
 	if alarm.Status != 0 {
@@ -126,6 +141,9 @@ func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 	if !alarm.ResolvedAt.IsZero() {
 		query = append(query, "resolved_at = :resolved_at,")
 	}
+	if !alarm.ClearedAt.IsZero() {
+		query = append(query, "cleared_at = :cleared_at,")
+	}
 	if alarm.Metadata != nil {
 		query = append(query, "metadata = :metadata,")
 	}
@@ -133,16 +151,61 @@ func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 		upq = strings.Join(query, " ")
 	}
 
-	q := fmt.Sprintf(`UPDATE alarms SET %s updated_by = :updated_by, updated_at = :updated_at WHERE id = :id
+	where := "alarms.id = :id"
+	conds := append([]string{where}, transitionConditions(alarms.UpdateTransitions(alarm))...)
+	q := fmt.Sprintf(`UPDATE alarms SET %s updated_by = :updated_by, updated_at = :updated_at WHERE %s
 		RETURNING id, rule_id, domain_id, channel_id, client_id, subtopic, measurement, value, unit, threshold,
-		cause, status, severity, assignee_id, assigned_at, assigned_by, acknowledged_at, acknowledged_by,
-		resolved_by, resolved_at, metadata, created_at, updated_by, updated_at;`, upq)
+		cause, status, severity, flapping, assignee_id, assigned_at, assigned_by, acknowledged_at, acknowledged_by,
+		resolved_by, resolved_at, cleared_at, shelved_until, shelved_at, shelved_by, metadata, created_at, updated_by, updated_at;`, upq, strings.Join(conds, " AND "))
 
 	dba, err := toDBAlarm(alarm)
 	if err != nil {
 		return alarms.Alarm{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
-	row, err := r.db.NamedQueryContext(ctx, q, dba)
+
+	return r.updateAlarm(ctx, q, where, dba, activities)
+}
+
+// updateAlarm runs the update query of a single alarm and records the
+// activities of the update in the same transaction. The where condition
+// selects the alarm, so if it selects an alarm the update didn't, the alarm
+// state doesn't allow the update.
+func (r *repository) updateAlarm(ctx context.Context, q, where string, params any, activities alarms.ActivitiesFunc) (alarms.Alarm, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	updated, err := queryAlarms(ctx, tx, q, params)
+	if err != nil {
+		return alarms.Alarm{}, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+	}
+	if len(updated) == 0 {
+		existing, err := queryAlarms(ctx, tx, fmt.Sprintf(`SELECT %s FROM alarms WHERE %s;`, alarmColumns, where), params)
+		if err != nil {
+			return alarms.Alarm{}, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+		}
+		if len(existing) > 0 {
+			return alarms.Alarm{}, rollback(tx, alarms.ErrInvalidTransition)
+		}
+		return alarms.Alarm{}, rollback(tx, repoerr.ErrNotFound)
+	}
+	if err := recordActivities(ctx, tx, updated, activities); err != nil {
+		return alarms.Alarm{}, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return updated[0], nil
+}
+
+func (r *repository) UpdateAlarmSeverity(ctx context.Context, id string, severity uint8) (alarms.Alarm, error) {
+	q := fmt.Sprintf(`UPDATE alarms SET severity = :severity, updated_at = :updated_at WHERE id = :id RETURNING %s;`, alarmColumns)
+	row, err := r.db.NamedQueryContext(ctx, q, map[string]any{
+		"id":         id,
+		"severity":   severity,
+		"updated_at": time.Now().UTC(),
+	})
 	if err != nil {
 		return alarms.Alarm{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
 	}
@@ -152,7 +215,7 @@ func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 		return alarms.Alarm{}, repoerr.ErrNotFound
 	}
 
-	dba = dbAlarm{}
+	dba := dbAlarm{}
 	if err := row.StructScan(&dba); err != nil {
 		return alarms.Alarm{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
 	}
@@ -160,6 +223,58 @@ func (r *repository) UpdateAlarm(ctx context.Context, alarm alarms.Alarm) (alarm
 	return toAlarm(dba)
 }
 
+func (r *repository) CountStateChanges(ctx context.Context, alarm alarms.Alarm, since time.Time) (uint64, error) {
+	q := `SELECT COUNT(*) FILTER (WHERE created_at > :since) + COUNT(*) FILTER (WHERE cleared_at > :since)
+		FROM alarms
+		WHERE domain_id = :domain_id
+			AND rule_id = :rule_id
+			AND channel_id = :channel_id
+			AND client_id = :client_id
+			AND subtopic = :subtopic
+			AND measurement = :measurement
+			AND (created_at > :since OR cleared_at > :since);`
+	params := sourceParams(alarm)
+	params["since"] = since
+	total, err := postgres.Total(ctx, r.db, q, params)
+	if err != nil {
+		return 0, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return total, nil
+}
+
+func (r *repository) MarkFlapping(ctx context.Context, alarm alarms.Alarm) error {
+	q := `UPDATE alarms SET flapping = TRUE
+		WHERE id = (
+			SELECT id FROM alarms
+			WHERE domain_id = :domain_id
+				AND rule_id = :rule_id
+				AND channel_id = :channel_id
+				AND client_id = :client_id
+				AND subtopic = :subtopic
+				AND measurement = :measurement
+			ORDER BY created_at DESC
+			LIMIT 1
+		) AND NOT flapping;`
+	if _, err := r.db.NamedExecContext(ctx, q, sourceParams(alarm)); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// sourceParams returns the query parameters which identify the source of the alarm.
+func sourceParams(alarm alarms.Alarm) map[string]any {
+	return map[string]any{
+		"domain_id":   alarm.DomainID,
+		"rule_id":     alarm.RuleID,
+		"channel_id":  alarm.ChannelID,
+		"client_id":   alarm.ClientID,
+		"subtopic":    alarm.Subtopic,
+		"measurement": alarm.Measurement,
+	}
+}
+
 func (r *repository) ViewAlarm(ctx context.Context, alarmID, domainID string) (alarms.Alarm, error) {
 	query := `SELECT * FROM alarms WHERE id = :id AND domain_id = :domain_id;`
 	row, err := r.db.NamedQueryContext(ctx, query, map[string]any{
@@ -282,6 +397,7 @@ type dbAlarm struct {
 	Threshold      string        `db:"threshold"`
 	Status         alarms.Status `db:"status"`
 	Severity       uint8         `db:"severity"`
+	Flapping       bool          `db:"flapping"`
 	AssigneeID     string        `db:"assignee_id"`
 	CreatedAt      time.Time     `db:"created_at"`
 	UpdatedAt      sql.NullTime  `db:"updated_at,omitempty"`
@@ -292,6 +408,10 @@ type dbAlarm struct {
 	AcknowledgedBy *string       `db:"acknowledged_by,omitempty"`
 	ResolvedAt     sql.NullTime  `db:"resolved_at,omitempty"`
 	ResolvedBy     *string       `db:"resolved_by,omitempty"`
+	ClearedAt      sql.NullTime  `db:"cleared_at,omitempty"`
+	ShelvedUntil   sql.NullTime  `db:"shelved_until,omitempty"`
+	ShelvedAt      sql.NullTime  `db:"shelved_at,omitempty"`
+	ShelvedBy      *string       `db:"shelved_by,omitempty"`
 	Metadata       []byte        `db:"metadata,omitempty"`
 }
 
@@ -335,6 +455,11 @@ func toDBAlarm(a alarms.Alarm) (dbAlarm, error) {
 		assignedAt = sql.NullTime{Time: a.AssignedAt, Valid: true}
 	}
 
+	var shelvedBy *string
+	if a.ShelvedBy != "" {
+		shelvedBy = &a.ShelvedBy
+	}
+
 	metadata := []byte("{}")
 	if len(a.Metadata) > 0 {
 		b, err := json.Marshal(a.Metadata)
@@ -358,6 +483,7 @@ func toDBAlarm(a alarms.Alarm) (dbAlarm, error) {
 		Threshold:      a.Threshold,
 		Status:         a.Status,
 		Severity:       a.Severity,
+		Flapping:       a.Flapping,
 		AssigneeID:     a.AssigneeID,
 		CreatedAt:      a.CreatedAt,
 		UpdatedAt:      updatedAt,
@@ -368,6 +494,10 @@ func toDBAlarm(a alarms.Alarm) (dbAlarm, error) {
 		AcknowledgedBy: acknowledgedBy,
 		ResolvedAt:     resolvedAt,
 		ResolvedBy:     resolvedBy,
+		ClearedAt:      sql.NullTime{Time: a.ClearedAt, Valid: !a.ClearedAt.IsZero()},
+		ShelvedUntil:   sql.NullTime{Time: a.ShelvedUntil, Valid: !a.ShelvedUntil.IsZero()},
+		ShelvedAt:      sql.NullTime{Time: a.ShelvedAt, Valid: !a.ShelvedAt.IsZero()},
+		ShelvedBy:      shelvedBy,
 		Metadata:       metadata,
 	}, nil
 }
@@ -409,6 +539,11 @@ func toAlarm(dbr dbAlarm) (alarms.Alarm, error) {
 		resolvedAt = dbr.ResolvedAt.Time
 	}
 
+	var shelvedBy string
+	if dbr.ShelvedBy != nil {
+		shelvedBy = *dbr.ShelvedBy
+	}
+
 	var metadata map[string]any
 	if len(dbr.Metadata) > 0 {
 		err := json.Unmarshal(dbr.Metadata, &metadata)
@@ -417,7 +552,7 @@ func toAlarm(dbr dbAlarm) (alarms.Alarm, error) {
 		}
 	}
 
-	return alarms.Alarm{
+	alarm := alarms.Alarm{
 		ID:             dbr.ID,
 		RuleID:         dbr.RuleID,
 		DomainID:       dbr.DomainID,
@@ -431,6 +566,7 @@ func toAlarm(dbr dbAlarm) (alarms.Alarm, error) {
 		Cause:          dbr.Cause,
 		Status:         dbr.Status,
 		Severity:       dbr.Severity,
+		Flapping:       dbr.Flapping,
 		AssigneeID:     dbr.AssigneeID,
 		CreatedAt:      dbr.CreatedAt,
 		UpdatedAt:      updatedAt,
@@ -441,8 +577,15 @@ func toAlarm(dbr dbAlarm) (alarms.Alarm, error) {
 		AcknowledgedBy: acknowledgedBy,
 		ResolvedAt:     resolvedAt,
 		ResolvedBy:     resolvedBy,
+		ClearedAt:      dbr.ClearedAt.Time,
+		ShelvedUntil:   dbr.ShelvedUntil.Time,
+		ShelvedAt:      dbr.ShelvedAt.Time,
+		ShelvedBy:      shelvedBy,
 		Metadata:       metadata,
-	}, nil
+	}
+	alarm.State = alarm.StateAt(time.Now())
+
+	return alarm, nil
 }
 
 func pageQuery(pm alarms.PageMetadata) (string, error) {
@@ -461,6 +604,9 @@ func pageQueryConditions(pm alarms.PageMetadata) []string {
 	if pm.DomainID != "" {
 		query = append(query, "alarms.domain_id = :domain_id")
 	}
+	if len(pm.AlarmIDs) > 0 {
+		query = append(query, "alarms.id = ANY(:alarm_ids)")
+	}
 	if pm.RuleID != "" {
 		query = append(query, "alarms.rule_id = :rule_id")
 	}
@@ -482,6 +628,7 @@ func pageQueryConditions(pm alarms.PageMetadata) []string {
 	if pm.Status != alarms.AllStatus {
 		query = append(query, "alarms.status = :status")
 	}
+	query = append(query, stateConditions(pm.State)...)
 	if pm.Severity != math.MaxUint8 {
 		query = append(query, "alarms.severity = :severity")
 	}
@@ -123,7 +123,7 @@ func TestCreateAlarm(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			alarm, err := repo.CreateAlarm(context.Background(), tc.alarm)
+			alarm, err := repo.CreateAlarm(context.Background(), tc.alarm, nil)
 			if tc.err != nil {
 				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 
@@ -170,7 +170,7 @@ func TestUpdateAlarm(t *testing.T) {
 			"key": "value",
 		},
 	}
-	alarm, err := repo.CreateAlarm(context.Background(), alarm)
+	alarm, err := repo.CreateAlarm(context.Background(), alarm, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
@@ -200,6 +200,17 @@ func TestUpdateAlarm(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "acknowledge resolved alarm",
+			alarm: alarms.Alarm{
+				ID:             alarm.ID,
+				AcknowledgedBy: generateUUID(t),
+				AcknowledgedAt: time.Now().UTC(),
+				UpdatedAt:      time.Now().UTC(),
+				UpdatedBy:      generateUUID(t),
+			},
+			err: alarms.ErrInvalidTransition,
+		},
 		{
 			desc: "non existing alarm",
 			alarm: alarms.Alarm{
@@ -207,6 +218,15 @@ func TestUpdateAlarm(t *testing.T) {
 			},
 			err: repoerr.ErrNotFound,
 		},
+		{
+			desc: "acknowledge non existing alarm",
+			alarm: alarms.Alarm{
+				ID:             generateUUID(t),
+				AcknowledgedBy: generateUUID(t),
+				AcknowledgedAt: time.Now().UTC(),
+			},
+			err: repoerr.ErrNotFound,
+		},
 		{
 			desc: "invalid alarm",
 			alarm: alarms.Alarm{
@@ -231,7 +251,7 @@ func TestUpdateAlarm(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			alarm, err := repo.UpdateAlarm(context.Background(), tc.alarm)
+			alarm, err := repo.UpdateAlarm(context.Background(), tc.alarm, nil)
 			if tc.err != nil {
 				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 
@@ -276,7 +296,7 @@ func TestViewAlarm(t *testing.T) {
 			"key": "value",
 		},
 	}
-	alarm, err := repo.CreateAlarm(context.Background(), alarm)
+	alarm, err := repo.CreateAlarm(context.Background(), alarm, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
@@ -346,7 +366,7 @@ func TestListAlarms(t *testing.T) {
 				"key": "value",
 			},
 		}
-		alarm, err := repo.CreateAlarm(context.Background(), items[i])
+		alarm, err := repo.CreateAlarm(context.Background(), items[i], nil)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		items[i].ID = alarm.ID
 	}
@@ -441,7 +461,7 @@ func TestDeleteAlarm(t *testing.T) {
 			"key": "value",
 		},
 	}
-	alarm, err := repo.CreateAlarm(context.Background(), alarm)
+	alarm, err := repo.CreateAlarm(context.Background(), alarm, nil)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
@@ -474,6 +494,74 @@ func TestDeleteAlarm(t *testing.T) {
 	}
 }
 
+func TestFlapping(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+
+	source := alarms.Alarm{
+		RuleID:      generateUUID(t),
+		DomainID:    generateUUID(t),
+		ChannelID:   generateUUID(t),
+		ClientID:    generateUUID(t),
+		Measurement: namegen.Generate(),
+	}
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	changes := 4
+	var latest alarms.Alarm
+	for i := range changes {
+		alarm := source
+		alarm.ID = generateUUID(t)
+		alarm.Status = alarms.ActiveStatus
+		if i%2 == 1 {
+			alarm.Status = alarms.ClearedStatus
+		}
+		alarm.CreatedAt = start.Add(time.Duration(i) * time.Second)
+		a, err := repo.CreateAlarm(context.Background(), alarm, nil)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		latest = a
+	}
+
+	cases := []struct {
+		desc  string
+		since time.Time
+		count uint64
+	}{
+		{
+			desc:  "count all state changes",
+			since: start.Add(-time.Second),
+			count: uint64(changes),
+		},
+		{
+			desc:  "count recent state changes",
+			since: start.Add(time.Second),
+			count: uint64(changes - 2),
+		},
+		{
+			desc:  "count state changes in the future",
+			since: start.Add(time.Hour),
+			count: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			count, err := repo.CountStateChanges(context.Background(), source, tc.since)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.count, count))
+		})
+	}
+
+	err := repo.MarkFlapping(context.Background(), source)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	alarm, err := repo.ViewAlarm(context.Background(), latest.ID, latest.DomainID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, alarm.Flapping, "expected the latest alarm to be marked as flapping")
+}
+
 func generateUUID(t *testing.T) string {
 	ulid, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
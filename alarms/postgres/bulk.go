@@ -0,0 +1,155 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+// bulkParams binds the filter and the update of a bulk operation. The update
+// values are prefixed, since the filter has columns of the same names.
+type bulkParams struct {
+	alarms.PageMetadata
+	SetAssigneeID     string       `db:"set_assignee_id"`
+	SetAssignedAt     sql.NullTime `db:"set_assigned_at"`
+	SetAssignedBy     string       `db:"set_assigned_by"`
+	SetAcknowledgedAt sql.NullTime `db:"set_acknowledged_at"`
+	SetAcknowledgedBy string       `db:"set_acknowledged_by"`
+	SetResolvedAt     sql.NullTime `db:"set_resolved_at"`
+	SetResolvedBy     string       `db:"set_resolved_by"`
+	SetUpdatedAt      sql.NullTime `db:"set_updated_at"`
+	SetUpdatedBy      string       `db:"set_updated_by"`
+}
+
+func (r *repository) UpdateAlarms(ctx context.Context, pm alarms.PageMetadata, update alarms.Alarm, activities alarms.ActivitiesFunc) ([]alarms.Alarm, error) {
+	params := bulkParams{
+		PageMetadata:      pm,
+		SetAssigneeID:     update.AssigneeID,
+		SetAssignedAt:     sql.NullTime{Time: update.AssignedAt, Valid: !update.AssignedAt.IsZero()},
+		SetAssignedBy:     update.AssignedBy,
+		SetAcknowledgedAt: sql.NullTime{Time: update.AcknowledgedAt, Valid: !update.AcknowledgedAt.IsZero()},
+		SetAcknowledgedBy: update.AcknowledgedBy,
+		SetResolvedAt:     sql.NullTime{Time: update.ResolvedAt, Valid: !update.ResolvedAt.IsZero()},
+		SetResolvedBy:     update.ResolvedBy,
+		SetUpdatedAt:      sql.NullTime{Time: update.UpdatedAt, Valid: !update.UpdatedAt.IsZero()},
+		SetUpdatedBy:      update.UpdatedBy,
+	}
+
+	set := []string{"updated_at = :set_updated_at", "updated_by = :set_updated_by"}
+	if update.AssigneeID != "" {
+		set = append(set, "assignee_id = :set_assignee_id", "assigned_at = :set_assigned_at", "assigned_by = :set_assigned_by")
+	}
+	if update.AcknowledgedBy != "" {
+		set = append(set, "acknowledged_at = :set_acknowledged_at", "acknowledged_by = :set_acknowledged_by")
+	}
+	if update.ResolvedBy != "" {
+		set = append(set, "resolved_at = :set_resolved_at", "resolved_by = :set_resolved_by")
+	}
+	// Only the alarms whose state allows the update are updated.
+	conds := append(pageQueryConditions(pm), transitionConditions(alarms.UpdateTransitions(update))...)
+
+	q := fmt.Sprintf(`UPDATE alarms SET %s WHERE alarms.id IN (%s) RETURNING %s;`,
+		strings.Join(set, ", "), bulkSelectQuery(conds), alarmColumns)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	updated, err := queryAlarms(ctx, tx, q, params)
+	if err != nil {
+		return nil, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+	}
+	if (update.AcknowledgedBy != "" || update.ResolvedBy != "") && len(updated) > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM alarm_escalations WHERE alarm_id = ANY($1);`, alarmIDs(updated)); err != nil {
+			return nil, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+		}
+	}
+	if err := recordActivities(ctx, tx, updated, activities); err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return updated, nil
+}
+
+func (r *repository) DeleteAlarms(ctx context.Context, pm alarms.PageMetadata) ([]alarms.Alarm, error) {
+	q := fmt.Sprintf(`DELETE FROM alarms WHERE alarms.id IN (%s) RETURNING %s;`,
+		bulkSelectQuery(pageQueryConditions(pm)), alarmColumns)
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	deleted, err := queryAlarms(ctx, tx, q, pm)
+	if err != nil {
+		return nil, rollback(tx, postgres.HandleError(repoerr.ErrRemoveEntity, err))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return deleted, nil
+}
+
+// bulkSelectQuery selects the IDs of the oldest alarms matching the
+// conditions and locks them until the end of the transaction.
+func bulkSelectQuery(conds []string) string {
+	var where string
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	return fmt.Sprintf(`SELECT alarms.id FROM alarms %s ORDER BY alarms.created_at, alarms.id LIMIT :limit FOR UPDATE`, where)
+}
+
+func queryAlarms(ctx context.Context, tx *sqlx.Tx, q string, params any) ([]alarms.Alarm, error) {
+	rows, err := sqlx.NamedQueryContext(ctx, tx, q, params)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []alarms.Alarm{}
+	for rows.Next() {
+		dba := dbAlarm{}
+		if err := rows.StructScan(&dba); err != nil {
+			return nil, err
+		}
+		a, err := toAlarm(dba)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, a)
+	}
+
+	return items, rows.Err()
+}
+
+func rollback(tx *sqlx.Tx, err error) error {
+	if rbErr := tx.Rollback(); rbErr != nil {
+		return errors.Wrap(err, errors.Wrap(errors.ErrRollbackTx, rbErr))
+	}
+
+	return err
+}
+
+func alarmIDs(items []alarms.Alarm) []string {
+	ids := make([]string, len(items))
+	for i, a := range items {
+		ids[i] = a.ID
+	}
+
+	return ids
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/postgres"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAlarms(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	channelID := generateUUID(t)
+	first := createBulkAlarm(t, repo, domainID, channelID, 0)
+	second := createBulkAlarm(t, repo, domainID, channelID, 1)
+	other := createBulkAlarm(t, repo, domainID, generateUUID(t), 2)
+	// Alarms acknowledged with only the acknowledgement time are acknowledged.
+	timeAcked := createBulkAlarm(t, repo, domainID, generateUUID(t), 3)
+	_, err := db.Exec("UPDATE alarms SET acknowledged_at = now() WHERE id = $1", timeAcked.ID)
+	require.Nil(t, err, fmt.Sprintf("acknowledge alarm unexpected error: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	userID := generateUUID(t)
+	acknowledge := alarms.Alarm{UpdatedAt: now, UpdatedBy: userID, AcknowledgedAt: now, AcknowledgedBy: userID}
+	assign := alarms.Alarm{UpdatedAt: now, UpdatedBy: userID, AssigneeID: userID, AssignedAt: now, AssignedBy: userID}
+
+	cases := []struct {
+		desc   string
+		pm     alarms.PageMetadata
+		update alarms.Alarm
+		ids    []string
+	}{
+		{
+			desc:   "acknowledge alarms by ids",
+			pm:     bulkPageMeta(domainID, first.ID, other.ID),
+			update: acknowledge,
+			ids:    []string{first.ID, other.ID},
+		},
+		{
+			desc:   "acknowledge acknowledged alarms",
+			pm:     bulkPageMeta(domainID, first.ID, other.ID),
+			update: acknowledge,
+			ids:    []string{},
+		},
+		{
+			desc:   "acknowledge alarms acknowledged without user",
+			pm:     bulkPageMeta(domainID, timeAcked.ID),
+			update: acknowledge,
+			ids:    []string{},
+		},
+		{
+			desc: "assign alarms by filter",
+			pm: func() alarms.PageMetadata {
+				pm := bulkPageMeta(domainID)
+				pm.ChannelID = channelID
+				return pm
+			}(),
+			update: assign,
+			ids:    []string{first.ID, second.ID},
+		},
+		{
+			desc: "assign oldest alarms",
+			pm: func() alarms.PageMetadata {
+				pm := bulkPageMeta(domainID)
+				pm.Limit = 1
+				return pm
+			}(),
+			update: assign,
+			ids:    []string{first.ID},
+		},
+		{
+			desc:   "acknowledge alarms of another domain",
+			pm:     bulkPageMeta(generateUUID(t), second.ID),
+			update: acknowledge,
+			ids:    []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var recorded []alarms.Activity
+			updated, err := repo.UpdateAlarms(context.Background(), tc.pm, tc.update, func(changed []alarms.Alarm) ([]alarms.Activity, error) {
+				for _, a := range changed {
+					recorded = append(recorded, alarms.Activity{
+						ID:        generateUUID(t),
+						AlarmID:   a.ID,
+						DomainID:  a.DomainID,
+						Type:      alarms.AssignActivity,
+						CreatedAt: tc.update.UpdatedAt,
+					})
+				}
+				return recorded, nil
+			})
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			ids := []string{}
+			for _, a := range updated {
+				ids = append(ids, a.ID)
+				assert.Equal(t, tc.update.UpdatedBy, a.UpdatedBy, fmt.Sprintf("%s: expected updated by %s got %s\n", tc.desc, tc.update.UpdatedBy, a.UpdatedBy))
+				if tc.update.AcknowledgedBy != "" {
+					assert.Equal(t, tc.update.AcknowledgedBy, a.AcknowledgedBy, fmt.Sprintf("%s: expected acknowledged by %s got %s\n", tc.desc, tc.update.AcknowledgedBy, a.AcknowledgedBy))
+				}
+				if tc.update.AssigneeID != "" {
+					assert.Equal(t, tc.update.AssigneeID, a.AssigneeID, fmt.Sprintf("%s: expected assignee %s got %s\n", tc.desc, tc.update.AssigneeID, a.AssigneeID))
+				}
+			}
+			assert.ElementsMatch(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+			for _, act := range recorded {
+				page, err := repo.ListActivities(context.Background(), alarms.ActivityPageMeta{AlarmID: act.AlarmID, DomainID: act.DomainID, Limit: 100})
+				require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				var actIDs []string
+				for _, a := range page.Activities {
+					actIDs = append(actIDs, a.ID)
+				}
+				assert.Contains(t, actIDs, act.ID, fmt.Sprintf("%s: expected activity %s to be recorded\n", tc.desc, act.ID))
+			}
+		})
+	}
+}
+
+func TestDeleteAlarms(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	channelID := generateUUID(t)
+	first := createBulkAlarm(t, repo, domainID, channelID, 0)
+	second := createBulkAlarm(t, repo, domainID, channelID, 1)
+	other := createBulkAlarm(t, repo, domainID, generateUUID(t), 2)
+
+	pm := bulkPageMeta(domainID)
+	pm.ChannelID = channelID
+	deleted, err := repo.DeleteAlarms(context.Background(), pm)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, deleted, 2)
+	assert.Equal(t, first.ID, deleted[0].ID)
+	assert.Equal(t, second.ID, deleted[1].ID)
+
+	_, err = repo.ViewAlarm(context.Background(), first.ID, domainID)
+	assert.ErrorIs(t, err, repoerr.ErrNotFound)
+	_, err = repo.ViewAlarm(context.Background(), other.ID, domainID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	deleted, err = repo.DeleteAlarms(context.Background(), pm)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, deleted)
+}
+
+func createBulkAlarm(t *testing.T, repo alarms.Repository, domainID, channelID string, i int) alarms.Alarm {
+	alarm := alarms.Alarm{
+		ID:          generateUUID(t),
+		RuleID:      generateUUID(t),
+		DomainID:    domainID,
+		ChannelID:   channelID,
+		ClientID:    generateUUID(t),
+		Measurement: namegen.Generate(),
+		Value:       "100",
+		Cause:       "high temperature",
+		Status:      alarms.ActiveStatus,
+		Severity:    10,
+		CreatedAt:   time.Now().UTC().Truncate(time.Microsecond).Add(time.Duration(i) * time.Second),
+	}
+	alarm, err := repo.CreateAlarm(context.Background(), alarm, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return alarm
+}
+
+func bulkPageMeta(domainID string, ids ...string) alarms.PageMetadata {
+	return alarms.PageMetadata{
+		Limit:    alarms.MaxBulkAlarms,
+		DomainID: domainID,
+		AlarmIDs: ids,
+		Status:   alarms.AllStatus,
+		Severity: math.MaxUint8,
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+const policyColumns = `id, name, domain_id, rule_id, channel_id, measurement, min_severity, max_severity, steps,
+	created_at, created_by, updated_at, updated_by`
+
+func (r *repository) CreateEscalationPolicy(ctx context.Context, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	q := `INSERT INTO escalation_policies (` + policyColumns + `)
+		VALUES (:id, :name, :domain_id, :rule_id, :channel_id, :measurement, :min_severity, :max_severity, :steps,
+			:created_at, :created_by, :updated_at, :updated_by)
+		RETURNING ` + policyColumns + `;`
+
+	return r.savePolicy(ctx, q, policy, repoerr.ErrCreateEntity)
+}
+
+func (r *repository) ViewEscalationPolicy(ctx context.Context, id, domainID string) (alarms.EscalationPolicy, error) {
+	q := `SELECT ` + policyColumns + ` FROM escalation_policies WHERE id = $1 AND domain_id = $2;`
+	var dbp dbPolicy
+	if err := r.db.QueryRowxContext(ctx, q, id, domainID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return alarms.EscalationPolicy{}, repoerr.ErrNotFound
+		}
+		return alarms.EscalationPolicy{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toPolicy(dbp)
+}
+
+func (r *repository) ListEscalationPolicies(ctx context.Context, pm alarms.EscalationPolicyPageMeta) (alarms.EscalationPoliciesPage, error) {
+	q := `SELECT ` + policyColumns + ` FROM escalation_policies WHERE domain_id = :domain_id
+		ORDER BY created_at DESC, id DESC LIMIT :limit OFFSET :offset;`
+	rows, err := r.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return alarms.EscalationPoliciesPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	policies := []alarms.EscalationPolicy{}
+	for rows.Next() {
+		var dbp dbPolicy
+		if err := rows.StructScan(&dbp); err != nil {
+			return alarms.EscalationPoliciesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		p, err := toPolicy(dbp)
+		if err != nil {
+			return alarms.EscalationPoliciesPage{}, err
+		}
+		policies = append(policies, p)
+	}
+
+	cq := `SELECT COUNT(*) FROM escalation_policies WHERE domain_id = :domain_id;`
+	total, err := postgres.Total(ctx, r.db, cq, pm)
+	if err != nil {
+		return alarms.EscalationPoliciesPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return alarms.EscalationPoliciesPage{
+		Total:    total,
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+		Policies: policies,
+	}, nil
+}
+
+func (r *repository) UpdateEscalationPolicy(ctx context.Context, policy alarms.EscalationPolicy) (alarms.EscalationPolicy, error) {
+	q := `UPDATE escalation_policies SET name = :name, rule_id = :rule_id, channel_id = :channel_id,
+			measurement = :measurement, min_severity = :min_severity, max_severity = :max_severity, steps = :steps,
+			updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id AND domain_id = :domain_id
+		RETURNING ` + policyColumns + `;`
+
+	return r.savePolicy(ctx, q, policy, repoerr.ErrUpdateEntity)
+}
+
+func (r *repository) DeleteEscalationPolicy(ctx context.Context, id, domainID string) error {
+	q := `DELETE FROM escalation_policies WHERE id = $1 AND domain_id = $2;`
+	res, err := r.db.ExecContext(ctx, q, id, domainID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) MatchEscalationPolicies(ctx context.Context, alarm alarms.Alarm) ([]alarms.EscalationPolicy, error) {
+	q := `SELECT ` + policyColumns + ` FROM escalation_policies
+		WHERE domain_id = :domain_id
+			AND (rule_id = '' OR rule_id = :rule_id)
+			AND (channel_id = '' OR channel_id = :channel_id)
+			AND (measurement = '' OR measurement = :measurement)
+			AND :severity BETWEEN min_severity AND max_severity
+		ORDER BY created_at, id;`
+	rows, err := r.db.NamedQueryContext(ctx, q, map[string]any{
+		"domain_id":   alarm.DomainID,
+		"rule_id":     alarm.RuleID,
+		"channel_id":  alarm.ChannelID,
+		"measurement": alarm.Measurement,
+		"severity":    alarm.Severity,
+	})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var policies []alarms.EscalationPolicy
+	for rows.Next() {
+		var dbp dbPolicy
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		p, err := toPolicy(dbp)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+func addEscalations(ctx context.Context, tx *sqlx.Tx, escalations []alarms.Escalation) error {
+	if len(escalations) == 0 {
+		return nil
+	}
+	q := `INSERT INTO alarm_escalations (alarm_id, policy_id, domain_id, step, next_at)
+		VALUES (:alarm_id, :policy_id, :domain_id, :step, :next_at)
+		ON CONFLICT (alarm_id, policy_id) DO NOTHING;`
+	dbes := make([]dbEscalation, len(escalations))
+	for i, e := range escalations {
+		dbes[i] = dbEscalation(e)
+	}
+	if _, err := sqlx.NamedExecContext(ctx, tx, q, dbes); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (r *repository) ClaimEscalations(ctx context.Context, due, until time.Time, limit uint64) ([]alarms.Escalation, error) {
+	// Skip the rows locked by concurrent claims, so each escalation is claimed once.
+	q := `UPDATE alarm_escalations SET next_at = $2
+		WHERE (alarm_id, policy_id) IN (
+			SELECT alarm_id, policy_id FROM alarm_escalations
+			WHERE next_at <= $1
+			ORDER BY next_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING alarm_id, policy_id, domain_id, step, next_at;`
+	rows, err := r.db.QueryxContext(ctx, q, due, until, limit)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var escs []alarms.Escalation
+	for rows.Next() {
+		var dbe dbEscalation
+		if err := rows.StructScan(&dbe); err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		escs = append(escs, alarms.Escalation(dbe))
+	}
+
+	return escs, nil
+}
+
+func (r *repository) UpdateEscalation(ctx context.Context, escalation alarms.Escalation) error {
+	q := `UPDATE alarm_escalations SET step = :step, next_at = :next_at
+		WHERE alarm_id = :alarm_id AND policy_id = :policy_id;`
+	res, err := r.db.NamedExecContext(ctx, q, dbEscalation(escalation))
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) RemoveEscalation(ctx context.Context, alarmID, policyID string) error {
+	q := `DELETE FROM alarm_escalations WHERE alarm_id = $1 AND policy_id = $2;`
+	if _, err := r.db.ExecContext(ctx, q, alarmID, policyID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (r *repository) RemoveAlarmEscalations(ctx context.Context, alarmID string) error {
+	q := `DELETE FROM alarm_escalations WHERE alarm_id = $1;`
+	if _, err := r.db.ExecContext(ctx, q, alarmID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (r *repository) savePolicy(ctx context.Context, q string, policy alarms.EscalationPolicy, wrapper error) (alarms.EscalationPolicy, error) {
+	dbp, err := toDBPolicy(policy)
+	if err != nil {
+		return alarms.EscalationPolicy{}, errors.Wrap(wrapper, err)
+	}
+	rows, err := r.db.NamedQueryContext(ctx, q, dbp)
+	if err != nil {
+		return alarms.EscalationPolicy{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return alarms.EscalationPolicy{}, repoerr.ErrNotFound
+	}
+	dbp = dbPolicy{}
+	if err := rows.StructScan(&dbp); err != nil {
+		return alarms.EscalationPolicy{}, errors.Wrap(wrapper, err)
+	}
+
+	return toPolicy(dbp)
+}
+
+type dbPolicy struct {
+	ID          string       `db:"id"`
+	Name        string       `db:"name"`
+	DomainID    string       `db:"domain_id"`
+	RuleID      string       `db:"rule_id"`
+	ChannelID   string       `db:"channel_id"`
+	Measurement string       `db:"measurement"`
+	MinSeverity uint8        `db:"min_severity"`
+	MaxSeverity uint8        `db:"max_severity"`
+	Steps       []byte       `db:"steps"`
+	CreatedAt   time.Time    `db:"created_at"`
+	CreatedBy   string       `db:"created_by"`
+	UpdatedAt   sql.NullTime `db:"updated_at"`
+	UpdatedBy   *string      `db:"updated_by"`
+}
+
+type dbEscalation struct {
+	AlarmID  string    `db:"alarm_id"`
+	PolicyID string    `db:"policy_id"`
+	DomainID string    `db:"domain_id"`
+	Step     uint      `db:"step"`
+	NextAt   time.Time `db:"next_at"`
+}
+
+func toDBPolicy(p alarms.EscalationPolicy) (dbPolicy, error) {
+	steps, err := json.Marshal(p.Steps)
+	if err != nil {
+		return dbPolicy{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	var updatedAt sql.NullTime
+	if !p.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: p.UpdatedAt, Valid: true}
+	}
+	var updatedBy *string
+	if p.UpdatedBy != "" {
+		updatedBy = &p.UpdatedBy
+	}
+
+	return dbPolicy{
+		ID:          p.ID,
+		Name:        p.Name,
+		DomainID:    p.DomainID,
+		RuleID:      p.Match.RuleID,
+		ChannelID:   p.Match.ChannelID,
+		Measurement: p.Match.Measurement,
+		MinSeverity: p.Match.MinSeverity,
+		MaxSeverity: p.Match.MaxSeverity,
+		Steps:       steps,
+		CreatedAt:   p.CreatedAt,
+		CreatedBy:   p.CreatedBy,
+		UpdatedAt:   updatedAt,
+		UpdatedBy:   updatedBy,
+	}, nil
+}
+
+func toPolicy(dbp dbPolicy) (alarms.EscalationPolicy, error) {
+	var steps []alarms.EscalationStep
+	if err := json.Unmarshal(dbp.Steps, &steps); err != nil {
+		return alarms.EscalationPolicy{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	p := alarms.EscalationPolicy{
+		ID:       dbp.ID,
+		Name:     dbp.Name,
+		DomainID: dbp.DomainID,
+		Match: alarms.EscalationMatch{
+			RuleID:      dbp.RuleID,
+			ChannelID:   dbp.ChannelID,
+			Measurement: dbp.Measurement,
+			MinSeverity: dbp.MinSeverity,
+			MaxSeverity: dbp.MaxSeverity,
+		},
+		Steps:     steps,
+		CreatedAt: dbp.CreatedAt,
+		CreatedBy: dbp.CreatedBy,
+	}
+	if dbp.UpdatedAt.Valid {
+		p.UpdatedAt = dbp.UpdatedAt.Time
+	}
+	if dbp.UpdatedBy != nil {
+		p.UpdatedBy = *dbp.UpdatedBy
+	}
+
+	return p, nil
+}
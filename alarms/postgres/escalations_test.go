@@ -0,0 +1,411 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEscalationPolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	policy := newPolicy(t, generateUUID(t))
+
+	cases := []struct {
+		desc   string
+		policy alarms.EscalationPolicy
+		err    error
+	}{
+		{
+			desc:   "create escalation policy successfully",
+			policy: policy,
+		},
+		{
+			desc:   "create escalation policy with existing id",
+			policy: policy,
+			err:    repoerr.ErrConflict,
+		},
+		{
+			desc: "create escalation policy with invalid severity range",
+			policy: func() alarms.EscalationPolicy {
+				p := newPolicy(t, policy.DomainID)
+				p.Match.MinSeverity = 90
+				p.Match.MaxSeverity = 10
+				return p
+			}(),
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := repo.CreateEscalationPolicy(context.Background(), tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.policy, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, p))
+			}
+		})
+	}
+}
+
+func TestViewEscalationPolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	policy, err := repo.CreateEscalationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		domainID string
+		response alarms.EscalationPolicy
+		err      error
+	}{
+		{
+			desc:     "view escalation policy successfully",
+			id:       policy.ID,
+			domainID: policy.DomainID,
+			response: policy,
+		},
+		{
+			desc:     "view escalation policy of another domain",
+			id:       policy.ID,
+			domainID: generateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "view non-existing escalation policy",
+			id:       generateUUID(t),
+			domainID: policy.DomainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := repo.ViewEscalationPolicy(context.Background(), tc.id, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, p))
+		})
+	}
+}
+
+func TestListEscalationPolicies(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	num := 10
+	desc := make([]alarms.EscalationPolicy, num)
+	for i := range num {
+		p := newPolicy(t, domainID)
+		p.CreatedAt = now.Add(time.Duration(i) * time.Second)
+		p, err := repo.CreateEscalationPolicy(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		// Policies are listed from the newest.
+		desc[num-1-i] = p
+	}
+
+	cases := []struct {
+		desc     string
+		pm       alarms.EscalationPolicyPageMeta
+		response alarms.EscalationPoliciesPage
+	}{
+		{
+			desc: "list escalation policies successfully",
+			pm:   alarms.EscalationPolicyPageMeta{DomainID: domainID, Limit: 5},
+			response: alarms.EscalationPoliciesPage{
+				Total:    uint64(num),
+				Limit:    5,
+				Policies: desc[:5],
+			},
+		},
+		{
+			desc: "list escalation policies with offset",
+			pm:   alarms.EscalationPolicyPageMeta{DomainID: domainID, Offset: 8, Limit: 5},
+			response: alarms.EscalationPoliciesPage{
+				Total:    uint64(num),
+				Offset:   8,
+				Limit:    5,
+				Policies: desc[8:],
+			},
+		},
+		{
+			desc: "list escalation policies of another domain",
+			pm:   alarms.EscalationPolicyPageMeta{DomainID: generateUUID(t), Limit: 5},
+			response: alarms.EscalationPoliciesPage{
+				Limit:    5,
+				Policies: []alarms.EscalationPolicy{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListEscalationPolicies(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+		})
+	}
+}
+
+func TestUpdateEscalationPolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	policy, err := repo.CreateEscalationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := policy
+	updated.Name = namegen.Generate()
+	updated.Match.MinSeverity = 80
+	updated.Steps = append(updated.Steps, alarms.EscalationStep{After: time.Hour, Action: alarms.SeverityAction, Severity: 100})
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	updated.UpdatedBy = generateUUID(t)
+
+	cases := []struct {
+		desc   string
+		policy alarms.EscalationPolicy
+		err    error
+	}{
+		{
+			desc:   "update escalation policy successfully",
+			policy: updated,
+		},
+		{
+			desc: "update escalation policy of another domain",
+			policy: func() alarms.EscalationPolicy {
+				p := updated
+				p.DomainID = generateUUID(t)
+				return p
+			}(),
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := repo.UpdateEscalationPolicy(context.Background(), tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.policy, p, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policy, p))
+			}
+		})
+	}
+}
+
+func TestDeleteEscalationPolicy(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	policy, err := repo.CreateEscalationPolicy(context.Background(), newPolicy(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		domainID string
+		err      error
+	}{
+		{
+			desc:     "delete escalation policy of another domain",
+			id:       policy.ID,
+			domainID: generateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "delete escalation policy successfully",
+			id:       policy.ID,
+			domainID: policy.DomainID,
+		},
+		{
+			desc:     "delete already deleted escalation policy",
+			id:       policy.ID,
+			domainID: policy.DomainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.DeleteEscalationPolicy(context.Background(), tc.id, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestMatchEscalationPolicies(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	ruleID := generateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	wildcard := newPolicy(t, domainID)
+	wildcard.CreatedAt = now
+	rule := newPolicy(t, domainID)
+	rule.Match.RuleID = ruleID
+	rule.CreatedAt = now.Add(time.Second)
+	critical := newPolicy(t, domainID)
+	critical.Match.MinSeverity = 90
+	critical.CreatedAt = now.Add(2 * time.Second)
+	for i, p := range []alarms.EscalationPolicy{wildcard, rule, critical} {
+		_, err := repo.CreateEscalationPolicy(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("unexpected error creating policy %d: %s", i, err))
+	}
+
+	cases := []struct {
+		desc     string
+		alarm    alarms.Alarm
+		response []alarms.EscalationPolicy
+	}{
+		{
+			desc:     "match policies of rule with critical alarm",
+			alarm:    alarms.Alarm{DomainID: domainID, RuleID: ruleID, Severity: 100},
+			response: []alarms.EscalationPolicy{wildcard, rule, critical},
+		},
+		{
+			desc:     "match policies of another rule",
+			alarm:    alarms.Alarm{DomainID: domainID, RuleID: generateUUID(t), Severity: 50},
+			response: []alarms.EscalationPolicy{wildcard},
+		},
+		{
+			desc:  "match policies of another domain",
+			alarm: alarms.Alarm{DomainID: generateUUID(t), RuleID: ruleID, Severity: 100},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			policies, err := repo.MatchEscalationPolicies(context.Background(), tc.alarm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.response, policies, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, policies))
+		})
+	}
+}
+
+func TestEscalations(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM escalation_policies")
+		require.Nil(t, err, fmt.Sprintf("clean escalation policies unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	policy, err := repo.CreateEscalationPolicy(context.Background(), newPolicy(t, domainID))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var escs []alarms.Escalation
+	for i := range 3 {
+		alarm := alarms.Alarm{
+			ID:        generateUUID(t),
+			RuleID:    generateUUID(t),
+			DomainID:  domainID,
+			ChannelID: generateUUID(t),
+			CreatedAt: now,
+		}
+		esc := alarms.Escalation{
+			AlarmID:  alarm.ID,
+			PolicyID: policy.ID,
+			DomainID: domainID,
+			NextAt:   now.Add(time.Duration(i-1) * time.Minute),
+		}
+		_, err := repo.CreateAlarm(context.Background(), alarm, []alarms.Escalation{esc})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		escs = append(escs, esc)
+	}
+	// The escalations of an alarm that is not created are not saved.
+	cleared := alarms.Alarm{
+		ID:        generateUUID(t),
+		RuleID:    generateUUID(t),
+		DomainID:  domainID,
+		ChannelID: generateUUID(t),
+		Status:    alarms.ClearedStatus,
+		CreatedAt: now,
+	}
+	_, err = repo.CreateAlarm(context.Background(), cleared, []alarms.Escalation{{AlarmID: cleared.ID, PolicyID: policy.ID, DomainID: domainID, NextAt: now}})
+	require.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s", repoerr.ErrNotFound, err))
+
+	until := now.Add(5 * time.Minute)
+	claimed, err := repo.ClaimEscalations(context.Background(), now, until, 10)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, claimed, 2, fmt.Sprintf("expected 2 due escalations got %d", len(claimed)))
+	for _, e := range claimed {
+		assert.Equal(t, until, e.NextAt, fmt.Sprintf("expected claimed escalation to be leased until %s got %s", until, e.NextAt))
+	}
+	claimed, err = repo.ClaimEscalations(context.Background(), now, until, 10)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, claimed, "expected leased escalations not to be claimed again")
+
+	next := escs[0]
+	next.Step = 1
+	next.NextAt = now.Add(-time.Second)
+	err = repo.UpdateEscalation(context.Background(), next)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	claimed, err = repo.ClaimEscalations(context.Background(), now, until, 10)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, []alarms.Escalation{{AlarmID: next.AlarmID, PolicyID: next.PolicyID, DomainID: domainID, Step: 1, NextAt: until}}, claimed)
+
+	err = repo.RemoveEscalation(context.Background(), next.AlarmID, next.PolicyID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = repo.UpdateEscalation(context.Background(), next)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s", repoerr.ErrNotFound, err))
+
+	err = repo.RemoveAlarmEscalations(context.Background(), escs[2].AlarmID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	claimed, err = repo.ClaimEscalations(context.Background(), until, until.Add(time.Minute), 10)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, claimed, 1, fmt.Sprintf("expected 1 remaining escalation got %d", len(claimed)))
+}
+
+func newPolicy(t *testing.T, domainID string) alarms.EscalationPolicy {
+	return alarms.EscalationPolicy{
+		ID:       generateUUID(t),
+		Name:     namegen.Generate(),
+		DomainID: domainID,
+		Match:    alarms.EscalationMatch{MaxSeverity: alarms.SeverityMax},
+		Steps: []alarms.EscalationStep{
+			{Action: alarms.NotifyAction, Contacts: []alarms.Contact{{Type: alarms.EmailContact, Address: "oncall@example.com"}}},
+			{After: 15 * time.Minute, Action: alarms.ReassignAction, AssigneeID: generateUUID(t)},
+		},
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: generateUUID(t),
+	}
+}
@@ -48,6 +48,110 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS alarms`,
 				},
 			},
+			{
+				Id: "alarms_02",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS escalation_policies (
+						id           VARCHAR(36) PRIMARY KEY,
+						name         TEXT NOT NULL,
+						domain_id    VARCHAR(36) NOT NULL,
+						rule_id      VARCHAR(36) NOT NULL DEFAULT '',
+						channel_id   VARCHAR(36) NOT NULL DEFAULT '',
+						measurement  TEXT NOT NULL DEFAULT '',
+						min_severity SMALLINT NOT NULL DEFAULT 0 CHECK (min_severity >= 0),
+						max_severity SMALLINT NOT NULL DEFAULT 100 CHECK (max_severity >= min_severity),
+						steps        JSONB NOT NULL,
+						created_at   TIMESTAMPTZ NOT NULL,
+						created_by   VARCHAR(36) NOT NULL,
+						updated_at   TIMESTAMPTZ NULL,
+						updated_by   VARCHAR(36) NULL
+					);`,
+					`CREATE INDEX IF NOT EXISTS idx_escalation_policies_domain_id ON escalation_policies (domain_id);`,
+					`CREATE TABLE IF NOT EXISTS alarm_escalations (
+						alarm_id   VARCHAR(36) NOT NULL REFERENCES alarms (id) ON DELETE CASCADE,
+						policy_id  VARCHAR(36) NOT NULL REFERENCES escalation_policies (id) ON DELETE CASCADE,
+						domain_id  VARCHAR(36) NOT NULL,
+						step       INTEGER NOT NULL DEFAULT 0 CHECK (step >= 0),
+						next_at    TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (alarm_id, policy_id)
+					);`,
+					`CREATE INDEX IF NOT EXISTS idx_alarm_escalations_next_at ON alarm_escalations (next_at);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS alarm_escalations`,
+					`DROP TABLE IF EXISTS escalation_policies`,
+				},
+			},
+			{
+				Id: "alarms_03",
+				Up: []string{
+					`ALTER TABLE alarms ADD COLUMN IF NOT EXISTS flapping BOOLEAN NOT NULL DEFAULT FALSE;`,
+					`CREATE TABLE IF NOT EXISTS suppression_windows (
+						id          VARCHAR(36) PRIMARY KEY,
+						name        TEXT NOT NULL,
+						domain_id   VARCHAR(36) NOT NULL,
+						channel_id  VARCHAR(36) NOT NULL DEFAULT '',
+						client_id   VARCHAR(36) NOT NULL DEFAULT '',
+						reason      TEXT NOT NULL DEFAULT '',
+						starts_at   TIMESTAMPTZ NOT NULL,
+						ends_at     TIMESTAMPTZ NOT NULL CHECK (ends_at > starts_at),
+						created_at  TIMESTAMPTZ NOT NULL,
+						created_by  VARCHAR(36) NOT NULL,
+						updated_at  TIMESTAMPTZ NULL,
+						updated_by  VARCHAR(36) NULL,
+						CHECK (channel_id <> '' OR client_id <> '')
+					);`,
+					`CREATE INDEX IF NOT EXISTS idx_suppression_windows_domain_id ON suppression_windows (domain_id, ends_at);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS suppression_windows`,
+					`ALTER TABLE alarms DROP COLUMN IF EXISTS flapping`,
+				},
+			},
+			{
+				Id: "alarms_04",
+				Up: []string{
+					`CREATE INDEX IF NOT EXISTS idx_alarms_domain_created_at ON alarms (domain_id, created_at DESC);`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_alarms_domain_created_at`,
+				},
+			},
+			{
+				Id: "alarms_05",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS alarm_activities (
+						id          VARCHAR(36) PRIMARY KEY,
+						alarm_id    VARCHAR(36) NOT NULL REFERENCES alarms (id) ON DELETE CASCADE,
+						domain_id   VARCHAR(36) NOT NULL,
+						type        TEXT NOT NULL,
+						content     TEXT NOT NULL DEFAULT '',
+						links       JSONB NULL,
+						details     JSONB NULL,
+						created_at  TIMESTAMPTZ NOT NULL,
+						created_by  VARCHAR(36) NOT NULL DEFAULT ''
+					);`,
+					`CREATE INDEX IF NOT EXISTS idx_alarm_activities_alarm_id ON alarm_activities (alarm_id, created_at);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS alarm_activities`,
+				},
+			},
+			{
+				Id: "alarms_06",
+				Up: []string{
+					`ALTER TABLE alarms ADD COLUMN IF NOT EXISTS cleared_at TIMESTAMPTZ NULL;`,
+					`ALTER TABLE alarms ADD COLUMN IF NOT EXISTS shelved_until TIMESTAMPTZ NULL;`,
+					`ALTER TABLE alarms ADD COLUMN IF NOT EXISTS shelved_at TIMESTAMPTZ NULL;`,
+					`ALTER TABLE alarms ADD COLUMN IF NOT EXISTS shelved_by VARCHAR(36) NULL;`,
+				},
+				Down: []string{
+					`ALTER TABLE alarms DROP COLUMN IF EXISTS shelved_by`,
+					`ALTER TABLE alarms DROP COLUMN IF EXISTS shelved_at`,
+					`ALTER TABLE alarms DROP COLUMN IF EXISTS shelved_until`,
+					`ALTER TABLE alarms DROP COLUMN IF EXISTS cleared_at`,
+				},
+			},
 		},
 	}
 
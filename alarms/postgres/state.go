@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/magistrala/alarms"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const (
+	shelvedCondition   = "alarms.shelved_until > now()"
+	unshelvedCondition = "(alarms.shelved_until IS NULL OR alarms.shelved_until <= now())"
+	// An alarm is acknowledged once it has the acknowledgement user or time,
+	// the same as in alarms.Alarm.StateAt.
+	ackedCondition     = "(COALESCE(alarms.acknowledged_by, '') <> '' OR alarms.acknowledged_at IS NOT NULL)"
+	unackedCondition   = "COALESCE(alarms.acknowledged_by, '') = '' AND alarms.acknowledged_at IS NULL"
+	clearedCondition   = "alarms.status = 1"
+	unclearedCondition = "alarms.status <> 1"
+)
+
+// stateConditions returns the conditions which select the alarms in the state.
+func stateConditions(state alarms.State) []string {
+	switch state {
+	case alarms.ShelvedState:
+		return []string{shelvedCondition}
+	case alarms.ActiveUnackedState:
+		return []string{unshelvedCondition, unclearedCondition, unackedCondition}
+	case alarms.ActiveAckedState:
+		return []string{unshelvedCondition, unclearedCondition, ackedCondition}
+	case alarms.ClearedUnackedState:
+		return []string{unshelvedCondition, clearedCondition, unackedCondition}
+	case alarms.ClearedAckedState:
+		return []string{unshelvedCondition, clearedCondition, ackedCondition}
+	default:
+		return nil
+	}
+}
+
+// transitionConditions returns the conditions which select the alarms whose
+// state allows the transitions. Resolution closes the alarm, so resolved
+// alarms allow no transitions.
+func transitionConditions(ts []alarms.Transition) []string {
+	if len(ts) == 0 {
+		return nil
+	}
+	conds := []string{"COALESCE(alarms.resolved_by, '') = ''", "alarms.resolved_at IS NULL"}
+	for _, t := range ts {
+		var states []string
+		for _, state := range alarms.TransitionStates(t) {
+			states = append(states, "("+strings.Join(stateConditions(state), " AND ")+")")
+		}
+		conds = append(conds, "("+strings.Join(states, " OR ")+")")
+	}
+
+	return conds
+}
+
+func (r *repository) ShelveAlarm(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) (alarms.Alarm, error) {
+	t := alarms.ShelveTransition
+	if alarm.ShelvedUntil.IsZero() {
+		t = alarms.UnshelveTransition
+	}
+	where := "alarms.id = :id AND alarms.domain_id = :domain_id"
+	conds := append([]string{where}, transitionConditions([]alarms.Transition{t})...)
+	q := fmt.Sprintf(`UPDATE alarms SET shelved_until = :shelved_until, shelved_at = :shelved_at, shelved_by = :shelved_by,
+		updated_at = :updated_at, updated_by = :updated_by
+		WHERE %s RETURNING %s;`, strings.Join(conds, " AND "), alarmColumns)
+	params := map[string]any{
+		"id":            alarm.ID,
+		"domain_id":     alarm.DomainID,
+		"shelved_until": sql.NullTime{Time: alarm.ShelvedUntil, Valid: !alarm.ShelvedUntil.IsZero()},
+		"shelved_at":    sql.NullTime{Time: alarm.ShelvedAt, Valid: !alarm.ShelvedAt.IsZero()},
+		"shelved_by":    sql.NullString{String: alarm.ShelvedBy, Valid: alarm.ShelvedBy != ""},
+		"updated_at":    alarm.UpdatedAt,
+		"updated_by":    alarm.UpdatedBy,
+	}
+
+	return r.updateAlarm(ctx, q, where, params, activities)
+}
+
+func (r *repository) ClearAlarms(ctx context.Context, alarm alarms.Alarm, activities alarms.ActivitiesFunc) ([]alarms.Alarm, error) {
+	var measurement string
+	if alarm.Measurement != "" {
+		measurement = "AND measurement = :measurement"
+	}
+	q := fmt.Sprintf(`UPDATE alarms SET status = :status, cleared_at = :cleared_at, updated_at = :cleared_at
+		WHERE alarms.id IN (
+			SELECT DISTINCT ON (measurement) id FROM alarms
+			WHERE domain_id = :domain_id
+				AND rule_id = :rule_id
+				AND channel_id = :channel_id
+				AND client_id = :client_id
+				AND subtopic = :subtopic
+				%s
+			ORDER BY measurement, created_at DESC
+		) AND alarms.status <> :status
+		RETURNING %s;`, measurement, alarmColumns)
+	params := sourceParams(alarm)
+	params["status"] = alarms.ClearedStatus
+	params["cleared_at"] = alarm.ClearedAt
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	cleared, err := queryAlarms(ctx, tx, q, params)
+	if err != nil {
+		return nil, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+	}
+	if err := recordActivities(ctx, tx, cleared, activities); err != nil {
+		return nil, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return cleared, nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShelveAlarm(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	alarm := createBulkAlarm(t, repo, domainID, generateUUID(t), 0)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	userID := generateUUID(t)
+
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		state alarms.State
+		err   error
+	}{
+		{
+			desc: "shelve alarm",
+			alarm: alarms.Alarm{
+				ID:           alarm.ID,
+				DomainID:     domainID,
+				ShelvedUntil: now.Add(time.Hour),
+				ShelvedAt:    now,
+				ShelvedBy:    userID,
+				UpdatedAt:    now,
+				UpdatedBy:    userID,
+			},
+			state: alarms.ShelvedState,
+		},
+		{
+			desc: "shelve shelved alarm",
+			alarm: alarms.Alarm{
+				ID:           alarm.ID,
+				DomainID:     domainID,
+				ShelvedUntil: now.Add(2 * time.Hour),
+				ShelvedAt:    now,
+				ShelvedBy:    userID,
+				UpdatedAt:    now,
+				UpdatedBy:    userID,
+			},
+			err: alarms.ErrInvalidTransition,
+		},
+		{
+			desc: "unshelve alarm",
+			alarm: alarms.Alarm{
+				ID:        alarm.ID,
+				DomainID:  domainID,
+				UpdatedAt: now,
+				UpdatedBy: userID,
+			},
+			state: alarms.ActiveUnackedState,
+		},
+		{
+			desc: "unshelve active alarm",
+			alarm: alarms.Alarm{
+				ID:        alarm.ID,
+				DomainID:  domainID,
+				UpdatedAt: now,
+				UpdatedBy: userID,
+			},
+			err: alarms.ErrInvalidTransition,
+		},
+		{
+			desc: "shelve alarm of another domain",
+			alarm: alarms.Alarm{
+				ID:           alarm.ID,
+				DomainID:     generateUUID(t),
+				ShelvedUntil: now.Add(time.Hour),
+				UpdatedAt:    now,
+				UpdatedBy:    userID,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			shelved, err := repo.ShelveAlarm(context.Background(), tc.alarm, nil)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.state, shelved.State, fmt.Sprintf("%s: expected state %s got %s\n", tc.desc, tc.state, shelved.State))
+			assert.Equal(t, tc.alarm.ShelvedBy, shelved.ShelvedBy, fmt.Sprintf("%s: expected shelved by %s got %s\n", tc.desc, tc.alarm.ShelvedBy, shelved.ShelvedBy))
+		})
+	}
+}
+
+func TestClearAlarms(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	alarm := createBulkAlarm(t, repo, domainID, generateUUID(t), 0)
+	other := createBulkAlarm(t, repo, domainID, alarm.ChannelID, 1)
+
+	src := alarms.Alarm{
+		RuleID:    alarm.RuleID,
+		DomainID:  domainID,
+		ChannelID: alarm.ChannelID,
+		ClientID:  alarm.ClientID,
+		ClearedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	activities := func(changed []alarms.Alarm) ([]alarms.Activity, error) {
+		var acts []alarms.Activity
+		for _, a := range changed {
+			acts = append(acts, alarms.Activity{
+				ID:        generateUUID(t),
+				AlarmID:   a.ID,
+				DomainID:  a.DomainID,
+				Type:      alarms.StatusActivity,
+				CreatedAt: src.ClearedAt,
+			})
+		}
+		return acts, nil
+	}
+	cleared, err := repo.ClearAlarms(context.Background(), src, activities)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, cleared, 1)
+	assert.Equal(t, alarm.ID, cleared[0].ID)
+	assert.Equal(t, alarms.ClearedStatus, cleared[0].Status)
+	assert.Equal(t, alarms.ClearedUnackedState, cleared[0].State)
+	page, err := repo.ListActivities(context.Background(), alarms.ActivityPageMeta{AlarmID: alarm.ID, DomainID: domainID, Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, page.Activities, 1, fmt.Sprintf("expected the clear activity got %v", page.Activities))
+
+	cleared, err = repo.ClearAlarms(context.Background(), src, activities)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Empty(t, cleared)
+
+	saved, err := repo.ViewAlarm(context.Background(), other.ID, domainID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, alarms.ActiveStatus, saved.Status)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+)
+
+func (r *repository) AlarmStats(ctx context.Context, pm alarms.StatsPageMeta) (alarms.Stats, error) {
+	where, params := statsQuery(pm)
+	stats := alarms.Stats{From: pm.From, To: pm.To}
+
+	if err := r.summary(ctx, where, params, &stats); err != nil {
+		return alarms.Stats{}, err
+	}
+
+	var err error
+	if stats.ByStatus, err = r.countBy(ctx, "CAST(status AS TEXT)", where, "", params); err != nil {
+		return alarms.Stats{}, err
+	}
+	for i, c := range stats.ByStatus {
+		status, err := strconv.ParseUint(c.Key, 10, 8)
+		if err != nil {
+			return alarms.Stats{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		stats.ByStatus[i].Key = alarms.Status(status).String()
+	}
+	if stats.BySeverity, err = r.countBy(ctx, "CAST(severity AS TEXT)", where, "", params); err != nil {
+		return alarms.Stats{}, err
+	}
+	if stats.ByRule, err = r.countBy(ctx, "rule_id", where, "", params); err != nil {
+		return alarms.Stats{}, err
+	}
+	if stats.ByChannel, err = r.countBy(ctx, "channel_id", where, "", params); err != nil {
+		return alarms.Stats{}, err
+	}
+	if stats.TopClients, err = r.countBy(ctx, "client_id", where, "LIMIT :top", params); err != nil {
+		return alarms.Stats{}, err
+	}
+	if stats.Histogram, err = r.histogram(ctx, where, params); err != nil {
+		return alarms.Stats{}, err
+	}
+
+	return stats, nil
+}
+
+// summary sets the alarm totals and the mean times to acknowledge and to resolve.
+func (r *repository) summary(ctx context.Context, where string, params map[string]any, stats *alarms.Stats) error {
+	q := fmt.Sprintf(`SELECT COUNT(*) AS total, COUNT(acknowledged_at) AS acknowledged, COUNT(resolved_at) AS resolved,
+			CAST(COALESCE(AVG(EXTRACT(EPOCH FROM acknowledged_at - created_at)), 0) AS FLOAT8) AS mtta,
+			CAST(COALESCE(AVG(EXTRACT(EPOCH FROM resolved_at - created_at)), 0) AS FLOAT8) AS mttr
+		FROM alarms WHERE %s;`, where)
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return errors.Wrap(repoerr.ErrViewEntity, rows.Err())
+	}
+	if err := rows.Scan(&stats.Total, &stats.Acknowledged, &stats.Resolved, &stats.MTTA, &stats.MTTR); err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return nil
+}
+
+// countBy counts the alarms grouped by the key expression, from the most to
+// the least frequent key.
+func (r *repository) countBy(ctx context.Context, key, where, limit string, params map[string]any) ([]alarms.Count, error) {
+	q := fmt.Sprintf(`SELECT %s AS key, COUNT(*) AS count FROM alarms WHERE %s
+		GROUP BY 1 ORDER BY count DESC, key %s;`, key, where, limit)
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	counts := []alarms.Count{}
+	for rows.Next() {
+		var c alarms.Count
+		if err := rows.Scan(&c.Key, &c.Count); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, nil
+}
+
+// histogram counts the alarms in the buckets of the interval width, aligned
+// to the start of the time range. Empty buckets are omitted.
+func (r *repository) histogram(ctx context.Context, where string, params map[string]any) ([]alarms.Bucket, error) {
+	q := fmt.Sprintf(`SELECT date_bin(make_interval(secs => :interval), created_at, :from) AS start, COUNT(*) AS count
+		FROM alarms WHERE %s
+		GROUP BY 1 ORDER BY 1;`, where)
+	rows, err := r.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	buckets := []alarms.Bucket{}
+	for rows.Next() {
+		var b alarms.Bucket
+		if err := rows.Scan(&b.Start, &b.Count); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		b.Start = b.Start.UTC()
+		buckets = append(buckets, b)
+	}
+
+	return buckets, nil
+}
+
+func statsQuery(pm alarms.StatsPageMeta) (string, map[string]any) {
+	conditions := []string{"domain_id = :domain_id", "created_at >= :from", "created_at < :to"}
+	if pm.RuleID != "" {
+		conditions = append(conditions, "rule_id = :rule_id")
+	}
+	if len(pm.RuleIDs) > 0 {
+		conditions = append(conditions, "rule_id = ANY(:rule_ids)")
+	}
+	if pm.ChannelID != "" {
+		conditions = append(conditions, "channel_id = :channel_id")
+	}
+	if pm.ClientID != "" {
+		conditions = append(conditions, "client_id = :client_id")
+	}
+
+	return strings.Join(conditions, " AND "), map[string]any{
+		"domain_id":  pm.DomainID,
+		"rule_id":    pm.RuleID,
+		"rule_ids":   pm.RuleIDs,
+		"channel_id": pm.ChannelID,
+		"client_id":  pm.ClientID,
+		"from":       pm.From,
+		"to":         pm.To,
+		"interval":   pm.Interval.Seconds(),
+		"top":        pm.Top,
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlarmStats(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM alarms")
+		require.Nil(t, err, fmt.Sprintf("clean alarms unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	rule1, rule2 := generateUUID(t), generateUUID(t)
+	channel1, channel2 := generateUUID(t), generateUUID(t)
+	client1, client2 := generateUUID(t), generateUUID(t)
+	from := time.Now().UTC().Truncate(time.Hour).Add(-2 * time.Hour)
+	to := from.Add(2 * time.Hour)
+
+	items := []alarms.Alarm{
+		{
+			RuleID: rule1, ChannelID: channel1, ClientID: client1, Status: alarms.ActiveStatus, Severity: 50,
+			CreatedAt:      from.Add(10 * time.Minute),
+			AcknowledgedAt: from.Add(11 * time.Minute),
+			ResolvedAt:     from.Add(20 * time.Minute),
+		},
+		{
+			RuleID: rule1, ChannelID: channel1, ClientID: client1, Status: alarms.ActiveStatus, Severity: 50,
+			CreatedAt:      from.Add(20 * time.Minute),
+			AcknowledgedAt: from.Add(23 * time.Minute),
+		},
+		{
+			RuleID: rule2, ChannelID: channel2, ClientID: client2, Status: alarms.SuppressedStatus, Severity: 80,
+			CreatedAt: from.Add(70 * time.Minute),
+		},
+		{
+			RuleID: rule2, ChannelID: channel2, ClientID: client2, Status: alarms.ActiveStatus, Severity: 80,
+			CreatedAt: from.Add(-time.Hour),
+		},
+	}
+	for _, item := range items {
+		item.ID = generateUUID(t)
+		item.DomainID = domainID
+		item.Measurement = namegen.Generate()
+		_, err := repo.CreateAlarm(context.Background(), item, nil)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		pm    alarms.StatsPageMeta
+		stats alarms.Stats
+	}{
+		{
+			desc: "alarm stats of the domain",
+			pm:   alarms.StatsPageMeta{DomainID: domainID, From: from, To: to, Interval: time.Hour, Top: 1},
+			stats: alarms.Stats{
+				From:         from,
+				To:           to,
+				Total:        3,
+				Acknowledged: 2,
+				Resolved:     1,
+				MTTA:         120,
+				MTTR:         600,
+				ByStatus:     []alarms.Count{{Key: "active", Count: 2}, {Key: "suppressed", Count: 1}},
+				BySeverity:   []alarms.Count{{Key: "50", Count: 2}, {Key: "80", Count: 1}},
+				ByRule:       []alarms.Count{{Key: rule1, Count: 2}, {Key: rule2, Count: 1}},
+				ByChannel:    []alarms.Count{{Key: channel1, Count: 2}, {Key: channel2, Count: 1}},
+				TopClients:   []alarms.Count{{Key: client1, Count: 2}},
+				Histogram:    []alarms.Bucket{{Start: from, Count: 2}, {Start: from.Add(time.Hour), Count: 1}},
+			},
+		},
+		{
+			desc: "alarm stats of a client",
+			pm:   alarms.StatsPageMeta{DomainID: domainID, ClientID: client2, From: from, To: to, Interval: time.Hour, Top: 10},
+			stats: alarms.Stats{
+				From:       from,
+				To:         to,
+				Total:      1,
+				ByStatus:   []alarms.Count{{Key: "suppressed", Count: 1}},
+				BySeverity: []alarms.Count{{Key: "80", Count: 1}},
+				ByRule:     []alarms.Count{{Key: rule2, Count: 1}},
+				ByChannel:  []alarms.Count{{Key: channel2, Count: 1}},
+				TopClients: []alarms.Count{{Key: client2, Count: 1}},
+				Histogram:  []alarms.Bucket{{Start: from.Add(time.Hour), Count: 1}},
+			},
+		},
+		{
+			desc: "alarm stats of another domain",
+			pm:   alarms.StatsPageMeta{DomainID: generateUUID(t), From: from, To: to, Interval: time.Hour, Top: 10},
+			stats: alarms.Stats{
+				From:       from,
+				To:         to,
+				ByStatus:   []alarms.Count{},
+				BySeverity: []alarms.Count{},
+				ByRule:     []alarms.Count{},
+				ByChannel:  []alarms.Count{},
+				TopClients: []alarms.Count{},
+				Histogram:  []alarms.Bucket{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			stats, err := repo.AlarmStats(context.Background(), tc.pm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.stats, stats, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.stats, stats))
+		})
+	}
+}
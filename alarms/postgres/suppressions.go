@@ -0,0 +1,217 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+)
+
+const windowColumns = `id, name, domain_id, channel_id, client_id, reason, starts_at, ends_at,
+	created_at, created_by, updated_at, updated_by`
+
+func (r *repository) CreateSuppressionWindow(ctx context.Context, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	q := `INSERT INTO suppression_windows (` + windowColumns + `)
+		VALUES (:id, :name, :domain_id, :channel_id, :client_id, :reason, :starts_at, :ends_at,
+			:created_at, :created_by, :updated_at, :updated_by)
+		RETURNING ` + windowColumns + `;`
+
+	return r.saveWindow(ctx, q, window, repoerr.ErrCreateEntity)
+}
+
+func (r *repository) ViewSuppressionWindow(ctx context.Context, id, domainID string) (alarms.SuppressionWindow, error) {
+	q := `SELECT ` + windowColumns + ` FROM suppression_windows WHERE id = $1 AND domain_id = $2;`
+	var dbw dbWindow
+	if err := r.db.QueryRowxContext(ctx, q, id, domainID).StructScan(&dbw); err != nil {
+		if err == sql.ErrNoRows {
+			return alarms.SuppressionWindow{}, repoerr.ErrNotFound
+		}
+		return alarms.SuppressionWindow{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toWindow(dbw), nil
+}
+
+func (r *repository) ListSuppressionWindows(ctx context.Context, pm alarms.SuppressionWindowPageMeta) (alarms.SuppressionWindowsPage, error) {
+	conditions := []string{"domain_id = :domain_id"}
+	if pm.ChannelID != "" {
+		conditions = append(conditions, "channel_id = :channel_id")
+	}
+	if pm.ClientID != "" {
+		conditions = append(conditions, "client_id = :client_id")
+	}
+	where := strings.Join(conditions, " AND ")
+
+	q := fmt.Sprintf(`SELECT %s FROM suppression_windows WHERE %s
+		ORDER BY starts_at DESC, id DESC LIMIT :limit OFFSET :offset;`, windowColumns, where)
+	rows, err := r.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return alarms.SuppressionWindowsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	windows := []alarms.SuppressionWindow{}
+	for rows.Next() {
+		var dbw dbWindow
+		if err := rows.StructScan(&dbw); err != nil {
+			return alarms.SuppressionWindowsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		windows = append(windows, toWindow(dbw))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM suppression_windows WHERE %s;`, where)
+	total, err := postgres.Total(ctx, r.db, cq, pm)
+	if err != nil {
+		return alarms.SuppressionWindowsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return alarms.SuppressionWindowsPage{
+		Total:   total,
+		Offset:  pm.Offset,
+		Limit:   pm.Limit,
+		Windows: windows,
+	}, nil
+}
+
+func (r *repository) UpdateSuppressionWindow(ctx context.Context, window alarms.SuppressionWindow) (alarms.SuppressionWindow, error) {
+	q := `UPDATE suppression_windows SET name = :name, channel_id = :channel_id, client_id = :client_id,
+			reason = :reason, starts_at = :starts_at, ends_at = :ends_at, updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id AND domain_id = :domain_id
+		RETURNING ` + windowColumns + `;`
+
+	return r.saveWindow(ctx, q, window, repoerr.ErrUpdateEntity)
+}
+
+func (r *repository) DeleteSuppressionWindow(ctx context.Context, id, domainID string) error {
+	q := `DELETE FROM suppression_windows WHERE id = $1 AND domain_id = $2;`
+	res, err := r.db.ExecContext(ctx, q, id, domainID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) MatchSuppressionWindows(ctx context.Context, alarm alarms.Alarm) ([]alarms.SuppressionWindow, error) {
+	q := `SELECT ` + windowColumns + ` FROM suppression_windows
+		WHERE domain_id = :domain_id
+			AND (channel_id = '' OR channel_id = :channel_id)
+			AND (client_id = '' OR client_id = :client_id)
+			AND starts_at <= :at AND ends_at > :at
+		ORDER BY starts_at, id;`
+	rows, err := r.db.NamedQueryContext(ctx, q, map[string]any{
+		"domain_id":  alarm.DomainID,
+		"channel_id": alarm.ChannelID,
+		"client_id":  alarm.ClientID,
+		"at":         alarm.CreatedAt,
+	})
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var windows []alarms.SuppressionWindow
+	for rows.Next() {
+		var dbw dbWindow
+		if err := rows.StructScan(&dbw); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		windows = append(windows, toWindow(dbw))
+	}
+
+	return windows, nil
+}
+
+func (r *repository) saveWindow(ctx context.Context, q string, window alarms.SuppressionWindow, wrapper error) (alarms.SuppressionWindow, error) {
+	rows, err := r.db.NamedQueryContext(ctx, q, toDBWindow(window))
+	if err != nil {
+		return alarms.SuppressionWindow{}, postgres.HandleError(wrapper, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return alarms.SuppressionWindow{}, repoerr.ErrNotFound
+	}
+	var dbw dbWindow
+	if err := rows.StructScan(&dbw); err != nil {
+		return alarms.SuppressionWindow{}, errors.Wrap(wrapper, err)
+	}
+
+	return toWindow(dbw), nil
+}
+
+type dbWindow struct {
+	ID        string       `db:"id"`
+	Name      string       `db:"name"`
+	DomainID  string       `db:"domain_id"`
+	ChannelID string       `db:"channel_id"`
+	ClientID  string       `db:"client_id"`
+	Reason    string       `db:"reason"`
+	StartsAt  time.Time    `db:"starts_at"`
+	EndsAt    time.Time    `db:"ends_at"`
+	CreatedAt time.Time    `db:"created_at"`
+	CreatedBy string       `db:"created_by"`
+	UpdatedAt sql.NullTime `db:"updated_at"`
+	UpdatedBy *string      `db:"updated_by"`
+}
+
+func toDBWindow(w alarms.SuppressionWindow) dbWindow {
+	var updatedAt sql.NullTime
+	if !w.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: w.UpdatedAt, Valid: true}
+	}
+	var updatedBy *string
+	if w.UpdatedBy != "" {
+		updatedBy = &w.UpdatedBy
+	}
+
+	return dbWindow{
+		ID:        w.ID,
+		Name:      w.Name,
+		DomainID:  w.DomainID,
+		ChannelID: w.ChannelID,
+		ClientID:  w.ClientID,
+		Reason:    w.Reason,
+		StartsAt:  w.StartsAt,
+		EndsAt:    w.EndsAt,
+		CreatedAt: w.CreatedAt,
+		CreatedBy: w.CreatedBy,
+		UpdatedAt: updatedAt,
+		UpdatedBy: updatedBy,
+	}
+}
+
+func toWindow(dbw dbWindow) alarms.SuppressionWindow {
+	w := alarms.SuppressionWindow{
+		ID:        dbw.ID,
+		Name:      dbw.Name,
+		DomainID:  dbw.DomainID,
+		ChannelID: dbw.ChannelID,
+		ClientID:  dbw.ClientID,
+		Reason:    dbw.Reason,
+		StartsAt:  dbw.StartsAt,
+		EndsAt:    dbw.EndsAt,
+		CreatedAt: dbw.CreatedAt,
+		CreatedBy: dbw.CreatedBy,
+	}
+	if dbw.UpdatedAt.Valid {
+		w.UpdatedAt = dbw.UpdatedAt.Time
+	}
+	if dbw.UpdatedBy != nil {
+		w.UpdatedBy = *dbw.UpdatedBy
+	}
+
+	return w
+}
@@ -0,0 +1,299 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/postgres"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSuppressionWindow(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM suppression_windows")
+		require.Nil(t, err, fmt.Sprintf("clean suppression windows unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	window := newWindow(t, generateUUID(t))
+
+	cases := []struct {
+		desc   string
+		window alarms.SuppressionWindow
+		err    error
+	}{
+		{
+			desc:   "create suppression window successfully",
+			window: window,
+		},
+		{
+			desc:   "create suppression window with existing id",
+			window: window,
+			err:    repoerr.ErrConflict,
+		},
+		{
+			desc: "create suppression window with invalid range",
+			window: func() alarms.SuppressionWindow {
+				w := newWindow(t, window.DomainID)
+				w.EndsAt = w.StartsAt.Add(-time.Hour)
+				return w
+			}(),
+			err: repoerr.ErrCreateEntity,
+		},
+		{
+			desc: "create suppression window without channel and client",
+			window: func() alarms.SuppressionWindow {
+				w := newWindow(t, window.DomainID)
+				w.ChannelID = ""
+				return w
+			}(),
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			w, err := repo.CreateSuppressionWindow(context.Background(), tc.window)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.window, w, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.window, w))
+			}
+		})
+	}
+}
+
+func TestListSuppressionWindows(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM suppression_windows")
+		require.Nil(t, err, fmt.Sprintf("clean suppression windows unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	domainID := generateUUID(t)
+	num := 10
+	for range num {
+		_, err := repo.CreateSuppressionWindow(context.Background(), newWindow(t, domainID))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	client := newWindow(t, domainID)
+	client.ChannelID = ""
+	client.ClientID = generateUUID(t)
+	_, err := repo.CreateSuppressionWindow(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    alarms.SuppressionWindowPageMeta
+		total uint64
+		size  int
+	}{
+		{
+			desc:  "list all suppression windows",
+			pm:    alarms.SuppressionWindowPageMeta{DomainID: domainID, Limit: 100},
+			total: uint64(num + 1),
+			size:  num + 1,
+		},
+		{
+			desc:  "list suppression windows with limit",
+			pm:    alarms.SuppressionWindowPageMeta{DomainID: domainID, Limit: 5},
+			total: uint64(num + 1),
+			size:  5,
+		},
+		{
+			desc:  "list suppression windows by client",
+			pm:    alarms.SuppressionWindowPageMeta{DomainID: domainID, ClientID: client.ClientID, Limit: 100},
+			total: 1,
+			size:  1,
+		},
+		{
+			desc:  "list suppression windows of another domain",
+			pm:    alarms.SuppressionWindowPageMeta{DomainID: generateUUID(t), Limit: 100},
+			total: 0,
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListSuppressionWindows(context.Background(), tc.pm)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+			assert.Len(t, page.Windows, tc.size, fmt.Sprintf("%s: expected %d windows got %d\n", tc.desc, tc.size, len(page.Windows)))
+		})
+	}
+}
+
+func TestUpdateSuppressionWindow(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM suppression_windows")
+		require.Nil(t, err, fmt.Sprintf("clean suppression windows unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	window, err := repo.CreateSuppressionWindow(context.Background(), newWindow(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := window
+	updated.Reason = "firmware upgrade"
+	updated.EndsAt = window.EndsAt.Add(time.Hour)
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	updated.UpdatedBy = generateUUID(t)
+
+	other := updated
+	other.DomainID = generateUUID(t)
+
+	cases := []struct {
+		desc   string
+		window alarms.SuppressionWindow
+		err    error
+	}{
+		{
+			desc:   "update suppression window successfully",
+			window: updated,
+		},
+		{
+			desc:   "update suppression window of another domain",
+			window: other,
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			w, err := repo.UpdateSuppressionWindow(context.Background(), tc.window)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.window, w, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.window, w))
+			}
+		})
+	}
+}
+
+func TestDeleteSuppressionWindow(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM suppression_windows")
+		require.Nil(t, err, fmt.Sprintf("clean suppression windows unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	window, err := repo.CreateSuppressionWindow(context.Background(), newWindow(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		domainID string
+		err      error
+	}{
+		{
+			desc:     "delete suppression window of another domain",
+			id:       window.ID,
+			domainID: generateUUID(t),
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "delete suppression window successfully",
+			id:       window.ID,
+			domainID: window.DomainID,
+		},
+		{
+			desc:     "delete already deleted suppression window",
+			id:       window.ID,
+			domainID: window.DomainID,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.DeleteSuppressionWindow(context.Background(), tc.id, tc.domainID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestMatchSuppressionWindows(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM suppression_windows")
+		require.Nil(t, err, fmt.Sprintf("clean suppression windows unexpected error: %s", err))
+	})
+
+	repo := postgres.NewAlarmsRepo(db)
+	window, err := repo.CreateSuppressionWindow(context.Background(), newWindow(t, generateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	alarm := alarms.Alarm{
+		DomainID:  window.DomainID,
+		ChannelID: window.ChannelID,
+		ClientID:  generateUUID(t),
+		CreatedAt: window.StartsAt.Add(time.Minute),
+	}
+
+	cases := []struct {
+		desc  string
+		alarm func(alarms.Alarm) alarms.Alarm
+		size  int
+	}{
+		{
+			desc:  "match alarm of the channel during the window",
+			alarm: func(a alarms.Alarm) alarms.Alarm { return a },
+			size:  1,
+		},
+		{
+			desc: "match alarm of another channel",
+			alarm: func(a alarms.Alarm) alarms.Alarm {
+				a.ChannelID = generateUUID(t)
+				return a
+			},
+			size: 0,
+		},
+		{
+			desc: "match alarm after the window",
+			alarm: func(a alarms.Alarm) alarms.Alarm {
+				a.CreatedAt = window.EndsAt
+				return a
+			},
+			size: 0,
+		},
+		{
+			desc: "match alarm of another domain",
+			alarm: func(a alarms.Alarm) alarms.Alarm {
+				a.DomainID = generateUUID(t)
+				return a
+			},
+			size: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			windows, err := repo.MatchSuppressionWindows(context.Background(), tc.alarm(alarm))
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Len(t, windows, tc.size, fmt.Sprintf("%s: expected %d windows got %d\n", tc.desc, tc.size, len(windows)))
+		})
+	}
+}
+
+func newWindow(t *testing.T, domainID string) alarms.SuppressionWindow {
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	return alarms.SuppressionWindow{
+		ID:        generateUUID(t),
+		Name:      namegen.Generate(),
+		DomainID:  domainID,
+		ChannelID: generateUUID(t),
+		Reason:    "maintenance",
+		StartsAt:  start,
+		EndsAt:    start.Add(time.Hour),
+		CreatedAt: start,
+		CreatedBy: generateUUID(t),
+	}
+}
@@ -9,20 +9,33 @@ import (
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 )
 
+var (
+	errStartEscalations  = errors.New("failed to start alarm escalations")
+	errCancelEscalations = errors.New("failed to cancel alarm escalations")
+	errDetectFlapping    = errors.New("failed to detect alarm flapping")
+	errMatchSuppressions = errors.New("failed to match alarm suppression windows")
+	errRecordActivity    = errors.New("failed to record alarm activity")
+)
+
 type service struct {
-	idp  magistrala.IDProvider
-	repo Repository
+	idp    magistrala.IDProvider
+	repo   Repository
+	stream *Stream
+	flap   FlapConfig
 }
 
 var _ Service = (*service)(nil)
 
-func NewService(idp magistrala.IDProvider, repo Repository) Service {
+func NewService(idp magistrala.IDProvider, repo Repository, stream *Stream, flap FlapConfig) Service {
 	return &service{
-		idp:  idp,
-		repo: repo,
+		idp:    idp,
+		repo:   repo,
+		stream: stream,
+		flap:   flap,
 	}
 }
 
@@ -40,7 +53,32 @@ func (s *service) CreateAlarm(ctx context.Context, alarm Alarm) (Alarm, error) {
 		return Alarm{}, err
 	}
 
-	created, err := s.repo.CreateAlarm(ctx, alarm)
+	// Flapping sources are not stored until they are stable again.
+	flapping, err := s.flapping(ctx, alarm)
+	if err != nil {
+		return Alarm{}, errors.Wrap(errDetectFlapping, err)
+	}
+	if flapping {
+		return Alarm{}, nil
+	}
+	if alarm.Status == ActiveStatus {
+		suppressed, err := s.suppressed(ctx, alarm)
+		if err != nil {
+			return Alarm{}, errors.Wrap(errMatchSuppressions, err)
+		}
+		if suppressed {
+			alarm.Status = SuppressedStatus
+		}
+	}
+
+	var escs []Escalation
+	if alarm.Status == ActiveStatus {
+		if escs, err = s.escalations(ctx, alarm); err != nil {
+			return Alarm{}, errors.Wrap(errStartEscalations, err)
+		}
+	}
+
+	created, err := s.repo.CreateAlarm(ctx, alarm, escs)
 	if err != nil && err != repoerr.ErrNotFound {
 		return Alarm{}, err
 	}
@@ -60,13 +98,250 @@ func (s *service) ListAlarms(ctx context.Context, session authn.Session, pm Page
 	return s.repo.ListAllAlarms(ctx, pm)
 }
 
+func (s *service) AlarmStats(ctx context.Context, session authn.Session, pm StatsPageMeta) (Stats, error) {
+	pm.DomainID = session.DomainID
+	return s.repo.AlarmStats(ctx, pm)
+}
+
 func (s *service) DeleteAlarm(ctx context.Context, session authn.Session, alarmID string) error {
 	return s.repo.DeleteAlarm(ctx, alarmID)
 }
 
 func (s *service) UpdateAlarm(ctx context.Context, session authn.Session, alarm Alarm) (Alarm, error) {
-	alarm.UpdatedAt = time.Now()
+	now := time.Now()
+	if alarm.Status == ClearedStatus {
+		alarm.ClearedAt = now
+	}
+	alarm.UpdatedAt = now
 	alarm.UpdatedBy = session.UserID
 
-	return s.repo.UpdateAlarm(ctx, alarm)
+	updated, err := s.repo.UpdateAlarm(ctx, alarm, s.activities(func(updated Alarm) []Activity {
+		return updateActivities(session, alarm, updated)
+	}))
+	if err != nil {
+		return Alarm{}, err
+	}
+	// Acknowledged and resolved alarms are no longer escalated.
+	if alarm.AcknowledgedBy != "" || alarm.ResolvedBy != "" {
+		if err := s.repo.RemoveAlarmEscalations(ctx, alarm.ID); err != nil {
+			return Alarm{}, errors.Wrap(errCancelEscalations, err)
+		}
+	}
+
+	return updated, nil
+}
+
+func (s *service) ShelveAlarm(ctx context.Context, session authn.Session, id string, shelve Shelve) (Alarm, error) {
+	now := time.Now()
+	shelved, err := s.repo.ShelveAlarm(ctx, Alarm{
+		ID:           id,
+		DomainID:     session.DomainID,
+		ShelvedUntil: shelve.Until,
+		ShelvedAt:    now,
+		ShelvedBy:    session.UserID,
+		UpdatedAt:    now,
+		UpdatedBy:    session.UserID,
+	}, s.activities(func(Alarm) []Activity {
+		return []Activity{{
+			AlarmID:   id,
+			DomainID:  session.DomainID,
+			Type:      ShelveActivity,
+			Details:   map[string]any{"until": shelve.Until},
+			CreatedAt: now,
+			CreatedBy: session.UserID,
+		}}
+	}))
+	if err != nil {
+		return Alarm{}, err
+	}
+
+	return shelved, nil
+}
+
+func (s *service) UnshelveAlarm(ctx context.Context, session authn.Session, id string) (Alarm, error) {
+	now := time.Now()
+	unshelved, err := s.repo.ShelveAlarm(ctx, Alarm{
+		ID:        id,
+		DomainID:  session.DomainID,
+		UpdatedAt: now,
+		UpdatedBy: session.UserID,
+	}, s.activities(func(Alarm) []Activity {
+		return []Activity{{
+			AlarmID:   id,
+			DomainID:  session.DomainID,
+			Type:      UnshelveActivity,
+			CreatedAt: now,
+			CreatedBy: session.UserID,
+		}}
+	}))
+	if err != nil {
+		return Alarm{}, err
+	}
+
+	return unshelved, nil
+}
+
+// ClearAlarms follows the condition of the source, so it clears the alarms
+// in any state, including the shelved and the resolved ones. Otherwise, the
+// next alarm of the source would be taken for a duplicate.
+func (s *service) ClearAlarms(ctx context.Context, alarm Alarm) ([]Alarm, error) {
+	if err := alarm.ValidateSource(); err != nil {
+		return nil, err
+	}
+	alarm.ClearedAt = alarm.CreatedAt
+	if alarm.ClearedAt.IsZero() {
+		alarm.ClearedAt = time.Now()
+	}
+
+	cleared, err := s.repo.ClearAlarms(ctx, alarm, s.activities(func(a Alarm) []Activity {
+		return []Activity{{
+			AlarmID:   a.ID,
+			DomainID:  a.DomainID,
+			Type:      StatusActivity,
+			Details:   map[string]any{"status": a.Status.String()},
+			CreatedAt: alarm.ClearedAt,
+		}}
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return cleared, nil
+}
+
+func (s *service) BulkUpdateAlarms(ctx context.Context, session authn.Session, req BulkRequest) (BulkResult, error) {
+	pm := req.Filter
+	pm.DomainID = session.DomainID
+	pm.AlarmIDs = req.AlarmIDs
+	pm.Offset = 0
+	pm.Limit = MaxBulkAlarms
+
+	if req.Action == DeleteAction {
+		deleted, err := s.repo.DeleteAlarms(ctx, pm)
+		if err != nil {
+			return BulkResult{}, err
+		}
+		return newBulkResult(req, deleted), nil
+	}
+
+	update := req.update(session, time.Now())
+	updated, err := s.repo.UpdateAlarms(ctx, pm, update, s.activities(func(a Alarm) []Activity {
+		return updateActivities(session, update, a)
+	}))
+	if err != nil {
+		return BulkResult{}, err
+	}
+
+	return newBulkResult(req, updated), nil
+}
+
+func (s *service) AddComment(ctx context.Context, session authn.Session, alarmID string, comment Comment) (Activity, error) {
+	if _, err := s.repo.ViewAlarm(ctx, alarmID, session.DomainID); err != nil {
+		return Activity{}, err
+	}
+	id, err := s.idp.ID()
+	if err != nil {
+		return Activity{}, err
+	}
+	act := Activity{
+		ID:        id,
+		AlarmID:   alarmID,
+		DomainID:  session.DomainID,
+		Type:      CommentActivity,
+		Content:   comment.Content,
+		Links:     comment.Links,
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: session.UserID,
+	}
+	if err := s.repo.AddActivities(ctx, []Activity{act}); err != nil {
+		return Activity{}, err
+	}
+
+	return act, nil
+}
+
+func (s *service) ListComments(ctx context.Context, session authn.Session, alarmID string, pm ActivityPageMeta) (ActivitiesPage, error) {
+	pm.AlarmID = alarmID
+	pm.DomainID = session.DomainID
+	pm.Type = CommentActivity
+	return s.repo.ListActivities(ctx, pm)
+}
+
+func (s *service) AlarmTimeline(ctx context.Context, session authn.Session, alarmID string, pm ActivityPageMeta) (ActivitiesPage, error) {
+	pm.AlarmID = alarmID
+	pm.DomainID = session.DomainID
+	return s.repo.AlarmTimeline(ctx, pm)
+}
+
+func (s *service) CreateEscalationPolicy(ctx context.Context, session authn.Session, policy EscalationPolicy) (EscalationPolicy, error) {
+	id, err := s.idp.ID()
+	if err != nil {
+		return EscalationPolicy{}, err
+	}
+	policy.ID = id
+	policy.DomainID = session.DomainID
+	policy.CreatedAt = time.Now().UTC()
+	policy.CreatedBy = session.UserID
+
+	return s.repo.CreateEscalationPolicy(ctx, policy)
+}
+
+func (s *service) ViewEscalationPolicy(ctx context.Context, session authn.Session, id string) (EscalationPolicy, error) {
+	return s.repo.ViewEscalationPolicy(ctx, id, session.DomainID)
+}
+
+func (s *service) ListEscalationPolicies(ctx context.Context, session authn.Session, pm EscalationPolicyPageMeta) (EscalationPoliciesPage, error) {
+	pm.DomainID = session.DomainID
+	return s.repo.ListEscalationPolicies(ctx, pm)
+}
+
+func (s *service) UpdateEscalationPolicy(ctx context.Context, session authn.Session, policy EscalationPolicy) (EscalationPolicy, error) {
+	policy.DomainID = session.DomainID
+	policy.UpdatedAt = time.Now().UTC()
+	policy.UpdatedBy = session.UserID
+
+	return s.repo.UpdateEscalationPolicy(ctx, policy)
+}
+
+func (s *service) DeleteEscalationPolicy(ctx context.Context, session authn.Session, id string) error {
+	return s.repo.DeleteEscalationPolicy(ctx, id, session.DomainID)
+}
+
+func (s *service) CreateSuppressionWindow(ctx context.Context, session authn.Session, window SuppressionWindow) (SuppressionWindow, error) {
+	id, err := s.idp.ID()
+	if err != nil {
+		return SuppressionWindow{}, err
+	}
+	window.ID = id
+	window.DomainID = session.DomainID
+	window.CreatedAt = time.Now().UTC()
+	window.CreatedBy = session.UserID
+
+	return s.repo.CreateSuppressionWindow(ctx, window)
+}
+
+func (s *service) ViewSuppressionWindow(ctx context.Context, session authn.Session, id string) (SuppressionWindow, error) {
+	return s.repo.ViewSuppressionWindow(ctx, id, session.DomainID)
+}
+
+func (s *service) ListSuppressionWindows(ctx context.Context, session authn.Session, pm SuppressionWindowPageMeta) (SuppressionWindowsPage, error) {
+	pm.DomainID = session.DomainID
+	return s.repo.ListSuppressionWindows(ctx, pm)
+}
+
+func (s *service) UpdateSuppressionWindow(ctx context.Context, session authn.Session, window SuppressionWindow) (SuppressionWindow, error) {
+	window.DomainID = session.DomainID
+	window.UpdatedAt = time.Now().UTC()
+	window.UpdatedBy = session.UserID
+
+	return s.repo.UpdateSuppressionWindow(ctx, window)
+}
+
+func (s *service) DeleteSuppressionWindow(ctx context.Context, session authn.Session, id string) error {
+	return s.repo.DeleteSuppressionWindow(ctx, id, session.DomainID)
+}
+
+func (s *service) StreamAlarms(ctx context.Context, session authn.Session, filter StreamFilter) (<-chan Event, error) {
+	filter.DomainID = session.DomainID
+	return s.stream.Subscribe(ctx, filter), nil
 }
@@ -22,35 +22,81 @@ import (
 var idp = uuid.New()
 
 func newService(t *testing.T, repo *mocks.Repository) alarms.Service {
-	return alarms.NewService(idp, repo)
+	return alarms.NewService(idp, repo, alarms.NewStream(alarms.DefStreamBuffer), alarms.FlapConfig{})
 }
 
 func TestCreateAlarm(t *testing.T) {
 	repo := new(mocks.Repository)
 	svc := newService(t, repo)
 	ts := time.Now()
+	alarm := alarms.Alarm{
+		RuleID:      "rule-id",
+		DomainID:    "domain-id",
+		ChannelID:   "channel-id",
+		ClientID:    "client-id",
+		Subtopic:    "subtopic",
+		Measurement: "measurement",
+		Value:       "value",
+		Unit:        "unit",
+		Cause:       "cause",
+		Severity:    100,
+		CreatedAt:   ts,
+	}
+	policy := alarms.EscalationPolicy{
+		ID: "policy-id",
+		Steps: []alarms.EscalationStep{
+			{After: time.Minute, Action: alarms.ReassignAction, AssigneeID: "assignee-id"},
+		},
+	}
+	cleared := alarm
+	cleared.Status = alarms.ClearedStatus
+	window := alarms.SuppressionWindow{ID: "window-id", ChannelID: alarm.ChannelID}
+
 	cases := []struct {
-		desc  string
-		alarm alarms.Alarm
-		err   error
+		desc        string
+		alarm       alarms.Alarm
+		windows     []alarms.SuppressionWindow
+		suppressErr error
+		status      alarms.Status
+		repoErr     error
+		policies    []alarms.EscalationPolicy
+		matchErr    error
+		escalations []alarms.Escalation
+		err         error
 	}{
 		{
-			desc: "valid alarm",
-			alarm: alarms.Alarm{
-				RuleID:      "rule-id",
-				DomainID:    "domain-id",
-				ChannelID:   "channel-id",
-				ClientID:    "client-id",
-				Subtopic:    "subtopic",
-				Measurement: "measurement",
-				Value:       "value",
-				Unit:        "unit",
-				Cause:       "cause",
-				Severity:    100,
-				CreatedAt:   ts,
+			desc:  "valid alarm",
+			alarm: alarm,
+			err:   nil,
+		},
+		{
+			desc:    "valid alarm during suppression window",
+			alarm:   alarm,
+			windows: []alarms.SuppressionWindow{window},
+			status:  alarms.SuppressedStatus,
+			err:     nil,
+		},
+		{
+			desc:        "valid alarm with failed suppression windows match",
+			alarm:       alarm,
+			suppressErr: repoerr.ErrViewEntity,
+			err:         repoerr.ErrViewEntity,
+		},
+		{
+			desc:     "valid alarm with matching escalation policy",
+			alarm:    alarm,
+			policies: []alarms.EscalationPolicy{policy},
+			escalations: []alarms.Escalation{
+				{PolicyID: policy.ID, DomainID: alarm.DomainID, NextAt: ts.Add(time.Minute)},
 			},
 			err: nil,
 		},
+		{
+			desc:   "cleared alarm",
+			alarm:  cleared,
+			status: alarms.ClearedStatus,
+			err:    nil,
+		},
 		{
 			desc: "missing rule_id",
 			alarm: alarms.Alarm{
@@ -67,14 +113,117 @@ func TestCreateAlarm(t *testing.T) {
 			},
 			err: errors.New("rule_id is required"),
 		},
+		{
+			desc:     "valid alarm with failed escalation policies match",
+			alarm:    alarm,
+			matchErr: repoerr.ErrViewEntity,
+			err:      repoerr.ErrViewEntity,
+		},
+		{
+			desc:     "valid alarm with matching escalation policy with failed repo create",
+			alarm:    alarm,
+			policies: []alarms.EscalationPolicy{policy},
+			repoErr:  repoerr.ErrCreateEntity,
+			err:      repoerr.ErrCreateEntity,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			repoCall := repo.On("CreateAlarm", context.Background(), mock.Anything).Return(tc.alarm, tc.err)
+			var stored alarms.Alarm
+			var escs []alarms.Escalation
+			matched := false
+			repoCall := repo.On("MatchSuppressionWindows", context.Background(), mock.Anything).Return(tc.windows, tc.suppressErr)
+			repoCall1 := repo.On("CreateAlarm", context.Background(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				stored = args.Get(1).(alarms.Alarm)
+				escs = args.Get(2).([]alarms.Escalation)
+			}).Return(tc.alarm, tc.repoErr)
+			repoCall2 := repo.On("MatchEscalationPolicies", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				matched = true
+			}).Return(tc.policies, tc.matchErr)
 			_, err := svc.CreateAlarm(context.Background(), tc.alarm)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.status, stored.Status, fmt.Sprintf("%s: expected stored status %s got %s\n", tc.desc, tc.status, stored.Status))
+				for i := range tc.escalations {
+					tc.escalations[i].AlarmID = stored.ID
+				}
+				assert.Equal(t, tc.escalations, escs, fmt.Sprintf("%s: expected escalations %v got %v\n", tc.desc, tc.escalations, escs))
+			}
+			if tc.alarm.Status != alarms.ActiveStatus {
+				assert.False(t, matched, fmt.Sprintf("%s: expected escalation policies not to be matched\n", tc.desc))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
+func TestCreateFlappingAlarm(t *testing.T) {
+	flap := alarms.FlapConfig{Threshold: 3, Window: 10 * time.Minute}
+	ts := time.Now()
+	alarm := alarms.Alarm{
+		RuleID:      "rule-id",
+		DomainID:    "domain-id",
+		ChannelID:   "channel-id",
+		ClientID:    "client-id",
+		Measurement: "measurement",
+		Value:       "value",
+		Cause:       "cause",
+		Severity:    50,
+		Status:      alarms.ClearedStatus,
+		CreatedAt:   ts,
+	}
+
+	cases := []struct {
+		desc     string
+		changes  uint64
+		countErr error
+		markErr  error
+		stored   bool
+		err      error
+	}{
+		{
+			desc:    "alarm of stable source",
+			changes: 2,
+			stored:  true,
+		},
+		{
+			desc:    "alarm of flapping source",
+			changes: 3,
+		},
+		{
+			desc:     "alarm with failed state changes count",
+			countErr: repoerr.ErrViewEntity,
+			err:      repoerr.ErrViewEntity,
+		},
+		{
+			desc:    "alarm with failed flapping mark",
+			changes: 3,
+			markErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.Repository)
+			svc := alarms.NewService(idp, repo, alarms.NewStream(alarms.DefStreamBuffer), flap)
+			repoCall := repo.On("CountStateChanges", context.Background(), mock.Anything, ts.Add(-flap.Window)).Return(tc.changes, tc.countErr)
+			repoCall1 := repo.On("MarkFlapping", context.Background(), mock.Anything).Return(tc.markErr)
+			repoCall2 := repo.On("CreateAlarm", context.Background(), mock.Anything, mock.Anything).Return(alarm, nil)
+			_, err := svc.CreateAlarm(context.Background(), alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.stored {
+				repo.AssertNotCalled(t, "MarkFlapping", context.Background(), mock.Anything)
+				repo.AssertCalled(t, "CreateAlarm", context.Background(), mock.Anything, mock.Anything)
+			} else {
+				repo.AssertNotCalled(t, "CreateAlarm", context.Background(), mock.Anything, mock.Anything)
+			}
 			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
 		})
 	}
 }
@@ -121,57 +270,137 @@ func TestViewAlarm(t *testing.T) {
 func TestUpdateAlarm(t *testing.T) {
 	repo := new(mocks.Repository)
 	svc := newService(t, repo)
+	alarm := alarms.Alarm{
+		ID:          "alarm-id",
+		RuleID:      "rule-id",
+		DomainID:    "domain-id",
+		ChannelID:   "channel-id",
+		ClientID:    "client-id",
+		Subtopic:    "subtopic",
+		Measurement: "measurement",
+		Value:       "value",
+		Unit:        "unit",
+		Cause:       "cause",
+		Severity:    100,
+	}
+	acknowledged := alarm
+	acknowledged.AcknowledgedBy = "user-id"
+	acknowledged.AcknowledgedAt = time.Now()
+	resolved := alarm
+	resolved.ResolvedBy = "user-id"
+	resolved.ResolvedAt = time.Now()
+	assigned := alarm
+	assigned.AssigneeID = "assignee-id"
+	cleared := alarm
+	cleared.Status = alarms.ClearedStatus
+	shelved := alarm
+	shelved.ShelvedUntil = time.Now().Add(time.Hour)
 
 	cases := []struct {
-		desc  string
-		alarm alarms.Alarm
-		err   error
+		desc       string
+		alarm      alarms.Alarm
+		repoErr    error
+		cancel     bool
+		cancelErr  error
+		activities []alarms.ActivityType
+		err        error
 	}{
 		{
-			desc: "valid alarm",
-			alarm: alarms.Alarm{
-				RuleID:      "rule-id",
-				DomainID:    "domain-id",
-				ChannelID:   "channel-id",
-				ClientID:    "client-id",
-				Subtopic:    "subtopic",
-				Measurement: "measurement",
-				Value:       "value",
-				Unit:        "unit",
-				Cause:       "cause",
-				Severity:    100,
-			},
-			err: nil,
+			desc:  "valid alarm",
+			alarm: alarm,
+			err:   nil,
 		},
 		{
-			desc: "non existing alarm",
-			alarm: alarms.Alarm{
-				RuleID:      "rule-id",
-				DomainID:    "domain-id",
-				ChannelID:   "channel-id",
-				ClientID:    "client-id",
-				Subtopic:    "subtopic",
-				Measurement: "measurement",
-				Value:       "value",
-				Unit:        "unit",
-				Cause:       "cause",
-				Severity:    100,
-			},
-			err: repoerr.ErrNotFound,
+			desc:    "non existing alarm",
+			alarm:   alarm,
+			repoErr: repoerr.ErrNotFound,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:       "acknowledge alarm",
+			alarm:      acknowledged,
+			cancel:     true,
+			activities: []alarms.ActivityType{alarms.AcknowledgeActivity},
+			err:        nil,
+		},
+		{
+			desc:       "resolve alarm",
+			alarm:      resolved,
+			cancel:     true,
+			activities: []alarms.ActivityType{alarms.ResolveActivity},
+			err:        nil,
+		},
+		{
+			desc:       "assign alarm",
+			alarm:      assigned,
+			activities: []alarms.ActivityType{alarms.AssignActivity},
+			err:        nil,
+		},
+		{
+			desc:       "clear alarm",
+			alarm:      cleared,
+			activities: []alarms.ActivityType{alarms.StatusActivity},
+			err:        nil,
+		},
+		{
+			desc:       "acknowledge alarm in invalid state",
+			alarm:      acknowledged,
+			activities: []alarms.ActivityType{alarms.AcknowledgeActivity},
+			repoErr:    alarms.ErrInvalidTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "clear alarm in invalid state",
+			alarm:      cleared,
+			activities: []alarms.ActivityType{alarms.StatusActivity},
+			repoErr:    alarms.ErrInvalidTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "acknowledge non existing alarm",
+			alarm:      acknowledged,
+			activities: []alarms.ActivityType{alarms.AcknowledgeActivity},
+			repoErr:    repoerr.ErrNotFound,
+			err:        repoerr.ErrNotFound,
+		},
+		{
+			desc:       "acknowledge alarm with failed activity record",
+			alarm:      acknowledged,
+			activities: []alarms.ActivityType{alarms.AcknowledgeActivity},
+			repoErr:    repoerr.ErrCreateEntity,
+			err:        repoerr.ErrCreateEntity,
+		},
+		{
+			desc:       "acknowledge alarm with failed escalations cancel",
+			alarm:      acknowledged,
+			cancel:     true,
+			cancelErr:  repoerr.ErrRemoveEntity,
+			activities: []alarms.ActivityType{alarms.AcknowledgeActivity},
+			err:        repoerr.ErrRemoveEntity,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			s := authn.Session{DomainID: tc.alarm.DomainID}
-			repoCall := repo.On("UpdateAlarm", context.Background(), mock.Anything).Return(tc.alarm, tc.err)
+			var recorded []alarms.ActivityType
+			repoCall := repo.On("UpdateAlarm", context.Background(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				acts, err := args.Get(2).(alarms.ActivitiesFunc)([]alarms.Alarm{tc.alarm})
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				for _, a := range acts {
+					recorded = append(recorded, a.Type)
+				}
+			}).Return(tc.alarm, tc.repoErr)
+			repoCall1 := repo.On("RemoveAlarmEscalations", context.Background(), tc.alarm.ID).Return(tc.cancelErr)
 			_, err := svc.UpdateAlarm(context.Background(), s, tc.alarm)
-			if tc.err != nil {
-				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-
-				return
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.activities, recorded, fmt.Sprintf("%s: expected activities %v got %v\n", tc.desc, tc.activities, recorded))
+			if tc.cancel {
+				repo.AssertCalled(t, "RemoveAlarmEscalations", context.Background(), tc.alarm.ID)
 			}
 			repoCall.Unset()
+			repoCall1.Unset()
+			repo.Calls = nil
 		})
 	}
 }
@@ -217,6 +446,47 @@ func TestListAlarms(t *testing.T) {
 	}
 }
 
+func TestAlarmStats(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id"}
+	to := time.Now().UTC()
+	pm := alarms.StatsPageMeta{From: to.Add(-24 * time.Hour), To: to, Interval: time.Hour, Top: 10}
+	expectedPM := pm
+	expectedPM.DomainID = session.DomainID
+
+	cases := []struct {
+		desc  string
+		stats alarms.Stats
+		err   error
+	}{
+		{
+			desc: "alarm stats successfully",
+			stats: alarms.Stats{
+				From:     pm.From,
+				To:       pm.To,
+				Total:    3,
+				MTTA:     60,
+				ByStatus: []alarms.Count{{Key: "active", Count: 2}, {Key: "cleared", Count: 1}},
+			},
+		},
+		{
+			desc: "alarm stats with failed repo",
+			err:  repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("AlarmStats", context.Background(), expectedPM).Return(tc.stats, tc.err)
+			stats, err := svc.AlarmStats(context.Background(), session, pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.stats, stats, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.stats, stats))
+			repoCall.Unset()
+		})
+	}
+}
+
 func TestDeleteAlarm(t *testing.T) {
 	repo := new(mocks.Repository)
 	svc := newService(t, repo)
@@ -252,3 +522,216 @@ func TestDeleteAlarm(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamAlarms(t *testing.T) {
+	repo := new(mocks.Repository)
+	stream := alarms.NewStream(alarms.DefStreamBuffer)
+	svc := alarms.NewService(idp, repo, stream, alarms.FlapConfig{})
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The domain filter is always set from the session.
+	events, err := svc.StreamAlarms(ctx, session, alarms.StreamFilter{DomainID: "other-domain-id", Status: alarms.AllStatus})
+	assert.Nil(t, err, fmt.Sprintf("stream alarms: unexpected error %s", err))
+
+	other := alarms.Event{Type: alarms.CreateEvent, Alarm: alarms.Alarm{ID: "other-alarm-id", DomainID: "other-domain-id"}}
+	event := alarms.Event{Type: alarms.CreateEvent, Alarm: alarms.Alarm{ID: "alarm-id", DomainID: session.DomainID}}
+	stream.Publish(other)
+	stream.Publish(event)
+
+	select {
+	case got := <-events:
+		assert.Equal(t, event, got, fmt.Sprintf("stream alarms: expected %v got %v", event, got))
+	case <-time.After(time.Second):
+		assert.Fail(t, "stream alarms: expected event of the session domain")
+	}
+}
+
+func TestCreateEscalationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+	policy := alarms.EscalationPolicy{
+		Name: "policy",
+		Steps: []alarms.EscalationStep{
+			{After: time.Minute, Action: alarms.ReassignAction, AssigneeID: "assignee-id"},
+		},
+	}
+
+	cases := []struct {
+		desc    string
+		policy  alarms.EscalationPolicy
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "create escalation policy successfully",
+			policy: policy,
+			err:    nil,
+		},
+		{
+			desc:    "create escalation policy with failed repo",
+			policy:  policy,
+			repoErr: repoerr.ErrCreateEntity,
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var saved alarms.EscalationPolicy
+			repoCall := repo.On("CreateEscalationPolicy", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(alarms.EscalationPolicy)
+			}).Return(tc.policy, tc.repoErr)
+			_, err := svc.CreateEscalationPolicy(context.Background(), session, tc.policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected policy id to be set", tc.desc))
+			assert.Equal(t, session.DomainID, saved.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, session.DomainID, saved.DomainID))
+			assert.Equal(t, session.UserID, saved.CreatedBy, fmt.Sprintf("%s: expected creator %s got %s\n", tc.desc, session.UserID, saved.CreatedBy))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewEscalationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id"}
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "view escalation policy successfully",
+			id:   "policy-id",
+			err:  nil,
+		},
+		{
+			desc: "view non-existing escalation policy",
+			id:   "policy-id",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ViewEscalationPolicy", context.Background(), tc.id, session.DomainID).Return(alarms.EscalationPolicy{}, tc.err)
+			_, err := svc.ViewEscalationPolicy(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListEscalationPolicies(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id"}
+
+	cases := []struct {
+		desc string
+		pm   alarms.EscalationPolicyPageMeta
+		page alarms.EscalationPoliciesPage
+		err  error
+	}{
+		{
+			desc: "list escalation policies successfully",
+			pm:   alarms.EscalationPolicyPageMeta{Limit: 10},
+			page: alarms.EscalationPoliciesPage{Limit: 10, Policies: []alarms.EscalationPolicy{}},
+			err:  nil,
+		},
+		{
+			desc: "list escalation policies with failed repo",
+			pm:   alarms.EscalationPolicyPageMeta{Limit: 10},
+			err:  repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := tc.pm
+			pm.DomainID = session.DomainID
+			repoCall := repo.On("ListEscalationPolicies", context.Background(), pm).Return(tc.page, tc.err)
+			page, err := svc.ListEscalationPolicies(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.page, page))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateEscalationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+	policy := alarms.EscalationPolicy{
+		ID:   "policy-id",
+		Name: "policy",
+		Steps: []alarms.EscalationStep{
+			{After: time.Minute, Action: alarms.SeverityAction, Severity: 90},
+		},
+	}
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "update escalation policy successfully",
+			err:  nil,
+		},
+		{
+			desc: "update non-existing escalation policy",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var saved alarms.EscalationPolicy
+			repoCall := repo.On("UpdateEscalationPolicy", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(alarms.EscalationPolicy)
+			}).Return(policy, tc.err)
+			_, err := svc.UpdateEscalationPolicy(context.Background(), session, policy)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, session.DomainID, saved.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, session.DomainID, saved.DomainID))
+			assert.Equal(t, session.UserID, saved.UpdatedBy, fmt.Sprintf("%s: expected updater %s got %s\n", tc.desc, session.UserID, saved.UpdatedBy))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDeleteEscalationPolicy(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id"}
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "delete escalation policy successfully",
+			id:   "policy-id",
+			err:  nil,
+		},
+		{
+			desc: "delete non-existing escalation policy",
+			id:   "policy-id",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("DeleteEscalationPolicy", context.Background(), tc.id, session.DomainID).Return(tc.err)
+			err := svc.DeleteEscalationPolicy(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
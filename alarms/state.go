@@ -0,0 +1,148 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// MaxShelveDuration is the longest time an alarm can be shelved for.
+const MaxShelveDuration = 30 * 24 * time.Hour
+
+var (
+	// ErrInvalidTransition indicates that the alarm can not make the
+	// requested change in its current state.
+	ErrInvalidTransition = errors.NewRequestError("invalid alarm state transition")
+
+	errShelveExpiry = errors.New("shelve expiry must be in the future and within 30 days")
+	errInvalidState = errors.New("invalid alarm state")
+)
+
+// State is the state of an alarm, derived from its status, acknowledgement
+// and shelving.
+type State string
+
+const (
+	ActiveUnackedState  State = "active_unacked"
+	ActiveAckedState    State = "active_acked"
+	ClearedUnackedState State = "cleared_unacked"
+	ClearedAckedState   State = "cleared_acked"
+	// ShelvedState hides the alarm from operators until the shelving expires.
+	ShelvedState State = "shelved"
+)
+
+// ToState converts string value to a valid alarm state.
+func ToState(state string) (State, error) {
+	switch s := State(strings.ToLower(state)); s {
+	case ActiveUnackedState, ActiveAckedState, ClearedUnackedState, ClearedAckedState, ShelvedState:
+		return s, nil
+	default:
+		return "", errInvalidState
+	}
+}
+
+// Transition is a change of the alarm state.
+type Transition string
+
+const (
+	AcknowledgeTransition Transition = "acknowledge"
+	ClearTransition       Transition = "clear"
+	ShelveTransition      Transition = "shelve"
+	UnshelveTransition    Transition = "unshelve"
+	ResolveTransition     Transition = "resolve"
+)
+
+// transitions lists the changes allowed in each state. The condition of a
+// shelved alarm may still return to normal, so a shelved alarm can be
+// cleared. Resolution closes the alarm and allows no further changes.
+var transitions = map[State][]Transition{
+	ActiveUnackedState:  {AcknowledgeTransition, ClearTransition, ShelveTransition, ResolveTransition},
+	ActiveAckedState:    {ClearTransition, ShelveTransition, ResolveTransition},
+	ClearedUnackedState: {AcknowledgeTransition, ShelveTransition, ResolveTransition},
+	ClearedAckedState:   {ResolveTransition},
+	ShelvedState:        {ClearTransition, UnshelveTransition, ResolveTransition},
+}
+
+// Shelved reports whether the alarm is shelved at the given time.
+func (a Alarm) Shelved(at time.Time) bool {
+	return a.ShelvedUntil.After(at)
+}
+
+// Resolved reports whether the alarm is resolved.
+func (a Alarm) Resolved() bool {
+	return a.ResolvedBy != "" || !a.ResolvedAt.IsZero()
+}
+
+// StateAt returns the state of the alarm at the given time. Suppressed
+// alarms are active alarms raised during a suppression window.
+func (a Alarm) StateAt(at time.Time) State {
+	acked := a.AcknowledgedBy != "" || !a.AcknowledgedAt.IsZero()
+	switch {
+	case a.Shelved(at):
+		return ShelvedState
+	case a.Status == ClearedStatus && acked:
+		return ClearedAckedState
+	case a.Status == ClearedStatus:
+		return ClearedUnackedState
+	case acked:
+		return ActiveAckedState
+	default:
+		return ActiveUnackedState
+	}
+}
+
+// CanTransition returns ErrInvalidTransition unless the alarm can make the
+// transition at the given time.
+func (a Alarm) CanTransition(t Transition, at time.Time) error {
+	if a.Resolved() || !slices.Contains(transitions[a.StateAt(at)], t) {
+		return ErrInvalidTransition
+	}
+
+	return nil
+}
+
+// TransitionStates returns the states in which the transition is allowed.
+func TransitionStates(t Transition) []State {
+	var states []State
+	for _, s := range []State{ActiveUnackedState, ActiveAckedState, ClearedUnackedState, ClearedAckedState, ShelvedState} {
+		if slices.Contains(transitions[s], t) {
+			states = append(states, s)
+		}
+	}
+
+	return states
+}
+
+// UpdateTransitions returns the state transitions requested by the alarm update.
+func UpdateTransitions(update Alarm) []Transition {
+	var ts []Transition
+	if update.Status == ClearedStatus {
+		ts = append(ts, ClearTransition)
+	}
+	if update.AcknowledgedBy != "" {
+		ts = append(ts, AcknowledgeTransition)
+	}
+	if update.ResolvedBy != "" {
+		ts = append(ts, ResolveTransition)
+	}
+
+	return ts
+}
+
+// Shelve is a request to shelve an alarm until the expiry.
+type Shelve struct {
+	Until time.Time `json:"until"`
+}
+
+func (s Shelve) Validate(now time.Time) error {
+	if !s.Until.After(now) || s.Until.After(now.Add(MaxShelveDuration)) {
+		return errShelveExpiry
+	}
+
+	return nil
+}
@@ -0,0 +1,442 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/mocks"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	now          = time.Now()
+	activeAlarm  = alarms.Alarm{ID: "alarm-id", Status: alarms.ActiveStatus}
+	ackedAlarm   = alarms.Alarm{ID: "alarm-id", Status: alarms.ActiveStatus, AcknowledgedBy: "user-id", AcknowledgedAt: now}
+	clearedAlarm = alarms.Alarm{ID: "alarm-id", Status: alarms.ClearedStatus, ClearedAt: now}
+	closedAlarm  = alarms.Alarm{ID: "alarm-id", Status: alarms.ClearedStatus, AcknowledgedBy: "user-id", AcknowledgedAt: now}
+	shelvedAlarm = alarms.Alarm{ID: "alarm-id", Status: alarms.ActiveStatus, ShelvedUntil: now.Add(time.Hour)}
+)
+
+func TestAlarmStateAt(t *testing.T) {
+	cases := []struct {
+		desc  string
+		alarm alarms.Alarm
+		state alarms.State
+	}{
+		{
+			desc:  "active unacknowledged alarm",
+			alarm: activeAlarm,
+			state: alarms.ActiveUnackedState,
+		},
+		{
+			desc:  "suppressed alarm",
+			alarm: alarms.Alarm{Status: alarms.SuppressedStatus},
+			state: alarms.ActiveUnackedState,
+		},
+		{
+			desc:  "active acknowledged alarm",
+			alarm: ackedAlarm,
+			state: alarms.ActiveAckedState,
+		},
+		{
+			desc:  "cleared unacknowledged alarm",
+			alarm: clearedAlarm,
+			state: alarms.ClearedUnackedState,
+		},
+		{
+			desc:  "cleared acknowledged alarm",
+			alarm: closedAlarm,
+			state: alarms.ClearedAckedState,
+		},
+		{
+			desc:  "shelved alarm",
+			alarm: shelvedAlarm,
+			state: alarms.ShelvedState,
+		},
+		{
+			desc:  "alarm with expired shelving",
+			alarm: alarms.Alarm{Status: alarms.ActiveStatus, ShelvedUntil: now.Add(-time.Minute)},
+			state: alarms.ActiveUnackedState,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			state := tc.alarm.StateAt(now)
+			assert.Equal(t, tc.state, state, fmt.Sprintf("%s: expected state %s got %s\n", tc.desc, tc.state, state))
+		})
+	}
+}
+
+func TestAlarmCanTransition(t *testing.T) {
+	resolved := ackedAlarm
+	resolved.ResolvedBy = "user-id"
+	resolved.ResolvedAt = now
+
+	cases := []struct {
+		desc       string
+		alarm      alarms.Alarm
+		transition alarms.Transition
+		err        error
+	}{
+		{
+			desc:       "acknowledge active alarm",
+			alarm:      activeAlarm,
+			transition: alarms.AcknowledgeTransition,
+		},
+		{
+			desc:       "acknowledge acknowledged alarm",
+			alarm:      ackedAlarm,
+			transition: alarms.AcknowledgeTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "acknowledge cleared alarm",
+			alarm:      clearedAlarm,
+			transition: alarms.AcknowledgeTransition,
+		},
+		{
+			desc:       "acknowledge shelved alarm",
+			alarm:      shelvedAlarm,
+			transition: alarms.AcknowledgeTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "clear acknowledged alarm",
+			alarm:      ackedAlarm,
+			transition: alarms.ClearTransition,
+		},
+		{
+			desc:       "clear cleared alarm",
+			alarm:      clearedAlarm,
+			transition: alarms.ClearTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "clear shelved alarm",
+			alarm:      shelvedAlarm,
+			transition: alarms.ClearTransition,
+		},
+		{
+			desc:       "shelve active alarm",
+			alarm:      activeAlarm,
+			transition: alarms.ShelveTransition,
+		},
+		{
+			desc:       "shelve shelved alarm",
+			alarm:      shelvedAlarm,
+			transition: alarms.ShelveTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "shelve cleared acknowledged alarm",
+			alarm:      closedAlarm,
+			transition: alarms.ShelveTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "unshelve shelved alarm",
+			alarm:      shelvedAlarm,
+			transition: alarms.UnshelveTransition,
+		},
+		{
+			desc:       "unshelve active alarm",
+			alarm:      activeAlarm,
+			transition: alarms.UnshelveTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "resolve cleared acknowledged alarm",
+			alarm:      closedAlarm,
+			transition: alarms.ResolveTransition,
+		},
+		{
+			desc:       "resolve resolved alarm",
+			alarm:      resolved,
+			transition: alarms.ResolveTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+		{
+			desc:       "clear resolved alarm",
+			alarm:      resolved,
+			transition: alarms.ClearTransition,
+			err:        alarms.ErrInvalidTransition,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.alarm.CanTransition(tc.transition, now)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestTransitionStates(t *testing.T) {
+	cases := []struct {
+		desc       string
+		transition alarms.Transition
+		states     []alarms.State
+	}{
+		{
+			desc:       "acknowledge",
+			transition: alarms.AcknowledgeTransition,
+			states:     []alarms.State{alarms.ActiveUnackedState, alarms.ClearedUnackedState},
+		},
+		{
+			desc:       "unshelve",
+			transition: alarms.UnshelveTransition,
+			states:     []alarms.State{alarms.ShelvedState},
+		},
+		{
+			desc:       "resolve",
+			transition: alarms.ResolveTransition,
+			states:     []alarms.State{alarms.ActiveUnackedState, alarms.ActiveAckedState, alarms.ClearedUnackedState, alarms.ClearedAckedState, alarms.ShelvedState},
+		},
+		{
+			desc:       "unknown transition",
+			transition: alarms.Transition("unknown"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			states := alarms.TransitionStates(tc.transition)
+			assert.Equal(t, tc.states, states, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.states, states))
+		})
+	}
+}
+
+func TestToState(t *testing.T) {
+	cases := []struct {
+		desc  string
+		state string
+		err   bool
+	}{
+		{desc: "active unacknowledged state", state: "active_unacked"},
+		{desc: "shelved state in upper case", state: "SHELVED"},
+		{desc: "invalid state", state: "resolved", err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := alarms.ToState(tc.state)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		})
+	}
+}
+
+func TestShelveValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		shelve alarms.Shelve
+		err    bool
+	}{
+		{desc: "valid shelve", shelve: alarms.Shelve{Until: now.Add(time.Hour)}},
+		{desc: "shelve without expiry", shelve: alarms.Shelve{}, err: true},
+		{desc: "shelve with past expiry", shelve: alarms.Shelve{Until: now.Add(-time.Hour)}, err: true},
+		{desc: "shelve for too long", shelve: alarms.Shelve{Until: now.Add(alarms.MaxShelveDuration + time.Hour)}, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.shelve.Validate(now)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		})
+	}
+}
+
+func TestShelveAlarm(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+	shelve := alarms.Shelve{Until: time.Now().Add(time.Hour)}
+
+	cases := []struct {
+		desc     string
+		repoErr  error
+		activity bool
+		err      error
+	}{
+		{
+			desc:     "shelve active alarm",
+			activity: true,
+		},
+		{
+			desc:    "shelve non existing alarm",
+			repoErr: repoerr.ErrNotFound,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "shelve shelved alarm",
+			repoErr: alarms.ErrInvalidTransition,
+			err:     alarms.ErrInvalidTransition,
+		},
+		{
+			desc:    "shelve alarm with failed repo",
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+		{
+			desc:     "shelve alarm with failed activity record",
+			repoErr:  repoerr.ErrCreateEntity,
+			activity: true,
+			err:      repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var update alarms.Alarm
+			var recorded []alarms.Activity
+			repoCall := repo.On("ShelveAlarm", context.Background(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				update = args.Get(1).(alarms.Alarm)
+				acts, err := args.Get(2).(alarms.ActivitiesFunc)([]alarms.Alarm{shelvedAlarm})
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				recorded = acts
+			}).Return(shelvedAlarm, tc.repoErr)
+			_, err := svc.ShelveAlarm(context.Background(), session, "alarm-id", shelve)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, shelve.Until, update.ShelvedUntil, fmt.Sprintf("%s: expected expiry %s got %s\n", tc.desc, shelve.Until, update.ShelvedUntil))
+				assert.Equal(t, session.UserID, update.ShelvedBy, fmt.Sprintf("%s: expected shelved by %s got %s\n", tc.desc, session.UserID, update.ShelvedBy))
+			}
+			if tc.activity {
+				assert.Len(t, recorded, 1, fmt.Sprintf("%s: expected a single activity", tc.desc))
+				assert.Equal(t, alarms.ShelveActivity, recorded[0].Type, fmt.Sprintf("%s: expected type %s got %s\n", tc.desc, alarms.ShelveActivity, recorded[0].Type))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUnshelveAlarm(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+
+	cases := []struct {
+		desc    string
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "unshelve shelved alarm",
+		},
+		{
+			desc:    "unshelve non existing alarm",
+			repoErr: repoerr.ErrNotFound,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "unshelve active alarm",
+			repoErr: alarms.ErrInvalidTransition,
+			err:     alarms.ErrInvalidTransition,
+		},
+		{
+			desc:    "unshelve alarm with failed repo",
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var update alarms.Alarm
+			var recorded []alarms.Activity
+			repoCall := repo.On("ShelveAlarm", context.Background(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				update = args.Get(1).(alarms.Alarm)
+				acts, err := args.Get(2).(alarms.ActivitiesFunc)([]alarms.Alarm{activeAlarm})
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				recorded = acts
+			}).Return(activeAlarm, tc.repoErr)
+			_, err := svc.UnshelveAlarm(context.Background(), session, "alarm-id")
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.True(t, update.ShelvedUntil.IsZero(), fmt.Sprintf("%s: expected shelving to be removed", tc.desc))
+				assert.Len(t, recorded, 1, fmt.Sprintf("%s: expected a single activity", tc.desc))
+				assert.Equal(t, alarms.UnshelveActivity, recorded[0].Type, fmt.Sprintf("%s: expected type %s got %s\n", tc.desc, alarms.UnshelveActivity, recorded[0].Type))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestClearAlarms(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	source := alarms.Alarm{
+		RuleID:    "rule-id",
+		DomainID:  "domain-id",
+		ChannelID: "channel-id",
+		ClientID:  "client-id",
+		Status:    alarms.ClearedStatus,
+		CreatedAt: now,
+	}
+	cleared := []alarms.Alarm{
+		{ID: "alarm-1", DomainID: "domain-id", Status: alarms.ClearedStatus, ClearedAt: now},
+		{ID: "alarm-2", DomainID: "domain-id", Status: alarms.ClearedStatus, ClearedAt: now},
+	}
+
+	cases := []struct {
+		desc       string
+		alarm      alarms.Alarm
+		cleared    []alarms.Alarm
+		repoErr    error
+		activities int
+		err        error
+	}{
+		{
+			desc:       "clear alarms of the source",
+			alarm:      source,
+			cleared:    cleared,
+			activities: len(cleared),
+		},
+		{
+			desc:  "clear alarms of the source without alarms",
+			alarm: source,
+		},
+		{
+			desc:  "clear alarms without rule",
+			alarm: alarms.Alarm{DomainID: "domain-id", ChannelID: "channel-id", ClientID: "client-id"},
+			err:   errors.New("rule_id is required"),
+		},
+		{
+			desc:    "clear alarms with failed repo",
+			alarm:   source,
+			repoErr: repoerr.ErrUpdateEntity,
+			err:     repoerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var clear alarms.Alarm
+			var recorded []alarms.Activity
+			repoCall := repo.On("ClearAlarms", context.Background(), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				clear = args.Get(1).(alarms.Alarm)
+				acts, err := args.Get(2).(alarms.ActivitiesFunc)(tc.cleared)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				recorded = acts
+			}).Return(tc.cleared, tc.repoErr)
+			res, err := svc.ClearAlarms(context.Background(), tc.alarm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.cleared, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.cleared, res))
+				assert.Equal(t, tc.alarm.CreatedAt, clear.ClearedAt, fmt.Sprintf("%s: expected clear time %s got %s\n", tc.desc, tc.alarm.CreatedAt, clear.ClearedAt))
+				assert.Len(t, recorded, tc.activities, fmt.Sprintf("%s: expected %d activities got %d\n", tc.desc, tc.activities, len(recorded)))
+			}
+			repoCall.Unset()
+		})
+	}
+}
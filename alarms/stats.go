@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import "time"
+
+// StatsPageMeta selects the alarms created between From and To which the
+// statistics are computed over. Interval is the width of the histogram
+// buckets and Top the number of the noisiest clients reported.
+type StatsPageMeta struct {
+	DomainID  string        `json:"domain_id"`
+	RuleID    string        `json:"rule_id"`
+	RuleIDs   []string      `json:"rule_ids"`
+	ChannelID string        `json:"channel_id"`
+	ClientID  string        `json:"client_id"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Interval  time.Duration `json:"interval"`
+	Top       uint64        `json:"top"`
+}
+
+// Stats are the statistics of the alarms of a time range. MTTA and MTTR are
+// the mean times to acknowledge and to resolve in seconds, computed over the
+// acknowledged and the resolved alarms.
+type Stats struct {
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Total        uint64    `json:"total"`
+	Acknowledged uint64    `json:"acknowledged"`
+	Resolved     uint64    `json:"resolved"`
+	MTTA         float64   `json:"mtta_seconds"`
+	MTTR         float64   `json:"mttr_seconds"`
+	ByStatus     []Count   `json:"by_status"`
+	BySeverity   []Count   `json:"by_severity"`
+	ByRule       []Count   `json:"by_rule"`
+	ByChannel    []Count   `json:"by_channel"`
+	TopClients   []Count   `json:"top_clients"`
+	Histogram    []Bucket  `json:"histogram"`
+}
+
+// Count is the number of alarms with the same key.
+type Count struct {
+	Key   string `json:"key"`
+	Count uint64 `json:"count"`
+}
+
+// Bucket is the number of alarms created within the histogram interval
+// starting at Start.
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Count uint64    `json:"count"`
+}
@@ -15,6 +15,8 @@ type Status uint8
 const (
 	ActiveStatus Status = iota
 	ClearedStatus
+	// SuppressedStatus marks the alarms raised during a suppression window.
+	SuppressedStatus
 
 	// AllStatus is used for querying purposes to list alarms irrespective
 	// of their status. It is never stored in the database as the actual
@@ -23,10 +25,11 @@ const (
 )
 
 const (
-	Active  = "active"
-	Cleared = "cleared"
-	Unknown = "unknown"
-	All     = "all"
+	Active     = "active"
+	Cleared    = "cleared"
+	Suppressed = "suppressed"
+	Unknown    = "unknown"
+	All        = "all"
 )
 
 // String converts alarm status to string literal.
@@ -36,6 +39,8 @@ func (s Status) String() string {
 		return Active
 	case ClearedStatus:
 		return Cleared
+	case SuppressedStatus:
+		return Suppressed
 	default:
 		return Unknown
 	}
@@ -48,6 +53,8 @@ func ToStatus(status string) (Status, error) {
 		return ActiveStatus, nil
 	case Cleared:
 		return ClearedStatus, nil
+	case Suppressed:
+		return SuppressedStatus, nil
 	case All:
 		return AllStatus, nil
 	default:
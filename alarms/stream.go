@@ -0,0 +1,146 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefStreamBuffer is the default number of events buffered per stream subscriber.
+const DefStreamBuffer = 64
+
+// EventType is the type of an alarm change.
+type EventType string
+
+const (
+	CreateEvent      EventType = "create"
+	UpdateEvent      EventType = "update"
+	AssignEvent      EventType = "assign"
+	AcknowledgeEvent EventType = "acknowledge"
+	ResolveEvent     EventType = "resolve"
+	ClearEvent       EventType = "clear"
+	ShelveEvent      EventType = "shelve"
+	UnshelveEvent    EventType = "unshelve"
+	DeleteEvent      EventType = "delete"
+)
+
+// UpdateEventType returns the type of the change made by the alarm update.
+// An update making several changes is reported by the most significant one.
+func UpdateEventType(update Alarm) EventType {
+	switch {
+	case update.ResolvedBy != "":
+		return ResolveEvent
+	case update.AcknowledgedBy != "":
+		return AcknowledgeEvent
+	case update.Status == ClearedStatus:
+		return ClearEvent
+	case update.AssigneeID != "":
+		return AssignEvent
+	default:
+		return UpdateEvent
+	}
+}
+
+// Event is a change of an alarm.
+type Event struct {
+	Type       EventType `json:"type"`
+	Alarm      Alarm     `json:"alarm"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// StreamFilter selects the alarm events sent to a stream subscriber.
+// Empty fields match any value.
+type StreamFilter struct {
+	DomainID  string
+	RuleID    string
+	ChannelID string
+	// RuleIDs limits the events to the alarms of the given rules.
+	// It is set by the authorization when only some of the rules are readable.
+	RuleIDs     []string
+	MinSeverity uint8
+	// Status matches alarms of any status when set to AllStatus.
+	Status Status
+}
+
+// Match reports whether the alarm passes the filter.
+func (f StreamFilter) Match(a Alarm) bool {
+	switch {
+	case f.DomainID != "" && a.DomainID != f.DomainID,
+		f.RuleID != "" && a.RuleID != f.RuleID,
+		f.ChannelID != "" && a.ChannelID != f.ChannelID,
+		len(f.RuleIDs) > 0 && !slices.Contains(f.RuleIDs, a.RuleID),
+		a.Severity < f.MinSeverity,
+		f.Status != AllStatus && a.Status != f.Status:
+		return false
+	default:
+		return true
+	}
+}
+
+// Stream fans out alarm events to the subscribers of a service instance.
+type Stream struct {
+	mu     sync.RWMutex
+	subs   map[*subscription]struct{}
+	buffer int
+}
+
+type subscription struct {
+	filter StreamFilter
+	events chan Event
+}
+
+// NewStream returns a stream which buffers up to buffer events per subscriber.
+func NewStream(buffer int) *Stream {
+	if buffer <= 0 {
+		buffer = DefStreamBuffer
+	}
+
+	return &Stream{
+		subs:   make(map[*subscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Publish sends the event to the matching subscribers. Events are dropped
+// for the subscribers which do not keep up, so a slow subscriber does not
+// block the others.
+func (s *Stream) Publish(event Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subs {
+		if !sub.filter.Match(event.Alarm) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the channel of the events matching the filter.
+// The channel is closed once the context is done.
+func (s *Stream) Subscribe(ctx context.Context, filter StreamFilter) <-chan Event {
+	sub := &subscription{
+		filter: filter,
+		events: make(chan Event, s.buffer),
+	}
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.subs, sub)
+		close(sub.events)
+		s.mu.Unlock()
+	}()
+
+	return sub.events
+}
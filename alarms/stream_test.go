@@ -0,0 +1,164 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var streamAlarm = alarms.Alarm{
+	ID:        "alarm-id",
+	RuleID:    "rule-id",
+	DomainID:  "domain-id",
+	ChannelID: "channel-id",
+	Status:    alarms.ActiveStatus,
+	Severity:  50,
+}
+
+func TestStreamFilterMatch(t *testing.T) {
+	cases := []struct {
+		desc   string
+		filter alarms.StreamFilter
+		match  bool
+	}{
+		{
+			desc:   "match empty filter",
+			filter: alarms.StreamFilter{},
+			match:  true,
+		},
+		{
+			desc:   "match all filter fields",
+			filter: alarms.StreamFilter{DomainID: "domain-id", RuleID: "rule-id", ChannelID: "channel-id", MinSeverity: 50, Status: alarms.ActiveStatus},
+			match:  true,
+		},
+		{
+			desc:   "match any status",
+			filter: alarms.StreamFilter{Status: alarms.AllStatus},
+			match:  true,
+		},
+		{
+			desc:   "match readable rules",
+			filter: alarms.StreamFilter{RuleIDs: []string{"other-rule-id", "rule-id"}},
+			match:  true,
+		},
+		{
+			desc:   "not match other domain",
+			filter: alarms.StreamFilter{DomainID: "other-domain-id"},
+		},
+		{
+			desc:   "not match other channel",
+			filter: alarms.StreamFilter{ChannelID: "other-channel-id"},
+		},
+		{
+			desc:   "not match other rule",
+			filter: alarms.StreamFilter{RuleID: "other-rule-id"},
+		},
+		{
+			desc:   "not match unreadable rule",
+			filter: alarms.StreamFilter{RuleIDs: []string{"other-rule-id"}},
+		},
+		{
+			desc:   "not match lower severity",
+			filter: alarms.StreamFilter{MinSeverity: 51},
+		},
+		{
+			desc:   "not match other status",
+			filter: alarms.StreamFilter{Status: alarms.ClearedStatus},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			match := tc.filter.Match(streamAlarm)
+			assert.Equal(t, tc.match, match, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.match, match))
+		})
+	}
+}
+
+func TestUpdateEventType(t *testing.T) {
+	cases := []struct {
+		desc      string
+		update    alarms.Alarm
+		eventType alarms.EventType
+	}{
+		{
+			desc:      "resolve",
+			update:    alarms.Alarm{ResolvedBy: "user-id", AcknowledgedBy: "user-id"},
+			eventType: alarms.ResolveEvent,
+		},
+		{
+			desc:      "acknowledge",
+			update:    alarms.Alarm{AcknowledgedBy: "user-id", AssigneeID: "user-id"},
+			eventType: alarms.AcknowledgeEvent,
+		},
+		{
+			desc:      "clear",
+			update:    alarms.Alarm{Status: alarms.ClearedStatus},
+			eventType: alarms.ClearEvent,
+		},
+		{
+			desc:      "assign",
+			update:    alarms.Alarm{AssigneeID: "user-id"},
+			eventType: alarms.AssignEvent,
+		},
+		{
+			desc:      "update",
+			update:    alarms.Alarm{Metadata: alarms.Metadata{"key": "value"}},
+			eventType: alarms.UpdateEvent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			eventType := alarms.UpdateEventType(tc.update)
+			assert.Equal(t, tc.eventType, eventType, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.eventType, eventType))
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	stream := alarms.NewStream(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	matching := stream.Subscribe(ctx, alarms.StreamFilter{DomainID: streamAlarm.DomainID})
+	other := stream.Subscribe(ctx, alarms.StreamFilter{DomainID: "other-domain-id"})
+
+	event := alarms.Event{Type: alarms.CreateEvent, Alarm: streamAlarm, OccurredAt: time.Now()}
+	stream.Publish(event)
+	// The subscriber buffer is full, so the event is dropped instead of blocking.
+	stream.Publish(event)
+
+	select {
+	case got := <-matching:
+		assert.Equal(t, event, got)
+	default:
+		require.Fail(t, "expected event for the matching subscriber")
+	}
+	select {
+	case got := <-matching:
+		require.Fail(t, fmt.Sprintf("expected the second event to be dropped, got %v", got))
+	default:
+	}
+	select {
+	case got := <-other:
+		require.Fail(t, fmt.Sprintf("expected no event for the other subscriber, got %v", got))
+	default:
+	}
+
+	cancel()
+	for _, events := range []<-chan alarms.Event{matching, other} {
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok, "expected the events channel to be closed")
+		case <-time.After(time.Second):
+			require.Fail(t, "expected the events channel to be closed on cancel")
+		}
+	}
+}
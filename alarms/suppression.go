@@ -0,0 +1,102 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	errSuppressionName   = errors.New("suppression window name is required")
+	errSuppressionTarget = errors.New("suppression window must have channel_id or client_id")
+	errSuppressionRange  = errors.New("suppression window ends_at must be after starts_at")
+)
+
+// FlapConfig configures the flapping detection. An alarm source flaps when
+// it changes its state Threshold times within the Window. A zero Threshold
+// disables the detection.
+type FlapConfig struct {
+	Threshold uint64
+	Window    time.Duration
+}
+
+// SuppressionWindow is a maintenance window of a channel or a client. Alarms
+// raised during the window are recorded as suppressed instead of active, so
+// they are neither escalated nor reported as active. Empty fields match any
+// value, so a window of a channel suppresses the alarms of all its clients.
+type SuppressionWindow struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	DomainID  string    `json:"domain_id"`
+	ChannelID string    `json:"channel_id,omitempty"`
+	ClientID  string    `json:"client_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+func (w SuppressionWindow) Validate() error {
+	if w.Name == "" {
+		return errSuppressionName
+	}
+	if w.ChannelID == "" && w.ClientID == "" {
+		return errSuppressionTarget
+	}
+	if !w.EndsAt.After(w.StartsAt) {
+		return errSuppressionRange
+	}
+
+	return nil
+}
+
+type SuppressionWindowsPage struct {
+	Offset  uint64              `json:"offset"`
+	Limit   uint64              `json:"limit"`
+	Total   uint64              `json:"total"`
+	Windows []SuppressionWindow `json:"suppression_windows"`
+}
+
+type SuppressionWindowPageMeta struct {
+	Offset    uint64 `json:"offset"     db:"offset"`
+	Limit     uint64 `json:"limit"      db:"limit"`
+	DomainID  string `json:"domain_id"  db:"domain_id"`
+	ChannelID string `json:"channel_id" db:"channel_id"`
+	ClientID  string `json:"client_id"  db:"client_id"`
+}
+
+// flapping reports whether the alarm source flaps. The latest alarm of a
+// flapping source is marked, so the flapping shows up in the alarm lists.
+func (s *service) flapping(ctx context.Context, alarm Alarm) (bool, error) {
+	if s.flap.Threshold == 0 {
+		return false, nil
+	}
+	changes, err := s.repo.CountStateChanges(ctx, alarm, alarm.CreatedAt.Add(-s.flap.Window))
+	if err != nil {
+		return false, err
+	}
+	if changes < s.flap.Threshold {
+		return false, nil
+	}
+	if err := s.repo.MarkFlapping(ctx, alarm); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// suppressed reports whether the alarm is raised during a suppression window.
+func (s *service) suppressed(ctx context.Context, alarm Alarm) (bool, error) {
+	windows, err := s.repo.MatchSuppressionWindows(ctx, alarm)
+	if err != nil {
+		return false, err
+	}
+
+	return len(windows) > 0, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package alarms_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/alarms"
+	"github.com/absmach/magistrala/alarms/mocks"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSuppressionWindowValidate(t *testing.T) {
+	start := time.Now()
+
+	cases := []struct {
+		desc   string
+		window alarms.SuppressionWindow
+		err    bool
+	}{
+		{
+			desc:   "valid channel window",
+			window: alarms.SuppressionWindow{Name: "maintenance", ChannelID: "channel-id", StartsAt: start, EndsAt: start.Add(time.Hour)},
+		},
+		{
+			desc:   "valid client window",
+			window: alarms.SuppressionWindow{Name: "maintenance", ClientID: "client-id", StartsAt: start, EndsAt: start.Add(time.Hour)},
+		},
+		{
+			desc:   "window without name",
+			window: alarms.SuppressionWindow{ChannelID: "channel-id", StartsAt: start, EndsAt: start.Add(time.Hour)},
+			err:    true,
+		},
+		{
+			desc:   "window without channel and client",
+			window: alarms.SuppressionWindow{Name: "maintenance", StartsAt: start, EndsAt: start.Add(time.Hour)},
+			err:    true,
+		},
+		{
+			desc:   "window ending before it starts",
+			window: alarms.SuppressionWindow{Name: "maintenance", ChannelID: "channel-id", StartsAt: start, EndsAt: start.Add(-time.Hour)},
+			err:    true,
+		},
+		{
+			desc:   "empty window",
+			window: alarms.SuppressionWindow{Name: "maintenance", ChannelID: "channel-id", StartsAt: start, EndsAt: start},
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.window.Validate()
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		})
+	}
+}
+
+func TestCreateSuppressionWindow(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id", UserID: "user-id"}
+	start := time.Now()
+	window := alarms.SuppressionWindow{Name: "maintenance", ChannelID: "channel-id", StartsAt: start, EndsAt: start.Add(time.Hour)}
+
+	cases := []struct {
+		desc    string
+		window  alarms.SuppressionWindow
+		repoErr error
+		err     error
+	}{
+		{
+			desc:   "create suppression window successfully",
+			window: window,
+			err:    nil,
+		},
+		{
+			desc:    "create suppression window with failed repo",
+			window:  window,
+			repoErr: repoerr.ErrCreateEntity,
+			err:     repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var saved alarms.SuppressionWindow
+			repoCall := repo.On("CreateSuppressionWindow", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(alarms.SuppressionWindow)
+			}).Return(tc.window, tc.repoErr)
+			_, err := svc.CreateSuppressionWindow(context.Background(), session, tc.window)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected window id to be set", tc.desc))
+			assert.Equal(t, session.DomainID, saved.DomainID, fmt.Sprintf("%s: expected domain %s got %s\n", tc.desc, session.DomainID, saved.DomainID))
+			assert.Equal(t, session.UserID, saved.CreatedBy, fmt.Sprintf("%s: expected creator %s got %s\n", tc.desc, session.UserID, saved.CreatedBy))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDeleteSuppressionWindow(t *testing.T) {
+	repo := new(mocks.Repository)
+	svc := newService(t, repo)
+	session := authn.Session{DomainID: "domain-id"}
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "delete suppression window successfully",
+			id:   "window-id",
+			err:  nil,
+		},
+		{
+			desc: "delete non-existing suppression window",
+			id:   "window-id",
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("DeleteSuppressionWindow", context.Background(), tc.id, session.DomainID).Return(tc.err)
+			err := svc.DeleteSuppressionWindow(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
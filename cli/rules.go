@@ -0,0 +1,87 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	smqsdk "github.com/absmach/magistrala/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var cmdRules = []cobra.Command{
+	{
+		Use:   "test <JSON_rule> <JSON_message> <domain_id> <user_auth_token>",
+		Short: "Test rule",
+		Long: "Runs a rule against a sample message without invoking its outputs\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli rules test '{\"name\":\"temp\",\"logic\":{\"type\":0,\"value\":\"return message.payload\"}}' '{\"payload\":{\"t\":30}}' <domain_id> <user_auth_token>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var rule smqsdk.Rule
+			if err := json.Unmarshal([]byte(args[0]), &rule); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			var msg smqsdk.RuleTestMessage
+			if err := json.Unmarshal([]byte(args[1]), &msg); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			res, err := sdk.TestRule(cmd.Context(), rule, msg, args[2], args[3])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, res)
+		},
+	},
+	{
+		Use:   "executions <rule_id> <domain_id> <user_auth_token>",
+		Short: "List rule executions",
+		Long: "Lists the execution history of a rule\n" +
+			"Usage:\n" +
+			"\tmagistrala-cli rules executions <rule_id> <domain_id> <user_auth_token>\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			pm := smqsdk.PageMetadata{
+				Offset: Offset,
+				Limit:  Limit,
+			}
+			page, err := sdk.ListRuleExecutions(cmd.Context(), args[0], pm, args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, page)
+		},
+	},
+}
+
+// NewRulesCmd returns rules command.
+func NewRulesCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "rules [test | executions]",
+		Short: "Rules management",
+		Long:  `Rules management: test rules against sample messages and list rule executions`,
+	}
+
+	for i := range cmdRules {
+		cmd.AddCommand(&cmdRules[i])
+	}
+
+	return &cmd
+}
@@ -7,20 +7,28 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/absmach/magistrala/alarms"
 	httpAPI "github.com/absmach/magistrala/alarms/api"
 	"github.com/absmach/magistrala/alarms/brokers"
 	"github.com/absmach/magistrala/alarms/consumer"
+	"github.com/absmach/magistrala/alarms/events"
 	"github.com/absmach/magistrala/alarms/middleware"
+	"github.com/absmach/magistrala/alarms/notifiers"
 	"github.com/absmach/magistrala/alarms/operations"
 	alarmsRepo "github.com/absmach/magistrala/alarms/postgres"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/notifiers/smpp"
 	"github.com/absmach/magistrala/internal/atom"
+	"github.com/absmach/magistrala/internal/email"
 	mglog "github.com/absmach/magistrala/logger"
 	smqauthn "github.com/absmach/magistrala/pkg/authn"
 	atomauthn "github.com/absmach/magistrala/pkg/authn/atom"
+	"github.com/absmach/magistrala/pkg/emailer"
 	"github.com/absmach/magistrala/pkg/jaeger"
 	"github.com/absmach/magistrala/pkg/messaging"
 	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
@@ -29,6 +37,7 @@ import (
 	"github.com/absmach/magistrala/pkg/prometheus"
 	"github.com/absmach/magistrala/pkg/server"
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/caarlos0/env/v11"
 	"golang.org/x/sync/errgroup"
@@ -44,12 +53,17 @@ const (
 )
 
 type config struct {
-	LogLevel        string  `env:"MG_ALARMS_LOG_LEVEL"    envDefault:"info"`
-	BrokerURL       string  `env:"MG_MESSAGE_BROKER_URL" envDefault:"nats://localhost:4222"`
-	InstanceID      string  `env:"MG_ALARMS_INSTANCE_ID"  envDefault:""`
-	JaegerURL       url.URL `env:"MG_JAEGER_URL"         envDefault:"http://localhost:4318/v1/traces"`
-	TraceRatio      float64 `env:"MG_JAEGER_TRACE_RATIO" envDefault:"1.0"`
-	PermissionsFile string  `env:"MG_PERMISSIONS_FILE"             envDefault:"permission.yaml"`
+	LogLevel           string        `env:"MG_ALARMS_LOG_LEVEL"           envDefault:"info"`
+	BrokerURL          string        `env:"MG_MESSAGE_BROKER_URL"         envDefault:"nats://localhost:4222"`
+	InstanceID         string        `env:"MG_ALARMS_INSTANCE_ID"         envDefault:""`
+	JaegerURL          url.URL       `env:"MG_JAEGER_URL"                 envDefault:"http://localhost:4318/v1/traces"`
+	TraceRatio         float64       `env:"MG_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
+	PermissionsFile    string        `env:"MG_PERMISSIONS_FILE"           envDefault:"permission.yaml"`
+	EscalationInterval time.Duration `env:"MG_ALARMS_ESCALATION_INTERVAL" envDefault:"30s"`
+	ESURL              string        `env:"MG_ES_URL"                     envDefault:"nats://localhost:4222"`
+	ESConsumerName     string        `env:"MG_ALARMS_EVENT_CONSUMER"      envDefault:"alarms"`
+	FlapThreshold      uint64        `env:"MG_ALARMS_FLAP_THRESHOLD"      envDefault:"6"`
+	FlapWindow         time.Duration `env:"MG_ALARMS_FLAP_WINDOW"         envDefault:"10m"`
 }
 
 func main() {
@@ -69,6 +83,14 @@ func main() {
 	var exitCode int
 	defer mglog.ExitWithError(&exitCode)
 
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
 	tp, err := jaeger.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -116,7 +138,30 @@ func main() {
 
 	idp := uuid.New()
 
-	svc := alarms.NewService(idp, repo)
+	stream := alarms.NewStream(alarms.DefStreamBuffer)
+	streamSub, err := events.SubscribeStream(ctx, stream, cfg.ESURL, fmt.Sprintf("%s-stream-%s", cfg.ESConsumerName, cfg.InstanceID), logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to alarm events: %s", err))
+		exitCode = 1
+		return
+	}
+	defer streamSub.Close()
+
+	flap := alarms.FlapConfig{Threshold: cfg.FlapThreshold, Window: cfg.FlapWindow}
+	svc := alarms.NewService(idp, repo, stream, flap)
+	svc, err = events.NewEventStoreMiddleware(ctx, svc, cfg.ESURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init alarms event store middleware: %s", err))
+		exitCode = 1
+		return
+	}
+
+	notifier, err := newNotifier(logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to configure escalation notifier: %s", err))
+		exitCode = 1
+		return
+	}
 
 	permConfig, err := permissions.ParsePermissionsFile(cfg.PermissionsFile)
 	if err != nil {
@@ -194,6 +239,10 @@ func main() {
 		return hs.Start()
 	})
 
+	g.Go(func() error {
+		return alarms.StartEscalations(ctx, repo, notifier, ticker.NewTicker(cfg.EscalationInterval), logger)
+	})
+
 	g.Go(func() error {
 		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
 	})
@@ -202,3 +251,38 @@ func main() {
 		logger.Error(fmt.Sprintf("billing service terminated: %s", err))
 	}
 }
+
+// newNotifier configures the escalation notifier. Email and SMS notifications
+// are disabled if they are not configured.
+func newNotifier(logger *slog.Logger) (alarms.Notifier, error) {
+	ncfg := notifiers.Config{}
+	if err := env.Parse(&ncfg); err != nil {
+		return nil, err
+	}
+
+	var e emailer.Emailer
+	ec := email.Config{}
+	if err := env.Parse(&ec); err != nil {
+		return nil, err
+	}
+	switch client, err := emailer.New(&ec); {
+	case err != nil:
+		logger.Warn(fmt.Sprintf("email escalation notifications are disabled: %s", err))
+	default:
+		e = client
+	}
+
+	var sms consumers.Notifier
+	sc := smpp.Config{}
+	if err := env.Parse(&sc); err != nil {
+		return nil, err
+	}
+	switch sc.Address {
+	case "":
+		logger.Warn("sms escalation notifications are disabled: MG_SMPP_ADDRESS is not set")
+	default:
+		sms = smpp.New(sc)
+	}
+
+	return notifiers.New(ncfg, e, sms), nil
+}
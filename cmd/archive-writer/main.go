@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains archive-writer main function to start the archive-writer service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	httpapi "github.com/absmach/magistrala/consumers/writers/api"
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	"github.com/absmach/magistrala/consumers/writers/brokers"
+	mglog "github.com/absmach/magistrala/logger"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/ticker"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "archive-writer"
+	envPrefixHTTP  = "MG_ARCHIVE_WRITER_HTTP_"
+	envPrefix      = "MG_ARCHIVE_WRITER_"
+	defSvcHTTPPort = "9018"
+	// rotationCheck is the interval of the checks for the files open longer
+	// than the rotation interval.
+	rotationCheck = time.Minute
+)
+
+type config struct {
+	LogLevel      string  `env:"MG_ARCHIVE_WRITER_LOG_LEVEL"      envDefault:"info"`
+	ConfigPath    string  `env:"MG_ARCHIVE_WRITER_CONFIG_PATH"    envDefault:"/config.toml"`
+	BrokerURL     string  `env:"MG_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL `env:"MG_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool    `env:"MG_SEND_TELEMETRY"                envDefault:"true"`
+	InstanceID    string  `env:"MG_ARCHIVE_WRITER_INSTANCE_ID"    envDefault:""`
+	TraceRatio    float64 `env:"MG_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	batchConfig := consumers.BatchConfig{}
+	if err := env.ParseWithOptions(&batchConfig, env.Options{Prefix: envPrefix}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s batch configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	archiveConfig := archive.Config{}
+	if err := env.ParseWithOptions(&archiveConfig, env.Options{Prefix: envPrefix}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s archive configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	arch, err := archive.New(archiveConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create archive: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := arch.Close(); err != nil {
+			logger.Error(fmt.Sprintf("failed to close archive: %s", err))
+		}
+	}()
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	repo := newService(arch, logger)
+	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
+	repo = consumers.NewBatchConsumer(ctx, repo, batchConfig, makeBatchMetrics())
+
+	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, brokers.AllTopic, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create archive writer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return archive.StartRotation(ctx, arch, ticker.NewTicker(rotationCheck), logger)
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Archive writer service terminated: %s", err))
+	}
+}
+
+func newService(arch archive.Archive, logger *slog.Logger) consumers.BlockingConsumer {
+	var svc consumers.BlockingConsumer = arch
+	svc = httpapi.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("archive", "message_writer")
+	svc = httpapi.MetricsMiddleware(svc, counter, latency)
+	return svc
+}
+
+func makeBatchMetrics() consumers.BatchMetrics {
+	return consumers.BatchMetrics{
+		Size: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "archive",
+			Subsystem: "message_writer",
+			Name:      "batch_size",
+			Help:      "Number of records of the flushed batches.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{}),
+		Latency: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "archive",
+			Subsystem: "message_writer",
+			Name:      "batch_flush_seconds",
+			Help:      "Time taken to flush the batches.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, []string{}),
+		Failed: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "archive",
+			Subsystem: "message_writer",
+			Name:      "failed_batches",
+			Help:      "Number of batches which failed to flush as a whole.",
+		}, []string{}),
+		Dropped: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "archive",
+			Subsystem: "message_writer",
+			Name:      "dropped_messages",
+			Help:      "Number of messages rejected because the batch buffer was full.",
+		}, []string{}),
+	}
+}
@@ -28,7 +28,9 @@ import (
 	httpserver "github.com/absmach/magistrala/pkg/server/http"
 	"github.com/absmach/magistrala/pkg/uuid"
 	"github.com/caarlos0/env/v11"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -36,6 +38,7 @@ const (
 	svcName        = "postgres-writer"
 	envPrefixDB    = "MG_POSTGRES_"
 	envPrefixHTTP  = "MG_POSTGRES_WRITER_HTTP_"
+	envPrefixBatch = "MG_POSTGRES_WRITER_"
 	defDB          = "messages"
 	defSvcHTTPPort = "9010"
 )
@@ -82,6 +85,13 @@ func main() {
 		return
 	}
 
+	batchConfig := consumers.BatchConfig{}
+	if err := env.ParseWithOptions(&batchConfig, env.Options{Prefix: envPrefixBatch}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s batch configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
 	dbConfig := pgclient.Config{Name: defDB}
 	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load %s Postgres configuration : %s", svcName, err))
@@ -118,6 +128,7 @@ func main() {
 
 	repo := newService(db, logger)
 	repo = consumertracing.NewBlocking(tracer, repo, httpServerConfig)
+	repo = consumers.NewBatchConsumer(ctx, repo, batchConfig, makeBatchMetrics())
 
 	if err = consumers.Start(ctx, svcName, pubSub, repo, cfg.ConfigPath, brokers.AllTopic, logger); err != nil {
 		logger.Error(fmt.Sprintf("failed to create Postgres writer: %s", err))
@@ -152,3 +163,34 @@ func newService(db *sqlx.DB, logger *slog.Logger) consumers.BlockingConsumer {
 	svc = httpapi.MetricsMiddleware(svc, counter, latency)
 	return svc
 }
+
+func makeBatchMetrics() consumers.BatchMetrics {
+	return consumers.BatchMetrics{
+		Size: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "batch_size",
+			Help:      "Number of records of the flushed batches.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{}),
+		Latency: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "batch_flush_seconds",
+			Help:      "Time taken to flush the batches.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, []string{}),
+		Failed: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "failed_batches",
+			Help:      "Number of batches which failed to flush as a whole.",
+		}, []string{}),
+		Dropped: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "postgres",
+			Subsystem: "message_writer",
+			Name:      "dropped_messages",
+			Help:      "Number of messages rejected because the batch buffer was full.",
+		}, []string{}),
+	}
+}
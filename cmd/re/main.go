@@ -19,6 +19,7 @@ import (
 	grpcReadersV1 "github.com/absmach/magistrala/api/grpc/readers/v1"
 	"github.com/absmach/magistrala/consumers/writers/brokers"
 	"github.com/absmach/magistrala/internal/atom"
+	redisclient "github.com/absmach/magistrala/internal/clients/redis"
 	"github.com/absmach/magistrala/internal/email"
 	mglog "github.com/absmach/magistrala/logger"
 	smqauthn "github.com/absmach/magistrala/pkg/authn"
@@ -43,10 +44,14 @@ import (
 	"github.com/absmach/magistrala/re/events"
 	"github.com/absmach/magistrala/re/middleware"
 	"github.com/absmach/magistrala/re/operations"
+	"github.com/absmach/magistrala/re/outputs"
 	repg "github.com/absmach/magistrala/re/postgres"
+	"github.com/absmach/magistrala/re/state"
 	grpcClient "github.com/absmach/magistrala/readers/api/grpc"
 	"github.com/caarlos0/env/v11"
 	"github.com/go-chi/chi/v5"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
@@ -67,17 +72,35 @@ const (
 const channBuffer = 256
 
 type config struct {
-	LogLevel         string        `env:"MG_RE_LOG_LEVEL"             envDefault:"info"`
-	InstanceID       string        `env:"MG_RE_INSTANCE_ID"           envDefault:""`
-	JaegerURL        url.URL       `env:"MG_JAEGER_URL"              envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry    bool          `env:"MG_SEND_TELEMETRY"          envDefault:"true"`
-	ESURL            string        `env:"MG_ES_URL"                  envDefault:"nats://localhost:4222"`
-	ESConsumerName   string        `env:"MG_RE_EVENT_CONSUMER"        envDefault:"rules_engine"`
-	CacheURL         string        `env:"MG_RE_CACHE_URL"             envDefault:"redis://localhost:6379/0"`
-	CacheKeyDuration time.Duration `env:"MG_RE_CACHE_KEY_DURATION"    envDefault:"10m"`
-	TraceRatio       float64       `env:"MG_JAEGER_TRACE_RATIO"      envDefault:"1.0"`
-	BrokerURL        string        `env:"MG_MESSAGE_BROKER_URL"      envDefault:"nats://localhost:4222"`
-	PermissionsFile  string        `env:"MG_PERMISSIONS_FILE"        envDefault:"permission.yaml"`
+	LogLevel            string        `env:"MG_RE_LOG_LEVEL"             envDefault:"info"`
+	InstanceID          string        `env:"MG_RE_INSTANCE_ID"           envDefault:""`
+	JaegerURL           url.URL       `env:"MG_JAEGER_URL"              envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry       bool          `env:"MG_SEND_TELEMETRY"          envDefault:"true"`
+	ESURL               string        `env:"MG_ES_URL"                  envDefault:"nats://localhost:4222"`
+	ESConsumerName      string        `env:"MG_RE_EVENT_CONSUMER"        envDefault:"rules_engine"`
+	CacheURL            string        `env:"MG_RE_CACHE_URL"             envDefault:"redis://localhost:6379/0"`
+	CacheKeyDuration    time.Duration `env:"MG_RE_CACHE_KEY_DURATION"    envDefault:"10m"`
+	TraceRatio          float64       `env:"MG_JAEGER_TRACE_RATIO"      envDefault:"1.0"`
+	BrokerURL           string        `env:"MG_MESSAGE_BROKER_URL"      envDefault:"nats://localhost:4222"`
+	PermissionsFile     string        `env:"MG_PERMISSIONS_FILE"        envDefault:"permission.yaml"`
+	ExecutionsRetention time.Duration `env:"MG_RE_EXECUTIONS_RETENTION"  envDefault:"720h"`
+	ExecutionsBatch     int           `env:"MG_RE_EXECUTIONS_BATCH"      envDefault:"100"`
+	ExecutionsBuffer    int           `env:"MG_RE_EXECUTIONS_BUFFER"     envDefault:"1000"`
+	ExecutionsInterval  time.Duration `env:"MG_RE_EXECUTIONS_INTERVAL"   envDefault:"1s"`
+	DeadLetterInterval  time.Duration `env:"MG_RE_DEAD_LETTER_INTERVAL"  envDefault:"30s"`
+	DeadLetterRetention time.Duration `env:"MG_RE_DEAD_LETTER_RETENTION" envDefault:"720h"`
+	StateStore          string        `env:"MG_RE_STATE_STORE"           envDefault:"memory"`
+	Workers             int           `env:"MG_RE_WORKERS"               envDefault:"100"`
+	WorkerWait          time.Duration `env:"MG_RE_WORKER_WAIT"           envDefault:"0s"`
+	RulesCacheTTL       time.Duration `env:"MG_RE_RULES_CACHE_TTL"       envDefault:"5m"`
+	ScriptsCacheSize    int64         `env:"MG_RE_SCRIPTS_CACHE_SIZE"    envDefault:"1000"`
+	ScriptTimeout       time.Duration `env:"MG_RE_SCRIPT_TIMEOUT"        envDefault:"5s"`
+	LuaCallStackSize    int           `env:"MG_RE_LUA_CALL_STACK_SIZE"   envDefault:"256"`
+	LuaStackSize        int           `env:"MG_RE_LUA_STACK_SIZE"        envDefault:"5120"`
+	LuaInstructions     uint64        `env:"MG_RE_LUA_INSTRUCTIONS"      envDefault:"0"`
+	SandboxFile         string        `env:"MG_RE_SANDBOX_FILE"          envDefault:""`
+	SandboxAllowAll     bool          `env:"MG_RE_SANDBOX_ALLOW_ALL"     envDefault:"false"`
+	WebhookAllowPrivate bool          `env:"MG_RE_WEBHOOK_ALLOW_PRIVATE" envDefault:"false"`
 }
 
 func main() {
@@ -234,7 +257,40 @@ func main() {
 	readersClient := grpcClient.NewReadersClient(client.Connection(), regrpcCfg.Timeout)
 	logger.Info("Readers gRPC client successfully connected to readers gRPC server " + client.Secure())
 
-	svc, err := newService(ctx, cfg, database, runInfo, msgSub, writersPub, alarmsPub, ec, logger, readersClient, callout, tracer)
+	var stateStore re.StateStore
+	switch cfg.StateStore {
+	case "memory":
+		stateStore = state.NewMemory()
+	case "redis":
+		cacheClient, err := redisclient.Connect(cfg.CacheURL)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to connect to state store: %s", err))
+			exitCode = 1
+
+			return
+		}
+		defer cacheClient.Close()
+		stateStore = state.NewRedis(cacheClient)
+	default:
+		logger.Error(fmt.Sprintf("unsupported state store %q, use memory or redis", cfg.StateStore))
+		exitCode = 1
+
+		return
+	}
+	logger.Info(fmt.Sprintf("Rule state is stored in %s", cfg.StateStore))
+
+	index := re.NewRuleIndex(cfg.RulesCacheTTL)
+	indexSub, err := events.SubscribeIndexInvalidation(ctx, index, cfg.ESURL, fmt.Sprintf("%s-index-%s", cfg.ESConsumerName, cfg.InstanceID), logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to rule events: %s", err))
+		exitCode = 1
+
+		return
+	}
+	defer indexSub.Close()
+
+	repo := repg.NewRepository(database)
+	svc, err := newService(ctx, cfg, repo, stateStore, index, runInfo, msgSub, writersPub, alarmsPub, ec, logger, readersClient, callout, tracer)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
@@ -273,6 +329,22 @@ func main() {
 		return svc.StartScheduler(ctx)
 	})
 
+	g.Go(func() error {
+		return svc.StartExecutionsWriter(ctx, ticker.NewTicker(cfg.ExecutionsInterval))
+	})
+
+	g.Go(func() error {
+		return re.StartExecutionsCleanup(ctx, repo, ticker.NewTicker(time.Hour), cfg.ExecutionsRetention, runInfo)
+	})
+
+	g.Go(func() error {
+		return svc.StartDeadLetterRetries(ctx, ticker.NewTicker(cfg.DeadLetterInterval))
+	})
+
+	g.Go(func() error {
+		return re.StartDeadLetterCleanup(ctx, repo, ticker.NewTicker(time.Hour), cfg.DeadLetterRetention, runInfo)
+	})
+
 	g.Go(func() error {
 		return httpSvc.Start()
 	})
@@ -286,8 +358,7 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, cfg config, db pgclient.Database, runInfo chan pkglog.RunInfo, rePubSub messaging.PubSub, writersPub, alarmsPub messaging.Publisher, ec email.Config, logger *slog.Logger, readersClient grpcReadersV1.ReadersServiceClient, callout callout.Callout, tracer trace.Tracer) (re.Service, error) {
-	repo := repg.NewRepository(db)
+func newService(ctx context.Context, cfg config, repo re.Repository, stateStore re.StateStore, index *re.RuleIndex, runInfo chan pkglog.RunInfo, rePubSub messaging.PubSub, writersPub, alarmsPub messaging.Publisher, ec email.Config, logger *slog.Logger, readersClient grpcReadersV1.ReadersServiceClient, callout callout.Callout, tracer trace.Tracer) (re.Service, error) {
 	idp := uuid.New()
 
 	emailerClient, err := emailer.New(&ec)
@@ -298,7 +369,35 @@ func newService(ctx context.Context, cfg config, db pgclient.Database, runInfo c
 	atomCfg := atom.LoadConfig()
 
 	var csvc re.Service
-	csvc, err = re.NewService(repo, runInfo, idp, rePubSub, writersPub, alarmsPub, ticker.NewTicker(time.Second*30), emailerClient, readersClient)
+	var sandbox re.Sandbox
+	if cfg.SandboxFile != "" {
+		sandbox, err = re.ReadSandboxFile(cfg.SandboxFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sandbox file: %w", err)
+		}
+	}
+	sandbox.Limits = re.Limits{
+		Timeout:          cfg.ScriptTimeout,
+		LuaCallStackSize: cfg.LuaCallStackSize,
+		LuaStackSize:     cfg.LuaStackSize,
+		LuaInstructions:  cfg.LuaInstructions,
+	}
+	// Keep the rules created before the allow-lists running, unless the file sets the default allow-list.
+	if cfg.SandboxAllowAll && sandbox.Modules == nil {
+		all := re.AllModules()
+		sandbox.Modules = &all
+	}
+	outputs.AllowPrivateWebhooks(cfg.WebhookAllowPrivate)
+	reCfg := re.Config{
+		Workers:          cfg.Workers,
+		WorkerWait:       cfg.WorkerWait,
+		ScriptsCacheSize: cfg.ScriptsCacheSize,
+		ExecutionsBatch:  cfg.ExecutionsBatch,
+		ExecutionsBuffer: cfg.ExecutionsBuffer,
+		Sandbox:          sandbox,
+		Metrics:          makeEngineMetrics(),
+	}
+	csvc, err = re.NewService(repo, stateStore, index, runInfo, idp, rePubSub, writersPub, alarmsPub, ticker.NewTicker(time.Second*30), emailerClient, readersClient, reCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create RE service: %w", err)
 	}
@@ -346,3 +445,33 @@ func newService(ctx context.Context, cfg config, db pgclient.Database, runInfo c
 
 	return csvc, nil
 }
+
+func makeEngineMetrics() re.Metrics {
+	return re.Metrics{
+		Running: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: "re",
+			Subsystem: "engine",
+			Name:      "running_rules",
+			Help:      "Number of rules being processed.",
+		}, []string{}),
+		Wait: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: "re",
+			Subsystem: "engine",
+			Name:      "worker_wait_seconds",
+			Help:      "Time rule runs waited for a free worker.",
+			Buckets:   stdprometheus.DefBuckets,
+		}, []string{}),
+		Dropped: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "re",
+			Subsystem: "engine",
+			Name:      "dropped_runs",
+			Help:      "Number of rule runs dropped because no worker was free.",
+		}, []string{}),
+		Cache: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "re",
+			Subsystem: "engine",
+			Name:      "cache_lookups",
+			Help:      "Number of rules index and compiled scripts cache lookups.",
+		}, []string{"cache", "result"}),
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains smtp-notifier main function to start the smtp-notifier service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/magistrala"
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/notifiers"
+	httpapi "github.com/absmach/magistrala/consumers/notifiers/api"
+	notifierpg "github.com/absmach/magistrala/consumers/notifiers/postgres"
+	"github.com/absmach/magistrala/consumers/notifiers/smtp"
+	"github.com/absmach/magistrala/consumers/notifiers/tracing"
+	consumertracing "github.com/absmach/magistrala/consumers/tracing"
+	"github.com/absmach/magistrala/internal/email"
+	mglog "github.com/absmach/magistrala/logger"
+	atomauthn "github.com/absmach/magistrala/pkg/authn/atom"
+	jaegerclient "github.com/absmach/magistrala/pkg/jaeger"
+	"github.com/absmach/magistrala/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/magistrala/pkg/messaging/brokers/tracing"
+	pgclient "github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/prometheus"
+	"github.com/absmach/magistrala/pkg/server"
+	httpserver "github.com/absmach/magistrala/pkg/server/http"
+	"github.com/absmach/magistrala/pkg/ticker"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/caarlos0/env/v11"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName        = "smtp-notifier"
+	envPrefixDB    = "MG_SMTP_NOTIFIER_DB_"
+	envPrefixHTTP  = "MG_SMTP_NOTIFIER_HTTP_"
+	defDB          = "subscriptions"
+	defSvcHTTPPort = "9015"
+)
+
+type config struct {
+	LogLevel       string        `env:"MG_SMTP_NOTIFIER_LOG_LEVEL"       envDefault:"info"`
+	ConfigPath     string        `env:"MG_SMTP_NOTIFIER_CONFIG_PATH"     envDefault:"/config.toml"`
+	From           string        `env:"MG_SMTP_NOTIFIER_FROM_ADDRESS"    envDefault:""`
+	DigestInterval time.Duration `env:"MG_SMTP_NOTIFIER_DIGEST_INTERVAL" envDefault:"1m"`
+	BrokerURL      string        `env:"MG_MESSAGE_BROKER_URL"            envDefault:"nats://localhost:4222"`
+	JaegerURL      url.URL       `env:"MG_JAEGER_URL"                    envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry  bool          `env:"MG_SEND_TELEMETRY"                envDefault:"true"`
+	InstanceID     string        `env:"MG_SMTP_NOTIFIER_INSTANCE_ID"     envDefault:""`
+	TraceRatio     float64       `env:"MG_JAEGER_TRACE_RATIO"            envDefault:"1.0"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := mglog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err.Error())
+	}
+
+	var exitCode int
+	defer mglog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s Postgres configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *notifierpg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	ec := email.Config{}
+	if err := env.Parse(&ec); err != nil {
+		logger.Error(fmt.Sprintf("failed to load email configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	agent, err := email.New(&ec)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create email agent: %s", err))
+		exitCode = 1
+		return
+	}
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Error shutting down tracer provider: %v", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger, brokers.ConnectionName(svcName))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	repo := tracing.New(tracer, notifierpg.New(notifierpg.NewDatabase(db, tracer)))
+	notifier := smtp.New(agent)
+	svc := newService(repo, notifier, cfg, logger)
+
+	consumer := consumertracing.NewBlocking(tracer, svc, httpServerConfig)
+	if err = consumers.Start(ctx, svcName, pubSub, consumer, cfg.ConfigPath, brokers.SubjectAllMessages, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create SMTP notifier: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, logger, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, magistrala.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return notifiers.StartDigests(ctx, repo, notifier, cfg.From, ticker.NewTicker(cfg.DigestInterval), logger)
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("SMTP notifier service terminated: %s", err))
+	}
+}
+
+func newService(repo notifiers.SubscriptionsRepository, notifier consumers.Notifier, cfg config, logger *slog.Logger) notifiers.Service {
+	idp := uuid.New()
+	svc := notifiers.New(atomauthn.NewAuthentication(), repo, idp, notifier, cfg.From)
+	svc = httpapi.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("notifier", "smtp")
+	svc = httpapi.MetricsMiddleware(svc, counter, latency)
+
+	return svc
+}
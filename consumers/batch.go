@@ -0,0 +1,215 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	smqjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/go-kit/kit/metrics"
+)
+
+// ErrBatchBufferFull indicates that the batch buffer has no room for the
+// message. The message is negatively acknowledged, so it is redelivered.
+var ErrBatchBufferFull = errors.New("batch buffer is full")
+
+// BatchConfig configures the batching of a BlockingConsumer.
+type BatchConfig struct {
+	// Size is the number of records which flushes the batch.
+	Size int `env:"BATCH_SIZE"     envDefault:"500"`
+	// Interval is the longest time a message waits for its batch to flush.
+	Interval time.Duration `env:"BATCH_INTERVAL" envDefault:"500ms"`
+	// Buffer is the number of messages waiting for a flush. It bounds the
+	// messages handled concurrently, so it should not be smaller than Size
+	// divided by the records per message. The subscribed topics share it,
+	// each handling an equal part of it concurrently.
+	Buffer int `env:"BATCH_BUFFER"   envDefault:"1000"`
+}
+
+// BatchMetrics instruments the batches of a BlockingConsumer.
+type BatchMetrics struct {
+	// Size observes the records of each flushed batch.
+	Size metrics.Histogram
+	// Latency observes the flush duration in seconds.
+	Latency metrics.Histogram
+	// Failed counts the batches which failed to flush as a whole.
+	Failed metrics.Counter
+	// Dropped counts the messages rejected because the buffer is full.
+	Dropped metrics.Counter
+}
+
+// inFlightConsumer is implemented by the consumers which expect several
+// messages to be consumed at once.
+type inFlightConsumer interface {
+	maxInFlight() int
+}
+
+var (
+	_ BlockingConsumer = (*batchConsumer)(nil)
+	_ inFlightConsumer = (*batchConsumer)(nil)
+)
+
+type batchItem struct {
+	messages any
+	records  int
+	done     chan error
+}
+
+type batchConsumer struct {
+	consumer BlockingConsumer
+	cfg      BatchConfig
+	metrics  BatchMetrics
+	queue    chan batchItem
+}
+
+// NewBatchConsumer returns a BlockingConsumer which writes the consumed
+// messages in batches to the consumer. A batch is flushed once it holds
+// cfg.Size records or once its oldest message waited for cfg.Interval.
+// ConsumeBlocking returns only after the batch of the message is flushed, so
+// messages are acknowledged only once they are written. If a batch fails,
+// its messages are written one by one, so a single invalid message does not
+// fail the rest. Consumption stops when the context is canceled.
+func NewBatchConsumer(ctx context.Context, consumer BlockingConsumer, cfg BatchConfig, m BatchMetrics) BlockingConsumer {
+	bc := &batchConsumer{
+		consumer: consumer,
+		cfg:      cfg,
+		metrics:  m,
+		queue:    make(chan batchItem, max(cfg.Buffer, 1)),
+	}
+	go bc.run(ctx)
+
+	return bc
+}
+
+func (bc *batchConsumer) ConsumeBlocking(ctx context.Context, messages any) error {
+	item := batchItem{messages: messages, records: records(messages), done: make(chan error, 1)}
+	select {
+	case bc.queue <- item:
+	default:
+		bc.metrics.Dropped.Add(1)
+		return messaging.NewError(ErrBatchBufferFull, messaging.Nack)
+	}
+
+	select {
+	case err := <-item.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (bc *batchConsumer) maxInFlight() int {
+	return bc.cfg.Buffer
+}
+
+func (bc *batchConsumer) run(ctx context.Context) {
+	timer := time.NewTimer(bc.cfg.Interval)
+	timer.Stop()
+	defer timer.Stop()
+
+	var batch []batchItem
+	var size int
+	for {
+		select {
+		case <-ctx.Done():
+			for _, item := range batch {
+				item.done <- ctx.Err()
+			}
+			return
+		case item := <-bc.queue:
+			if len(batch) == 0 {
+				timer.Reset(bc.cfg.Interval)
+			}
+			batch = append(batch, item)
+			size += item.records
+			if size < bc.cfg.Size {
+				continue
+			}
+			timer.Stop()
+		case <-timer.C:
+		}
+		bc.flush(ctx, batch, size)
+		batch, size = nil, 0
+	}
+}
+
+func (bc *batchConsumer) flush(ctx context.Context, batch []batchItem, size int) {
+	defer func(begin time.Time) {
+		bc.metrics.Size.Observe(float64(size))
+		bc.metrics.Latency.Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	for _, g := range merge(batch) {
+		err := bc.consumer.ConsumeBlocking(ctx, g.messages)
+		if err != nil && len(g.items) > 1 {
+			bc.metrics.Failed.Add(1)
+			for _, item := range g.items {
+				item.done <- bc.consumer.ConsumeBlocking(ctx, item.messages)
+			}
+			continue
+		}
+		if err != nil {
+			bc.metrics.Failed.Add(1)
+		}
+		for _, item := range g.items {
+			item.done <- err
+		}
+	}
+}
+
+// batchGroup is the merged messages of the items which are written together.
+type batchGroup struct {
+	messages any
+	items    []batchItem
+}
+
+// merge merges the SenML messages of the batch, and the JSON messages of each
+// format, keeping their order. Other messages are written on their own.
+func merge(batch []batchItem) []*batchGroup {
+	var groups []*batchGroup
+	var senmlGroup *batchGroup
+	jsonGroups := map[string]*batchGroup{}
+	for _, item := range batch {
+		switch m := item.messages.(type) {
+		case []senml.Message:
+			if senmlGroup == nil {
+				senmlGroup = &batchGroup{messages: []senml.Message{}}
+				groups = append(groups, senmlGroup)
+			}
+			senmlGroup.messages = append(senmlGroup.messages.([]senml.Message), m...)
+			senmlGroup.items = append(senmlGroup.items, item)
+		case smqjson.Messages:
+			g, ok := jsonGroups[m.Format]
+			if !ok {
+				g = &batchGroup{messages: smqjson.Messages{Format: m.Format}}
+				jsonGroups[m.Format] = g
+				groups = append(groups, g)
+			}
+			msgs := g.messages.(smqjson.Messages)
+			msgs.Data = append(msgs.Data, m.Data...)
+			g.messages = msgs
+			g.items = append(g.items, item)
+		default:
+			groups = append(groups, &batchGroup{messages: item.messages, items: []batchItem{item}})
+		}
+	}
+
+	return groups
+}
+
+// records returns the number of records the messages write.
+func records(messages any) int {
+	switch m := messages.(type) {
+	case []senml.Message:
+		return len(m)
+	case smqjson.Messages:
+		return len(m.Data)
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/messaging/mocks"
+	smqjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var errBadMessage = errors.New("bad message")
+
+// writer records the written messages and fails the writes which contain a
+// message named "bad".
+type writer struct {
+	mu      sync.Mutex
+	writes  []any
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *writer) ConsumeBlocking(_ context.Context, messages any) error {
+	if w.started != nil {
+		w.started <- struct{}{}
+		<-w.release
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if msgs, ok := messages.([]senml.Message); ok {
+		for _, m := range msgs {
+			if m.Name == "bad" {
+				return errBadMessage
+			}
+		}
+	}
+	w.writes = append(w.writes, messages)
+
+	return nil
+}
+
+func (w *writer) written() []any {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.writes
+}
+
+func batchMetrics() consumers.BatchMetrics {
+	return consumers.BatchMetrics{
+		Size:    discard.NewHistogram(),
+		Latency: discard.NewHistogram(),
+		Failed:  discard.NewCounter(),
+		Dropped: discard.NewCounter(),
+	}
+}
+
+func senmlMessages(names ...string) []senml.Message {
+	msgs := []senml.Message{}
+	for _, name := range names {
+		msgs = append(msgs, senml.Message{Channel: "channel", Name: name})
+	}
+
+	return msgs
+}
+
+// consumeAll consumes the messages concurrently and returns their errors.
+func consumeAll(ctx context.Context, c consumers.BlockingConsumer, messages ...any) []error {
+	errs := make([]error, len(messages))
+	var wg sync.WaitGroup
+	for i, m := range messages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.ConsumeBlocking(ctx, m)
+		}()
+	}
+	wg.Wait()
+
+	return errs
+}
+
+func TestBatchConsumer(t *testing.T) {
+	cases := []struct {
+		desc     string
+		cfg      consumers.BatchConfig
+		messages []any
+		errs     []error
+		writes   int
+		records  int
+	}{
+		{
+			desc:     "flush full batch",
+			cfg:      consumers.BatchConfig{Size: 4, Interval: time.Hour, Buffer: 10},
+			messages: []any{senmlMessages("a", "b"), senmlMessages("c", "d")},
+			errs:     []error{nil, nil},
+			writes:   1,
+			records:  4,
+		},
+		{
+			desc:     "flush batch after interval",
+			cfg:      consumers.BatchConfig{Size: 100, Interval: 10 * time.Millisecond, Buffer: 10},
+			messages: []any{senmlMessages("a")},
+			errs:     []error{nil},
+			writes:   1,
+			records:  1,
+		},
+		{
+			desc: "flush JSON messages of each format",
+			cfg:  consumers.BatchConfig{Size: 3, Interval: time.Hour, Buffer: 10},
+			messages: []any{
+				smqjson.Messages{Format: "first", Data: []smqjson.Message{{}}},
+				smqjson.Messages{Format: "second", Data: []smqjson.Message{{}}},
+				smqjson.Messages{Format: "first", Data: []smqjson.Message{{}}},
+			},
+			errs:    []error{nil, nil, nil},
+			writes:  2,
+			records: 3,
+		},
+		{
+			desc:     "write messages of failed batch one by one",
+			cfg:      consumers.BatchConfig{Size: 3, Interval: time.Hour, Buffer: 10},
+			messages: []any{senmlMessages("a"), senmlMessages("bad"), senmlMessages("c")},
+			errs:     []error{nil, errBadMessage, nil},
+			writes:   2,
+			records:  2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			w := &writer{}
+			bc := consumers.NewBatchConsumer(ctx, w, tc.cfg, batchMetrics())
+
+			errs := consumeAll(ctx, bc, tc.messages...)
+			for i, err := range errs {
+				assert.True(t, errors.Contains(err, tc.errs[i]), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.errs[i], err))
+			}
+			writes := w.written()
+			assert.Len(t, writes, tc.writes, fmt.Sprintf("%s: expected %d writes got %d\n", tc.desc, tc.writes, len(writes)))
+			records := 0
+			for _, m := range writes {
+				switch m := m.(type) {
+				case []senml.Message:
+					records += len(m)
+				case smqjson.Messages:
+					records += len(m.Data)
+				}
+			}
+			assert.Equal(t, tc.records, records, fmt.Sprintf("%s: expected %d records got %d\n", tc.desc, tc.records, records))
+		})
+	}
+}
+
+func TestBatchConsumerBufferFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := &writer{started: make(chan struct{}), release: make(chan struct{})}
+	bc := consumers.NewBatchConsumer(ctx, w, consumers.BatchConfig{Size: 1, Interval: time.Hour, Buffer: 1}, batchMetrics())
+
+	first := make(chan error, 1)
+	go func() { first <- bc.ConsumeBlocking(ctx, senmlMessages("a")) }()
+	<-w.started
+
+	// One of the messages waits in the buffer and the other one is dropped.
+	errs := make(chan error, 2)
+	go func() { errs <- bc.ConsumeBlocking(ctx, senmlMessages("b")) }()
+	go func() { errs <- bc.ConsumeBlocking(ctx, senmlMessages("c")) }()
+	err := <-errs
+	assert.True(t, errors.Contains(err, consumers.ErrBatchBufferFull), fmt.Sprintf("expected error %s got %s\n", consumers.ErrBatchBufferFull, err))
+
+	w.release <- struct{}{}
+	assert.Nil(t, <-first)
+	<-w.started
+	w.release <- struct{}{}
+	assert.Nil(t, <-errs)
+	assert.Len(t, w.written(), 2)
+}
+
+func TestBatchConsumerCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &writer{}
+	bc := consumers.NewBatchConsumer(ctx, w, consumers.BatchConfig{Size: 100, Interval: time.Hour, Buffer: 10}, batchMetrics())
+
+	errs := make(chan error, 1)
+	go func() { errs <- bc.ConsumeBlocking(ctx, senmlMessages("a")) }()
+	cancel()
+
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	assert.Empty(t, w.written())
+}
+
+func TestStartSharesInFlight(t *testing.T) {
+	cases := []struct {
+		desc        string
+		topics      string
+		buffer      int
+		maxInFlight []int
+	}{
+		{
+			desc:        "one topic",
+			topics:      `["writers/#"]`,
+			buffer:      1000,
+			maxInFlight: []int{1000},
+		},
+		{
+			desc:        "several topics",
+			topics:      `["first/#", "second/#", "third/#"]`,
+			buffer:      1000,
+			maxInFlight: []int{333, 333, 333},
+		},
+		{
+			desc:        "more topics than buffer",
+			topics:      `["first/#", "second/#"]`,
+			buffer:      1,
+			maxInFlight: []int{1, 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			require.Nil(t, os.WriteFile(path, []byte("[subscriber]\ntopics = "+tc.topics+"\n"), 0o600))
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			bc := consumers.NewBatchConsumer(ctx, &writer{}, consumers.BatchConfig{Size: 10, Interval: time.Second, Buffer: tc.buffer}, batchMetrics())
+
+			var maxInFlight []int
+			pubsub := mocks.NewPubSub(t)
+			pubsub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				maxInFlight = append(maxInFlight, args.Get(1).(messaging.SubscriberConfig).MaxInFlight)
+			}).Return(nil)
+
+			err := consumers.Start(ctx, "consumer", pubsub, bc, path, "writers/#", slog.New(slog.DiscardHandler))
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.maxInFlight, maxInFlight, fmt.Sprintf("%s: unexpected max in flight", tc.desc))
+		})
+	}
+}
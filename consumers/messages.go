@@ -14,31 +14,40 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/transformers"
+	"github.com/absmach/magistrala/pkg/transformers/binary"
 	"github.com/absmach/magistrala/pkg/transformers/json"
 	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/pkg/transformers/sparkplug"
 	"github.com/pelletier/go-toml"
 )
 
 const (
 	defContentType = "application/senml+json"
 	defFormat      = "senml"
+	binaryFormat   = "BINARY"
+	// rawFormat passes the messages on untransformed.
+	rawFormat = "RAW"
 )
 
 var (
 	errOpenConfFile  = errors.New("unable to open configuration file")
 	errParseConfFile = errors.New("unable to parse configuration file")
+	errInvalidSchema = errors.New("invalid binary schema of transformer")
 )
 
 // Start method starts consuming messages received from Message broker.
-// This method transforms messages to SenML format before
-// using MessageRepository to store them.
+// This method transforms messages to SenML or JSON format, as configured
+// for their topic and channel, before using MessageRepository to store them.
 func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer any, configPath string, defaultTopic string, logger *slog.Logger) error {
 	cfg, err := loadConfig(configPath, defaultTopic)
-	if err != nil {
+	switch {
+	case errors.Contains(err, errInvalidSchema):
+		return err
+	case err != nil:
 		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
 	}
 
-	transformer := makeTransformer(cfg.TransformerCfg, logger)
+	transformer := makeTransformers(cfg, defaultTopic, logger)
 
 	for _, topic := range cfg.SubscriberCfg.Topics {
 		subCfg := messaging.SubscriberConfig{
@@ -54,6 +63,10 @@ func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer an
 			}
 		case BlockingConsumer:
 			subCfg.Handler = handleSync(ctx, transformer, c)
+			// The topics share the messages the consumer handles at once.
+			if ic, ok := c.(inFlightConsumer); ok {
+				subCfg.MaxInFlight = max(ic.maxInFlight()/len(cfg.SubscriberCfg.Topics), 1)
+			}
 			if err := sub.Subscribe(ctx, subCfg); err != nil {
 				return err
 			}
@@ -112,11 +125,15 @@ type transformerConfig struct {
 	Format      string           `toml:"format"`
 	ContentType string           `toml:"content_type"`
 	TimeFields  []json.TimeField `toml:"time_fields"`
+	// Schema describes the payloads of the binary format.
+	Schema binary.Schema `toml:"schema"`
 }
 
 type config struct {
 	SubscriberCfg  subscriberConfig  `toml:"subscriber"`
 	TransformerCfg transformerConfig `toml:"transformer"`
+	// Transformers override the transformer of the matching messages.
+	Transformers []transformerRule `toml:"transformers"`
 }
 
 func loadConfig(configPath, defaultTopic string) (config, error) {
@@ -138,10 +155,37 @@ func loadConfig(configPath, defaultTopic string) (config, error) {
 	if err := toml.Unmarshal(data, &cfg); err != nil {
 		return cfg, errors.Wrap(errParseConfFile, err)
 	}
+	if err := validateSchema(cfg.TransformerCfg); err != nil {
+		return cfg, err
+	}
+	for i, rule := range cfg.Transformers {
+		if rule.Format == "" {
+			cfg.Transformers[i].Format = defFormat
+		}
+		if rule.ContentType == "" {
+			cfg.Transformers[i].ContentType = defContentType
+		}
+		if err := validateSchema(rule.config()); err != nil {
+			return cfg, err
+		}
+	}
 
 	return cfg, nil
 }
 
+// validateSchema validates the schema of the binary format, so an invalid
+// schema fails the start of the consumer instead of each message.
+func validateSchema(cfg transformerConfig) error {
+	if strings.ToUpper(cfg.Format) != binaryFormat {
+		return nil
+	}
+	if err := cfg.Schema.Validate(); err != nil {
+		return errors.Wrap(errInvalidSchema, err)
+	}
+
+	return nil
+}
+
 func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Transformer {
 	switch strings.ToUpper(cfg.Format) {
 	case "SENML":
@@ -150,6 +194,23 @@ func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Tr
 	case "JSON":
 		logger.Info("Using JSON transformer")
 		return json.New(cfg.TimeFields)
+	case autoFormat:
+		logger.Info("Using SenML or JSON transformer by message payload")
+		return newAutoTransformer(cfg.TimeFields)
+	case "SPARKPLUG":
+		logger.Info("Using Sparkplug B transformer")
+		return sparkplug.New(sparkplug.DefMaxNodes, logger)
+	case rawFormat:
+		logger.Info("Using no transformer")
+		return nil
+	case binaryFormat:
+		tr, err := binary.New(cfg.Schema)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("No transformer created: %s", err))
+			return nil
+		}
+		logger.Info("Using binary transformer")
+		return tr
 	default:
 		logger.Warn(fmt.Sprintf("No transformer created: unknown transformer type %s", cfg.Format))
 		return nil
@@ -9,6 +9,8 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/absmach/magistrala/pkg/messaging"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -41,16 +43,16 @@ func (_m *Notifier) EXPECT() *Notifier_Expecter {
 }
 
 // Notify provides a mock function for the type Notifier
-func (_mock *Notifier) Notify(from string, to []string, msg *messaging.Message) error {
-	ret := _mock.Called(from, to, msg)
+func (_mock *Notifier) Notify(ctx context.Context, from string, to []string, msg *messaging.Message) error {
+	ret := _mock.Called(ctx, from, to, msg)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Notify")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(string, []string, *messaging.Message) error); ok {
-		r0 = returnFunc(from, to, msg)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, *messaging.Message) error); ok {
+		r0 = returnFunc(ctx, from, to, msg)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -63,31 +65,37 @@ type Notifier_Notify_Call struct {
 }
 
 // Notify is a helper method to define mock.On call
+//   - ctx context.Context
 //   - from string
 //   - to []string
 //   - msg *messaging.Message
-func (_e *Notifier_Expecter) Notify(from interface{}, to interface{}, msg interface{}) *Notifier_Notify_Call {
-	return &Notifier_Notify_Call{Call: _e.mock.On("Notify", from, to, msg)}
+func (_e *Notifier_Expecter) Notify(ctx interface{}, from interface{}, to interface{}, msg interface{}) *Notifier_Notify_Call {
+	return &Notifier_Notify_Call{Call: _e.mock.On("Notify", ctx, from, to, msg)}
 }
 
-func (_c *Notifier_Notify_Call) Run(run func(from string, to []string, msg *messaging.Message)) *Notifier_Notify_Call {
+func (_c *Notifier_Notify_Call) Run(run func(ctx context.Context, from string, to []string, msg *messaging.Message)) *Notifier_Notify_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
 		}
-		var arg2 *messaging.Message
+		var arg2 []string
 		if args[2] != nil {
-			arg2 = args[2].(*messaging.Message)
+			arg2 = args[2].([]string)
+		}
+		var arg3 *messaging.Message
+		if args[3] != nil {
+			arg3 = args[3].(*messaging.Message)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -98,7 +106,7 @@ func (_c *Notifier_Notify_Call) Return(err error) *Notifier_Notify_Call {
 	return _c
 }
 
-func (_c *Notifier_Notify_Call) RunAndReturn(run func(from string, to []string, msg *messaging.Message) error) *Notifier_Notify_Call {
+func (_c *Notifier_Notify_Call) RunAndReturn(run func(ctx context.Context, from string, to []string, msg *messaging.Message) error) *Notifier_Notify_Call {
 	_c.Call.Return(run)
 	return _c
 }
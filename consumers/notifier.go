@@ -4,6 +4,7 @@
 package consumers
 
 import (
+	"context"
 	"errors"
 
 	"github.com/absmach/magistrala/pkg/messaging"
@@ -16,5 +17,32 @@ var ErrNotify = errors.New("error sending notification")
 type Notifier interface {
 	// Notify method is used to send notification for the
 	// received message to the provided list of receivers.
-	Notify(from string, to []string, msg *messaging.Message) error
+	Notify(ctx context.Context, from string, to []string, msg *messaging.Message) error
+}
+
+// ContactValidator is implemented by the notifiers which restrict the
+// contacts they send notifications to.
+type ContactValidator interface {
+	// ValidateContact returns an error if the notifier can't notify the
+	// contact.
+	ValidateContact(ctx context.Context, contact string) error
+}
+
+// Recipient is a notification contact with its own notification settings.
+type Recipient struct {
+	Contact string
+	// Secret signs the notifications of the recipient, if the notifier
+	// supports signing. They are not signed if it is empty.
+	Secret string
+	// Headers are added to the notifications of the recipient, if the
+	// notifier supports headers.
+	Headers map[string]string
+}
+
+// RecipientNotifier is implemented by the notifiers which send the
+// notifications with the settings of each recipient.
+type RecipientNotifier interface {
+	// NotifyRecipients sends the notification of the message to the
+	// recipients.
+	NotifyRecipients(ctx context.Context, from string, to []Recipient, msg *messaging.Message) error
 }
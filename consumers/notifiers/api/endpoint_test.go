@@ -12,6 +12,7 @@ import (
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	apiutil "github.com/absmach/magistrala/api/http/util"
 	"github.com/absmach/magistrala/consumers/notifiers"
@@ -95,6 +96,22 @@ func TestCreate(t *testing.T) {
 
 	emptyTopic := toJSON(notifiers.Subscription{Contact: contact1})
 	emptyContact := toJSON(notifiers.Subscription{Topic: "topic123"})
+	invalidTemplate := toJSON(notifiers.Subscription{Topic: topic, Contact: contact1, Template: "{{.Payload"})
+	threshold := 30.0
+	settingsSub := notifiers.Subscription{
+		Topic:          topic,
+		Contact:        "settings@example.com",
+		Filters:        []notifiers.Filter{{Name: "temperature", Comparator: notifiers.GreaterThanKey, Value: &threshold}},
+		RateLimit:      5,
+		RateInterval:   30 * time.Minute,
+		QuietHours:     &notifiers.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Belgrade"},
+		DigestInterval: time.Hour,
+	}
+	settings := fmt.Sprintf(`{"topic":%q,"contact":"settings@example.com","filters":[{"name":"temperature","comparator":"gt","value":30}],"rate_limit":5,"rate_interval":"30m","quiet_hours":{"start":"22:00","end":"07:00","timezone":"Europe/Belgrade"},"digest_interval":"1h"}`, topic)
+	invalidFilter := fmt.Sprintf(`{"topic":%q,"contact":%q,"filters":[{"name":"temperature","comparator":"gt","string_value":"high"}]}`, topic, contact1)
+	invalidRateInterval := fmt.Sprintf(`{"topic":%q,"contact":%q,"rate_limit":5,"rate_interval":"often"}`, topic, contact1)
+	invalidQuietHours := fmt.Sprintf(`{"topic":%q,"contact":%q,"quiet_hours":{"start":"22:00","end":"22:00"}}`, topic, contact1)
+	invalidDigestInterval := fmt.Sprintf(`{"topic":%q,"contact":%q,"digest_interval":"daily"}`, topic, contact1)
 
 	cases := []struct {
 		desc        string
@@ -141,6 +158,60 @@ func TestCreate(t *testing.T) {
 			location:    "",
 			err:         svcerr.ErrMalformedEntity,
 		},
+		{
+			desc:        "add with invalid template",
+			req:         invalidTemplate,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with filters, rate limit, quiet hours and digest",
+			req:         settings,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s%012d", uuid.Prefix, 2),
+			err:         nil,
+		},
+		{
+			desc:        "add with invalid filter",
+			req:         invalidFilter,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with invalid rate interval",
+			req:         invalidRateInterval,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with invalid quiet hours",
+			req:         invalidQuietHours,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "add with invalid digest interval",
+			req:         invalidDigestInterval,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			location:    "",
+			err:         svcerr.ErrMalformedEntity,
+		},
 		{
 			desc:        "add with invalid auth token",
 			req:         data,
@@ -181,6 +252,7 @@ func TestCreate(t *testing.T) {
 
 	for _, tc := range cases {
 		svcCall := svc.On("CreateSubscription", mock.Anything, tc.auth, sub).Return(path.Base(tc.location), tc.err)
+		svcCall1 := svc.On("CreateSubscription", mock.Anything, tc.auth, settingsSub).Return(path.Base(tc.location), tc.err)
 
 		req := testRequest{
 			client:      ss.Client(),
@@ -198,6 +270,7 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, tc.location, location, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, location))
 
 		svcCall.Unset()
+		svcCall1.Unset()
 	}
 }
 
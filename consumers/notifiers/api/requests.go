@@ -3,12 +3,57 @@
 
 package api
 
-import apiutil "github.com/absmach/magistrala/api/http/util"
+import (
+	"time"
+
+	apiutil "github.com/absmach/magistrala/api/http/util"
+	notifiers "github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/pkg/errors"
+)
 
 type createSubReq struct {
-	token   string
-	Topic   string `json:"topic,omitempty"`
-	Contact string `json:"contact,omitempty"`
+	token          string
+	Topic          string                `json:"topic,omitempty"`
+	Contact        string                `json:"contact,omitempty"`
+	Template       string                `json:"template,omitempty"`
+	Filters        []notifiers.Filter    `json:"filters,omitempty"`
+	RateLimit      uint                  `json:"rate_limit,omitempty"`
+	RateInterval   string                `json:"rate_interval,omitempty"`
+	QuietHours     *notifiers.QuietHours `json:"quiet_hours,omitempty"`
+	DigestInterval string                `json:"digest_interval,omitempty"`
+	Secret         string                `json:"secret,omitempty"`
+	Headers        map[string]string     `json:"headers,omitempty"`
+}
+
+// subscription returns the subscription of the request, whose intervals are
+// duration strings such as "30m" or "1h".
+func (req createSubReq) subscription() (notifiers.Subscription, error) {
+	sub := notifiers.Subscription{
+		Contact:    req.Contact,
+		Topic:      req.Topic,
+		Template:   req.Template,
+		Filters:    req.Filters,
+		RateLimit:  req.RateLimit,
+		QuietHours: req.QuietHours,
+		Secret:     req.Secret,
+		Headers:    req.Headers,
+	}
+	if req.RateInterval != "" {
+		d, err := time.ParseDuration(req.RateInterval)
+		if err != nil {
+			return notifiers.Subscription{}, errors.Wrap(notifiers.ErrInvalidRateLimit, err)
+		}
+		sub.RateInterval = d
+	}
+	if req.DigestInterval != "" {
+		d, err := time.ParseDuration(req.DigestInterval)
+		if err != nil {
+			return notifiers.Subscription{}, errors.Wrap(notifiers.ErrInvalidDigest, err)
+		}
+		sub.DigestInterval = d
+	}
+
+	return sub, nil
 }
 
 func (req createSubReq) validate() error {
@@ -21,7 +66,11 @@ func (req createSubReq) validate() error {
 	if req.Contact == "" {
 		return apiutil.ErrInvalidContact
 	}
-	return nil
+	sub, err := req.subscription()
+	if err != nil {
+		return err
+	}
+	return sub.Validate()
 }
 
 type subReq struct {
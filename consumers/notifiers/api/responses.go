@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/absmach/magistrala"
+	notifiers "github.com/absmach/magistrala/consumers/notifiers"
 )
 
 var (
@@ -36,10 +37,37 @@ func (res createSubRes) Empty() bool {
 }
 
 type viewSubRes struct {
-	ID      string `json:"id"`
-	OwnerID string `json:"owner_id"`
-	Contact string `json:"contact"`
-	Topic   string `json:"topic"`
+	ID             string                `json:"id"`
+	OwnerID        string                `json:"owner_id"`
+	Contact        string                `json:"contact"`
+	Topic          string                `json:"topic"`
+	Template       string                `json:"template,omitempty"`
+	Filters        []notifiers.Filter    `json:"filters,omitempty"`
+	RateLimit      uint                  `json:"rate_limit,omitempty"`
+	RateInterval   string                `json:"rate_interval,omitempty"`
+	QuietHours     *notifiers.QuietHours `json:"quiet_hours,omitempty"`
+	DigestInterval string                `json:"digest_interval,omitempty"`
+}
+
+func newViewSubRes(sub notifiers.Subscription) viewSubRes {
+	res := viewSubRes{
+		ID:         sub.ID,
+		OwnerID:    sub.OwnerID,
+		Contact:    sub.Contact,
+		Topic:      sub.Topic,
+		Template:   sub.Template,
+		Filters:    sub.Filters,
+		RateLimit:  sub.RateLimit,
+		QuietHours: sub.QuietHours,
+	}
+	if sub.RateInterval > 0 {
+		res.RateInterval = sub.RateInterval.String()
+	}
+	if sub.DigestInterval > 0 {
+		res.DigestInterval = sub.DigestInterval.String()
+	}
+
+	return res
 }
 
 func (res viewSubRes) Code() int {
@@ -0,0 +1,105 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
+)
+
+const (
+	digestBatch = 100
+	// maxDigestMessages caps the messages listed by a digest, and the ones
+	// over it are only counted.
+	maxDigestMessages = 100
+	// digestLease is how long claimed digests are hidden from other service
+	// instances.
+	digestLease = 5 * time.Minute
+)
+
+// StartDigests sends the due subscription digests on every tick until the
+// context is done.
+func StartDigests(ctx context.Context, repo SubscriptionsRepository, notifier consumers.Notifier, from string, tck ticker.Ticker, logger *slog.Logger) error {
+	defer tck.Stop()
+	d := digester{repo: repo, notifier: notifier, from: from, logger: logger}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tck.Tick():
+			d.send(ctx)
+		}
+	}
+}
+
+type digester struct {
+	repo     SubscriptionsRepository
+	notifier consumers.Notifier
+	from     string
+	logger   *slog.Logger
+}
+
+// send sends the due digests, except the ones of the subscriptions in their
+// quiet hours, which are sent once the quiet hours end. Digests are claimed
+// in batches, so concurrent service instances send each digest once. A
+// failed digest is kept, so it is sent again once its claim expires.
+func (d digester) send(ctx context.Context) {
+	now := time.Now()
+	digests, err := d.repo.ClaimDueDigests(ctx, now, now.Add(digestLease), digestBatch, maxDigestMessages)
+	if err != nil {
+		d.logger.Error(fmt.Sprintf("failed to claim subscription digests: %s", err))
+		return
+	}
+	for _, dg := range digests {
+		sub := dg.Subscription
+		if sub.quiet(now) || len(dg.Messages) == 0 {
+			continue
+		}
+		if err := send(ctx, d.notifier, d.from, []consumers.Recipient{sub.recipient()}, digestMessage(dg, now)); err != nil {
+			d.logger.Error(fmt.Sprintf("failed to send subscription digest: %s", err), slog.String("subscription_id", sub.ID))
+			continue
+		}
+		if err := d.repo.RemoveDigest(ctx, sub.ID, dg.LastID); err != nil {
+			d.logger.Error(fmt.Sprintf("failed to remove subscription digest: %s", err), slog.String("subscription_id", sub.ID))
+		}
+	}
+}
+
+// digestMessage joins the messages of the digest into one message, with a
+// line per message, and a last line counting the messages over the cap of
+// the digest. The messages are rendered with the subscription template, and
+// the ones which fail to render keep their payload.
+func digestMessage(dg Digest, now time.Time) *messaging.Message {
+	first := dg.Messages[0]
+	total := max(dg.Total, uint64(len(dg.Messages)))
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d notifications on %s since %s\n", total, dg.Subscription.Topic, time.Unix(0, first.GetCreated()).UTC().Format(time.RFC3339))
+	for _, msg := range dg.Messages {
+		text := string(msg.GetPayload())
+		if dg.Subscription.Template != "" {
+			if m, err := render(dg.Subscription.Template, msg); err == nil {
+				text = string(m.GetPayload())
+			}
+		}
+		fmt.Fprintf(&b, "\n%s: %s", time.Unix(0, msg.GetCreated()).UTC().Format(time.RFC3339), text)
+	}
+	if more := total - uint64(len(dg.Messages)); more > 0 {
+		fmt.Fprintf(&b, "\n... and %d more notifications", more)
+	}
+
+	return &messaging.Message{
+		Channel:  first.GetChannel(),
+		Domain:   first.GetDomain(),
+		Subtopic: first.GetSubtopic(),
+		Payload:  []byte(b.String()),
+		Created:  now.UnixNano(),
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	smqmocks "github.com/absmach/magistrala/consumers/mocks"
+	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/consumers/notifiers/mocks"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/messaging"
+	tmocks "github.com/absmach/magistrala/pkg/ticker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartDigests(t *testing.T) {
+	created := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	msgs := []*messaging.Message{
+		{Channel: "topic", Payload: []byte(`{"temperature":21.5}`), Created: created.UnixNano()},
+		{Channel: "topic", Payload: []byte(`{"temperature":23}`), Created: created.Add(time.Minute).UnixNano()},
+	}
+	now := time.Now().UTC()
+	quiet := &notifiers.QuietHours{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}
+
+	cases := []struct {
+		desc        string
+		digest      notifiers.Digest
+		retrieveErr error
+		notify      bool
+		notifyErr   error
+		payload     string
+		remove      bool
+	}{
+		{
+			desc: "send digest successfully",
+			digest: notifiers.Digest{
+				Subscription: notifiers.Subscription{ID: "sub", Contact: "user@example.com", Topic: "topic", DigestInterval: time.Hour},
+				Messages:     msgs,
+				LastID:       2,
+			},
+			notify:  true,
+			payload: "2 notifications on topic since 2024-03-01T10:00:00Z\n\n2024-03-01T10:00:00Z: {\"temperature\":21.5}\n2024-03-01T10:01:00Z: {\"temperature\":23}",
+			remove:  true,
+		},
+		{
+			desc: "send digest with overflow",
+			digest: notifiers.Digest{
+				Subscription: notifiers.Subscription{ID: "sub", Contact: "user@example.com", Topic: "topic", DigestInterval: time.Hour},
+				Messages:     msgs,
+				Total:        150,
+				LastID:       150,
+			},
+			notify:  true,
+			payload: "150 notifications on topic since 2024-03-01T10:00:00Z\n\n2024-03-01T10:00:00Z: {\"temperature\":21.5}\n2024-03-01T10:01:00Z: {\"temperature\":23}\n... and 148 more notifications",
+			remove:  true,
+		},
+		{
+			desc: "send digest with template",
+			digest: notifiers.Digest{
+				Subscription: notifiers.Subscription{ID: "sub", Contact: "user@example.com", Topic: "topic", Template: "temperature {{.Values.temperature}}", DigestInterval: time.Hour},
+				Messages:     msgs,
+				LastID:       2,
+			},
+			notify:  true,
+			payload: "2 notifications on topic since 2024-03-01T10:00:00Z\n\n2024-03-01T10:00:00Z: temperature 21.5\n2024-03-01T10:01:00Z: temperature 23",
+			remove:  true,
+		},
+		{
+			desc: "keep digest in quiet hours",
+			digest: notifiers.Digest{
+				Subscription: notifiers.Subscription{ID: "sub", Contact: "user@example.com", Topic: "topic", QuietHours: quiet, DigestInterval: time.Hour},
+				Messages:     msgs,
+				LastID:       2,
+			},
+		},
+		{
+			desc: "keep digest with failed notification",
+			digest: notifiers.Digest{
+				Subscription: notifiers.Subscription{ID: "sub", Contact: "user@example.com", Topic: "topic", DigestInterval: time.Hour},
+				Messages:     msgs,
+				LastID:       2,
+			},
+			notify:    true,
+			notifyErr: errors.New("failed to notify"),
+			payload:   "2 notifications on topic since 2024-03-01T10:00:00Z\n\n2024-03-01T10:00:00Z: {\"temperature\":21.5}\n2024-03-01T10:01:00Z: {\"temperature\":23}",
+		},
+		{
+			desc:        "claim digests with failed repository",
+			retrieveErr: repoerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repo := new(mocks.SubscriptionsRepository)
+			notifier := new(smqmocks.Notifier)
+			tck := new(tmocks.Ticker)
+
+			var digests []notifiers.Digest
+			if tc.retrieveErr == nil {
+				digests = []notifiers.Digest{tc.digest}
+			}
+			repo.On("ClaimDueDigests", mock.Anything, mock.Anything, mock.Anything, uint64(100), uint64(100)).Return(digests, tc.retrieveErr).Once()
+			repo.On("ClaimDueDigests", mock.Anything, mock.Anything, mock.Anything, uint64(100), uint64(100)).Return([]notifiers.Digest{}, nil)
+			var payload string
+			if tc.notify {
+				notifier.On("Notify", mock.Anything, "exampleFrom", []string{tc.digest.Subscription.Contact}, mock.Anything).
+					Run(func(args mock.Arguments) {
+						payload = string(args.Get(3).(*messaging.Message).GetPayload())
+					}).
+					Return(tc.notifyErr).Once()
+			}
+			if tc.remove {
+				repo.On("RemoveDigest", mock.Anything, tc.digest.Subscription.ID, tc.digest.LastID).Return(nil).Once()
+			}
+			tickChan := make(chan time.Time)
+			tck.On("Tick").Return((<-chan time.Time)(tickChan))
+			tck.On("Stop").Return()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- notifiers.StartDigests(ctx, repo, notifier, "exampleFrom", tck, mglog.NewMock())
+			}()
+
+			// The second tick is received only after the first one is handled.
+			tickChan <- time.Now()
+			tickChan <- time.Now()
+			cancel()
+			err := <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+			assert.Equal(t, tc.payload, payload, fmt.Sprintf("%s: expected %q got %q\n", tc.desc, tc.payload, payload))
+
+			repo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+			tck.AssertCalled(t, "Stop")
+		})
+	}
+}
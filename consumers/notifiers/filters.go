@@ -0,0 +1,191 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+// Filter comparators.
+const (
+	EqualKey            = "eq"
+	NotEqualKey         = "ne"
+	LowerThanKey        = "lt"
+	LowerThanEqualKey   = "le"
+	GreaterThanKey      = "gt"
+	GreaterThanEqualKey = "ge"
+)
+
+// ErrInvalidFilter indicates an invalid subscription filter.
+var ErrInvalidFilter = errors.New("invalid subscription filter")
+
+// Filter compares the values of the message records of the given name.
+// Exactly one of the values is set. Strings and booleans only support the
+// eq and ne comparators.
+type Filter struct {
+	Name        string   `json:"name"`
+	Comparator  string   `json:"comparator"`
+	Value       *float64 `json:"value,omitempty"`
+	StringValue *string  `json:"string_value,omitempty"`
+	BoolValue   *bool    `json:"bool_value,omitempty"`
+}
+
+// Validate returns an error if the filter can not be applied.
+func (f Filter) Validate() error {
+	if f.Name == "" {
+		return errors.Wrap(ErrInvalidFilter, errors.New("missing record name"))
+	}
+	values := 0
+	for _, set := range []bool{f.Value != nil, f.StringValue != nil, f.BoolValue != nil} {
+		if set {
+			values++
+		}
+	}
+	if values != 1 {
+		return errors.Wrap(ErrInvalidFilter, fmt.Errorf("filter of %s needs exactly one value", f.Name))
+	}
+	switch f.Comparator {
+	case EqualKey, NotEqualKey:
+		return nil
+	case LowerThanKey, LowerThanEqualKey, GreaterThanKey, GreaterThanEqualKey:
+		if f.Value == nil {
+			return errors.Wrap(ErrInvalidFilter, fmt.Errorf("comparator %s needs a numeric value", f.Comparator))
+		}
+		return nil
+	default:
+		return errors.Wrap(ErrInvalidFilter, fmt.Errorf("unknown comparator %q", f.Comparator))
+	}
+}
+
+// record is a named value of a message payload.
+type record struct {
+	name  string
+	value any
+}
+
+// records returns the records of the SenML JSON payload, or the top level
+// fields of the JSON object payload. It returns false for other payloads.
+func records(msg *messaging.Message) ([]record, bool) {
+	if msgs, err := senml.New(senml.JSON).Transform(msg); err == nil {
+		var recs []record
+		for _, m := range msgs.([]senml.Message) {
+			switch {
+			case m.Value != nil:
+				recs = append(recs, record{name: m.Name, value: *m.Value})
+			case m.StringValue != nil:
+				recs = append(recs, record{name: m.Name, value: *m.StringValue})
+			case m.BoolValue != nil:
+				recs = append(recs, record{name: m.Name, value: *m.BoolValue})
+			}
+		}
+		return recs, true
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(msg.GetPayload(), &obj); err != nil {
+		return nil, false
+	}
+	recs := make([]record, 0, len(obj))
+	for name, v := range obj {
+		recs = append(recs, record{name: name, value: v})
+	}
+
+	return recs, true
+}
+
+// match reports whether a record of the filter name matches the filter.
+func (f Filter) match(recs []record) bool {
+	for _, r := range recs {
+		if r.name == f.Name && f.compare(r.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f Filter) compare(v any) bool {
+	var cmp int
+	switch v := v.(type) {
+	case float64:
+		if f.Value == nil {
+			return false
+		}
+		switch {
+		case v < *f.Value:
+			cmp = -1
+		case v > *f.Value:
+			cmp = 1
+		}
+	case string:
+		if f.StringValue == nil {
+			return false
+		}
+		if v != *f.StringValue {
+			cmp = 1
+		}
+	case bool:
+		if f.BoolValue == nil {
+			return false
+		}
+		if v != *f.BoolValue {
+			cmp = 1
+		}
+	default:
+		return false
+	}
+
+	switch f.Comparator {
+	case EqualKey:
+		return cmp == 0
+	case NotEqualKey:
+		return cmp != 0
+	case LowerThanKey:
+		return cmp < 0
+	case LowerThanEqualKey:
+		return cmp <= 0
+	case GreaterThanKey:
+		return cmp > 0
+	case GreaterThanEqualKey:
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+// matcher matches filters against the records of a message, which are
+// decoded once for all the filters.
+type matcher struct {
+	msg     *messaging.Message
+	recs    []record
+	decoded bool
+	ok      bool
+}
+
+// match reports whether the message matches all the filters. Messages
+// without records match no filter.
+func (m *matcher) match(filters []Filter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	if !m.decoded {
+		m.recs, m.ok = records(m.msg)
+		m.decoded = true
+	}
+	if !m.ok {
+		return false
+	}
+	for _, f := range filters {
+		if !f.match(m.recs) {
+			return false
+		}
+	}
+
+	return true
+}
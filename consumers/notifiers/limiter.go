@@ -0,0 +1,55 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"sync"
+	"time"
+)
+
+// window counts the notifications of a subscription since its start.
+type window struct {
+	start time.Time
+	count uint
+}
+
+// limiter limits the notifications of each subscription to its rate limit
+// in fixed windows of its rate interval. The windows are kept in memory, so
+// each service instance applies the limits on its own, and the instances
+// consuming the same messages together send up to the rate limit times
+// their number.
+type limiter struct {
+	mu      sync.Mutex
+	windows map[string]window
+}
+
+func newLimiter() *limiter {
+	return &limiter{windows: map[string]window{}}
+}
+
+// allow reports whether the subscription may send a notification at the
+// given time, and counts it if so.
+func (l *limiter) allow(sub Subscription, now time.Time) bool {
+	if sub.RateLimit == 0 {
+		return true
+	}
+	interval := sub.RateInterval
+	if interval == 0 {
+		interval = DefRateInterval
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w, ok := l.windows[sub.ID]
+	if !ok || now.Sub(w.start) >= interval {
+		w = window{start: now}
+	}
+	if w.count >= sub.RateLimit {
+		return false
+	}
+	w.count++
+	l.windows[sub.ID] = w
+
+	return true
+}
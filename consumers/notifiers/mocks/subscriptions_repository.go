@@ -10,8 +10,10 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/pkg/messaging"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -42,6 +44,155 @@ func (_m *SubscriptionsRepository) EXPECT() *SubscriptionsRepository_Expecter {
 	return &SubscriptionsRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddDigestMessage provides a mock function for the type SubscriptionsRepository
+func (_mock *SubscriptionsRepository) AddDigestMessage(ctx context.Context, subID string, msg *messaging.Message) error {
+	ret := _mock.Called(ctx, subID, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDigestMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *messaging.Message) error); ok {
+		r0 = returnFunc(ctx, subID, msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// SubscriptionsRepository_AddDigestMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDigestMessage'
+type SubscriptionsRepository_AddDigestMessage_Call struct {
+	*mock.Call
+}
+
+// AddDigestMessage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subID string
+//   - msg *messaging.Message
+func (_e *SubscriptionsRepository_Expecter) AddDigestMessage(ctx interface{}, subID interface{}, msg interface{}) *SubscriptionsRepository_AddDigestMessage_Call {
+	return &SubscriptionsRepository_AddDigestMessage_Call{Call: _e.mock.On("AddDigestMessage", ctx, subID, msg)}
+}
+
+func (_c *SubscriptionsRepository_AddDigestMessage_Call) Run(run func(ctx context.Context, subID string, msg *messaging.Message)) *SubscriptionsRepository_AddDigestMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *messaging.Message
+		if args[2] != nil {
+			arg2 = args[2].(*messaging.Message)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *SubscriptionsRepository_AddDigestMessage_Call) Return(_a0 error) *SubscriptionsRepository_AddDigestMessage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionsRepository_AddDigestMessage_Call) RunAndReturn(run func(ctx context.Context, subID string, msg *messaging.Message) error) *SubscriptionsRepository_AddDigestMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDueDigests provides a mock function for the type SubscriptionsRepository
+func (_mock *SubscriptionsRepository) ClaimDueDigests(ctx context.Context, now time.Time, until time.Time, limit uint64, maxMessages uint64) ([]notifiers.Digest, error) {
+	ret := _mock.Called(ctx, now, until, limit, maxMessages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDigests")
+	}
+
+	var r0 []notifiers.Digest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64, uint64) ([]notifiers.Digest, error)); ok {
+		return returnFunc(ctx, now, until, limit, maxMessages)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64, uint64) []notifiers.Digest); ok {
+		r0 = returnFunc(ctx, now, until, limit, maxMessages)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notifiers.Digest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, now, until, limit, maxMessages)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// SubscriptionsRepository_ClaimDueDigests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueDigests'
+type SubscriptionsRepository_ClaimDueDigests_Call struct {
+	*mock.Call
+}
+
+// ClaimDueDigests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - until time.Time
+//   - limit uint64
+//   - maxMessages uint64
+func (_e *SubscriptionsRepository_Expecter) ClaimDueDigests(ctx interface{}, now interface{}, until interface{}, limit interface{}, maxMessages interface{}) *SubscriptionsRepository_ClaimDueDigests_Call {
+	return &SubscriptionsRepository_ClaimDueDigests_Call{Call: _e.mock.On("ClaimDueDigests", ctx, now, until, limit, maxMessages)}
+}
+
+func (_c *SubscriptionsRepository_ClaimDueDigests_Call) Run(run func(ctx context.Context, now time.Time, until time.Time, limit uint64, maxMessages uint64)) *SubscriptionsRepository_ClaimDueDigests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SubscriptionsRepository_ClaimDueDigests_Call) Return(digests []notifiers.Digest, err error) *SubscriptionsRepository_ClaimDueDigests_Call {
+	_c.Call.Return(digests, err)
+	return _c
+}
+
+func (_c *SubscriptionsRepository_ClaimDueDigests_Call) RunAndReturn(run func(ctx context.Context, now time.Time, until time.Time, limit uint64, maxMessages uint64) ([]notifiers.Digest, error)) *SubscriptionsRepository_ClaimDueDigests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Remove provides a mock function for the type SubscriptionsRepository
 func (_mock *SubscriptionsRepository) Remove(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -99,6 +250,69 @@ func (_c *SubscriptionsRepository_Remove_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// RemoveDigest provides a mock function for the type SubscriptionsRepository
+func (_mock *SubscriptionsRepository) RemoveDigest(ctx context.Context, subID string, lastID uint64) error {
+	ret := _mock.Called(ctx, subID, lastID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDigest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = returnFunc(ctx, subID, lastID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// SubscriptionsRepository_RemoveDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDigest'
+type SubscriptionsRepository_RemoveDigest_Call struct {
+	*mock.Call
+}
+
+// RemoveDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subID string
+//   - lastID uint64
+func (_e *SubscriptionsRepository_Expecter) RemoveDigest(ctx interface{}, subID interface{}, lastID interface{}) *SubscriptionsRepository_RemoveDigest_Call {
+	return &SubscriptionsRepository_RemoveDigest_Call{Call: _e.mock.On("RemoveDigest", ctx, subID, lastID)}
+}
+
+func (_c *SubscriptionsRepository_RemoveDigest_Call) Run(run func(ctx context.Context, subID string, lastID uint64)) *SubscriptionsRepository_RemoveDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *SubscriptionsRepository_RemoveDigest_Call) Return(_a0 error) *SubscriptionsRepository_RemoveDigest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionsRepository_RemoveDigest_Call) RunAndReturn(run func(ctx context.Context, subID string, lastID uint64) error) *SubscriptionsRepository_RemoveDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Retrieve provides a mock function for the type SubscriptionsRepository
 func (_mock *SubscriptionsRepository) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
 	ret := _mock.Called(ctx, id)
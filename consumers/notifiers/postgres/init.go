@@ -37,6 +37,56 @@ func Migration() *migrate.MemoryMigrationSource {
 						WHERE topic LIKE '%/%'`,
 				},
 			},
+			{
+				Id: "subscriptions_3",
+				Up: []string{
+					`ALTER TABLE subscriptions
+						ADD COLUMN IF NOT EXISTS template TEXT NOT NULL DEFAULT '',
+						ADD COLUMN IF NOT EXISTS secret   TEXT NOT NULL DEFAULT '',
+						ADD COLUMN IF NOT EXISTS headers  JSONB NOT NULL DEFAULT '{}'`,
+				},
+				Down: []string{
+					`ALTER TABLE subscriptions
+						DROP COLUMN IF EXISTS template,
+						DROP COLUMN IF EXISTS secret,
+						DROP COLUMN IF EXISTS headers`,
+				},
+			},
+			{
+				Id: "subscriptions_4",
+				Up: []string{
+					`ALTER TABLE subscriptions
+						ADD COLUMN IF NOT EXISTS filters              JSONB NOT NULL DEFAULT '[]',
+						ADD COLUMN IF NOT EXISTS rate_limit           BIGINT NOT NULL DEFAULT 0,
+						ADD COLUMN IF NOT EXISTS rate_interval        BIGINT NOT NULL DEFAULT 0,
+						ADD COLUMN IF NOT EXISTS quiet_hours          JSONB NULL,
+						ADD COLUMN IF NOT EXISTS digest_interval      BIGINT NOT NULL DEFAULT 0,
+						ADD COLUMN IF NOT EXISTS digest_claimed_until BIGINT NOT NULL DEFAULT 0`,
+					`CREATE TABLE IF NOT EXISTS digest_messages (
+                        id              BIGSERIAL PRIMARY KEY,
+                        subscription_id VARCHAR(254) NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+                        channel         VARCHAR(254),
+                        domain          VARCHAR(254),
+                        subtopic        TEXT,
+                        publisher       VARCHAR(254),
+                        protocol        VARCHAR(254),
+                        payload         BYTEA,
+                        created         BIGINT,
+                        queued_at       BIGINT NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS idx_digest_messages_subscription ON digest_messages (subscription_id, id)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS digest_messages",
+					`ALTER TABLE subscriptions
+						DROP COLUMN IF EXISTS filters,
+						DROP COLUMN IF EXISTS rate_limit,
+						DROP COLUMN IF EXISTS rate_interval,
+						DROP COLUMN IF EXISTS quiet_hours,
+						DROP COLUMN IF EXISTS digest_interval,
+						DROP COLUMN IF EXISTS digest_claimed_until`,
+				},
+			},
 		},
 	}
 }
@@ -6,16 +6,21 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const subscriptionColumns = "id, owner_id, contact, topic, template, secret, headers, filters, rate_limit, rate_interval, quiet_hours, digest_interval"
+
 var _ notifiers.SubscriptionsRepository = (*subscriptionsRepo)(nil)
 
 type subscriptionsRepo struct {
@@ -30,13 +35,12 @@ func New(db Database) notifiers.SubscriptionsRepository {
 }
 
 func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscription) (string, error) {
-	q := `INSERT INTO subscriptions (id, owner_id, contact, topic) VALUES (:id, :owner_id, :contact, :topic) RETURNING id`
+	q := `INSERT INTO subscriptions (id, owner_id, contact, topic, template, secret, headers, filters, rate_limit, rate_interval, quiet_hours, digest_interval)
+		VALUES (:id, :owner_id, :contact, :topic, :template, :secret, :headers, :filters, :rate_limit, :rate_interval, :quiet_hours, :digest_interval) RETURNING id`
 
-	dbSub := dbSubscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+	dbSub, err := toDBSub(sub)
+	if err != nil {
+		return "", errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
 	row, err := repo.db.NamedQueryContext(ctx, q, dbSub)
@@ -52,7 +56,7 @@ func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscripti
 }
 
 func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions WHERE id = $1`
+	q := fmt.Sprintf(`SELECT %s FROM subscriptions WHERE id = $1`, subscriptionColumns)
 	sub := dbSubscription{}
 	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&sub); err != nil {
 		if err == sql.ErrNoRows {
@@ -60,12 +64,16 @@ func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifier
 		}
 		return notifiers.Subscription{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
+	ret, err := fromDBSub(sub)
+	if err != nil {
+		return notifiers.Subscription{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
 
-	return fromDBSub(sub), nil
+	return ret, nil
 }
 
 func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.PageMetadata) (notifiers.Page, error) {
-	q := `SELECT id, owner_id, contact, topic FROM subscriptions`
+	q := fmt.Sprintf(`SELECT %s FROM subscriptions`, subscriptionColumns)
 	args := make(map[string]any)
 	if pm.Topic != "" {
 		args["topic"] = pm.Topic
@@ -101,7 +109,11 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 		if err := rows.StructScan(&sub); err != nil {
 			return notifiers.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
 		}
-		subs = append(subs, fromDBSub(sub))
+		s, err := fromDBSub(sub)
+		if err != nil {
+			return notifiers.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		subs = append(subs, s)
 	}
 
 	if len(subs) == 0 {
@@ -147,18 +159,224 @@ func total(ctx context.Context, db Database, query string, params any) (uint, er
 	return total, nil
 }
 
+func (repo subscriptionsRepo) AddDigestMessage(ctx context.Context, subID string, msg *messaging.Message) error {
+	q := `INSERT INTO digest_messages (subscription_id, channel, domain, subtopic, publisher, protocol, payload, created, queued_at)
+		VALUES (:subscription_id, :channel, :domain, :subtopic, :publisher, :protocol, :payload, :created, :queued_at)`
+
+	dm := dbDigestMessage{
+		SubscriptionID: subID,
+		Channel:        msg.GetChannel(),
+		Domain:         msg.GetDomain(),
+		Subtopic:       msg.GetSubtopic(),
+		Publisher:      msg.GetPublisher(),
+		Protocol:       msg.GetProtocol(),
+		Payload:        msg.GetPayload(),
+		Created:        msg.GetCreated(),
+		QueuedAt:       time.Now().UnixNano(),
+	}
+	if _, err := repo.db.NamedExecContext(ctx, q, dm); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo subscriptionsRepo) ClaimDueDigests(ctx context.Context, now, until time.Time, limit, maxMessages uint64) ([]notifiers.Digest, error) {
+	// Skip the subscriptions locked by concurrent claims, so each digest is claimed once.
+	q := fmt.Sprintf(`UPDATE subscriptions SET digest_claimed_until = :until
+		WHERE id IN (
+			SELECT s.id FROM subscriptions s
+			JOIN LATERAL (SELECT MIN(queued_at) AS oldest FROM digest_messages WHERE subscription_id = s.id) d ON TRUE
+			WHERE s.digest_claimed_until <= :now AND d.oldest + s.digest_interval <= :now
+			ORDER BY d.oldest
+			LIMIT :limit
+			FOR UPDATE OF s SKIP LOCKED
+		)
+		RETURNING %s`, subscriptionColumns)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, map[string]any{"now": now.UnixNano(), "until": until.UnixNano(), "limit": limit})
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var digests []notifiers.Digest
+	for rows.Next() {
+		dbSub := dbSubscription{}
+		if err := rows.StructScan(&dbSub); err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		sub, err := fromDBSub(dbSub)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		digests = append(digests, notifiers.Digest{Subscription: sub})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	for i := range digests {
+		if err := repo.retrieveDigestMessages(ctx, &digests[i], maxMessages); err != nil {
+			return nil, err
+		}
+	}
+
+	return digests, nil
+}
+
+// retrieveDigestMessages retrieves the oldest maxMessages messages of the
+// digest, and counts all of its messages up to the last one.
+func (repo subscriptionsRepo) retrieveDigestMessages(ctx context.Context, dg *notifiers.Digest, maxMessages uint64) error {
+	q := `SELECT COUNT(*), COALESCE(MAX(id), 0) FROM digest_messages WHERE subscription_id = $1`
+	if err := repo.db.QueryRowxContext(ctx, q, dg.Subscription.ID).Scan(&dg.Total, &dg.LastID); err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	q = `SELECT id, subscription_id, channel, domain, subtopic, publisher, protocol, payload, created, queued_at
+		FROM digest_messages WHERE subscription_id = :subscription_id AND id <= :last_id ORDER BY id LIMIT :limit`
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, map[string]any{"subscription_id": dg.Subscription.ID, "last_id": dg.LastID, "limit": maxMessages})
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		dm := dbDigestMessage{}
+		if err := rows.StructScan(&dm); err != nil {
+			return errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		dg.Messages = append(dg.Messages, &messaging.Message{
+			Channel:   dm.Channel,
+			Domain:    dm.Domain,
+			Subtopic:  dm.Subtopic,
+			Publisher: dm.Publisher,
+			Protocol:  dm.Protocol,
+			Payload:   dm.Payload,
+			Created:   dm.Created,
+		})
+	}
+
+	return rows.Err()
+}
+
+func (repo subscriptionsRepo) RemoveDigest(ctx context.Context, subID string, lastID uint64) error {
+	q := `WITH removed AS (
+			DELETE FROM digest_messages WHERE subscription_id = :subscription_id AND id <= :id
+		)
+		UPDATE subscriptions SET digest_claimed_until = 0 WHERE id = :subscription_id`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, map[string]any{"subscription_id": subID, "id": lastID}); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 type dbSubscription struct {
-	ID      string `db:"id"`
-	OwnerID string `db:"owner_id"`
-	Contact string `db:"contact"`
-	Topic   string `db:"topic"`
+	ID             string `db:"id"`
+	OwnerID        string `db:"owner_id"`
+	Contact        string `db:"contact"`
+	Topic          string `db:"topic"`
+	Template       string `db:"template"`
+	Secret         string `db:"secret"`
+	Headers        []byte `db:"headers"`
+	Filters        []byte `db:"filters"`
+	RateLimit      uint64 `db:"rate_limit"`
+	RateInterval   int64  `db:"rate_interval"`
+	QuietHours     []byte `db:"quiet_hours"`
+	DigestInterval int64  `db:"digest_interval"`
+}
+
+func toDBSub(sub notifiers.Subscription) (dbSubscription, error) {
+	headers := []byte("{}")
+	if len(sub.Headers) > 0 {
+		var err error
+		if headers, err = json.Marshal(sub.Headers); err != nil {
+			return dbSubscription{}, err
+		}
+	}
+	filters := []byte("[]")
+	if len(sub.Filters) > 0 {
+		var err error
+		if filters, err = json.Marshal(sub.Filters); err != nil {
+			return dbSubscription{}, err
+		}
+	}
+	var quietHours []byte
+	if sub.QuietHours != nil {
+		var err error
+		if quietHours, err = json.Marshal(sub.QuietHours); err != nil {
+			return dbSubscription{}, err
+		}
+	}
+
+	return dbSubscription{
+		ID:             sub.ID,
+		OwnerID:        sub.OwnerID,
+		Contact:        sub.Contact,
+		Topic:          sub.Topic,
+		Template:       sub.Template,
+		Secret:         sub.Secret,
+		Headers:        headers,
+		Filters:        filters,
+		RateLimit:      uint64(sub.RateLimit),
+		RateInterval:   int64(sub.RateInterval),
+		QuietHours:     quietHours,
+		DigestInterval: int64(sub.DigestInterval),
+	}, nil
 }
 
-func fromDBSub(sub dbSubscription) notifiers.Subscription {
-	return notifiers.Subscription{
-		ID:      sub.ID,
-		OwnerID: sub.OwnerID,
-		Contact: sub.Contact,
-		Topic:   sub.Topic,
+func fromDBSub(sub dbSubscription) (notifiers.Subscription, error) {
+	ret := notifiers.Subscription{
+		ID:             sub.ID,
+		OwnerID:        sub.OwnerID,
+		Contact:        sub.Contact,
+		Topic:          sub.Topic,
+		Template:       sub.Template,
+		Secret:         sub.Secret,
+		RateLimit:      uint(sub.RateLimit),
+		RateInterval:   time.Duration(sub.RateInterval),
+		DigestInterval: time.Duration(sub.DigestInterval),
+	}
+	if len(sub.Headers) > 0 {
+		var headers map[string]string
+		if err := json.Unmarshal(sub.Headers, &headers); err != nil {
+			return notifiers.Subscription{}, err
+		}
+		if len(headers) > 0 {
+			ret.Headers = headers
+		}
 	}
+	if len(sub.Filters) > 0 {
+		var filters []notifiers.Filter
+		if err := json.Unmarshal(sub.Filters, &filters); err != nil {
+			return notifiers.Subscription{}, err
+		}
+		if len(filters) > 0 {
+			ret.Filters = filters
+		}
+	}
+	if len(sub.QuietHours) > 0 {
+		ret.QuietHours = &notifiers.QuietHours{}
+		if err := json.Unmarshal(sub.QuietHours, ret.QuietHours); err != nil {
+			return notifiers.Subscription{}, err
+		}
+	}
+
+	return ret, nil
+}
+
+type dbDigestMessage struct {
+	ID             uint64 `db:"id"`
+	SubscriptionID string `db:"subscription_id"`
+	Channel        string `db:"channel"`
+	Domain         string `db:"domain"`
+	Subtopic       string `db:"subtopic"`
+	Publisher      string `db:"publisher"`
+	Protocol       string `db:"protocol"`
+	Payload        []byte `db:"payload"`
+	Created        int64  `db:"created"`
+	QueuedAt       int64  `db:"queued_at"`
 }
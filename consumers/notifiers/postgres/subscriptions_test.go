@@ -7,11 +7,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/consumers/notifiers"
 	"github.com/absmach/magistrala/consumers/notifiers/postgres"
 	"github.com/absmach/magistrala/pkg/errors"
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
@@ -78,11 +80,20 @@ func TestView(t *testing.T) {
 	id, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
 
+	threshold := 30.0
 	sub := notifiers.Subscription{
-		OwnerID: id,
-		ID:      id,
-		Contact: owner,
-		Topic:   "view.subtopic",
+		OwnerID:        id,
+		ID:             id,
+		Contact:        owner,
+		Topic:          "view.subtopic",
+		Template:       "{{.Publisher}} sent {{.Payload}}",
+		Secret:         "secret",
+		Headers:        map[string]string{"Authorization": "Bearer token"},
+		Filters:        []notifiers.Filter{{Name: "temperature", Comparator: notifiers.GreaterThanKey, Value: &threshold}},
+		RateLimit:      5,
+		RateInterval:   30 * time.Minute,
+		QuietHours:     &notifiers.QuietHours{Start: "22:00", End: "07:00", Timezone: "Europe/Belgrade"},
+		DigestInterval: time.Hour,
 	}
 
 	ret, err := repo.Save(context.Background(), sub)
@@ -261,3 +272,66 @@ func TestRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestDigests(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db, tracer)
+	repo := postgres.New(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+	sub := notifiers.Subscription{
+		OwnerID:        id,
+		ID:             id,
+		Contact:        owner,
+		Topic:          "digest.subtopic",
+		DigestInterval: time.Hour,
+	}
+	_, err = repo.Save(context.Background(), sub)
+	require.Nil(t, err, fmt.Sprintf("creating subscription must not fail: %s", err))
+
+	msgs := []*messaging.Message{
+		{Channel: "digest", Subtopic: "subtopic", Publisher: "publisher", Payload: []byte(`{"temperature":21.5}`), Created: time.Now().UnixNano()},
+		{Channel: "digest", Subtopic: "subtopic", Publisher: "publisher", Payload: []byte(`{"temperature":23}`), Created: time.Now().UnixNano()},
+	}
+	for _, msg := range msgs {
+		err := repo.AddDigestMessage(context.Background(), id, msg)
+		require.Nil(t, err, fmt.Sprintf("adding digest message must not fail: %s", err))
+	}
+
+	now := time.Now()
+	digests, err := repo.ClaimDueDigests(context.Background(), now, now.Add(time.Minute), 10, 10)
+	assert.Nil(t, err, fmt.Sprintf("claim digests before interval: unexpected error: %s", err))
+	assert.Empty(t, digests, "claim digests before interval: expected no digests")
+
+	due := now.Add(2 * time.Hour)
+	digests, err = repo.ClaimDueDigests(context.Background(), due, due.Add(time.Minute), 10, 1)
+	assert.Nil(t, err, fmt.Sprintf("claim due digests: unexpected error: %s", err))
+	require.Len(t, digests, 1, "claim due digests: expected one digest")
+	assert.Equal(t, sub, digests[0].Subscription, fmt.Sprintf("claim due digests: expected sub %v got %v\n", sub, digests[0].Subscription))
+	assert.Equal(t, uint64(len(msgs)), digests[0].Total, fmt.Sprintf("claim due digests: expected total %d got %d\n", len(msgs), digests[0].Total))
+	require.Len(t, digests[0].Messages, 1, "claim due digests: expected the capped digest messages")
+	assert.Equal(t, msgs[0].GetPayload(), digests[0].Messages[0].GetPayload(), fmt.Sprintf("claim due digests: expected payload %s got %s\n", msgs[0].GetPayload(), digests[0].Messages[0].GetPayload()))
+	assert.Equal(t, msgs[0].GetCreated(), digests[0].Messages[0].GetCreated(), fmt.Sprintf("claim due digests: expected created %d got %d\n", msgs[0].GetCreated(), digests[0].Messages[0].GetCreated()))
+
+	claimed, err := repo.ClaimDueDigests(context.Background(), due, due.Add(time.Minute), 10, 10)
+	assert.Nil(t, err, fmt.Sprintf("claim claimed digests: unexpected error: %s", err))
+	assert.Empty(t, claimed, "claim claimed digests: expected no digests")
+
+	expired := due.Add(2 * time.Minute)
+	claimed, err = repo.ClaimDueDigests(context.Background(), expired, expired.Add(time.Minute), 10, 10)
+	assert.Nil(t, err, fmt.Sprintf("claim expired digests: unexpected error: %s", err))
+	require.Len(t, claimed, 1, "claim expired digests: expected one digest")
+	require.Len(t, claimed[0].Messages, len(msgs), "claim expired digests: expected all digest messages")
+
+	err = repo.RemoveDigest(context.Background(), id, digests[0].LastID)
+	assert.Nil(t, err, fmt.Sprintf("remove digest: unexpected error: %s", err))
+	digests, err = repo.ClaimDueDigests(context.Background(), expired, expired.Add(time.Minute), 10, 10)
+	assert.Nil(t, err, fmt.Sprintf("claim removed digests: unexpected error: %s", err))
+	assert.Empty(t, digests, "claim removed digests: expected no digests")
+
+	err = repo.AddDigestMessage(context.Background(), id, msgs[0])
+	require.Nil(t, err, fmt.Sprintf("adding digest message must not fail: %s", err))
+	digests, err = repo.ClaimDueDigests(context.Background(), expired, expired.Add(time.Minute), 10, 10)
+	assert.Nil(t, err, fmt.Sprintf("claim released digests: unexpected error: %s", err))
+	assert.Len(t, digests, 1, "claim released digests: expected one digest")
+}
@@ -5,6 +5,7 @@ package notifiers
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala"
 	"github.com/absmach/magistrala/consumers"
@@ -49,6 +50,7 @@ type notifierService struct {
 	subs     SubscriptionsRepository
 	idp      magistrala.IDProvider
 	notifier consumers.Notifier
+	limiter  *limiter
 	errCh    chan error
 	from     string
 }
@@ -60,6 +62,7 @@ func New(authn smqauthn.Authentication, subs SubscriptionsRepository, idp magist
 		subs:     subs,
 		idp:      idp,
 		notifier: notifier,
+		limiter:  newLimiter(),
 		errCh:    make(chan error, 1),
 		from:     from,
 	}
@@ -70,6 +73,14 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 	if err != nil {
 		return "", err
 	}
+	if err := sub.Validate(); err != nil {
+		return "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	if cv, ok := ns.notifier.(consumers.ContactValidator); ok {
+		if err := cv.ValidateContact(ctx, sub.Contact); err != nil {
+			return "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
 	sub.ID, err = ns.idp.ID()
 	if err != nil {
 		return "", err
@@ -112,19 +123,8 @@ func (ns *notifierService) ConsumeBlocking(ctx context.Context, message any) err
 	if !ok {
 		return ErrMessage
 	}
-	to, err := ns.recipients(ctx, msg)
-	if err != nil {
-		return err
-	}
 
-	if len(to) > 0 {
-		err := ns.notifier.Notify(ns.from, to, msg)
-		if err != nil {
-			return errors.Wrap(consumers.ErrNotify, err)
-		}
-	}
-
-	return nil
+	return ns.notify(ctx, msg)
 }
 
 func (ns *notifierService) ConsumeAsync(ctx context.Context, message any) {
@@ -133,24 +133,85 @@ func (ns *notifierService) ConsumeAsync(ctx context.Context, message any) {
 		ns.errCh <- ErrMessage
 		return
 	}
-	to, err := ns.recipients(ctx, msg)
-	if err != nil {
+	if err := ns.notify(ctx, msg); err != nil {
 		ns.errCh <- err
-		return
+	}
+}
+
+func (ns *notifierService) Errors() <-chan error {
+	return ns.errCh
+}
+
+// notify notifies the contacts subscribed to the message topic whose
+// filters match the message. The messages of digest subscriptions are held
+// back for their digest. The others are notified unless the subscription is
+// in its quiet hours or over its rate limit. The contacts of the
+// subscriptions with the same template are notified together, and a failed
+// notification does not stop the others. The first error is returned.
+func (ns *notifierService) notify(ctx context.Context, msg *messaging.Message) error {
+	subs, err := ns.subscriptions(ctx, msg)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var templates []string
+	recipients := map[string][]consumers.Recipient{}
+	m := matcher{msg: msg}
+	now := time.Now()
+	for _, sub := range subs {
+		if !m.match(sub.Filters) {
+			continue
+		}
+		if sub.DigestInterval > 0 {
+			if err := ns.subs.AddDigestMessage(ctx, sub.ID, msg); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if sub.quiet(now) || !ns.limiter.allow(sub, now) {
+			continue
+		}
+		if _, ok := recipients[sub.Template]; !ok {
+			templates = append(templates, sub.Template)
+		}
+		recipients[sub.Template] = append(recipients[sub.Template], sub.recipient())
 	}
 
-	if len(to) > 0 {
-		if err := ns.notifier.Notify(ns.from, to, msg); err != nil {
-			ns.errCh <- errors.Wrap(consumers.ErrNotify, err)
+	for _, tmpl := range templates {
+		m := msg
+		if tmpl != "" {
+			if m, err = render(tmpl, msg); err != nil {
+				errs = append(errs, errors.Wrap(consumers.ErrNotify, err))
+				continue
+			}
 		}
+		if err := send(ctx, ns.notifier, ns.from, recipients[tmpl], m); err != nil {
+			errs = append(errs, errors.Wrap(consumers.ErrNotify, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errs[0]
 	}
+
+	return nil
 }
 
-func (ns *notifierService) Errors() <-chan error {
-	return ns.errCh
+// send notifies the recipients with their settings if the notifier supports
+// them, or notifies their contacts otherwise.
+func send(ctx context.Context, n consumers.Notifier, from string, to []consumers.Recipient, msg *messaging.Message) error {
+	if rn, ok := n.(consumers.RecipientNotifier); ok {
+		return rn.NotifyRecipients(ctx, from, to, msg)
+	}
+	contacts := make([]string, len(to))
+	for i, r := range to {
+		contacts[i] = r.Contact
+	}
+
+	return n.Notify(ctx, from, contacts, msg)
 }
 
-func (ns *notifierService) recipients(ctx context.Context, msg *messaging.Message) ([]string, error) {
+func (ns *notifierService) subscriptions(ctx context.Context, msg *messaging.Message) ([]Subscription, error) {
 	topic, ok := subscriptionTopic(msg)
 	if !ok {
 		return nil, nil
@@ -169,12 +230,7 @@ func (ns *notifierService) recipients(ctx context.Context, msg *messaging.Messag
 		return nil, err
 	}
 
-	to := make([]string, 0, len(page.Subscriptions))
-	for _, sub := range page.Subscriptions {
-		to = append(to, sub.Contact)
-	}
-
-	return to, nil
+	return page.Subscriptions, nil
 }
 
 func subscriptionTopic(msg *messaging.Message) (string, bool) {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/absmach/magistrala/consumers"
 	smqmocks "github.com/absmach/magistrala/consumers/mocks"
@@ -16,6 +17,7 @@ import (
 	smqauthn "github.com/absmach/magistrala/pkg/authn"
 	authnmocks "github.com/absmach/magistrala/pkg/authn/mocks"
 	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/uuid"
@@ -74,6 +76,69 @@ func TestCreateSubscription(t *testing.T) {
 			authenticateErr: nil,
 			userID:          validID,
 		},
+		{
+			desc:            "test with template",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", Template: "{{.Publisher}} sent {{.Payload}}"},
+			id:              uuid.Prefix + fmt.Sprintf("%012d", 2),
+			err:             nil,
+			authenticateErr: nil,
+			userID:          validID,
+		},
+		{
+			desc:            "test with invalid template",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", Template: "{{.Payload"},
+			id:              "",
+			err:             svcerr.ErrMalformedEntity,
+			authenticateErr: nil,
+			userID:          validID,
+		},
+		{
+			desc:            "test with invalid filter",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", Filters: []notifiers.Filter{{Name: "temperature", Comparator: "gt"}}},
+			id:              "",
+			err:             svcerr.ErrMalformedEntity,
+			authenticateErr: nil,
+			userID:          validID,
+		},
+		{
+			desc:            "test with rate interval without rate limit",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", RateInterval: time.Minute},
+			id:              "",
+			err:             svcerr.ErrMalformedEntity,
+			authenticateErr: nil,
+			userID:          validID,
+		},
+		{
+			desc:            "test with invalid quiet hours",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", QuietHours: &notifiers.QuietHours{Start: "22:00", End: "25:00"}},
+			id:              "",
+			err:             svcerr.ErrMalformedEntity,
+			authenticateErr: nil,
+			userID:          validID,
+		},
+		{
+			desc:            "test with negative digest interval",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", DigestInterval: -time.Minute},
+			id:              "",
+			err:             svcerr.ErrMalformedEntity,
+			authenticateErr: nil,
+			userID:          validID,
+		},
+		{
+			desc:            "test with empty header name",
+			token:           exampleUser1,
+			sub:             notifiers.Subscription{Contact: exampleUser2, Topic: "valid.topic", Headers: map[string]string{" ": "value"}},
+			id:              "",
+			err:             svcerr.ErrMalformedEntity,
+			authenticateErr: nil,
+			userID:          validID,
+		},
 		{
 			desc:            "test with empty token",
 			token:           "",
@@ -95,6 +160,53 @@ func TestCreateSubscription(t *testing.T) {
 	}
 }
 
+// contactNotifier accepts the contacts of the example domain.
+type contactNotifier struct {
+	*smqmocks.Notifier
+}
+
+func (contactNotifier) ValidateContact(_ context.Context, contact string) error {
+	if contact != "hooks.example.com" {
+		return errors.New("invalid contact")
+	}
+
+	return nil
+}
+
+func TestCreateSubscriptionValidatesContact(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	auth := new(authnmocks.Authentication)
+	svc := notifiers.New(auth, repo, uuid.NewMock(), contactNotifier{Notifier: new(smqmocks.Notifier)}, "exampleFrom")
+
+	cases := []struct {
+		desc    string
+		contact string
+		id      string
+		err     error
+	}{
+		{
+			desc:    "create subscription with valid contact",
+			contact: "hooks.example.com",
+			id:      uuid.Prefix + fmt.Sprintf("%012d", 1),
+		},
+		{
+			desc:    "create subscription with invalid contact",
+			contact: "localhost",
+			err:     svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		authCall := auth.On("Authenticate", context.Background(), exampleUser1).Return(smqauthn.Session{UserID: validID}, nil)
+		repoCall := repo.On("Save", context.Background(), mock.Anything).Return(tc.id, nil)
+		id, err := svc.CreateSubscription(context.Background(), exampleUser1, notifiers.Subscription{Contact: tc.contact, Topic: "valid.topic"})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+		authCall.Unset()
+		repoCall.Unset()
+	}
+}
+
 func TestViewSubscription(t *testing.T) {
 	svc, auth, repo := newService()
 	sub := notifiers.Subscription{
@@ -378,8 +490,360 @@ func TestConsume(t *testing.T) {
 		},
 	}, nil).Once()
 
-	notifier.On("Notify", "exampleFrom", []string{"user@example.com"}, canonicalMsg).Return(nil).Once()
+	notifier.On("Notify", mock.Anything, "exampleFrom", []string{"user@example.com"}, canonicalMsg).Return(nil).Once()
 
 	err := svc.ConsumeBlocking(context.TODO(), canonicalMsg)
 	assert.NoError(t, err)
 }
+
+// recipientNotifier records the recipients it is asked to notify.
+type recipientNotifier struct {
+	*smqmocks.Notifier
+	recipients []consumers.Recipient
+}
+
+func (n *recipientNotifier) NotifyRecipients(_ context.Context, _ string, to []consumers.Recipient, _ *messaging.Message) error {
+	n.recipients = append(n.recipients, to...)
+	return nil
+}
+
+func TestConsumeRecipients(t *testing.T) {
+	repo := new(mocks.SubscriptionsRepository)
+	auth := new(authnmocks.Authentication)
+	notifier := &recipientNotifier{Notifier: new(smqmocks.Notifier)}
+	svc := notifiers.New(auth, repo, uuid.NewMock(), notifier, "exampleFrom")
+	headers := map[string]string{"Authorization": "Bearer token"}
+	subs := []notifiers.Subscription{
+		{Contact: "https://example.com/first", Secret: "secret", Headers: headers},
+		{Contact: "https://example.com/second"},
+	}
+	repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: subs}, nil).Once()
+
+	err := svc.ConsumeBlocking(context.TODO(), &messaging.Message{Channel: "topic"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, []consumers.Recipient{
+		{Contact: "https://example.com/first", Secret: "secret", Headers: headers},
+		{Contact: "https://example.com/second"},
+	}, notifier.recipients)
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestConsumeTemplate(t *testing.T) {
+	svc, _, repo, notifier := newServiceWithNotifier()
+	msg := &messaging.Message{
+		Channel:   "topic",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Payload:   []byte(`{"temperature":21.5}`),
+		Created:   1700000000000000000,
+	}
+	rendered := func(payload string) *messaging.Message {
+		return &messaging.Message{
+			Channel:   msg.Channel,
+			Subtopic:  msg.Subtopic,
+			Publisher: msg.Publisher,
+			Protocol:  msg.Protocol,
+			Payload:   []byte(payload),
+			Created:   msg.Created,
+		}
+	}
+
+	cases := []struct {
+		desc   string
+		subs   []notifiers.Subscription
+		notify map[string]*messaging.Message
+		err    error
+	}{
+		{
+			desc: "notify with template",
+			subs: []notifiers.Subscription{
+				{Contact: "first@example.com", Template: "{{.Publisher}} measured {{.Values.temperature}} at {{.Created.Format \"15:04\"}}"},
+			},
+			notify: map[string]*messaging.Message{
+				"first@example.com": rendered("publisher measured 21.5 at 22:13"),
+			},
+			err: nil,
+		},
+		{
+			desc: "notify contacts of each template",
+			subs: []notifiers.Subscription{
+				{Contact: "first@example.com", Template: "{{.Payload}} on {{.Channel}}"},
+				{Contact: "second@example.com"},
+			},
+			notify: map[string]*messaging.Message{
+				"first@example.com":  rendered(`{"temperature":21.5} on topic`),
+				"second@example.com": msg,
+			},
+			err: nil,
+		},
+		{
+			desc: "notify with failing template",
+			subs: []notifiers.Subscription{
+				{Contact: "first@example.com", Template: "{{.Values.temperature.value}}"},
+				{Contact: "second@example.com"},
+			},
+			notify: map[string]*messaging.Message{
+				"second@example.com": msg,
+			},
+			err: consumers.ErrNotify,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: tc.subs}, nil)
+			var calls []*mock.Call
+			for contact, m := range tc.notify {
+				calls = append(calls, notifier.On("Notify", mock.Anything, "exampleFrom", []string{contact}, m).Return(nil).Once())
+			}
+			err := svc.ConsumeBlocking(context.TODO(), msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			notifier.AssertExpectations(t)
+			repoCall.Unset()
+			for _, c := range calls {
+				c.Unset()
+			}
+		})
+	}
+}
+
+func TestConsumeFilters(t *testing.T) {
+	svc, _, repo, notifier := newServiceWithNotifier()
+	senml := &messaging.Message{
+		Channel: "topic",
+		Payload: []byte(`[{"bn":"sensor:","n":"temperature","u":"Cel","v":31.5},{"n":"state","vs":"on"},{"n":"alarm","vb":true}]`),
+	}
+	object := &messaging.Message{
+		Channel: "topic",
+		Payload: []byte(`{"temperature":18,"state":"off"}`),
+	}
+	text := &messaging.Message{
+		Channel: "topic",
+		Payload: []byte("temperature is 31.5"),
+	}
+	value := func(v float64) *float64 { return &v }
+	str := func(v string) *string { return &v }
+	boolean := func(v bool) *bool { return &v }
+
+	cases := []struct {
+		desc    string
+		msg     *messaging.Message
+		filters []notifiers.Filter
+		notify  bool
+	}{
+		{
+			desc:    "notify with matching SenML value",
+			msg:     senml,
+			filters: []notifiers.Filter{{Name: "sensor:temperature", Comparator: notifiers.GreaterThanKey, Value: value(30)}},
+			notify:  true,
+		},
+		{
+			desc:    "skip with non matching SenML value",
+			msg:     senml,
+			filters: []notifiers.Filter{{Name: "sensor:temperature", Comparator: notifiers.LowerThanEqualKey, Value: value(30)}},
+			notify:  false,
+		},
+		{
+			desc: "notify with all filters matching",
+			msg:  senml,
+			filters: []notifiers.Filter{
+				{Name: "sensor:temperature", Comparator: notifiers.GreaterThanEqualKey, Value: value(31.5)},
+				{Name: "sensor:state", Comparator: notifiers.EqualKey, StringValue: str("on")},
+				{Name: "sensor:alarm", Comparator: notifiers.EqualKey, BoolValue: boolean(true)},
+			},
+			notify: true,
+		},
+		{
+			desc: "skip with one filter not matching",
+			msg:  senml,
+			filters: []notifiers.Filter{
+				{Name: "sensor:temperature", Comparator: notifiers.GreaterThanKey, Value: value(30)},
+				{Name: "sensor:state", Comparator: notifiers.NotEqualKey, StringValue: str("on")},
+			},
+			notify: false,
+		},
+		{
+			desc:    "skip with missing record",
+			msg:     senml,
+			filters: []notifiers.Filter{{Name: "humidity", Comparator: notifiers.GreaterThanKey, Value: value(30)}},
+			notify:  false,
+		},
+		{
+			desc:    "skip with value of other type",
+			msg:     senml,
+			filters: []notifiers.Filter{{Name: "sensor:state", Comparator: notifiers.EqualKey, Value: value(1)}},
+			notify:  false,
+		},
+		{
+			desc:    "notify with matching JSON object field",
+			msg:     object,
+			filters: []notifiers.Filter{{Name: "temperature", Comparator: notifiers.LowerThanKey, Value: value(20)}},
+			notify:  true,
+		},
+		{
+			desc:    "skip with text payload",
+			msg:     text,
+			filters: []notifiers.Filter{{Name: "temperature", Comparator: notifiers.GreaterThanKey, Value: value(30)}},
+			notify:  false,
+		},
+		{
+			desc:   "notify text payload without filters",
+			msg:    text,
+			notify: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			subs := []notifiers.Subscription{{ID: validID, Contact: "user@example.com", Filters: tc.filters}}
+			repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: subs}, nil)
+			notifyCall := notifier.On("Notify", mock.Anything, "exampleFrom", []string{"user@example.com"}, tc.msg).Return(nil)
+			err := svc.ConsumeBlocking(context.TODO(), tc.msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			if tc.notify {
+				notifier.AssertNumberOfCalls(t, "Notify", 1)
+			} else {
+				notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			repoCall.Unset()
+			notifyCall.Unset()
+			notifier.Calls = nil
+		})
+	}
+}
+
+func TestConsumeRateLimit(t *testing.T) {
+	svc, _, repo, notifier := newServiceWithNotifier()
+	msg := &messaging.Message{Channel: "topic", Payload: []byte(`{"temperature":21.5}`)}
+	subs := []notifiers.Subscription{
+		{ID: "limited", Contact: "limited@example.com", RateLimit: 2, RateInterval: time.Hour},
+		{ID: "unlimited", Contact: "unlimited@example.com"},
+	}
+
+	repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: subs}, nil)
+	notifier.On("Notify", mock.Anything, "exampleFrom", []string{"limited@example.com", "unlimited@example.com"}, msg).Return(nil).Twice()
+	notifier.On("Notify", mock.Anything, "exampleFrom", []string{"unlimited@example.com"}, msg).Return(nil).Once()
+
+	for i := 0; i < 3; i++ {
+		err := svc.ConsumeBlocking(context.TODO(), msg)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	notifier.AssertExpectations(t)
+}
+
+func TestConsumeQuietHours(t *testing.T) {
+	svc, _, repo, notifier := newServiceWithNotifier()
+	msg := &messaging.Message{Channel: "topic", Payload: []byte(`{"temperature":21.5}`)}
+	now := time.Now().UTC()
+	quiet := &notifiers.QuietHours{
+		Start: now.Add(-time.Hour).Format("15:04"),
+		End:   now.Add(time.Hour).Format("15:04"),
+	}
+	active := &notifiers.QuietHours{
+		Start: now.Add(time.Hour).Format("15:04"),
+		End:   now.Add(2 * time.Hour).Format("15:04"),
+	}
+	subs := []notifiers.Subscription{
+		{ID: "quiet", Contact: "quiet@example.com", QuietHours: quiet},
+		{ID: "active", Contact: "active@example.com", QuietHours: active},
+	}
+
+	repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: subs}, nil)
+	notifier.On("Notify", mock.Anything, "exampleFrom", []string{"active@example.com"}, msg).Return(nil).Once()
+
+	err := svc.ConsumeBlocking(context.TODO(), msg)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	notifier.AssertExpectations(t)
+}
+
+func TestConsumeDigest(t *testing.T) {
+	svc, _, repo, notifier := newServiceWithNotifier()
+	msg := &messaging.Message{Channel: "topic", Payload: []byte(`{"temperature":21.5}`)}
+	subs := []notifiers.Subscription{
+		{ID: "digest", Contact: "digest@example.com", DigestInterval: time.Hour},
+		{ID: "instant", Contact: "instant@example.com"},
+	}
+
+	cases := []struct {
+		desc      string
+		digestErr error
+		err       error
+	}{
+		{
+			desc: "queue digest message successfully",
+		},
+		{
+			desc:      "queue digest message with failed repository",
+			digestErr: repoerr.ErrCreateEntity,
+			err:       repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveAll", context.TODO(), mock.Anything).Return(notifiers.Page{Subscriptions: subs}, nil)
+			repoCall1 := repo.On("AddDigestMessage", context.TODO(), "digest", msg).Return(tc.digestErr).Once()
+			notifyCall := notifier.On("Notify", mock.Anything, "exampleFrom", []string{"instant@example.com"}, msg).Return(nil).Once()
+			err := svc.ConsumeBlocking(context.TODO(), msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repo.AssertExpectations(t)
+			notifier.AssertExpectations(t)
+			repoCall.Unset()
+			repoCall1.Unset()
+			notifyCall.Unset()
+		})
+	}
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.March, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		desc     string
+		hours    notifiers.QuietHours
+		time     time.Time
+		contains bool
+	}{
+		{
+			desc:     "time within daytime quiet hours",
+			hours:    notifiers.QuietHours{Start: "09:00", End: "17:00"},
+			time:     at(12, 0),
+			contains: true,
+		},
+		{
+			desc:     "time at end of quiet hours",
+			hours:    notifiers.QuietHours{Start: "09:00", End: "17:00"},
+			time:     at(17, 0),
+			contains: false,
+		},
+		{
+			desc:     "time after midnight within overnight quiet hours",
+			hours:    notifiers.QuietHours{Start: "22:00", End: "07:00"},
+			time:     at(3, 30),
+			contains: true,
+		},
+		{
+			desc:     "time outside overnight quiet hours",
+			hours:    notifiers.QuietHours{Start: "22:00", End: "07:00"},
+			time:     at(12, 0),
+			contains: false,
+		},
+		{
+			desc:     "time within quiet hours of timezone",
+			hours:    notifiers.QuietHours{Start: "22:00", End: "07:00", Timezone: "America/New_York"},
+			time:     at(4, 0),
+			contains: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Nil(t, tc.hours.Validate(), fmt.Sprintf("%s: unexpected invalid quiet hours", tc.desc))
+			contains := tc.hours.Contains(tc.time)
+			assert.Equal(t, tc.contains, contains, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.contains, contains))
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package smpp
 
 import (
+	"context"
 	"time"
 
 	"github.com/absmach/magistrala/consumers"
@@ -47,7 +48,7 @@ func New(cfg Config) consumers.Notifier {
 	return ret
 }
 
-func (n *notifier) Notify(from string, to []string, msg *messaging.Message) error {
+func (n *notifier) Notify(_ context.Context, from string, to []string, msg *messaging.Message) error {
 	send := &smpp.ShortMessage{
 		Src:           from,
 		DstList:       to,
@@ -4,6 +4,7 @@
 package smtp
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/absmach/magistrala/consumers"
@@ -27,7 +28,7 @@ func New(agent *email.Agent) consumers.Notifier {
 	return &notifier{agent: agent}
 }
 
-func (n *notifier) Notify(from string, to []string, msg *messaging.Message) error {
+func (n *notifier) Notify(_ context.Context, from string, to []string, msg *messaging.Message) error {
 	subject := fmt.Sprintf(`Notification for Channel %s`, msg.GetChannel())
 	if msg.GetSubtopic() != "" {
 		subject = fmt.Sprintf("%s and subtopic %s", subject, msg.GetSubtopic())
@@ -3,7 +3,38 @@
 
 package notifiers
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const (
+	// DefRateInterval is the rate limit interval of subscriptions which
+	// set a rate limit without an interval.
+	DefRateInterval = time.Hour
+
+	quietHoursLayout = "15:04"
+)
+
+var (
+	// ErrInvalidRateLimit indicates an invalid subscription rate limit.
+	ErrInvalidRateLimit = errors.New("invalid subscription rate limit")
+
+	// ErrInvalidQuietHours indicates invalid subscription quiet hours.
+	ErrInvalidQuietHours = errors.New("invalid subscription quiet hours")
+
+	// ErrInvalidDigest indicates an invalid subscription digest interval.
+	ErrInvalidDigest = errors.New("invalid subscription digest interval")
+
+	// ErrInvalidHeaders indicates invalid subscription headers.
+	ErrInvalidHeaders = errors.New("invalid subscription headers")
+)
 
 // Subscription represents a user Subscription.
 type Subscription struct {
@@ -11,6 +42,139 @@ type Subscription struct {
 	OwnerID string
 	Contact string
 	Topic   string
+	// Template renders the notifications of the subscription. Contacts of
+	// subscriptions without a template receive the message payload as is.
+	Template string
+	// Secret signs the notifications of the subscription, and Headers are
+	// added to them, if the notifier supports it, such as the webhook one.
+	// They are set when the subscription is created and not exposed after.
+	Secret  string
+	Headers map[string]string
+	// Filters select the messages which notify the contact. All of them
+	// must match.
+	Filters []Filter
+	// RateLimit is the largest number of notifications sent per
+	// RateInterval by each service instance. Zero means no limit.
+	RateLimit    uint
+	RateInterval time.Duration
+	// QuietHours is the daily period in which no notifications are sent.
+	QuietHours *QuietHours
+	// DigestInterval batches the notifications of the subscription into one
+	// notification sent once the oldest of them waited for the interval.
+	// Zero sends each notification right away.
+	DigestInterval time.Duration
+}
+
+// Validate returns an error if the subscription settings are invalid.
+func (s Subscription) Validate() error {
+	if s.Template != "" {
+		if err := ValidateTemplate(s.Template); err != nil {
+			return err
+		}
+	}
+	for _, f := range s.Filters {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.RateInterval < 0 || (s.RateInterval > 0 && s.RateLimit == 0) {
+		return errors.Wrap(ErrInvalidRateLimit, fmt.Errorf("rate interval %s needs a rate limit", s.RateInterval))
+	}
+	if s.QuietHours != nil {
+		if err := s.QuietHours.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.DigestInterval < 0 {
+		return errors.Wrap(ErrInvalidDigest, fmt.Errorf("negative digest interval %s", s.DigestInterval))
+	}
+	for k := range s.Headers {
+		if strings.TrimSpace(k) == "" {
+			return errors.Wrap(ErrInvalidHeaders, errors.New("empty header name"))
+		}
+	}
+
+	return nil
+}
+
+// QuietHours is a daily period, from Start to End in the Timezone. A period
+// which ends before it starts spans midnight.
+type QuietHours struct {
+	// Start and End are in the 15:04 format.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Timezone is the IANA timezone of the period, UTC by default.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Validate returns an error if the quiet hours can not be applied.
+func (q QuietHours) Validate() error {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return errors.Wrap(ErrInvalidQuietHours, err)
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return errors.Wrap(ErrInvalidQuietHours, err)
+	}
+	if start.Equal(end) {
+		return errors.Wrap(ErrInvalidQuietHours, errors.New("quiet hours start and end at the same time"))
+	}
+	if _, err := time.LoadLocation(q.Timezone); err != nil {
+		return errors.Wrap(ErrInvalidQuietHours, err)
+	}
+
+	return nil
+}
+
+// Contains reports whether the time is in the quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return false
+	}
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+	minute := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from < to {
+		return minute >= from && minute < to
+	}
+
+	return minute >= from || minute < to
+}
+
+// recipient returns the contact of the subscription with its notification
+// settings.
+func (s Subscription) recipient() consumers.Recipient {
+	return consumers.Recipient{Contact: s.Contact, Secret: s.Secret, Headers: s.Headers}
+}
+
+// quiet reports whether the subscription is in its quiet hours.
+func (s Subscription) quiet(t time.Time) bool {
+	return s.QuietHours != nil && s.QuietHours.Contains(t)
+}
+
+// Digest is the messages held back for the digest of a subscription.
+type Digest struct {
+	Subscription Subscription
+	// Messages are the oldest messages of the digest, up to the maximum
+	// number of messages of a digest.
+	Messages []*messaging.Message
+	// Total is the number of the messages of the digest, including the
+	// ones over the maximum which are only counted.
+	Total uint64
+	// LastID identifies the last message of the digest, so the messages
+	// added since the digest was retrieved are kept.
+	LastID uint64
 }
 
 // Page represents page metadata with content.
@@ -43,4 +207,18 @@ type SubscriptionsRepository interface {
 
 	// Remove removes the subscription for the given ID.
 	Remove(ctx context.Context, id string) error
+
+	// AddDigestMessage holds back the message for the digest of the subscription.
+	AddDigestMessage(ctx context.Context, subID string, msg *messaging.Message) error
+
+	// ClaimDueDigests claims the digests whose oldest message waited for
+	// the digest interval of the subscription at the given time, and hides
+	// them from other claims until the until time, so concurrent service
+	// instances do not send them again. The digests hold at most
+	// maxMessages messages.
+	ClaimDueDigests(ctx context.Context, now, until time.Time, limit, maxMessages uint64) ([]Digest, error)
+
+	// RemoveDigest removes the messages of the subscription digest up to the
+	// message of the given ID, and releases the claim of the digest.
+	RemoveDigest(ctx context.Context, subID string, lastID uint64) error
 }
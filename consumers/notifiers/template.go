@@ -0,0 +1,85 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+var (
+	// ErrInvalidTemplate indicates a subscription template which can not be parsed.
+	ErrInvalidTemplate = errors.New("invalid subscription template")
+
+	errRender = errors.New("failed to render subscription template")
+)
+
+// TemplateData is the data subscription templates are executed with.
+type TemplateData struct {
+	Channel   string
+	Subtopic  string
+	Publisher string
+	Protocol  string
+	Created   time.Time
+	// Payload is the raw message payload.
+	Payload string
+	// Values is the JSON decoded payload, or nil if the payload is not JSON.
+	Values any
+}
+
+// ValidateTemplate returns an error if the subscription template can not be
+// parsed.
+func ValidateTemplate(text string) error {
+	if _, err := parseTemplate(text); err != nil {
+		return errors.Wrap(ErrInvalidTemplate, err)
+	}
+
+	return nil
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("subscription").Option("missingkey=zero").Parse(text)
+}
+
+// render returns a copy of the message whose payload is the subscription
+// template executed with the message.
+func render(text string, msg *messaging.Message) (*messaging.Message, error) {
+	tmpl, err := parseTemplate(text)
+	if err != nil {
+		return nil, errors.Wrap(errRender, err)
+	}
+
+	data := TemplateData{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Created:   time.Unix(0, msg.GetCreated()).UTC(),
+		Payload:   string(msg.GetPayload()),
+	}
+	if err := json.Unmarshal(msg.GetPayload(), &data.Values); err != nil {
+		data.Values = nil
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, errors.Wrap(errRender, err)
+	}
+
+	return &messaging.Message{
+		Channel:   msg.GetChannel(),
+		Domain:    msg.GetDomain(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Payload:   []byte(b.String()),
+		Created:   msg.GetCreated(),
+		ClientId:  msg.GetClientId(),
+	}, nil
+}
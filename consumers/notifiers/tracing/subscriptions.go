@@ -7,8 +7,10 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/consumers/notifiers"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -18,6 +20,9 @@ const (
 	retrieveOp    = "retrieve_op"
 	retrieveAllOp = "retrieve_all_op"
 	removeOp      = "remove_op"
+	addDigestOp   = "add_digest_message_op"
+	dueDigestsOp  = "claim_due_digests_op"
+	rmDigestOp    = "remove_digest_op"
 )
 
 var _ notifiers.SubscriptionsRepository = (*subRepositoryMiddleware)(nil)
@@ -71,3 +76,27 @@ func (urm subRepositoryMiddleware) Remove(ctx context.Context, id string) error
 
 	return urm.repo.Remove(ctx, id)
 }
+
+// AddDigestMessage traces the "AddDigestMessage" operation of the wrapped Subscriptions repository.
+func (urm subRepositoryMiddleware) AddDigestMessage(ctx context.Context, subID string, msg *messaging.Message) error {
+	ctx, span := urm.tracer.Start(ctx, addDigestOp, trace.WithAttributes(attribute.String("subscription_id", subID)))
+	defer span.End()
+
+	return urm.repo.AddDigestMessage(ctx, subID, msg)
+}
+
+// ClaimDueDigests traces the "ClaimDueDigests" operation of the wrapped Subscriptions repository.
+func (urm subRepositoryMiddleware) ClaimDueDigests(ctx context.Context, now, until time.Time, limit, maxMessages uint64) ([]notifiers.Digest, error) {
+	ctx, span := urm.tracer.Start(ctx, dueDigestsOp)
+	defer span.End()
+
+	return urm.repo.ClaimDueDigests(ctx, now, until, limit, maxMessages)
+}
+
+// RemoveDigest traces the "RemoveDigest" operation of the wrapped Subscriptions repository.
+func (urm subRepositoryMiddleware) RemoveDigest(ctx context.Context, subID string, lastID uint64) error {
+	ctx, span := urm.tracer.Start(ctx, rmDigestOp, trace.WithAttributes(attribute.String("subscription_id", subID)))
+	defer span.End()
+
+	return urm.repo.RemoveDigest(ctx, subID, lastID)
+}
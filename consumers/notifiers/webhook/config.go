@@ -0,0 +1,24 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import "time"
+
+// Config represents webhook notifier configuration.
+type Config struct {
+	Method  string        `env:"MG_WEBHOOK_METHOD"         envDefault:"POST"`
+	Timeout time.Duration `env:"MG_WEBHOOK_TIMEOUT"        envDefault:"10s"`
+	// Retries is the number of times a failed request is retried.
+	Retries uint `env:"MG_WEBHOOK_RETRIES"        envDefault:"3"`
+	// RetryInterval is the wait before the first retry, doubled on each
+	// following one.
+	RetryInterval time.Duration `env:"MG_WEBHOOK_RETRY_INTERVAL" envDefault:"1s"`
+	// SendTimeout bounds the requests and the retries of a notification. It
+	// must be shorter than the ack wait of the message broker, which
+	// redelivers the message otherwise.
+	SendTimeout time.Duration `env:"MG_WEBHOOK_SEND_TIMEOUT"   envDefault:"20s"`
+	// AllowPrivate allows webhooks on loopback, private and link-local
+	// addresses, which are blocked by default.
+	AllowPrivate bool `env:"MG_WEBHOOK_ALLOW_PRIVATE"  envDefault:"false"`
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook contains the notifier which sends Magistrala
+// notifications to HTTP webhooks.
+package webhook
@@ -0,0 +1,199 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/netguard"
+)
+
+// Headers of the webhook requests.
+const (
+	ChannelHeader   = "X-Magistrala-Channel"
+	SubtopicHeader  = "X-Magistrala-Subtopic"
+	PublisherHeader = "X-Magistrala-Publisher"
+	TimestampHeader = "X-Magistrala-Timestamp"
+	SignatureHeader = "X-Magistrala-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	errWebhookStatus = errors.New("unexpected webhook response status")
+	errInvalidURL    = errors.New("webhook URL must be an absolute http or https URL")
+)
+
+var (
+	_ consumers.Notifier          = (*notifier)(nil)
+	_ consumers.RecipientNotifier = (*notifier)(nil)
+	_ consumers.ContactValidator  = (*notifier)(nil)
+)
+
+type notifier struct {
+	cfg    Config
+	client *http.Client
+}
+
+// New instantiates webhook message notifier. Unless the config allows
+// private addresses, the requests fail to connect to loopback, private and
+// link-local addresses, including the ones a webhook host resolves or
+// redirects to.
+func New(cfg Config) consumers.Notifier {
+	if cfg.Method == "" {
+		cfg.Method = http.MethodPost
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.AllowPrivate {
+		transport = netguard.Transport()
+	}
+
+	return &notifier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout, Transport: transport},
+	}
+}
+
+// Notify sends the message payload to each webhook URL of the recipients,
+// without signing the requests. The sender is not used.
+func (n *notifier) Notify(ctx context.Context, from string, to []string, msg *messaging.Message) error {
+	recipients := make([]consumers.Recipient, len(to))
+	for i, contact := range to {
+		recipients[i] = consumers.Recipient{Contact: contact}
+	}
+
+	return n.NotifyRecipients(ctx, from, recipients, msg)
+}
+
+// NotifyRecipients sends the message payload to the webhook URL of each
+// recipient, with the headers of the recipient and signed with its secret.
+// The sender is not used. Requests which fail to connect, or which are
+// answered with 429 or 5xx, are retried until the send timeout expires or
+// the context is canceled. The webhooks are notified concurrently, and the
+// first error is returned once all of them are done.
+func (n *notifier) NotifyRecipients(ctx context.Context, _ string, to []consumers.Recipient, msg *messaging.Message) error {
+	if n.cfg.SendTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.cfg.SendTimeout)
+		defer cancel()
+	}
+
+	errs := make([]error, len(to))
+	var wg sync.WaitGroup
+	for i, r := range to {
+		wg.Go(func() {
+			errs[i] = n.send(ctx, r, msg)
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateContact checks that the contact is an http or https URL and,
+// unless the config allows private addresses, that its host does not
+// resolve to a loopback, private or link-local address.
+func (n *notifier) ValidateContact(ctx context.Context, contact string) error {
+	u, err := url.Parse(contact)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errInvalidURL
+	}
+	if n.cfg.AllowPrivate {
+		return nil
+	}
+
+	return netguard.ValidateHost(ctx, u.Hostname())
+}
+
+func (n *notifier) send(ctx context.Context, r consumers.Recipient, msg *messaging.Message) error {
+	interval := n.cfg.RetryInterval
+	var err error
+	for attempt := uint(0); ; attempt++ {
+		var retry bool
+		if retry, err = n.post(ctx, r, msg); !retry || attempt >= n.cfg.Retries {
+			return err
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+}
+
+// post sends the request and reports whether it can be retried.
+func (n *notifier) post(ctx context.Context, r consumers.Recipient, msg *messaging.Message) (bool, error) {
+	payload := msg.GetPayload()
+	req, err := http.NewRequestWithContext(ctx, n.cfg.Method, r.Contact, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if json.Valid(payload) {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	// The headers of the recipient can't override the Magistrala headers.
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(ChannelHeader, msg.GetChannel())
+	if msg.GetSubtopic() != "" {
+		req.Header.Set(SubtopicHeader, msg.GetSubtopic())
+	}
+	if msg.GetPublisher() != "" {
+		req.Header.Set(PublisherHeader, msg.GetPublisher())
+	}
+	if r.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, ts)
+		req.Header.Set(SignatureHeader, signaturePrefix+Sign(r.Secret, ts, payload))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil && !errors.Contains(err, netguard.ErrPrivateAddress), err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+		return retry, errors.Wrap(errWebhookStatus, fmt.Errorf("%s", res.Status))
+	}
+
+	return false, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the timestamp and the body
+// joined by a dot, which webhooks use to verify the requests.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
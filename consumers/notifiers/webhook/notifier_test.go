@@ -0,0 +1,287 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/consumers/notifiers/webhook"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+type request struct {
+	method string
+	header http.Header
+	body   []byte
+}
+
+// server answers the requests with the queued statuses, then with 200.
+type server struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []request
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request{method: r.Method, header: r.Header, body: body})
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func TestNotify(t *testing.T) {
+	msg := &messaging.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Payload:   []byte(`{"temperature":21.5}`),
+	}
+
+	cases := []struct {
+		desc        string
+		cfg         webhook.Config
+		msg         *messaging.Message
+		statuses    []int
+		requests    int
+		method      string
+		headers     map[string]string
+		contentType string
+		header      string
+		err         bool
+	}{
+		{
+			desc:        "notify webhook successfully",
+			cfg:         webhook.Config{Timeout: time.Second},
+			msg:         msg,
+			requests:    1,
+			method:      http.MethodPost,
+			contentType: "application/json",
+		},
+		{
+			desc:        "notify webhook with method and headers",
+			cfg:         webhook.Config{Method: http.MethodPut, Timeout: time.Second},
+			msg:         msg,
+			requests:    1,
+			method:      http.MethodPut,
+			headers:     map[string]string{"Authorization": "Bearer token", webhook.ChannelHeader: "other"},
+			contentType: "application/json",
+			header:      "Bearer token",
+		},
+		{
+			desc:        "notify webhook with text payload",
+			cfg:         webhook.Config{Timeout: time.Second},
+			msg:         &messaging.Message{Channel: "channel", Payload: []byte("temperature is 21.5")},
+			requests:    1,
+			method:      http.MethodPost,
+			contentType: "text/plain; charset=utf-8",
+		},
+		{
+			desc:        "notify webhook after retries",
+			cfg:         webhook.Config{Timeout: time.Second, Retries: 2, RetryInterval: time.Millisecond},
+			msg:         msg,
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			requests:    3,
+			method:      http.MethodPost,
+			contentType: "application/json",
+		},
+		{
+			desc:        "notify webhook with exhausted retries",
+			cfg:         webhook.Config{Timeout: time.Second, Retries: 1, RetryInterval: time.Millisecond},
+			msg:         msg,
+			statuses:    []int{http.StatusInternalServerError, http.StatusBadGateway},
+			requests:    2,
+			method:      http.MethodPost,
+			contentType: "application/json",
+			err:         true,
+		},
+		{
+			desc:        "notify webhook with client error",
+			cfg:         webhook.Config{Timeout: time.Second, Retries: 3, RetryInterval: time.Millisecond},
+			msg:         msg,
+			statuses:    []int{http.StatusBadRequest},
+			requests:    1,
+			method:      http.MethodPost,
+			contentType: "application/json",
+			err:         true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &server{statuses: tc.statuses}
+			ts := httptest.NewServer(s)
+			defer ts.Close()
+
+			tc.cfg.AllowPrivate = true
+			n := webhook.New(tc.cfg).(consumers.RecipientNotifier)
+			err := n.NotifyRecipients(context.Background(), "", []consumers.Recipient{{Contact: ts.URL, Secret: secret, Headers: tc.headers}}, tc.msg)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+			assert.Len(t, s.requests, tc.requests, fmt.Sprintf("%s: expected %d requests got %d\n", tc.desc, tc.requests, len(s.requests)))
+
+			req := s.requests[len(s.requests)-1]
+			assert.Equal(t, tc.method, req.method)
+			assert.Equal(t, tc.msg.Payload, req.body)
+			assert.Equal(t, tc.contentType, req.header.Get("Content-Type"))
+			assert.Equal(t, tc.header, req.header.Get("Authorization"))
+			assert.Equal(t, tc.msg.Channel, req.header.Get(webhook.ChannelHeader))
+			timestamp := req.header.Get(webhook.TimestampHeader)
+			assert.Equal(t, "sha256="+webhook.Sign(secret, timestamp, tc.msg.Payload), req.header.Get(webhook.SignatureHeader))
+		})
+	}
+}
+
+func TestNotifyWithoutRecipientSettings(t *testing.T) {
+	s := &server{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	n := webhook.New(webhook.Config{Timeout: time.Second, AllowPrivate: true})
+	err := n.Notify(context.Background(), "", []string{ts.URL}, &messaging.Message{Channel: "channel", Payload: []byte("{}")})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.Len(t, s.requests, 1)
+	assert.Empty(t, s.requests[0].header.Get(webhook.SignatureHeader), "expected request not to be signed")
+	assert.Empty(t, s.requests[0].header.Get(webhook.TimestampHeader), "expected request without timestamp")
+}
+
+func TestNotifyUnreachable(t *testing.T) {
+	n := webhook.New(webhook.Config{Timeout: time.Second, Retries: 1, RetryInterval: time.Millisecond, AllowPrivate: true})
+	err := n.Notify(context.Background(), "", []string{"http://127.0.0.1:0"}, &messaging.Message{Channel: "channel"})
+	assert.NotNil(t, err, "expected error notifying unreachable webhook")
+}
+
+func TestNotifyPrivate(t *testing.T) {
+	s := &server{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	n := webhook.New(webhook.Config{Timeout: time.Second, Retries: 3, RetryInterval: time.Hour})
+	err := n.Notify(context.Background(), "", []string{ts.URL}, &messaging.Message{Channel: "channel"})
+	assert.NotNil(t, err, "expected error notifying private webhook")
+	assert.Empty(t, s.requests)
+}
+
+func TestNotifyTimeout(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		desc     string
+		ctx      context.Context
+		cfg      webhook.Config
+		requests int
+	}{
+		{
+			desc:     "notify webhook with expired send timeout",
+			ctx:      context.Background(),
+			cfg:      webhook.Config{Timeout: time.Second, Retries: 3, RetryInterval: time.Hour, SendTimeout: 50 * time.Millisecond, AllowPrivate: true},
+			requests: 1,
+		},
+		{
+			desc:     "notify webhook with canceled context",
+			ctx:      canceled,
+			cfg:      webhook.Config{Timeout: time.Second, Retries: 3, RetryInterval: time.Hour, AllowPrivate: true},
+			requests: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &server{statuses: []int{http.StatusServiceUnavailable}}
+			ts := httptest.NewServer(s)
+			defer ts.Close()
+
+			start := time.Now()
+			err := webhook.New(tc.cfg).Notify(tc.ctx, "", []string{ts.URL}, &messaging.Message{Channel: "channel"})
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			assert.Less(t, time.Since(start), time.Second, fmt.Sprintf("%s: expected notify to stop retrying", tc.desc))
+			assert.Len(t, s.requests, tc.requests, fmt.Sprintf("%s: expected %d requests got %d\n", tc.desc, tc.requests, len(s.requests)))
+		})
+	}
+}
+
+func TestValidateContact(t *testing.T) {
+	cases := []struct {
+		desc    string
+		cfg     webhook.Config
+		contact string
+		err     bool
+	}{
+		{
+			desc:    "validate public webhook",
+			contact: "https://93.184.216.34/hooks",
+		},
+		{
+			desc:    "validate webhook with invalid scheme",
+			contact: "ftp://93.184.216.34/hooks",
+			err:     true,
+		},
+		{
+			desc:    "validate webhook without host",
+			contact: "https:///hooks",
+			err:     true,
+		},
+		{
+			desc:    "validate email contact",
+			contact: "user@example.com",
+			err:     true,
+		},
+		{
+			desc:    "validate loopback webhook",
+			contact: "http://localhost:8080/hooks",
+			err:     true,
+		},
+		{
+			desc:    "validate private webhook",
+			contact: "http://10.0.0.1/hooks",
+			err:     true,
+		},
+		{
+			desc:    "validate link-local webhook",
+			contact: "http://169.254.169.254/latest",
+			err:     true,
+		},
+		{
+			desc:    "validate private webhook with private addresses allowed",
+			cfg:     webhook.Config{AllowPrivate: true},
+			contact: "http://10.0.0.1/hooks",
+		},
+	}
+
+	for _, tc := range cases {
+		n := webhook.New(tc.cfg).(consumers.ContactValidator)
+		err := n.ValidateContact(context.Background(), tc.contact)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestNotifyUnsigned(t *testing.T) {
+	s := &server{}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	err := webhook.New(webhook.Config{Timeout: time.Second, AllowPrivate: true}).Notify(context.Background(), "", []string{ts.URL, ts.URL}, &messaging.Message{Channel: "channel"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Len(t, s.requests, 2)
+	for _, req := range s.requests {
+		assert.Empty(t, req.header.Get(webhook.SignatureHeader))
+		assert.Empty(t, req.header.Get(webhook.TimestampHeader))
+	}
+}
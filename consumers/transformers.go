@@ -0,0 +1,146 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers"
+	"github.com/absmach/magistrala/pkg/transformers/binary"
+	"github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+// autoFormat selects the transformer of each message by its payload.
+const autoFormat = "AUTO"
+
+// transformerRule selects the transformer of the messages published to the
+// topics or the channels of the rule.
+type transformerRule struct {
+	Topics      []string         `toml:"topics"`
+	Channels    []string         `toml:"channels"`
+	Format      string           `toml:"format"`
+	ContentType string           `toml:"content_type"`
+	TimeFields  []json.TimeField `toml:"time_fields"`
+	Schema      binary.Schema    `toml:"schema"`
+}
+
+func (r transformerRule) config() transformerConfig {
+	return transformerConfig{
+		Format:      r.Format,
+		ContentType: r.ContentType,
+		TimeFields:  r.TimeFields,
+		Schema:      r.Schema,
+	}
+}
+
+type route struct {
+	topics      []string
+	channels    []string
+	transformer transformers.Transformer
+}
+
+func (r route) matches(topic, channel string) bool {
+	if len(r.channels) > 0 && !slices.Contains(r.channels, channel) {
+		return false
+	}
+	if len(r.topics) == 0 {
+		return true
+	}
+	for _, t := range r.topics {
+		if messaging.MatchTopic(topic, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routingTransformer transforms each message with the transformer of the
+// first rule which matches it, or with the default transformer. Messages
+// without a transformer are passed on as they are.
+type routingTransformer struct {
+	prefix string
+	routes []route
+	def    transformers.Transformer
+}
+
+func (rt routingTransformer) Transform(msg *messaging.Message) (any, error) {
+	t := rt.def
+	topic := rt.prefix + "/" + messaging.EncodeMessageTopic(msg)
+	for _, r := range rt.routes {
+		if r.matches(topic, msg.GetChannel()) {
+			t = r.transformer
+			break
+		}
+	}
+	if t == nil {
+		return msg, nil
+	}
+
+	return t.Transform(msg)
+}
+
+// makeTransformers returns the transformer of the config. Rules are matched
+// against the topics the messages are published to, which start with the
+// first level of the default topic.
+func makeTransformers(cfg config, defaultTopic string, logger *slog.Logger) transformers.Transformer {
+	def := makeTransformer(cfg.TransformerCfg, logger)
+	if len(cfg.Transformers) == 0 {
+		return def
+	}
+
+	prefix, _, _ := strings.Cut(defaultTopic, "/")
+	rt := routingTransformer{prefix: prefix, def: def}
+	for i, rule := range cfg.Transformers {
+		if len(rule.Topics) == 0 && len(rule.Channels) == 0 {
+			logger.Warn(fmt.Sprintf("Skipping transformer rule %d without topics and channels", i))
+			continue
+		}
+		rt.routes = append(rt.routes, route{
+			topics:      rule.Topics,
+			channels:    rule.Channels,
+			transformer: makeTransformer(rule.config(), logger),
+		})
+	}
+
+	return rt
+}
+
+// autoTransformer detects SenML CBOR, SenML JSON and JSON payloads.
+type autoTransformer struct {
+	senmlJSON transformers.Transformer
+	senmlCBOR transformers.Transformer
+	json      transformers.Transformer
+}
+
+func newAutoTransformer(timeFields []json.TimeField) transformers.Transformer {
+	return autoTransformer{
+		senmlJSON: senml.New(senml.JSON),
+		senmlCBOR: senml.New(senml.CBOR),
+		json:      json.New(timeFields),
+	}
+}
+
+// Transform transforms CBOR arrays as SenML CBOR and JSON arrays of valid
+// SenML records as SenML JSON. Other payloads are transformed as JSON.
+func (at autoTransformer) Transform(msg *messaging.Message) (any, error) {
+	payload := msg.GetPayload()
+	// CBOR arrays have the major type 4 in the top 3 bits of the first byte.
+	if len(payload) > 0 && payload[0]>>5 == 4 {
+		return at.senmlCBOR.Transform(msg)
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(payload), []byte("[")) {
+		if msgs, err := at.senmlJSON.Transform(msg); err == nil {
+			return msgs, nil
+		}
+	}
+
+	return at.json.Transform(msg)
+}
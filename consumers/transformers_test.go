@@ -0,0 +1,235 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/binary"
+	"github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	domainID     = "domain"
+	senmlChannel = "senml-channel"
+	jsonChannel  = "json-channel"
+	autoChannel  = "auto-channel"
+	binChannel   = "binary-channel"
+	rawChannel   = "raw-channel"
+
+	configFile = `
+["subscriber"]
+topics = ["writers/#"]
+
+[transformer]
+format = "senml"
+
+[[transformers]]
+topics = ["writers/+/c/+/gateways/#"]
+format = "json"
+
+[[transformers]]
+channels = ["json-channel"]
+format = "json"
+
+[[transformers]]
+channels = ["auto-channel"]
+format = "auto"
+
+[[transformers]]
+channels = ["binary-channel"]
+format = "binary"
+
+[transformers.schema]
+name = "env/"
+endianness = "little"
+
+[[transformers.schema.fields]]
+name = "temperature"
+type = "int16"
+scale = 0.01
+unit = "Cel"
+
+[[transformers]]
+channels = ["raw-channel"]
+format = "raw"
+
+[[transformers]]
+format = "json"
+`
+)
+
+var (
+	senmlPayload = []byte(`[{"bn":"base-name","n":"name","u":"unit","t":300,"v":42}]`)
+	jsonPayload  = []byte(`{"temperature":42}`)
+)
+
+func TestLoadTransformerRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.Nil(t, os.WriteFile(path, []byte(configFile), 0o600))
+
+	cfg, err := loadConfig(path, "writers/#")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, cfg.Transformers, 6)
+	assert.Equal(t, []string{"writers/+/c/+/gateways/#"}, cfg.Transformers[0].Topics)
+	assert.Equal(t, "json", cfg.Transformers[0].Format)
+	assert.Equal(t, defContentType, cfg.Transformers[0].ContentType)
+	assert.Equal(t, []string{jsonChannel}, cfg.Transformers[1].Channels)
+	assert.Equal(t, "auto", cfg.Transformers[2].Format)
+	assert.Equal(t, binary.Schema{
+		Name:       "env/",
+		Endianness: binary.LittleEndian,
+		Fields:     []binary.Field{{Name: "temperature", Type: binary.Int16, Scale: 0.01, Unit: "Cel"}},
+	}, cfg.Transformers[3].Schema)
+}
+
+func TestLoadInvalidSchema(t *testing.T) {
+	cases := []struct {
+		desc   string
+		config string
+	}{
+		{
+			desc: "load default transformer with invalid schema",
+			config: `
+[transformer]
+format = "binary"
+`,
+		},
+		{
+			desc: "load transformer rule with invalid schema",
+			config: `
+[[transformers]]
+channels = ["binary-channel"]
+format = "binary"
+
+[[transformers.schema.fields]]
+name = "temperature"
+type = "int128"
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		require.Nil(t, os.WriteFile(path, []byte(tc.config), 0o600))
+		_, err := loadConfig(path, "writers/#")
+		assert.True(t, errors.Contains(err, binary.ErrInvalidSchema), fmt.Sprintf("%s: expected error %s got %s", tc.desc, binary.ErrInvalidSchema, err))
+		err = Start(context.Background(), "consumer", nil, nil, path, "writers/#", slog.New(slog.DiscardHandler))
+		assert.True(t, errors.Contains(err, binary.ErrInvalidSchema), fmt.Sprintf("%s: expected start error %s got %s", tc.desc, binary.ErrInvalidSchema, err))
+	}
+}
+
+func TestRoutingTransformer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.Nil(t, os.WriteFile(path, []byte(configFile), 0o600))
+	cfg, err := loadConfig(path, "writers/#")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	tr := makeTransformers(cfg, "writers/#", slog.New(slog.DiscardHandler))
+
+	cborPayload, err := hex.DecodeString("81ac2169626173652d6e616d6522fb40590000000000002369626173652d756e6974200a24fb402400000000000025fb405900000000000000646e616d650164756e697406fb4072c0000000000007fb4062c0000000000002fb404500000000000005fb4024000000000000")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		channel  string
+		subtopic string
+		payload  []byte
+		senml    bool
+		raw      bool
+	}{
+		{
+			desc:     "transform message with default transformer",
+			channel:  senmlChannel,
+			subtopic: "sensors",
+			payload:  senmlPayload,
+			senml:    true,
+		},
+		{
+			desc:     "transform message of matching topic",
+			channel:  senmlChannel,
+			subtopic: "gateways/boiler",
+			payload:  jsonPayload,
+		},
+		{
+			desc:     "transform message of matching channel",
+			channel:  jsonChannel,
+			subtopic: "boiler",
+			payload:  jsonPayload,
+		},
+		{
+			desc:     "detect SenML JSON message",
+			channel:  autoChannel,
+			subtopic: "sensors",
+			payload:  senmlPayload,
+			senml:    true,
+		},
+		{
+			desc:     "detect SenML CBOR message",
+			channel:  autoChannel,
+			subtopic: "sensors",
+			payload:  cborPayload,
+			senml:    true,
+		},
+		{
+			desc:     "detect JSON message",
+			channel:  autoChannel,
+			subtopic: "boiler",
+			payload:  jsonPayload,
+		},
+		{
+			desc:     "transform binary message",
+			channel:  binChannel,
+			subtopic: "sensors",
+			payload:  []byte{0x0c, 0xfe},
+			senml:    true,
+		},
+		{
+			desc:     "pass raw message on untransformed",
+			channel:  rawChannel,
+			subtopic: "sensors",
+			payload:  senmlPayload,
+			raw:      true,
+		},
+		{
+			desc:     "detect JSON array message",
+			channel:  autoChannel,
+			subtopic: "boiler",
+			payload:  []byte(`[{"temperature":42},{"temperature":43}]`),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg := &messaging.Message{
+				Domain:    domainID,
+				Channel:   tc.channel,
+				Subtopic:  tc.subtopic,
+				Publisher: "publisher",
+				Protocol:  "mqtt",
+				Payload:   tc.payload,
+			}
+			res, err := tr.Transform(msg)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			switch {
+			case tc.raw:
+				assert.Equal(t, msg, res, fmt.Sprintf("%s: expected raw message got %T", tc.desc, res))
+			case tc.senml:
+				assert.IsType(t, []senml.Message{}, res, fmt.Sprintf("%s: expected SenML messages got %T", tc.desc, res))
+			default:
+				assert.IsType(t, json.Messages{}, res, fmt.Sprintf("%s: expected JSON messages got %T", tc.desc, res))
+			}
+		})
+	}
+}
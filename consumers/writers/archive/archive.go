@@ -0,0 +1,538 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/consumers"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/ticker"
+	smqjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+const (
+	// ManifestFile is the name of the manifest in the archive directory.
+	ManifestFile = "manifest.ndjson"
+
+	senmlTable       = "senml"
+	jsonTable        = "json"
+	fileExt          = ".ndjson.gz"
+	inProgressExt    = ".inprogress"
+	dateFormat       = "2006-01-02"
+	defaultPartition = "unknown"
+)
+
+var (
+	errInvalidMessage = errors.New("invalid message representation")
+	errSaveMessage    = errors.New("failed to write message to archive")
+	errInvalidConfig  = errors.New("invalid archive configuration")
+	errClosed         = errors.New("archive is closed")
+	errRecover        = errors.New("failed to recover archive file")
+)
+
+// Config configures the archive files.
+type Config struct {
+	// Dir is the root directory of the archive.
+	Dir string `env:"DIR"             envDefault:"/archive"`
+	// MaxFileSize is the compressed size in bytes which completes a file.
+	MaxFileSize int64 `env:"MAX_FILE_SIZE"   envDefault:"67108864"`
+	// RotateInterval is the longest time a file stays open.
+	RotateInterval time.Duration `env:"ROTATE_INTERVAL" envDefault:"1h"`
+	// MaxOpenFiles bounds the files open at once. Opening one more file
+	// completes the oldest open one.
+	MaxOpenFiles int `env:"MAX_OPEN_FILES"  envDefault:"100"`
+}
+
+// File is the manifest entry of a completed archive file.
+type File struct {
+	// Path is the path of the file relative to the archive directory.
+	Path      string    `json:"path"`
+	Table     string    `json:"table"`
+	Domain    string    `json:"domain"`
+	Channel   string    `json:"channel"`
+	Date      string    `json:"date"`
+	Records   uint64    `json:"records"`
+	Size      int64     `json:"size"`
+	Created   time.Time `json:"created"`
+	Completed time.Time `json:"completed"`
+}
+
+// Archive is a BlockingConsumer which writes messages to archive files.
+type Archive interface {
+	consumers.BlockingConsumer
+
+	// Rotate completes the files which are open longer than the rotation
+	// interval at the given time.
+	Rotate(now time.Time) error
+
+	// Close completes all the open files. Messages consumed after Close
+	// are rejected.
+	Close() error
+}
+
+var _ Archive = (*archive)(nil)
+
+type partition struct {
+	table   string
+	domain  string
+	channel string
+	date    string
+}
+
+func (p partition) dir() string {
+	return filepath.Join(filepath.FromSlash(p.table), "domain="+p.domain, "channel="+p.channel, "date="+p.date)
+}
+
+type record struct {
+	part partition
+	data []byte
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(b []byte) (int, error) {
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	return n, err
+}
+
+type file struct {
+	part    partition
+	name    string
+	f       *os.File
+	cw      *countWriter
+	gz      *gzip.Writer
+	records uint64
+	created time.Time
+}
+
+type archive struct {
+	cfg    Config
+	mu     sync.Mutex
+	files  map[partition]*file
+	last   int64
+	closed bool
+}
+
+// New returns a new archive writing to the directory of the config. Files
+// left in progress by a previous run are completed with the records which
+// were fully written to them.
+func New(cfg Config) (Archive, error) {
+	if cfg.Dir == "" || cfg.MaxFileSize <= 0 || cfg.RotateInterval <= 0 || cfg.MaxOpenFiles < 1 {
+		return nil, errInvalidConfig
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	a := &archive{
+		cfg:   cfg,
+		files: make(map[partition]*file),
+	}
+	if err := a.recoverAll(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// ConsumeBlocking appends the messages to the files of their partitions.
+// The files are flushed and synced before it returns, so the acknowledged
+// messages are not lost if the writer stops.
+func (a *archive) ConsumeBlocking(_ context.Context, message any) error {
+	recs, err := records(message)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return errClosed
+	}
+
+	written := make(map[partition]*file)
+	for _, r := range recs {
+		f, err := a.file(r.part)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		if _, err := f.gz.Write(r.data); err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		f.records++
+		written[r.part] = f
+	}
+	for p, f := range written {
+		// The file is already completed if it was evicted by a newer one.
+		if a.files[p] != f {
+			continue
+		}
+		if err := f.flush(); err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		if f.cw.n >= a.cfg.MaxFileSize {
+			if err := a.complete(f, time.Now()); err != nil {
+				return errors.Wrap(errSaveMessage, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (a *archive) Rotate(now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var errs error
+	for _, f := range a.files {
+		if now.Sub(f.created) < a.cfg.RotateInterval {
+			continue
+		}
+		if err := a.complete(f, now); err != nil {
+			errs = errors.Wrap(err, errs)
+		}
+	}
+
+	return errs
+}
+
+func (a *archive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.closed = true
+	now := time.Now()
+	var errs error
+	for _, f := range a.files {
+		if err := a.complete(f, now); err != nil {
+			errs = errors.Wrap(err, errs)
+		}
+	}
+
+	return errs
+}
+
+// StartRotation rotates the files of the archive on every tick until the
+// context is done.
+func StartRotation(ctx context.Context, a Archive, tck ticker.Ticker, logger *slog.Logger) error {
+	defer tck.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-tck.Tick():
+			if err := a.Rotate(now); err != nil {
+				logger.Error(fmt.Sprintf("failed to rotate archive files: %s", err))
+			}
+		}
+	}
+}
+
+// file returns the open file of the partition, opening a new one if there
+// is none.
+func (a *archive) file(p partition) (*file, error) {
+	if f, ok := a.files[p]; ok {
+		return f, nil
+	}
+	if len(a.files) >= a.cfg.MaxOpenFiles {
+		var oldest *file
+		for _, f := range a.files {
+			if oldest == nil || f.created.Before(oldest.created) {
+				oldest = f
+			}
+		}
+		if err := a.complete(oldest, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	f, err := a.open(p)
+	if err != nil {
+		return nil, err
+	}
+	a.files[p] = f
+
+	return f, nil
+}
+
+// open creates a new file in the partition. The file is hidden, so the data
+// lake ignores it until it is completed.
+func (a *archive) open(p partition) (*file, error) {
+	dir := filepath.Join(a.cfg.Dir, p.dir())
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	created := time.Now()
+	// File names are unique, even if two files are opened at once.
+	id := created.UnixNano()
+	if id <= a.last {
+		id = a.last + 1
+	}
+	a.last = id
+	name := fmt.Sprintf("part-%d%s", id, fileExt)
+	f, err := os.OpenFile(filepath.Join(dir, "."+name+inProgressExt), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	cw := &countWriter{w: f}
+
+	return &file{
+		part:    p,
+		name:    name,
+		f:       f,
+		cw:      cw,
+		gz:      gzip.NewWriter(cw),
+		created: created,
+	}, nil
+}
+
+func (f *file) flush() error {
+	if err := f.gz.Flush(); err != nil {
+		return err
+	}
+
+	return f.f.Sync()
+}
+
+// complete closes the file, gives it its final name and adds it to the
+// manifest. The file is removed from the open files even if it fails, so
+// the next messages of its partition go to a new file.
+func (a *archive) complete(f *file, now time.Time) error {
+	delete(a.files, f.part)
+	if err := f.gz.Close(); err != nil {
+		f.f.Close()
+		return err
+	}
+	if err := f.f.Sync(); err != nil {
+		f.f.Close()
+		return err
+	}
+	if err := f.f.Close(); err != nil {
+		return err
+	}
+	rel := filepath.Join(f.part.dir(), f.name)
+	if err := os.Rename(f.f.Name(), filepath.Join(a.cfg.Dir, rel)); err != nil {
+		return err
+	}
+
+	return a.appendManifest(File{
+		Path:      filepath.ToSlash(rel),
+		Table:     f.part.table,
+		Domain:    f.part.domain,
+		Channel:   f.part.channel,
+		Date:      f.part.date,
+		Records:   f.records,
+		Size:      f.cw.n,
+		Created:   f.created.UTC(),
+		Completed: now.UTC(),
+	})
+}
+
+func (a *archive) appendManifest(entry File) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	m, err := os.OpenFile(filepath.Join(a.cfg.Dir, ManifestFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := m.Write(append(data, '\n')); err != nil {
+		m.Close()
+		return err
+	}
+	if err := m.Sync(); err != nil {
+		m.Close()
+		return err
+	}
+
+	return m.Close()
+}
+
+// recoverAll completes the files left in progress by a previous run.
+func (a *archive) recoverAll() error {
+	var paths []string
+	err := filepath.WalkDir(a.cfg.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), inProgressExt) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(errRecover, err)
+	}
+	for _, path := range paths {
+		if err := a.recover(path); err != nil {
+			return errors.Wrap(errRecover, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+
+	return nil
+}
+
+// recover copies the complete lines of the file in progress to a new file
+// and completes it. The file misses the gzip trailer and may end with a
+// partially written record, which was never acknowledged, so it is dropped.
+func (a *archive) recover(path string) error {
+	rel, err := filepath.Rel(a.cfg.Dir, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	p, err := parsePartition(rel)
+	if err != nil {
+		return err
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	var lines [][]byte
+	if zr, err := gzip.NewReader(src); err == nil {
+		r := bufio.NewReader(zr)
+		for {
+			line, err := r.ReadBytes('\n')
+			if err != nil {
+				break
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > 0 {
+		f, err := a.open(p)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			if _, err := f.gz.Write(line); err != nil {
+				f.gz.Close()
+				f.f.Close()
+				return err
+			}
+			f.records++
+		}
+		if err := a.complete(f, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return os.Remove(path)
+}
+
+func parsePartition(rel string) (partition, error) {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) < 4 {
+		return partition{}, fmt.Errorf("invalid partition directory %q", rel)
+	}
+	n := len(parts)
+	p := partition{table: strings.Join(parts[:n-3], "/")}
+	for i, key := range []string{"domain=", "channel=", "date="} {
+		v, ok := strings.CutPrefix(parts[n-3+i], key)
+		if !ok {
+			return partition{}, fmt.Errorf("invalid partition directory %q", rel)
+		}
+		switch i {
+		case 0:
+			p.domain = v
+		case 1:
+			p.channel = v
+		case 2:
+			p.date = v
+		}
+	}
+
+	return p, nil
+}
+
+// records encodes the messages to NDJSON lines along with their partitions.
+// SenML records are stored in the senml table and JSON messages in the
+// table of their format.
+func records(message any) ([]record, error) {
+	now := time.Now()
+	var recs []record
+	switch msgs := message.(type) {
+	case []senml.Message:
+		for _, m := range msgs {
+			t := now
+			if m.Time > 0 {
+				t = time.Unix(0, int64(m.Time))
+			}
+			data, err := json.Marshal(m)
+			if err != nil {
+				return nil, errors.Wrap(errSaveMessage, err)
+			}
+			recs = append(recs, record{
+				part: newPartition(senmlTable, m.Domain, m.Channel, t),
+				data: append(data, '\n'),
+			})
+		}
+	case smqjson.Messages:
+		table := jsonTable + "/" + partitionValue(msgs.Format)
+		for _, m := range msgs.Data {
+			t := now
+			if m.Created > 0 {
+				t = time.Unix(0, m.Created)
+			}
+			data, err := json.Marshal(m)
+			if err != nil {
+				return nil, errors.Wrap(errSaveMessage, err)
+			}
+			recs = append(recs, record{
+				part: newPartition(table, m.Domain, m.Channel, t),
+				data: append(data, '\n'),
+			})
+		}
+	default:
+		return nil, errors.Wrap(errSaveMessage, errInvalidMessage)
+	}
+
+	return recs, nil
+}
+
+func newPartition(table, domain, channel string, t time.Time) partition {
+	return partition{
+		table:   table,
+		domain:  partitionValue(domain),
+		channel: partitionValue(channel),
+		date:    t.UTC().Format(dateFormat),
+	}
+}
+
+// partitionValue makes the value safe to use as a directory name.
+func partitionValue(v string) string {
+	if v == "" {
+		return defaultPartition
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, v)
+}
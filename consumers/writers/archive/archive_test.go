@@ -0,0 +1,281 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package archive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/consumers/writers/archive"
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	tmocks "github.com/absmach/magistrala/pkg/ticker/mocks"
+	smqjson "github.com/absmach/magistrala/pkg/transformers/json"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	day1 = time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	day2 = day1.Add(24 * time.Hour)
+)
+
+func newConfig(t *testing.T) archive.Config {
+	return archive.Config{
+		Dir:            t.TempDir(),
+		MaxFileSize:    1 << 20,
+		RotateInterval: time.Hour,
+		MaxOpenFiles:   10,
+	}
+}
+
+func senmlMessage(domain, channel string, t time.Time, v float64) senml.Message {
+	return senml.Message{
+		Domain:    domain,
+		Channel:   channel,
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Name:      "temperature",
+		Unit:      "Cel",
+		Time:      float64(t.UnixNano()),
+		Value:     &v,
+	}
+}
+
+func readManifest(t *testing.T, dir string) []archive.File {
+	data, err := os.ReadFile(filepath.Join(dir, archive.ManifestFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.Nil(t, err, fmt.Sprintf("unexpected error reading manifest: %s", err))
+
+	var files []archive.File
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var f archive.File
+		require.Nil(t, dec.Decode(&f), "unexpected error decoding manifest")
+		files = append(files, f)
+	}
+
+	return files
+}
+
+func readFile[T any](t *testing.T, dir string, f archive.File) []T {
+	file, err := os.Open(filepath.Join(dir, filepath.FromSlash(f.Path)))
+	require.Nil(t, err, fmt.Sprintf("unexpected error opening %s: %s", f.Path, err))
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	require.Nil(t, err, fmt.Sprintf("unexpected error reading %s: %s", f.Path, err))
+
+	var recs []T
+	dec := json.NewDecoder(zr)
+	for dec.More() {
+		var r T
+		require.Nil(t, dec.Decode(&r), fmt.Sprintf("unexpected error decoding %s", f.Path))
+		recs = append(recs, r)
+	}
+
+	return recs
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	cfg := newConfig(t)
+	a, err := archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive: %s", err))
+
+	senmlMsgs := []senml.Message{
+		senmlMessage("domain", "channel", day1, 21.5),
+		senmlMessage("domain", "channel", day1.Add(time.Minute), 22),
+		senmlMessage("domain", "channel", day2, 23),
+		senmlMessage("", "other", day1, 24),
+	}
+	jsonMsgs := smqjson.Messages{
+		Format: "boiler",
+		Data: []smqjson.Message{
+			{Domain: "domain", Channel: "channel", Created: day1.UnixNano(), Payload: smqjson.Payload{"temperature": 42.0}},
+		},
+	}
+
+	cases := []struct {
+		desc string
+		msg  any
+		err  error
+	}{
+		{
+			desc: "consume SenML messages",
+			msg:  senmlMsgs,
+		},
+		{
+			desc: "consume JSON messages",
+			msg:  jsonMsgs,
+		},
+		{
+			desc: "consume invalid message",
+			msg:  &messaging.Message{Channel: "channel"},
+			err:  errors.New("invalid message representation"),
+		},
+	}
+
+	for _, tc := range cases {
+		err := a.ConsumeBlocking(context.Background(), tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	assert.Empty(t, readManifest(t, cfg.Dir), "expected no completed files before close")
+
+	err = a.Close()
+	require.Nil(t, err, fmt.Sprintf("unexpected error closing archive: %s", err))
+	err = a.ConsumeBlocking(context.Background(), senmlMsgs)
+	assert.NotNil(t, err, "expected error consuming after close")
+
+	files := map[string]archive.File{}
+	for _, f := range readManifest(t, cfg.Dir) {
+		files[filepath.Dir(f.Path)] = f
+	}
+	expected := map[string][]any{
+		"senml/domain=domain/channel=channel/date=2024-03-01":       {senmlMsgs[0], senmlMsgs[1]},
+		"senml/domain=domain/channel=channel/date=2024-03-02":       {senmlMsgs[2]},
+		"senml/domain=unknown/channel=other/date=2024-03-01":        {senmlMsgs[3]},
+		"json/boiler/domain=domain/channel=channel/date=2024-03-01": {jsonMsgs.Data[0]},
+	}
+	assert.Len(t, files, len(expected), "unexpected number of completed files")
+	for dir, recs := range expected {
+		f, ok := files[dir]
+		require.True(t, ok, fmt.Sprintf("expected completed file in %s", dir))
+		assert.Equal(t, uint64(len(recs)), f.Records, fmt.Sprintf("%s: unexpected number of records", dir))
+		info, err := os.Stat(filepath.Join(cfg.Dir, filepath.FromSlash(f.Path)))
+		require.Nil(t, err, fmt.Sprintf("unexpected error reading %s: %s", f.Path, err))
+		assert.Equal(t, info.Size(), f.Size, fmt.Sprintf("%s: unexpected file size", dir))
+		switch recs[0].(type) {
+		case senml.Message:
+			got := readFile[senml.Message](t, cfg.Dir, f)
+			for i, r := range recs {
+				assert.Equal(t, r, got[i], fmt.Sprintf("%s: unexpected record", dir))
+			}
+		case smqjson.Message:
+			got := readFile[smqjson.Message](t, cfg.Dir, f)
+			assert.Equal(t, recs[0], got[0], fmt.Sprintf("%s: unexpected record", dir))
+		}
+	}
+}
+
+func TestRotateBySize(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.MaxFileSize = 1
+	a, err := archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive: %s", err))
+
+	for i := range 3 {
+		err := a.ConsumeBlocking(context.Background(), []senml.Message{senmlMessage("domain", "channel", day1, float64(i))})
+		require.Nil(t, err, fmt.Sprintf("unexpected error consuming message: %s", err))
+	}
+
+	files := readManifest(t, cfg.Dir)
+	assert.Len(t, files, 3, "expected a completed file per message")
+	for _, f := range files {
+		assert.Equal(t, uint64(1), f.Records, "unexpected number of records")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	cfg := newConfig(t)
+	a, err := archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive: %s", err))
+	err = a.ConsumeBlocking(context.Background(), []senml.Message{senmlMessage("domain", "channel", day1, 1)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error consuming message: %s", err))
+
+	err = a.Rotate(time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected error rotating archive: %s", err))
+	assert.Empty(t, readManifest(t, cfg.Dir), "expected no completed files before rotation interval")
+
+	err = a.Rotate(time.Now().Add(cfg.RotateInterval))
+	require.Nil(t, err, fmt.Sprintf("unexpected error rotating archive: %s", err))
+	assert.Len(t, readManifest(t, cfg.Dir), 1, "expected completed file after rotation interval")
+}
+
+func TestMaxOpenFiles(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.MaxOpenFiles = 1
+	a, err := archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive: %s", err))
+
+	msgs := []senml.Message{
+		senmlMessage("domain", "channel", day1, 1),
+		senmlMessage("domain", "channel", day2, 2),
+	}
+	err = a.ConsumeBlocking(context.Background(), msgs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error consuming messages: %s", err))
+
+	files := readManifest(t, cfg.Dir)
+	require.Len(t, files, 1, "expected oldest file to be completed")
+	assert.Equal(t, "2024-03-01", files[0].Date, "unexpected completed file")
+}
+
+func TestRecover(t *testing.T) {
+	cfg := newConfig(t)
+	a, err := archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive: %s", err))
+	msgs := []senml.Message{
+		senmlMessage("domain", "channel", day1, 1),
+		senmlMessage("domain", "channel", day1, 2),
+	}
+	err = a.ConsumeBlocking(context.Background(), msgs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error consuming messages: %s", err))
+
+	// The first archive is never closed, as if the writer had crashed.
+	_, err = archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error recovering archive: %s", err))
+
+	files := readManifest(t, cfg.Dir)
+	require.Len(t, files, 1, "expected recovered file")
+	assert.Equal(t, uint64(len(msgs)), files[0].Records, "unexpected number of recovered records")
+	assert.Equal(t, msgs, readFile[senml.Message](t, cfg.Dir, files[0]), "unexpected recovered records")
+
+	partial, err := filepath.Glob(filepath.Join(cfg.Dir, "senml", "*", "*", "*", ".*"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error listing files: %s", err))
+	assert.Empty(t, partial, "expected no files in progress")
+}
+
+func TestNew(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.MaxOpenFiles = 0
+	_, err := archive.New(cfg)
+	assert.NotNil(t, err, "expected error creating archive with invalid config")
+}
+
+func TestStartRotation(t *testing.T) {
+	cfg := newConfig(t)
+	a, err := archive.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error creating archive: %s", err))
+	err = a.ConsumeBlocking(context.Background(), []senml.Message{senmlMessage("domain", "channel", day1, 1)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error consuming message: %s", err))
+
+	tck := new(tmocks.Ticker)
+	tickChan := make(chan time.Time)
+	tck.On("Tick").Return((<-chan time.Time)(tickChan))
+	tck.On("Stop").Return()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		errc <- archive.StartRotation(ctx, a, tck, mglog.NewMock())
+	}()
+
+	// The second tick is received only after the first one is handled.
+	tickChan <- time.Now().Add(cfg.RotateInterval)
+	tickChan <- time.Now().Add(cfg.RotateInterval)
+	cancel()
+	err = <-errc
+	assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("expected %s got %s\n", context.Canceled, err))
+	assert.Len(t, readManifest(t, cfg.Dir), 1, "expected completed file after rotation")
+	tck.AssertCalled(t, "Stop")
+}
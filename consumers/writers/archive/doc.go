@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package archive contains a writer which stores the consumed messages in
+// gzip compressed NDJSON files, partitioned by domain, channel and day, for
+// long-term retention and data lake ingestion.
+package archive
@@ -25,6 +25,14 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
+const (
+	// maxParams is the PostgreSQL limit of the parameters of a statement,
+	// which bounds the rows of a multi-row insert.
+	maxParams    = 65535
+	senmlColumns = 14
+	jsonColumns  = 7
+)
+
 var _ consumers.BlockingConsumer = (*postgresRepo)(nil)
 
 type postgresRepo struct {
@@ -74,13 +82,17 @@ func (pr postgresRepo) saveSenml(ctx context.Context, messages any) (err error)
 		}
 	}()
 
+	rows := make([]senmlMessage, 0, len(msgs))
 	for _, msg := range msgs {
 		id, err := uuid.NewV4()
 		if err != nil {
 			return err
 		}
-		m := senmlMessage{Message: msg, ID: id.String()}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		rows = append(rows, senmlMessage{Message: msg, ID: id.String()})
+	}
+
+	for _, chunk := range chunks(rows, maxParams/senmlColumns) {
+		if _, err := tx.NamedExecContext(ctx, q, chunk); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				if pgErr.Code == pgerrcode.InvalidTextRepresentation {
@@ -107,7 +119,7 @@ func (pr postgresRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) erro
 	return nil
 }
 
-func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) (err error) {
 	tx, err := pr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
@@ -129,14 +141,18 @@ func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) er
           VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
 	q = fmt.Sprintf(q, msgs.Format)
 
+	rows := make([]jsonMessage, 0, len(msgs.Data))
 	for _, m := range msgs.Data {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
+		rows = append(rows, dbmsg)
+	}
 
-		if _, err = tx.NamedExec(q, dbmsg); err != nil {
+	for _, chunk := range chunks(rows, maxParams/jsonColumns) {
+		if _, err = tx.NamedExecContext(ctx, q, chunk); err != nil {
 			if preErr, ok := err.(*pgconn.PrepareError); ok {
 				err = preErr.Unwrap()
 			}
@@ -172,6 +188,20 @@ func (pr postgresRepo) createTable(name string) error {
 	return err
 }
 
+// chunks splits the rows into multi-row inserts of at most size rows.
+func chunks[T any](rows []T, size int) [][]T {
+	var ret [][]T
+	for len(rows) > size {
+		ret = append(ret, rows[:size])
+		rows = rows[size:]
+	}
+	if len(rows) > 0 {
+		ret = append(ret, rows)
+	}
+
+	return ret
+}
+
 type senmlMessage struct {
 	senml.Message
 	ID string `db:"id"`
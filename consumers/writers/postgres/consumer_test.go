@@ -14,6 +14,7 @@ import (
 	"github.com/absmach/magistrala/pkg/transformers/senml"
 	"github.com/gofrs/uuid/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -110,3 +111,36 @@ func TestSaveJSON(t *testing.T) {
 	err = repo.ConsumeBlocking(context.TODO(), msgs)
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
+
+func TestSaveJSONCommitError(t *testing.T) {
+	repo := postgres.New(db)
+
+	// The deferred constraint is checked on commit, so the inserts succeed
+	// and the commit fails.
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS deferred_json (
+		id UUID, created BIGINT, channel VARCHAR(254), subtopic VARCHAR(254), publisher VARCHAR(254),
+		protocol TEXT, payload JSONB, UNIQUE (channel) DEFERRABLE INITIALLY DEFERRED
+	)`)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	chid, err := uuid.NewV4()
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	msg := json.Message{
+		Channel:  chid.String(),
+		Created:  time.Now().Unix(),
+		Protocol: "mqtt",
+		Payload:  map[string]any{"field_1": 123},
+	}
+	msgs := json.Messages{
+		Format: "deferred_json",
+		Data:   []json.Message{msg, msg},
+	}
+
+	err = repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.NotNil(t, err, "expected error committing messages which violate a deferred constraint")
+
+	var count int
+	err = db.Get(&count, "SELECT COUNT(*) FROM deferred_json")
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, 0, count, "expected no messages to be saved")
+}
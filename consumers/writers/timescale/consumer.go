@@ -27,6 +27,14 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
+const (
+	// maxParams is the PostgreSQL limit of the parameters of a statement,
+	// which bounds the rows of a multi-row insert.
+	maxParams    = 65535
+	senmlColumns = 13
+	jsonColumns  = 6
+)
+
 var _ consumers.BlockingConsumer = (*timescaleRepo)(nil)
 
 type timescaleRepo struct {
@@ -80,9 +88,13 @@ func (tr timescaleRepo) saveSenml(ctx context.Context, messages any) (err error)
 		}
 	}()
 
+	rows := make([]senmlMessage, 0, len(msgs))
 	for _, msg := range msgs {
-		m := senmlMessage{Message: msg}
-		if _, err := tx.NamedExec(q, m); err != nil {
+		rows = append(rows, senmlMessage{Message: msg})
+	}
+
+	for _, chunk := range chunks(rows, maxParams/senmlColumns) {
+		if _, err := tx.NamedExecContext(ctx, q, chunk); err != nil {
 			pgErr, ok := err.(*pgconn.PgError)
 			if ok {
 				if pgErr.Code == pgerrcode.InvalidTextRepresentation {
@@ -110,7 +122,7 @@ func (tr timescaleRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) err
 	return nil
 }
 
-func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) (err error) {
 	tx, err := tr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(errSaveMessage, err)
@@ -132,13 +144,18 @@ func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) e
           VALUES (:channel, :created, :subtopic, :publisher, :protocol, :payload);`
 	q = fmt.Sprintf(q, msgs.Format)
 
+	rows := make([]jsonMessage, 0, len(msgs.Data))
 	for _, m := range msgs.Data {
 		var dbmsg jsonMessage
 		dbmsg, err = toJSONMessage(m)
 		if err != nil {
 			return errors.Wrap(errSaveMessage, err)
 		}
-		if _, err = tx.NamedExec(q, dbmsg); err != nil {
+		rows = append(rows, dbmsg)
+	}
+
+	for _, chunk := range chunks(rows, maxParams/jsonColumns) {
+		if _, err = tx.NamedExecContext(ctx, q, chunk); err != nil {
 			if preErr, ok := err.(*pgconn.PrepareError); ok {
 				err = preErr.Unwrap()
 			}
@@ -173,6 +190,20 @@ func (tr timescaleRepo) createTable(name string) error {
 	return err
 }
 
+// chunks splits the rows into multi-row inserts of at most size rows.
+func chunks[T any](rows []T, size int) [][]T {
+	var ret [][]T
+	for len(rows) > size {
+		ret = append(ret, rows[:size])
+		rows = rows[size:]
+	}
+	if len(rows) > 0 {
+		ret = append(ret, rows)
+	}
+
+	return ret
+}
+
 type senmlMessage struct {
 	senml.Message
 }
@@ -45,6 +45,9 @@ type SubscriberConfig struct {
 	Handler        EventHandler
 	Ordered        bool
 	DeliveryPolicy messaging.DeliveryPolicy
+	// InactiveThreshold is the time after which the consumer is removed once
+	// it stops consuming. Zero keeps the consumer.
+	InactiveThreshold time.Duration
 }
 
 // Subscriber specifies event subscription API.
@@ -71,8 +71,9 @@ func (es *subEventStore) Subscribe(ctx context.Context, cfg events.SubscriberCon
 			handler: cfg.Handler,
 			ctx:     ctx,
 		},
-		DeliveryPolicy: cfg.DeliveryPolicy,
-		Ordered:        cfg.Ordered,
+		DeliveryPolicy:    cfg.DeliveryPolicy,
+		Ordered:           cfg.Ordered,
+		InactiveThreshold: cfg.InactiveThreshold,
 	}
 
 	return es.pubsub.Subscribe(ctx, subCfg)
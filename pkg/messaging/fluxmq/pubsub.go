@@ -109,11 +109,15 @@ func (ps *pubsub) Subscribe(_ context.Context, cfg messaging.SubscriberConfig) e
 			opts.Offset = "first"
 		}
 
-		if err := ps.client.SubscribeToStream(opts, func(msg *fluxamqp.QueueMessage) {
+		handle := func(msg *fluxamqp.QueueMessage) {
 			if err := ps.handle(cfg.Handler, msg); err != nil {
 				ps.logWarn("failed to process FluxMQ stream message", "error", err, "topic", cfg.Topic, "consumer_group", group)
 			}
-		}); err != nil {
+		}
+		if !cfg.Ordered && cfg.MaxInFlight > 1 {
+			handle = concurrentHandler(handle, cfg.MaxInFlight)
+		}
+		if err := ps.client.SubscribeToStream(opts, handle); err != nil {
 			return err
 		}
 
@@ -175,6 +179,19 @@ func (ps *pubsub) Unsubscribe(_ context.Context, id, topic string) error {
 	return errors.Join(streamErr, topicErr)
 }
 
+// concurrentHandler handles at most n stream messages at once, each in its
+// own goroutine, and blocks the delivery of further messages until one is done.
+func concurrentHandler(h func(msg *fluxamqp.QueueMessage), n int) func(msg *fluxamqp.QueueMessage) {
+	sem := make(chan struct{}, n)
+	return func(msg *fluxamqp.QueueMessage) {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			h(msg)
+		}()
+	}
+}
+
 func (ps *pubsub) handleTopicMessage(h messaging.MessageHandler, msg *fluxamqp.Message) error {
 	mqttTopic := fluxtopics.AMQPTopicToMQTT(msg.Topic)
 	m, err := messageFromDelivery(msg.Body, msg.Headers, msg.Timestamp, ps.prefix, mqttTopic)
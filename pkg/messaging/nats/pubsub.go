@@ -85,15 +85,20 @@ func (ps *pubsub) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig)
 
 	natsTopic := toNATSTopic(cfg.Topic)
 	consumerConfig := jetstream.ConsumerConfig{
-		Name:          formatConsumerName(cfg.Topic, cfg.ID),
-		Durable:       formatConsumerName(cfg.Topic, cfg.ID),
-		Description:   fmt.Sprintf("Magistrala consumer of id %s for cfg.Topic %s", cfg.ID, cfg.Topic),
-		DeliverPolicy: jetstream.DeliverNewPolicy,
-		FilterSubject: natsTopic,
+		Name:              formatConsumerName(cfg.Topic, cfg.ID),
+		Durable:           formatConsumerName(cfg.Topic, cfg.ID),
+		Description:       fmt.Sprintf("Magistrala consumer of id %s for cfg.Topic %s", cfg.ID, cfg.Topic),
+		DeliverPolicy:     jetstream.DeliverNewPolicy,
+		FilterSubject:     natsTopic,
+		InactiveThreshold: cfg.InactiveThreshold,
 	}
 
-	if cfg.Ordered {
+	switch {
+	case cfg.Ordered:
 		consumerConfig.MaxAckPending = 1
+	case cfg.MaxInFlight > 1:
+		consumerConfig.MaxAckPending = cfg.MaxInFlight
+		nh = concurrentHandler(nh, cfg.MaxInFlight)
 	}
 
 	switch cfg.DeliveryPolicy {
@@ -171,6 +176,19 @@ func (ps *pubsub) natsHandler(h messaging.MessageHandler) func(m jetstream.Msg)
 	}
 }
 
+// concurrentHandler handles at most n messages at once, each in its own
+// goroutine, and blocks the delivery of further messages until one is done.
+func concurrentHandler(h func(m jetstream.Msg), n int) func(m jetstream.Msg) {
+	sem := make(chan struct{}, n)
+	return func(m jetstream.Msg) {
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem }()
+			h(m)
+		}()
+	}
+}
+
 func (ps *pubsub) errAckType(err error) messaging.AckType {
 	if err == nil {
 		return messaging.Ack
@@ -6,6 +6,7 @@ package messaging
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type DeliveryPolicy uint8
@@ -77,6 +78,13 @@ type SubscriberConfig struct {
 	Handler        MessageHandler // Function that handles incoming messages.
 	DeliveryPolicy DeliveryPolicy // DeliverPolicy defines from which point to start delivering messages.
 	Ordered        bool           // Whether message delivery must preserve order.
+	// MaxInFlight is the number of messages handled concurrently. Values
+	// below 2 handle one message at a time. It is ignored for ordered delivery.
+	MaxInFlight int
+	// InactiveThreshold is the time after which the subscription is removed
+	// once it stops consuming, for subscriptions unique to a service instance.
+	// Zero keeps the subscription until it is unsubscribed.
+	InactiveThreshold time.Duration
 }
 
 // Subscriber specifies message subscription API.
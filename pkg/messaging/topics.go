@@ -450,3 +450,24 @@ func ParseTopic(topic string) (domainID, chanID, subtopic string, topicType Topi
 
 	return domainID, chanID, subtopic, MessageType, nil
 }
+
+// MatchTopic matches a published topic against a subscription pattern
+// using MQTT-style wildcards: + (single level) and # (multi-level).
+func MatchTopic(published, subscribed string) bool {
+	p := strings.Split(published, subtopicSep)
+	s := strings.Split(subscribed, subtopicSep)
+	n := len(p)
+
+	for i := range s {
+		if s[i] == "#" {
+			return true
+		}
+		if i >= n {
+			return false
+		}
+		if s[i] != "+" && p[i] != s[i] {
+			return false
+		}
+	}
+	return len(s) == n
+}
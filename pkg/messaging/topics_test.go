@@ -0,0 +1,29 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import "testing"
+
+func TestMatchTopic(t *testing.T) {
+	cases := []struct {
+		published  string
+		subscribed string
+		match      bool
+	}{
+		{"writers/d/c/ch/a/b", "writers/#", true},
+		{"writers/d/c/ch/a/b", "writers/+/c/ch/a/+", true},
+		{"writers/d/c/ch/a/b", "writers/+/c/ch/a", false},
+		{"writers/d/c/ch", "writers/+/c/ch/a", false},
+		{"writers/d/c/other/a", "writers/+/c/ch/#", false},
+		{"temperature", "temperature", true},
+		{"temperature/room", "temperature", false},
+		{"", "#", true},
+	}
+
+	for _, tc := range cases {
+		if got := MatchTopic(tc.published, tc.subscribed); got != tc.match {
+			t.Errorf("%s matching %s: expected %t got %t", tc.published, tc.subscribed, tc.match, got)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package netguard keeps the HTTP requests to user supplied URLs away from
+// loopback, private and link-local addresses, such as the service network
+// and the cloud metadata endpoints.
+package netguard
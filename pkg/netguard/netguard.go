@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package netguard
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+var (
+	// ErrPrivateAddress indicates a loopback, private or link-local address.
+	ErrPrivateAddress = errors.New("address is loopback, private or link-local")
+
+	// ErrResolveHost indicates a host which failed to resolve.
+	ErrResolveHost = errors.New("failed to resolve host")
+)
+
+// Private reports whether the IP is a loopback, private, unspecified or
+// link-local address.
+func Private(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
+
+// Control is a net.Dialer control function which rejects the connections
+// to private addresses, once the host is resolved. It covers the hosts
+// which resolve or redirect to private addresses after they were validated.
+func Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || Private(ip) {
+		return ErrPrivateAddress
+	}
+
+	return nil
+}
+
+// Transport returns a copy of the default HTTP transport which fails to
+// connect to private addresses.
+func Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	dialer := &net.Dialer{Control: Control}
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+// ValidateHost resolves the host and returns an error if any of its
+// addresses is private.
+func ValidateHost(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return errors.Wrap(ErrResolveHost, err)
+	}
+	for _, addr := range addrs {
+		if Private(addr.IP) {
+			return ErrPrivateAddress
+		}
+	}
+
+	return nil
+}
+
+// PrivateHost reports whether the host is a private IP address or localhost,
+// without resolving it.
+func PrivateHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return Private(ip)
+	}
+
+	return host == "localhost"
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package netguard_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/netguard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControl(t *testing.T) {
+	cases := []struct {
+		desc    string
+		address string
+		err     error
+	}{
+		{desc: "public address", address: "93.184.215.14:443"},
+		{desc: "loopback address", address: "127.0.0.1:80", err: netguard.ErrPrivateAddress},
+		{desc: "private address", address: "192.168.1.10:80", err: netguard.ErrPrivateAddress},
+		{desc: "link-local address", address: "169.254.169.254:80", err: netguard.ErrPrivateAddress},
+		{desc: "unspecified address", address: "0.0.0.0:80", err: netguard.ErrPrivateAddress},
+		{desc: "IPv6 loopback address", address: "[::1]:80", err: netguard.ErrPrivateAddress},
+		{desc: "IPv6 unique local address", address: "[fd00::1]:80", err: netguard.ErrPrivateAddress},
+		{desc: "host name", address: "localhost:80", err: netguard.ErrPrivateAddress},
+	}
+
+	for _, tc := range cases {
+		err := netguard.Control("tcp", tc.address, nil)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
+	}
+}
+
+func TestPrivateHost(t *testing.T) {
+	cases := []struct {
+		host    string
+		private bool
+	}{
+		{host: "example.com"},
+		{host: "8.8.8.8"},
+		{host: "localhost", private: true},
+		{host: "127.0.0.1", private: true},
+		{host: "10.1.2.3", private: true},
+		{host: "::1", private: true},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.private, netguard.PrivateHost(tc.host), fmt.Sprintf("%s: unexpected result", tc.host))
+	}
+}
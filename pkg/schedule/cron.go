@@ -0,0 +1,229 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schedule
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// maxCronYears limits the search for the next run of expressions which rarely or never match, such as "0 0 30 2 *".
+const maxCronYears = 5
+
+var ErrInvalidCron = errors.NewRequestError("invalid cron expression")
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	// 7 is accepted as Sunday and folded to 0.
+	{name: "day of week", min: 0, max: 7, names: dayNames},
+}
+
+// cron is a parsed five field cron expression: minute, hour, day of month,
+// month and day of week. Each field is a bit set of the matching values.
+type cron struct {
+	minute, hour, dom, month, dow uint64
+	// If both day fields are restricted, a day matches either of them, as in Vixie cron.
+	domStar, dowStar bool
+}
+
+// parseCron parses the standard cron expression. Fields support lists (1,2),
+// ranges (1-5), steps (*/15, 8-18/2), month and day names, and the
+// @yearly, @monthly, @weekly, @daily and @hourly macros.
+func parseCron(expr string) (cron, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return cron{}, errors.Wrap(ErrInvalidCron, fmt.Errorf("expected %d fields, got %d", len(cronFields), len(parts)))
+	}
+	sets := make([]uint64, len(parts))
+	for i, part := range parts {
+		set, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return cron{}, errors.Wrap(ErrInvalidCron, err)
+		}
+		sets[i] = set
+	}
+	// Sunday can be either 0 or 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return cron{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: parts[2] == "*" || parts[2] == "?",
+		dowStar: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepStr)
+			if err != nil || s < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepStr)
+			}
+			step = s
+		}
+		var lo, hi int
+		switch {
+		case rng == "*" || rng == "?":
+			lo, hi = f.min, f.max
+		case strings.Contains(rng, "-"):
+			loStr, hiStr, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = cronValue(loStr, f); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(hiStr, f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rng)
+			}
+		default:
+			v, err := cronValue(rng, f)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			// A single value with a step, such as 5/15, runs until the end of the range.
+			if hasStep {
+				hi = f.max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func cronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s value %q", f.name, s)
+	}
+
+	return v, nil
+}
+
+func (c cron) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// next returns the first time after the given time which matches the expression
+// in the location. Wall clock times skipped by a DST transition don't run, and
+// times repeated by a DST transition run only once, at the first occurrence.
+// The zero time is returned if there is no such time in the next years.
+func (c cron) next(after time.Time, loc *time.Location) time.Time {
+	a := after.In(loc)
+	// Walk over the wall clock as UTC, which has no DST transitions.
+	wall := time.Date(a.Year(), a.Month(), a.Day(), a.Hour(), a.Minute(), 0, 0, time.UTC).Add(time.Minute)
+	limit := wall.AddDate(maxCronYears, 0, 0)
+
+	for wall.Before(limit) {
+		switch {
+		case c.month&(1<<uint(wall.Month())) == 0:
+			wall = time.Date(wall.Year(), wall.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchDay(wall):
+			wall = time.Date(wall.Year(), wall.Month(), wall.Day()+1, 0, 0, 0, 0, time.UTC)
+		case c.hour&(1<<uint(wall.Hour())) == 0:
+			wall = wall.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<uint(wall.Minute())) == 0:
+			wall = c.nextMinute(wall)
+		default:
+			if t, ok := instant(wall, after, loc); ok {
+				return t
+			}
+			wall = wall.Add(time.Minute)
+		}
+	}
+
+	return time.Time{}
+}
+
+// instant returns the first instant after the given time with the wall clock
+// time in the location. A wall clock time doesn't exist if it's skipped by a DST
+// transition, and exists twice if it's repeated by a DST transition.
+func instant(wall, after time.Time, loc *time.Location) (time.Time, bool) {
+	var first time.Time
+	// Zone offsets can only differ around a transition, which is never a day long.
+	for _, around := range []time.Time{wall.AddDate(0, 0, -1), wall.AddDate(0, 0, 1)} {
+		_, offset := around.In(loc).Zone()
+		t := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		if t.Hour() != wall.Hour() || t.Minute() != wall.Minute() || !t.After(after) {
+			continue
+		}
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+
+	return first, !first.IsZero()
+}
+
+// nextMinute jumps to the next matching minute of the hour, or to the next hour.
+func (c cron) nextMinute(wall time.Time) time.Time {
+	rest := c.minute >> uint(wall.Minute()+1)
+	if rest == 0 {
+		return wall.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	return wall.Add(time.Duration(bits.TrailingZeros64(rest)+1) * time.Minute)
+}
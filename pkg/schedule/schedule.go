@@ -6,6 +6,7 @@ package schedule
 import (
 	"encoding/json"
 	"time"
+	_ "time/tzdata" // Embed timezone database
 
 	"github.com/absmach/magistrala/pkg/errors"
 )
@@ -16,14 +17,17 @@ const (
 	dailyType   = "daily"
 	weeklyType  = "weekly"
 	monthlyType = "monthly"
+	cronType    = "cron"
 )
 
 var (
 	ErrInvalidRecurringType = errors.NewRequestError("invalid recurring type")
 	ErrStartDateTimeInPast  = errors.NewRequestError("start_datetime must be greater than or equal to current time")
+	ErrInvalidTimezone      = errors.NewRequestError("invalid timezone")
+	ErrCronNeverDue         = errors.NewRequestError("cron expression never matches")
 )
 
-// Type can be daily, weekly or monthly.
+// Type can be hourly, daily, weekly, monthly or a cron expression.
 type Recurring uint
 
 const (
@@ -32,6 +36,7 @@ const (
 	Daily
 	Weekly
 	Monthly
+	Cron
 )
 
 func (rt Recurring) String() string {
@@ -44,6 +49,8 @@ func (rt Recurring) String() string {
 		return weeklyType
 	case Monthly:
 		return monthlyType
+	case Cron:
+		return cronType
 	default:
 		return noneType
 	}
@@ -68,6 +75,8 @@ func (rt *Recurring) UnmarshalJSON(data []byte) error {
 		*rt = Weekly
 	case monthlyType:
 		*rt = Monthly
+	case cronType:
+		*rt = Cron
 	case noneType:
 		*rt = None
 	default:
@@ -79,8 +88,10 @@ func (rt *Recurring) UnmarshalJSON(data []byte) error {
 type Schedule struct {
 	StartDateTime   time.Time `json:"start_datetime,omitempty"`   // When the schedule becomes active
 	Time            time.Time `json:"time,omitempty"`             // Specific time for the rule to run
-	Recurring       Recurring `json:"recurring,omitempty"`        // None, Daily, Weekly, Monthly
+	Recurring       Recurring `json:"recurring,omitempty"`        // None, Hourly, Daily, Weekly, Monthly, Cron
 	RecurringPeriod uint      `json:"recurring_period,omitempty"` // Controls how many intervals to skip between executions: 1 = every interval, 2 = every second interval, etc.
+	Cron            string    `json:"cron,omitempty"`             // Cron expression for the Cron recurring type, such as "30 8 * * 1-5"
+	Timezone        string    `json:"timezone,omitempty"`         // IANA timezone of the recurrence, such as "Europe/Berlin"; defaults to UTC
 }
 
 func (s Schedule) Validate() error {
@@ -90,9 +101,35 @@ func (s Schedule) Validate() error {
 			return ErrStartDateTimeInPast
 		}
 	}
+	loc, err := s.location()
+	if err != nil {
+		return err
+	}
+	if s.Recurring == Cron {
+		c, err := parseCron(s.Cron)
+		if err != nil {
+			return err
+		}
+		if c.next(time.Now(), loc).IsZero() {
+			return ErrCronNeverDue
+		}
+	}
 	return nil
 }
 
+// location returns the schedule timezone.
+func (s Schedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidTimezone, err)
+	}
+
+	return loc, nil
+}
+
 func (s Schedule) MarshalJSON() ([]byte, error) {
 	type Alias Schedule
 	jTimes := struct {
@@ -141,19 +178,60 @@ func (s *Schedule) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// NextDue returns the due time following the current one. Daily, weekly and monthly
+// schedules keep the wall clock time in the schedule timezone across DST changes.
+// The zero time is returned for schedules which are not recurring or have an
+// invalid cron expression or timezone.
 func (s Schedule) NextDue() time.Time {
+	loc, err := s.location()
+	if err != nil {
+		return time.Time{}
+	}
+	t := s.Time.In(loc)
+	var next time.Time
 	switch s.Recurring {
 	case Hourly:
-		return s.Time.Add(time.Hour * time.Duration(s.RecurringPeriod))
+		next = t.Add(time.Hour * time.Duration(s.RecurringPeriod))
 	case Daily:
-		return s.Time.AddDate(0, 0, int(s.RecurringPeriod))
+		next = t.AddDate(0, 0, int(s.RecurringPeriod))
 	case Weekly:
-		return s.Time.AddDate(0, 0, int(s.RecurringPeriod)*7)
+		next = t.AddDate(0, 0, int(s.RecurringPeriod)*7)
 	case Monthly:
-		return s.Time.AddDate(0, int(s.RecurringPeriod), 0)
+		next = t.AddDate(0, int(s.RecurringPeriod), 0)
+	case Cron:
+		c, err := parseCron(s.Cron)
+		if err != nil {
+			return time.Time{}
+		}
+		next = c.next(s.Time, loc)
 	default:
 		return time.Time{}
 	}
+
+	return next.In(s.Time.Location())
+}
+
+// FirstDue returns the first due time of the schedule. Cron schedules are first
+// due at the first time matching the expression at or after the start time, and
+// other schedules are due at the start time.
+func (s Schedule) FirstDue() time.Time {
+	if s.Recurring != Cron {
+		return s.StartDateTime
+	}
+	start := s.StartDateTime
+	if start.IsZero() {
+		start = time.Now().UTC()
+	}
+	loc, err := s.location()
+	if err != nil {
+		return start
+	}
+	c, err := parseCron(s.Cron)
+	if err != nil {
+		return start
+	}
+
+	return c.next(start.Add(-time.Nanosecond), loc).In(start.Location())
 }
 
 // EventEncode converts a schedule.Schedule struct to map[string]any.
@@ -168,5 +246,11 @@ func (s Schedule) EventEncode() map[string]any {
 	if !s.Time.IsZero() {
 		m["time"] = s.Time.Format(time.RFC3339)
 	}
+	if s.Cron != "" {
+		m["cron"] = s.Cron
+	}
+	if s.Timezone != "" {
+		m["timezone"] = s.Timezone
+	}
 	return m
 }
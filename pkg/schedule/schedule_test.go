@@ -0,0 +1,268 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schedule_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/schedule"
+	"github.com/stretchr/testify/assert"
+)
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNextDue(t *testing.T) {
+	cases := []struct {
+		desc     string
+		schedule schedule.Schedule
+		next     time.Time
+	}{
+		{
+			desc:     "not recurring",
+			schedule: schedule.Schedule{Time: utc("2026-03-27T07:30:00Z")},
+			next:     time.Time{},
+		},
+		{
+			desc:     "hourly",
+			schedule: schedule.Schedule{Time: utc("2026-03-27T07:30:00Z"), Recurring: schedule.Hourly, RecurringPeriod: 2},
+			next:     utc("2026-03-27T09:30:00Z"),
+		},
+		{
+			desc:     "daily in UTC",
+			schedule: schedule.Schedule{Time: utc("2026-03-28T07:00:00Z"), Recurring: schedule.Daily, RecurringPeriod: 1},
+			next:     utc("2026-03-29T07:00:00Z"),
+		},
+		{
+			desc:     "daily across DST start keeps the wall clock time",
+			schedule: schedule.Schedule{Time: utc("2026-03-28T07:00:00Z"), Recurring: schedule.Daily, RecurringPeriod: 1, Timezone: "Europe/Berlin"},
+			next:     utc("2026-03-29T06:00:00Z"),
+		},
+		{
+			desc:     "weekly across DST end keeps the wall clock time",
+			schedule: schedule.Schedule{Time: utc("2026-10-20T06:00:00Z"), Recurring: schedule.Weekly, RecurringPeriod: 1, Timezone: "Europe/Berlin"},
+			next:     utc("2026-10-27T07:00:00Z"),
+		},
+		{
+			desc:     "monthly in timezone",
+			schedule: schedule.Schedule{Time: utc("2026-01-31T23:00:00Z"), Recurring: schedule.Monthly, RecurringPeriod: 1, Timezone: "Europe/Berlin"},
+			next:     utc("2026-02-28T23:00:00Z"),
+		},
+		{
+			desc:     "cron on weekdays across a weekend with DST start",
+			schedule: schedule.Schedule{Time: utc("2026-03-27T07:30:00Z"), Recurring: schedule.Cron, Cron: "30 8 * * 1-5", Timezone: "Europe/Berlin"},
+			next:     utc("2026-03-30T06:30:00Z"),
+		},
+		{
+			desc:     "cron every 15 minutes during business hours",
+			schedule: schedule.Schedule{Time: utc("2026-03-24T09:15:00Z"), Recurring: schedule.Cron, Cron: "*/15 9-17 * * MON-FRI"},
+			next:     utc("2026-03-24T09:30:00Z"),
+		},
+		{
+			desc:     "cron every 15 minutes after business hours",
+			schedule: schedule.Schedule{Time: utc("2026-03-27T17:45:00Z"), Recurring: schedule.Cron, Cron: "*/15 9-17 * * MON-FRI"},
+			next:     utc("2026-03-30T09:00:00Z"),
+		},
+		{
+			desc:     "cron at a time skipped by DST start",
+			schedule: schedule.Schedule{Time: utc("2026-03-28T01:30:00Z"), Recurring: schedule.Cron, Cron: "30 2 * * *", Timezone: "Europe/Berlin"},
+			next:     utc("2026-03-30T00:30:00Z"),
+		},
+		{
+			desc:     "cron at a time repeated by DST end runs at the first occurrence",
+			schedule: schedule.Schedule{Time: utc("2026-10-24T00:30:00Z"), Recurring: schedule.Cron, Cron: "30 2 * * *", Timezone: "Europe/Berlin"},
+			next:     utc("2026-10-25T00:30:00Z"),
+		},
+		{
+			desc:     "cron at a time repeated by DST end runs once",
+			schedule: schedule.Schedule{Time: utc("2026-10-25T00:30:00Z"), Recurring: schedule.Cron, Cron: "30 2 * * *", Timezone: "Europe/Berlin"},
+			next:     utc("2026-10-26T01:30:00Z"),
+		},
+		{
+			desc:     "cron every 30 minutes during the hour repeated by DST end",
+			schedule: schedule.Schedule{Time: utc("2026-11-01T05:30:00Z"), Recurring: schedule.Cron, Cron: "*/30 * * * *", Timezone: "America/New_York"},
+			next:     utc("2026-11-01T07:00:00Z"),
+		},
+		{
+			desc:     "cron with day of month or day of week",
+			schedule: schedule.Schedule{Time: utc("2026-03-01T00:00:00Z"), Recurring: schedule.Cron, Cron: "0 0 13 * 5"},
+			next:     utc("2026-03-06T00:00:00Z"),
+		},
+		{
+			desc:     "cron with a macro",
+			schedule: schedule.Schedule{Time: utc("2026-03-01T10:00:00Z"), Recurring: schedule.Cron, Cron: "@monthly"},
+			next:     utc("2026-04-01T00:00:00Z"),
+		},
+		{
+			desc:     "cron with Sunday as 7",
+			schedule: schedule.Schedule{Time: utc("2026-03-24T00:00:00Z"), Recurring: schedule.Cron, Cron: "0 12 * * 7"},
+			next:     utc("2026-03-29T12:00:00Z"),
+		},
+		{
+			desc:     "cron on February 29th",
+			schedule: schedule.Schedule{Time: utc("2026-03-01T00:00:00Z"), Recurring: schedule.Cron, Cron: "0 0 29 2 *"},
+			next:     utc("2028-02-29T00:00:00Z"),
+		},
+		{
+			desc:     "cron never matching",
+			schedule: schedule.Schedule{Time: utc("2026-03-01T00:00:00Z"), Recurring: schedule.Cron, Cron: "0 0 30 2 *"},
+			next:     time.Time{},
+		},
+		{
+			desc:     "invalid cron",
+			schedule: schedule.Schedule{Time: utc("2026-03-01T00:00:00Z"), Recurring: schedule.Cron, Cron: "* * *"},
+			next:     time.Time{},
+		},
+		{
+			desc:     "invalid timezone",
+			schedule: schedule.Schedule{Time: utc("2026-03-01T00:00:00Z"), Recurring: schedule.Daily, RecurringPeriod: 1, Timezone: "Mars/Olympus"},
+			next:     time.Time{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			next := tc.schedule.NextDue()
+			assert.True(t, tc.next.Equal(next), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.next, next))
+		})
+	}
+}
+
+func TestFirstDue(t *testing.T) {
+	cases := []struct {
+		desc     string
+		schedule schedule.Schedule
+		first    time.Time
+	}{
+		{
+			desc:     "daily starts at the start time",
+			schedule: schedule.Schedule{StartDateTime: utc("2026-03-24T09:07:00Z"), Recurring: schedule.Daily, RecurringPeriod: 1},
+			first:    utc("2026-03-24T09:07:00Z"),
+		},
+		{
+			desc:     "cron starts at the first matching time",
+			schedule: schedule.Schedule{StartDateTime: utc("2026-03-24T09:07:30Z"), Recurring: schedule.Cron, Cron: "*/15 * * * *"},
+			first:    utc("2026-03-24T09:15:00Z"),
+		},
+		{
+			desc:     "cron starts at the start time matching the expression",
+			schedule: schedule.Schedule{StartDateTime: utc("2026-03-24T09:15:00Z"), Recurring: schedule.Cron, Cron: "*/15 * * * *"},
+			first:    utc("2026-03-24T09:15:00Z"),
+		},
+		{
+			desc:     "cron starts at the first matching time in timezone",
+			schedule: schedule.Schedule{StartDateTime: utc("2026-03-24T09:00:00Z"), Recurring: schedule.Cron, Cron: "30 8 * * *", Timezone: "Europe/Berlin"},
+			first:    utc("2026-03-25T07:30:00Z"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			first := tc.schedule.FirstDue()
+			assert.True(t, tc.first.Equal(first), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.first, first))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc     string
+		schedule schedule.Schedule
+		err      error
+	}{
+		{
+			desc:     "valid cron schedule",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "30 8 * * mon-fri", Timezone: "Europe/Berlin"},
+		},
+		{
+			desc:     "valid cron schedule with lists and steps",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "0,30 8-18/2 1,15 jan-jun ?"},
+		},
+		{
+			desc:     "cron with too many fields",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "0 30 8 * * *"},
+			err:      schedule.ErrInvalidCron,
+		},
+		{
+			desc:     "cron with value out of range",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "60 * * * *"},
+			err:      schedule.ErrInvalidCron,
+		},
+		{
+			desc:     "cron with invalid step",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "*/0 * * * *"},
+			err:      schedule.ErrInvalidCron,
+		},
+		{
+			desc:     "cron with reversed range",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "* 18-8 * * *"},
+			err:      schedule.ErrInvalidCron,
+		},
+		{
+			desc:     "empty cron",
+			schedule: schedule.Schedule{Recurring: schedule.Cron},
+			err:      schedule.ErrInvalidCron,
+		},
+		{
+			desc:     "cron never matching",
+			schedule: schedule.Schedule{Recurring: schedule.Cron, Cron: "0 0 31 4 *"},
+			err:      schedule.ErrCronNeverDue,
+		},
+		{
+			desc:     "invalid timezone",
+			schedule: schedule.Schedule{Recurring: schedule.Daily, RecurringPeriod: 1, Timezone: "Mars/Olympus"},
+			err:      schedule.ErrInvalidTimezone,
+		},
+		{
+			desc:     "start in the past",
+			schedule: schedule.Schedule{StartDateTime: time.Now().Add(-time.Hour)},
+			err:      schedule.ErrStartDateTimeInPast,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.schedule.Validate()
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestScheduleJSON(t *testing.T) {
+	sch := schedule.Schedule{
+		StartDateTime: utc("2026-03-24T09:00:00Z"),
+		Time:          utc("2026-03-24T09:15:00Z"),
+		Recurring:     schedule.Cron,
+		Cron:          "*/15 9-17 * * 1-5",
+		Timezone:      "Europe/Berlin",
+	}
+	data, err := json.Marshal(sch)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	var m map[string]any
+	err = json.Unmarshal(data, &m)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, "cron", m["recurring"])
+	assert.Equal(t, sch.Cron, m["cron"])
+	assert.Equal(t, sch.Timezone, m["timezone"])
+
+	var decoded schedule.Schedule
+	err = json.Unmarshal(data, &decoded)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, sch, decoded)
+
+	ev := sch.EventEncode()
+	assert.Equal(t, "cron", ev["recurring"])
+	assert.Equal(t, sch.Cron, ev["cron"])
+	assert.Equal(t, sch.Timezone, ev["timezone"])
+}
@@ -24,6 +24,7 @@ type Alarm struct {
 	ClientID       string    `json:"client_id,omitempty"`
 	Subtopic       string    `json:"subtopic,omitempty"`
 	Status         string    `json:"status,omitempty"`
+	State          string    `json:"state,omitempty"`
 	Measurement    string    `json:"measurement,omitempty"`
 	Value          string    `json:"value,omitempty"`
 	Unit           string    `json:"unit,omitempty"`
@@ -40,6 +41,10 @@ type Alarm struct {
 	AcknowledgedBy string    `json:"acknowledged_by,omitempty"`
 	ResolvedAt     time.Time `json:"resolved_at,omitempty"`
 	ResolvedBy     string    `json:"resolved_by,omitempty"`
+	ClearedAt      time.Time `json:"cleared_at,omitempty"`
+	ShelvedUntil   time.Time `json:"shelved_until,omitempty"`
+	ShelvedAt      time.Time `json:"shelved_at,omitempty"`
+	ShelvedBy      string    `json:"shelved_by,omitempty"`
 	Metadata       Metadata  `json:"metadata,omitempty"`
 }
 
@@ -50,6 +55,38 @@ type AlarmsPage struct {
 	Alarms []Alarm `json:"alarms"`
 }
 
+// AlarmActivity is an entry of the activity timeline of an alarm.
+type AlarmActivity struct {
+	ID        string         `json:"id,omitempty"`
+	AlarmID   string         `json:"alarm_id,omitempty"`
+	DomainID  string         `json:"domain_id,omitempty"`
+	Type      string         `json:"type,omitempty"`
+	Content   string         `json:"content,omitempty"`
+	Links     []AlarmLink    `json:"links,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+	CreatedAt time.Time      `json:"created_at,omitempty"`
+	CreatedBy string         `json:"created_by,omitempty"`
+}
+
+// AlarmLink is a reference attached to an alarm comment.
+type AlarmLink struct {
+	Title string `json:"title,omitempty"`
+	URL   string `json:"url"`
+}
+
+// AlarmComment is a comment to add to an alarm.
+type AlarmComment struct {
+	Content string      `json:"content"`
+	Links   []AlarmLink `json:"links,omitempty"`
+}
+
+type AlarmActivitiesPage struct {
+	Offset     uint64          `json:"offset"`
+	Limit      uint64          `json:"limit"`
+	Total      uint64          `json:"total"`
+	Activities []AlarmActivity `json:"activities"`
+}
+
 func (sdk mgSDK) UpdateAlarm(ctx context.Context, alarm Alarm, domainID, token string) (Alarm, errors.SDKError) {
 	data, err := json.Marshal(alarm)
 	if err != nil {
@@ -113,3 +150,83 @@ func (sdk mgSDK) DeleteAlarm(ctx context.Context, id, domainID, token string) er
 	_, _, sdkerr := sdk.processRequest(ctx, http.MethodDelete, url, token, nil, nil, http.StatusNoContent, http.StatusOK)
 	return sdkerr
 }
+
+func (sdk mgSDK) ShelveAlarm(ctx context.Context, id string, until time.Time, domainID, token string) (Alarm, errors.SDKError) {
+	data, err := json.Marshal(map[string]time.Time{"until": until})
+	if err != nil {
+		return Alarm{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/shelve", sdk.alarmsURL, domainID, alarmsEndpoint, id)
+
+	return sdk.changeAlarm(ctx, url, data, token)
+}
+
+func (sdk mgSDK) UnshelveAlarm(ctx context.Context, id, domainID, token string) (Alarm, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/unshelve", sdk.alarmsURL, domainID, alarmsEndpoint, id)
+
+	return sdk.changeAlarm(ctx, url, nil, token)
+}
+
+func (sdk mgSDK) changeAlarm(ctx context.Context, url string, data []byte, token string) (Alarm, errors.SDKError) {
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Alarm{}, sdkerr
+	}
+
+	var a Alarm
+	if err := json.Unmarshal(body, &a); err != nil {
+		return Alarm{}, errors.NewSDKError(err)
+	}
+
+	return a, nil
+}
+
+func (sdk mgSDK) AddAlarmComment(ctx context.Context, alarmID string, comment AlarmComment, domainID, token string) (AlarmActivity, errors.SDKError) {
+	data, err := json.Marshal(comment)
+	if err != nil {
+		return AlarmActivity{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/comments", sdk.alarmsURL, domainID, alarmsEndpoint, alarmID)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return AlarmActivity{}, sdkerr
+	}
+
+	var a AlarmActivity
+	if err := json.Unmarshal(body, &a); err != nil {
+		return AlarmActivity{}, errors.NewSDKError(err)
+	}
+
+	return a, nil
+}
+
+func (sdk mgSDK) ListAlarmComments(ctx context.Context, alarmID string, pm PageMetadata, domainID, token string) (AlarmActivitiesPage, errors.SDKError) {
+	return sdk.alarmActivities(ctx, alarmID, "comments", pm, domainID, token)
+}
+
+func (sdk mgSDK) AlarmTimeline(ctx context.Context, alarmID string, pm PageMetadata, domainID, token string) (AlarmActivitiesPage, errors.SDKError) {
+	return sdk.alarmActivities(ctx, alarmID, "timeline", pm, domainID, token)
+}
+
+func (sdk mgSDK) alarmActivities(ctx context.Context, alarmID, path string, pm PageMetadata, domainID, token string) (AlarmActivitiesPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s/%s", domainID, alarmsEndpoint, alarmID, path)
+	url, err := sdk.withQueryParams(sdk.alarmsURL, endpoint, pm)
+	if err != nil {
+		return AlarmActivitiesPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return AlarmActivitiesPage{}, sdkerr
+	}
+
+	var ap AlarmActivitiesPage
+	if err := json.Unmarshal(body, &ap); err != nil {
+		return AlarmActivitiesPage{}, errors.NewSDKError(err)
+	}
+
+	return ap, nil
+}
@@ -16,10 +16,34 @@ import (
 const subscriptionEndpoint = "subscriptions"
 
 type Subscription struct {
-	ID      string `json:"id,omitempty"`
-	OwnerID string `json:"owner_id,omitempty"`
-	Topic   string `json:"topic,omitempty"`
-	Contact string `json:"contact,omitempty"`
+	ID             string               `json:"id,omitempty"`
+	OwnerID        string               `json:"owner_id,omitempty"`
+	Topic          string               `json:"topic,omitempty"`
+	Contact        string               `json:"contact,omitempty"`
+	Template       string               `json:"template,omitempty"`
+	Filters        []SubscriptionFilter `json:"filters,omitempty"`
+	RateLimit      uint                 `json:"rate_limit,omitempty"`
+	RateInterval   string               `json:"rate_interval,omitempty"`
+	QuietHours     *QuietHours          `json:"quiet_hours,omitempty"`
+	DigestInterval string               `json:"digest_interval,omitempty"`
+}
+
+// SubscriptionFilter compares the values of the message records of the
+// given name.
+type SubscriptionFilter struct {
+	Name        string   `json:"name"`
+	Comparator  string   `json:"comparator"`
+	Value       *float64 `json:"value,omitempty"`
+	StringValue *string  `json:"string_value,omitempty"`
+	BoolValue   *bool    `json:"bool_value,omitempty"`
+}
+
+// QuietHours is the daily period in which a subscription sends no
+// notifications.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
 }
 
 func (sdk mgSDK) CreateSubscription(ctx context.Context, topic, contact, token string) (string, errors.SDKError) {
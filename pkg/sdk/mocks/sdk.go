@@ -10,6 +10,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/sdk"
@@ -106,6 +107,92 @@ func (_c *SDK_AcceptInvitation_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// AddAlarmComment provides a mock function for the type SDK
+func (_mock *SDK) AddAlarmComment(ctx context.Context, alarmID string, comment sdk.AlarmComment, domainID string, token string) (sdk.AlarmActivity, errors.SDKError) {
+	ret := _mock.Called(ctx, alarmID, comment, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAlarmComment")
+	}
+
+	var r0 sdk.AlarmActivity
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.AlarmComment, string, string) (sdk.AlarmActivity, errors.SDKError)); ok {
+		return returnFunc(ctx, alarmID, comment, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.AlarmComment, string, string) sdk.AlarmActivity); ok {
+		r0 = returnFunc(ctx, alarmID, comment, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmActivity)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.AlarmComment, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, alarmID, comment, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_AddAlarmComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAlarmComment'
+type SDK_AddAlarmComment_Call struct {
+	*mock.Call
+}
+
+// AddAlarmComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarmID string
+//   - comment sdk.AlarmComment
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) AddAlarmComment(ctx interface{}, alarmID interface{}, comment interface{}, domainID interface{}, token interface{}) *SDK_AddAlarmComment_Call {
+	return &SDK_AddAlarmComment_Call{Call: _e.mock.On("AddAlarmComment", ctx, alarmID, comment, domainID, token)}
+}
+
+func (_c *SDK_AddAlarmComment_Call) Run(run func(ctx context.Context, alarmID string, comment sdk.AlarmComment, domainID string, token string)) *SDK_AddAlarmComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.AlarmComment
+		if args[2] != nil {
+			arg2 = args[2].(sdk.AlarmComment)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_AddAlarmComment_Call) Return(alarmActivity sdk.AlarmActivity, sDKError errors.SDKError) *SDK_AddAlarmComment_Call {
+	_c.Call.Return(alarmActivity, sDKError)
+	return _c
+}
+
+func (_c *SDK_AddAlarmComment_Call) RunAndReturn(run func(ctx context.Context, alarmID string, comment sdk.AlarmComment, domainID string, token string) (sdk.AlarmActivity, errors.SDKError)) *SDK_AddAlarmComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddBootstrap provides a mock function for the type SDK
 func (_mock *SDK) AddBootstrap(ctx context.Context, cfg sdk.BootstrapConfig, domainID string, token string) (string, errors.SDKError) {
 	ret := _mock.Called(ctx, cfg, domainID, token)
@@ -975,6 +1062,92 @@ func (_c *SDK_AddRule_Call) RunAndReturn(run func(ctx context.Context, r sdk.Rul
 	return _c
 }
 
+// AlarmTimeline provides a mock function for the type SDK
+func (_mock *SDK) AlarmTimeline(ctx context.Context, alarmID string, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmActivitiesPage, errors.SDKError) {
+	ret := _mock.Called(ctx, alarmID, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AlarmTimeline")
+	}
+
+	var r0 sdk.AlarmActivitiesPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) (sdk.AlarmActivitiesPage, errors.SDKError)); ok {
+		return returnFunc(ctx, alarmID, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) sdk.AlarmActivitiesPage); ok {
+		r0 = returnFunc(ctx, alarmID, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmActivitiesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, alarmID, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_AlarmTimeline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AlarmTimeline'
+type SDK_AlarmTimeline_Call struct {
+	*mock.Call
+}
+
+// AlarmTimeline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarmID string
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) AlarmTimeline(ctx interface{}, alarmID interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_AlarmTimeline_Call {
+	return &SDK_AlarmTimeline_Call{Call: _e.mock.On("AlarmTimeline", ctx, alarmID, pm, domainID, token)}
+}
+
+func (_c *SDK_AlarmTimeline_Call) Run(run func(ctx context.Context, alarmID string, pm sdk.PageMetadata, domainID string, token string)) *SDK_AlarmTimeline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(sdk.PageMetadata)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_AlarmTimeline_Call) Return(alarmActivitiesPage sdk.AlarmActivitiesPage, sDKError errors.SDKError) *SDK_AlarmTimeline_Call {
+	_c.Call.Return(alarmActivitiesPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_AlarmTimeline_Call) RunAndReturn(run func(ctx context.Context, alarmID string, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmActivitiesPage, errors.SDKError)) *SDK_AlarmTimeline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AssignBootstrapProfile provides a mock function for the type SDK
 func (_mock *SDK) AssignBootstrapProfile(ctx context.Context, configID string, profileID string, domainID string, token string) errors.SDKError {
 	ret := _mock.Called(ctx, configID, profileID, domainID, token)
@@ -4615,6 +4788,98 @@ func (_c *SDK_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id str
 	return _c
 }
 
+// DiffRuleVersions provides a mock function for the type SDK
+func (_mock *SDK) DiffRuleVersions(ctx context.Context, id string, from uint64, to uint64, domainID string, token string) (sdk.RuleVersionDiff, errors.SDKError) {
+	ret := _mock.Called(ctx, id, from, to, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffRuleVersions")
+	}
+
+	var r0 sdk.RuleVersionDiff
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64, string, string) (sdk.RuleVersionDiff, errors.SDKError)); ok {
+		return returnFunc(ctx, id, from, to, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64, string, string) sdk.RuleVersionDiff); ok {
+		r0 = returnFunc(ctx, id, from, to, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleVersionDiff)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64, uint64, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, from, to, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_DiffRuleVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRuleVersions'
+type SDK_DiffRuleVersions_Call struct {
+	*mock.Call
+}
+
+// DiffRuleVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - from uint64
+//   - to uint64
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) DiffRuleVersions(ctx interface{}, id interface{}, from interface{}, to interface{}, domainID interface{}, token interface{}) *SDK_DiffRuleVersions_Call {
+	return &SDK_DiffRuleVersions_Call{Call: _e.mock.On("DiffRuleVersions", ctx, id, from, to, domainID, token)}
+}
+
+func (_c *SDK_DiffRuleVersions_Call) Run(run func(ctx context.Context, id string, from uint64, to uint64, domainID string, token string)) *SDK_DiffRuleVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_DiffRuleVersions_Call) Return(ruleVersionDiff sdk.RuleVersionDiff, sDKError errors.SDKError) *SDK_DiffRuleVersions_Call {
+	_c.Call.Return(ruleVersionDiff, sDKError)
+	return _c
+}
+
+func (_c *SDK_DiffRuleVersions_Call) RunAndReturn(run func(ctx context.Context, id string, from uint64, to uint64, domainID string, token string) (sdk.RuleVersionDiff, errors.SDKError)) *SDK_DiffRuleVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DisableChannel provides a mock function for the type SDK
 func (_mock *SDK) DisableChannel(ctx context.Context, id string, domainID string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _mock.Called(ctx, id, domainID, token)
@@ -7864,6 +8129,92 @@ func (_c *SDK_Journal_Call) RunAndReturn(run func(ctx context.Context, entityTyp
 	return _c
 }
 
+// ListAlarmComments provides a mock function for the type SDK
+func (_mock *SDK) ListAlarmComments(ctx context.Context, alarmID string, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmActivitiesPage, errors.SDKError) {
+	ret := _mock.Called(ctx, alarmID, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAlarmComments")
+	}
+
+	var r0 sdk.AlarmActivitiesPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) (sdk.AlarmActivitiesPage, errors.SDKError)); ok {
+		return returnFunc(ctx, alarmID, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) sdk.AlarmActivitiesPage); ok {
+		r0 = returnFunc(ctx, alarmID, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.AlarmActivitiesPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, alarmID, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ListAlarmComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAlarmComments'
+type SDK_ListAlarmComments_Call struct {
+	*mock.Call
+}
+
+// ListAlarmComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alarmID string
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ListAlarmComments(ctx interface{}, alarmID interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ListAlarmComments_Call {
+	return &SDK_ListAlarmComments_Call{Call: _e.mock.On("ListAlarmComments", ctx, alarmID, pm, domainID, token)}
+}
+
+func (_c *SDK_ListAlarmComments_Call) Run(run func(ctx context.Context, alarmID string, pm sdk.PageMetadata, domainID string, token string)) *SDK_ListAlarmComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(sdk.PageMetadata)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ListAlarmComments_Call) Return(alarmActivitiesPage sdk.AlarmActivitiesPage, sDKError errors.SDKError) *SDK_ListAlarmComments_Call {
+	_c.Call.Return(alarmActivitiesPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ListAlarmComments_Call) RunAndReturn(run func(ctx context.Context, alarmID string, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmActivitiesPage, errors.SDKError)) *SDK_ListAlarmComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListAlarms provides a mock function for the type SDK
 func (_mock *SDK) ListAlarms(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.AlarmsPage, errors.SDKError) {
 	ret := _mock.Called(ctx, pm, domainID, token)
@@ -8442,8 +8793,266 @@ func (_c *SDK_ListReportsConfig_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// ListRules provides a mock function for the type SDK
-func (_mock *SDK) ListRules(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.Page, errors.SDKError) {
+// ListRuleDeadLetters provides a mock function for the type SDK
+func (_mock *SDK) ListRuleDeadLetters(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleDeadLettersPage, errors.SDKError) {
+	ret := _mock.Called(ctx, id, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuleDeadLetters")
+	}
+
+	var r0 sdk.RuleDeadLettersPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) (sdk.RuleDeadLettersPage, errors.SDKError)); ok {
+		return returnFunc(ctx, id, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) sdk.RuleDeadLettersPage); ok {
+		r0 = returnFunc(ctx, id, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleDeadLettersPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ListRuleDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuleDeadLetters'
+type SDK_ListRuleDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListRuleDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ListRuleDeadLetters(ctx interface{}, id interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ListRuleDeadLetters_Call {
+	return &SDK_ListRuleDeadLetters_Call{Call: _e.mock.On("ListRuleDeadLetters", ctx, id, pm, domainID, token)}
+}
+
+func (_c *SDK_ListRuleDeadLetters_Call) Run(run func(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string)) *SDK_ListRuleDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(sdk.PageMetadata)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ListRuleDeadLetters_Call) Return(ruleDeadLettersPage sdk.RuleDeadLettersPage, sDKError errors.SDKError) *SDK_ListRuleDeadLetters_Call {
+	_c.Call.Return(ruleDeadLettersPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ListRuleDeadLetters_Call) RunAndReturn(run func(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleDeadLettersPage, errors.SDKError)) *SDK_ListRuleDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRuleExecutions provides a mock function for the type SDK
+func (_mock *SDK) ListRuleExecutions(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleExecutionsPage, errors.SDKError) {
+	ret := _mock.Called(ctx, id, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuleExecutions")
+	}
+
+	var r0 sdk.RuleExecutionsPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) (sdk.RuleExecutionsPage, errors.SDKError)); ok {
+		return returnFunc(ctx, id, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) sdk.RuleExecutionsPage); ok {
+		r0 = returnFunc(ctx, id, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleExecutionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ListRuleExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuleExecutions'
+type SDK_ListRuleExecutions_Call struct {
+	*mock.Call
+}
+
+// ListRuleExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ListRuleExecutions(ctx interface{}, id interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ListRuleExecutions_Call {
+	return &SDK_ListRuleExecutions_Call{Call: _e.mock.On("ListRuleExecutions", ctx, id, pm, domainID, token)}
+}
+
+func (_c *SDK_ListRuleExecutions_Call) Run(run func(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string)) *SDK_ListRuleExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(sdk.PageMetadata)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ListRuleExecutions_Call) Return(ruleExecutionsPage sdk.RuleExecutionsPage, sDKError errors.SDKError) *SDK_ListRuleExecutions_Call {
+	_c.Call.Return(ruleExecutionsPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ListRuleExecutions_Call) RunAndReturn(run func(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleExecutionsPage, errors.SDKError)) *SDK_ListRuleExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRuleVersions provides a mock function for the type SDK
+func (_mock *SDK) ListRuleVersions(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleVersionsPage, errors.SDKError) {
+	ret := _mock.Called(ctx, id, pm, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuleVersions")
+	}
+
+	var r0 sdk.RuleVersionsPage
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) (sdk.RuleVersionsPage, errors.SDKError)); ok {
+		return returnFunc(ctx, id, pm, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, sdk.PageMetadata, string, string) sdk.RuleVersionsPage); ok {
+		r0 = returnFunc(ctx, id, pm, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleVersionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, pm, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ListRuleVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuleVersions'
+type SDK_ListRuleVersions_Call struct {
+	*mock.Call
+}
+
+// ListRuleVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - pm sdk.PageMetadata
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ListRuleVersions(ctx interface{}, id interface{}, pm interface{}, domainID interface{}, token interface{}) *SDK_ListRuleVersions_Call {
+	return &SDK_ListRuleVersions_Call{Call: _e.mock.On("ListRuleVersions", ctx, id, pm, domainID, token)}
+}
+
+func (_c *SDK_ListRuleVersions_Call) Run(run func(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string)) *SDK_ListRuleVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 sdk.PageMetadata
+		if args[2] != nil {
+			arg2 = args[2].(sdk.PageMetadata)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ListRuleVersions_Call) Return(ruleVersionsPage sdk.RuleVersionsPage, sDKError errors.SDKError) *SDK_ListRuleVersions_Call {
+	_c.Call.Return(ruleVersionsPage, sDKError)
+	return _c
+}
+
+func (_c *SDK_ListRuleVersions_Call) RunAndReturn(run func(ctx context.Context, id string, pm sdk.PageMetadata, domainID string, token string) (sdk.RuleVersionsPage, errors.SDKError)) *SDK_ListRuleVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRules provides a mock function for the type SDK
+func (_mock *SDK) ListRules(ctx context.Context, pm sdk.PageMetadata, domainID string, token string) (sdk.Page, errors.SDKError) {
 	ret := _mock.Called(ctx, pm, domainID, token)
 
 	if len(ret) == 0 {
@@ -10637,40 +11246,50 @@ func (_c *SDK_RenewCert_Call) RunAndReturn(run func(ctx context.Context, serialN
 	return _c
 }
 
-// ResetPassword provides a mock function for the type SDK
-func (_mock *SDK) ResetPassword(ctx context.Context, password string, confPass string, token string) errors.SDKError {
-	ret := _mock.Called(ctx, password, confPass, token)
+// ReplayRuleDeadLetter provides a mock function for the type SDK
+func (_mock *SDK) ReplayRuleDeadLetter(ctx context.Context, id string, deadLetterID string, domainID string, token string) (sdk.RuleDeadLetter, errors.SDKError) {
+	ret := _mock.Called(ctx, id, deadLetterID, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPassword")
+		panic("no return value specified for ReplayRuleDeadLetter")
 	}
 
-	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) errors.SDKError); ok {
-		r0 = returnFunc(ctx, password, confPass, token)
+	var r0 sdk.RuleDeadLetter
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (sdk.RuleDeadLetter, errors.SDKError)); ok {
+		return returnFunc(ctx, id, deadLetterID, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) sdk.RuleDeadLetter); ok {
+		r0 = returnFunc(ctx, id, deadLetterID, domainID, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.RuleDeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, deadLetterID, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
-	return r0
+	return r0, r1
 }
 
-// SDK_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
-type SDK_ResetPassword_Call struct {
+// SDK_ReplayRuleDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayRuleDeadLetter'
+type SDK_ReplayRuleDeadLetter_Call struct {
 	*mock.Call
 }
 
-// ResetPassword is a helper method to define mock.On call
+// ReplayRuleDeadLetter is a helper method to define mock.On call
 //   - ctx context.Context
-//   - password string
-//   - confPass string
+//   - id string
+//   - deadLetterID string
+//   - domainID string
 //   - token string
-func (_e *SDK_Expecter) ResetPassword(ctx interface{}, password interface{}, confPass interface{}, token interface{}) *SDK_ResetPassword_Call {
-	return &SDK_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, password, confPass, token)}
+func (_e *SDK_Expecter) ReplayRuleDeadLetter(ctx interface{}, id interface{}, deadLetterID interface{}, domainID interface{}, token interface{}) *SDK_ReplayRuleDeadLetter_Call {
+	return &SDK_ReplayRuleDeadLetter_Call{Call: _e.mock.On("ReplayRuleDeadLetter", ctx, id, deadLetterID, domainID, token)}
 }
 
-func (_c *SDK_ResetPassword_Call) Run(run func(ctx context.Context, password string, confPass string, token string)) *SDK_ResetPassword_Call {
+func (_c *SDK_ReplayRuleDeadLetter_Call) Run(run func(ctx context.Context, id string, deadLetterID string, domainID string, token string)) *SDK_ReplayRuleDeadLetter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -10688,37 +11307,42 @@ func (_c *SDK_ResetPassword_Call) Run(run func(ctx context.Context, password str
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *SDK_ResetPassword_Call) Return(sDKError errors.SDKError) *SDK_ResetPassword_Call {
-	_c.Call.Return(sDKError)
+func (_c *SDK_ReplayRuleDeadLetter_Call) Return(ruleDeadLetter sdk.RuleDeadLetter, sDKError errors.SDKError) *SDK_ReplayRuleDeadLetter_Call {
+	_c.Call.Return(ruleDeadLetter, sDKError)
 	return _c
 }
 
-func (_c *SDK_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, password string, confPass string, token string) errors.SDKError) *SDK_ResetPassword_Call {
+func (_c *SDK_ReplayRuleDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id string, deadLetterID string, domainID string, token string) (sdk.RuleDeadLetter, errors.SDKError)) *SDK_ReplayRuleDeadLetter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetPasswordRequest provides a mock function for the type SDK
-func (_mock *SDK) ResetPasswordRequest(ctx context.Context, email string) errors.SDKError {
-	ret := _mock.Called(ctx, email)
+// ResetPassword provides a mock function for the type SDK
+func (_mock *SDK) ResetPassword(ctx context.Context, password string, confPass string, token string) errors.SDKError {
+	ret := _mock.Called(ctx, password, confPass, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPasswordRequest")
+		panic("no return value specified for ResetPassword")
 	}
 
 	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) errors.SDKError); ok {
-		r0 = returnFunc(ctx, email)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, password, confPass, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -10727,18 +11351,89 @@ func (_mock *SDK) ResetPasswordRequest(ctx context.Context, email string) errors
 	return r0
 }
 
-// SDK_ResetPasswordRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPasswordRequest'
-type SDK_ResetPasswordRequest_Call struct {
+// SDK_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type SDK_ResetPassword_Call struct {
 	*mock.Call
 }
 
-// ResetPasswordRequest is a helper method to define mock.On call
+// ResetPassword is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-func (_e *SDK_Expecter) ResetPasswordRequest(ctx interface{}, email interface{}) *SDK_ResetPasswordRequest_Call {
-	return &SDK_ResetPasswordRequest_Call{Call: _e.mock.On("ResetPasswordRequest", ctx, email)}
-}
-
+//   - password string
+//   - confPass string
+//   - token string
+func (_e *SDK_Expecter) ResetPassword(ctx interface{}, password interface{}, confPass interface{}, token interface{}) *SDK_ResetPassword_Call {
+	return &SDK_ResetPassword_Call{Call: _e.mock.On("ResetPassword", ctx, password, confPass, token)}
+}
+
+func (_c *SDK_ResetPassword_Call) Run(run func(ctx context.Context, password string, confPass string, token string)) *SDK_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ResetPassword_Call) Return(sDKError errors.SDKError) *SDK_ResetPassword_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_ResetPassword_Call) RunAndReturn(run func(ctx context.Context, password string, confPass string, token string) errors.SDKError) *SDK_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPasswordRequest provides a mock function for the type SDK
+func (_mock *SDK) ResetPasswordRequest(ctx context.Context, email string) errors.SDKError {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPasswordRequest")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_ResetPasswordRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPasswordRequest'
+type SDK_ResetPasswordRequest_Call struct {
+	*mock.Call
+}
+
+// ResetPasswordRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *SDK_Expecter) ResetPasswordRequest(ctx interface{}, email interface{}) *SDK_ResetPasswordRequest_Call {
+	return &SDK_ResetPasswordRequest_Call{Call: _e.mock.On("ResetPasswordRequest", ctx, email)}
+}
+
 func (_c *SDK_ResetPasswordRequest_Call) Run(run func(ctx context.Context, email string)) *SDK_ResetPasswordRequest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
@@ -10767,6 +11462,92 @@ func (_c *SDK_ResetPasswordRequest_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// RestoreRuleVersion provides a mock function for the type SDK
+func (_mock *SDK) RestoreRuleVersion(ctx context.Context, id string, version uint64, domainID string, token string) (sdk.Rule, errors.SDKError) {
+	ret := _mock.Called(ctx, id, version, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRuleVersion")
+	}
+
+	var r0 sdk.Rule
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, string, string) (sdk.Rule, errors.SDKError)); ok {
+		return returnFunc(ctx, id, version, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, string, string) sdk.Rule); ok {
+		r0 = returnFunc(ctx, id, version, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Rule)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, version, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_RestoreRuleVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRuleVersion'
+type SDK_RestoreRuleVersion_Call struct {
+	*mock.Call
+}
+
+// RestoreRuleVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - version uint64
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) RestoreRuleVersion(ctx interface{}, id interface{}, version interface{}, domainID interface{}, token interface{}) *SDK_RestoreRuleVersion_Call {
+	return &SDK_RestoreRuleVersion_Call{Call: _e.mock.On("RestoreRuleVersion", ctx, id, version, domainID, token)}
+}
+
+func (_c *SDK_RestoreRuleVersion_Call) Run(run func(ctx context.Context, id string, version uint64, domainID string, token string)) *SDK_RestoreRuleVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_RestoreRuleVersion_Call) Return(rule sdk.Rule, sDKError errors.SDKError) *SDK_RestoreRuleVersion_Call {
+	_c.Call.Return(rule, sDKError)
+	return _c
+}
+
+func (_c *SDK_RestoreRuleVersion_Call) RunAndReturn(run func(ctx context.Context, id string, version uint64, domainID string, token string) (sdk.Rule, errors.SDKError)) *SDK_RestoreRuleVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RevokeAll provides a mock function for the type SDK
 func (_mock *SDK) RevokeAll(ctx context.Context, entityID string, domainID string, token string) errors.SDKError {
 	ret := _mock.Called(ctx, entityID, domainID, token)
@@ -11118,22 +11899,235 @@ func (_c *SDK_SendMessage_Call) Return(sDKError errors.SDKError) *SDK_SendMessag
 	return _c
 }
 
-func (_c *SDK_SendMessage_Call) RunAndReturn(run func(ctx context.Context, domainID string, topic string, msg string, secret string) errors.SDKError) *SDK_SendMessage_Call {
+func (_c *SDK_SendMessage_Call) RunAndReturn(run func(ctx context.Context, domainID string, topic string, msg string, secret string) errors.SDKError) *SDK_SendMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendVerification provides a mock function for the type SDK
+func (_mock *SDK) SendVerification(ctx context.Context, token string) errors.SDKError {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerification")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_SendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendVerification'
+type SDK_SendVerification_Call struct {
+	*mock.Call
+}
+
+// SendVerification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *SDK_Expecter) SendVerification(ctx interface{}, token interface{}) *SDK_SendVerification_Call {
+	return &SDK_SendVerification_Call{Call: _e.mock.On("SendVerification", ctx, token)}
+}
+
+func (_c *SDK_SendVerification_Call) Run(run func(ctx context.Context, token string)) *SDK_SendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_SendVerification_Call) Return(sDKError errors.SDKError) *SDK_SendVerification_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_SendVerification_Call) RunAndReturn(run func(ctx context.Context, token string) errors.SDKError) *SDK_SendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetChannelParent provides a mock function for the type SDK
+func (_mock *SDK) SetChannelParent(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError {
+	ret := _mock.Called(ctx, id, domainID, groupID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChannelParent")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, id, domainID, groupID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_SetChannelParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetChannelParent'
+type SDK_SetChannelParent_Call struct {
+	*mock.Call
+}
+
+// SetChannelParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+//   - groupID string
+//   - token string
+func (_e *SDK_Expecter) SetChannelParent(ctx interface{}, id interface{}, domainID interface{}, groupID interface{}, token interface{}) *SDK_SetChannelParent_Call {
+	return &SDK_SetChannelParent_Call{Call: _e.mock.On("SetChannelParent", ctx, id, domainID, groupID, token)}
+}
+
+func (_c *SDK_SetChannelParent_Call) Run(run func(ctx context.Context, id string, domainID string, groupID string, token string)) *SDK_SetChannelParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_SetChannelParent_Call) Return(sDKError errors.SDKError) *SDK_SetChannelParent_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_SetChannelParent_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError) *SDK_SetChannelParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetClientParent provides a mock function for the type SDK
+func (_mock *SDK) SetClientParent(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError {
+	ret := _mock.Called(ctx, id, domainID, groupID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetClientParent")
+	}
+
+	var r0 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) errors.SDKError); ok {
+		r0 = returnFunc(ctx, id, domainID, groupID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+	return r0
+}
+
+// SDK_SetClientParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetClientParent'
+type SDK_SetClientParent_Call struct {
+	*mock.Call
+}
+
+// SetClientParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - domainID string
+//   - groupID string
+//   - token string
+func (_e *SDK_Expecter) SetClientParent(ctx interface{}, id interface{}, domainID interface{}, groupID interface{}, token interface{}) *SDK_SetClientParent_Call {
+	return &SDK_SetClientParent_Call{Call: _e.mock.On("SetClientParent", ctx, id, domainID, groupID, token)}
+}
+
+func (_c *SDK_SetClientParent_Call) Run(run func(ctx context.Context, id string, domainID string, groupID string, token string)) *SDK_SetClientParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_SetClientParent_Call) Return(sDKError errors.SDKError) *SDK_SetClientParent_Call {
+	_c.Call.Return(sDKError)
+	return _c
+}
+
+func (_c *SDK_SetClientParent_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError) *SDK_SetClientParent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SendVerification provides a mock function for the type SDK
-func (_mock *SDK) SendVerification(ctx context.Context, token string) errors.SDKError {
-	ret := _mock.Called(ctx, token)
+// SetContentType provides a mock function for the type SDK
+func (_mock *SDK) SetContentType(ct sdk.ContentType) errors.SDKError {
+	ret := _mock.Called(ct)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendVerification")
+		panic("no return value specified for SetContentType")
 	}
 
 	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) errors.SDKError); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(sdk.ContentType) errors.SDKError); ok {
+		r0 = returnFunc(ct)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -11142,52 +12136,46 @@ func (_mock *SDK) SendVerification(ctx context.Context, token string) errors.SDK
 	return r0
 }
 
-// SDK_SendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendVerification'
-type SDK_SendVerification_Call struct {
+// SDK_SetContentType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetContentType'
+type SDK_SetContentType_Call struct {
 	*mock.Call
 }
 
-// SendVerification is a helper method to define mock.On call
-//   - ctx context.Context
-//   - token string
-func (_e *SDK_Expecter) SendVerification(ctx interface{}, token interface{}) *SDK_SendVerification_Call {
-	return &SDK_SendVerification_Call{Call: _e.mock.On("SendVerification", ctx, token)}
+// SetContentType is a helper method to define mock.On call
+//   - ct sdk.ContentType
+func (_e *SDK_Expecter) SetContentType(ct interface{}) *SDK_SetContentType_Call {
+	return &SDK_SetContentType_Call{Call: _e.mock.On("SetContentType", ct)}
 }
 
-func (_c *SDK_SendVerification_Call) Run(run func(ctx context.Context, token string)) *SDK_SendVerification_Call {
+func (_c *SDK_SetContentType_Call) Run(run func(ct sdk.ContentType)) *SDK_SetContentType_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
+		var arg0 sdk.ContentType
 		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg0 = args[0].(sdk.ContentType)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *SDK_SendVerification_Call) Return(sDKError errors.SDKError) *SDK_SendVerification_Call {
+func (_c *SDK_SetContentType_Call) Return(sDKError errors.SDKError) *SDK_SetContentType_Call {
 	_c.Call.Return(sDKError)
 	return _c
 }
 
-func (_c *SDK_SendVerification_Call) RunAndReturn(run func(ctx context.Context, token string) errors.SDKError) *SDK_SendVerification_Call {
+func (_c *SDK_SetContentType_Call) RunAndReturn(run func(ct sdk.ContentType) errors.SDKError) *SDK_SetContentType_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetChannelParent provides a mock function for the type SDK
-func (_mock *SDK) SetChannelParent(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError {
+// SetGroupParent provides a mock function for the type SDK
+func (_mock *SDK) SetGroupParent(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError {
 	ret := _mock.Called(ctx, id, domainID, groupID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetChannelParent")
+		panic("no return value specified for SetGroupParent")
 	}
 
 	var r0 errors.SDKError
@@ -11201,22 +12189,22 @@ func (_mock *SDK) SetChannelParent(ctx context.Context, id string, domainID stri
 	return r0
 }
 
-// SDK_SetChannelParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetChannelParent'
-type SDK_SetChannelParent_Call struct {
+// SDK_SetGroupParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGroupParent'
+type SDK_SetGroupParent_Call struct {
 	*mock.Call
 }
 
-// SetChannelParent is a helper method to define mock.On call
+// SetGroupParent is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
 //   - domainID string
 //   - groupID string
 //   - token string
-func (_e *SDK_Expecter) SetChannelParent(ctx interface{}, id interface{}, domainID interface{}, groupID interface{}, token interface{}) *SDK_SetChannelParent_Call {
-	return &SDK_SetChannelParent_Call{Call: _e.mock.On("SetChannelParent", ctx, id, domainID, groupID, token)}
+func (_e *SDK_Expecter) SetGroupParent(ctx interface{}, id interface{}, domainID interface{}, groupID interface{}, token interface{}) *SDK_SetGroupParent_Call {
+	return &SDK_SetGroupParent_Call{Call: _e.mock.On("SetGroupParent", ctx, id, domainID, groupID, token)}
 }
 
-func (_c *SDK_SetChannelParent_Call) Run(run func(ctx context.Context, id string, domainID string, groupID string, token string)) *SDK_SetChannelParent_Call {
+func (_c *SDK_SetGroupParent_Call) Run(run func(ctx context.Context, id string, domainID string, groupID string, token string)) *SDK_SetGroupParent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -11249,51 +12237,60 @@ func (_c *SDK_SetChannelParent_Call) Run(run func(ctx context.Context, id string
 	return _c
 }
 
-func (_c *SDK_SetChannelParent_Call) Return(sDKError errors.SDKError) *SDK_SetChannelParent_Call {
+func (_c *SDK_SetGroupParent_Call) Return(sDKError errors.SDKError) *SDK_SetGroupParent_Call {
 	_c.Call.Return(sDKError)
 	return _c
 }
 
-func (_c *SDK_SetChannelParent_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError) *SDK_SetChannelParent_Call {
+func (_c *SDK_SetGroupParent_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError) *SDK_SetGroupParent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetClientParent provides a mock function for the type SDK
-func (_mock *SDK) SetClientParent(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError {
-	ret := _mock.Called(ctx, id, domainID, groupID, token)
+// ShelveAlarm provides a mock function for the type SDK
+func (_mock *SDK) ShelveAlarm(ctx context.Context, id string, until time.Time, domainID string, token string) (sdk.Alarm, errors.SDKError) {
+	ret := _mock.Called(ctx, id, until, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetClientParent")
+		panic("no return value specified for ShelveAlarm")
 	}
 
-	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) errors.SDKError); ok {
-		r0 = returnFunc(ctx, id, domainID, groupID, token)
+	var r0 sdk.Alarm
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, string, string) (sdk.Alarm, errors.SDKError)); ok {
+		return returnFunc(ctx, id, until, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time, string, string) sdk.Alarm); ok {
+		r0 = returnFunc(ctx, id, until, domainID, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, until, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
-	return r0
+	return r0, r1
 }
 
-// SDK_SetClientParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetClientParent'
-type SDK_SetClientParent_Call struct {
+// SDK_ShelveAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShelveAlarm'
+type SDK_ShelveAlarm_Call struct {
 	*mock.Call
 }
 
-// SetClientParent is a helper method to define mock.On call
+// ShelveAlarm is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
+//   - until time.Time
 //   - domainID string
-//   - groupID string
 //   - token string
-func (_e *SDK_Expecter) SetClientParent(ctx interface{}, id interface{}, domainID interface{}, groupID interface{}, token interface{}) *SDK_SetClientParent_Call {
-	return &SDK_SetClientParent_Call{Call: _e.mock.On("SetClientParent", ctx, id, domainID, groupID, token)}
+func (_e *SDK_Expecter) ShelveAlarm(ctx interface{}, id interface{}, until interface{}, domainID interface{}, token interface{}) *SDK_ShelveAlarm_Call {
+	return &SDK_ShelveAlarm_Call{Call: _e.mock.On("ShelveAlarm", ctx, id, until, domainID, token)}
 }
 
-func (_c *SDK_SetClientParent_Call) Run(run func(ctx context.Context, id string, domainID string, groupID string, token string)) *SDK_SetClientParent_Call {
+func (_c *SDK_ShelveAlarm_Call) Run(run func(ctx context.Context, id string, until time.Time, domainID string, token string)) *SDK_ShelveAlarm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -11303,9 +12300,9 @@ func (_c *SDK_SetClientParent_Call) Run(run func(ctx context.Context, id string,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 time.Time
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(time.Time)
 		}
 		var arg3 string
 		if args[3] != nil {
@@ -11326,104 +12323,145 @@ func (_c *SDK_SetClientParent_Call) Run(run func(ctx context.Context, id string,
 	return _c
 }
 
-func (_c *SDK_SetClientParent_Call) Return(sDKError errors.SDKError) *SDK_SetClientParent_Call {
-	_c.Call.Return(sDKError)
+func (_c *SDK_ShelveAlarm_Call) Return(alarm sdk.Alarm, sDKError errors.SDKError) *SDK_ShelveAlarm_Call {
+	_c.Call.Return(alarm, sDKError)
 	return _c
 }
 
-func (_c *SDK_SetClientParent_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError) *SDK_SetClientParent_Call {
+func (_c *SDK_ShelveAlarm_Call) RunAndReturn(run func(ctx context.Context, id string, until time.Time, domainID string, token string) (sdk.Alarm, errors.SDKError)) *SDK_ShelveAlarm_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetContentType provides a mock function for the type SDK
-func (_mock *SDK) SetContentType(ct sdk.ContentType) errors.SDKError {
-	ret := _mock.Called(ct)
+// TestRule provides a mock function for the type SDK
+func (_mock *SDK) TestRule(ctx context.Context, r sdk.Rule, msg sdk.RuleTestMessage, domainID string, token string) (sdk.RuleTestResult, errors.SDKError) {
+	ret := _mock.Called(ctx, r, msg, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetContentType")
+		panic("no return value specified for TestRule")
 	}
 
-	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(sdk.ContentType) errors.SDKError); ok {
-		r0 = returnFunc(ct)
+	var r0 sdk.RuleTestResult
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.Rule, sdk.RuleTestMessage, string, string) (sdk.RuleTestResult, errors.SDKError)); ok {
+		return returnFunc(ctx, r, msg, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, sdk.Rule, sdk.RuleTestMessage, string, string) sdk.RuleTestResult); ok {
+		r0 = returnFunc(ctx, r, msg, domainID, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.RuleTestResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, sdk.Rule, sdk.RuleTestMessage, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, r, msg, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
-	return r0
+	return r0, r1
 }
 
-// SDK_SetContentType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetContentType'
-type SDK_SetContentType_Call struct {
+// SDK_TestRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestRule'
+type SDK_TestRule_Call struct {
 	*mock.Call
 }
 
-// SetContentType is a helper method to define mock.On call
-//   - ct sdk.ContentType
-func (_e *SDK_Expecter) SetContentType(ct interface{}) *SDK_SetContentType_Call {
-	return &SDK_SetContentType_Call{Call: _e.mock.On("SetContentType", ct)}
+// TestRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r sdk.Rule
+//   - msg sdk.RuleTestMessage
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) TestRule(ctx interface{}, r interface{}, msg interface{}, domainID interface{}, token interface{}) *SDK_TestRule_Call {
+	return &SDK_TestRule_Call{Call: _e.mock.On("TestRule", ctx, r, msg, domainID, token)}
 }
 
-func (_c *SDK_SetContentType_Call) Run(run func(ct sdk.ContentType)) *SDK_SetContentType_Call {
+func (_c *SDK_TestRule_Call) Run(run func(ctx context.Context, r sdk.Rule, msg sdk.RuleTestMessage, domainID string, token string)) *SDK_TestRule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 sdk.ContentType
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(sdk.ContentType)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 sdk.Rule
+		if args[1] != nil {
+			arg1 = args[1].(sdk.Rule)
+		}
+		var arg2 sdk.RuleTestMessage
+		if args[2] != nil {
+			arg2 = args[2].(sdk.RuleTestMessage)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *SDK_SetContentType_Call) Return(sDKError errors.SDKError) *SDK_SetContentType_Call {
-	_c.Call.Return(sDKError)
+func (_c *SDK_TestRule_Call) Return(ruleTestResult sdk.RuleTestResult, sDKError errors.SDKError) *SDK_TestRule_Call {
+	_c.Call.Return(ruleTestResult, sDKError)
 	return _c
 }
 
-func (_c *SDK_SetContentType_Call) RunAndReturn(run func(ct sdk.ContentType) errors.SDKError) *SDK_SetContentType_Call {
+func (_c *SDK_TestRule_Call) RunAndReturn(run func(ctx context.Context, r sdk.Rule, msg sdk.RuleTestMessage, domainID string, token string) (sdk.RuleTestResult, errors.SDKError)) *SDK_TestRule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetGroupParent provides a mock function for the type SDK
-func (_mock *SDK) SetGroupParent(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError {
-	ret := _mock.Called(ctx, id, domainID, groupID, token)
+// UnshelveAlarm provides a mock function for the type SDK
+func (_mock *SDK) UnshelveAlarm(ctx context.Context, id string, domainID string, token string) (sdk.Alarm, errors.SDKError) {
+	ret := _mock.Called(ctx, id, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetGroupParent")
+		panic("no return value specified for UnshelveAlarm")
 	}
 
-	var r0 errors.SDKError
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) errors.SDKError); ok {
-		r0 = returnFunc(ctx, id, domainID, groupID, token)
+	var r0 sdk.Alarm
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (sdk.Alarm, errors.SDKError)); ok {
+		return returnFunc(ctx, id, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) sdk.Alarm); ok {
+		r0 = returnFunc(ctx, id, domainID, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.Alarm)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
-	return r0
+	return r0, r1
 }
 
-// SDK_SetGroupParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetGroupParent'
-type SDK_SetGroupParent_Call struct {
+// SDK_UnshelveAlarm_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnshelveAlarm'
+type SDK_UnshelveAlarm_Call struct {
 	*mock.Call
 }
 
-// SetGroupParent is a helper method to define mock.On call
+// UnshelveAlarm is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
 //   - domainID string
-//   - groupID string
 //   - token string
-func (_e *SDK_Expecter) SetGroupParent(ctx interface{}, id interface{}, domainID interface{}, groupID interface{}, token interface{}) *SDK_SetGroupParent_Call {
-	return &SDK_SetGroupParent_Call{Call: _e.mock.On("SetGroupParent", ctx, id, domainID, groupID, token)}
+func (_e *SDK_Expecter) UnshelveAlarm(ctx interface{}, id interface{}, domainID interface{}, token interface{}) *SDK_UnshelveAlarm_Call {
+	return &SDK_UnshelveAlarm_Call{Call: _e.mock.On("UnshelveAlarm", ctx, id, domainID, token)}
 }
 
-func (_c *SDK_SetGroupParent_Call) Run(run func(ctx context.Context, id string, domainID string, groupID string, token string)) *SDK_SetGroupParent_Call {
+func (_c *SDK_UnshelveAlarm_Call) Run(run func(ctx context.Context, id string, domainID string, token string)) *SDK_UnshelveAlarm_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -11441,27 +12479,22 @@ func (_c *SDK_SetGroupParent_Call) Run(run func(ctx context.Context, id string,
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *SDK_SetGroupParent_Call) Return(sDKError errors.SDKError) *SDK_SetGroupParent_Call {
-	_c.Call.Return(sDKError)
+func (_c *SDK_UnshelveAlarm_Call) Return(alarm sdk.Alarm, sDKError errors.SDKError) *SDK_UnshelveAlarm_Call {
+	_c.Call.Return(alarm, sDKError)
 	return _c
 }
 
-func (_c *SDK_SetGroupParent_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, groupID string, token string) errors.SDKError) *SDK_SetGroupParent_Call {
+func (_c *SDK_UnshelveAlarm_Call) RunAndReturn(run func(ctx context.Context, id string, domainID string, token string) (sdk.Alarm, errors.SDKError)) *SDK_UnshelveAlarm_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -14676,6 +15709,92 @@ func (_c *SDK_ViewRule_Call) RunAndReturn(run func(ctx context.Context, id strin
 	return _c
 }
 
+// ViewRuleDeadLetter provides a mock function for the type SDK
+func (_mock *SDK) ViewRuleDeadLetter(ctx context.Context, id string, deadLetterID string, domainID string, token string) (sdk.RuleDeadLetter, errors.SDKError) {
+	ret := _mock.Called(ctx, id, deadLetterID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRuleDeadLetter")
+	}
+
+	var r0 sdk.RuleDeadLetter
+	var r1 errors.SDKError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (sdk.RuleDeadLetter, errors.SDKError)); ok {
+		return returnFunc(ctx, id, deadLetterID, domainID, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) sdk.RuleDeadLetter); ok {
+		r0 = returnFunc(ctx, id, deadLetterID, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.RuleDeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) errors.SDKError); ok {
+		r1 = returnFunc(ctx, id, deadLetterID, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+	return r0, r1
+}
+
+// SDK_ViewRuleDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewRuleDeadLetter'
+type SDK_ViewRuleDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ViewRuleDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - deadLetterID string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ViewRuleDeadLetter(ctx interface{}, id interface{}, deadLetterID interface{}, domainID interface{}, token interface{}) *SDK_ViewRuleDeadLetter_Call {
+	return &SDK_ViewRuleDeadLetter_Call{Call: _e.mock.On("ViewRuleDeadLetter", ctx, id, deadLetterID, domainID, token)}
+}
+
+func (_c *SDK_ViewRuleDeadLetter_Call) Run(run func(ctx context.Context, id string, deadLetterID string, domainID string, token string)) *SDK_ViewRuleDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *SDK_ViewRuleDeadLetter_Call) Return(ruleDeadLetter sdk.RuleDeadLetter, sDKError errors.SDKError) *SDK_ViewRuleDeadLetter_Call {
+	_c.Call.Return(ruleDeadLetter, sDKError)
+	return _c
+}
+
+func (_c *SDK_ViewRuleDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id string, deadLetterID string, domainID string, token string) (sdk.RuleDeadLetter, errors.SDKError)) *SDK_ViewRuleDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewSubscription provides a mock function for the type SDK
 func (_mock *SDK) ViewSubscription(ctx context.Context, id string, token string) (sdk.Subscription, errors.SDKError) {
 	ret := _mock.Called(ctx, id, token)
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
 )
@@ -16,21 +17,129 @@ const rulesEndpoint = "rules"
 
 // Rule represents a rule configuration.
 type Rule struct {
-	ID           string   `json:"id,omitempty"`
-	Name         string   `json:"name,omitempty"`
-	DomainID     string   `json:"domain,omitempty"`
-	Metadata     Metadata `json:"metadata,omitempty"`
-	Tags         []string `json:"tags,omitempty"`
-	InputChannel string   `json:"input_channel,omitempty"`
-	InputTopic   string   `json:"input_topic,omitempty"`
-	Logic        any      `json:"logic,omitempty"`
-	Outputs      any      `json:"outputs,omitempty"`
-	Schedule     any      `json:"schedule,omitempty"`
-	Status       string   `json:"status,omitempty"`
-	CreatedAt    string   `json:"created_at,omitempty"`
-	CreatedBy    string   `json:"created_by,omitempty"`
-	UpdatedAt    string   `json:"updated_at,omitempty"`
-	UpdatedBy    string   `json:"updated_by,omitempty"`
+	ID           string     `json:"id,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	DomainID     string     `json:"domain,omitempty"`
+	Metadata     Metadata   `json:"metadata,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	InputChannel string     `json:"input_channel,omitempty"`
+	InputTopic   string     `json:"input_topic,omitempty"`
+	Logic        any        `json:"logic,omitempty"`
+	Outputs      any        `json:"outputs,omitempty"`
+	Steps        any        `json:"steps,omitempty"`
+	Schedule     any        `json:"schedule,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	Version      uint64     `json:"version,omitempty"`
+	CreatedAt    string     `json:"created_at,omitempty"`
+	CreatedBy    string     `json:"created_by,omitempty"`
+	UpdatedAt    string     `json:"updated_at,omitempty"`
+	UpdatedBy    string     `json:"updated_by,omitempty"`
+	Stats        *RuleStats `json:"stats,omitempty"`
+}
+
+// RuleStats represents aggregated rule run counters.
+type RuleStats struct {
+	SuccessCount uint64     `json:"success_count"`
+	FailureCount uint64     `json:"failure_count"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+}
+
+// RuleExecution represents a single rule run.
+type RuleExecution struct {
+	ID         string        `json:"id"`
+	RuleID     string        `json:"rule_id"`
+	DomainID   string        `json:"domain_id"`
+	Channel    string        `json:"channel,omitempty"`
+	Subtopic   string        `json:"subtopic,omitempty"`
+	Publisher  string        `json:"publisher,omitempty"`
+	Protocol   string        `json:"protocol,omitempty"`
+	Level      string        `json:"level"`
+	Message    string        `json:"message,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Outputs    []string      `json:"outputs,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	ExecutedAt time.Time     `json:"executed_at"`
+}
+
+type RuleExecutionsPage struct {
+	Offset     uint64          `json:"offset"`
+	Limit      uint64          `json:"limit"`
+	Total      uint64          `json:"total"`
+	Executions []RuleExecution `json:"executions"`
+}
+
+// RuleVersion represents an immutable version of the rule definition.
+type RuleVersion struct {
+	RuleID       string    `json:"rule_id"`
+	Version      uint64    `json:"version"`
+	DomainID     string    `json:"domain_id"`
+	InputChannel string    `json:"input_channel,omitempty"`
+	InputTopic   string    `json:"input_topic,omitempty"`
+	Logic        any       `json:"logic,omitempty"`
+	Outputs      any       `json:"outputs,omitempty"`
+	Steps        any       `json:"steps,omitempty"`
+	Schedule     any       `json:"schedule,omitempty"`
+	RestoredFrom uint64    `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by"`
+}
+
+type RuleVersionsPage struct {
+	Offset   uint64        `json:"offset"`
+	Limit    uint64        `json:"limit"`
+	Total    uint64        `json:"total"`
+	Versions []RuleVersion `json:"versions"`
+}
+
+// RuleFieldChange represents a change of a rule field between two versions.
+type RuleFieldChange struct {
+	Field string   `json:"field"`
+	From  any      `json:"from"`
+	To    any      `json:"to"`
+	Diff  []string `json:"diff,omitempty"`
+}
+
+// RuleVersionDiff represents the changes between two rule versions.
+type RuleVersionDiff struct {
+	RuleID  string            `json:"rule_id"`
+	From    uint64            `json:"from"`
+	To      uint64            `json:"to"`
+	Changes []RuleFieldChange `json:"changes"`
+}
+
+// RuleDeadLetter represents a failed run of a rule output.
+type RuleDeadLetter struct {
+	ID          string                `json:"id"`
+	RuleID      string                `json:"rule_id"`
+	DomainID    string                `json:"domain_id"`
+	OutputType  string                `json:"output_type"`
+	Output      map[string]any        `json:"output"`
+	Message     RuleDeadLetterMessage `json:"message"`
+	Result      any                   `json:"result,omitempty"`
+	Error       string                `json:"error"`
+	Status      string                `json:"status"`
+	Attempts    uint                  `json:"attempts"`
+	NextRetryAt *time.Time            `json:"next_retry_at,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at,omitempty"`
+}
+
+// RuleDeadLetterMessage represents the message the rule processed when the output failed.
+type RuleDeadLetterMessage struct {
+	Channel   string `json:"channel,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Payload   []byte `json:"payload,omitempty"`
+	Created   int64  `json:"created,omitempty"`
+}
+
+type RuleDeadLettersPage struct {
+	Offset      uint64           `json:"offset"`
+	Limit       uint64           `json:"limit"`
+	Total       uint64           `json:"total"`
+	DeadLetters []RuleDeadLetter `json:"dead_letters"`
 }
 
 type Page struct {
@@ -40,6 +149,40 @@ type Page struct {
 	Rules  []Rule `json:"rules"`
 }
 
+// RuleTestMessage represents a sample message used to test a rule.
+type RuleTestMessage struct {
+	Channel   string `json:"channel,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Created   int64  `json:"created,omitempty"`
+	Payload   any    `json:"payload,omitempty"`
+}
+
+// RuleOutputResult represents the payload rendered for a single rule output.
+type RuleOutputResult struct {
+	Type    string `json:"type"`
+	Payload any    `json:"payload,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// RuleStepResult represents the result of a single pipeline step in a rule test run.
+type RuleStepResult struct {
+	Step   int    `json:"step"`
+	Name   string `json:"name,omitempty"`
+	Result any    `json:"result"`
+	Branch *int   `json:"branch,omitempty"`
+}
+
+// RuleTestResult represents the outcome of a rule test run.
+type RuleTestResult struct {
+	Result  any                `json:"result"`
+	Steps   []RuleStepResult   `json:"steps,omitempty"`
+	Outputs []RuleOutputResult `json:"outputs,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
 func (sdk mgSDK) AddRule(ctx context.Context, r Rule, domainID, token string) (Rule, errors.SDKError) {
 	data, err := json.Marshal(r)
 	if err != nil {
@@ -160,6 +303,130 @@ func (sdk mgSDK) ListRules(ctx context.Context, pm PageMetadata, domainID, token
 	return ap, nil
 }
 
+func (sdk mgSDK) ListRuleExecutions(ctx context.Context, id string, pm PageMetadata, domainID, token string) (RuleExecutionsPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s/executions", domainID, rulesEndpoint, id)
+	url, err := sdk.withQueryParams(sdk.rulesEngineURL, endpoint, pm)
+	if err != nil {
+		return RuleExecutionsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleExecutionsPage{}, sdkerr
+	}
+
+	var ep RuleExecutionsPage
+	if err := json.Unmarshal(body, &ep); err != nil {
+		return RuleExecutionsPage{}, errors.NewSDKError(err)
+	}
+
+	return ep, nil
+}
+
+func (sdk mgSDK) ListRuleVersions(ctx context.Context, id string, pm PageMetadata, domainID, token string) (RuleVersionsPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s/versions", domainID, rulesEndpoint, id)
+	url, err := sdk.withQueryParams(sdk.rulesEngineURL, endpoint, pm)
+	if err != nil {
+		return RuleVersionsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleVersionsPage{}, sdkerr
+	}
+
+	var vp RuleVersionsPage
+	if err := json.Unmarshal(body, &vp); err != nil {
+		return RuleVersionsPage{}, errors.NewSDKError(err)
+	}
+
+	return vp, nil
+}
+
+func (sdk mgSDK) DiffRuleVersions(ctx context.Context, id string, from, to uint64, domainID, token string) (RuleVersionDiff, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/versions/diff?from=%d&to=%d", sdk.rulesEngineURL, domainID, rulesEndpoint, id, from, to)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleVersionDiff{}, sdkerr
+	}
+
+	var diff RuleVersionDiff
+	if err := json.Unmarshal(body, &diff); err != nil {
+		return RuleVersionDiff{}, errors.NewSDKError(err)
+	}
+
+	return diff, nil
+}
+
+func (sdk mgSDK) RestoreRuleVersion(ctx context.Context, id string, version uint64, domainID, token string) (Rule, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/versions/%d/restore", sdk.rulesEngineURL, domainID, rulesEndpoint, id, version)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Rule{}, sdkerr
+	}
+
+	var r Rule
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Rule{}, errors.NewSDKError(err)
+	}
+
+	return r, nil
+}
+
+func (sdk mgSDK) ListRuleDeadLetters(ctx context.Context, id string, pm PageMetadata, domainID, token string) (RuleDeadLettersPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s/%s/dead-letters", domainID, rulesEndpoint, id)
+	url, err := sdk.withQueryParams(sdk.rulesEngineURL, endpoint, pm)
+	if err != nil {
+		return RuleDeadLettersPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleDeadLettersPage{}, sdkerr
+	}
+
+	var dp RuleDeadLettersPage
+	if err := json.Unmarshal(body, &dp); err != nil {
+		return RuleDeadLettersPage{}, errors.NewSDKError(err)
+	}
+
+	return dp, nil
+}
+
+func (sdk mgSDK) ViewRuleDeadLetter(ctx context.Context, id, deadLetterID, domainID, token string) (RuleDeadLetter, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/dead-letters/%s", sdk.rulesEngineURL, domainID, rulesEndpoint, id, deadLetterID)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleDeadLetter{}, sdkerr
+	}
+
+	var dl RuleDeadLetter
+	if err := json.Unmarshal(body, &dl); err != nil {
+		return RuleDeadLetter{}, errors.NewSDKError(err)
+	}
+
+	return dl, nil
+}
+
+func (sdk mgSDK) ReplayRuleDeadLetter(ctx context.Context, id, deadLetterID, domainID, token string) (RuleDeadLetter, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/%s/dead-letters/%s/replay", sdk.rulesEngineURL, domainID, rulesEndpoint, id, deadLetterID)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleDeadLetter{}, sdkerr
+	}
+
+	var dl RuleDeadLetter
+	if err := json.Unmarshal(body, &dl); err != nil {
+		return RuleDeadLetter{}, errors.NewSDKError(err)
+	}
+
+	return dl, nil
+}
+
 func (sdk mgSDK) RemoveRule(ctx context.Context, id, domainID, token string) errors.SDKError {
 	url := fmt.Sprintf("%s/%s/%s/%s", sdk.rulesEngineURL, domainID, rulesEndpoint, id)
 
@@ -198,3 +465,27 @@ func (sdk mgSDK) DisableRule(ctx context.Context, id, domainID, token string) (R
 
 	return a, nil
 }
+
+func (sdk mgSDK) TestRule(ctx context.Context, r Rule, msg RuleTestMessage, domainID, token string) (RuleTestResult, errors.SDKError) {
+	data, err := json.Marshal(map[string]any{
+		"rule":    r,
+		"message": msg,
+	})
+	if err != nil {
+		return RuleTestResult{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/test", sdk.rulesEngineURL, domainID, rulesEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(ctx, http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return RuleTestResult{}, sdkerr
+	}
+
+	var res RuleTestResult
+	if err := json.Unmarshal(body, &res); err != nil {
+		return RuleTestResult{}, errors.NewSDKError(err)
+	}
+
+	return res, nil
+}
@@ -1700,6 +1700,21 @@ type SDK interface {
 	// DeleteAlarm deletes an alarm.
 	DeleteAlarm(ctx context.Context, id, domainID, token string) smqerrors.SDKError
 
+	// ShelveAlarm shelves an alarm until the given time.
+	ShelveAlarm(ctx context.Context, id string, until time.Time, domainID, token string) (Alarm, smqerrors.SDKError)
+
+	// UnshelveAlarm ends the shelving of an alarm.
+	UnshelveAlarm(ctx context.Context, id, domainID, token string) (Alarm, smqerrors.SDKError)
+
+	// AddAlarmComment adds a comment to the activity timeline of an alarm.
+	AddAlarmComment(ctx context.Context, alarmID string, comment AlarmComment, domainID, token string) (AlarmActivity, smqerrors.SDKError)
+
+	// ListAlarmComments retrieves a page of the comments of an alarm.
+	ListAlarmComments(ctx context.Context, alarmID string, pm PageMetadata, domainID, token string) (AlarmActivitiesPage, smqerrors.SDKError)
+
+	// AlarmTimeline retrieves a page of the activity timeline of an alarm.
+	AlarmTimeline(ctx context.Context, alarmID string, pm PageMetadata, domainID, token string) (AlarmActivitiesPage, smqerrors.SDKError)
+
 	// AddReportConfig creates a new report configuration.
 	AddReportConfig(ctx context.Context, cfg ReportConfig, domainID, token string) (ReportConfig, smqerrors.SDKError)
 
@@ -1754,6 +1769,28 @@ type SDK interface {
 	// ListRules retrieves a page of rules.
 	ListRules(ctx context.Context, pm PageMetadata, domainID, token string) (Page, smqerrors.SDKError)
 
+	// ListRuleExecutions retrieves a page of rule executions.
+	ListRuleExecutions(ctx context.Context, id string, pm PageMetadata, domainID, token string) (RuleExecutionsPage, smqerrors.SDKError)
+
+	// ListRuleVersions retrieves a page of rule versions, from the newest.
+	ListRuleVersions(ctx context.Context, id string, pm PageMetadata, domainID, token string) (RuleVersionsPage, smqerrors.SDKError)
+
+	// DiffRuleVersions retrieves the changes between two rule versions. Zero versions
+	// stand for the latest version and the version preceding the compared one.
+	DiffRuleVersions(ctx context.Context, id string, from, to uint64, domainID, token string) (RuleVersionDiff, smqerrors.SDKError)
+
+	// RestoreRuleVersion restores the rule definition of the version.
+	RestoreRuleVersion(ctx context.Context, id string, version uint64, domainID, token string) (Rule, smqerrors.SDKError)
+
+	// ListRuleDeadLetters retrieves a page of failed rule output runs, from the newest.
+	ListRuleDeadLetters(ctx context.Context, id string, pm PageMetadata, domainID, token string) (RuleDeadLettersPage, smqerrors.SDKError)
+
+	// ViewRuleDeadLetter retrieves a failed rule output run.
+	ViewRuleDeadLetter(ctx context.Context, id, deadLetterID, domainID, token string) (RuleDeadLetter, smqerrors.SDKError)
+
+	// ReplayRuleDeadLetter runs the failed rule output again.
+	ReplayRuleDeadLetter(ctx context.Context, id, deadLetterID, domainID, token string) (RuleDeadLetter, smqerrors.SDKError)
+
 	// RemoveRule deletes a rule.
 	RemoveRule(ctx context.Context, id, domainID, token string) smqerrors.SDKError
 
@@ -1763,6 +1800,9 @@ type SDK interface {
 	// DisableRule disables a rule.
 	DisableRule(ctx context.Context, id, domainID, token string) (Rule, smqerrors.SDKError)
 
+	// TestRule runs a rule against a sample message without invoking its outputs.
+	TestRule(ctx context.Context, r Rule, msg RuleTestMessage, domainID, token string) (RuleTestResult, smqerrors.SDKError)
+
 	// IssueCert issues a certificate for an entity.
 	//
 	// example:
@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary
+
+import (
+	"fmt"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+// Field types.
+const (
+	Uint8   = "uint8"
+	Int8    = "int8"
+	Uint16  = "uint16"
+	Int16   = "int16"
+	Uint24  = "uint24"
+	Int24   = "int24"
+	Uint32  = "uint32"
+	Int32   = "int32"
+	Uint64  = "uint64"
+	Int64   = "int64"
+	Float32 = "float32"
+	Float64 = "float64"
+	Bool    = "bool"
+	String  = "string"
+	Bytes   = "bytes"
+)
+
+// Byte orders.
+const (
+	BigEndian    = "big"
+	LittleEndian = "little"
+)
+
+// ErrInvalidSchema indicates an invalid decoding schema.
+var ErrInvalidSchema = errors.New("invalid binary schema")
+
+type fieldType struct {
+	size   int
+	signed bool
+	// integer types support bitfields.
+	integer bool
+}
+
+// types are the field types by name. String and bytes fields take the size
+// of their length.
+var types = map[string]fieldType{
+	Uint8:   {size: 1, integer: true},
+	Int8:    {size: 1, signed: true, integer: true},
+	Uint16:  {size: 2, integer: true},
+	Int16:   {size: 2, signed: true, integer: true},
+	Uint24:  {size: 3, integer: true},
+	Int24:   {size: 3, signed: true, integer: true},
+	Uint32:  {size: 4, integer: true},
+	Int32:   {size: 4, signed: true, integer: true},
+	Uint64:  {size: 8, integer: true},
+	Int64:   {size: 8, signed: true, integer: true},
+	Float32: {size: 4},
+	Float64: {size: 8},
+	Bool:    {size: 1, integer: true},
+	String:  {},
+	Bytes:   {},
+}
+
+// Schema describes the layout of a binary payload.
+type Schema struct {
+	// Name prefixes the names of the fields, as the SenML base name.
+	Name string `toml:"name"`
+	// Endianness is the byte order of the fields, big endian by default.
+	Endianness string  `toml:"endianness"`
+	Fields     []Field `toml:"fields"`
+}
+
+// Field describes a value of a binary payload.
+type Field struct {
+	Name string `toml:"name"`
+	// Offset is the position of the first byte of the field.
+	Offset int    `toml:"offset"`
+	Type   string `toml:"type"`
+	// Length is the number of bytes of string and bytes fields.
+	Length int `toml:"length"`
+	// Endianness overrides the byte order of the schema.
+	Endianness string `toml:"endianness"`
+	// BitOffset and BitLength select the bits of an integer or bool field,
+	// counting from its least significant bit. Signed bitfields are sign
+	// extended from their most significant bit.
+	BitOffset int `toml:"bit_offset"`
+	BitLength int `toml:"bit_length"`
+	// Scale and Bias convert a number to Scale * value + Bias. The scale
+	// defaults to 1.
+	Scale float64 `toml:"scale"`
+	Bias  float64 `toml:"bias"`
+	Unit  string  `toml:"unit"`
+}
+
+// Validate returns an error if the schema can not decode payloads.
+func (s Schema) Validate() error {
+	if err := validateEndianness(s.Endianness); err != nil {
+		return errors.Wrap(ErrInvalidSchema, err)
+	}
+	if len(s.Fields) == 0 {
+		return errors.Wrap(ErrInvalidSchema, errors.New("schema has no fields"))
+	}
+	names := map[string]bool{}
+	for i, f := range s.Fields {
+		if f.Name == "" {
+			return errors.Wrap(ErrInvalidSchema, fmt.Errorf("field %d has no name", i))
+		}
+		if names[f.Name] {
+			return errors.Wrap(ErrInvalidSchema, fmt.Errorf("field %s is declared twice", f.Name))
+		}
+		names[f.Name] = true
+		if err := f.validate(); err != nil {
+			return errors.Wrap(ErrInvalidSchema, fmt.Errorf("field %s: %w", f.Name, err))
+		}
+	}
+
+	return nil
+}
+
+func (f Field) validate() error {
+	t, ok := types[f.Type]
+	if !ok {
+		return fmt.Errorf("unknown type %q", f.Type)
+	}
+	if f.Offset < 0 {
+		return fmt.Errorf("negative offset %d", f.Offset)
+	}
+	if err := validateEndianness(f.Endianness); err != nil {
+		return err
+	}
+	switch f.Type {
+	case String, Bytes:
+		if f.Length <= 0 {
+			return fmt.Errorf("%s field needs a positive length", f.Type)
+		}
+	default:
+		if f.Length != 0 {
+			return fmt.Errorf("length is only supported by string and bytes fields")
+		}
+	}
+	if f.BitOffset == 0 && f.BitLength == 0 {
+		return nil
+	}
+	if !t.integer {
+		return fmt.Errorf("bitfields are only supported by integer and bool fields")
+	}
+	if f.BitOffset < 0 || f.BitLength <= 0 || f.BitOffset+f.BitLength > t.size*8 {
+		return fmt.Errorf("bits %d to %d are out of the %d bits of %s", f.BitOffset, f.BitOffset+f.BitLength-1, t.size*8, f.Type)
+	}
+
+	return nil
+}
+
+func validateEndianness(e string) error {
+	switch e {
+	case "", BigEndian, LittleEndian:
+		return nil
+	default:
+		return fmt.Errorf("unknown endianness %q", e)
+	}
+}
+
+// size returns the number of bytes of the field.
+func (f Field) size() int {
+	if f.Type == String || f.Type == Bytes {
+		return f.Length
+	}
+
+	return types[f.Type].size
+}
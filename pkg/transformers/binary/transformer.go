@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+// ErrTransform indicates a payload which does not match the schema.
+var ErrTransform = errors.New("unable to decode binary payload")
+
+type transformer struct {
+	schema Schema
+	// length is the smallest payload length which holds all the fields.
+	length int
+}
+
+// New returns transformer service implementation for binary payloads laid
+// out as the schema describes.
+func New(schema Schema) (transformers.Transformer, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	tr := transformer{schema: schema}
+	for _, f := range schema.Fields {
+		tr.length = max(tr.length, f.Offset+f.size())
+	}
+
+	return tr, nil
+}
+
+// Transform decodes each field of the schema to a SenML record named after
+// the schema and the field names.
+func (tr transformer) Transform(msg *messaging.Message) (any, error) {
+	payload := msg.GetPayload()
+	if len(payload) < tr.length {
+		return nil, errors.Wrap(ErrTransform, fmt.Errorf("payload has %d bytes, schema needs %d", len(payload), tr.length))
+	}
+
+	msgs := make([]senml.Message, len(tr.schema.Fields))
+	for i, f := range tr.schema.Fields {
+		m := senml.Message{
+			Domain:    msg.GetDomain(),
+			Channel:   msg.GetChannel(),
+			Subtopic:  msg.GetSubtopic(),
+			Publisher: msg.GetPublisher(),
+			Protocol:  msg.GetProtocol(),
+			Name:      tr.schema.Name + f.Name,
+			Unit:      f.Unit,
+			Time:      float64(msg.GetCreated()),
+		}
+		b := payload[f.Offset : f.Offset+f.size()]
+		switch f.Type {
+		case String:
+			v := strings.TrimRight(string(b), "\x00")
+			m.StringValue = &v
+		case Bytes:
+			v := base64.StdEncoding.EncodeToString(b)
+			m.DataValue = &v
+		case Bool:
+			v := tr.integer(f, b) != 0
+			m.BoolValue = &v
+		case Float32:
+			v := float64(math.Float32frombits(uint32(tr.unsigned(f, b))))
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, errors.Wrap(ErrTransform, fmt.Errorf("field %s is not a number", f.Name))
+			}
+			m.Value = scale(f, v)
+		case Float64:
+			v := math.Float64frombits(tr.unsigned(f, b))
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, errors.Wrap(ErrTransform, fmt.Errorf("field %s is not a number", f.Name))
+			}
+			m.Value = scale(f, v)
+		default:
+			m.Value = scale(f, tr.integer(f, b))
+		}
+		msgs[i] = m
+	}
+
+	return msgs, nil
+}
+
+// unsigned reads the bytes of the field as an unsigned integer.
+func (tr transformer) unsigned(f Field, b []byte) uint64 {
+	endianness := f.Endianness
+	if endianness == "" {
+		endianness = tr.schema.Endianness
+	}
+	var v uint64
+	for i := range b {
+		if endianness == LittleEndian {
+			v |= uint64(b[i]) << (8 * i)
+			continue
+		}
+		v = v<<8 | uint64(b[i])
+	}
+
+	return v
+}
+
+// integer reads the bits of an integer field, extending the sign of signed
+// fields.
+func (tr transformer) integer(f Field, b []byte) float64 {
+	v := tr.unsigned(f, b)
+	bits := len(b) * 8
+	if f.BitLength > 0 {
+		v = v >> f.BitOffset & (1<<f.BitLength - 1)
+		bits = f.BitLength
+	}
+	if !types[f.Type].signed {
+		return float64(v)
+	}
+	// Shift the sign bit to the top and back to extend it.
+	shift := 64 - bits
+
+	return float64(int64(v<<shift) >> shift)
+}
+
+func scale(f Field, v float64) *float64 {
+	if f.Scale != 0 {
+		v *= f.Scale
+	}
+	v += f.Bias
+
+	return &v
+}
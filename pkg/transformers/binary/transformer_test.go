@@ -0,0 +1,225 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/binary"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var schema = binary.Schema{
+	Name:       "env/",
+	Endianness: binary.LittleEndian,
+	Fields: []binary.Field{
+		{Name: "temperature", Offset: 0, Type: binary.Int16, Scale: 0.01, Unit: "Cel"},
+		{Name: "humidity", Offset: 2, Type: binary.Uint8, Scale: 0.5, Unit: "%RH"},
+		{Name: "pressure", Offset: 3, Type: binary.Uint24, Endianness: binary.BigEndian, Unit: "Pa"},
+		{Name: "battery", Offset: 6, Type: binary.Uint8, BitOffset: 0, BitLength: 4, Scale: 0.1, Bias: 2.0, Unit: "V"},
+		{Name: "alarm", Offset: 6, Type: binary.Bool, BitOffset: 7, BitLength: 1},
+		{Name: "trend", Offset: 6, Type: binary.Int8, BitOffset: 4, BitLength: 3},
+		{Name: "ratio", Offset: 7, Type: binary.Float32},
+		{Name: "label", Offset: 11, Type: binary.String, Length: 4},
+		{Name: "raw", Offset: 15, Type: binary.Bytes, Length: 2},
+	},
+}
+
+func record(name, unit string) senml.Message {
+	return senml.Message{
+		Channel:   "channel",
+		Subtopic:  "subtopic",
+		Publisher: "publisher",
+		Protocol:  "protocol",
+		Name:      name,
+		Unit:      unit,
+		Time:      42,
+	}
+}
+
+func withValue(m senml.Message, v float64) senml.Message {
+	m.Value = &v
+	return m
+}
+
+func withBool(m senml.Message, v bool) senml.Message {
+	m.BoolValue = &v
+	return m
+}
+
+func withString(m senml.Message, v string) senml.Message {
+	m.StringValue = &v
+	return m
+}
+
+func withData(m senml.Message, v string) senml.Message {
+	m.DataValue = &v
+	return m
+}
+
+func TestTransform(t *testing.T) {
+	tr, err := binary.New(schema)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	payload := []byte{
+		0x0c, 0xfe, // temperature -5.00
+		0x64,             // humidity 50
+		0x01, 0x86, 0xa0, // pressure 100000
+		0xe5,                   // alarm 1, trend -2, battery 5
+		0x00, 0x00, 0x40, 0x3f, // ratio 0.75
+		'a', 'b', 0x00, 0x00, // label
+		0xca, 0xfe, // raw
+	}
+
+	cases := []struct {
+		desc    string
+		payload []byte
+		msgs    any
+		err     error
+	}{
+		{
+			desc:    "transform binary payload",
+			payload: payload,
+			msgs: []senml.Message{
+				withValue(record("env/temperature", "Cel"), -5),
+				withValue(record("env/humidity", "%RH"), 50),
+				withValue(record("env/pressure", "Pa"), 100000),
+				withValue(record("env/battery", "V"), 2.5),
+				withBool(record("env/alarm", ""), true),
+				withValue(record("env/trend", ""), -2),
+				withValue(record("env/ratio", ""), 0.75),
+				withString(record("env/label", ""), "ab"),
+				withData(record("env/raw", ""), base64.StdEncoding.EncodeToString([]byte{0xca, 0xfe})),
+			},
+		},
+		{
+			desc:    "transform binary payload with trailing bytes",
+			payload: append(append([]byte{}, payload...), 0xff),
+			msgs: []senml.Message{
+				withValue(record("env/temperature", "Cel"), -5),
+				withValue(record("env/humidity", "%RH"), 50),
+				withValue(record("env/pressure", "Pa"), 100000),
+				withValue(record("env/battery", "V"), 2.5),
+				withBool(record("env/alarm", ""), true),
+				withValue(record("env/trend", ""), -2),
+				withValue(record("env/ratio", ""), 0.75),
+				withString(record("env/label", ""), "ab"),
+				withData(record("env/raw", ""), base64.StdEncoding.EncodeToString([]byte{0xca, 0xfe})),
+			},
+		},
+		{
+			desc:    "transform short binary payload",
+			payload: payload[:10],
+			msgs:    nil,
+			err:     binary.ErrTransform,
+		},
+		{
+			desc:    "transform binary payload with NaN float",
+			payload: append(append([]byte{}, payload[:7]...), append([]byte{0x00, 0x00, 0xc0, 0x7f}, payload[11:]...)...),
+			msgs:    nil,
+			err:     binary.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		msg := &messaging.Message{
+			Channel:   "channel",
+			Subtopic:  "subtopic",
+			Publisher: "publisher",
+			Protocol:  "protocol",
+			Payload:   tc.payload,
+			Created:   42,
+		}
+		msgs, err := tr.Transform(msg)
+		assert.Equal(t, tc.msgs, msgs, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.msgs, msgs))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		schema binary.Schema
+		err    error
+	}{
+		{
+			desc:   "validate valid schema",
+			schema: schema,
+			err:    nil,
+		},
+		{
+			desc:   "validate schema without fields",
+			schema: binary.Schema{Name: "env/"},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate schema with unknown endianness",
+			schema: binary.Schema{Endianness: "middle", Fields: []binary.Field{{Name: "a", Type: binary.Uint8}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate field without name",
+			schema: binary.Schema{Fields: []binary.Field{{Type: binary.Uint8}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate duplicated field",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.Uint8}, {Name: "a", Offset: 1, Type: binary.Uint8}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate field of unknown type",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: "uint128"}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate field with negative offset",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Offset: -1, Type: binary.Uint8}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate field with unknown endianness",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.Uint16, Endianness: "middle"}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate string field without length",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.String}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate integer field with length",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.Uint16, Length: 2}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate float bitfield",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.Float32, BitLength: 4}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate bitfield out of field",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.Uint8, BitOffset: 6, BitLength: 4}}},
+			err:    binary.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate bitfield without length",
+			schema: binary.Schema{Fields: []binary.Field{{Name: "a", Type: binary.Uint8, BitOffset: 2}}},
+			err:    binary.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.schema.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		_, err = binary.New(tc.schema)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+}
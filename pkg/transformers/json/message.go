@@ -8,6 +8,7 @@ type Payload map[string]any
 
 // Message represents a JSON messages.
 type Message struct {
+	Domain    string  `json:"domain,omitempty" db:"domain" bson:"domain,omitempty"`
 	Channel   string  `json:"channel,omitempty" db:"channel" bson:"channel"`
 	Created   int64   `json:"created,omitempty" db:"created" bson:"created"`
 	Subtopic  string  `json:"subtopic,omitempty" db:"subtopic" bson:"subtopic,omitempty"`
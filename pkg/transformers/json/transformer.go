@@ -50,6 +50,7 @@ func New(tfs []TimeField) transformers.Transformer {
 // Transform transforms Magistrala message to a list of JSON messages.
 func (ts *transformerService) Transform(msg *messaging.Message) (any, error) {
 	ret := Message{
+		Domain:    msg.GetDomain(),
 		Publisher: msg.GetPublisher(),
 		Created:   msg.GetCreated(),
 		Protocol:  msg.GetProtocol(),
@@ -5,6 +5,7 @@ package senml
 
 // Message represents a resolved (normalized) SenML record.
 type Message struct {
+	Domain      string   `json:"domain,omitempty" db:"domain" bson:"domain,omitempty"`
 	Channel     string   `json:"channel,omitempty" db:"channel" bson:"channel"`
 	Subtopic    string   `json:"subtopic,omitempty" db:"subtopic" bson:"subtopic,omitempty"`
 	Publisher   string   `json:"publisher,omitempty" db:"publisher" bson:"publisher"`
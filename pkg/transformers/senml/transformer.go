@@ -74,6 +74,7 @@ func (t transformer) Transform(msg *messaging.Message) (any, error) {
 		}
 
 		msgs[i] = Message{
+			Domain:      msg.GetDomain(),
 			Channel:     msg.GetChannel(),
 			Subtopic:    msg.GetSubtopic(),
 			Publisher:   msg.GetPublisher(),
@@ -0,0 +1,183 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Sparkplug B metric data types.
+const (
+	Int8     uint32 = 1
+	Int16    uint32 = 2
+	Int32    uint32 = 3
+	Int64    uint32 = 4
+	UInt8    uint32 = 5
+	UInt16   uint32 = 6
+	UInt32   uint32 = 7
+	UInt64   uint32 = 8
+	Float    uint32 = 9
+	Double   uint32 = 10
+	Boolean  uint32 = 11
+	String   uint32 = 12
+	DateTime uint32 = 13
+	Text     uint32 = 14
+	UUID     uint32 = 15
+	DataSet  uint32 = 16
+	Bytes    uint32 = 17
+	File     uint32 = 18
+	Template uint32 = 19
+)
+
+// Field numbers of the Sparkplug B Payload and Payload.Metric messages.
+const (
+	payloadTimestamp = 1
+	payloadMetrics   = 2
+	payloadSeq       = 3
+
+	metricName        = 1
+	metricAlias       = 2
+	metricTimestamp   = 3
+	metricDatatype    = 4
+	metricIsNull      = 7
+	metricIntValue    = 10
+	metricLongValue   = 11
+	metricFloatValue  = 12
+	metricDoubleValue = 13
+	metricBoolValue   = 14
+	metricStringValue = 15
+	metricBytesValue  = 16
+)
+
+// payload is the part of the Sparkplug B payload which is transformed.
+type payload struct {
+	timestamp uint64
+	seq       uint64
+	metrics   []metric
+}
+
+type metric struct {
+	name      string
+	alias     uint64
+	hasAlias  bool
+	timestamp uint64
+	datatype  uint32
+	isNull    bool
+	// value holds a uint32, uint64, float32, float64, bool, string or
+	// []byte, or nil for the values which are not transformed.
+	value any
+}
+
+// decodePayload decodes the protobuf encoded Sparkplug B payload. Unknown
+// fields, and metric values such as data sets and templates, are skipped.
+func decodePayload(b []byte) (payload, error) {
+	var p payload
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch {
+		case num == payloadTimestamp && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			p.timestamp = v
+			return n
+		case num == payloadSeq && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			p.seq = v
+			return n
+		case num == payloadMetrics && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return n
+			}
+			m, err := decodeMetric(v)
+			if err != nil {
+				return -1
+			}
+			p.metrics = append(p.metrics, m)
+			return n
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b)
+		}
+	})
+
+	return p, err
+}
+
+func decodeMetric(b []byte) (metric, error) {
+	var m metric
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		switch {
+		case num == metricName && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			m.name = string(v)
+			return n
+		case num == metricAlias && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.alias, m.hasAlias = v, true
+			return n
+		case num == metricTimestamp && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.timestamp = v
+			return n
+		case num == metricDatatype && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.datatype = uint32(v)
+			return n
+		case num == metricIsNull && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.isNull = protowire.DecodeBool(v)
+			return n
+		case num == metricIntValue && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.value = uint32(v)
+			return n
+		case num == metricLongValue && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.value = v
+			return n
+		case num == metricFloatValue && typ == protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(b)
+			m.value = math.Float32frombits(v)
+			return n
+		case num == metricDoubleValue && typ == protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			m.value = math.Float64frombits(v)
+			return n
+		case num == metricBoolValue && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			m.value = protowire.DecodeBool(v)
+			return n
+		case num == metricStringValue && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			m.value = string(v)
+			return n
+		case num == metricBytesValue && typ == protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			m.value = append([]byte(nil), v...)
+			return n
+		default:
+			return protowire.ConsumeFieldValue(num, typ, b)
+		}
+	})
+
+	return m, err
+}
+
+// consumeFields calls consume with the value of each field of the message.
+// Consume returns the length of the value, or a negative number on error.
+func consumeFields(b []byte, consume func(num protowire.Number, typ protowire.Type, b []byte) int) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if n = consume(num, typ, b); n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+
+	return nil
+}
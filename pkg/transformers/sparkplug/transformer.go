@@ -0,0 +1,308 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+)
+
+const (
+	// Namespace is the first topic level of Sparkplug B messages.
+	Namespace = "spBv1.0"
+	// DefMaxNodes is the default maximum number of edge nodes whose births
+	// the transformer keeps.
+	DefMaxNodes = 10000
+)
+
+// Sparkplug B message types.
+const (
+	NodeBirth   = "NBIRTH"
+	NodeDeath   = "NDEATH"
+	DeviceBirth = "DBIRTH"
+	DeviceDeath = "DDEATH"
+	NodeData    = "NDATA"
+	DeviceData  = "DDATA"
+)
+
+var (
+	errDecode       = errors.New("failed to decode sparkplug payload")
+	errInvalidTopic = errors.New("subtopic is not a sparkplug topic")
+)
+
+// topic is the Sparkplug B topic of a message.
+type topic struct {
+	group   string
+	msgType string
+	node    string
+	device  string
+}
+
+// parseTopic parses the Sparkplug B topic which ends the subtopic:
+// spBv1.0/<group_id>/<message_type>/<edge_node_id>[/<device_id>].
+func parseTopic(subtopic string) (topic, error) {
+	levels := strings.Split(subtopic, "/")
+	for i, l := range levels {
+		if l != Namespace {
+			continue
+		}
+		switch rest := levels[i+1:]; len(rest) {
+		case 3:
+			return topic{group: rest[0], msgType: rest[1], node: rest[2]}, nil
+		case 4:
+			return topic{group: rest[0], msgType: rest[1], node: rest[2], device: rest[3]}, nil
+		}
+	}
+
+	return topic{}, errInvalidTopic
+}
+
+// name is the name of the edge node or the device of the topic.
+func (t topic) name() string {
+	if t.device == "" {
+		return t.node
+	}
+
+	return t.node + "/" + t.device
+}
+
+// node holds the metrics an edge node and its devices declared in their
+// births, by alias and by name.
+type node struct {
+	aliases map[uint64]string
+	types   map[string]uint32
+	seen    time.Time
+}
+
+type transformer struct {
+	mu       sync.Mutex
+	nodes    map[string]*node
+	maxNodes int
+	logger   *slog.Logger
+}
+
+// New returns transformer service implementation for Sparkplug B messages.
+// The transformer keeps the metrics declared in the births of each edge node
+// to resolve the aliases and the data types of the metrics of its data
+// messages. It keeps the births of at most maxNodes edge nodes, and a new
+// edge node replaces the least recently seen one.
+func New(maxNodes int, logger *slog.Logger) transformers.Transformer {
+	return &transformer{
+		nodes:    map[string]*node{},
+		maxNodes: max(maxNodes, 1),
+		logger:   logger,
+	}
+}
+
+// Transform transforms the metrics of births and data messages to SenML
+// records named <edge_node_id>[/<device_id>]/<metric_name>. Births and deaths
+// also produce a record named <edge_node_id>[/<device_id>] whose boolean
+// value reports whether the edge node or device is online. Other Sparkplug B
+// messages, such as commands, produce no records.
+func (tr *transformer) Transform(msg *messaging.Message) (any, error) {
+	t, err := parseTopic(msg.GetSubtopic())
+	if err != nil {
+		return nil, err
+	}
+	switch t.msgType {
+	case NodeBirth, DeviceBirth, NodeData, DeviceData, NodeDeath, DeviceDeath:
+	default:
+		return []senml.Message{}, nil
+	}
+
+	p, err := decodePayload(msg.GetPayload())
+	if err != nil {
+		return nil, errors.Wrap(errDecode, err)
+	}
+
+	base := senml.Message{
+		Domain:    msg.GetDomain(),
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Time:      float64(msg.GetCreated()),
+	}
+	if p.timestamp > 0 {
+		base.Time = toNano(p.timestamp)
+	}
+
+	key := strings.Join([]string{msg.GetDomain(), msg.GetChannel(), t.group, t.node}, "/")
+	switch t.msgType {
+	case NodeDeath:
+		tr.mu.Lock()
+		delete(tr.nodes, key)
+		tr.mu.Unlock()
+		return []senml.Message{status(base, t, false)}, nil
+	case DeviceDeath:
+		return []senml.Message{status(base, t, false)}, nil
+	}
+
+	msgs := []senml.Message{}
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	n := tr.node(key, t.msgType == NodeBirth)
+	var dropped int
+	for _, m := range p.metrics {
+		switch {
+		case m.name != "":
+			if m.hasAlias {
+				n.aliases[m.alias] = m.name
+			}
+			if m.datatype != 0 {
+				n.types[m.name] = m.datatype
+			}
+		case m.hasAlias:
+			name, ok := n.aliases[m.alias]
+			if !ok {
+				// Without the birth the metric can't be named, and storing
+				// it under its alias would mix metrics across sessions.
+				dropped++
+				continue
+			}
+			m.name = name
+		}
+		if m.datatype == 0 {
+			m.datatype = n.types[m.name]
+		}
+		if rec, ok := record(base, t, m); ok {
+			msgs = append(msgs, rec)
+		}
+	}
+	if t.msgType == NodeBirth || t.msgType == DeviceBirth {
+		msgs = append(msgs, status(base, t, true))
+	}
+	if dropped > 0 {
+		tr.logger.Warn(fmt.Sprintf("Dropped %d sparkplug metrics with aliases not declared in a received birth", dropped),
+			slog.String("domain_id", msg.GetDomain()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("group_id", t.group),
+			slog.String("edge_node", t.name()),
+		)
+	}
+
+	return msgs, nil
+}
+
+// node returns the metrics of the edge node. A node birth starts a new
+// session of the edge node, which drops the metrics of the previous one.
+func (tr *transformer) node(key string, reset bool) *node {
+	n, ok := tr.nodes[key]
+	if !ok && len(tr.nodes) >= tr.maxNodes {
+		tr.evict()
+	}
+	if !ok || reset {
+		n = &node{aliases: map[uint64]string{}, types: map[string]uint32{}}
+		tr.nodes[key] = n
+	}
+	n.seen = time.Now()
+
+	return n
+}
+
+// evict drops the metrics of the least recently seen edge node.
+func (tr *transformer) evict() {
+	var oldest string
+	var seen time.Time
+	for key, n := range tr.nodes {
+		if oldest == "" || n.seen.Before(seen) {
+			oldest, seen = key, n.seen
+		}
+	}
+	delete(tr.nodes, oldest)
+}
+
+func status(base senml.Message, t topic, online bool) senml.Message {
+	base.Name = t.name()
+	base.BoolValue = &online
+
+	return base
+}
+
+// record returns the SenML record of the metric, or false if the metric has
+// no value which can be stored as a SenML record.
+func record(base senml.Message, t topic, m metric) (senml.Message, bool) {
+	if m.isNull || m.value == nil {
+		return senml.Message{}, false
+	}
+	rec := base
+	rec.Name = t.name() + "/" + m.name
+	if m.timestamp > 0 {
+		rec.Time = toNano(m.timestamp)
+	}
+
+	switch v := m.value.(type) {
+	case uint32:
+		rec.Value = number(intValue(v, m.datatype))
+	case uint64:
+		rec.Value = number(longValue(v, m.datatype))
+	case float32:
+		rec.Value = number(float64(v))
+	case float64:
+		rec.Value = number(v)
+	case bool:
+		rec.BoolValue = &v
+	case string:
+		rec.StringValue = &v
+	case []byte:
+		data := base64.StdEncoding.EncodeToString(v)
+		rec.DataValue = &data
+	default:
+		return senml.Message{}, false
+	}
+	if rec.Value == nil && rec.BoolValue == nil && rec.StringValue == nil && rec.DataValue == nil {
+		return senml.Message{}, false
+	}
+
+	return rec, true
+}
+
+// intValue converts the value of the 32 bits integer types, where signed
+// values are stored in two's complement.
+func intValue(v, datatype uint32) float64 {
+	switch datatype {
+	case Int8:
+		return float64(int8(v))
+	case Int16:
+		return float64(int16(v))
+	case Int32:
+		return float64(int32(v))
+	default:
+		return float64(v)
+	}
+}
+
+// longValue converts the value of the 64 bits integer types.
+func longValue(v uint64, datatype uint32) float64 {
+	if datatype == Int64 {
+		return float64(int64(v))
+	}
+
+	return float64(v)
+}
+
+// number returns nil for the values which are not numbers.
+func number(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+
+	return &v
+}
+
+// toNano converts the Sparkplug B timestamp, which is always in milliseconds,
+// to nanoseconds.
+func toNano(ms uint64) float64 {
+	return float64(ms) * 1e6
+}
@@ -0,0 +1,310 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sparkplug_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"testing"
+
+	mglog "github.com/absmach/magistrala/logger"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/transformers/senml"
+	"github.com/absmach/magistrala/pkg/transformers/sparkplug"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	birthTime  = 1700000000000
+	dataTime   = 1700000001000
+	metricTime = 1700000002000
+)
+
+// metric is a Sparkplug B metric encoded by the test.
+type metric struct {
+	name      string
+	alias     uint64
+	timestamp uint64
+	datatype  uint32
+	isNull    bool
+	value     any
+}
+
+func encodeMetric(m metric) []byte {
+	var b []byte
+	if m.name != "" {
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendString(b, m.name)
+	}
+	if m.alias != 0 {
+		b = protowire.AppendTag(b, 2, protowire.VarintType)
+		b = protowire.AppendVarint(b, m.alias)
+	}
+	if m.timestamp != 0 {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, m.timestamp)
+	}
+	if m.datatype != 0 {
+		b = protowire.AppendTag(b, 4, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.datatype))
+	}
+	if m.isNull {
+		b = protowire.AppendTag(b, 7, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	switch v := m.value.(type) {
+	case uint32:
+		b = protowire.AppendTag(b, 10, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v))
+	case uint64:
+		b = protowire.AppendTag(b, 11, protowire.VarintType)
+		b = protowire.AppendVarint(b, v)
+	case float32:
+		b = protowire.AppendTag(b, 12, protowire.Fixed32Type)
+		b = protowire.AppendFixed32(b, math.Float32bits(v))
+	case float64:
+		b = protowire.AppendTag(b, 13, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(v))
+	case bool:
+		b = protowire.AppendTag(b, 14, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v))
+	case string:
+		b = protowire.AppendTag(b, 15, protowire.BytesType)
+		b = protowire.AppendString(b, v)
+	case []byte:
+		b = protowire.AppendTag(b, 16, protowire.BytesType)
+		b = protowire.AppendBytes(b, v)
+	}
+
+	return b
+}
+
+func encodePayload(timestamp uint64, metrics ...metric) []byte {
+	var b []byte
+	if timestamp != 0 {
+		b = protowire.AppendTag(b, 1, protowire.VarintType)
+		b = protowire.AppendVarint(b, timestamp)
+	}
+	for _, m := range metrics {
+		b = protowire.AppendTag(b, 2, protowire.BytesType)
+		b = protowire.AppendBytes(b, encodeMetric(m))
+	}
+	b = protowire.AppendTag(b, 3, protowire.VarintType)
+
+	return protowire.AppendVarint(b, 0)
+}
+
+func message(subtopic string, payload []byte) *messaging.Message {
+	return &messaging.Message{
+		Domain:    "domain",
+		Channel:   "channel",
+		Subtopic:  subtopic,
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Payload:   payload,
+		Created:   42,
+	}
+}
+
+func record(subtopic, name string, time float64) senml.Message {
+	return senml.Message{
+		Domain:    "domain",
+		Channel:   "channel",
+		Subtopic:  subtopic,
+		Publisher: "publisher",
+		Protocol:  "mqtt",
+		Name:      name,
+		Time:      time,
+	}
+}
+
+func withValue(m senml.Message, v float64) senml.Message {
+	m.Value = &v
+	return m
+}
+
+func withBool(m senml.Message, v bool) senml.Message {
+	m.BoolValue = &v
+	return m
+}
+
+func withString(m senml.Message, v string) senml.Message {
+	m.StringValue = &v
+	return m
+}
+
+func withData(m senml.Message, v string) senml.Message {
+	m.DataValue = &v
+	return m
+}
+
+func TestTransform(t *testing.T) {
+	const (
+		nbirth = "spBv1.0/plant/NBIRTH/edge"
+		ndata  = "spBv1.0/plant/NDATA/edge"
+		ndeath = "spBv1.0/plant/NDEATH/edge"
+		dbirth = "spBv1.0/plant/DBIRTH/edge/boiler"
+		ddata  = "spBv1.0/plant/DDATA/edge/boiler"
+		ddeath = "spBv1.0/plant/DDEATH/edge/boiler"
+	)
+
+	tr := sparkplug.New(sparkplug.DefMaxNodes, mglog.NewMock())
+	cases := []struct {
+		desc string
+		msg  *messaging.Message
+		msgs any
+		err  error
+	}{
+		{
+			desc: "transform node birth",
+			msg: message(nbirth, encodePayload(birthTime,
+				metric{name: "temperature", alias: 1, datatype: sparkplug.Double, value: 21.5},
+				metric{name: "offset", alias: 2, datatype: sparkplug.Int16, value: uint32(0xfffe)},
+				metric{name: "running", alias: 3, datatype: sparkplug.Boolean, value: true},
+				metric{name: "firmware", datatype: sparkplug.String, value: "1.0.0"},
+				metric{name: "calibration", alias: 4, datatype: sparkplug.Int64, isNull: true},
+			)),
+			msgs: []senml.Message{
+				withValue(record(nbirth, "edge/temperature", birthTime*1e6), 21.5),
+				withValue(record(nbirth, "edge/offset", birthTime*1e6), -2),
+				withBool(record(nbirth, "edge/running", birthTime*1e6), true),
+				withString(record(nbirth, "edge/firmware", birthTime*1e6), "1.0.0"),
+				withBool(record(nbirth, "edge", birthTime*1e6), true),
+			},
+		},
+		{
+			desc: "transform node data with aliases",
+			msg: message(ndata, encodePayload(dataTime,
+				metric{alias: 1, value: 22.5},
+				metric{alias: 2, value: uint32(0xfffd)},
+				metric{alias: 4, timestamp: metricTime, value: uint64(math.MaxUint64)},
+			)),
+			msgs: []senml.Message{
+				withValue(record(ndata, "edge/temperature", dataTime*1e6), 22.5),
+				withValue(record(ndata, "edge/offset", dataTime*1e6), -3),
+				withValue(record(ndata, "edge/calibration", metricTime*1e6), -1),
+			},
+		},
+		{
+			desc: "transform node data with unknown alias",
+			msg:  message(ndata, encodePayload(dataTime, metric{alias: 9, value: uint32(7)})),
+			msgs: []senml.Message{},
+		},
+		{
+			desc: "transform device birth",
+			msg: message(dbirth, encodePayload(birthTime,
+				metric{name: "pressure", alias: 10, datatype: sparkplug.Float, value: float32(1.5)},
+				metric{name: "image", alias: 11, datatype: sparkplug.Bytes, value: []byte{1, 2, 3}},
+			)),
+			msgs: []senml.Message{
+				withValue(record(dbirth, "edge/boiler/pressure", birthTime*1e6), 1.5),
+				withData(record(dbirth, "edge/boiler/image", birthTime*1e6), base64.StdEncoding.EncodeToString([]byte{1, 2, 3})),
+				withBool(record(dbirth, "edge/boiler", birthTime*1e6), true),
+			},
+		},
+		{
+			desc: "transform device data with aliases",
+			msg:  message(ddata, encodePayload(0, metric{alias: 10, value: float32(2.5)})),
+			msgs: []senml.Message{
+				withValue(record(ddata, "edge/boiler/pressure", 42), 2.5),
+			},
+		},
+		{
+			desc: "transform device death",
+			msg:  message(ddeath, encodePayload(dataTime)),
+			msgs: []senml.Message{
+				withBool(record(ddeath, "edge/boiler", dataTime*1e6), false),
+			},
+		},
+		{
+			desc: "transform node death",
+			msg:  message(ndeath, encodePayload(0)),
+			msgs: []senml.Message{
+				withBool(record(ndeath, "edge", 42), false),
+			},
+		},
+		{
+			desc: "transform node data after node death",
+			msg:  message(ndata, encodePayload(dataTime, metric{alias: 1, value: 22.5})),
+			msgs: []senml.Message{},
+		},
+		{
+			desc: "transform message with subtopic prefix",
+			msg:  message("gateways/spBv1.0/plant/NDATA/other", encodePayload(dataTime, metric{name: "level", value: 3.0})),
+			msgs: []senml.Message{
+				withValue(record("gateways/spBv1.0/plant/NDATA/other", "other/level", dataTime*1e6), 3),
+			},
+		},
+		{
+			desc: "transform node command",
+			msg:  message("spBv1.0/plant/NCMD/edge", encodePayload(0, metric{name: "Node Control/Rebirth", value: true})),
+			msgs: []senml.Message{},
+		},
+		{
+			desc: "transform message with invalid topic",
+			msg:  message("plant/NDATA/edge", encodePayload(dataTime)),
+			msgs: nil,
+			err:  errors.New("subtopic is not a sparkplug topic"),
+		},
+		{
+			desc: "transform message with invalid payload",
+			msg:  message(ndata, []byte{0x12, 0xff}),
+			msgs: nil,
+			err:  errors.New("failed to decode sparkplug payload"),
+		},
+	}
+
+	for _, tc := range cases {
+		msgs, err := tr.Transform(tc.msg)
+		assert.Equal(t, tc.msgs, msgs, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.msgs, msgs))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestTransformEvictsNodes(t *testing.T) {
+	const (
+		ndata = "spBv1.0/plant/NDATA/edge"
+		odata = "spBv1.0/plant/NDATA/other"
+	)
+
+	tr := sparkplug.New(1, mglog.NewMock())
+	birth := encodePayload(0, metric{name: "temperature", alias: 1, datatype: sparkplug.Double, value: 21.5})
+	_, err := tr.Transform(message("spBv1.0/plant/NBIRTH/edge", birth))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		msg  *messaging.Message
+		msgs any
+	}{
+		{
+			desc: "transform data of tracked node",
+			msg:  message(ndata, encodePayload(0, metric{alias: 1, value: 22.5})),
+			msgs: []senml.Message{
+				withValue(record(ndata, "edge/temperature", 42), 22.5),
+			},
+		},
+		{
+			desc: "transform data of new node",
+			msg:  message(odata, encodePayload(0, metric{name: "level", value: 3.0})),
+			msgs: []senml.Message{
+				withValue(record(odata, "other/level", 42), 3),
+			},
+		},
+		{
+			desc: "transform data of evicted node",
+			msg:  message(ndata, encodePayload(0, metric{alias: 1, value: 23.5})),
+			msgs: []senml.Message{},
+		},
+	}
+
+	for _, tc := range cases {
+		msgs, err := tr.Transform(tc.msg)
+		assert.Equal(t, tc.msgs, msgs, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.msgs, msgs))
+		assert.Nil(t, err, fmt.Sprintf("%s unexpected error: %s", tc.desc, err))
+	}
+}
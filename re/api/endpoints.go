@@ -29,7 +29,7 @@ func addRuleEndpoint(s re.Service) endpoint.Endpoint {
 		if err != nil {
 			return addRuleRes{}, err
 		}
-		return addRuleRes{Rule: rule, created: true}, nil
+		return addRuleRes{Rule: rule.Redact(), created: true}, nil
 	}
 }
 
@@ -48,7 +48,7 @@ func viewRuleEndpoint(s re.Service) endpoint.Endpoint {
 		if err != nil {
 			return viewRuleRes{}, err
 		}
-		return viewRuleRes{Rule: rule}, nil
+		return viewRuleRes{Rule: rule.Redact()}, nil
 	}
 }
 
@@ -67,7 +67,7 @@ func updateRuleEndpoint(s re.Service) endpoint.Endpoint {
 		if err != nil {
 			return updateRuleRes{}, err
 		}
-		return updateRuleRes{Rule: rule}, nil
+		return updateRuleRes{Rule: rule.Redact()}, nil
 	}
 }
 
@@ -92,7 +92,7 @@ func updateRuleTagsEndpoint(svc re.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		return updateRuleRes{Rule: res}, nil
+		return updateRuleRes{Rule: res.Redact()}, nil
 	}
 }
 
@@ -117,7 +117,7 @@ func updateRuleScheduleEndpoint(s re.Service) endpoint.Endpoint {
 		if err != nil {
 			return updateRuleRes{}, err
 		}
-		return updateRuleRes{Rule: updatedRule}, nil
+		return updateRuleRes{Rule: updatedRule.Redact()}, nil
 	}
 }
 
@@ -136,6 +136,9 @@ func listRulesEndpoint(s re.Service) endpoint.Endpoint {
 		if err != nil {
 			return rulesPageRes{}, err
 		}
+		for i, r := range page.Rules {
+			page.Rules[i] = r.Redact()
+		}
 		ret := rulesPageRes{
 			Page: page,
 		}
@@ -179,7 +182,7 @@ func enableRuleEndpoint(s re.Service) endpoint.Endpoint {
 			return updateRuleStatusRes{}, err
 		}
 
-		return updateRuleStatusRes{Rule: rule}, err
+		return updateRuleStatusRes{Rule: rule.Redact()}, err
 	}
 }
 
@@ -200,6 +203,178 @@ func disableRuleEndpoint(s re.Service) endpoint.Endpoint {
 			return updateRuleStatusRes{}, err
 		}
 
-		return updateRuleStatusRes{Rule: rule}, err
+		return updateRuleStatusRes{Rule: rule.Redact()}, err
+	}
+}
+
+func testRuleEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(testRuleReq)
+		if err := req.validate(); err != nil {
+			return testRuleRes{}, err
+		}
+
+		res, err := s.TestRule(ctx, session, req.Rule, req.message())
+		if err != nil {
+			return testRuleRes{}, err
+		}
+
+		return testRuleRes{TestResult: res}, nil
+	}
+}
+
+func listExecutionsEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(listExecutionsReq)
+		if err := req.validate(); err != nil {
+			return executionsPageRes{}, err
+		}
+
+		page, err := s.ListExecutions(ctx, session, req.ExecutionPageMeta)
+		if err != nil {
+			return executionsPageRes{}, err
+		}
+
+		return executionsPageRes{ExecutionsPage: page}, nil
+	}
+}
+
+func listRuleVersionsEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(listRuleVersionsReq)
+		if err := req.validate(); err != nil {
+			return versionsPageRes{}, err
+		}
+
+		page, err := s.ListRuleVersions(ctx, session, req.VersionPageMeta)
+		if err != nil {
+			return versionsPageRes{}, err
+		}
+
+		for i, v := range page.Versions {
+			page.Versions[i] = v.Redact()
+		}
+
+		return versionsPageRes{VersionsPage: page}, nil
+	}
+}
+
+func diffRuleVersionsEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(diffRuleVersionsReq)
+		if err := req.validate(); err != nil {
+			return versionDiffRes{}, err
+		}
+
+		diff, err := s.DiffRuleVersions(ctx, session, req.id, req.from, req.to)
+		if err != nil {
+			return versionDiffRes{}, err
+		}
+
+		return versionDiffRes{VersionDiff: diff}, nil
+	}
+}
+
+func restoreRuleVersionEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(restoreRuleVersionReq)
+		if err := req.validate(); err != nil {
+			return updateRuleRes{}, err
+		}
+
+		rule, err := s.RestoreRuleVersion(ctx, session, req.id, req.version)
+		if err != nil {
+			return updateRuleRes{}, err
+		}
+
+		return updateRuleRes{Rule: rule.Redact()}, nil
+	}
+}
+
+func listDeadLettersEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(listDeadLettersReq)
+		if err := req.validate(); err != nil {
+			return deadLettersPageRes{}, err
+		}
+
+		page, err := s.ListDeadLetters(ctx, session, req.DeadLetterPageMeta)
+		if err != nil {
+			return deadLettersPageRes{}, err
+		}
+
+		return deadLettersPageRes{DeadLettersPage: page}, nil
+	}
+}
+
+func viewDeadLetterEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return deadLetterRes{}, err
+		}
+
+		dl, err := s.ViewDeadLetter(ctx, session, req.ruleID, req.id)
+		if err != nil {
+			return deadLetterRes{}, err
+		}
+
+		return deadLetterRes{DeadLetter: dl}, nil
+	}
+}
+
+func replayDeadLetterEndpoint(s re.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		session, ok := ctx.Value(authn.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthorization
+		}
+
+		req := request.(deadLetterReq)
+		if err := req.validate(); err != nil {
+			return deadLetterRes{}, err
+		}
+
+		dl, err := s.ReplayDeadLetter(ctx, session, req.ruleID, req.id)
+		if err != nil {
+			return deadLetterRes{}, err
+		}
+
+		return deadLetterRes{DeadLetter: dl}, nil
 	}
 }
@@ -254,6 +254,135 @@ func TestAddRuleEndpoint(t *testing.T) {
 	}
 }
 
+func TestTestRuleEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	testRule := re.Rule{
+		Name:         namegen.Generate(),
+		InputChannel: "channel",
+		Logic: re.Script{
+			Type:  re.LuaType,
+			Value: "return message.payload",
+		},
+	}
+	validReq := map[string]any{
+		"rule": testRule,
+		"message": map[string]any{
+			"subtopic": "temperature",
+			"payload":  map[string]any{"temperature": 25.5},
+		},
+	}
+	session := smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc        string
+		req         any
+		domainID    string
+		token       string
+		contentType string
+		status      int
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      re.TestResult
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "test rule successfully",
+			req:         validReq,
+			token:       validToken,
+			contentType: contentType,
+			domainID:    domainID,
+			authnRes:    session,
+			status:      http.StatusOK,
+			svcRes:      re.TestResult{Result: map[string]any{"temperature": 25.5}},
+		},
+		{
+			desc:        "test rule with invalid token",
+			req:         validReq,
+			token:       invalidToken,
+			domainID:    domainID,
+			contentType: contentType,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc: "test rule with name that is too long",
+			req: map[string]any{
+				"rule": re.Rule{Name: strings.Repeat("a", 1025)},
+			},
+			token:       validToken,
+			domainID:    domainID,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrNameSize,
+		},
+		{
+			desc:        "test rule with invalid content type",
+			req:         validReq,
+			token:       validToken,
+			domainID:    domainID,
+			contentType: "application/xml",
+			authnRes:    session,
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "test rule with malformed body",
+			req:         "invalid",
+			token:       validToken,
+			domainID:    domainID,
+			contentType: contentType,
+			authnRes:    session,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMalformedRequestBody,
+		},
+		{
+			desc:        "test rule with service error",
+			req:         validReq,
+			token:       validToken,
+			domainID:    domainID,
+			contentType: contentType,
+			authnRes:    session,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/rules/test", ts.URL, tc.domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(toJSON(tc.req)),
+			}
+
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("TestRule", mock.Anything, tc.authnRes, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var errRes respBody
+			err = json.NewDecoder(res.Body).Decode(&errRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if errRes.Err != "" || errRes.Message != "" {
+				err = errors.Wrap(errors.New(errRes.Err), errors.New(errRes.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestViewRuleEndpoint(t *testing.T) {
 	ts, svc, authn := newRuleEngineServer()
 	defer ts.Close()
@@ -492,63 +621,520 @@ func TestListRulesEndpoint(t *testing.T) {
 			err:    nil,
 		},
 		{
-			desc:     "list rules with invalid status",
-			domainID: domainID,
-			token:    validToken,
-			query:    "status=invalid",
-			status:   http.StatusBadRequest,
-			err:      svcerr.ErrInvalidStatus,
+			desc:     "list rules with invalid status",
+			domainID: domainID,
+			token:    validToken,
+			query:    "status=invalid",
+			status:   http.StatusBadRequest,
+			err:      svcerr.ErrInvalidStatus,
+		},
+		{
+			desc:     "list rules with duplicate status",
+			domainID: domainID,
+			token:    validToken,
+			query:    "status=enabled&status=disabled",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:     "list rules with duplicate tags",
+			domainID: domainID,
+			token:    validToken,
+			query:    "tag=tag1&tag=tag2",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:              "list rules with service error",
+			domainID:          domainID,
+			token:             validToken,
+			listRulesResponse: re.Page{},
+			status:            http.StatusForbidden,
+			err:               svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodGet,
+				url:         ts.URL + "/" + tc.domainID + "/rules?" + tc.query,
+				contentType: contentType,
+				token:       tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListRules", mock.Anything, tc.session, mock.Anything).Return(tc.listRulesResponse, tc.err)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var bodyRes respBody
+			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if bodyRes.Err != "" || bodyRes.Message != "" {
+				err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListExecutionsEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	execution := re.Execution{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     rule.ID,
+		DomainID:   domainID,
+		Level:      "INFO",
+		Message:    "rule processed successfully",
+		ExecutedAt: time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc     string
+		query    string
+		domainID string
+		ruleID   string
+		token    string
+		session  smqauthn.Session
+		pm       re.ExecutionPageMeta
+		svcRes   re.ExecutionsPage
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "list executions successfully",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			pm:       re.ExecutionPageMeta{RuleID: rule.ID, Limit: 10, Dir: "desc"},
+			svcRes: re.ExecutionsPage{
+				Total:      1,
+				Limit:      10,
+				Executions: []re.Execution{execution},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:     "list executions with level, offset and direction",
+			query:    "level=error&offset=1&dir=asc",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			pm:       re.ExecutionPageMeta{RuleID: rule.ID, Offset: 1, Limit: 10, Dir: "asc", Level: "ERROR"},
+			svcRes:   re.ExecutionsPage{Offset: 1, Limit: 10},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list executions with empty token",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    "",
+			status:   http.StatusUnauthorized,
+			err:      apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "list executions with invalid token",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    invalidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list executions with invalid offset",
+			query:    "offset=invalid",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:     "list executions with limit that is too big",
+			query:    "limit=10000",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrLimitSize,
+		},
+		{
+			desc:     "list executions with invalid direction",
+			query:    "dir=invalid",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidDirection,
+		},
+		{
+			desc:     "list executions with invalid level",
+			query:    "level=debug",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list executions with service error",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			pm:       re.ExecutionPageMeta{RuleID: rule.ID, Limit: 10, Dir: "desc"},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodGet,
+				url:         fmt.Sprintf("%s/%s/rules/%s/executions?%s", ts.URL, tc.domainID, tc.ruleID, tc.query),
+				contentType: contentType,
+				token:       tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListExecutions", mock.Anything, tc.session, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var bodyRes respBody
+			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if bodyRes.Err != "" || bodyRes.Message != "" {
+				err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListRuleVersionsEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	version := re.RuleVersion{
+		RuleID:    rule.ID,
+		Version:   1,
+		DomainID:  domainID,
+		Logic:     re.Script{Type: re.LuaType, Value: "return true"},
+		CreatedAt: time.Now().UTC(),
+		CreatedBy: userID,
+	}
+
+	cases := []struct {
+		desc     string
+		query    string
+		domainID string
+		ruleID   string
+		token    string
+		session  smqauthn.Session
+		pm       re.VersionPageMeta
+		svcRes   re.VersionsPage
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:     "list rule versions successfully",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			pm:       re.VersionPageMeta{RuleID: rule.ID, Limit: 10},
+			svcRes: re.VersionsPage{
+				Total:    1,
+				Limit:    10,
+				Versions: []re.RuleVersion{version},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:     "list rule versions with offset and limit",
+			query:    "offset=1&limit=5",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			pm:       re.VersionPageMeta{RuleID: rule.ID, Offset: 1, Limit: 5},
+			svcRes:   re.VersionsPage{Offset: 1, Limit: 5},
+			status:   http.StatusOK,
+		},
+		{
+			desc:     "list rule versions with invalid token",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    invalidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "list rule versions with invalid offset",
+			query:    "offset=invalid",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:     "list rule versions with limit that is too big",
+			query:    "limit=10000",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrLimitSize,
+		},
+		{
+			desc:     "list rule versions with service error",
+			domainID: domainID,
+			ruleID:   rule.ID,
+			token:    validToken,
+			pm:       re.VersionPageMeta{RuleID: rule.ID, Limit: 10},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodGet,
+				url:         fmt.Sprintf("%s/%s/rules/%s/versions?%s", ts.URL, tc.domainID, tc.ruleID, tc.query),
+				contentType: contentType,
+				token:       tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListRuleVersions", mock.Anything, tc.session, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var bodyRes respBody
+			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if bodyRes.Err != "" || bodyRes.Message != "" {
+				err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDiffRuleVersionsEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	diff := re.VersionDiff{
+		RuleID: rule.ID,
+		From:   1,
+		To:     2,
+		Changes: []re.FieldChange{
+			{Field: "logic.value", From: "return 1", To: "return 2", Diff: []string{"-return 1", "+return 2"}},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		query    string
+		token    string
+		session  smqauthn.Session
+		from     uint64
+		to       uint64
+		svcRes   re.VersionDiff
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:   "diff latest rule version successfully",
+			token:  validToken,
+			svcRes: diff,
+			status: http.StatusOK,
+		},
+		{
+			desc:   "diff rule versions successfully",
+			query:  "from=1&to=2",
+			token:  validToken,
+			from:   1,
+			to:     2,
+			svcRes: diff,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "diff rule versions with invalid token",
+			token:    invalidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "diff rule versions with invalid version",
+			query:  "from=first",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+		{
+			desc:   "diff rule versions with reversed range",
+			query:  "from=2&to=1",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "diff non-existing rule versions",
+			query:  "to=3",
+			token:  validToken,
+			to:     3,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodGet,
+				url:         fmt.Sprintf("%s/%s/rules/%s/versions/diff?%s", ts.URL, domainID, rule.ID, tc.query),
+				contentType: contentType,
+				token:       tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("DiffRuleVersions", mock.Anything, tc.session, rule.ID, tc.from, tc.to).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var bodyRes respBody
+			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if bodyRes.Err != "" || bodyRes.Message != "" {
+				err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRestoreRuleVersionEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	restored := rule
+	restored.Version = 3
+
+	cases := []struct {
+		desc     string
+		token    string
+		version  string
+		session  smqauthn.Session
+		svcResp  re.Rule
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:    "restore rule version successfully",
+			token:   validToken,
+			version: "1",
+			svcResp: restored,
+			status:  http.StatusOK,
+		},
+		{
+			desc:     "restore rule version with invalid token",
+			token:    invalidToken,
+			version:  "1",
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
 		},
 		{
-			desc:     "list rules with duplicate status",
-			domainID: domainID,
-			token:    validToken,
-			query:    "status=enabled&status=disabled",
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrInvalidQueryParams,
+			desc:    "restore rule version with invalid version",
+			token:   validToken,
+			version: "latest",
+			status:  http.StatusBadRequest,
+			err:     apiutil.ErrValidation,
 		},
 		{
-			desc:     "list rules with duplicate tags",
-			domainID: domainID,
-			token:    validToken,
-			query:    "tag=tag1&tag=tag2",
-			status:   http.StatusBadRequest,
-			err:      apiutil.ErrInvalidQueryParams,
+			desc:    "restore rule version with zero version",
+			token:   validToken,
+			version: "0",
+			status:  http.StatusBadRequest,
+			err:     apiutil.ErrValidation,
 		},
 		{
-			desc:              "list rules with service error",
-			domainID:          domainID,
-			token:             validToken,
-			listRulesResponse: re.Page{},
-			status:            http.StatusForbidden,
-			err:               svcerr.ErrAuthorization,
+			desc:    "restore rule version with service error",
+			token:   validToken,
+			version: "1",
+			svcErr:  svcerr.ErrAuthorization,
+			status:  http.StatusForbidden,
+			err:     svcerr.ErrAuthorization,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			req := testRequest{
-				client:      ts.Client(),
-				method:      http.MethodGet,
-				url:         ts.URL + "/" + tc.domainID + "/rules?" + tc.query,
-				contentType: contentType,
-				token:       tc.token,
+				client: ts.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/rules/%s/versions/%s/restore", ts.URL, domainID, rule.ID, tc.version),
+				token:  tc.token,
 			}
 			if tc.token == validToken {
 				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
 			}
 			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
-			svcCall := svc.On("ListRules", mock.Anything, tc.session, mock.Anything).Return(tc.listRulesResponse, tc.err)
+			svcCall := svc.On("RestoreRuleVersion", mock.Anything, tc.session, rule.ID, uint64(1)).Return(tc.svcResp, tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-			var bodyRes respBody
-			err = json.NewDecoder(res.Body).Decode(&bodyRes)
+			var resBody struct {
+				respBody
+				Version uint64 `json:"version"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&resBody)
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-			if bodyRes.Err != "" || bodyRes.Message != "" {
-				err = errors.Wrap(errors.New(bodyRes.Err), errors.New(bodyRes.Message))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
 			}
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.svcResp.Version, resBody.Version, fmt.Sprintf("%s: expected version %d got %d", tc.desc, tc.svcResp.Version, resBody.Version))
 			svcCall.Unset()
 			authCall.Unset()
 		})
@@ -939,6 +1525,50 @@ func TestUpdateRuleScheduleEndpoint(t *testing.T) {
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
+		{
+			desc:     "update rule schedule with cron expression and timezone",
+			token:    validToken,
+			id:       validID,
+			domainID: domainID,
+			schedule: pkgSch.Schedule{
+				StartDateTime: future,
+				Recurring:     pkgSch.Cron,
+				Cron:          "30 8 * * 1-5",
+				Timezone:      "Europe/Berlin",
+			},
+			contentType: contentType,
+			svcResp:     ruleWithSchedule,
+			status:      http.StatusOK,
+		},
+		{
+			desc:     "update rule schedule with invalid cron expression",
+			token:    validToken,
+			id:       validID,
+			domainID: domainID,
+			schedule: pkgSch.Schedule{
+				StartDateTime: future,
+				Recurring:     pkgSch.Cron,
+				Cron:          "30 25 * * *",
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:     "update rule schedule with invalid timezone",
+			token:    validToken,
+			id:       validID,
+			domainID: domainID,
+			schedule: pkgSch.Schedule{
+				StartDateTime:   future,
+				Recurring:       pkgSch.Daily,
+				RecurringPeriod: 1,
+				Timezone:        "Europe/Atlantis",
+			},
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
 		{
 			desc:        "update rule schedule with service error",
 			token:       validToken,
@@ -1275,6 +1905,283 @@ func TestDeleteRuleEndpoint(t *testing.T) {
 	}
 }
 
+func TestListDeadLettersEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	deadLetter := re.DeadLetter{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     rule.ID,
+		DomainID:   domainID,
+		OutputType: "channels",
+		Output:     json.RawMessage(`{"type":"channels","channel":"output.channel"}`),
+		Error:      "publish failed",
+		Status:     re.FailedDeadLetter,
+		Attempts:   1,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc     string
+		query    string
+		token    string
+		session  smqauthn.Session
+		pm       re.DeadLetterPageMeta
+		svcRes   re.DeadLettersPage
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:  "list dead letters successfully",
+			token: validToken,
+			pm:    re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 10, Status: re.AllDeadLetters},
+			svcRes: re.DeadLettersPage{
+				Total:       1,
+				Limit:       10,
+				DeadLetters: []re.DeadLetter{deadLetter},
+			},
+			status: http.StatusOK,
+		},
+		{
+			desc:   "list dead letters with status",
+			query:  "status=failed&offset=1&limit=5",
+			token:  validToken,
+			pm:     re.DeadLetterPageMeta{RuleID: rule.ID, Offset: 1, Limit: 5, Status: re.FailedDeadLetter},
+			svcRes: re.DeadLettersPage{Offset: 1, Limit: 5},
+			status: http.StatusOK,
+		},
+		{
+			desc:     "list dead letters with invalid token",
+			token:    invalidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list dead letters with invalid status",
+			query:  "status=invalid",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    svcerr.ErrInvalidStatus,
+		},
+		{
+			desc:   "list dead letters with limit that is too big",
+			query:  "limit=10000",
+			token:  validToken,
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list dead letters with service error",
+			token:  validToken,
+			pm:     re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 10, Status: re.AllDeadLetters},
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters?%s", ts.URL, domainID, rule.ID, tc.query),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListDeadLetters", mock.Anything, tc.session, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody struct {
+				respBody
+				DeadLetters []re.DeadLetter `json:"dead_letters"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.svcRes.Total, resBody.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.svcRes.Total, resBody.Total))
+			assert.Len(t, resBody.DeadLetters, len(tc.svcRes.DeadLetters), fmt.Sprintf("%s: unexpected number of dead letters", tc.desc))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewDeadLetterEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	deadLetter := re.DeadLetter{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     rule.ID,
+		DomainID:   domainID,
+		OutputType: "channels",
+		Status:     re.PendingDeadLetter,
+		Attempts:   1,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		session  smqauthn.Session
+		svcRes   re.DeadLetter
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:   "view dead letter successfully",
+			token:  validToken,
+			id:     deadLetter.ID,
+			svcRes: deadLetter,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "view dead letter with invalid token",
+			token:    invalidToken,
+			id:       deadLetter.ID,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view non-existing dead letter",
+			token:  validToken,
+			id:     deadLetter.ID,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters/%s", ts.URL, domainID, rule.ID, tc.id),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewDeadLetter", mock.Anything, tc.session, rule.ID, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody struct {
+				respBody
+				Message any                 `json:"message"`
+				Status  re.DeadLetterStatus `json:"status"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if msg, ok := resBody.Message.(string); resBody.Err != "" || ok {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(msg))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.svcRes.ID, resBody.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.svcRes.ID, resBody.ID))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestReplayDeadLetterEndpoint(t *testing.T) {
+	ts, svc, authn := newRuleEngineServer()
+	defer ts.Close()
+
+	deadLetter := re.DeadLetter{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     rule.ID,
+		DomainID:   domainID,
+		OutputType: "channels",
+		Status:     re.DeliveredDeadLetter,
+		Attempts:   2,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		session  smqauthn.Session
+		svcRes   re.DeadLetter
+		svcErr   error
+		status   int
+		authnErr error
+		err      error
+	}{
+		{
+			desc:   "replay dead letter successfully",
+			token:  validToken,
+			svcRes: deadLetter,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "replay dead letter with invalid token",
+			token:    invalidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "replay delivered dead letter",
+			token:  validToken,
+			svcErr: svcerr.ErrConflict,
+			status: http.StatusBadRequest,
+			err:    svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/%s/rules/%s/dead-letters/%s/replay", ts.URL, domainID, rule.ID, deadLetter.ID),
+				token:  tc.token,
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ReplayDeadLetter", mock.Anything, tc.session, rule.ID, deadLetter.ID).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody struct {
+				respBody
+				Message  any                 `json:"message"`
+				Status   re.DeadLetterStatus `json:"status"`
+				Attempts uint                `json:"attempts"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if msg, ok := resBody.Message.(string); resBody.Err != "" || ok {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(msg))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.svcRes.Attempts, resBody.Attempts, fmt.Sprintf("%s: expected attempts %d got %d", tc.desc, tc.svcRes.Attempts, resBody.Attempts))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 type respBody struct {
 	Err     string    `json:"error"`
 	Message string    `json:"message"`
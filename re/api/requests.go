@@ -4,13 +4,23 @@
 package api
 
 import (
+	"encoding/json"
+	"log/slog"
+
 	api "github.com/absmach/magistrala/api/http"
 	apiutil "github.com/absmach/magistrala/api/http/util"
 	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/schedule"
 	"github.com/absmach/magistrala/re"
 )
 
+var (
+	errInvalidLevel   = errors.NewRequestError("invalid execution level")
+	errInvalidVersion = errors.NewRequestError("invalid rule version")
+	errInvalidRange   = errors.NewRequestError("version to diff from must be lower than version to diff to")
+)
+
 const (
 	maxLimitSize = 1000
 	MaxNameSize  = 1024
@@ -32,6 +42,41 @@ func (req addRuleReq) validate() error {
 	return nil
 }
 
+type testMessage struct {
+	Channel   string          `json:"channel,omitempty"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher,omitempty"`
+	ClientID  string          `json:"client_id,omitempty"`
+	Protocol  string          `json:"protocol,omitempty"`
+	Created   int64           `json:"created,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+type testRuleReq struct {
+	Rule    re.Rule     `json:"rule"`
+	Message testMessage `json:"message"`
+}
+
+func (req testRuleReq) validate() error {
+	if len(req.Rule.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+func (req testRuleReq) message() *messaging.Message {
+	return &messaging.Message{
+		Channel:   req.Message.Channel,
+		Subtopic:  req.Message.Subtopic,
+		Publisher: req.Message.Publisher,
+		ClientId:  req.Message.ClientID,
+		Protocol:  req.Message.Protocol,
+		Created:   req.Message.Created,
+		Payload:   req.Message.Payload,
+	}
+}
+
 type viewRuleReq struct {
 	id        string
 	withRoles bool
@@ -67,6 +112,29 @@ func (req listRulesReq) validate() error {
 	return nil
 }
 
+type listExecutionsReq struct {
+	re.ExecutionPageMeta
+}
+
+func (req listExecutionsReq) validate() error {
+	if req.RuleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	if req.Dir != api.AscDir && req.Dir != api.DescDir {
+		return apiutil.ErrInvalidDirection
+	}
+	switch req.Level {
+	case "", slog.LevelInfo.String(), slog.LevelWarn.String(), slog.LevelError.String():
+	default:
+		return errors.Wrap(errInvalidLevel, apiutil.ErrValidation)
+	}
+
+	return nil
+}
+
 type updateRuleReq struct {
 	Rule re.Rule
 }
@@ -135,3 +203,79 @@ func (req deleteRuleReq) validate() error {
 
 	return nil
 }
+
+type listRuleVersionsReq struct {
+	re.VersionPageMeta
+}
+
+func (req listRuleVersionsReq) validate() error {
+	if req.RuleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type diffRuleVersionsReq struct {
+	id   string
+	from uint64
+	to   uint64
+}
+
+func (req diffRuleVersionsReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.from != 0 && req.to != 0 && req.from >= req.to {
+		return errors.Wrap(errInvalidRange, apiutil.ErrValidation)
+	}
+
+	return nil
+}
+
+type restoreRuleVersionReq struct {
+	id      string
+	version uint64
+}
+
+func (req restoreRuleVersionReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.version == 0 {
+		return errors.Wrap(errInvalidVersion, apiutil.ErrValidation)
+	}
+
+	return nil
+}
+
+type listDeadLettersReq struct {
+	re.DeadLetterPageMeta
+}
+
+func (req listDeadLettersReq) validate() error {
+	if req.RuleID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type deadLetterReq struct {
+	ruleID string
+	id     string
+}
+
+func (req deadLetterReq) validate() error {
+	if req.ruleID == "" || req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -18,6 +18,12 @@ var (
 	_ magistrala.Response = (*rulesPageRes)(nil)
 	_ magistrala.Response = (*updateRuleRes)(nil)
 	_ magistrala.Response = (*deleteRuleRes)(nil)
+	_ magistrala.Response = (*testRuleRes)(nil)
+	_ magistrala.Response = (*executionsPageRes)(nil)
+	_ magistrala.Response = (*versionsPageRes)(nil)
+	_ magistrala.Response = (*versionDiffRes)(nil)
+	_ magistrala.Response = (*deadLettersPageRes)(nil)
+	_ magistrala.Response = (*deadLetterRes)(nil)
 )
 
 type pageRes struct {
@@ -136,3 +142,99 @@ func (res deleteRuleRes) Headers() map[string]string {
 func (res deleteRuleRes) Empty() bool {
 	return true
 }
+
+type testRuleRes struct {
+	re.TestResult `json:",inline"`
+}
+
+func (res testRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res testRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res testRuleRes) Empty() bool {
+	return false
+}
+
+type executionsPageRes struct {
+	re.ExecutionsPage `json:",inline"`
+}
+
+func (res executionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res executionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res executionsPageRes) Empty() bool {
+	return false
+}
+
+type versionsPageRes struct {
+	re.VersionsPage `json:",inline"`
+}
+
+func (res versionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res versionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res versionsPageRes) Empty() bool {
+	return false
+}
+
+type versionDiffRes struct {
+	re.VersionDiff `json:",inline"`
+}
+
+func (res versionDiffRes) Code() int {
+	return http.StatusOK
+}
+
+func (res versionDiffRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res versionDiffRes) Empty() bool {
+	return false
+}
+
+type deadLettersPageRes struct {
+	re.DeadLettersPage `json:",inline"`
+}
+
+func (res deadLettersPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deadLettersPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deadLettersPageRes) Empty() bool {
+	return false
+}
+
+type deadLetterRes struct {
+	re.DeadLetter `json:",inline"`
+}
+
+func (res deadLetterRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deadLetterRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deadLetterRes) Empty() bool {
+	return false
+}
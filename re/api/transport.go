@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/absmach/magistrala"
@@ -24,7 +25,11 @@ import (
 
 const (
 	ruleIdKey       = "ruleID"
+	versionKey      = "version"
+	deadLetterIDKey = "deadLetterID"
 	inputChannelKey = "input_channel"
+	fromKey         = "from"
+	toKey           = "to"
 )
 
 // MakeHandler creates an HTTP handler for the service endpoints.
@@ -50,6 +55,13 @@ func MakeHandler(svc re.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mux, l
 					opts...,
 				), "list_rules").ServeHTTP)
 
+				r.Post("/test", otelhttp.NewHandler(kithttp.NewServer(
+					testRuleEndpoint(svc),
+					decodeTestRuleRequest,
+					api.EncodeResponse,
+					opts...,
+				), "test_rule").ServeHTTP)
+
 				r.Route("/{ruleID}", func(r chi.Router) {
 					r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 						viewRuleEndpoint(svc),
@@ -99,6 +111,59 @@ func MakeHandler(svc re.Service, authn smqauthn.AuthNMiddleware, mux *chi.Mux, l
 						api.EncodeResponse,
 						opts...,
 					), "disable_rule").ServeHTTP)
+
+					r.Get("/executions", otelhttp.NewHandler(kithttp.NewServer(
+						listExecutionsEndpoint(svc),
+						decodeListExecutionsRequest,
+						api.EncodeResponse,
+						opts...,
+					), "list_rule_executions").ServeHTTP)
+
+					r.Route("/versions", func(r chi.Router) {
+						r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+							listRuleVersionsEndpoint(svc),
+							decodeListRuleVersionsRequest,
+							api.EncodeResponse,
+							opts...,
+						), "list_rule_versions").ServeHTTP)
+
+						r.Get("/diff", otelhttp.NewHandler(kithttp.NewServer(
+							diffRuleVersionsEndpoint(svc),
+							decodeDiffRuleVersionsRequest,
+							api.EncodeResponse,
+							opts...,
+						), "diff_rule_versions").ServeHTTP)
+
+						r.Post("/{version}/restore", otelhttp.NewHandler(kithttp.NewServer(
+							restoreRuleVersionEndpoint(svc),
+							decodeRestoreRuleVersionRequest,
+							api.EncodeResponse,
+							opts...,
+						), "restore_rule_version").ServeHTTP)
+					})
+
+					r.Route("/dead-letters", func(r chi.Router) {
+						r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+							listDeadLettersEndpoint(svc),
+							decodeListDeadLettersRequest,
+							api.EncodeResponse,
+							opts...,
+						), "list_dead_letters").ServeHTTP)
+
+						r.Get("/{deadLetterID}", otelhttp.NewHandler(kithttp.NewServer(
+							viewDeadLetterEndpoint(svc),
+							decodeDeadLetterRequest,
+							api.EncodeResponse,
+							opts...,
+						), "view_dead_letter").ServeHTTP)
+
+						r.Post("/{deadLetterID}/replay", otelhttp.NewHandler(kithttp.NewServer(
+							replayDeadLetterEndpoint(svc),
+							decodeDeadLetterRequest,
+							api.EncodeResponse,
+							opts...,
+						), "replay_dead_letter").ServeHTTP)
+					})
 				})
 			})
 		})
@@ -121,6 +186,18 @@ func decodeAddRuleRequest(_ context.Context, r *http.Request) (any, error) {
 	return addRuleReq{Rule: rule}, nil
 }
 
+func decodeTestRuleRequest(_ context.Context, r *http.Request) (any, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+	var req testRuleReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedRequestBody, err)
+	}
+
+	return req, nil
+}
+
 func decodeViewRuleRequest(_ context.Context, r *http.Request) (any, error) {
 	id := chi.URLParam(r, ruleIdKey)
 	withRoles, err := apiutil.ReadBoolQuery(r, api.RolesKey, false)
@@ -233,8 +310,120 @@ func decodeListRulesRequest(_ context.Context, r *http.Request) (any, error) {
 	}, nil
 }
 
+func decodeListExecutionsRequest(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	dir, err := apiutil.ReadStringQuery(r, api.DirKey, api.DescDir)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	level, err := apiutil.ReadStringQuery(r, api.LevelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listExecutionsReq{
+		ExecutionPageMeta: re.ExecutionPageMeta{
+			RuleID: chi.URLParam(r, ruleIdKey),
+			Offset: offset,
+			Limit:  limit,
+			Dir:    dir,
+			Level:  strings.ToUpper(level),
+		},
+	}, nil
+}
+
 func decodeDeleteRuleRequest(_ context.Context, r *http.Request) (any, error) {
 	id := chi.URLParam(r, ruleIdKey)
 
 	return deleteRuleReq{id: id}, nil
 }
+
+func decodeListRuleVersionsRequest(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listRuleVersionsReq{
+		VersionPageMeta: re.VersionPageMeta{
+			RuleID: chi.URLParam(r, ruleIdKey),
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func decodeDiffRuleVersionsRequest(_ context.Context, r *http.Request) (any, error) {
+	from, err := apiutil.ReadNumQuery[uint64](r, fromKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	to, err := apiutil.ReadNumQuery[uint64](r, toKey, 0)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return diffRuleVersionsReq{
+		id:   chi.URLParam(r, ruleIdKey),
+		from: from,
+		to:   to,
+	}, nil
+}
+
+func decodeRestoreRuleVersionRequest(_ context.Context, r *http.Request) (any, error) {
+	version, err := strconv.ParseUint(chi.URLParam(r, versionKey), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidVersion, apiutil.ErrValidation)
+	}
+
+	return restoreRuleVersionReq{
+		id:      chi.URLParam(r, ruleIdKey),
+		version: version,
+	}, nil
+}
+
+func decodeListDeadLettersRequest(_ context.Context, r *http.Request) (any, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	s, err := apiutil.ReadStringQuery(r, api.StatusKey, re.All)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := re.ToDeadLetterStatus(s)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	return listDeadLettersReq{
+		DeadLetterPageMeta: re.DeadLetterPageMeta{
+			RuleID: chi.URLParam(r, ruleIdKey),
+			Offset: offset,
+			Limit:  limit,
+			Status: status,
+		},
+	}, nil
+}
+
+func decodeDeadLetterRequest(_ context.Context, r *http.Request) (any, error) {
+	return deadLetterReq{
+		ruleID: chi.URLParam(r, ruleIdKey),
+		id:     chi.URLParam(r, deadLetterIDKey),
+	}, nil
+}
@@ -0,0 +1,448 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
+	"github.com/absmach/magistrala/re/outputs"
+)
+
+const (
+	// deadLetterBatch is the maximum number of dead letters retried on a single tick.
+	deadLetterBatch = 100
+	// deadLetterLease is how long claimed dead letters are hidden from other
+	// retry runs, so they are not retried concurrently.
+	deadLetterLease = 5 * time.Minute
+)
+
+var (
+	errSaveDeadLetter      = errors.New("failed to save dead letter")
+	errDeadLetterDelivered = errors.New("dead letter is already delivered")
+	errDeadLetterRetrying  = errors.New("dead letter is already being retried")
+	errDeadLetterOutput    = errors.New("dead letter output credentials are not in the rule anymore")
+)
+
+// DeadLetterStatus represents the delivery status of a dead letter.
+type DeadLetterStatus uint8
+
+// Possible dead letter status values.
+const (
+	// PendingDeadLetter is waiting for the next automatic retry.
+	PendingDeadLetter DeadLetterStatus = iota
+	// FailedDeadLetter has no automatic retries left, but can be replayed.
+	FailedDeadLetter
+	// DeliveredDeadLetter was delivered by a retry or a replay.
+	DeliveredDeadLetter
+	// RetryingDeadLetter is being retried or replayed. Its next retry is the
+	// end of the claim, after which it can be claimed again.
+	RetryingDeadLetter
+
+	// AllDeadLetters is used for querying purposes to list dead letters
+	// irrespective of their status. It is never stored in the database.
+	AllDeadLetters
+)
+
+// String representation of the possible dead letter status values.
+const (
+	Pending   = "pending"
+	Failed    = "failed"
+	Delivered = "delivered"
+	Retrying  = "retrying"
+)
+
+func (s DeadLetterStatus) String() string {
+	switch s {
+	case PendingDeadLetter:
+		return Pending
+	case FailedDeadLetter:
+		return Failed
+	case DeliveredDeadLetter:
+		return Delivered
+	case RetryingDeadLetter:
+		return Retrying
+	case AllDeadLetters:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToDeadLetterStatus converts string value to a valid dead letter status.
+// Empty value stands for all the dead letters.
+func ToDeadLetterStatus(status string) (DeadLetterStatus, error) {
+	switch status {
+	case "", All:
+		return AllDeadLetters, nil
+	case Pending:
+		return PendingDeadLetter, nil
+	case Failed:
+		return FailedDeadLetter, nil
+	case Delivered:
+		return DeliveredDeadLetter, nil
+	case Retrying:
+		return RetryingDeadLetter, nil
+	}
+	return DeadLetterStatus(0), svcerr.ErrInvalidStatus
+}
+
+func (s DeadLetterStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *DeadLetterStatus) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), "\"")
+	val, err := ToDeadLetterStatus(str)
+	*s = val
+	return err
+}
+
+// DeadLetter is a failed run of a rule output. It keeps the snapshots of
+// the output configuration, the message and the rule result, so the output
+// is run again the same way, regardless of the later changes of the rule.
+// The output credentials are masked in the snapshot, and are taken from the
+// same output of the rule when the output is run again.
+type DeadLetter struct {
+	ID         string            `json:"id"`
+	RuleID     string            `json:"rule_id"`
+	DomainID   string            `json:"domain_id"`
+	OutputType string            `json:"output_type"`
+	Output     json.RawMessage   `json:"output"`
+	Message    DeadLetterMessage `json:"message"`
+	Result     any               `json:"result"`
+	// Error is the error of the last failed attempt.
+	Error  string           `json:"error"`
+	Status DeadLetterStatus `json:"status"`
+	// Attempts is the number of times the output ran, including the original run.
+	Attempts uint `json:"attempts"`
+	// NextRetryAt is the time of the next automatic retry of pending dead letters.
+	NextRetryAt *time.Time `json:"next_retry_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at,omitempty"`
+}
+
+// DeadLetterMessage is the message the rule processed when the output failed.
+type DeadLetterMessage struct {
+	Channel   string `json:"channel,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	Payload   []byte `json:"payload,omitempty"`
+	Created   int64  `json:"created,omitempty"`
+}
+
+// DeadLetterPageMeta contains page metadata for listing dead letters.
+type DeadLetterPageMeta struct {
+	Offset uint64           `json:"offset"  db:"offset"`
+	Limit  uint64           `json:"limit"   db:"limit"`
+	RuleID string           `json:"rule_id" db:"rule_id"`
+	Status DeadLetterStatus `json:"status"  db:"status"`
+}
+
+type DeadLettersPage struct {
+	Offset      uint64       `json:"offset"`
+	Limit       uint64       `json:"limit"`
+	Total       uint64       `json:"total"`
+	DeadLetters []DeadLetter `json:"dead_letters"`
+}
+
+func newDeadLetterMessage(msg *messaging.Message) DeadLetterMessage {
+	return DeadLetterMessage{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		ClientID:  msg.GetClientId(),
+		Protocol:  msg.GetProtocol(),
+		Payload:   msg.GetPayload(),
+		Created:   msg.GetCreated(),
+	}
+}
+
+func (m DeadLetterMessage) message(domainID string) *messaging.Message {
+	return &messaging.Message{
+		Domain:    domainID,
+		Channel:   m.Channel,
+		Subtopic:  m.Subtopic,
+		Publisher: m.Publisher,
+		ClientId:  m.ClientID,
+		Protocol:  m.Protocol,
+		Payload:   m.Payload,
+		Created:   m.Created,
+	}
+}
+
+// retryPolicy returns the retry policy of the output, nil if it has none.
+func retryPolicy(o Runnable) *outputs.RetryPolicy {
+	if r, ok := o.(interface{ RetryPolicy() *outputs.RetryPolicy }); ok {
+		return r.RetryPolicy()
+	}
+
+	return nil
+}
+
+// retryAfter sets the status and the next retry of the dead letter after a failed attempt.
+func (dl *DeadLetter) retryAfter(o Runnable, failed time.Time) {
+	next := retryPolicy(o).Next(dl.Attempts, failed)
+	if next.IsZero() {
+		dl.Status = FailedDeadLetter
+		dl.NextRetryAt = nil
+		return
+	}
+	dl.Status = PendingDeadLetter
+	dl.NextRetryAt = &next
+}
+
+// runOutput runs the rule output and saves the failed run to the dead-letter
+// queue, if the output has a retry policy.
+func (re *re) runOutput(ctx context.Context, r Rule, o Runnable, msg *messaging.Message, val any) error {
+	err := re.handleOutput(ctx, o, msg, val)
+	if err == nil || retryPolicy(o) == nil {
+		return err
+	}
+	if e := re.saveDeadLetter(ctx, r, o, msg, val, err); e != nil {
+		return errors.Wrap(err, errors.Wrap(errSaveDeadLetter, e))
+	}
+
+	return err
+}
+
+func (re *re) saveDeadLetter(ctx context.Context, r Rule, o Runnable, msg *messaging.Message, val any, runErr error) error {
+	id, err := re.idp.ID()
+	if err != nil {
+		return err
+	}
+	output, err := json.Marshal(redactOutput(o))
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	dl := DeadLetter{
+		ID:         id,
+		RuleID:     r.ID,
+		DomainID:   r.DomainID,
+		OutputType: outputType(o),
+		Output:     output,
+		Message:    newDeadLetterMessage(msg),
+		Result:     val,
+		Error:      runErr.Error(),
+		Attempts:   1,
+		CreatedAt:  now,
+	}
+	dl.retryAfter(o, now)
+
+	return re.repo.AddDeadLetter(ctx, dl)
+}
+
+// deadLetterOutput returns the output of the dead letter. Outputs with
+// credentials are taken from the rule, since their snapshot is masked.
+func (re *re) deadLetterOutput(ctx context.Context, dl DeadLetter) (Runnable, error) {
+	var snapshot any
+	if err := json.Unmarshal(dl.Output, &snapshot); err != nil {
+		return nil, err
+	}
+	if !hasRedacted(snapshot) {
+		return decodeOutput(dl.Output)
+	}
+	r, err := re.repo.ViewRule(ctx, dl.RuleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range ruleOutputs(r) {
+		data, err := json.Marshal(redactOutput(o))
+		if err != nil {
+			return nil, err
+		}
+		var masked any
+		if err := json.Unmarshal(data, &masked); err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(masked, snapshot) {
+			return o, nil
+		}
+	}
+
+	return nil, errDeadLetterOutput
+}
+
+// hasRedacted reports whether the decoded output has masked credentials.
+func hasRedacted(v any) bool {
+	switch v := v.(type) {
+	case string:
+		return v == outputs.Redacted
+	case map[string]any:
+		for _, val := range v {
+			if hasRedacted(val) {
+				return true
+			}
+		}
+	case []any:
+		for _, val := range v {
+			if hasRedacted(val) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ruleOutputs returns the outputs of the rule, including the outputs of its steps.
+func ruleOutputs(r Rule) Outputs {
+	outs := append(Outputs{}, r.Outputs...)
+	for _, s := range r.Steps {
+		for _, b := range s.Branches {
+			outs = append(outs, b.Outputs...)
+		}
+	}
+
+	return outs
+}
+
+// redeliver runs the output of the dead letter again and updates the dead letter with the outcome.
+func (re *re) redeliver(ctx context.Context, dl DeadLetter) (DeadLetter, error) {
+	o, err := re.deadLetterOutput(ctx, dl)
+	if err == nil {
+		re.bindOutput(dl.RuleID, o)
+		err = re.handleOutput(ctx, o, dl.Message.message(dl.DomainID), dl.Result)
+	}
+	now := time.Now().UTC()
+	dl.Attempts++
+	dl.UpdatedAt = now
+	switch err {
+	case nil:
+		dl.Status = DeliveredDeadLetter
+		dl.NextRetryAt = nil
+	default:
+		dl.Error = err.Error()
+		dl.retryAfter(o, now)
+	}
+	if err := re.repo.UpdateDeadLetter(ctx, dl); err != nil {
+		return DeadLetter{}, err
+	}
+
+	return dl, nil
+}
+
+func (re *re) ListDeadLetters(ctx context.Context, session authn.Session, pm DeadLetterPageMeta) (DeadLettersPage, error) {
+	page, err := re.repo.ListDeadLetters(ctx, pm)
+	if err != nil {
+		return DeadLettersPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (re *re) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (DeadLetter, error) {
+	dl, err := re.repo.ViewDeadLetter(ctx, ruleID, id)
+	if err != nil {
+		return DeadLetter{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return dl, nil
+}
+
+func (re *re) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (DeadLetter, error) {
+	now := time.Now().UTC()
+	dl, err := re.repo.ClaimDeadLetter(ctx, ruleID, id, now, now.Add(deadLetterLease))
+	switch {
+	case err == nil:
+	case errors.Contains(err, repoerr.ErrNotFound):
+		// The dead letter is missing, delivered or being retried meanwhile.
+		dl, err := re.repo.ViewDeadLetter(ctx, ruleID, id)
+		if err != nil {
+			return DeadLetter{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		if dl.Status == DeliveredDeadLetter {
+			return DeadLetter{}, errors.Wrap(svcerr.ErrConflict, errDeadLetterDelivered)
+		}
+		return DeadLetter{}, errors.Wrap(svcerr.ErrConflict, errDeadLetterRetrying)
+	default:
+		return DeadLetter{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	dl, err = re.redeliver(ctx, dl)
+	if err != nil {
+		return DeadLetter{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return dl, nil
+}
+
+// StartDeadLetterCleanup periodically removes the delivered and failed dead
+// letters not updated within the retention period.
+func StartDeadLetterCleanup(ctx context.Context, repo Repository, tck ticker.Ticker, retention time.Duration, runInfo chan<- pkglog.RunInfo) error {
+	defer tck.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tck.Tick():
+			before := time.Now().UTC().Add(-retention)
+			if err := repo.RemoveDeadLetters(ctx, before); err != nil {
+				runInfo <- pkglog.RunInfo{
+					Level:   slog.LevelError,
+					Message: fmt.Sprintf("failed to remove dead letters: %s", err),
+					Details: []slog.Attr{slog.Time("before", before)},
+				}
+			}
+		}
+	}
+}
+
+func (re *re) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	defer tck.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tck.Tick():
+			re.retryDeadLetters(ctx)
+		}
+	}
+}
+
+// retryDeadLetters retries the due dead letters. Dead letters are retried in
+// batches, so the ones left over are retried on the next tick.
+func (re *re) retryDeadLetters(ctx context.Context) {
+	now := time.Now().UTC()
+	dls, err := re.repo.ClaimDeadLetters(ctx, now, now.Add(deadLetterLease), deadLetterBatch)
+	if err != nil {
+		re.runInfo <- pkglog.RunInfo{
+			Level:   slog.LevelError,
+			Message: fmt.Sprintf("failed to claim dead letters: %s", err),
+			Details: []slog.Attr{slog.Time("due", now)},
+		}
+		return
+	}
+	for _, dl := range dls {
+		details := []slog.Attr{
+			slog.String("domain_id", dl.DomainID),
+			slog.String("rule_id", dl.RuleID),
+			slog.String("dead_letter_id", dl.ID),
+			slog.String("output_type", dl.OutputType),
+		}
+		dl, err := re.redeliver(ctx, dl)
+		switch {
+		case err != nil:
+			re.runInfo <- pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to update dead letter: %s", err), Details: details}
+		case dl.Status == DeliveredDeadLetter:
+			re.runInfo <- pkglog.RunInfo{Level: slog.LevelInfo, Message: "dead letter delivered", Details: append(details, slog.Uint64("attempts", uint64(dl.Attempts)))}
+		default:
+			re.runInfo <- pkglog.RunInfo{Level: slog.LevelWarn, Message: fmt.Sprintf("dead letter retry failed: %s", dl.Error), Details: append(details, slog.Uint64("attempts", uint64(dl.Attempts)))}
+		}
+	}
+}
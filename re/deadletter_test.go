@@ -0,0 +1,667 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	tmocks "github.com/absmach/magistrala/pkg/ticker/mocks"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errPublish = errors.New("publish failed")
+
+func TestOutputRetryPolicy(t *testing.T) {
+	cases := []struct {
+		desc   string
+		data   string
+		policy *outputs.RetryPolicy
+		err    bool
+	}{
+		{
+			desc: "output without retry policy",
+			data: `[{"type": "channels", "channel": "alerts"}]`,
+		},
+		{
+			desc:   "output with retry policy",
+			data:   `[{"type": "slack", "channel_id": "C1", "retry": {"retries": 3, "backoff": "30s"}}]`,
+			policy: &outputs.RetryPolicy{Retries: 3, Backoff: 30 * time.Second},
+		},
+		{
+			desc:   "webhook with retry policy and default backoff",
+			data:   `[{"type": "webhook", "url": "https://example.com", "retry": {"retries": 2}}]`,
+			policy: &outputs.RetryPolicy{Retries: 2, Backoff: time.Minute},
+		},
+		{
+			desc: "output with too many retries",
+			data: `[{"type": "email", "retry": {"retries": 21}}]`,
+			err:  true,
+		},
+		{
+			desc: "output with invalid backoff",
+			data: `[{"type": "alarms", "retry": {"retries": 1, "backoff": "-1m"}}]`,
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var outs re.Outputs
+			err := json.Unmarshal([]byte(tc.data), &outs)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			if err != nil {
+				return
+			}
+			o := outs[0].(interface{ RetryPolicy() *outputs.RetryPolicy })
+			assert.Equal(t, tc.policy, o.RetryPolicy())
+
+			// The policy is kept in the output snapshots.
+			data, err := json.Marshal(outs)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var decoded re.Outputs
+			err = json.Unmarshal(data, &decoded)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.policy, decoded[0].(interface{ RetryPolicy() *outputs.RetryPolicy }).RetryPolicy())
+		})
+	}
+}
+
+func TestRetryPolicyNext(t *testing.T) {
+	now := time.Now().UTC()
+	policy := &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute}
+
+	cases := []struct {
+		desc     string
+		policy   *outputs.RetryPolicy
+		attempts uint
+		next     time.Time
+	}{
+		{desc: "first retry", policy: policy, attempts: 1, next: now.Add(time.Minute)},
+		{desc: "second retry doubles backoff", policy: policy, attempts: 2, next: now.Add(2 * time.Minute)},
+		{desc: "last retry", policy: policy, attempts: 3, next: now.Add(4 * time.Minute)},
+		{desc: "no retries left", policy: policy, attempts: 4},
+		{desc: "no retry policy", attempts: 1},
+		{
+			desc:     "backoff is capped",
+			policy:   &outputs.RetryPolicy{Retries: 20, Backoff: time.Hour},
+			attempts: 10,
+			next:     now.Add(6 * time.Hour),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.next, tc.policy.Next(tc.attempts, now))
+		})
+	}
+}
+
+func TestHandleSavesDeadLetter(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, pubsub, _, _, _ := newService(t, ri)
+	msg := &messaging.Message{
+		Domain:    domainID,
+		Channel:   inputChannel,
+		Subtopic:  "temperature",
+		Publisher: testsutil.GenerateUUID(t),
+		Protocol:  "mqtt",
+		Payload:   []byte(`{"temperature": 20}`),
+		Created:   time.Now().UnixNano(),
+	}
+
+	cases := []struct {
+		desc       string
+		retry      *outputs.RetryPolicy
+		publishErr error
+		saveErr    error
+		saved      bool
+		status     re.DeadLetterStatus
+		retryAt    time.Duration
+	}{
+		{
+			desc: "run output successfully",
+		},
+		{
+			desc:       "save failed output with retry policy",
+			retry:      &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute},
+			publishErr: errPublish,
+			saved:      true,
+			status:     re.PendingDeadLetter,
+			retryAt:    time.Minute,
+		},
+		{
+			desc:       "save failed output without retries",
+			retry:      &outputs.RetryPolicy{},
+			publishErr: errPublish,
+			saved:      true,
+			status:     re.FailedDeadLetter,
+		},
+		{
+			desc:       "skip failed output without retry policy",
+			publishErr: errPublish,
+		},
+		{
+			desc:       "save failed output with failed repo",
+			retry:      &outputs.RetryPolicy{},
+			publishErr: errPublish,
+			saveErr:    repoerr.ErrCreateEntity,
+			saved:      true,
+			status:     re.FailedDeadLetter,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			output := &outputs.ChannelPublisher{Channel: "alerts", Topic: "high"}
+			output.Retry = tc.retry
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				InputTopic:   msg.Subtopic,
+				Status:       re.EnabledStatus,
+				Logic:        re.Script{Type: re.LuaType, Value: `return message.payload`},
+				Outputs:      re.Outputs{output},
+			}
+			saved := make(chan re.DeadLetter, 1)
+			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall2 := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(tc.saveErr).Run(func(args mock.Arguments) {
+				saved <- args.Get(1).(re.DeadLetter)
+			}).Maybe()
+			pubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr)
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			info := <-ri
+
+			switch tc.saved {
+			case true:
+				dl := <-saved
+				assert.NotEmpty(t, dl.ID)
+				assert.Equal(t, rule.ID, dl.RuleID)
+				assert.Equal(t, domainID, dl.DomainID)
+				assert.Equal(t, outputs.ChannelsType.String(), dl.OutputType)
+				assert.Equal(t, re.DeadLetterMessage{
+					Channel:   msg.Channel,
+					Subtopic:  msg.Subtopic,
+					Publisher: msg.Publisher,
+					Protocol:  msg.Protocol,
+					Payload:   msg.Payload,
+					Created:   msg.Created,
+				}, dl.Message)
+				assert.Equal(t, map[string]any{"temperature": float64(20)}, dl.Result)
+				assert.Equal(t, errPublish.Error(), dl.Error)
+				assert.Equal(t, tc.status, dl.Status)
+				assert.Equal(t, uint(1), dl.Attempts)
+				switch tc.retryAt {
+				case 0:
+					assert.Nil(t, dl.NextRetryAt)
+				default:
+					assert.WithinDuration(t, dl.CreatedAt.Add(tc.retryAt), *dl.NextRetryAt, time.Millisecond)
+				}
+				expected, err := json.Marshal(output)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.JSONEq(t, string(expected), string(dl.Output))
+				assert.Equal(t, slog.LevelError, info.Level)
+				if tc.saveErr != nil {
+					assert.Contains(t, info.Message, "failed to save dead letter")
+				}
+			default:
+				level := slog.LevelInfo
+				if tc.publishErr != nil {
+					level = slog.LevelError
+				}
+				assert.Equal(t, level, info.Level)
+				assert.Empty(t, saved)
+			}
+
+			repoCall.Unset()
+			repoCall2.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestListDeadLetters(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	dls := []re.DeadLetter{
+		{ID: testsutil.GenerateUUID(t), RuleID: ruleID, Status: re.FailedDeadLetter},
+		{ID: testsutil.GenerateUUID(t), RuleID: ruleID, Status: re.PendingDeadLetter},
+	}
+
+	cases := []struct {
+		desc     string
+		pm       re.DeadLetterPageMeta
+		repoResp re.DeadLettersPage
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "list dead letters successfully",
+			pm:       re.DeadLetterPageMeta{RuleID: ruleID, Limit: 10, Status: re.AllDeadLetters},
+			repoResp: re.DeadLettersPage{Total: 2, Limit: 10, DeadLetters: dls},
+		},
+		{
+			desc:    "list dead letters with repo error",
+			pm:      re.DeadLetterPageMeta{RuleID: ruleID, Limit: 10, Status: re.AllDeadLetters},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ListDeadLetters", mock.Anything, tc.pm).Return(tc.repoResp, tc.repoErr)
+			page, err := svc.ListDeadLetters(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.repoResp, page)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	dl := re.DeadLetter{ID: testsutil.GenerateUUID(t), RuleID: ruleID, Status: re.FailedDeadLetter}
+
+	cases := []struct {
+		desc     string
+		id       string
+		repoResp re.DeadLetter
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "view dead letter successfully",
+			id:       dl.ID,
+			repoResp: dl,
+		},
+		{
+			desc:    "view non-existing dead letter",
+			id:      testsutil.GenerateUUID(t),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ViewDeadLetter", mock.Anything, ruleID, tc.id).Return(tc.repoResp, tc.repoErr)
+			res, err := svc.ViewDeadLetter(context.Background(), session, ruleID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.repoResp, res)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, pubsub, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc       string
+		deadLetter re.DeadLetter
+		claimErr   error
+		viewErr    error
+		publishErr error
+		updateErr  error
+		status     re.DeadLetterStatus
+		attempts   uint
+		retry      bool
+		err        error
+	}{
+		{
+			desc:       "replay failed dead letter successfully",
+			deadLetter: newDeadLetter(t, re.FailedDeadLetter, nil),
+			status:     re.DeliveredDeadLetter,
+			attempts:   2,
+		},
+		{
+			desc:       "replay pending dead letter with failed output",
+			deadLetter: newDeadLetter(t, re.PendingDeadLetter, &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute}),
+			publishErr: errPublish,
+			status:     re.PendingDeadLetter,
+			attempts:   2,
+			retry:      true,
+		},
+		{
+			desc:       "replay dead letter with failed output and no retries left",
+			deadLetter: newDeadLetter(t, re.PendingDeadLetter, &outputs.RetryPolicy{Retries: 1, Backoff: time.Minute}),
+			publishErr: errPublish,
+			status:     re.FailedDeadLetter,
+			attempts:   2,
+		},
+		{
+			desc:       "replay delivered dead letter",
+			deadLetter: newDeadLetter(t, re.DeliveredDeadLetter, nil),
+			claimErr:   repoerr.ErrNotFound,
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:       "replay dead letter being retried",
+			deadLetter: newDeadLetter(t, re.RetryingDeadLetter, &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute}),
+			claimErr:   repoerr.ErrNotFound,
+			err:        svcerr.ErrConflict,
+		},
+		{
+			desc:       "replay non-existing dead letter",
+			deadLetter: re.DeadLetter{ID: testsutil.GenerateUUID(t)},
+			claimErr:   repoerr.ErrNotFound,
+			viewErr:    repoerr.ErrNotFound,
+			err:        svcerr.ErrNotFound,
+		},
+		{
+			desc:       "replay dead letter with failed repo",
+			deadLetter: newDeadLetter(t, re.FailedDeadLetter, nil),
+			updateErr:  repoerr.ErrUpdateEntity,
+			err:        svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var topic string
+			var published *messaging.Message
+			var now, until time.Time
+			claimCall := repo.On("ClaimDeadLetter", mock.Anything, ruleID, tc.deadLetter.ID, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				now = args.Get(3).(time.Time)
+				until = args.Get(4).(time.Time)
+			}).Return(tc.deadLetter, tc.claimErr)
+			repoCall := repo.On("ViewDeadLetter", mock.Anything, ruleID, tc.deadLetter.ID).Return(tc.deadLetter, tc.viewErr)
+			updated := false
+			repoCall1 := repo.On("UpdateDeadLetter", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				updated = true
+			}).Return(tc.updateErr)
+			pubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				topic = args.Get(1).(string)
+				published = args.Get(2).(*messaging.Message)
+			}).Return(tc.publishErr).Maybe()
+
+			dl, err := svc.ReplayDeadLetter(context.Background(), session, ruleID, tc.deadLetter.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.True(t, until.After(now), fmt.Sprintf("%s: expected dead letter to be claimed before the replay", tc.desc))
+			assert.Equal(t, tc.claimErr == nil, updated, fmt.Sprintf("%s: expected only claimed dead letters to be replayed", tc.desc))
+			if err == nil {
+				assert.Equal(t, tc.status, dl.Status)
+				assert.Equal(t, tc.attempts, dl.Attempts)
+				assert.Equal(t, tc.retry, dl.NextRetryAt != nil)
+				assert.False(t, dl.UpdatedAt.IsZero())
+				if tc.publishErr != nil {
+					assert.Equal(t, errPublish.Error(), dl.Error)
+				}
+				assert.Equal(t, messaging.EncodeTopicSuffix(domainID, "alerts", "high"), topic)
+				assert.Equal(t, tc.deadLetter.Message.Publisher, published.Publisher)
+				assert.JSONEq(t, `{"temperature": 35}`, string(published.Payload))
+				repo.AssertCalled(t, "UpdateDeadLetter", mock.Anything, dl)
+			}
+			claimCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestReplayDeadLetterWithCredentials(t *testing.T) {
+	allowPrivateWebhooks(t)
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	secret := "webhook-secret"
+	signed := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		timestamp := r.Header.Get(outputs.TimestampHeader)
+		if r.Header.Get(outputs.SignatureHeader) == "sha256="+outputs.Sign(secret, timestamp, body) {
+			signed <- secret
+		}
+	}))
+	defer ts.Close()
+	webhook := &outputs.Webhook{
+		Method:    http.MethodPost,
+		URL:       ts.URL,
+		Secret:    secret,
+		Retryable: outputs.Retryable{Retry: &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute}},
+	}
+	masked, err := json.Marshal(&outputs.Webhook{
+		Method:    webhook.Method,
+		URL:       webhook.URL,
+		Secret:    outputs.Redacted,
+		Retryable: webhook.Retryable,
+	})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		outputs re.Outputs
+		status  re.DeadLetterStatus
+		signed  bool
+	}{
+		{
+			desc:    "replay dead letter with the credentials of the rule",
+			outputs: re.Outputs{&outputs.ChannelPublisher{Channel: "alerts"}, webhook},
+			status:  re.DeliveredDeadLetter,
+			signed:  true,
+		},
+		{
+			desc:    "replay dead letter with the output removed from the rule",
+			outputs: re.Outputs{&outputs.Webhook{Method: http.MethodPost, URL: ts.URL + "/other", Secret: secret}},
+			status:  re.FailedDeadLetter,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			deadLetter := newDeadLetter(t, re.RetryingDeadLetter, nil)
+			deadLetter.OutputType = outputs.WebhookType.String()
+			deadLetter.Output = masked
+			claimCall := repo.On("ClaimDeadLetter", mock.Anything, ruleID, deadLetter.ID, mock.Anything, mock.Anything).Return(deadLetter, nil)
+			ruleCall := repo.On("ViewRule", mock.Anything, ruleID).Return(re.Rule{ID: ruleID, DomainID: domainID, Outputs: tc.outputs}, nil)
+			updateCall := repo.On("UpdateDeadLetter", mock.Anything, mock.Anything).Return(nil)
+
+			dl, err := svc.ReplayDeadLetter(context.Background(), session, ruleID, deadLetter.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, dl.Status)
+			assert.Equal(t, tc.signed, len(signed) == 1, fmt.Sprintf("%s: unexpected webhook signature", tc.desc))
+			if !tc.signed {
+				assert.Contains(t, dl.Error, "credentials")
+			}
+			assert.JSONEq(t, string(masked), string(dl.Output), fmt.Sprintf("%s: expected dead letter output to stay masked", tc.desc))
+
+			for len(signed) > 0 {
+				<-signed
+			}
+			claimCall.Unset()
+			ruleCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
+
+func TestStartDeadLetterRetries(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	svc, repo, pubsub, _, _, _ := newService(t, ri)
+	tck := new(tmocks.Ticker)
+
+	cases := []struct {
+		desc       string
+		claimed    []re.DeadLetter
+		claimErr   error
+		publishErr error
+		level      slog.Level
+		message    string
+	}{
+		{
+			desc:    "retry dead letters successfully",
+			claimed: []re.DeadLetter{newDeadLetter(t, re.PendingDeadLetter, &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute})},
+			level:   slog.LevelInfo,
+			message: "dead letter delivered",
+		},
+		{
+			desc:       "retry dead letters with failed output",
+			claimed:    []re.DeadLetter{newDeadLetter(t, re.PendingDeadLetter, &outputs.RetryPolicy{Retries: 3, Backoff: time.Minute})},
+			publishErr: errPublish,
+			level:      slog.LevelWarn,
+			message:    "dead letter retry failed",
+		},
+		{
+			desc:     "retry dead letters with failed claim",
+			claimErr: repoerr.ErrUpdateEntity,
+			level:    slog.LevelError,
+			message:  "failed to claim dead letters",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var due, until time.Time
+			repoCall := repo.On("ClaimDeadLetters", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				due = args.Get(1).(time.Time)
+				until = args.Get(2).(time.Time)
+			}).Return(tc.claimed, tc.claimErr)
+			repoCall1 := repo.On("UpdateDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			pubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr).Maybe()
+			tickChan := make(chan time.Time, 1)
+			tickCall := tck.On("Tick").Return((<-chan time.Time)(tickChan))
+			tickCall1 := tck.On("Stop").Return()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- svc.StartDeadLetterRetries(ctx, tck)
+			}()
+
+			tickChan <- time.Now()
+			select {
+			case info := <-ri:
+				assert.Equal(t, tc.level, info.Level)
+				assert.Contains(t, info.Message, tc.message)
+			case <-time.After(time.Second):
+				t.Fatalf("%s: dead letters were not retried", tc.desc)
+			}
+			assert.True(t, until.After(due), fmt.Sprintf("%s: expected claimed dead letters to be leased", tc.desc))
+
+			cancel()
+			err := <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+
+			repoCall.Unset()
+			repoCall1.Unset()
+			pubCall.Unset()
+			tickCall.Unset()
+			tickCall1.Unset()
+		})
+	}
+}
+
+func TestStartDeadLetterCleanup(t *testing.T) {
+	repo := new(mocks.Repository)
+	tck := new(tmocks.Ticker)
+	ri := make(chan pkglog.RunInfo, 1)
+	retention := time.Hour
+
+	cases := []struct {
+		desc      string
+		removeErr error
+	}{
+		{
+			desc: "remove expired dead letters successfully",
+		},
+		{
+			desc:      "remove expired dead letters with failed repo",
+			removeErr: repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			removed := make(chan time.Time, 1)
+			repoCall := repo.On("RemoveDeadLetters", mock.Anything, mock.Anything).Return(tc.removeErr).Run(func(args mock.Arguments) {
+				removed <- args.Get(1).(time.Time)
+			})
+			tickChan := make(chan time.Time, 1)
+			tickCall := tck.On("Tick").Return((<-chan time.Time)(tickChan))
+			tickCall1 := tck.On("Stop").Return()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- re.StartDeadLetterCleanup(ctx, repo, tck, retention, ri)
+			}()
+
+			tickChan <- time.Now()
+			select {
+			case before := <-removed:
+				assert.WithinDuration(t, time.Now().UTC().Add(-retention), before, time.Second)
+			case <-time.After(time.Second):
+				t.Fatalf("%s: dead letters were not removed", tc.desc)
+			}
+			if tc.removeErr != nil {
+				info := <-ri
+				assert.Equal(t, slog.LevelError, info.Level)
+				assert.Contains(t, info.Message, "failed to remove dead letters")
+			}
+
+			cancel()
+			err := <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+
+			repoCall.Unset()
+			tickCall.Unset()
+			tickCall1.Unset()
+		})
+	}
+}
+
+func newDeadLetter(t *testing.T, status re.DeadLetterStatus, retry *outputs.RetryPolicy) re.DeadLetter {
+	output := &outputs.ChannelPublisher{Channel: "alerts", Topic: "high"}
+	output.Retry = retry
+	data, err := json.Marshal(output)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return re.DeadLetter{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     ruleID,
+		DomainID:   domainID,
+		OutputType: outputs.ChannelsType.String(),
+		Output:     data,
+		Message: re.DeadLetterMessage{
+			Channel:   inputChannel,
+			Publisher: testsutil.GenerateUUID(t),
+			Protocol:  "mqtt",
+			Payload:   []byte(`{"temperature": 35}`),
+		},
+		Result:    map[string]any{"temperature": float64(35)},
+		Error:     errPublish.Error(),
+		Status:    status,
+		Attempts:  1,
+		CreatedAt: time.Now().UTC().Add(-time.Minute),
+	}
+}
@@ -12,16 +12,18 @@ import (
 )
 
 const (
-	rulePrefix         = "rule."
-	ruleCreate         = rulePrefix + "create"
-	ruleList           = rulePrefix + "list"
-	ruleView           = rulePrefix + "view"
-	ruleUpdate         = rulePrefix + "update"
-	ruleUpdateTags     = rulePrefix + "update_tags"
-	ruleUpdateSchedule = rulePrefix + "update_schedule"
-	ruleEnable         = rulePrefix + "enable"
-	ruleDisable        = rulePrefix + "disable"
-	ruleRemove         = rulePrefix + "remove"
+	rulePrefix           = "rule."
+	ruleCreate           = rulePrefix + "create"
+	ruleList             = rulePrefix + "list"
+	ruleView             = rulePrefix + "view"
+	ruleUpdate           = rulePrefix + "update"
+	ruleUpdateTags       = rulePrefix + "update_tags"
+	ruleUpdateSchedule   = rulePrefix + "update_schedule"
+	ruleEnable           = rulePrefix + "enable"
+	ruleDisable          = rulePrefix + "disable"
+	ruleRemove           = rulePrefix + "remove"
+	ruleRestoreVersion   = rulePrefix + "restore_version"
+	ruleReplayDeadLetter = rulePrefix + "replay_dead_letter"
 )
 
 var (
@@ -34,6 +36,8 @@ var (
 	_ events.Event = (*enableRuleEvent)(nil)
 	_ events.Event = (*disableRuleEvent)(nil)
 	_ events.Event = (*removeRuleEvent)(nil)
+	_ events.Event = (*restoreRuleVersionEvent)(nil)
+	_ events.Event = (*replayDeadLetterEvent)(nil)
 )
 
 type baseRuleEvent struct {
@@ -187,3 +191,39 @@ func (rre removeRuleEvent) Encode() (map[string]any, error) {
 	val["operation"] = ruleRemove
 	return val, nil
 }
+
+type restoreRuleVersionEvent struct {
+	rule         re.Rule
+	restoredFrom uint64
+	baseRuleEvent
+}
+
+func (rrve restoreRuleVersionEvent) Encode() (map[string]any, error) {
+	val, err := rrve.rule.EventEncode()
+	if err != nil {
+		return map[string]any{}, err
+	}
+	maps.Copy(val, rrve.baseRuleEvent.Encode())
+	val["restored_from"] = rrve.restoredFrom
+	val["operation"] = ruleRestoreVersion
+	return val, nil
+}
+
+type replayDeadLetterEvent struct {
+	deadLetter re.DeadLetter
+	baseRuleEvent
+}
+
+func (rdle replayDeadLetterEvent) Encode() (map[string]any, error) {
+	val := rdle.baseRuleEvent.Encode()
+	val["id"] = rdle.deadLetter.ID
+	val["rule_id"] = rdle.deadLetter.RuleID
+	val["output_type"] = rdle.deadLetter.OutputType
+	val["status"] = rdle.deadLetter.Status.String()
+	val["attempts"] = rdle.deadLetter.Attempts
+	if rdle.deadLetter.Status != re.DeliveredDeadLetter {
+		val["error"] = rdle.deadLetter.Error
+	}
+	val["operation"] = ruleReplayDeadLetter
+	return val, nil
+}
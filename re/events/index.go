@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/events"
+	"github.com/absmach/magistrala/pkg/events/store"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/re"
+)
+
+const (
+	allStream = "events." + magistralaPrefix + rulePrefix + "*"
+	// indexInactiveThreshold is the time after which the consumer of a
+	// stopped service instance is removed from the event store.
+	indexInactiveThreshold = 10 * time.Minute
+)
+
+var _ events.EventHandler = (*indexHandler)(nil)
+
+type indexHandler struct {
+	index *re.RuleIndex
+}
+
+// SubscribeIndexInvalidation subscribes to rule events and invalidates the rules index
+// on rule changes, so changes made through any service instance are applied to all of them.
+// The consumer must be unique per service instance, since each instance needs all the events.
+// Consumers of stopped instances are removed once they stop consuming.
+func SubscribeIndexInvalidation(ctx context.Context, index *re.RuleIndex, esURL, consumer string, logger *slog.Logger) (events.Subscriber, error) {
+	subscriber, err := store.NewSubscriber(ctx, esURL, "re-index-es-sub", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := events.SubscriberConfig{
+		Stream:            allStream,
+		Consumer:          consumer,
+		Handler:           NewIndexHandler(index),
+		DeliveryPolicy:    messaging.DeliverNewPolicy,
+		InactiveThreshold: indexInactiveThreshold,
+	}
+	if err := subscriber.Subscribe(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	return subscriber, nil
+}
+
+// NewIndexHandler returns an event handler that invalidates
+// the rules of the event domain in the rules index.
+func NewIndexHandler(index *re.RuleIndex) events.EventHandler {
+	return &indexHandler{index: index}
+}
+
+func (h *indexHandler) Handle(_ context.Context, event events.Event) error {
+	data, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	switch events.Read(data, "operation", "") {
+	case ruleList, ruleView, ruleReplayDeadLetter, "":
+		// Read operations and replays don't change the rules.
+		return nil
+	}
+	if domainID := events.Read(data, "domain", ""); domainID != "" {
+		h.index.Invalidate(domainID)
+	}
+
+	return nil
+}
@@ -10,21 +10,24 @@ import (
 	"github.com/absmach/magistrala/pkg/events"
 	"github.com/absmach/magistrala/pkg/events/store"
 	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 const (
-	magistralaPrefix     = "magistrala."
-	CreateStream         = magistralaPrefix + ruleCreate
-	ListStream           = magistralaPrefix + ruleList
-	ViewStream           = magistralaPrefix + ruleView
-	UpdateStream         = magistralaPrefix + ruleUpdate
-	UpdateTagsStream     = magistralaPrefix + ruleUpdateTags
-	UpdateScheduleStream = magistralaPrefix + ruleUpdateSchedule
-	EnableStream         = magistralaPrefix + ruleEnable
-	DisableStream        = magistralaPrefix + ruleDisable
-	RemoveStream         = magistralaPrefix + ruleRemove
+	magistralaPrefix       = "magistrala."
+	CreateStream           = magistralaPrefix + ruleCreate
+	ListStream             = magistralaPrefix + ruleList
+	ViewStream             = magistralaPrefix + ruleView
+	UpdateStream           = magistralaPrefix + ruleUpdate
+	UpdateTagsStream       = magistralaPrefix + ruleUpdateTags
+	UpdateScheduleStream   = magistralaPrefix + ruleUpdateSchedule
+	EnableStream           = magistralaPrefix + ruleEnable
+	DisableStream          = magistralaPrefix + ruleDisable
+	RemoveStream           = magistralaPrefix + ruleRemove
+	RestoreVersionStream   = magistralaPrefix + ruleRestoreVersion
+	ReplayDeadLetterStream = magistralaPrefix + ruleReplayDeadLetter
 )
 
 var _ re.Service = (*eventStore)(nil)
@@ -183,10 +186,73 @@ func (es *eventStore) DisableRule(ctx context.Context, session authn.Session, id
 	return rule, nil
 }
 
+func (es *eventStore) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error) {
+	return es.svc.TestRule(ctx, session, r, msg)
+}
+
+func (es *eventStore) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	return es.svc.ListExecutions(ctx, session, pm)
+}
+
+func (es *eventStore) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	return es.svc.ListRuleVersions(ctx, session, pm)
+}
+
+func (es *eventStore) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (re.VersionDiff, error) {
+	return es.svc.DiffRuleVersions(ctx, session, ruleID, from, to)
+}
+
+func (es *eventStore) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error) {
+	rule, err := es.svc.RestoreRuleVersion(ctx, session, ruleID, version)
+	if err != nil {
+		return rule, err
+	}
+	event := restoreRuleVersionEvent{
+		rule:          rule,
+		restoredFrom:  version,
+		baseRuleEvent: newBaseRuleEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, RestoreVersionStream, event); err != nil {
+		return rule, err
+	}
+	return rule, nil
+}
+
+func (es *eventStore) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	return es.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (es *eventStore) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	return es.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (es *eventStore) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	dl, err := es.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+	if err != nil {
+		return dl, err
+	}
+	event := replayDeadLetterEvent{
+		deadLetter:    dl,
+		baseRuleEvent: newBaseRuleEvent(session, middleware.GetReqID(ctx)),
+	}
+	if err := es.Publish(ctx, ReplayDeadLetterStream, event); err != nil {
+		return dl, err
+	}
+	return dl, nil
+}
+
 func (es *eventStore) StartScheduler(ctx context.Context) error {
 	return es.svc.StartScheduler(ctx)
 }
 
+func (es *eventStore) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	return es.svc.StartDeadLetterRetries(ctx, tck)
+}
+
+func (es *eventStore) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	return es.svc.StartExecutionsWriter(ctx, tck)
+}
+
 func (es *eventStore) Handle(msg *messaging.Message) error {
 	return es.svc.Handle(msg)
 }
@@ -466,6 +466,106 @@ func TestDisableRule(t *testing.T) {
 	}
 }
 
+func TestRestoreRuleVersion(t *testing.T) {
+	svc, nsvc := newEventStoreMiddleware(t)
+
+	validCtx := context.WithValue(context.Background(), middleware.RequestIDKey, testsutil.GenerateUUID(t))
+	restored := validRule
+	restored.Version = 3
+
+	cases := []struct {
+		desc    string
+		session authn.Session
+		ruleID  string
+		version uint64
+		svcRes  re.Rule
+		svcErr  error
+		resp    re.Rule
+		err     error
+	}{
+		{
+			desc:    "publish successfully",
+			session: validSession,
+			ruleID:  validRule.ID,
+			version: 1,
+			svcRes:  restored,
+			resp:    restored,
+		},
+		{
+			desc:    "failed to publish with service error",
+			session: validSession,
+			ruleID:  validRule.ID,
+			version: 1,
+			svcRes:  re.Rule{},
+			svcErr:  svcerr.ErrUpdateEntity,
+			resp:    re.Rule{},
+			err:     svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RestoreRuleVersion", validCtx, tc.session, tc.ruleID, tc.version).Return(tc.svcRes, tc.svcErr)
+			resp, err := nsvc.RestoreRuleVersion(validCtx, tc.session, tc.ruleID, tc.version)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	svc, nsvc := newEventStoreMiddleware(t)
+
+	validCtx := context.WithValue(context.Background(), middleware.RequestIDKey, testsutil.GenerateUUID(t))
+	deadLetter := re.DeadLetter{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     validRule.ID,
+		DomainID:   validRule.DomainID,
+		OutputType: "channels",
+		Status:     re.DeliveredDeadLetter,
+		Attempts:   2,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc    string
+		session authn.Session
+		id      string
+		svcRes  re.DeadLetter
+		svcErr  error
+		resp    re.DeadLetter
+		err     error
+	}{
+		{
+			desc:    "publish successfully",
+			session: validSession,
+			id:      deadLetter.ID,
+			svcRes:  deadLetter,
+			resp:    deadLetter,
+		},
+		{
+			desc:    "failed to publish with service error",
+			session: validSession,
+			id:      deadLetter.ID,
+			svcRes:  re.DeadLetter{},
+			svcErr:  svcerr.ErrUpdateEntity,
+			resp:    re.DeadLetter{},
+			err:     svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ReplayDeadLetter", validCtx, tc.session, validRule.ID, tc.id).Return(tc.svcRes, tc.svcErr)
+			resp, err := nsvc.ReplayDeadLetter(validCtx, tc.session, validRule.ID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.resp, resp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, resp))
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestStartScheduler(t *testing.T) {
 	svc, nsvc := newEventStoreMiddleware(t)
 
@@ -0,0 +1,174 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
+)
+
+// errExecutionsBufferFull indicates that the execution of a rule run is not
+// saved, because the executions buffer is full.
+var errExecutionsBufferFull = errors.New("executions buffer is full")
+
+// Execution is a persisted record of a single rule run.
+type Execution struct {
+	ID        string `json:"id"`
+	RuleID    string `json:"rule_id"`
+	DomainID  string `json:"domain_id"`
+	Channel   string `json:"channel,omitempty"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	Protocol  string `json:"protocol,omitempty"`
+	// Level is the level of the run result: INFO and WARN runs are
+	// counted as successful, ERROR runs as failed.
+	Level string `json:"level"`
+	// Message describes the outcome of a successful run, and Error
+	// the reason of a failed one.
+	Message    string        `json:"message,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Outputs    []string      `json:"outputs,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	ExecutedAt time.Time     `json:"executed_at"`
+}
+
+// Failed returns true if the execution resulted in an error.
+func (e Execution) Failed() bool {
+	return e.Level == slog.LevelError.String()
+}
+
+// ExecutionStats contains aggregated run counters of a rule.
+type ExecutionStats struct {
+	SuccessCount uint64     `json:"success_count"`
+	FailureCount uint64     `json:"failure_count"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+}
+
+// ExecutionPageMeta contains page metadata for listing rule executions.
+type ExecutionPageMeta struct {
+	Offset uint64 `json:"offset"          db:"offset"`
+	Limit  uint64 `json:"limit"           db:"limit"`
+	Dir    string `json:"dir"             db:"dir"`
+	RuleID string `json:"rule_id"         db:"rule_id"`
+	Level  string `json:"level,omitempty" db:"level"`
+}
+
+type ExecutionsPage struct {
+	Offset     uint64      `json:"offset"`
+	Limit      uint64      `json:"limit"`
+	Total      uint64      `json:"total"`
+	Executions []Execution `json:"executions"`
+}
+
+// newExecution creates an execution record from the rule run result.
+func newExecution(r Rule, msg *messaging.Message, info pkglog.RunInfo, outputs []string, start time.Time) Execution {
+	e := Execution{
+		RuleID:     r.ID,
+		DomainID:   r.DomainID,
+		Channel:    msg.Channel,
+		Subtopic:   msg.Subtopic,
+		Publisher:  msg.Publisher,
+		Protocol:   msg.Protocol,
+		Level:      info.Level.String(),
+		Outputs:    outputs,
+		Duration:   time.Since(start),
+		ExecutedAt: start,
+	}
+	if e.Failed() {
+		e.Error = info.Message
+		return e
+	}
+	e.Message = info.Message
+
+	return e
+}
+
+// saveExecution buffers the execution for the executions writer, so the rule
+// run does not wait for the database.
+func (re *re) saveExecution(e Execution) error {
+	id, err := re.idp.ID()
+	if err != nil {
+		return err
+	}
+	e.ID = id
+
+	select {
+	case re.executions <- e:
+		return nil
+	default:
+		return errExecutionsBufferFull
+	}
+}
+
+func (re *re) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	defer tck.Stop()
+	batch := make([]Execution, 0, re.batch)
+	for {
+		select {
+		case <-ctx.Done():
+			// The context is canceled, but the buffered executions are still saved.
+			flushCtx := context.WithoutCancel(ctx)
+			for {
+				select {
+				case e := <-re.executions:
+					if batch = append(batch, e); len(batch) >= re.batch {
+						batch = re.flushExecutions(flushCtx, batch)
+					}
+				default:
+					re.flushExecutions(flushCtx, batch)
+					return ctx.Err()
+				}
+			}
+		case e := <-re.executions:
+			if batch = append(batch, e); len(batch) >= re.batch {
+				batch = re.flushExecutions(ctx, batch)
+			}
+		case <-tck.Tick():
+			batch = re.flushExecutions(ctx, batch)
+		}
+	}
+}
+
+// flushExecutions saves the batch and returns a new, empty batch.
+func (re *re) flushExecutions(ctx context.Context, batch []Execution) []Execution {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := re.repo.AddExecutions(ctx, batch); err != nil {
+		re.runInfo <- pkglog.RunInfo{
+			Level:   slog.LevelError,
+			Message: fmt.Sprintf("failed to save rule executions: %s", err),
+			Details: []slog.Attr{slog.Int("executions", len(batch))},
+		}
+	}
+
+	return make([]Execution, 0, re.batch)
+}
+
+// StartExecutionsCleanup periodically removes executions older than the retention period.
+func StartExecutionsCleanup(ctx context.Context, repo Repository, tck ticker.Ticker, retention time.Duration, runInfo chan<- pkglog.RunInfo) error {
+	defer tck.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tck.Tick():
+			before := time.Now().UTC().Add(-retention)
+			if err := repo.RemoveExecutions(ctx, before); err != nil {
+				runInfo <- pkglog.RunInfo{
+					Level:   slog.LevelError,
+					Message: fmt.Sprintf("failed to remove rule executions: %s", err),
+					Details: []slog.Attr{slog.Time("before", before)},
+				}
+			}
+		}
+	}
+}
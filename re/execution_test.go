@@ -0,0 +1,328 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	emocks "github.com/absmach/magistrala/pkg/emailer/mocks"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	pubsubmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	tmocks "github.com/absmach/magistrala/pkg/ticker/mocks"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/absmach/magistrala/re/state"
+	readmocks "github.com/absmach/magistrala/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListExecutions(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	execution := re.Execution{
+		ID:         testsutil.GenerateUUID(t),
+		RuleID:     ruleID,
+		DomainID:   domainID,
+		Channel:    inputChannel,
+		Level:      slog.LevelInfo.String(),
+		Message:    "rule processed successfully",
+		Outputs:    []string{outputs.ChannelsType.String()},
+		Duration:   time.Millisecond,
+		ExecutedAt: time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc    string
+		pm      re.ExecutionPageMeta
+		res     re.ExecutionsPage
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "list executions successfully",
+			pm:   re.ExecutionPageMeta{RuleID: ruleID, Limit: 10},
+			res: re.ExecutionsPage{
+				Total:      1,
+				Limit:      10,
+				Executions: []re.Execution{execution},
+			},
+		},
+		{
+			desc:    "list executions with failed repo",
+			pm:      re.ExecutionPageMeta{RuleID: ruleID, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ListExecutions", context.Background(), tc.pm).Return(tc.res, tc.repoErr)
+			res, err := svc.ListExecutions(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.res, res)
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestHandleSavesExecution(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 1)
+	svc, repo, pubsub, _, _, _ := newService(t, ri)
+
+	cases := []struct {
+		desc       string
+		logic      re.Script
+		publishErr error
+		saveErr    error
+		level      string
+		outputs    []string
+		failed     bool
+	}{
+		{
+			desc:    "save successful execution",
+			logic:   re.Script{Type: re.LuaType, Value: `return message.payload`},
+			level:   slog.LevelInfo.String(),
+			outputs: []string{outputs.ChannelsType.String()},
+		},
+		{
+			desc:    "save execution that skipped outputs",
+			logic:   re.Script{Type: re.GoType, Value: "package main\n\nfunc logicFunction() any {\n\treturn false\n}"},
+			level:   slog.LevelInfo.String(),
+			outputs: nil,
+		},
+		{
+			desc:       "save execution with failed output",
+			logic:      re.Script{Type: re.LuaType, Value: `return message.payload`},
+			publishErr: errors.New("publish failed"),
+			level:      slog.LevelError.String(),
+			outputs:    []string{outputs.ChannelsType.String()},
+			failed:     true,
+		},
+		{
+			desc:    "save execution with failed repo",
+			logic:   re.Script{Type: re.LuaType, Value: `return message.payload`},
+			saveErr: repoerr.ErrCreateEntity,
+			level:   slog.LevelInfo.String(),
+			outputs: []string{outputs.ChannelsType.String()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        tc.logic,
+				Outputs: re.Outputs{
+					&outputs.ChannelPublisher{Channel: "output.channel", Topic: "out"},
+				},
+			}
+			msg := &messaging.Message{
+				Domain:    domainID,
+				Channel:   inputChannel,
+				Publisher: testsutil.GenerateUUID(t),
+				Protocol:  "mqtt",
+				Payload:   []byte(`{"temperature": 20}`),
+			}
+			saved := make(chan []re.Execution, 1)
+			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repoCall1 := repo.On("AddExecutions", mock.Anything, mock.Anything).Return(tc.saveErr).Run(func(args mock.Arguments) {
+				saved <- args.Get(1).([]re.Execution)
+			})
+			dlCall := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			pubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr).Maybe()
+			tck := new(tmocks.Ticker)
+			tickChan := make(chan time.Time)
+			tck.On("Tick").Return((<-chan time.Time)(tickChan))
+			tck.On("Stop").Return()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- svc.StartExecutionsWriter(ctx, tck)
+			}()
+
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			info := <-ri
+			assert.NotContains(t, fmt.Sprint(info.Details), "execution_error")
+
+			// The execution is saved on the tick following its run.
+			var batch []re.Execution
+			timeout := time.After(time.Second)
+		wait:
+			for {
+				select {
+				case batch = <-saved:
+					break wait
+				case tickChan <- time.Now():
+				case <-timeout:
+					t.Fatalf("%s: execution was not saved", tc.desc)
+				}
+			}
+			assert.Len(t, batch, 1)
+			e := batch[0]
+			assert.NotEmpty(t, e.ID)
+			assert.Equal(t, rule.ID, e.RuleID)
+			assert.Equal(t, domainID, e.DomainID)
+			assert.Equal(t, msg.Channel, e.Channel)
+			assert.Equal(t, msg.Publisher, e.Publisher)
+			assert.Equal(t, msg.Protocol, e.Protocol)
+			assert.Equal(t, tc.level, e.Level)
+			assert.Equal(t, tc.outputs, e.Outputs)
+			assert.Equal(t, tc.failed, e.Failed())
+			assert.Equal(t, tc.failed, e.Error != "")
+			assert.False(t, e.ExecutedAt.IsZero())
+
+			if tc.saveErr != nil {
+				info := <-ri
+				assert.Equal(t, slog.LevelError, info.Level)
+				assert.Contains(t, info.Message, "failed to save rule executions")
+			}
+
+			cancel()
+			err = <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+			tck.AssertCalled(t, "Stop")
+
+			repoCall.Unset()
+			repoCall1.Unset()
+			dlCall.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestStartExecutionsWriter(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	repo := new(mocks.Repository)
+	svc, err := re.NewService(repo, state.NewMemory(), nil, ri, uuid.NewMock(), pubsubmocks.NewPubSub(t), nil, nil, new(tmocks.Ticker), new(emocks.Emailer), new(readmocks.ReadersServiceClient), re.Config{ExecutionsBatch: 2})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating service: %s", err))
+
+	rule := re.Rule{
+		ID:           testsutil.GenerateUUID(t),
+		DomainID:     domainID,
+		InputChannel: inputChannel,
+		Status:       re.EnabledStatus,
+		Logic:        re.Script{Type: re.LuaType, Value: `return false`},
+	}
+	msg := &messaging.Message{Domain: domainID, Channel: inputChannel, Payload: []byte(`{}`)}
+	repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+	saved := make(chan []re.Execution, 3)
+	repo.On("AddExecutions", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		saved <- args.Get(1).([]re.Execution)
+	})
+	for range 3 {
+		err := svc.Handle(msg)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		<-ri
+	}
+
+	tck := new(tmocks.Ticker)
+	tck.On("Tick").Return((<-chan time.Time)(make(chan time.Time)))
+	tck.On("Stop").Return()
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		errc <- svc.StartExecutionsWriter(ctx, tck)
+	}()
+
+	// A full batch is saved without waiting for a tick.
+	select {
+	case batch := <-saved:
+		assert.Len(t, batch, 2, "expected full batch to be saved")
+	case <-time.After(time.Second):
+		t.Fatal("full batch was not saved")
+	}
+
+	// The rest of the buffered executions are saved once the writer stops.
+	cancel()
+	err = <-errc
+	assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("expected %s got %s\n", context.Canceled, err))
+	select {
+	case batch := <-saved:
+		assert.Len(t, batch, 1, "expected buffered executions to be saved on stop")
+	default:
+		t.Fatal("buffered executions were not saved on stop")
+	}
+}
+
+func TestStartExecutionsCleanup(t *testing.T) {
+	repo := new(mocks.Repository)
+	tck := new(tmocks.Ticker)
+	ri := make(chan pkglog.RunInfo, 1)
+	retention := time.Hour
+
+	cases := []struct {
+		desc      string
+		removeErr error
+	}{
+		{
+			desc: "remove expired executions successfully",
+		},
+		{
+			desc:      "remove expired executions with failed repo",
+			removeErr: repoerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			removed := make(chan time.Time, 1)
+			repoCall := repo.On("RemoveExecutions", mock.Anything, mock.Anything).Return(tc.removeErr).Run(func(args mock.Arguments) {
+				removed <- args.Get(1).(time.Time)
+			})
+			tickChan := make(chan time.Time, 1)
+			tickCall := tck.On("Tick").Return((<-chan time.Time)(tickChan))
+			tickCall1 := tck.On("Stop").Return()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errc := make(chan error)
+			go func() {
+				errc <- re.StartExecutionsCleanup(ctx, repo, tck, retention, ri)
+			}()
+
+			tickChan <- time.Now()
+			select {
+			case before := <-removed:
+				assert.WithinDuration(t, time.Now().UTC().Add(-retention), before, time.Second)
+			case <-time.After(time.Second):
+				t.Fatalf("%s: executions were not removed", tc.desc)
+			}
+			if tc.removeErr != nil {
+				info := <-ri
+				assert.Equal(t, slog.LevelError, info.Level)
+				assert.Contains(t, info.Message, "failed to remove rule executions")
+			}
+
+			cancel()
+			err := <-errc
+			assert.True(t, errors.Contains(err, context.Canceled), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, context.Canceled, err))
+
+			repoCall.Unset()
+			tickCall.Unset()
+			tickCall1.Unset()
+		})
+	}
+}
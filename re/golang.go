@@ -8,8 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"path"
 	"reflect"
-	"regexp"
+	"slices"
+	"testing/fstest"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	pkglog "github.com/absmach/magistrala/pkg/logger"
@@ -20,11 +23,6 @@ import (
 
 const logicFunction = "main.logicFunction"
 
-var (
-	goKeywordRegex = regexp.MustCompile(`\bgo\s+func\s*\(|^\s*go\s+\w+\(|[;\s{]go\s+func\s*\(|[;\s{]go\s+\w+\(`)
-	panicRegex     = regexp.MustCompile(`\bpanic\s*\(`)
-)
-
 // Type message is a magistrala message with payload replaces by JSON deserialized payload.
 type message struct {
 	Channel   string `json:"channel,omitempty"`
@@ -37,21 +35,127 @@ type message struct {
 	Payload   any    `json:"payload,omitempty"`
 }
 
-func (re *re) processGo(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (ret pkglog.RunInfo) {
+func (re *re) processGo(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (pkglog.RunInfo, []string) {
+	p, release, err := re.scripts.golang(ctx, r.ID, r.Logic, re.sandbox.modules(r.DomainID).Go)
+	if err != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}, nil
+	}
+	runCtx, cancel := re.runContext(ctx, r.DomainID, GoType)
+	res, err := p.run(runCtx, newRuleState(re.state, r.ID, msg, false), msg, nil)
+	cancel()
+	release()
+	if err != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}, nil
+	}
+	if b, ok := res.(bool); ok && !b {
+		return re.autoClear(ctx, r, msg, pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details})
+	}
+	err = re.trackRaised(ctx, r, msg)
+	var attempted []string
+	for _, o := range r.Outputs {
+		attempted = append(attempted, outputType(o))
+		if e := re.runOutput(ctx, r, o, msg, res); e != nil {
+			err = errors.Wrap(e, err)
+		}
+	}
+	ret := pkglog.RunInfo{Level: slog.LevelInfo, Details: details, Message: "rule processed successfully"}
+	if err != nil {
+		ret.Level = slog.LevelError
+		ret.Message = fmt.Sprintf("failed to handle rule output: %s", err)
+	}
+	return ret, attempted
+}
+
+// runGo interprets the script and returns the result of its logic function.
+func runGo(ctx context.Context, st *ruleState, script string, packages []string, msg *messaging.Message, prev any) (any, error) {
+	p, err := compileGo(ctx, script, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.run(ctx, st, msg, prev)
+}
+
+// goProgram is an interpreter with an evaluated rule script. Interpreters are
+// not safe for concurrent use, so a program must not be shared between runs.
+type goProgram struct {
+	i   *golang.Interpreter
+	env *goEnv
+	// Stopped programs may still be running in the background, so they can't be reused.
+	stopped bool
+	// Programs with package-level variables are not reused, so the
+	// variables don't keep their values from the previous runs.
+	globals bool
+}
+
+// goEnv is the environment of a single run exposed to the script.
+type goEnv struct {
+	ctx context.Context
+	st  *ruleState
+	msg message
+	// result is the result of the previous pipeline step.
+	result any
+}
+
+// compileGo evaluates the script in an interpreter which provides only the
+// allowed standard library packages and can't load packages from the file system.
+func compileGo(ctx context.Context, script string, packages []string) (p *goProgram, err error) {
+	if err := checkGo(script, packages); err != nil {
+		return nil, err
+	}
+	file := parseGo(script)
+	globals := file != nil && hasGlobals(file)
 	defer func() {
 		if r := recover(); r != nil {
-			ret = pkglog.RunInfo{
-				Level:   slog.LevelError,
-				Details: details,
-				Message: fmt.Sprintf("panic in Go script: %v", r),
-			}
+			err = fmt.Errorf("panic in Go script: %v", r)
 		}
 	}()
 
-	i := golang.New(golang.Options{})
-	if err := i.Use(stdlib.Symbols); err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+	i := golang.New(golang.Options{SourcecodeFilesystem: fstest.MapFS{}})
+	symbols := golang.Exports{}
+	for key, sym := range stdlib.Symbols {
+		if slices.Contains(packages, path.Dir(key)) {
+			symbols[key] = sym
+		}
+	}
+	if err := i.Use(symbols); err != nil {
+		return nil, err
+	}
+	env := &goEnv{}
+	err = i.Use(golang.Exports{
+		"messaging/m": {
+			// Exported as a variable, so the script reads the message of the current run.
+			"message": reflect.ValueOf(&env.msg).Elem(),
+			"result":  reflect.ValueOf(&env.result).Elem(),
+		},
+		"state/state":   stateSymbols(env),
+		"window/window": windowSymbols(env),
+	})
+	if err != nil {
+		return nil, err
 	}
+	if _, err = i.EvalWithContext(ctx, script); err != nil {
+		return nil, scriptError(ctx, err)
+	}
+	ifc, err := i.EvalWithContext(ctx, logicFunction)
+	if err != nil {
+		return nil, scriptError(ctx, err)
+	}
+	if _, ok := ifc.Interface().(func() any); !ok {
+		return nil, errors.New("invalid logic function signature")
+	}
+
+	return &goProgram{i: i, env: env, globals: globals}, nil
+}
+
+// run runs the logic function for the message and the result of the previous pipeline step.
+func (p *goProgram) run(ctx context.Context, st *ruleState, msg *messaging.Message, prev any) (res any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in Go script: %v", r)
+		}
+	}()
+
 	m := message{
 		Created:   msg.Created,
 		ClientID:  msg.ClientIdentity(),
@@ -67,38 +171,65 @@ func (re *re) processGo(ctx context.Context, details []slog.Attr, r Rule, msg *m
 	}
 	m.Payload = pld
 
-	err := i.Use(golang.Exports{
-		"messaging/m": {
-			"message": reflect.ValueOf(m),
-		},
-	})
-	if err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+	p.env.ctx, p.env.st, p.env.msg, p.env.result = ctx, st, m, prev
+	// The logic function is called through the interpreter, so it's interrupted on context cancellation.
+	v, err := p.i.EvalWithContext(ctx, logicFunction+"()")
+	if ctx.Err() != nil {
+		p.stopped = true
+		return nil, scriptError(ctx, err)
 	}
-	if _, err = i.Eval(r.Logic.Value); err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
-	}
-	ifc, err := i.Eval(logicFunction)
+	// Don't keep the run environment referenced between runs.
+	p.env.ctx, p.env.st, p.env.msg, p.env.result = nil, nil, message{}, nil
 	if err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Details: details, Message: err.Error()}
+		return nil, err
 	}
-	f, ok := ifc.Interface().(func() any)
-	if !ok {
-		return pkglog.RunInfo{Level: slog.LevelError, Message: "invalid logic function signature", Details: details}
+	if !v.IsValid() {
+		return nil, nil
 	}
-	res := f()
-	if b, ok := res.(bool); ok && !b {
-		return pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details}
+
+	return v.Interface(), nil
+}
+
+// stateSymbols exposes the rule key/value state to Go scripts as the "state" package.
+func stateSymbols(env *goEnv) map[string]reflect.Value {
+	return map[string]reflect.Value{
+		"Get": reflect.ValueOf(func(key string) (any, error) {
+			return env.st.get(env.ctx, key)
+		}),
+		"Set": reflect.ValueOf(func(key string, value any, ttl time.Duration) error {
+			return env.st.set(env.ctx, key, value, ttl)
+		}),
+		"Incr": reflect.ValueOf(func(key string, delta float64, ttl time.Duration) (float64, error) {
+			return env.st.incr(env.ctx, key, delta, ttl)
+		}),
+		"Delete": reflect.ValueOf(func(key string) error {
+			return env.st.delete(env.ctx, key)
+		}),
 	}
-	for _, o := range r.Outputs {
-		if e := re.handleOutput(ctx, o, r, msg, res); e != nil {
-			err = errors.Wrap(e, err)
+}
+
+// windowSymbols exposes window aggregations to Go scripts as the "window" package.
+// Size is either a time.Duration or an int number of latest samples, and aggregations
+// of empty windows return zero.
+func windowSymbols(env *goEnv) map[string]reflect.Value {
+	agg := func(fn string) func(key string, size any) (float64, error) {
+		return func(key string, size any) (float64, error) {
+			samples, err := env.st.window(env.ctx, key, size)
+			if err != nil {
+				return 0, err
+			}
+			v, _ := aggregate(fn, samples)
+			return v, nil
 		}
 	}
-	ret = pkglog.RunInfo{Level: slog.LevelInfo, Details: details, Message: "rule processed successfully"}
-	if err != nil {
-		ret.Level = slog.LevelError
-		ret.Message = fmt.Sprintf("failed to handle rule output: %s", err)
+
+	return map[string]reflect.Value{
+		"Add": reflect.ValueOf(func(key string, value float64) error {
+			return env.st.addSample(env.ctx, key, value)
+		}),
+		"Min":   reflect.ValueOf(agg("min")),
+		"Max":   reflect.ValueOf(agg("max")),
+		"Avg":   reflect.ValueOf(agg("avg")),
+		"Count": reflect.ValueOf(agg("count")),
 	}
-	return ret
 }
@@ -8,10 +8,11 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
-	"strings"
 	"time"
 
+	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	pkglog "github.com/absmach/magistrala/pkg/logger"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/re/outputs"
@@ -20,12 +21,15 @@ import (
 var (
 	scheduledTrue  = true
 	scheduledFalse = false
+
+	errTrackRaised = errors.New("failed to save rule alarm state")
 )
 
 const (
 	maxPayload     = 100 * 1024
 	pldExceededFmt = "max payload size of 100kB exceeded: "
 	protocol       = "nats"
+	testTimeout    = 5 * time.Second
 )
 
 func (re *re) Handle(msg *messaging.Message) error {
@@ -33,86 +37,288 @@ func (re *re) Handle(msg *messaging.Message) error {
 	if n := len(msg.Payload); n > maxPayload {
 		return errors.New(pldExceededFmt + strconv.Itoa(n))
 	}
+	ctx := context.Background()
+	rules, err := re.listRules(ctx, msg.Domain, msg.Channel)
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if !messaging.MatchTopic(msg.Subtopic, r.InputTopic) {
+			continue
+		}
+		// Submit blocks while all the workers are busy, slowing down the consumption.
+		ok := re.pool.submit(func() {
+			re.runInfo <- re.process(ctx, r, msg)
+		})
+		if !ok {
+			re.runInfo <- pkglog.RunInfo{
+				Level:   slog.LevelError,
+				Message: "rule run dropped: no free worker",
+				Details: []slog.Attr{
+					slog.String("domain_id", r.DomainID),
+					slog.String("rule_id", r.ID),
+					slog.String("rule_name", r.Name),
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+// listRules returns the enabled, non-scheduled rules of the channel from the index,
+// loading them on cache miss.
+func (re *re) listRules(ctx context.Context, domainID, channel string) ([]Rule, error) {
+	rules, gen, ok := re.index.get(domainID, channel)
+	if ok {
+		re.metrics.Cache.With("cache", "rules", "result", "hit").Add(1)
+		return rules, nil
+	}
+	re.metrics.Cache.With("cache", "rules", "result", "miss").Add(1)
+
 	// Skip filtering by message topic and fetch all non-scheduled rules instead.
 	// It's cleaner and more efficient to match wildcards in Go, but we can
 	// revisit this if it ever becomes a performance bottleneck.
 	pm := PageMeta{
-		Domain:       msg.Domain,
-		InputChannel: msg.Channel,
+		Domain:       domainID,
+		InputChannel: channel,
 		Status:       EnabledStatus,
 		Scheduled:    &scheduledFalse,
 	}
-	ctx := context.Background()
 	page, err := re.repo.ListAllRules(ctx, pm)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	re.bindOutputs(page.Rules)
+	re.index.set(domainID, channel, gen, page.Rules)
+
+	return page.Rules, nil
+}
+
+func (re *re) process(ctx context.Context, r Rule, msg *messaging.Message) pkglog.RunInfo {
+	start := time.Now().UTC()
+	details := []slog.Attr{
+		slog.String("domain_id", r.DomainID),
+		slog.String("rule_id", r.ID),
+		slog.String("rule_name", r.Name),
+		slog.Time("exec_time", start),
+	}
+	var info pkglog.RunInfo
+	var attempted []string
+	switch {
+	case len(r.Steps) > 0:
+		info, attempted = re.processPipeline(ctx, details, r, msg)
+	case r.Logic.Type == GoType:
+		info, attempted = re.processGo(ctx, details, r, msg)
+	default:
+		info, attempted = re.processLua(ctx, details, r, msg)
+	}
+	if err := re.saveExecution(newExecution(r, msg, info, attempted, start)); err != nil {
+		info.Details = append(info.Details, slog.String("execution_error", err.Error()))
 	}
-	for _, r := range page.Rules {
-		if matchTopic(msg.Subtopic, r.InputTopic) {
-			go func(ctx context.Context) {
-				re.runInfo <- re.process(ctx, r, msg)
-			}(ctx)
+
+	return info
+}
+
+// runLogic executes the rule logic in the sandbox and returns the converted result.
+func (re *re) runLogic(ctx context.Context, st *ruleState, r Rule, msg *messaging.Message) (any, error) {
+	return re.runScript(ctx, st, r.DomainID, r.Logic, msg, nil)
+}
+
+// runScript executes the script in the sandbox with the result of the previous
+// pipeline step and returns the converted result. The script is not cached.
+func (re *re) runScript(ctx context.Context, st *ruleState, domainID string, s Script, msg *messaging.Message, prev any) (any, error) {
+	ctx, cancel := re.runContext(ctx, domainID, s.Type)
+	defer cancel()
+
+	switch s.Type {
+	case GoType:
+		return runGo(ctx, st, s.Value, re.sandbox.modules(domainID).Go, msg, prev)
+	default:
+		l := newLuaState(re.sandbox, domainID)
+		defer l.Close()
+		setLuaResult(l, prev)
+		result, err := runLua(ctx, l, st, s.Value, msg)
+		if err != nil {
+			return nil, err
 		}
+		return convertLua(result), nil
 	}
+}
 
-	return nil
+func (re *re) TestRule(ctx context.Context, session authn.Session, r Rule, msg *messaging.Message) (TestResult, error) {
+	if err := re.validateRule(session.DomainID, r); err != nil {
+		return TestResult{}, err
+	}
+	if n := len(msg.Payload); n > maxPayload {
+		return TestResult{}, errors.Wrap(svcerr.ErrMalformedEntity, errors.New(pldExceededFmt+strconv.Itoa(n)))
+	}
+	r.DomainID = session.DomainID
+	msg.Domain = session.DomainID
+	if msg.Channel == "" {
+		msg.Channel = r.InputChannel
+	}
+	if msg.Subtopic == "" {
+		msg.Subtopic = r.InputTopic
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+
+	// Rule state is readable, but changes are not persisted in test runs.
+	st := newRuleState(re.state, r.ID, msg, true)
+	if len(r.Steps) > 0 {
+		return re.testPipeline(ctx, st, r, msg), nil
+	}
+	res, err := re.runLogic(ctx, st, r, msg)
+	if err != nil {
+		return TestResult{Error: fmt.Sprintf("failed to run rule logic: %s", err)}, nil
+	}
+	ret := TestResult{Result: res}
+	// Outputs are skipped for nil and false results, same as in processing.
+	if !truthy(res) {
+		return ret, nil
+	}
+	ret.Outputs = renderOutputs(r, r.Outputs, msg, res)
+
+	return ret, nil
 }
 
-// matchTopic matches a published subtopic against a subscription pattern
-// using MQTT-style wildcards: + (single level) and # (multi-level).
-func matchTopic(published, subscribed string) bool {
-	p := strings.Split(published, "/")
-	s := strings.Split(subscribed, "/")
-	n := len(p)
+// testPipeline runs the pipeline and renders the outputs of the taken branches.
+// The result is the result of the last step that ran.
+func (re *re) testPipeline(ctx context.Context, st *ruleState, r Rule, msg *messaging.Message) TestResult {
+	run := func(ctx context.Context, s Script, prev any) (any, error) {
+		return re.runScript(ctx, st, r.DomainID, s, msg, prev)
+	}
+	var ret TestResult
+	steps, err := runPipeline(ctx, r.Steps, run, func(outs Outputs, res any) {
+		ret.Outputs = append(ret.Outputs, renderOutputs(r, outs, msg, res)...)
+	})
+	ret.Steps = steps
+	if len(steps) > 0 {
+		ret.Result = steps[len(steps)-1].Result
+	}
+	if err != nil {
+		ret.Error = fmt.Sprintf("failed to run rule logic: %s", err)
+	}
+
+	return ret
+}
 
-	for i := range s {
-		if s[i] == "#" {
-			return true
+// renderOutputs renders the payloads the outputs would deliver for the result.
+func renderOutputs(r Rule, outs Outputs, msg *messaging.Message, res any) []OutputResult {
+	var ret []OutputResult
+	for _, o := range outs {
+		or := OutputResult{Type: outputType(o)}
+		if a, ok := o.(*outputs.Alarm); ok {
+			a.RuleID = r.ID
 		}
-		if i >= n {
-			return false
+		rd, ok := o.(Renderer)
+		if !ok {
+			or.Error = fmt.Sprintf("output type %s does not support rendering", or.Type)
+			ret = append(ret, or)
+			continue
 		}
-		if s[i] != "+" && p[i] != s[i] {
-			return false
+		pld, err := rd.Render(msg, res)
+		if err != nil {
+			or.Error = err.Error()
+		} else {
+			or.Payload = pld
 		}
+		ret = append(ret, or)
 	}
-	return len(s) == n
+
+	return ret
 }
 
-func (re *re) process(ctx context.Context, r Rule, msg *messaging.Message) pkglog.RunInfo {
-	details := []slog.Attr{
-		slog.String("domain_id", r.DomainID),
-		slog.String("rule_id", r.ID),
-		slog.String("rule_name", r.Name),
-		slog.Time("exec_time", time.Now().UTC()),
-	}
-	switch r.Logic.Type {
-	case GoType:
-		return re.processGo(ctx, details, r, msg)
+func (re *re) handleOutput(ctx context.Context, o Runnable, msg *messaging.Message, val any) error {
+	switch o.(type) {
+	case *outputs.Alarm, *outputs.Email, *outputs.ChannelPublisher, *outputs.SenML,
+		*outputs.Postgres, *outputs.Slack, *outputs.Webhook:
+		return o.Run(ctx, msg, val)
 	default:
-		return re.processLua(ctx, details, r, msg)
+		return fmt.Errorf("unknown output type: %T", o)
 	}
 }
 
-func (re *re) handleOutput(ctx context.Context, o Runnable, r Rule, msg *messaging.Message, val any) error {
+// autoClear clears the alarms raised by the rule for the message source once
+// the rule logic no longer holds, if the rule has an auto-clear alarm output.
+// The alarms are cleared only if the logic held on the previous evaluation
+// for the source, so a source in the normal state doesn't publish a clear on
+// every message. A failed clear is reported in the run info.
+func (re *re) autoClear(ctx context.Context, r Rule, msg *messaging.Message, info pkglog.RunInfo) (pkglog.RunInfo, []string) {
+	a := autoClearAlarm(r)
+	if a == nil {
+		return info, nil
+	}
+	st := newRuleState(re.state, r.ID, msg, false)
+	raised, err := st.raised(ctx)
+	if err != nil {
+		info.Level = slog.LevelError
+		info.Message = fmt.Sprintf("failed to clear rule alarms: %s", err)
+		return info, nil
+	}
+	if !raised {
+		return info, nil
+	}
+	if err := a.Clear(ctx, msg); err != nil {
+		info.Level = slog.LevelError
+		info.Message = fmt.Sprintf("failed to clear rule alarms: %s", err)
+		return info, []string{outputs.AlarmsType.String()}
+	}
+	if err := st.setRaised(ctx, false); err != nil {
+		info.Level = slog.LevelError
+		info.Message = fmt.Sprintf("failed to clear rule alarms: %s", err)
+	}
+
+	return info, []string{outputs.AlarmsType.String()}
+}
+
+// trackRaised records that the rule logic held for the message source, so the
+// next evaluation which doesn't hold clears the source alarms.
+func (re *re) trackRaised(ctx context.Context, r Rule, msg *messaging.Message) error {
+	if autoClearAlarm(r) == nil {
+		return nil
+	}
+	if err := newRuleState(re.state, r.ID, msg, false).setRaised(ctx, true); err != nil {
+		return errors.Wrap(errTrackRaised, err)
+	}
+
+	return nil
+}
+
+// autoClearAlarm returns the auto-clear alarm output of the rule, or nil if it has none.
+func autoClearAlarm(r Rule) *outputs.Alarm {
+	for _, o := range r.allOutputs() {
+		if a, ok := o.(*outputs.Alarm); ok && a.AutoClear {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// bindOutputs sets the service dependencies of the rules outputs. Outputs are
+// bound once the rules are loaded, since cached rules are shared between runs.
+func (re *re) bindOutputs(rules []Rule) {
+	for _, r := range rules {
+		for _, o := range r.allOutputs() {
+			re.bindOutput(r.ID, o)
+		}
+	}
+}
+
+func (re *re) bindOutput(ruleID string, o Runnable) {
 	switch o := o.(type) {
 	case *outputs.Alarm:
 		o.AlarmsPub = re.alarmsPub
-		o.RuleID = r.ID
-		return o.Run(ctx, msg, val)
+		o.RuleID = ruleID
 	case *outputs.Email:
 		o.Emailer = re.email
-		return o.Run(ctx, msg, val)
 	case *outputs.ChannelPublisher:
 		o.RePubSub = re.rePubSub
-		return o.Run(ctx, msg, val)
 	case *outputs.SenML:
 		o.WritersPub = re.writersPub
-		return o.Run(ctx, msg, val)
-	case *outputs.Postgres, *outputs.Slack:
-		return o.Run(ctx, msg, val)
-	default:
-		return fmt.Errorf("unknown output type: %T", o)
 	}
 }
 
@@ -141,6 +347,7 @@ func (re *re) StartScheduler(ctx context.Context) error {
 				continue
 			}
 
+			re.bindOutputs(page.Rules)
 			for _, r := range page.Rules {
 				go func(rule Rule, dueTime time.Time) {
 					if _, err := re.repo.UpdateRuleDue(ctx, rule.ID, rule.Schedule.NextDue()); err != nil {
@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// RuleIndex caches the enabled, non-scheduled rules by domain and input channel,
+// so messages are matched against rules without querying the database. Entries
+// of a domain are invalidated on rule changes, and expire after the TTL in case
+// a change is missed. A nil index doesn't cache anything.
+type RuleIndex struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]indexEntry
+	// Generations are used to discard rules loaded before an invalidation.
+	generations map[string]uint64
+}
+
+type indexEntry struct {
+	rules     []Rule
+	expiresAt time.Time
+}
+
+// NewRuleIndex returns a new rule index with the given TTL.
+func NewRuleIndex(ttl time.Duration) *RuleIndex {
+	return &RuleIndex{
+		ttl:         ttl,
+		entries:     make(map[string]indexEntry),
+		generations: make(map[string]uint64),
+	}
+}
+
+// Invalidate removes the rules of the domain from the index.
+func (idx *RuleIndex) Invalidate(domainID string) {
+	if idx == nil {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.generations[domainID]++
+	prefix := indexKey(domainID, "")
+	for key := range idx.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(idx.entries, key)
+		}
+	}
+}
+
+// get returns the cached rules and the generation of the domain, which
+// must be passed when storing the rules loaded after a cache miss.
+func (idx *RuleIndex) get(domainID, channel string) ([]Rule, uint64, bool) {
+	if idx == nil {
+		return nil, 0, false
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	e, ok := idx.entries[indexKey(domainID, channel)]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, idx.generations[domainID], false
+	}
+
+	return e.rules, 0, true
+}
+
+// set stores the rules unless the domain was invalidated after they were loaded.
+func (idx *RuleIndex) set(domainID, channel string, generation uint64, rules []Rule) {
+	if idx == nil {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.generations[domainID] != generation {
+		return
+	}
+	idx.entries[indexKey(domainID, channel)] = indexEntry{
+		rules:     rules,
+		expiresAt: time.Now().Add(idx.ttl),
+	}
+}
+
+func indexKey(domainID, channel string) string {
+	return domainID + "/" + channel
+}
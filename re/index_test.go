@@ -0,0 +1,162 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	pubsubmocks "github.com/absmach/magistrala/pkg/messaging/mocks"
+	tmocks "github.com/absmach/magistrala/pkg/ticker/mocks"
+	"github.com/absmach/magistrala/pkg/uuid"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/events"
+	"github.com/absmach/magistrala/re/mocks"
+	"github.com/absmach/magistrala/re/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testEvent map[string]any
+
+func (e testEvent) Encode() (map[string]any, error) {
+	return e, nil
+}
+
+func newCachingService(t *testing.T, idx *re.RuleIndex, cfg re.Config, runInfo chan pkglog.RunInfo) (re.Service, *mocks.Repository, *pubsubmocks.PubSub) {
+	repo := new(mocks.Repository)
+	pubsub := pubsubmocks.NewPubSub(t)
+	svc, err := re.NewService(repo, state.NewMemory(), idx, runInfo, uuid.NewMock(), pubsub, pubsub, pubsub, new(tmocks.Ticker), nil, nil, cfg)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	return svc, repo, pubsub
+}
+
+func TestHandleWithRuleIndex(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	idx := re.NewRuleIndex(time.Minute)
+	svc, repo, _ := newCachingService(t, idx, re.Config{}, ri)
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	rule := re.Rule{
+		ID:           testsutil.GenerateUUID(t),
+		DomainID:     domainID,
+		InputChannel: inputChannel,
+		Status:       re.EnabledStatus,
+		Logic:        re.Script{Type: re.LuaType, Value: "return 1"},
+	}
+	repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+	dlCall := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+	repoCall2 := repo.On("UpdateRuleStatus", mock.Anything, mock.Anything).Return(rule, nil)
+	defer func() {
+		repoCall.Unset()
+		dlCall.Unset()
+		repoCall2.Unset()
+	}()
+
+	cases := []struct {
+		desc    string
+		channel string
+		change  func()
+		loads   int
+	}{
+		{
+			desc:    "handle message with rules loaded from repository",
+			channel: inputChannel,
+			loads:   1,
+		},
+		{
+			desc:    "handle message with rules from index",
+			channel: inputChannel,
+			loads:   1,
+		},
+		{
+			desc:    "handle message of another channel",
+			channel: "another.channel",
+			loads:   2,
+		},
+		{
+			desc:    "handle message after rule change",
+			channel: inputChannel,
+			change: func() {
+				_, err := svc.DisableRule(context.Background(), session, rule.ID)
+				assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			},
+			loads: 3,
+		},
+		{
+			desc:    "handle message after index invalidation",
+			channel: inputChannel,
+			change:  func() { idx.Invalidate(domainID) },
+			loads:   4,
+		},
+		{
+			desc:    "handle message after rule event",
+			channel: inputChannel,
+			change: func() {
+				err := events.NewIndexHandler(idx).Handle(context.Background(), testEvent{"operation": "rule.update", "domain": domainID})
+				assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			},
+			loads: 5,
+		},
+		{
+			desc:    "handle message after read rule event",
+			channel: inputChannel,
+			change: func() {
+				err := events.NewIndexHandler(idx).Handle(context.Background(), testEvent{"operation": "rule.view", "domain": domainID})
+				assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			},
+			loads: 5,
+		},
+		{
+			desc:    "handle message after invalidation of another domain",
+			channel: inputChannel,
+			change:  func() { idx.Invalidate(testsutil.GenerateUUID(t)) },
+			loads:   5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.change != nil {
+				tc.change()
+			}
+			err := svc.Handle(&messaging.Message{Domain: domainID, Channel: tc.channel})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			<-ri
+			repo.AssertNumberOfCalls(t, "ListAllRules", tc.loads)
+		})
+	}
+}
+
+func TestRuleIndexExpiration(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	svc, repo, _ := newCachingService(t, re.NewRuleIndex(50*time.Millisecond), re.Config{}, ri)
+
+	rule := re.Rule{
+		ID:           testsutil.GenerateUUID(t),
+		DomainID:     domainID,
+		InputChannel: inputChannel,
+		Status:       re.EnabledStatus,
+		Logic:        re.Script{Type: re.LuaType, Value: "return 1"},
+	}
+	repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+	repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	for _, wait := range []time.Duration{0, 0, 100 * time.Millisecond} {
+		time.Sleep(wait)
+		err := svc.Handle(&messaging.Message{Domain: domainID, Channel: inputChannel})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		<-ri
+	}
+	repo.AssertNumberOfCalls(t, "ListAllRules", 2)
+}
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	gostrings "strings"
+	"time"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	pkglog "github.com/absmach/magistrala/pkg/logger"
@@ -27,40 +29,49 @@ import (
 	luatime "github.com/vadv/gopher-lua-libs/time"
 	"github.com/vadv/gopher-lua-libs/yaml"
 	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
 )
 
-const payloadKey = "payload"
+const (
+	payloadKey = "payload"
+	// Chunk name used in script error messages.
+	luaChunkName = "<string>"
+)
 
-func (re *re) processLua(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) pkglog.RunInfo {
-	l := lua.NewState()
+func (re *re) processLua(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (pkglog.RunInfo, []string) {
+	l := newLuaState(re.sandbox, r.DomainID)
 	defer l.Close()
-	preload(l)
-	message := prepareMsg(l, msg)
 
-	// Set the message object as a Lua global variable.
-	l.SetGlobal("message", message)
-	if err := l.DoString(r.Logic.Value); err != nil {
-		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to run rule logic: %s", err), Details: details}
+	proto, err := re.scripts.lua(r.ID, r.Logic)
+	if err != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to run rule logic: %s", err), Details: details}, nil
 	}
-	// Get the last result.
-	result := l.Get(-1)
+	runCtx, cancel := re.runContext(ctx, r.DomainID, LuaType)
+	result, err := runLuaProto(runCtx, l, newRuleState(re.state, r.ID, msg, false), proto, msg)
+	cancel()
+	if err != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to run rule logic: %s", err), Details: details}, nil
+	}
+	// A nil result is not a normal condition, so the alarms are not cleared.
 	if result == lua.LNil {
-		return pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with nil script result", Details: details}
+		return pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with nil script result", Details: details}, nil
 	}
 	// Converting Lua is an expensive operation, so
 	// don't do it if there are no outputs.
 	if len(r.Outputs) == 0 {
-		return pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with no outputs", Details: details}
+		return pkglog.RunInfo{Level: slog.LevelWarn, Message: "rule with no outputs", Details: details}, nil
 	}
-	var err error
 	res := convertLua(result)
+	// If value is false, don't run the follow-up.
+	if v, ok := res.(bool); ok && !v {
+		return re.autoClear(ctx, r, msg, pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details})
+	}
 
+	err = re.trackRaised(ctx, r, msg)
+	var attempted []string
 	for _, o := range r.Outputs {
-		// If value is false, don't run the follow-up.
-		if v, ok := res.(bool); ok && !v {
-			return pkglog.RunInfo{Level: slog.LevelInfo, Message: "logic returned false", Details: details}
-		}
-		if e := re.handleOutput(ctx, o, r, msg, res); e != nil {
+		attempted = append(attempted, outputType(o))
+		if e := re.runOutput(ctx, r, o, msg, res); e != nil {
 			err = errors.Wrap(e, err)
 		}
 	}
@@ -69,24 +80,212 @@ func (re *re) processLua(ctx context.Context, details []slog.Attr, r Rule, msg *
 		ret.Level = slog.LevelError
 		ret.Message = fmt.Sprintf("failed to handle rule output: %s", err)
 	}
-	return ret
+	return ret, attempted
+}
+
+// runLua executes the script in the given state and returns the last result.
+func runLua(ctx context.Context, l *lua.LState, st *ruleState, script string, msg *messaging.Message) (lua.LValue, error) {
+	proto, err := compileLua(script)
+	if err != nil {
+		return lua.LNil, err
+	}
+
+	return runLuaProto(ctx, l, st, proto, msg)
+}
+
+// compileLua compiles the script to a function prototype which,
+// unlike the state, can be shared and run concurrently.
+func compileLua(script string) (*lua.FunctionProto, error) {
+	chunk, err := parse.Parse(gostrings.NewReader(script), luaChunkName)
+	if err != nil {
+		return nil, err
+	}
+
+	return lua.Compile(chunk, luaChunkName)
+}
+
+// runLuaProto executes the compiled script in the given state and returns the last result.
+func runLuaProto(ctx context.Context, l *lua.LState, st *ruleState, proto *lua.FunctionProto, msg *messaging.Message) (lua.LValue, error) {
+	l.SetContext(ctx)
+	message := prepareMsg(l, msg)
+
+	// Set the message object as a Lua global variable.
+	l.SetGlobal("message", message)
+	l.SetGlobal("state", stateModule(l, st))
+	l.SetGlobal("window", windowModule(l, st))
+	l.Push(l.NewFunctionFromProto(proto))
+	if err := l.PCall(0, lua.MultRet, nil); err != nil {
+		return lua.LNil, scriptError(ctx, err)
+	}
+	// Get the last result.
+	return l.Get(-1), nil
+}
+
+// luaModules are the modules which can be preloaded, by the name scripts require them.
+var luaModules = map[string]lua.LGFunction{
+	"argparse":    argparse.Loader,
+	"base64":      base64.Loader,
+	"bit":         bit.Loader,
+	"crypto":      crypto.Loader,
+	"db":          db.Loader,
+	"filepath":    filepath.Loader,
+	"http_client": client.Loader,
+	"ioutil":      ioutil.Loader,
+	"json":        luajson.Loader,
+	"regexp":      regexp.Loader,
+	"storage":     storage.Loader,
+	"strings":     strings.Loader,
+	"time":        luatime.Loader,
+	"yaml":        yaml.Loader,
+}
+
+type luaLib struct {
+	name string
+	fn   lua.LGFunction
+}
+
+// luaLibs are the standard libraries opened in rule scripts.
+var luaLibs = []luaLib{
+	{lua.LoadLibName, lua.OpenPackage},
+	{lua.BaseLibName, lua.OpenBase},
+	{lua.TabLibName, lua.OpenTable},
+	{lua.StringLibName, lua.OpenString},
+	{lua.MathLibName, lua.OpenMath},
+	{lua.CoroutineLibName, lua.OpenCoroutine},
+	{lua.OsLibName, lua.OpenOs},
+}
+
+// newLuaState returns a Lua state limited by the sandbox, with the modules allowed
+// in the domain preloaded. The io, debug and channel libraries are not opened, and
+// only the time functions of the os library are available.
+func newLuaState(sb Sandbox, domainID string) *lua.LState {
+	limits := sb.limits(domainID)
+	l := lua.NewState(lua.Options{
+		CallStackSize: limits.LuaCallStackSize,
+		RegistrySize:  limits.LuaStackSize,
+		SkipOpenLibs:  true,
+	})
+	for _, lib := range luaLibs {
+		l.Push(l.NewFunction(lib.fn))
+		l.Push(lua.LString(lib.name))
+		l.Call(1, 0)
+	}
+	// Don't load scripts from the file system.
+	l.SetGlobal("dofile", lua.LNil)
+	l.SetGlobal("loadfile", lua.LNil)
+	pkg := l.GetGlobal(lua.LoadLibName).(*lua.LTable)
+	pkg.RawSetString("path", lua.LString(""))
+	pkg.RawSetString("cpath", lua.LString(""))
+	osLib := l.GetGlobal(lua.OsLibName).(*lua.LTable)
+	safeOs := l.NewTable()
+	for _, fn := range []string{"clock", "date", "difftime", "time"} {
+		safeOs.RawSetString(fn, osLib.RawGetString(fn))
+	}
+	l.SetGlobal(lua.OsLibName, safeOs)
+	l.GetField(pkg, "loaded").(*lua.LTable).RawSetString(lua.OsLibName, safeOs)
+
+	for _, name := range sb.modules(domainID).Lua {
+		if loader, ok := luaModules[name]; ok {
+			l.PreloadModule(name, loader)
+		}
+	}
+
+	return l
+}
+
+// stateModule exposes the rule key/value state to Lua:
+//
+//	state.get(key)
+//	state.set(key, value[, ttl])
+//	state.incr(key[, delta[, ttl]])
+//	state.del(key)
+//
+// TTL is a duration string such as "5m". Values can be any JSON compatible value.
+func stateModule(l *lua.LState, st *ruleState) *lua.LTable {
+	return l.SetFuncs(l.NewTable(), map[string]lua.LGFunction{
+		"get": func(l *lua.LState) int {
+			v, err := st.get(l.Context(), l.CheckString(1))
+			if err != nil {
+				l.RaiseError("failed to get state: %s", err)
+			}
+			l.Push(traverseJson(l, v))
+			return 1
+		},
+		"set": func(l *lua.LState) int {
+			key, value := l.CheckString(1), convertLua(l.CheckAny(2))
+			if err := st.set(l.Context(), key, value, luaDuration(l, 3)); err != nil {
+				l.RaiseError("failed to set state: %s", err)
+			}
+			return 0
+		},
+		"incr": func(l *lua.LState) int {
+			key, delta := l.CheckString(1), l.OptNumber(2, 1)
+			v, err := st.incr(l.Context(), key, float64(delta), luaDuration(l, 3))
+			if err != nil {
+				l.RaiseError("failed to increment state: %s", err)
+			}
+			l.Push(lua.LNumber(v))
+			return 1
+		},
+		"del": func(l *lua.LState) int {
+			if err := st.delete(l.Context(), l.CheckString(1)); err != nil {
+				l.RaiseError("failed to delete state: %s", err)
+			}
+			return 0
+		},
+	})
+}
+
+// windowModule exposes window aggregations to Lua:
+//
+//	window.add(key, value)
+//	window.min(key, size)
+//	window.max(key, size)
+//	window.avg(key, size)
+//	window.count(key, size)
+//
+// Size is either a duration string such as "5m" or the number of latest samples.
+// Aggregations of empty windows return nil, except for the count which returns 0.
+func windowModule(l *lua.LState, st *ruleState) *lua.LTable {
+	funcs := map[string]lua.LGFunction{
+		"add": func(l *lua.LState) int {
+			if err := st.addSample(l.Context(), l.CheckString(1), float64(l.CheckNumber(2))); err != nil {
+				l.RaiseError("failed to add window sample: %s", err)
+			}
+			return 0
+		},
+	}
+	for _, fn := range []string{"min", "max", "avg", "count"} {
+		funcs[fn] = func(l *lua.LState) int {
+			samples, err := st.window(l.Context(), l.CheckString(1), convertLua(l.CheckAny(2)))
+			if err != nil {
+				l.RaiseError("failed to get window: %s", err)
+			}
+			v, ok := aggregate(fn, samples)
+			if !ok {
+				l.Push(lua.LNil)
+				return 1
+			}
+			l.Push(lua.LNumber(v))
+			return 1
+		}
+	}
+
+	return l.SetFuncs(l.NewTable(), funcs)
 }
 
-func preload(l *lua.LState) {
-	db.Preload(l)
-	ioutil.Preload(l)
-	luajson.Preload(l)
-	yaml.Preload(l)
-	crypto.Preload(l)
-	regexp.Preload(l)
-	luatime.Preload(l)
-	storage.Preload(l)
-	base64.Preload(l)
-	argparse.Preload(l)
-	strings.Preload(l)
-	filepath.Preload(l)
-	client.Preload(l)
-	bit.Preload(l)
+// luaDuration returns the optional duration string argument at position n.
+func luaDuration(l *lua.LState, n int) time.Duration {
+	s := l.OptString(n, "")
+	if s == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		l.ArgError(n, fmt.Sprintf("invalid duration %q", s))
+	}
+
+	return d
 }
 
 func prepareMsg(l *lua.LState, msg *messaging.Message) lua.LValue {
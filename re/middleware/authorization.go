@@ -15,6 +15,7 @@ import (
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/permissions"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re"
 	"github.com/absmach/magistrala/re/operations"
 )
@@ -135,10 +136,82 @@ func (am *authorizationMiddleware) DisableRule(ctx context.Context, session auth
 	return am.svc.DisableRule(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error) {
+	if err := am.authorize(ctx, operations.OpTestRule, session, policies.DomainType, session.DomainID); err != nil {
+		return re.TestResult{}, errors.Wrap(errDomainCreateRules, err)
+	}
+
+	return am.svc.TestRule(ctx, session, r, msg)
+}
+
+func (am *authorizationMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	if err := am.authorize(ctx, operations.OpListRuleExecutions, session, operations.EntityType, pm.RuleID); err != nil {
+		return re.ExecutionsPage{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ListExecutions(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	if err := am.authorize(ctx, operations.OpListRuleVersions, session, operations.EntityType, pm.RuleID); err != nil {
+		return re.VersionsPage{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ListRuleVersions(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (re.VersionDiff, error) {
+	if err := am.authorize(ctx, operations.OpDiffRuleVersions, session, operations.EntityType, ruleID); err != nil {
+		return re.VersionDiff{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.DiffRuleVersions(ctx, session, ruleID, from, to)
+}
+
+func (am *authorizationMiddleware) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error) {
+	if err := am.authorize(ctx, operations.OpRestoreRuleVersion, session, operations.EntityType, ruleID); err != nil {
+		return re.Rule{}, errors.Wrap(errDomainUpdateRules, err)
+	}
+
+	return am.svc.RestoreRuleVersion(ctx, session, ruleID, version)
+}
+
+func (am *authorizationMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	if err := am.authorize(ctx, operations.OpListRuleDeadLetters, session, operations.EntityType, pm.RuleID); err != nil {
+		return re.DeadLettersPage{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	if err := am.authorize(ctx, operations.OpViewRuleDeadLetter, session, operations.EntityType, ruleID); err != nil {
+		return re.DeadLetter{}, errors.Wrap(errDomainViewRules, err)
+	}
+
+	return am.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (am *authorizationMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	if err := am.authorize(ctx, operations.OpReplayRuleDeadLetter, session, operations.EntityType, ruleID); err != nil {
+		return re.DeadLetter{}, errors.Wrap(errDomainUpdateRules, err)
+	}
+
+	return am.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
 func (am *authorizationMiddleware) StartScheduler(ctx context.Context) error {
 	return am.svc.StartScheduler(ctx)
 }
 
+func (am *authorizationMiddleware) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	return am.svc.StartDeadLetterRetries(ctx, tck)
+}
+
+func (am *authorizationMiddleware) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	return am.svc.StartExecutionsWriter(ctx, tck)
+}
+
 func (am *authorizationMiddleware) Handle(msg *messaging.Message) error {
 	return am.svc.Handle(msg)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/permissions"
 	"github.com/absmach/magistrala/pkg/policies"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re"
 	"github.com/absmach/magistrala/re/operations"
 )
@@ -150,10 +151,122 @@ func (cm *calloutMiddleware) DisableRule(ctx context.Context, session authn.Sess
 	return cm.svc.DisableRule(ctx, session, id)
 }
 
+func (cm *calloutMiddleware) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error) {
+	params := map[string]any{
+		"entities": r,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpTestRule, params); err != nil {
+		return re.TestResult{}, err
+	}
+
+	return cm.svc.TestRule(ctx, session, r, msg)
+}
+
+func (cm *calloutMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	params := map[string]any{
+		entityIDKey: pm.RuleID,
+		"pagemeta":  pm,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpListRuleExecutions, params); err != nil {
+		return re.ExecutionsPage{}, err
+	}
+
+	return cm.svc.ListExecutions(ctx, session, pm)
+}
+
+func (cm *calloutMiddleware) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	params := map[string]any{
+		entityIDKey: pm.RuleID,
+		"pagemeta":  pm,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpListRuleVersions, params); err != nil {
+		return re.VersionsPage{}, err
+	}
+
+	return cm.svc.ListRuleVersions(ctx, session, pm)
+}
+
+func (cm *calloutMiddleware) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (re.VersionDiff, error) {
+	params := map[string]any{
+		entityIDKey: ruleID,
+		"from":      from,
+		"to":        to,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpDiffRuleVersions, params); err != nil {
+		return re.VersionDiff{}, err
+	}
+
+	return cm.svc.DiffRuleVersions(ctx, session, ruleID, from, to)
+}
+
+func (cm *calloutMiddleware) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error) {
+	params := map[string]any{
+		entityIDKey: ruleID,
+		"version":   version,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpRestoreRuleVersion, params); err != nil {
+		return re.Rule{}, err
+	}
+
+	return cm.svc.RestoreRuleVersion(ctx, session, ruleID, version)
+}
+
+func (cm *calloutMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	params := map[string]any{
+		entityIDKey: pm.RuleID,
+		"pagemeta":  pm,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpListRuleDeadLetters, params); err != nil {
+		return re.DeadLettersPage{}, err
+	}
+
+	return cm.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (cm *calloutMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	params := map[string]any{
+		entityIDKey:      ruleID,
+		"dead_letter_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpViewRuleDeadLetter, params); err != nil {
+		return re.DeadLetter{}, err
+	}
+
+	return cm.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (cm *calloutMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	params := map[string]any{
+		entityIDKey:      ruleID,
+		"dead_letter_id": id,
+	}
+
+	if err := cm.callOut(ctx, session, operations.OpReplayRuleDeadLetter, params); err != nil {
+		return re.DeadLetter{}, err
+	}
+
+	return cm.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
 func (cm *calloutMiddleware) StartScheduler(ctx context.Context) error {
 	return cm.svc.StartScheduler(ctx)
 }
 
+func (cm *calloutMiddleware) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	return cm.svc.StartDeadLetterRetries(ctx, tck)
+}
+
+func (cm *calloutMiddleware) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	return cm.svc.StartExecutionsWriter(ctx, tck)
+}
+
 func (cm *calloutMiddleware) Handle(msg *messaging.Message) error {
 	return cm.svc.Handle(msg)
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re"
 )
 
@@ -204,6 +205,178 @@ func (lm *loggingMiddleware) DisableRule(ctx context.Context, session authn.Sess
 	return lm.svc.DisableRule(ctx, session, id)
 }
 
+func (lm *loggingMiddleware) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (res re.TestResult, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("rule",
+				slog.String("id", r.ID),
+				slog.String("name", r.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Test rule failed", args...)
+			return
+		}
+		if res.Error != "" {
+			args = append(args, slog.String("rule_error", res.Error))
+		}
+		lm.logger.Info("Test rule completed successfully", args...)
+	}(time.Now())
+	return lm.svc.TestRule(ctx, session, r, msg)
+}
+
+func (lm *loggingMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (page re.ExecutionsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("rule_id", pm.RuleID),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("List rule executions failed", args...)
+			return
+		}
+		lm.logger.Info("List rule executions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListExecutions(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (page re.VersionsPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("rule_id", pm.RuleID),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("List rule versions failed", args...)
+			return
+		}
+		lm.logger.Info("List rule versions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListRuleVersions(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (diff re.VersionDiff, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("rule",
+				slog.String("id", ruleID),
+				slog.Uint64("from", diff.From),
+				slog.Uint64("to", diff.To),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Diff rule versions failed", args...)
+			return
+		}
+		lm.logger.Info("Diff rule versions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DiffRuleVersions(ctx, session, ruleID, from, to)
+}
+
+func (lm *loggingMiddleware) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (res re.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("rule",
+				slog.String("id", ruleID),
+				slog.Uint64("restored_version", version),
+				slog.Uint64("version", res.Version),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Restore rule version failed", args...)
+			return
+		}
+		lm.logger.Info("Restore rule version completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RestoreRuleVersion(ctx, session, ruleID, version)
+}
+
+func (lm *loggingMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (page re.DeadLettersPage, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("rule_id", pm.RuleID),
+				slog.String("status", pm.Status.String()),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("List dead letters failed", args...)
+			return
+		}
+		lm.logger.Info("List dead letters completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (dl re.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", ruleID),
+			slog.String("dead_letter_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("View dead letter failed", args...)
+			return
+		}
+		lm.logger.Info("View dead letter completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (lm *loggingMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (dl re.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("dead_letter",
+				slog.String("id", id),
+				slog.String("rule_id", ruleID),
+				slog.String("status", dl.Status.String()),
+				slog.Uint64("attempts", uint64(dl.Attempts)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Replay dead letter failed", args...)
+			return
+		}
+		lm.logger.Info("Replay dead letter completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
 func (lm *loggingMiddleware) StartScheduler(ctx context.Context) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -219,6 +392,36 @@ func (lm *loggingMiddleware) StartScheduler(ctx context.Context) (err error) {
 	return lm.svc.StartScheduler(ctx)
 }
 
+func (lm *loggingMiddleware) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Start dead letter retries failed", args...)
+			return
+		}
+		lm.logger.Info("Start dead letter retries completed successfully", args...)
+	}(time.Now())
+	return lm.svc.StartDeadLetterRetries(ctx, tck)
+}
+
+func (lm *loggingMiddleware) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Start executions writer failed", args...)
+			return
+		}
+		lm.logger.Info("Start executions writer completed successfully", args...)
+	}(time.Now())
+	return lm.svc.StartExecutionsWriter(ctx, tck)
+}
+
 func (lm *loggingMiddleware) Handle(msg *messaging.Message) (err error) {
 	defer func(begin time.Time) {
 		// Log only failure since the handlers are executed async and will always
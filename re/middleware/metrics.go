@@ -9,6 +9,7 @@ import (
 
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re"
 	"github.com/go-kit/kit/metrics"
 )
@@ -110,6 +111,70 @@ func (mm *metricsMiddleware) DisableRule(ctx context.Context, session authn.Sess
 	return mm.service.DisableRule(ctx, session, id)
 }
 
+func (mm *metricsMiddleware) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "test_rule").Add(1)
+		mm.latency.With("method", "test_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.TestRule(ctx, session, r, msg)
+}
+
+func (mm *metricsMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_rule_executions").Add(1)
+		mm.latency.With("method", "list_rule_executions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.ListExecutions(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_rule_versions").Add(1)
+		mm.latency.With("method", "list_rule_versions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.ListRuleVersions(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (re.VersionDiff, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "diff_rule_versions").Add(1)
+		mm.latency.With("method", "diff_rule_versions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.DiffRuleVersions(ctx, session, ruleID, from, to)
+}
+
+func (mm *metricsMiddleware) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "restore_rule_version").Add(1)
+		mm.latency.With("method", "restore_rule_version").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.RestoreRuleVersion(ctx, session, ruleID, version)
+}
+
+func (mm *metricsMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_dead_letters").Add(1)
+		mm.latency.With("method", "list_dead_letters").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.ListDeadLetters(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_dead_letter").Add(1)
+		mm.latency.With("method", "view_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (mm *metricsMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "replay_dead_letter").Add(1)
+		mm.latency.With("method", "replay_dead_letter").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return mm.service.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
 func (mm *metricsMiddleware) Handle(msg *messaging.Message) error {
 	defer func(begin time.Time) {
 		mm.counter.With("method", "handle").Add(1)
@@ -128,6 +193,24 @@ func (mm *metricsMiddleware) StartScheduler(ctx context.Context) error {
 	return mm.service.StartScheduler(ctx)
 }
 
+func (mm *metricsMiddleware) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "start_dead_letter_retries").Add(1)
+		mm.latency.With("method", "start_dead_letter_retries").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.StartDeadLetterRetries(ctx, tck)
+}
+
+func (mm *metricsMiddleware) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "start_executions_writer").Add(1)
+		mm.latency.With("method", "start_executions_writer").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.service.StartExecutionsWriter(ctx, tck)
+}
+
 func (mm *metricsMiddleware) Cancel() error {
 	return mm.service.Cancel()
 }
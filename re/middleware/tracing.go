@@ -8,6 +8,7 @@ import (
 
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
 	smqTracing "github.com/absmach/magistrala/pkg/tracing"
 	"github.com/absmach/magistrala/re"
 	"go.opentelemetry.io/otel/attribute"
@@ -111,6 +112,90 @@ func (tm *tracingMiddleware) DisableRule(ctx context.Context, session authn.Sess
 	return tm.svc.DisableRule(ctx, session, id)
 }
 
+func (tm *tracingMiddleware) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "test_rule", trace.WithAttributes(
+		attribute.String("id", r.ID),
+		attribute.String("domain_id", session.DomainID),
+	))
+	defer span.End()
+	return tm.svc.TestRule(ctx, session, r, msg)
+}
+
+func (tm *tracingMiddleware) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_rule_executions", trace.WithAttributes(
+		attribute.String("rule_id", pm.RuleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+	return tm.svc.ListExecutions(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_rule_versions", trace.WithAttributes(
+		attribute.String("rule_id", pm.RuleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+	return tm.svc.ListRuleVersions(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (re.VersionDiff, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "diff_rule_versions", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int64("from", int64(from)),
+		attribute.Int64("to", int64(to)),
+	))
+	defer span.End()
+	return tm.svc.DiffRuleVersions(ctx, session, ruleID, from, to)
+}
+
+func (tm *tracingMiddleware) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "restore_rule_version", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.Int64("version", int64(version)),
+	))
+	defer span.End()
+	return tm.svc.RestoreRuleVersion(ctx, session, ruleID, version)
+}
+
+func (tm *tracingMiddleware) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "list_dead_letters", trace.WithAttributes(
+		attribute.String("rule_id", pm.RuleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("status", pm.Status.String()),
+		attribute.Int("offset", int(pm.Offset)),
+		attribute.Int("limit", int(pm.Limit)),
+	))
+	defer span.End()
+	return tm.svc.ListDeadLetters(ctx, session, pm)
+}
+
+func (tm *tracingMiddleware) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "view_dead_letter", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("dead_letter_id", id),
+	))
+	defer span.End()
+	return tm.svc.ViewDeadLetter(ctx, session, ruleID, id)
+}
+
+func (tm *tracingMiddleware) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (re.DeadLetter, error) {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "replay_dead_letter", trace.WithAttributes(
+		attribute.String("rule_id", ruleID),
+		attribute.String("domain_id", session.DomainID),
+		attribute.String("dead_letter_id", id),
+	))
+	defer span.End()
+	return tm.svc.ReplayDeadLetter(ctx, session, ruleID, id)
+}
+
 func (tm *tracingMiddleware) Handle(msg *messaging.Message) error {
 	_, span := smqTracing.StartSpan(context.Background(), tm.tracer, "handle", trace.WithAttributes(
 		attribute.String("channel", msg.Channel),
@@ -128,6 +213,20 @@ func (tm *tracingMiddleware) StartScheduler(ctx context.Context) error {
 	return tm.svc.StartScheduler(ctx)
 }
 
+func (tm *tracingMiddleware) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "start_dead_letter_retries")
+	defer span.End()
+
+	return tm.svc.StartDeadLetterRetries(ctx, tck)
+}
+
+func (tm *tracingMiddleware) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	ctx, span := smqTracing.StartSpan(ctx, tm.tracer, "start_executions_writer")
+	defer span.End()
+
+	return tm.svc.StartExecutionsWriter(ctx, tck)
+}
+
 func (tm *tracingMiddleware) Cancel() error {
 	return tm.svc.Cancel()
 }
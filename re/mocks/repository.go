@@ -43,53 +43,652 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// AddDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) AddDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	ret := _mock.Called(ctx, dl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetter) error); ok {
+		r0 = returnFunc(ctx, dl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AddDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddDeadLetter'
+type Repository_AddDeadLetter_Call struct {
+	*mock.Call
+}
+
+// AddDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dl re.DeadLetter
+func (_e *Repository_Expecter) AddDeadLetter(ctx interface{}, dl interface{}) *Repository_AddDeadLetter_Call {
+	return &Repository_AddDeadLetter_Call{Call: _e.mock.On("AddDeadLetter", ctx, dl)}
+}
+
+func (_c *Repository_AddDeadLetter_Call) Run(run func(ctx context.Context, dl re.DeadLetter)) *Repository_AddDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.DeadLetter
+		if args[1] != nil {
+			arg1 = args[1].(re.DeadLetter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddDeadLetter_Call) Return(err error) *Repository_AddDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AddDeadLetter_Call) RunAndReturn(run func(ctx context.Context, dl re.DeadLetter) error) *Repository_AddDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddExecutions provides a mock function for the type Repository
+func (_mock *Repository) AddExecutions(ctx context.Context, executions []re.Execution) error {
+	ret := _mock.Called(ctx, executions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddExecutions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []re.Execution) error); ok {
+		r0 = returnFunc(ctx, executions)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_AddExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddExecutions'
+type Repository_AddExecutions_Call struct {
+	*mock.Call
+}
+
+// AddExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - executions []re.Execution
+func (_e *Repository_Expecter) AddExecutions(ctx interface{}, executions interface{}) *Repository_AddExecutions_Call {
+	return &Repository_AddExecutions_Call{Call: _e.mock.On("AddExecutions", ctx, executions)}
+}
+
+func (_c *Repository_AddExecutions_Call) Run(run func(ctx context.Context, executions []re.Execution)) *Repository_AddExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []re.Execution
+		if args[1] != nil {
+			arg1 = args[1].([]re.Execution)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddExecutions_Call) Return(err error) *Repository_AddExecutions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_AddExecutions_Call) RunAndReturn(run func(ctx context.Context, executions []re.Execution) error) *Repository_AddExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddRule provides a mock function for the type Repository
 func (_mock *Repository) AddRule(ctx context.Context, r re.Rule) (re.Rule, error) {
 	ret := _mock.Called(ctx, r)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddRule")
+		panic("no return value specified for AddRule")
+	}
+
+	var r0 re.Rule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Rule) (re.Rule, error)); ok {
+		return returnFunc(ctx, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Rule) re.Rule); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		r0 = ret.Get(0).(re.Rule)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.Rule) error); ok {
+		r1 = returnFunc(ctx, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_AddRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRule'
+type Repository_AddRule_Call struct {
+	*mock.Call
+}
+
+// AddRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r re.Rule
+func (_e *Repository_Expecter) AddRule(ctx interface{}, r interface{}) *Repository_AddRule_Call {
+	return &Repository_AddRule_Call{Call: _e.mock.On("AddRule", ctx, r)}
+}
+
+func (_c *Repository_AddRule_Call) Run(run func(ctx context.Context, r re.Rule)) *Repository_AddRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.Rule
+		if args[1] != nil {
+			arg1 = args[1].(re.Rule)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_AddRule_Call) Return(rule re.Rule, err error) *Repository_AddRule_Call {
+	_c.Call.Return(rule, err)
+	return _c
+}
+
+func (_c *Repository_AddRule_Call) RunAndReturn(run func(ctx context.Context, r re.Rule) (re.Rule, error)) *Repository_AddRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) ClaimDeadLetter(ctx context.Context, ruleID string, id string, now time.Time, until time.Time) (re.DeadLetter, error) {
+	ret := _mock.Called(ctx, ruleID, id, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeadLetter")
+	}
+
+	var r0 re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (re.DeadLetter, error)); ok {
+		return returnFunc(ctx, ruleID, id, now, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) re.DeadLetter); ok {
+		r0 = returnFunc(ctx, ruleID, id, now, until)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = returnFunc(ctx, ruleID, id, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ClaimDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeadLetter'
+type Repository_ClaimDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ClaimDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+//   - id string
+//   - now time.Time
+//   - until time.Time
+func (_e *Repository_Expecter) ClaimDeadLetter(ctx interface{}, ruleID interface{}, id interface{}, now interface{}, until interface{}) *Repository_ClaimDeadLetter_Call {
+	return &Repository_ClaimDeadLetter_Call{Call: _e.mock.On("ClaimDeadLetter", ctx, ruleID, id, now, until)}
+}
+
+func (_c *Repository_ClaimDeadLetter_Call) Run(run func(ctx context.Context, ruleID string, id string, now time.Time, until time.Time)) *Repository_ClaimDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 time.Time
+		if args[4] != nil {
+			arg4 = args[4].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ClaimDeadLetter_Call) Return(deadLetter re.DeadLetter, err error) *Repository_ClaimDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *Repository_ClaimDeadLetter_Call) RunAndReturn(run func(ctx context.Context, ruleID string, id string, now time.Time, until time.Time) (re.DeadLetter, error)) *Repository_ClaimDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDeadLetters provides a mock function for the type Repository
+func (_mock *Repository) ClaimDeadLetters(ctx context.Context, due time.Time, until time.Time, limit uint64) ([]re.DeadLetter, error) {
+	ret := _mock.Called(ctx, due, until, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeadLetters")
+	}
+
+	var r0 []re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64) ([]re.DeadLetter, error)); ok {
+		return returnFunc(ctx, due, until, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64) []re.DeadLetter); ok {
+		r0 = returnFunc(ctx, due, until, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]re.DeadLetter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint64) error); ok {
+		r1 = returnFunc(ctx, due, until, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ClaimDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeadLetters'
+type Repository_ClaimDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ClaimDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - due time.Time
+//   - until time.Time
+//   - limit uint64
+func (_e *Repository_Expecter) ClaimDeadLetters(ctx interface{}, due interface{}, until interface{}, limit interface{}) *Repository_ClaimDeadLetters_Call {
+	return &Repository_ClaimDeadLetters_Call{Call: _e.mock.On("ClaimDeadLetters", ctx, due, until, limit)}
+}
+
+func (_c *Repository_ClaimDeadLetters_Call) Run(run func(ctx context.Context, due time.Time, until time.Time, limit uint64)) *Repository_ClaimDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ClaimDeadLetters_Call) Return(deadLetters []re.DeadLetter, err error) *Repository_ClaimDeadLetters_Call {
+	_c.Call.Return(deadLetters, err)
+	return _c
+}
+
+func (_c *Repository_ClaimDeadLetters_Call) RunAndReturn(run func(ctx context.Context, due time.Time, until time.Time, limit uint64) ([]re.DeadLetter, error)) *Repository_ClaimDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllRules provides a mock function for the type Repository
+func (_mock *Repository) ListAllRules(ctx context.Context, pm re.PageMeta) (re.Page, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllRules")
+	}
+
+	var r0 re.Page
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.PageMeta) (re.Page, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.PageMeta) re.Page); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(re.Page)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.PageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListAllRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllRules'
+type Repository_ListAllRules_Call struct {
+	*mock.Call
+}
+
+// ListAllRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm re.PageMeta
+func (_e *Repository_Expecter) ListAllRules(ctx interface{}, pm interface{}) *Repository_ListAllRules_Call {
+	return &Repository_ListAllRules_Call{Call: _e.mock.On("ListAllRules", ctx, pm)}
+}
+
+func (_c *Repository_ListAllRules_Call) Run(run func(ctx context.Context, pm re.PageMeta)) *Repository_ListAllRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.PageMeta
+		if args[1] != nil {
+			arg1 = args[1].(re.PageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListAllRules_Call) Return(page re.Page, err error) *Repository_ListAllRules_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *Repository_ListAllRules_Call) RunAndReturn(run func(ctx context.Context, pm re.PageMeta) (re.Page, error)) *Repository_ListAllRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeadLetters provides a mock function for the type Repository
+func (_mock *Repository) ListDeadLetters(ctx context.Context, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 re.DeadLettersPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetterPageMeta) (re.DeadLettersPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetterPageMeta) re.DeadLettersPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(re.DeadLettersPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.DeadLetterPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetters'
+type Repository_ListDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm re.DeadLetterPageMeta
+func (_e *Repository_Expecter) ListDeadLetters(ctx interface{}, pm interface{}) *Repository_ListDeadLetters_Call {
+	return &Repository_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, pm)}
+}
+
+func (_c *Repository_ListDeadLetters_Call) Run(run func(ctx context.Context, pm re.DeadLetterPageMeta)) *Repository_ListDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.DeadLetterPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(re.DeadLetterPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListDeadLetters_Call) Return(deadLettersPage re.DeadLettersPage, err error) *Repository_ListDeadLetters_Call {
+	_c.Call.Return(deadLettersPage, err)
+	return _c
+}
+
+func (_c *Repository_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error)) *Repository_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExecutions provides a mock function for the type Repository
+func (_mock *Repository) ListExecutions(ctx context.Context, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExecutions")
+	}
+
+	var r0 re.ExecutionsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.ExecutionPageMeta) (re.ExecutionsPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.ExecutionPageMeta) re.ExecutionsPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(re.ExecutionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.ExecutionPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExecutions'
+type Repository_ListExecutions_Call struct {
+	*mock.Call
+}
+
+// ListExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm re.ExecutionPageMeta
+func (_e *Repository_Expecter) ListExecutions(ctx interface{}, pm interface{}) *Repository_ListExecutions_Call {
+	return &Repository_ListExecutions_Call{Call: _e.mock.On("ListExecutions", ctx, pm)}
+}
+
+func (_c *Repository_ListExecutions_Call) Run(run func(ctx context.Context, pm re.ExecutionPageMeta)) *Repository_ListExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.ExecutionPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(re.ExecutionPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListExecutions_Call) Return(executionsPage re.ExecutionsPage, err error) *Repository_ListExecutions_Call {
+	_c.Call.Return(executionsPage, err)
+	return _c
+}
+
+func (_c *Repository_ListExecutions_Call) RunAndReturn(run func(ctx context.Context, pm re.ExecutionPageMeta) (re.ExecutionsPage, error)) *Repository_ListExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRuleVersions provides a mock function for the type Repository
+func (_mock *Repository) ListRuleVersions(ctx context.Context, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	ret := _mock.Called(ctx, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuleVersions")
+	}
+
+	var r0 re.VersionsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.VersionPageMeta) (re.VersionsPage, error)); ok {
+		return returnFunc(ctx, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.VersionPageMeta) re.VersionsPage); ok {
+		r0 = returnFunc(ctx, pm)
+	} else {
+		r0 = ret.Get(0).(re.VersionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.VersionPageMeta) error); ok {
+		r1 = returnFunc(ctx, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ListRuleVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuleVersions'
+type Repository_ListRuleVersions_Call struct {
+	*mock.Call
+}
+
+// ListRuleVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pm re.VersionPageMeta
+func (_e *Repository_Expecter) ListRuleVersions(ctx interface{}, pm interface{}) *Repository_ListRuleVersions_Call {
+	return &Repository_ListRuleVersions_Call{Call: _e.mock.On("ListRuleVersions", ctx, pm)}
+}
+
+func (_c *Repository_ListRuleVersions_Call) Run(run func(ctx context.Context, pm re.VersionPageMeta)) *Repository_ListRuleVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.VersionPageMeta
+		if args[1] != nil {
+			arg1 = args[1].(re.VersionPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ListRuleVersions_Call) Return(versionsPage re.VersionsPage, err error) *Repository_ListRuleVersions_Call {
+	_c.Call.Return(versionsPage, err)
+	return _c
+}
+
+func (_c *Repository_ListRuleVersions_Call) RunAndReturn(run func(ctx context.Context, pm re.VersionPageMeta) (re.VersionsPage, error)) *Repository_ListRuleVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveDeadLetters provides a mock function for the type Repository
+func (_mock *Repository) RemoveDeadLetters(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDeadLetters")
 	}
 
-	var r0 re.Rule
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Rule) (re.Rule, error)); ok {
-		return returnFunc(ctx, r)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Rule) re.Rule); ok {
-		r0 = returnFunc(ctx, r)
-	} else {
-		r0 = ret.Get(0).(re.Rule)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, re.Rule) error); ok {
-		r1 = returnFunc(ctx, r)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// Repository_AddRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRule'
-type Repository_AddRule_Call struct {
+// Repository_RemoveDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveDeadLetters'
+type Repository_RemoveDeadLetters_Call struct {
 	*mock.Call
 }
 
-// AddRule is a helper method to define mock.On call
+// RemoveDeadLetters is a helper method to define mock.On call
 //   - ctx context.Context
-//   - r re.Rule
-func (_e *Repository_Expecter) AddRule(ctx interface{}, r interface{}) *Repository_AddRule_Call {
-	return &Repository_AddRule_Call{Call: _e.mock.On("AddRule", ctx, r)}
+//   - before time.Time
+func (_e *Repository_Expecter) RemoveDeadLetters(ctx interface{}, before interface{}) *Repository_RemoveDeadLetters_Call {
+	return &Repository_RemoveDeadLetters_Call{Call: _e.mock.On("RemoveDeadLetters", ctx, before)}
 }
 
-func (_c *Repository_AddRule_Call) Run(run func(ctx context.Context, r re.Rule)) *Repository_AddRule_Call {
+func (_c *Repository_RemoveDeadLetters_Call) Run(run func(ctx context.Context, before time.Time)) *Repository_RemoveDeadLetters_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 re.Rule
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(re.Rule)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -99,63 +698,54 @@ func (_c *Repository_AddRule_Call) Run(run func(ctx context.Context, r re.Rule))
 	return _c
 }
 
-func (_c *Repository_AddRule_Call) Return(rule re.Rule, err error) *Repository_AddRule_Call {
-	_c.Call.Return(rule, err)
+func (_c *Repository_RemoveDeadLetters_Call) Return(err error) *Repository_RemoveDeadLetters_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_AddRule_Call) RunAndReturn(run func(ctx context.Context, r re.Rule) (re.Rule, error)) *Repository_AddRule_Call {
+func (_c *Repository_RemoveDeadLetters_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *Repository_RemoveDeadLetters_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListAllRules provides a mock function for the type Repository
-func (_mock *Repository) ListAllRules(ctx context.Context, pm re.PageMeta) (re.Page, error) {
-	ret := _mock.Called(ctx, pm)
+// RemoveExecutions provides a mock function for the type Repository
+func (_mock *Repository) RemoveExecutions(ctx context.Context, before time.Time) error {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListAllRules")
+		panic("no return value specified for RemoveExecutions")
 	}
 
-	var r0 re.Page
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, re.PageMeta) (re.Page, error)); ok {
-		return returnFunc(ctx, pm)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, re.PageMeta) re.Page); ok {
-		r0 = returnFunc(ctx, pm)
-	} else {
-		r0 = ret.Get(0).(re.Page)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, re.PageMeta) error); ok {
-		r1 = returnFunc(ctx, pm)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// Repository_ListAllRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllRules'
-type Repository_ListAllRules_Call struct {
+// Repository_RemoveExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExecutions'
+type Repository_RemoveExecutions_Call struct {
 	*mock.Call
 }
 
-// ListAllRules is a helper method to define mock.On call
+// RemoveExecutions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pm re.PageMeta
-func (_e *Repository_Expecter) ListAllRules(ctx interface{}, pm interface{}) *Repository_ListAllRules_Call {
-	return &Repository_ListAllRules_Call{Call: _e.mock.On("ListAllRules", ctx, pm)}
+//   - before time.Time
+func (_e *Repository_Expecter) RemoveExecutions(ctx interface{}, before interface{}) *Repository_RemoveExecutions_Call {
+	return &Repository_RemoveExecutions_Call{Call: _e.mock.On("RemoveExecutions", ctx, before)}
 }
 
-func (_c *Repository_ListAllRules_Call) Run(run func(ctx context.Context, pm re.PageMeta)) *Repository_ListAllRules_Call {
+func (_c *Repository_RemoveExecutions_Call) Run(run func(ctx context.Context, before time.Time)) *Repository_RemoveExecutions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 re.PageMeta
+		var arg1 time.Time
 		if args[1] != nil {
-			arg1 = args[1].(re.PageMeta)
+			arg1 = args[1].(time.Time)
 		}
 		run(
 			arg0,
@@ -165,12 +755,12 @@ func (_c *Repository_ListAllRules_Call) Run(run func(ctx context.Context, pm re.
 	return _c
 }
 
-func (_c *Repository_ListAllRules_Call) Return(page re.Page, err error) *Repository_ListAllRules_Call {
-	_c.Call.Return(page, err)
+func (_c *Repository_RemoveExecutions_Call) Return(err error) *Repository_RemoveExecutions_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *Repository_ListAllRules_Call) RunAndReturn(run func(ctx context.Context, pm re.PageMeta) (re.Page, error)) *Repository_ListAllRules_Call {
+func (_c *Repository_RemoveExecutions_Call) RunAndReturn(run func(ctx context.Context, before time.Time) error) *Repository_RemoveExecutions_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -232,6 +822,135 @@ func (_c *Repository_RemoveRule_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// RestoreRule provides a mock function for the type Repository
+func (_mock *Repository) RestoreRule(ctx context.Context, r re.Rule, version uint64) (re.Rule, error) {
+	ret := _mock.Called(ctx, r, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRule")
+	}
+
+	var r0 re.Rule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Rule, uint64) (re.Rule, error)); ok {
+		return returnFunc(ctx, r, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.Rule, uint64) re.Rule); ok {
+		r0 = returnFunc(ctx, r, version)
+	} else {
+		r0 = ret.Get(0).(re.Rule)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, re.Rule, uint64) error); ok {
+		r1 = returnFunc(ctx, r, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_RestoreRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRule'
+type Repository_RestoreRule_Call struct {
+	*mock.Call
+}
+
+// RestoreRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r re.Rule
+//   - version uint64
+func (_e *Repository_Expecter) RestoreRule(ctx interface{}, r interface{}, version interface{}) *Repository_RestoreRule_Call {
+	return &Repository_RestoreRule_Call{Call: _e.mock.On("RestoreRule", ctx, r, version)}
+}
+
+func (_c *Repository_RestoreRule_Call) Run(run func(ctx context.Context, r re.Rule, version uint64)) *Repository_RestoreRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.Rule
+		if args[1] != nil {
+			arg1 = args[1].(re.Rule)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_RestoreRule_Call) Return(rule re.Rule, err error) *Repository_RestoreRule_Call {
+	_c.Call.Return(rule, err)
+	return _c
+}
+
+func (_c *Repository_RestoreRule_Call) RunAndReturn(run func(ctx context.Context, r re.Rule, version uint64) (re.Rule, error)) *Repository_RestoreRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) UpdateDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	ret := _mock.Called(ctx, dl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, re.DeadLetter) error); ok {
+		r0 = returnFunc(ctx, dl)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Repository_UpdateDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDeadLetter'
+type Repository_UpdateDeadLetter_Call struct {
+	*mock.Call
+}
+
+// UpdateDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dl re.DeadLetter
+func (_e *Repository_Expecter) UpdateDeadLetter(ctx interface{}, dl interface{}) *Repository_UpdateDeadLetter_Call {
+	return &Repository_UpdateDeadLetter_Call{Call: _e.mock.On("UpdateDeadLetter", ctx, dl)}
+}
+
+func (_c *Repository_UpdateDeadLetter_Call) Run(run func(ctx context.Context, dl re.DeadLetter)) *Repository_UpdateDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 re.DeadLetter
+		if args[1] != nil {
+			arg1 = args[1].(re.DeadLetter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateDeadLetter_Call) Return(err error) *Repository_UpdateDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Repository_UpdateDeadLetter_Call) RunAndReturn(run func(ctx context.Context, dl re.DeadLetter) error) *Repository_UpdateDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRule provides a mock function for the type Repository
 func (_mock *Repository) UpdateRule(ctx context.Context, r re.Rule) (re.Rule, error) {
 	ret := _mock.Called(ctx, r)
@@ -568,6 +1287,78 @@ func (_c *Repository_UpdateRuleTags_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// ViewDeadLetter provides a mock function for the type Repository
+func (_mock *Repository) ViewDeadLetter(ctx context.Context, ruleID string, id string) (re.DeadLetter, error) {
+	ret := _mock.Called(ctx, ruleID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewDeadLetter")
+	}
+
+	var r0 re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (re.DeadLetter, error)); ok {
+		return returnFunc(ctx, ruleID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) re.DeadLetter); ok {
+		r0 = returnFunc(ctx, ruleID, id)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, ruleID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ViewDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewDeadLetter'
+type Repository_ViewDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ViewDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+//   - id string
+func (_e *Repository_Expecter) ViewDeadLetter(ctx interface{}, ruleID interface{}, id interface{}) *Repository_ViewDeadLetter_Call {
+	return &Repository_ViewDeadLetter_Call{Call: _e.mock.On("ViewDeadLetter", ctx, ruleID, id)}
+}
+
+func (_c *Repository_ViewDeadLetter_Call) Run(run func(ctx context.Context, ruleID string, id string)) *Repository_ViewDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ViewDeadLetter_Call) Return(deadLetter re.DeadLetter, err error) *Repository_ViewDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *Repository_ViewDeadLetter_Call) RunAndReturn(run func(ctx context.Context, ruleID string, id string) (re.DeadLetter, error)) *Repository_ViewDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewRule provides a mock function for the type Repository
 func (_mock *Repository) ViewRule(ctx context.Context, id string) (re.Rule, error) {
 	ret := _mock.Called(ctx, id)
@@ -633,3 +1424,75 @@ func (_c *Repository_ViewRule_Call) RunAndReturn(run func(ctx context.Context, i
 	_c.Call.Return(run)
 	return _c
 }
+
+// ViewRuleVersion provides a mock function for the type Repository
+func (_mock *Repository) ViewRuleVersion(ctx context.Context, ruleID string, version uint64) (re.RuleVersion, error) {
+	ret := _mock.Called(ctx, ruleID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewRuleVersion")
+	}
+
+	var r0 re.RuleVersion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) (re.RuleVersion, error)); ok {
+		return returnFunc(ctx, ruleID, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) re.RuleVersion); ok {
+		r0 = returnFunc(ctx, ruleID, version)
+	} else {
+		r0 = ret.Get(0).(re.RuleVersion)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = returnFunc(ctx, ruleID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Repository_ViewRuleVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewRuleVersion'
+type Repository_ViewRuleVersion_Call struct {
+	*mock.Call
+}
+
+// ViewRuleVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ruleID string
+//   - version uint64
+func (_e *Repository_Expecter) ViewRuleVersion(ctx interface{}, ruleID interface{}, version interface{}) *Repository_ViewRuleVersion_Call {
+	return &Repository_ViewRuleVersion_Call{Call: _e.mock.On("ViewRuleVersion", ctx, ruleID, version)}
+}
+
+func (_c *Repository_ViewRuleVersion_Call) Run(run func(ctx context.Context, ruleID string, version uint64)) *Repository_ViewRuleVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Repository_ViewRuleVersion_Call) Return(ruleVersion re.RuleVersion, err error) *Repository_ViewRuleVersion_Call {
+	_c.Call.Return(ruleVersion, err)
+	return _c
+}
+
+func (_c *Repository_ViewRuleVersion_Call) RunAndReturn(run func(ctx context.Context, ruleID string, version uint64) (re.RuleVersion, error)) *Repository_ViewRuleVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
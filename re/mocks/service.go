@@ -13,6 +13,7 @@ import (
 
 	"github.com/absmach/magistrala/pkg/authn"
 	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -160,6 +161,90 @@ func (_c *Service_Cancel_Call) RunAndReturn(run func() error) *Service_Cancel_Ca
 	return _c
 }
 
+// DiffRuleVersions provides a mock function for the type Service
+func (_mock *Service) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from uint64, to uint64) (re.VersionDiff, error) {
+	ret := _mock.Called(ctx, session, ruleID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffRuleVersions")
+	}
+
+	var r0 re.VersionDiff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64, uint64) (re.VersionDiff, error)); ok {
+		return returnFunc(ctx, session, ruleID, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64, uint64) re.VersionDiff); ok {
+		r0 = returnFunc(ctx, session, ruleID, from, to)
+	} else {
+		r0 = ret.Get(0).(re.VersionDiff)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, session, ruleID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_DiffRuleVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffRuleVersions'
+type Service_DiffRuleVersions_Call struct {
+	*mock.Call
+}
+
+// DiffRuleVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+//   - from uint64
+//   - to uint64
+func (_e *Service_Expecter) DiffRuleVersions(ctx interface{}, session interface{}, ruleID interface{}, from interface{}, to interface{}) *Service_DiffRuleVersions_Call {
+	return &Service_DiffRuleVersions_Call{Call: _e.mock.On("DiffRuleVersions", ctx, session, ruleID, from, to)}
+}
+
+func (_c *Service_DiffRuleVersions_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string, from uint64, to uint64)) *Service_DiffRuleVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		var arg4 uint64
+		if args[4] != nil {
+			arg4 = args[4].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_DiffRuleVersions_Call) Return(versionDiff re.VersionDiff, err error) *Service_DiffRuleVersions_Call {
+	_c.Call.Return(versionDiff, err)
+	return _c
+}
+
+func (_c *Service_DiffRuleVersions_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string, from uint64, to uint64) (re.VersionDiff, error)) *Service_DiffRuleVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DisableRule provides a mock function for the type Service
 func (_mock *Service) DisableRule(ctx context.Context, session authn.Session, id string) (re.Rule, error) {
 	ret := _mock.Called(ctx, session, id)
@@ -355,6 +440,222 @@ func (_c *Service_Handle_Call) RunAndReturn(run func(msg *messaging.Message) err
 	return _c
 }
 
+// ListDeadLetters provides a mock function for the type Service
+func (_mock *Service) ListDeadLetters(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 re.DeadLettersPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.DeadLetterPageMeta) (re.DeadLettersPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.DeadLetterPageMeta) re.DeadLettersPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(re.DeadLettersPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.DeadLetterPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeadLetters'
+type Service_ListDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm re.DeadLetterPageMeta
+func (_e *Service_Expecter) ListDeadLetters(ctx interface{}, session interface{}, pm interface{}) *Service_ListDeadLetters_Call {
+	return &Service_ListDeadLetters_Call{Call: _e.mock.On("ListDeadLetters", ctx, session, pm)}
+}
+
+func (_c *Service_ListDeadLetters_Call) Run(run func(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta)) *Service_ListDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.DeadLetterPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(re.DeadLetterPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListDeadLetters_Call) Return(deadLettersPage re.DeadLettersPage, err error) *Service_ListDeadLetters_Call {
+	_c.Call.Return(deadLettersPage, err)
+	return _c
+}
+
+func (_c *Service_ListDeadLetters_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error)) *Service_ListDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExecutions provides a mock function for the type Service
+func (_mock *Service) ListExecutions(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExecutions")
+	}
+
+	var r0 re.ExecutionsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.ExecutionPageMeta) (re.ExecutionsPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.ExecutionPageMeta) re.ExecutionsPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(re.ExecutionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.ExecutionPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListExecutions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExecutions'
+type Service_ListExecutions_Call struct {
+	*mock.Call
+}
+
+// ListExecutions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm re.ExecutionPageMeta
+func (_e *Service_Expecter) ListExecutions(ctx interface{}, session interface{}, pm interface{}) *Service_ListExecutions_Call {
+	return &Service_ListExecutions_Call{Call: _e.mock.On("ListExecutions", ctx, session, pm)}
+}
+
+func (_c *Service_ListExecutions_Call) Run(run func(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta)) *Service_ListExecutions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.ExecutionPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(re.ExecutionPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListExecutions_Call) Return(executionsPage re.ExecutionsPage, err error) *Service_ListExecutions_Call {
+	_c.Call.Return(executionsPage, err)
+	return _c
+}
+
+func (_c *Service_ListExecutions_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm re.ExecutionPageMeta) (re.ExecutionsPage, error)) *Service_ListExecutions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRuleVersions provides a mock function for the type Service
+func (_mock *Service) ListRuleVersions(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	ret := _mock.Called(ctx, session, pm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuleVersions")
+	}
+
+	var r0 re.VersionsPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.VersionPageMeta) (re.VersionsPage, error)); ok {
+		return returnFunc(ctx, session, pm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.VersionPageMeta) re.VersionsPage); ok {
+		r0 = returnFunc(ctx, session, pm)
+	} else {
+		r0 = ret.Get(0).(re.VersionsPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.VersionPageMeta) error); ok {
+		r1 = returnFunc(ctx, session, pm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ListRuleVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRuleVersions'
+type Service_ListRuleVersions_Call struct {
+	*mock.Call
+}
+
+// ListRuleVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - pm re.VersionPageMeta
+func (_e *Service_Expecter) ListRuleVersions(ctx interface{}, session interface{}, pm interface{}) *Service_ListRuleVersions_Call {
+	return &Service_ListRuleVersions_Call{Call: _e.mock.On("ListRuleVersions", ctx, session, pm)}
+}
+
+func (_c *Service_ListRuleVersions_Call) Run(run func(ctx context.Context, session authn.Session, pm re.VersionPageMeta)) *Service_ListRuleVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.VersionPageMeta
+		if args[2] != nil {
+			arg2 = args[2].(re.VersionPageMeta)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ListRuleVersions_Call) Return(versionsPage re.VersionsPage, err error) *Service_ListRuleVersions_Call {
+	_c.Call.Return(versionsPage, err)
+	return _c
+}
+
+func (_c *Service_ListRuleVersions_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, pm re.VersionPageMeta) (re.VersionsPage, error)) *Service_ListRuleVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListRules provides a mock function for the type Service
 func (_mock *Service) ListRules(ctx context.Context, session authn.Session, pm re.PageMeta) (re.Page, error) {
 	ret := _mock.Called(ctx, session, pm)
@@ -490,6 +791,276 @@ func (_c *Service_RemoveRule_Call) RunAndReturn(run func(ctx context.Context, se
 	return _c
 }
 
+// ReplayDeadLetter provides a mock function for the type Service
+func (_mock *Service) ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID string, id string) (re.DeadLetter, error) {
+	ret := _mock.Called(ctx, session, ruleID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDeadLetter")
+	}
+
+	var r0 re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (re.DeadLetter, error)); ok {
+		return returnFunc(ctx, session, ruleID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) re.DeadLetter); ok {
+		r0 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ReplayDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayDeadLetter'
+type Service_ReplayDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ReplayDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+//   - id string
+func (_e *Service_Expecter) ReplayDeadLetter(ctx interface{}, session interface{}, ruleID interface{}, id interface{}) *Service_ReplayDeadLetter_Call {
+	return &Service_ReplayDeadLetter_Call{Call: _e.mock.On("ReplayDeadLetter", ctx, session, ruleID, id)}
+}
+
+func (_c *Service_ReplayDeadLetter_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string, id string)) *Service_ReplayDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ReplayDeadLetter_Call) Return(deadLetter re.DeadLetter, err error) *Service_ReplayDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *Service_ReplayDeadLetter_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string, id string) (re.DeadLetter, error)) *Service_ReplayDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreRuleVersion provides a mock function for the type Service
+func (_mock *Service) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error) {
+	ret := _mock.Called(ctx, session, ruleID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRuleVersion")
+	}
+
+	var r0 re.Rule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64) (re.Rule, error)); ok {
+		return returnFunc(ctx, session, ruleID, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, uint64) re.Rule); ok {
+		r0 = returnFunc(ctx, session, ruleID, version)
+	} else {
+		r0 = ret.Get(0).(re.Rule)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, uint64) error); ok {
+		r1 = returnFunc(ctx, session, ruleID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_RestoreRuleVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreRuleVersion'
+type Service_RestoreRuleVersion_Call struct {
+	*mock.Call
+}
+
+// RestoreRuleVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+//   - version uint64
+func (_e *Service_Expecter) RestoreRuleVersion(ctx interface{}, session interface{}, ruleID interface{}, version interface{}) *Service_RestoreRuleVersion_Call {
+	return &Service_RestoreRuleVersion_Call{Call: _e.mock.On("RestoreRuleVersion", ctx, session, ruleID, version)}
+}
+
+func (_c *Service_RestoreRuleVersion_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string, version uint64)) *Service_RestoreRuleVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_RestoreRuleVersion_Call) Return(rule re.Rule, err error) *Service_RestoreRuleVersion_Call {
+	_c.Call.Return(rule, err)
+	return _c
+}
+
+func (_c *Service_RestoreRuleVersion_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string, version uint64) (re.Rule, error)) *Service_RestoreRuleVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartDeadLetterRetries provides a mock function for the type Service
+func (_mock *Service) StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error {
+	ret := _mock.Called(ctx, tck)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartDeadLetterRetries")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ticker.Ticker) error); ok {
+		r0 = returnFunc(ctx, tck)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StartDeadLetterRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartDeadLetterRetries'
+type Service_StartDeadLetterRetries_Call struct {
+	*mock.Call
+}
+
+// StartDeadLetterRetries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tck ticker.Ticker
+func (_e *Service_Expecter) StartDeadLetterRetries(ctx interface{}, tck interface{}) *Service_StartDeadLetterRetries_Call {
+	return &Service_StartDeadLetterRetries_Call{Call: _e.mock.On("StartDeadLetterRetries", ctx, tck)}
+}
+
+func (_c *Service_StartDeadLetterRetries_Call) Run(run func(ctx context.Context, tck ticker.Ticker)) *Service_StartDeadLetterRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ticker.Ticker
+		if args[1] != nil {
+			arg1 = args[1].(ticker.Ticker)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StartDeadLetterRetries_Call) Return(err error) *Service_StartDeadLetterRetries_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StartDeadLetterRetries_Call) RunAndReturn(run func(ctx context.Context, tck ticker.Ticker) error) *Service_StartDeadLetterRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartExecutionsWriter provides a mock function for the type Service
+func (_mock *Service) StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error {
+	ret := _mock.Called(ctx, tck)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExecutionsWriter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ticker.Ticker) error); ok {
+		r0 = returnFunc(ctx, tck)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Service_StartExecutionsWriter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartExecutionsWriter'
+type Service_StartExecutionsWriter_Call struct {
+	*mock.Call
+}
+
+// StartExecutionsWriter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tck ticker.Ticker
+func (_e *Service_Expecter) StartExecutionsWriter(ctx interface{}, tck interface{}) *Service_StartExecutionsWriter_Call {
+	return &Service_StartExecutionsWriter_Call{Call: _e.mock.On("StartExecutionsWriter", ctx, tck)}
+}
+
+func (_c *Service_StartExecutionsWriter_Call) Run(run func(ctx context.Context, tck ticker.Ticker)) *Service_StartExecutionsWriter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 ticker.Ticker
+		if args[1] != nil {
+			arg1 = args[1].(ticker.Ticker)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_StartExecutionsWriter_Call) Return(err error) *Service_StartExecutionsWriter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Service_StartExecutionsWriter_Call) RunAndReturn(run func(ctx context.Context, tck ticker.Ticker) error) *Service_StartExecutionsWriter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartScheduler provides a mock function for the type Service
 func (_mock *Service) StartScheduler(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -541,6 +1112,84 @@ func (_c *Service_StartScheduler_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// TestRule provides a mock function for the type Service
+func (_mock *Service) TestRule(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error) {
+	ret := _mock.Called(ctx, session, r, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestRule")
+	}
+
+	var r0 re.TestResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.Rule, *messaging.Message) (re.TestResult, error)); ok {
+		return returnFunc(ctx, session, r, msg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, re.Rule, *messaging.Message) re.TestResult); ok {
+		r0 = returnFunc(ctx, session, r, msg)
+	} else {
+		r0 = ret.Get(0).(re.TestResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, re.Rule, *messaging.Message) error); ok {
+		r1 = returnFunc(ctx, session, r, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_TestRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TestRule'
+type Service_TestRule_Call struct {
+	*mock.Call
+}
+
+// TestRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - r re.Rule
+//   - msg *messaging.Message
+func (_e *Service_Expecter) TestRule(ctx interface{}, session interface{}, r interface{}, msg interface{}) *Service_TestRule_Call {
+	return &Service_TestRule_Call{Call: _e.mock.On("TestRule", ctx, session, r, msg)}
+}
+
+func (_c *Service_TestRule_Call) Run(run func(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message)) *Service_TestRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 re.Rule
+		if args[2] != nil {
+			arg2 = args[2].(re.Rule)
+		}
+		var arg3 *messaging.Message
+		if args[3] != nil {
+			arg3 = args[3].(*messaging.Message)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_TestRule_Call) Return(testResult re.TestResult, err error) *Service_TestRule_Call {
+	_c.Call.Return(testResult, err)
+	return _c
+}
+
+func (_c *Service_TestRule_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, r re.Rule, msg *messaging.Message) (re.TestResult, error)) *Service_TestRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateRule provides a mock function for the type Service
 func (_mock *Service) UpdateRule(ctx context.Context, session authn.Session, r re.Rule) (re.Rule, error) {
 	ret := _mock.Called(ctx, session, r)
@@ -757,6 +1406,84 @@ func (_c *Service_UpdateRuleTags_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// ViewDeadLetter provides a mock function for the type Service
+func (_mock *Service) ViewDeadLetter(ctx context.Context, session authn.Session, ruleID string, id string) (re.DeadLetter, error) {
+	ret := _mock.Called(ctx, session, ruleID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewDeadLetter")
+	}
+
+	var r0 re.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) (re.DeadLetter, error)); ok {
+		return returnFunc(ctx, session, ruleID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, authn.Session, string, string) re.DeadLetter); ok {
+		r0 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r0 = ret.Get(0).(re.DeadLetter)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, authn.Session, string, string) error); ok {
+		r1 = returnFunc(ctx, session, ruleID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Service_ViewDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewDeadLetter'
+type Service_ViewDeadLetter_Call struct {
+	*mock.Call
+}
+
+// ViewDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session authn.Session
+//   - ruleID string
+//   - id string
+func (_e *Service_Expecter) ViewDeadLetter(ctx interface{}, session interface{}, ruleID interface{}, id interface{}) *Service_ViewDeadLetter_Call {
+	return &Service_ViewDeadLetter_Call{Call: _e.mock.On("ViewDeadLetter", ctx, session, ruleID, id)}
+}
+
+func (_c *Service_ViewDeadLetter_Call) Run(run func(ctx context.Context, session authn.Session, ruleID string, id string)) *Service_ViewDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 authn.Session
+		if args[1] != nil {
+			arg1 = args[1].(authn.Session)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Service_ViewDeadLetter_Call) Return(deadLetter re.DeadLetter, err error) *Service_ViewDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *Service_ViewDeadLetter_Call) RunAndReturn(run func(ctx context.Context, session authn.Session, ruleID string, id string) (re.DeadLetter, error)) *Service_ViewDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewRule provides a mock function for the type Service
 func (_mock *Service) ViewRule(ctx context.Context, session authn.Session, id string, withRoles bool) (re.Rule, error) {
 	ret := _mock.Called(ctx, session, id, withRoles)
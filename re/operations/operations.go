@@ -18,6 +18,14 @@ const (
 	OpListRules
 	OpEnableRule
 	OpDisableRule
+	OpTestRule
+	OpListRuleExecutions
+	OpListRuleVersions
+	OpDiffRuleVersions
+	OpRestoreRuleVersion
+	OpListRuleDeadLetters
+	OpViewRuleDeadLetter
+	OpReplayRuleDeadLetter
 )
 
 func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
@@ -58,5 +66,37 @@ func OperationDetails() map[permissions.Operation]permissions.OperationDetails {
 			Name:               "disable",
 			PermissionRequired: true,
 		},
+		OpTestRule: {
+			Name:               "test",
+			PermissionRequired: true,
+		},
+		OpListRuleExecutions: {
+			Name:               "list_executions",
+			PermissionRequired: true,
+		},
+		OpListRuleVersions: {
+			Name:               "list_versions",
+			PermissionRequired: true,
+		},
+		OpDiffRuleVersions: {
+			Name:               "diff_versions",
+			PermissionRequired: true,
+		},
+		OpRestoreRuleVersion: {
+			Name:               "restore_version",
+			PermissionRequired: true,
+		},
+		OpListRuleDeadLetters: {
+			Name:               "list_dead_letters",
+			PermissionRequired: true,
+		},
+		OpViewRuleDeadLetter: {
+			Name:               "view_dead_letter",
+			PermissionRequired: true,
+		},
+		OpReplayRuleDeadLetter: {
+			Name:               "replay_dead_letter",
+			PermissionRequired: true,
+		},
 	}
 }
@@ -16,41 +16,67 @@ import (
 const outputTypeKey = "type"
 
 type Alarm struct {
+	Retryable
 	AlarmsPub messaging.Publisher `json:"-"`
 	RuleID    string              `json:"rule_id"`
+	// AutoClear clears the alarms raised for the message source once the
+	// rule logic no longer holds for it.
+	AutoClear bool `json:"auto_clear,omitempty"`
 }
 
 func (a *Alarm) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	data, err := json.Marshal(val)
+	alarmsList, err := a.alarms(msg, val)
 	if err != nil {
 		return err
 	}
 
+	for _, alarm := range alarmsList {
+		if err := a.processAlarm(ctx, msg, alarm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Render returns the alarms that would be published.
+func (a *Alarm) Render(msg *messaging.Message, val any) (any, error) {
+	return a.alarms(msg, val)
+}
+
+func (a *Alarm) alarms(msg *messaging.Message, val any) ([]alarms.Alarm, error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
 	var alarmsList []alarms.Alarm
 	if err := json.Unmarshal(data, &alarmsList); err != nil {
 		var single alarms.Alarm
 		if err := json.Unmarshal(data, &single); err != nil {
-			return err
+			return nil, err
 		}
 		alarmsList = []alarms.Alarm{single}
 	}
 
-	for _, alarm := range alarmsList {
-		if err := a.processAlarm(ctx, msg, alarm); err != nil {
-			return err
-		}
+	for i := range alarmsList {
+		alarmsList[i].RuleID = a.RuleID
+		alarmsList[i].DomainID = msg.Domain
+		alarmsList[i].ClientID = msg.ClientIdentity()
+		alarmsList[i].ChannelID = msg.Channel
+		alarmsList[i].Subtopic = msg.Subtopic
 	}
 
-	return nil
+	return alarmsList, nil
 }
 
-func (a *Alarm) processAlarm(ctx context.Context, msg *messaging.Message, alarm alarms.Alarm) error {
-	alarm.RuleID = a.RuleID
-	alarm.DomainID = msg.Domain
-	alarm.ClientID = msg.ClientIdentity()
-	alarm.ChannelID = msg.Channel
-	alarm.Subtopic = msg.Subtopic
+// Clear publishes a cleared alarm for the message source, which clears the
+// alarms of the rule raised for the source.
+func (a *Alarm) Clear(ctx context.Context, msg *messaging.Message) error {
+	return a.processAlarm(ctx, msg, alarms.Alarm{RuleID: a.RuleID, Status: alarms.ClearedStatus})
+}
 
+func (a *Alarm) processAlarm(ctx context.Context, msg *messaging.Message, alarm alarms.Alarm) error {
 	var buf bytes.Buffer
 	if err := gob.NewEncoder(&buf).Encode(alarm); err != nil {
 		return err
@@ -75,7 +101,12 @@ func (a *Alarm) processAlarm(ctx context.Context, msg *messaging.Message, alarm
 }
 
 func (a *Alarm) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	out := map[string]any{
 		outputTypeKey: AlarmsType.String(),
-	})
+	}
+	if a.AutoClear {
+		out["auto_clear"] = true
+	}
+
+	return json.Marshal(a.withRetry(out))
 }
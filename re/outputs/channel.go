@@ -11,6 +11,7 @@ import (
 )
 
 type ChannelPublisher struct {
+	Retryable
 	RePubSub messaging.PubSub `json:"-"`
 	Channel  string           `json:"channel"`
 	Topic    string           `json:"topic"`
@@ -41,10 +42,24 @@ func (p *ChannelPublisher) Run(ctx context.Context, msg *messaging.Message, val
 	return nil
 }
 
+// Render returns the message that would be published to the channel.
+func (p *ChannelPublisher) Render(msg *messaging.Message, val any) (any, error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"channel": p.Channel,
+		"topic":   p.Topic,
+		"payload": json.RawMessage(data),
+	}, nil
+}
+
 func (cp *ChannelPublisher) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]string{
+	return json.Marshal(cp.withRetry(map[string]any{
 		outputTypeKey: ChannelsType.String(),
 		"channel":     cp.Channel,
 		"topic":       cp.Topic,
-	})
+	}))
 }
@@ -4,16 +4,15 @@
 package outputs
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"text/template"
 
 	"github.com/absmach/magistrala/pkg/emailer"
 	"github.com/absmach/magistrala/pkg/messaging"
 )
 
 type Email struct {
+	Retryable
 	To      []string        `json:"to"`
 	Subject string          `json:"subject"`
 	Content string          `json:"content"`
@@ -21,34 +20,36 @@ type Email struct {
 }
 
 func (e *Email) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	templData := templateVal{
-		Message: msg,
-		Result:  val,
-	}
-
-	tmpl, err := template.New("email").Parse(e.Content)
+	content, err := renderTemplate("email", e.Content, msg, val)
 	if err != nil {
 		return err
 	}
 
-	var output bytes.Buffer
-	if err := tmpl.Execute(&output, templData); err != nil {
-		return err
-	}
-
-	content := output.String()
-
 	if err := e.Emailer.SendEmailNotification(e.To, "", e.Subject, "", "", content, "", make(map[string][]byte)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// Render returns the e-mail that would be sent, without sending it.
+func (e *Email) Render(msg *messaging.Message, val any) (any, error) {
+	content, err := renderTemplate("email", e.Content, msg, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"to":      e.To,
+		"subject": e.Subject,
+		"content": content,
+	}, nil
+}
+
 func (e *Email) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	return json.Marshal(e.withRetry(map[string]any{
 		outputTypeKey: EmailType.String(),
 		"to":          e.To,
 		"subject":     e.Subject,
 		"content":     e.Content,
-	})
+	}))
 }
@@ -4,18 +4,39 @@
 package outputs
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
+	"text/template"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/messaging"
 )
 
+// Redacted replaces the output credentials in the API responses.
+const Redacted = "********"
+
 type templateVal struct {
 	Message *messaging.Message
 	Result  any
 }
 
+// renderTemplate executes the output template against the message and
+// the rule logic result.
+func renderTemplate(name, text string, msg *messaging.Message, val any) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, templateVal{Message: msg, Result: val}); err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
 // OutputType is the indicator for type of the output
 // so we can move it to the Go instead calling Go from Lua.
 type OutputType uint
@@ -27,10 +48,11 @@ const (
 	EmailType
 	SaveRemotePgType
 	SlackType
+	WebhookType
 )
 
 var (
-	scriptKindToString = [...]string{"channels", "alarms", "save_senml", "email", "save_remote_pg", "slack"}
+	scriptKindToString = [...]string{"channels", "alarms", "save_senml", "email", "save_remote_pg", "slack", "webhook"}
 	stringToScriptKind = map[string]OutputType{
 		"channels":       ChannelsType,
 		"alarms":         AlarmsType,
@@ -38,6 +60,7 @@ var (
 		"email":          EmailType,
 		"save_remote_pg": SaveRemotePgType,
 		"slack":          SlackType,
+		"webhook":        WebhookType,
 	}
 )
 
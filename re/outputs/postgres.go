@@ -4,12 +4,10 @@
 package outputs
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
-	"text/template"
 
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/messaging"
@@ -18,6 +16,7 @@ import (
 )
 
 type Postgres struct {
+	Retryable
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
 	User     string `json:"user"`
@@ -28,27 +27,11 @@ type Postgres struct {
 }
 
 func (p *Postgres) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	templData := templateVal{
-		Message: msg,
-		Result:  val,
-	}
-
-	tmpl, err := template.New("postgres").Parse(p.Mapping)
+	columns, err := p.columns(msg, val)
 	if err != nil {
 		return err
 	}
 
-	var output bytes.Buffer
-	if err := tmpl.Execute(&output, templData); err != nil {
-		return err
-	}
-
-	mapping := output.String()
-	var columns map[string]any
-	if err = json.Unmarshal([]byte(mapping), &columns); err != nil {
-		return err
-	}
-
 	connStr := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		p.Host, p.Port, p.User, p.Password, p.Database,
@@ -93,8 +76,27 @@ func (p *Postgres) Run(ctx context.Context, msg *messaging.Message, val any) err
 	return nil
 }
 
+// Render returns the column values that would be inserted into the table.
+func (p *Postgres) Render(msg *messaging.Message, val any) (any, error) {
+	return p.columns(msg, val)
+}
+
+func (p *Postgres) columns(msg *messaging.Message, val any) (map[string]any, error) {
+	mapping, err := renderTemplate("postgres", p.Mapping, msg, val)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns map[string]any
+	if err := json.Unmarshal([]byte(mapping), &columns); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
 func (p *Postgres) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	return json.Marshal(p.withRetry(map[string]any{
 		outputTypeKey: SaveRemotePgType.String(),
 		"host":        p.Host,
 		"port":        p.Port,
@@ -103,5 +105,20 @@ func (p *Postgres) MarshalJSON() ([]byte, error) {
 		"database":    p.Database,
 		"table":       p.Table,
 		"mapping":     p.Mapping,
-	})
+	}))
+}
+
+// UnmarshalJSON parses the Postgres output, rejecting the masked password.
+func (p *Postgres) UnmarshalJSON(data []byte) error {
+	type postgres Postgres
+	var raw postgres
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Password == Redacted {
+		return errRedacted
+	}
+	*p = Postgres(raw)
+
+	return nil
 }
@@ -0,0 +1,90 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+)
+
+const (
+	maxRetries      = 20
+	defRetryBackoff = time.Minute
+	maxRetryBackoff = 6 * time.Hour
+	retryKey        = "retry"
+)
+
+var errRetries = errors.New("output retries exceed the maximum of " + strconv.Itoa(maxRetries))
+
+// RetryPolicy configures the automatic retries of the failed output runs, which
+// are kept in the dead-letter queue. Runs are retried waiting Backoff after the
+// failure, doubling it for each subsequent retry.
+type RetryPolicy struct {
+	Retries uint          `json:"retries"`
+	Backoff time.Duration `json:"backoff"`
+}
+
+// Next returns the time of the next retry after the given number of attempts,
+// or zero time if there are no retries left.
+func (p *RetryPolicy) Next(attempts uint, after time.Time) time.Time {
+	if p == nil || attempts == 0 || attempts > p.Retries {
+		return time.Time{}
+	}
+	backoff := p.Backoff
+	for i := uint(1); i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+
+	return after.Add(min(backoff, maxRetryBackoff))
+}
+
+func (p *RetryPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"retries": p.Retries,
+		"backoff": p.Backoff.String(),
+	})
+}
+
+// UnmarshalJSON parses the retry policy, applying the default backoff.
+func (p *RetryPolicy) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Retries uint   `json:"retries"`
+		Backoff string `json:"backoff"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Retries > maxRetries {
+		return errRetries
+	}
+	backoff, err := parseDuration(raw.Backoff, defRetryBackoff)
+	if err != nil {
+		return err
+	}
+	*p = RetryPolicy{Retries: raw.Retries, Backoff: backoff}
+
+	return nil
+}
+
+// Retryable is embedded in the outputs to set their retry policy.
+type Retryable struct {
+	Retry *RetryPolicy `json:"retry,omitempty"`
+}
+
+// RetryPolicy returns the retry policy of the output, nil if failed runs are not retried.
+func (r Retryable) RetryPolicy() *RetryPolicy {
+	return r.Retry
+}
+
+// withRetry adds the retry policy to the encoded output.
+func (r Retryable) withRetry(m map[string]any) map[string]any {
+	if r.Retry != nil {
+		m[retryKey] = r.Retry
+	}
+
+	return m
+}
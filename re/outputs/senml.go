@@ -12,21 +12,15 @@ import (
 )
 
 type SenML struct {
+	Retryable
 	WritersPub messaging.Publisher `json:"-"`
 }
 
 func (s *SenML) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	// In case there is a single SenML value, convert to slice so we can decode.
-	if _, ok := val.([]any); !ok {
-		val = []any{val}
-	}
-	data, err := json.Marshal(val)
+	data, err := s.encode(val)
 	if err != nil {
 		return err
 	}
-	if _, err := senml.Decode(data, senml.JSON); err != nil {
-		return err
-	}
 
 	m := &messaging.Message{
 		Domain:    msg.Domain,
@@ -46,8 +40,34 @@ func (s *SenML) Run(ctx context.Context, msg *messaging.Message, val any) error
 	return nil
 }
 
+// Render returns the SenML records that would be forwarded to writers.
+func (s *SenML) Render(msg *messaging.Message, val any) (any, error) {
+	data, err := s.encode(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(data), nil
+}
+
+func (s *SenML) encode(val any) ([]byte, error) {
+	// In case there is a single SenML value, convert to slice so we can decode.
+	if _, ok := val.([]any); !ok {
+		val = []any{val}
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := senml.Decode(data, senml.JSON); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 func (senml *SenML) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]string{
+	return json.Marshal(senml.withRetry(map[string]any{
 		outputTypeKey: SaveSenMLType.String(),
-	})
+	}))
 }
@@ -4,44 +4,26 @@
 package outputs
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"text/template"
 
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/slack-go/slack"
 )
 
 type Slack struct {
+	Retryable
 	Token     string `json:"token"`
 	ChannelID string `json:"channel_id"`
 	Message   string `json:"message"`
 }
 
 func (s *Slack) Run(ctx context.Context, msg *messaging.Message, val any) error {
-	templData := templateVal{
-		Message: msg,
-		Result:  val,
-	}
-
-	tmpl, err := template.New("slack").Parse(s.Message)
+	message, err := s.message(msg, val)
 	if err != nil {
 		return err
 	}
 
-	var output bytes.Buffer
-	if err := tmpl.Execute(&output, templData); err != nil {
-		return err
-	}
-
-	mapping := output.String()
-
-	var message slack.Msg
-	if err := json.Unmarshal([]byte(mapping), &message); err != nil {
-		return err
-	}
-
 	slackClient := slack.New(s.Token)
 
 	var opts []slack.MsgOption
@@ -62,11 +44,45 @@ func (s *Slack) Run(ctx context.Context, msg *messaging.Message, val any) error
 	return nil
 }
 
+// Render returns the Slack message without posting it.
+func (s *Slack) Render(msg *messaging.Message, val any) (any, error) {
+	return s.message(msg, val)
+}
+
+func (s *Slack) message(msg *messaging.Message, val any) (slack.Msg, error) {
+	mapping, err := renderTemplate("slack", s.Message, msg, val)
+	if err != nil {
+		return slack.Msg{}, err
+	}
+
+	var message slack.Msg
+	if err := json.Unmarshal([]byte(mapping), &message); err != nil {
+		return slack.Msg{}, err
+	}
+
+	return message, nil
+}
+
 func (s *Slack) MarshalJSON() ([]byte, error) {
-	return json.Marshal(map[string]any{
+	return json.Marshal(s.withRetry(map[string]any{
 		outputTypeKey: SlackType.String(),
 		"token":       s.Token,
 		"channel_id":  s.ChannelID,
 		"message":     s.Message,
-	})
+	}))
+}
+
+// UnmarshalJSON parses the Slack output, rejecting the masked token.
+func (s *Slack) UnmarshalJSON(data []byte) error {
+	type slack Slack
+	var raw slack
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw.Token == Redacted {
+		return errRedacted
+	}
+	*s = Slack(raw)
+
+	return nil
 }
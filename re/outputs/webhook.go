@@ -0,0 +1,283 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/netguard"
+)
+
+const (
+	// SignatureHeader contains the hex encoded HMAC-SHA256 signature
+	// of the timestamp and the request body, prefixed with "sha256=".
+	SignatureHeader = "X-Magistrala-Signature"
+	// TimestampHeader contains the Unix time the request was signed at.
+	TimestampHeader = "X-Magistrala-Timestamp"
+
+	defWebhookTimeout = 10 * time.Second
+	// Only the beginning of the response body is kept for errors.
+	maxErrBodySize = 512
+)
+
+var (
+	errWebhookURL    = errors.New("webhook URL must be an absolute HTTP(S) URL")
+	errWebhookMethod = errors.New("unsupported webhook method")
+	errRedacted      = errors.New("masked credentials must be replaced with the actual values")
+)
+
+var (
+	allowPrivate atomic.Bool
+	// webhookClient fails to connect to private addresses, including the
+	// ones a webhook host resolves or redirects to.
+	webhookClient = &http.Client{Transport: netguard.Transport()}
+)
+
+// AllowPrivateWebhooks sets whether webhooks may be sent to loopback,
+// private and link-local addresses. They are rejected by default, so
+// rules can't reach the internal services or the cloud metadata endpoints.
+func AllowPrivateWebhooks(allow bool) {
+	allowPrivate.Store(allow)
+}
+
+// Webhook sends the rule result to an HTTP endpoint. Failed requests
+// are retried by the output retry policy.
+type Webhook struct {
+	Retryable
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body is a template rendered with the message and the rule result.
+	Body string `json:"body"`
+	// Secret is used to sign requests with HMAC-SHA256. Requests are not signed if empty.
+	Secret  string        `json:"secret,omitempty"`
+	Timeout time.Duration `json:"timeout"`
+	Client  *http.Client  `json:"-"`
+}
+
+// webhookRequest is the request rendered from the webhook configuration.
+type webhookRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body"`
+}
+
+func (w *Webhook) Run(ctx context.Context, msg *messaging.Message, val any) error {
+	req, err := w.request(msg, val)
+	if err != nil {
+		return err
+	}
+
+	client := w.Client
+	switch {
+	case client != nil:
+	case allowPrivate.Load():
+		client = http.DefaultClient
+	default:
+		client = webhookClient
+	}
+
+	return w.send(ctx, client, req)
+}
+
+// Render returns the HTTP request that would be sent without sending it.
+func (w *Webhook) Render(msg *messaging.Message, val any) (any, error) {
+	req, err := w.request(msg, val)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]any{
+		"method":  req.Method,
+		"url":     req.URL,
+		"headers": req.Headers,
+		"body":    req.Body,
+	}
+	if json.Valid([]byte(req.Body)) {
+		ret["body"] = json.RawMessage(req.Body)
+	}
+
+	return ret, nil
+}
+
+func (w *Webhook) request(msg *messaging.Message, val any) (webhookRequest, error) {
+	body, err := renderTemplate("webhook", w.Body, msg, val)
+	if err != nil {
+		return webhookRequest{}, err
+	}
+
+	headers := make(map[string]string, len(w.Headers)+2)
+	maps.Copy(headers, w.Headers)
+	if w.Secret != "" {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		headers[TimestampHeader] = ts
+		headers[SignatureHeader] = "sha256=" + Sign(w.Secret, ts, []byte(body))
+	}
+
+	return webhookRequest{
+		Method:  w.Method,
+		URL:     w.URL,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+func (w *Webhook) send(ctx context.Context, client *http.Client, wr webhookRequest) error {
+	timeout := w.Timeout
+	if timeout == 0 {
+		timeout = defWebhookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, wr.Method, wr.URL, strings.NewReader(wr.Body))
+	if err != nil {
+		return err
+	}
+	for k, v := range wr.Headers {
+		req.Header.Set(k, v)
+	}
+	if req.Header.Get("Content-Type") == "" && json.Valid([]byte(wr.Body)) {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(errors.New("failed to send webhook request"), err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrBodySize))
+
+	return fmt.Errorf("webhook responded with status %d: %s", res.StatusCode, bytes.TrimSpace(body))
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the timestamp and
+// the body joined with a dot, so receivers can verify the request origin.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *Webhook) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.withRetry(map[string]any{
+		outputTypeKey: WebhookType.String(),
+		"method":      w.Method,
+		"url":         w.URL,
+		"headers":     w.Headers,
+		"body":        w.Body,
+		"secret":      w.Secret,
+		"timeout":     w.Timeout.String(),
+	}))
+}
+
+// UnmarshalJSON parses the webhook configuration, applying
+// defaults and validating the method and URL. Unless private webhooks
+// are allowed, the URLs with a private IP address or localhost host are
+// rejected. The hosts are not resolved here, the client refuses to connect
+// to the private addresses they resolve to.
+func (w *Webhook) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Method  string            `json:"method"`
+		URL     string            `json:"url"`
+		Headers map[string]string `json:"headers"`
+		Body    string            `json:"body"`
+		Secret  string            `json:"secret"`
+		Timeout string            `json:"timeout"`
+		Retries uint              `json:"retries"`
+		Backoff string            `json:"backoff"`
+		Retry   *RetryPolicy      `json:"retry"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(raw.Method)
+	switch method {
+	case "":
+		method = http.MethodPost
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return errors.Wrap(errWebhookMethod, errors.New(raw.Method))
+	}
+	u, err := url.Parse(raw.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errWebhookURL
+	}
+	if !allowPrivate.Load() && netguard.PrivateHost(u.Hostname()) {
+		return errors.Wrap(errWebhookURL, netguard.ErrPrivateAddress)
+	}
+	if raw.Secret == Redacted {
+		return errRedacted
+	}
+	for _, v := range raw.Headers {
+		if v == Redacted {
+			return errRedacted
+		}
+	}
+	timeout, err := parseDuration(raw.Timeout, defWebhookTimeout)
+	if err != nil {
+		return err
+	}
+	// Webhooks used to retry the failed requests within the rule run.
+	// Their retries are kept as the retry policy of the output.
+	if raw.Retry == nil && raw.Retries > 0 {
+		backoff, err := parseDuration(raw.Backoff, defRetryBackoff)
+		if err != nil {
+			return err
+		}
+		raw.Retry = &RetryPolicy{Retries: min(raw.Retries, maxRetries), Backoff: backoff}
+	}
+
+	*w = Webhook{
+		Retryable: Retryable{Retry: raw.Retry},
+		Method:    method,
+		URL:       raw.URL,
+		Headers:   raw.Headers,
+		Body:      raw.Body,
+		Secret:    raw.Secret,
+		Timeout:   timeout,
+	}
+
+	return nil
+}
+
+func parseDuration(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be positive", s)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,205 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/re/outputs"
+	lua "github.com/yuin/gopher-lua"
+)
+
+const resultKey = "result"
+
+// ErrInvalidPipeline indicates that the rule pipeline is not valid.
+var ErrInvalidPipeline = errors.New("invalid rule pipeline")
+
+// scriptRunner runs a script of the rule with the result of the previous pipeline step.
+type scriptRunner func(ctx context.Context, s Script, prev any) (any, error)
+
+// validateRule checks the rule logic, or the pipeline steps and branch conditions,
+// against the sandbox rules of the domain. A rule is either a single logic with
+// its outputs or a pipeline, not both.
+func (re *re) validateRule(domainID string, r Rule) error {
+	if len(r.Steps) == 0 {
+		return re.validateLogic(domainID, r.Logic)
+	}
+	if r.Logic.Value != "" || len(r.Outputs) > 0 {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(ErrInvalidPipeline, errors.New("rule with steps can't have logic or outputs")))
+	}
+	for i, s := range r.Steps {
+		if s.Logic.Value == "" {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(ErrInvalidPipeline, fmt.Errorf("step %d has no logic", i)))
+		}
+		if err := re.validateLogic(domainID, s.Logic); err != nil {
+			return err
+		}
+		for _, b := range s.Branches {
+			if b.Condition == nil {
+				continue
+			}
+			if err := re.validateLogic(domainID, *b.Condition); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// runPipeline runs the steps in order, each with the result of the previous step.
+// Nil and false results end the pipeline, same as they skip the outputs of the rule
+// logic. Otherwise, the outputs of the first matching branch are passed to the emit
+// function with the step result.
+func runPipeline(ctx context.Context, steps []Step, run scriptRunner, emit func(Outputs, any)) ([]StepResult, error) {
+	var results []StepResult
+	var prev any
+	for i, s := range steps {
+		res, err := run(ctx, s.Logic, prev)
+		if err != nil {
+			return results, fmt.Errorf("step %s: %w", stepName(i, s), err)
+		}
+		sr := StepResult{Step: i, Name: s.Name, Result: res}
+		if !truthy(res) {
+			results = append(results, sr)
+			return results, nil
+		}
+		stop := false
+		for j, b := range s.Branches {
+			if b.Condition != nil {
+				ok, err := run(ctx, *b.Condition, res)
+				if err != nil {
+					results = append(results, sr)
+					return results, fmt.Errorf("step %s branch %d condition: %w", stepName(i, s), j, err)
+				}
+				if !truthy(ok) {
+					continue
+				}
+			}
+			sr.Branch = &j
+			emit(b.Outputs, res)
+			stop = b.Stop
+			break
+		}
+		results = append(results, sr)
+		if stop {
+			return results, nil
+		}
+		prev = res
+	}
+
+	return results, nil
+}
+
+func (re *re) processPipeline(ctx context.Context, details []slog.Attr, r Rule, msg *messaging.Message) (pkglog.RunInfo, []string) {
+	st := newRuleState(re.state, r.ID, msg, false)
+	run := func(ctx context.Context, s Script, prev any) (any, error) {
+		return re.runCachedScript(ctx, st, r, s, msg, prev)
+	}
+
+	var attempted []string
+	var outErr error
+	raised := false
+	steps, err := runPipeline(ctx, r.Steps, run, func(outs Outputs, res any) {
+		for _, o := range outs {
+			if _, ok := o.(*outputs.Alarm); ok {
+				raised = true
+			}
+			attempted = append(attempted, outputType(o))
+			if e := re.runOutput(ctx, r, o, msg, res); e != nil {
+				outErr = errors.Wrap(e, outErr)
+			}
+		}
+	})
+	details = append(details, slog.Int("steps", len(steps)))
+	if err != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to run rule logic: %s", err), Details: details}, attempted
+	}
+	if raised {
+		if e := re.trackRaised(ctx, r, msg); e != nil {
+			outErr = errors.Wrap(e, outErr)
+		}
+	}
+	if outErr != nil {
+		return pkglog.RunInfo{Level: slog.LevelError, Message: fmt.Sprintf("failed to handle rule output: %s", outErr), Details: details}, attempted
+	}
+	info := pkglog.RunInfo{Level: slog.LevelInfo, Message: "rule processed successfully", Details: details}
+	// The pipeline raised no alarm, so the alarm condition no longer holds.
+	if !raised {
+		var cleared []string
+		info, cleared = re.autoClear(ctx, r, msg, info)
+		attempted = append(attempted, cleared...)
+	}
+
+	return info, attempted
+}
+
+// runCachedScript executes the script of the rule in the sandbox, same as runScript,
+// reusing the compiled script.
+func (re *re) runCachedScript(ctx context.Context, st *ruleState, r Rule, s Script, msg *messaging.Message, prev any) (any, error) {
+	ctx, cancel := re.runContext(ctx, r.DomainID, s.Type)
+	defer cancel()
+
+	switch s.Type {
+	case GoType:
+		p, release, err := re.scripts.golang(ctx, r.ID, s, re.sandbox.modules(r.DomainID).Go)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return p.run(ctx, st, msg, prev)
+	default:
+		proto, err := re.scripts.lua(r.ID, s)
+		if err != nil {
+			return nil, err
+		}
+		l := newLuaState(re.sandbox, r.DomainID)
+		defer l.Close()
+		setLuaResult(l, prev)
+		result, err := runLuaProto(ctx, l, st, proto, msg)
+		if err != nil {
+			return nil, err
+		}
+		return convertLua(result), nil
+	}
+}
+
+// setLuaResult sets the result of the previous pipeline step as a Lua global variable.
+func setLuaResult(l *lua.LState, prev any) {
+	if prev == nil {
+		return
+	}
+	// Go scripts can return any type, so the result is converted to JSON values first.
+	if b, err := json.Marshal(prev); err == nil {
+		var v any
+		if err := json.Unmarshal(b, &v); err == nil {
+			prev = v
+		}
+	}
+	l.SetGlobal(resultKey, traverseJson(l, prev))
+}
+
+// truthy reports whether the result is neither nil nor false.
+func truthy(v any) bool {
+	if b, ok := v.(bool); ok {
+		return b
+	}
+
+	return v != nil
+}
+
+func stepName(i int, s Step) string {
+	if s.Name != "" {
+		return fmt.Sprintf("%d (%s)", i, s.Name)
+	}
+
+	return fmt.Sprint(i)
+}
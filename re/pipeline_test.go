@@ -0,0 +1,276 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func luaScript(value string) re.Script {
+	return re.Script{Type: re.LuaType, Value: value}
+}
+
+func goScript(value string) *re.Script {
+	return &re.Script{Type: re.GoType, Value: value}
+}
+
+// thresholdSteps converts the temperature to Fahrenheit and routes it to the
+// alerts or the readings channel.
+func thresholdSteps() []re.Step {
+	return []re.Step{
+		{
+			Name:  "parse",
+			Logic: luaScript("return {celsius = message.payload.temperature}"),
+		},
+		{
+			Name:  "convert",
+			Logic: re.Script{Type: re.GoType, Value: "import m \"messaging\"\n\nfunc logicFunction() any { r := m.result.(map[string]any); return map[string]any{\"fahrenheit\": r[\"celsius\"].(float64)*9/5 + 32} }"},
+			Branches: []re.Branch{
+				{
+					Name:      "alert",
+					Condition: &re.Script{Type: re.LuaType, Value: "return result.fahrenheit > 100"},
+					Outputs:   re.Outputs{&outputs.ChannelPublisher{Channel: "alerts"}},
+					Stop:      true,
+				},
+				{
+					Name:    "reading",
+					Outputs: re.Outputs{&outputs.ChannelPublisher{Channel: "readings"}},
+				},
+			},
+		},
+		{
+			Name:  "round",
+			Logic: luaScript("return math.floor(result.fahrenheit)"),
+		},
+	}
+}
+
+func branch(i int) *int {
+	return &i
+}
+
+func TestAddRulePipeline(t *testing.T) {
+	svc, repo, _ := newCachingService(t, nil, re.Config{}, make(chan pkglog.RunInfo, 1))
+
+	cases := []struct {
+		desc string
+		rule re.Rule
+		err  error
+	}{
+		{
+			desc: "add rule with pipeline",
+			rule: re.Rule{Name: "pipeline", Steps: thresholdSteps()},
+		},
+		{
+			desc: "add rule with pipeline and logic",
+			rule: re.Rule{Name: "pipeline", Logic: luaScript("return 1"), Steps: thresholdSteps()},
+			err:  re.ErrInvalidPipeline,
+		},
+		{
+			desc: "add rule with pipeline and outputs",
+			rule: re.Rule{Name: "pipeline", Outputs: re.Outputs{&outputs.ChannelPublisher{Channel: "readings"}}, Steps: thresholdSteps()},
+			err:  re.ErrInvalidPipeline,
+		},
+		{
+			desc: "add rule with pipeline step without logic",
+			rule: re.Rule{Name: "pipeline", Steps: []re.Step{{Name: "empty"}}},
+			err:  re.ErrInvalidPipeline,
+		},
+		{
+			desc: "add rule with pipeline step requiring module not allowed",
+			rule: re.Rule{Name: "pipeline", Steps: []re.Step{{Logic: luaScript(`return require("ioutil") ~= nil`)}}},
+			err:  re.ErrModuleNotAllowed,
+		},
+		{
+			desc: "add rule with pipeline branch condition importing package not allowed",
+			rule: re.Rule{Name: "pipeline", Steps: []re.Step{{
+				Logic:    luaScript("return 1"),
+				Branches: []re.Branch{{Condition: goScript("import \"os\"\n\nfunc logicFunction() any { return os.Getenv(\"HOME\") != \"\" }")}},
+			}}},
+			err: re.ErrModuleNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("AddRule", mock.Anything, mock.Anything).Return(tc.rule, nil)
+			_, err := svc.AddRule(context.Background(), authn.Session{UserID: userID, DomainID: domainID}, tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("%s: expected malformed entity error got %s\n", tc.desc, err))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestTestRulePipeline(t *testing.T) {
+	svc, _, _ := newCachingService(t, nil, re.Config{}, make(chan pkglog.RunInfo, 1))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc    string
+		steps   []re.Step
+		payload string
+		res     any
+		results []re.StepResult
+		outputs []string
+		err     string
+	}{
+		{
+			desc:    "test pipeline taking the default branch",
+			steps:   thresholdSteps(),
+			payload: `{"temperature": 21.5}`,
+			res:     float64(70),
+			results: []re.StepResult{
+				{Step: 0, Name: "parse", Result: map[string]any{"celsius": 21.5}},
+				{Step: 1, Name: "convert", Result: map[string]any{"fahrenheit": 70.7}, Branch: branch(1)},
+				{Step: 2, Name: "round", Result: float64(70)},
+			},
+			outputs: []string{"readings"},
+		},
+		{
+			desc:    "test pipeline taking the branch which stops the pipeline",
+			steps:   thresholdSteps(),
+			payload: `{"temperature": 40}`,
+			res:     map[string]any{"fahrenheit": float64(104)},
+			results: []re.StepResult{
+				{Step: 0, Name: "parse", Result: map[string]any{"celsius": float64(40)}},
+				{Step: 1, Name: "convert", Result: map[string]any{"fahrenheit": float64(104)}, Branch: branch(0)},
+			},
+			outputs: []string{"alerts"},
+		},
+		{
+			desc: "test pipeline ending with false result",
+			steps: []re.Step{
+				{Logic: luaScript("return message.payload.temperature > 30")},
+				{Logic: luaScript("return 1")},
+			},
+			payload: `{"temperature": 21.5}`,
+			res:     false,
+			results: []re.StepResult{{Step: 0, Result: false}},
+		},
+		{
+			desc: "test pipeline with Go branch condition",
+			steps: []re.Step{
+				{
+					Logic: luaScript("return message.payload.temperature"),
+					Branches: []re.Branch{
+						{Condition: goScript("import m \"messaging\"\n\nfunc logicFunction() any { hot := m.result.(float64) > 30; return hot }"), Outputs: re.Outputs{&outputs.ChannelPublisher{Channel: "alerts"}}},
+						{Condition: goScript("import m \"messaging\"\n\nfunc logicFunction() any { hot := m.result.(float64) > 20; return hot }"), Outputs: re.Outputs{&outputs.ChannelPublisher{Channel: "readings"}}},
+					},
+				},
+			},
+			payload: `{"temperature": 25}`,
+			res:     float64(25),
+			results: []re.StepResult{{Step: 0, Result: float64(25), Branch: branch(1)}},
+			outputs: []string{"readings"},
+		},
+		{
+			desc: "test pipeline with step error",
+			steps: []re.Step{
+				{Name: "parse", Logic: luaScript("return message.payload.temperature")},
+				{Name: "fail", Logic: luaScript("error('invalid reading')")},
+			},
+			payload: `{"temperature": 25}`,
+			res:     float64(25),
+			results: []re.StepResult{{Step: 0, Name: "parse", Result: float64(25)}},
+			err:     "step 1 (fail)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := svc.TestRule(context.Background(), session, re.Rule{Steps: tc.steps}, &messaging.Message{Payload: []byte(tc.payload)})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != "" {
+				assert.Contains(t, res.Error, tc.err, fmt.Sprintf("%s: expected script error %q got %q", tc.desc, tc.err, res.Error))
+			} else {
+				assert.Empty(t, res.Error, fmt.Sprintf("%s: unexpected script error %s", tc.desc, res.Error))
+			}
+			assert.Equal(t, tc.res, res.Result)
+			assert.Equal(t, tc.results, res.Steps)
+			var channels []string
+			for _, o := range res.Outputs {
+				pld, ok := o.Payload.(map[string]any)
+				assert.True(t, ok, fmt.Sprintf("%s: unexpected output payload %v", tc.desc, o.Payload))
+				channels = append(channels, pld["channel"].(string))
+			}
+			assert.Equal(t, tc.outputs, channels)
+		})
+	}
+}
+
+func TestHandlePipeline(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	svc, repo, pubsub := newCachingService(t, nil, re.Config{}, ri)
+	rule := re.Rule{
+		ID:           testsutil.GenerateUUID(t),
+		DomainID:     domainID,
+		InputChannel: inputChannel,
+		Status:       re.EnabledStatus,
+		Steps:        thresholdSteps(),
+	}
+	repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+	repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	cases := []struct {
+		desc    string
+		payload string
+		channel string
+		value   any
+	}{
+		{
+			desc:    "handle message routed to the readings channel",
+			payload: `{"temperature": 21.5}`,
+			channel: "readings",
+			value:   map[string]any{"fahrenheit": 70.7},
+		},
+		{
+			desc:    "handle message routed to the alerts channel",
+			payload: `{"temperature": 40}`,
+			channel: "alerts",
+			value:   map[string]any{"fahrenheit": float64(104)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			published := make(chan *messaging.Message, 1)
+			pubsubCall := pubsub.On("Publish", mock.Anything, messaging.EncodeTopicSuffix(domainID, tc.channel, ""), mock.Anything).
+				Run(func(args mock.Arguments) { published <- args.Get(2).(*messaging.Message) }).
+				Return(nil).Once()
+			err := svc.Handle(&messaging.Message{Domain: domainID, Channel: inputChannel, Payload: []byte(tc.payload)})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			select {
+			case info := <-ri:
+				assert.Equal(t, slog.LevelInfo, info.Level, fmt.Sprintf("%s: unexpected run info %s", tc.desc, info.Message))
+			case <-time.After(2 * time.Second):
+				t.Fatal("rule was not processed")
+			}
+			msg := <-published
+			var value any
+			err = json.Unmarshal(msg.Payload, &value)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.value, value)
+			pubsubCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+)
+
+const (
+	defWorkers          = 100
+	defScriptsCacheSize = 1000
+	defExecutionsBatch  = 100
+	defExecutionsBuffer = 1000
+)
+
+// Config contains the rules processing configuration.
+type Config struct {
+	// Workers is the maximum number of rules processed concurrently.
+	Workers int
+	// WorkerWait is how long a message waits for a free worker before its rule
+	// run is dropped. Zero means waiting until a worker is free, which slows
+	// down the message consumption instead.
+	WorkerWait time.Duration
+	// ScriptsCacheSize is the maximum number of compiled scripts kept in memory.
+	ScriptsCacheSize int64
+	// ExecutionsBatch is the number of rule executions saved at once.
+	ExecutionsBatch int
+	// ExecutionsBuffer is the number of rule executions waiting to be saved.
+	// The executions of the runs finishing while the buffer is full are not saved.
+	ExecutionsBuffer int
+	// Sandbox limits the rule scripts.
+	Sandbox Sandbox
+	Metrics Metrics
+}
+
+// Metrics contains the rules processing metrics.
+type Metrics struct {
+	// Running is the number of rules being processed.
+	Running metrics.Gauge
+	// Wait is the time in seconds a rule run waited for a free worker.
+	Wait metrics.Histogram
+	// Dropped counts the rule runs dropped because no worker was free in time.
+	Dropped metrics.Counter
+	// Cache counts the rules index and scripts cache lookups,
+	// labeled with "cache" (rules, scripts) and "result" (hit, miss).
+	Cache metrics.Counter
+}
+
+func (c Config) withDefaults() Config {
+	if c.Workers <= 0 {
+		c.Workers = defWorkers
+	}
+	if c.ScriptsCacheSize <= 0 {
+		c.ScriptsCacheSize = defScriptsCacheSize
+	}
+	if c.ExecutionsBatch <= 0 {
+		c.ExecutionsBatch = defExecutionsBatch
+	}
+	if c.ExecutionsBuffer <= 0 {
+		c.ExecutionsBuffer = defExecutionsBuffer
+	}
+	c.Sandbox = c.Sandbox.withDefaults()
+	if c.Metrics.Running == nil {
+		c.Metrics.Running = discard.NewGauge()
+	}
+	if c.Metrics.Wait == nil {
+		c.Metrics.Wait = discard.NewHistogram()
+	}
+	if c.Metrics.Dropped == nil {
+		c.Metrics.Dropped = discard.NewCounter()
+	}
+	if c.Metrics.Cache == nil {
+		c.Metrics.Cache = discard.NewCounter()
+	}
+
+	return c
+}
+
+// workerPool limits the number of rules processed concurrently.
+type workerPool struct {
+	slots   chan struct{}
+	wait    time.Duration
+	metrics Metrics
+}
+
+func newWorkerPool(cfg Config) *workerPool {
+	return &workerPool{
+		slots:   make(chan struct{}, cfg.Workers),
+		wait:    cfg.WorkerWait,
+		metrics: cfg.Metrics,
+	}
+}
+
+// submit runs the job in a new goroutine once a worker is free. It blocks until
+// then, or returns false if no worker was free within the configured wait.
+func (p *workerPool) submit(job func()) bool {
+	start := time.Now()
+	if !p.acquire() {
+		p.metrics.Dropped.Add(1)
+		return false
+	}
+	p.metrics.Wait.Observe(time.Since(start).Seconds())
+	p.metrics.Running.Add(1)
+
+	go func() {
+		defer func() {
+			p.metrics.Running.Add(-1)
+			<-p.slots
+		}()
+		job()
+	}()
+
+	return true
+}
+
+func (p *workerPool) acquire() bool {
+	if p.wait <= 0 {
+		p.slots <- struct{}{}
+		return true
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	default:
+	}
+	t := time.NewTimer(p.wait)
+	defer t.Stop()
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-t.C:
+		return false
+	}
+}
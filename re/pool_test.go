@@ -0,0 +1,173 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const slowScript = `package main
+
+import "time"
+
+func logicFunction() any {
+	time.Sleep(200 * time.Millisecond)
+	return nil
+}`
+
+func TestHandleWorkerPool(t *testing.T) {
+	cases := []struct {
+		desc    string
+		wait    time.Duration
+		dropped bool
+	}{
+		{
+			desc: "handle message waiting for a free worker",
+		},
+		{
+			desc:    "handle message with no free worker in time",
+			wait:    10 * time.Millisecond,
+			dropped: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ri := make(chan pkglog.RunInfo, 10)
+			svc, repo, _ := newCachingService(t, nil, re.Config{Workers: 1, WorkerWait: tc.wait}, ri)
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        re.Script{Type: re.GoType, Value: slowScript},
+			}
+			repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			msg := &messaging.Message{Domain: domainID, Channel: inputChannel}
+			err := svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			start := time.Now()
+			err = svc.Handle(msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			elapsed := time.Since(start)
+
+			if tc.dropped {
+				assert.Less(t, elapsed, 100*time.Millisecond, fmt.Sprintf("%s: expected message not to wait for the worker", tc.desc))
+				info := <-ri
+				assert.Equal(t, slog.LevelError, info.Level)
+				assert.Contains(t, info.Message, "no free worker")
+				<-ri
+				return
+			}
+			assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond, fmt.Sprintf("%s: expected message to wait for the worker", tc.desc))
+			for range 2 {
+				info := <-ri
+				assert.NotContains(t, info.Message, "no free worker")
+			}
+		})
+	}
+}
+
+func TestHandleWithCachedScripts(t *testing.T) {
+	cases := []struct {
+		desc  string
+		logic re.Script
+	}{
+		{
+			desc:  "handle messages with cached Lua script",
+			logic: re.Script{Type: re.LuaType, Value: `return {value = message.payload.value, publisher = message.publisher}`},
+		},
+		{
+			desc: "handle messages with cached Go program",
+			logic: re.Script{
+				Type: re.GoType,
+				Value: `package main
+
+import m "messaging"
+
+func logicFunction() any {
+	pld := m.message.Payload.(map[string]any)
+	return map[string]any{"value": pld["value"], "publisher": m.message.Publisher}
+}`,
+			},
+		},
+		{
+			desc: "handle messages with Go program with package-level variables",
+			logic: re.Script{
+				Type: re.GoType,
+				// The value is multiplied by the number of runs, so it changes if runs are counted across messages.
+				Value: `package main
+
+import m "messaging"
+
+var runs float64
+
+func logicFunction() any {
+	runs++
+	pld := m.message.Payload.(map[string]any)
+	return map[string]any{"value": pld["value"].(float64) * runs, "publisher": m.message.Publisher}
+}`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ri := make(chan pkglog.RunInfo, 10)
+			svc, repo, pubsub := newCachingService(t, re.NewRuleIndex(time.Minute), re.Config{}, ri)
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        tc.logic,
+				Outputs:      re.Outputs{&outputs.ChannelPublisher{Channel: "output.channel"}},
+			}
+			published := make(chan *messaging.Message, 1)
+			repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				published <- args.Get(2).(*messaging.Message)
+			})
+
+			// Each run must see its own message, even though the script is compiled once.
+			for i := range 3 {
+				publisher := fmt.Sprintf("publisher-%d", i)
+				err := svc.Handle(&messaging.Message{
+					Domain:    domainID,
+					Channel:   inputChannel,
+					Publisher: publisher,
+					Payload:   fmt.Appendf(nil, `{"value": %d}`, i),
+				})
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				select {
+				case msg := <-published:
+					var res map[string]any
+					err := json.Unmarshal(msg.Payload, &res)
+					assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+					assert.Equal(t, map[string]any{"value": float64(i), "publisher": publisher}, res)
+				case <-time.After(time.Second):
+					t.Fatalf("%s: message was not published", tc.desc)
+				}
+				<-ri
+			}
+			repo.AssertNumberOfCalls(t, "ListAllRules", 1)
+		})
+	}
+}
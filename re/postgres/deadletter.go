@@ -0,0 +1,274 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/re"
+)
+
+const deadLetterColumns = `id, rule_id, domain_id, output_type, output, message, result, error, status, attempts,
+	next_retry_at, created_at, updated_at`
+
+// dbDeadLetter represents the database structure for a DeadLetter.
+type dbDeadLetter struct {
+	ID          string              `db:"id"`
+	RuleID      string              `db:"rule_id"`
+	DomainID    string              `db:"domain_id"`
+	OutputType  string              `db:"output_type"`
+	Output      []byte              `db:"output"`
+	Message     []byte              `db:"message"`
+	Result      []byte              `db:"result"`
+	Error       string              `db:"error"`
+	Status      re.DeadLetterStatus `db:"status"`
+	Attempts    uint                `db:"attempts"`
+	NextRetryAt sql.NullTime        `db:"next_retry_at"`
+	CreatedAt   time.Time           `db:"created_at"`
+	UpdatedAt   sql.NullTime        `db:"updated_at"`
+}
+
+func (repo *PostgresRepository) AddDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	q := `INSERT INTO rule_dead_letters (` + deadLetterColumns + `)
+		VALUES (:id, :rule_id, :domain_id, :output_type, :output, :message, :result, :error, :status, :attempts,
+			:next_retry_at, :created_at, :updated_at);`
+	dbdl, err := deadLetterToDb(dl)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	if _, err := repo.DB.NamedExecContext(ctx, q, dbdl); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) ViewDeadLetter(ctx context.Context, ruleID, id string) (re.DeadLetter, error) {
+	q := `SELECT ` + deadLetterColumns + ` FROM rule_dead_letters WHERE rule_id = $1 AND id = $2;`
+	row := repo.DB.QueryRowxContext(ctx, q, ruleID, id)
+	if err := row.Err(); err != nil {
+		return re.DeadLetter{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	var dbdl dbDeadLetter
+	if err := row.StructScan(&dbdl); err != nil {
+		if err == sql.ErrNoRows {
+			return re.DeadLetter{}, repoerr.ErrNotFound
+		}
+		return re.DeadLetter{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	ret, err := dbToDeadLetter(dbdl)
+	if err != nil {
+		return re.DeadLetter{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return ret, nil
+}
+
+func (repo *PostgresRepository) ListDeadLetters(ctx context.Context, pm re.DeadLetterPageMeta) (re.DeadLettersPage, error) {
+	where := "WHERE rule_id = :rule_id"
+	if pm.Status != re.AllDeadLetters {
+		where += " AND status = :status"
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM rule_dead_letters %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT :limit OFFSET :offset;`, deadLetterColumns, where)
+	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return re.DeadLettersPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	dls, err := scanDeadLetters(rows.Next, rows.StructScan)
+	if err != nil {
+		return re.DeadLettersPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM rule_dead_letters %s;`, where)
+	total, err := postgres.Total(ctx, repo.DB, cq, pm)
+	if err != nil {
+		return re.DeadLettersPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return re.DeadLettersPage{
+		Total:       total,
+		Offset:      pm.Offset,
+		Limit:       pm.Limit,
+		DeadLetters: dls,
+	}, nil
+}
+
+func (repo *PostgresRepository) UpdateDeadLetter(ctx context.Context, dl re.DeadLetter) error {
+	q := `UPDATE rule_dead_letters
+		SET error = :error, status = :status, attempts = :attempts, next_retry_at = :next_retry_at, updated_at = :updated_at
+		WHERE id = :id;`
+	dbdl, err := deadLetterToDb(dl)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	res, err := repo.DB.NamedExecContext(ctx, q, dbdl)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *PostgresRepository) ClaimDeadLetters(ctx context.Context, due, until time.Time, limit uint64) ([]re.DeadLetter, error) {
+	// Skip the rows locked by concurrent claims, so each dead letter is claimed once.
+	// Retrying dead letters are claimed again once their claim expired.
+	q := `UPDATE rule_dead_letters SET status = $5, next_retry_at = $2
+		WHERE id IN (
+			SELECT id FROM rule_dead_letters
+			WHERE status IN ($3, $5) AND next_retry_at <= $1
+			ORDER BY next_retry_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deadLetterColumns + `;`
+	rows, err := repo.DB.QueryxContext(ctx, q, due, until, re.PendingDeadLetter, limit, re.RetryingDeadLetter)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	dls, err := scanDeadLetters(rows.Next, rows.StructScan)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return dls, nil
+}
+
+func (repo *PostgresRepository) ClaimDeadLetter(ctx context.Context, ruleID, id string, now, until time.Time) (re.DeadLetter, error) {
+	q := `UPDATE rule_dead_letters SET status = $5, next_retry_at = $4
+		WHERE rule_id = $1 AND id = $2
+			AND (status IN ($6, $7) OR (status = $5 AND next_retry_at <= $3))
+		RETURNING ` + deadLetterColumns + `;`
+	row := repo.DB.QueryRowxContext(ctx, q, ruleID, id, now, until, re.RetryingDeadLetter, re.PendingDeadLetter, re.FailedDeadLetter)
+	if err := row.Err(); err != nil {
+		return re.DeadLetter{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	var dbdl dbDeadLetter
+	if err := row.StructScan(&dbdl); err != nil {
+		if err == sql.ErrNoRows {
+			return re.DeadLetter{}, repoerr.ErrNotFound
+		}
+		return re.DeadLetter{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	dl, err := dbToDeadLetter(dbdl)
+	if err != nil {
+		return re.DeadLetter{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return dl, nil
+}
+
+func (repo *PostgresRepository) RemoveDeadLetters(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM rule_dead_letters WHERE status IN ($2, $3) AND COALESCE(updated_at, created_at) < $1;`
+	if _, err := repo.DB.ExecContext(ctx, q, before, re.DeliveredDeadLetter, re.FailedDeadLetter); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func scanDeadLetters(next func() bool, scan func(dest any) error) ([]re.DeadLetter, error) {
+	dls := []re.DeadLetter{}
+	for next() {
+		var dbdl dbDeadLetter
+		if err := scan(&dbdl); err != nil {
+			return nil, err
+		}
+		dl, err := dbToDeadLetter(dbdl)
+		if err != nil {
+			return nil, err
+		}
+		dls = append(dls, dl)
+	}
+
+	return dls, nil
+}
+
+func deadLetterToDb(dl re.DeadLetter) (dbDeadLetter, error) {
+	msg, err := json.Marshal(dl.Message)
+	if err != nil {
+		return dbDeadLetter{}, err
+	}
+	var result []byte
+	if dl.Result != nil {
+		if result, err = json.Marshal(dl.Result); err != nil {
+			return dbDeadLetter{}, err
+		}
+	}
+	var nextRetry sql.NullTime
+	if dl.NextRetryAt != nil {
+		nextRetry = sql.NullTime{Time: *dl.NextRetryAt, Valid: true}
+	}
+	var updatedAt sql.NullTime
+	if !dl.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: dl.UpdatedAt, Valid: true}
+	}
+
+	return dbDeadLetter{
+		ID:          dl.ID,
+		RuleID:      dl.RuleID,
+		DomainID:    dl.DomainID,
+		OutputType:  dl.OutputType,
+		Output:      dl.Output,
+		Message:     msg,
+		Result:      result,
+		Error:       dl.Error,
+		Status:      dl.Status,
+		Attempts:    dl.Attempts,
+		NextRetryAt: nextRetry,
+		CreatedAt:   dl.CreatedAt,
+		UpdatedAt:   updatedAt,
+	}, nil
+}
+
+func dbToDeadLetter(dbdl dbDeadLetter) (re.DeadLetter, error) {
+	var msg re.DeadLetterMessage
+	if err := json.Unmarshal(dbdl.Message, &msg); err != nil {
+		return re.DeadLetter{}, err
+	}
+	var result any
+	if len(dbdl.Result) > 0 {
+		if err := json.Unmarshal(dbdl.Result, &result); err != nil {
+			return re.DeadLetter{}, err
+		}
+	}
+	dl := re.DeadLetter{
+		ID:         dbdl.ID,
+		RuleID:     dbdl.RuleID,
+		DomainID:   dbdl.DomainID,
+		OutputType: dbdl.OutputType,
+		Output:     dbdl.Output,
+		Message:    msg,
+		Result:     result,
+		Error:      dbdl.Error,
+		Status:     dbdl.Status,
+		Attempts:   dbdl.Attempts,
+		CreatedAt:  dbdl.CreatedAt,
+	}
+	if dbdl.NextRetryAt.Valid {
+		t := dbdl.NextRetryAt.Time
+		dl.NextRetryAt = &t
+	}
+	if dbdl.UpdatedAt.Valid {
+		dl.UpdatedAt = dbdl.UpdatedAt.Time
+	}
+
+	return dl, nil
+}
@@ -0,0 +1,469 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+// Output is stored as JSONB, so it is compared in the normalized form Postgres returns.
+var deadLetterOutput = json.RawMessage(`{"type": "channels", "channel": "output.channel"}`)
+
+func TestAddDeadLetter(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	dl := newDeadLetter(t, rule, time.Now().UTC().Truncate(time.Microsecond))
+
+	cases := []struct {
+		desc       string
+		deadLetter re.DeadLetter
+		err        error
+	}{
+		{
+			desc:       "add dead letter successfully",
+			deadLetter: dl,
+		},
+		{
+			desc:       "add dead letter with existing id",
+			deadLetter: dl,
+			err:        repoerr.ErrConflict,
+		},
+		{
+			desc: "add dead letter of non-existing rule",
+			deadLetter: func() re.DeadLetter {
+				d := newDeadLetter(t, rule, dl.CreatedAt)
+				d.RuleID = generateUUID(t)
+				return d
+			}(),
+			err: repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.AddDeadLetter(context.Background(), tc.deadLetter)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestViewDeadLetter(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	dl := newDeadLetter(t, rule, time.Now().UTC().Truncate(time.Microsecond))
+	err := repo.AddDeadLetter(context.Background(), dl)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		ruleID   string
+		id       string
+		response re.DeadLetter
+		err      error
+	}{
+		{
+			desc:     "view dead letter successfully",
+			ruleID:   rule.ID,
+			id:       dl.ID,
+			response: dl,
+		},
+		{
+			desc:   "view dead letter of another rule",
+			ruleID: generateUUID(t),
+			id:     dl.ID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "view non-existing dead letter",
+			ruleID: rule.ID,
+			id:     generateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := repo.ViewDeadLetter(context.Background(), tc.ruleID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, d, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, d))
+		})
+	}
+}
+
+func TestListDeadLetters(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	num := 10
+	desc := make([]re.DeadLetter, num)
+	var failed []re.DeadLetter
+	for i := range num {
+		dl := newDeadLetter(t, rule, now.Add(time.Duration(i)*time.Second))
+		if i%3 == 0 {
+			dl.Status = re.FailedDeadLetter
+			dl.NextRetryAt = nil
+		}
+		err := repo.AddDeadLetter(context.Background(), dl)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		// Dead letters are listed from the newest.
+		desc[num-1-i] = dl
+	}
+	for _, dl := range desc {
+		if dl.Status == re.FailedDeadLetter {
+			failed = append(failed, dl)
+		}
+	}
+
+	cases := []struct {
+		desc     string
+		pm       re.DeadLetterPageMeta
+		response re.DeadLettersPage
+	}{
+		{
+			desc: "list dead letters successfully",
+			pm:   re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 5, Status: re.AllDeadLetters},
+			response: re.DeadLettersPage{
+				Total:       uint64(num),
+				Limit:       5,
+				DeadLetters: desc[:5],
+			},
+		},
+		{
+			desc: "list dead letters with offset",
+			pm:   re.DeadLetterPageMeta{RuleID: rule.ID, Offset: 8, Limit: 5, Status: re.AllDeadLetters},
+			response: re.DeadLettersPage{
+				Total:       uint64(num),
+				Offset:      8,
+				Limit:       5,
+				DeadLetters: desc[8:],
+			},
+		},
+		{
+			desc: "list failed dead letters",
+			pm:   re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 10, Status: re.FailedDeadLetter},
+			response: re.DeadLettersPage{
+				Total:       uint64(len(failed)),
+				Limit:       10,
+				DeadLetters: failed,
+			},
+		},
+		{
+			desc: "list delivered dead letters",
+			pm:   re.DeadLetterPageMeta{RuleID: rule.ID, Limit: 10, Status: re.DeliveredDeadLetter},
+			response: re.DeadLettersPage{
+				Limit:       10,
+				DeadLetters: []re.DeadLetter{},
+			},
+		},
+		{
+			desc: "list dead letters of non-existing rule",
+			pm:   re.DeadLetterPageMeta{RuleID: generateUUID(t), Limit: 10, Status: re.AllDeadLetters},
+			response: re.DeadLettersPage{
+				Limit:       10,
+				DeadLetters: []re.DeadLetter{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListDeadLetters(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+		})
+	}
+}
+
+func TestUpdateDeadLetter(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	dl := newDeadLetter(t, rule, now)
+	err := repo.AddDeadLetter(context.Background(), dl)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	delivered := dl
+	delivered.Status = re.DeliveredDeadLetter
+	delivered.Attempts = 2
+	delivered.NextRetryAt = nil
+	delivered.UpdatedAt = now.Add(time.Minute)
+
+	cases := []struct {
+		desc       string
+		deadLetter re.DeadLetter
+		err        error
+	}{
+		{
+			desc:       "update dead letter successfully",
+			deadLetter: delivered,
+		},
+		{
+			desc: "update non-existing dead letter",
+			deadLetter: func() re.DeadLetter {
+				d := delivered
+				d.ID = generateUUID(t)
+				return d
+			}(),
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.UpdateDeadLetter(context.Background(), tc.deadLetter)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				d, err := repo.ViewDeadLetter(context.Background(), rule.ID, tc.deadLetter.ID)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				assert.Equal(t, tc.deadLetter, d, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.deadLetter, d))
+			}
+		})
+	}
+}
+
+func TestClaimDeadLetters(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := range 6 {
+		dl := newDeadLetter(t, rule, now)
+		next := now.Add(time.Duration(i-3) * time.Minute)
+		dl.NextRetryAt = &next
+		if i == 0 {
+			dl.Status = re.FailedDeadLetter
+			dl.NextRetryAt = nil
+		}
+		err := repo.AddDeadLetter(context.Background(), dl)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	until := now.Add(5 * time.Minute)
+
+	cases := []struct {
+		desc  string
+		due   time.Time
+		until time.Time
+		limit uint64
+		count int
+	}{
+		{
+			desc:  "claim due dead letters with limit",
+			due:   now,
+			until: until,
+			limit: 1,
+			count: 1,
+		},
+		{
+			desc:  "claim remaining due dead letters",
+			due:   now,
+			until: until,
+			limit: 10,
+			count: 2,
+		},
+		{
+			desc:  "claim already claimed dead letters",
+			due:   now,
+			until: until,
+			limit: 10,
+			count: 0,
+		},
+		{
+			desc:  "claim dead letters with expired claims",
+			due:   until,
+			until: until.Add(5 * time.Minute),
+			limit: 10,
+			count: 5,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dls, err := repo.ClaimDeadLetters(context.Background(), tc.due, tc.until, tc.limit)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Len(t, dls, tc.count, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.count, len(dls)))
+			for _, dl := range dls {
+				assert.Equal(t, re.RetryingDeadLetter, dl.Status)
+				assert.Equal(t, tc.until, *dl.NextRetryAt)
+			}
+		})
+	}
+}
+
+func TestClaimDeadLetter(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	until := now.Add(5 * time.Minute)
+
+	addDeadLetter := func(status re.DeadLetterStatus, next time.Time) re.DeadLetter {
+		dl := newDeadLetter(t, rule, now)
+		dl.Status = status
+		dl.NextRetryAt = &next
+		err := repo.AddDeadLetter(context.Background(), dl)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		return dl
+	}
+	pending := addDeadLetter(re.PendingDeadLetter, now.Add(time.Hour))
+	failed := addDeadLetter(re.FailedDeadLetter, now)
+	delivered := addDeadLetter(re.DeliveredDeadLetter, now)
+	retrying := addDeadLetter(re.RetryingDeadLetter, now.Add(time.Minute))
+	expired := addDeadLetter(re.RetryingDeadLetter, now.Add(-time.Minute))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "claim pending dead letter",
+			id:   pending.ID,
+		},
+		{
+			desc: "claim failed dead letter",
+			id:   failed.ID,
+		},
+		{
+			desc: "claim dead letter with expired claim",
+			id:   expired.ID,
+		},
+		{
+			desc: "claim claimed dead letter",
+			id:   pending.ID,
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "claim dead letter being retried",
+			id:   retrying.ID,
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "claim delivered dead letter",
+			id:   delivered.ID,
+			err:  repoerr.ErrNotFound,
+		},
+		{
+			desc: "claim non-existing dead letter",
+			id:   generateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dl, err := repo.ClaimDeadLetter(context.Background(), rule.ID, tc.id, now, until)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.id, dl.ID)
+				assert.Equal(t, re.RetryingDeadLetter, dl.Status)
+				assert.Equal(t, until, *dl.NextRetryAt)
+			}
+		})
+	}
+}
+
+func TestRemoveDeadLetters(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	old := now.Add(-time.Hour)
+
+	var kept []string
+	for _, status := range []re.DeadLetterStatus{re.PendingDeadLetter, re.FailedDeadLetter, re.DeliveredDeadLetter, re.RetryingDeadLetter} {
+		dl := newDeadLetter(t, rule, old)
+		dl.Status = status
+		err := repo.AddDeadLetter(context.Background(), dl)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if status == re.PendingDeadLetter || status == re.RetryingDeadLetter {
+			kept = append(kept, dl.ID)
+		}
+	}
+	recent := newDeadLetter(t, rule, old)
+	recent.Status = re.FailedDeadLetter
+	recent.UpdatedAt = now
+	err := repo.AddDeadLetter(context.Background(), recent)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	kept = append(kept, recent.ID)
+
+	err = repo.RemoveDeadLetters(context.Background(), now.Add(-time.Minute))
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	page, err := repo.ListDeadLetters(context.Background(), re.DeadLetterPageMeta{RuleID: rule.ID, Status: re.AllDeadLetters, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var ids []string
+	for _, dl := range page.DeadLetters {
+		ids = append(ids, dl.ID)
+	}
+	assert.ElementsMatch(t, kept, ids, "expected only pending, retrying and recently attempted dead letters to be kept")
+}
+
+func newDeadLetter(t *testing.T, rule re.Rule, created time.Time) re.DeadLetter {
+	next := created.Add(time.Minute)
+	return re.DeadLetter{
+		ID:         generateUUID(t),
+		RuleID:     rule.ID,
+		DomainID:   rule.DomainID,
+		OutputType: "channels",
+		Output:     deadLetterOutput,
+		Message: re.DeadLetterMessage{
+			Channel:   rule.InputChannel,
+			Subtopic:  "temperature",
+			Publisher: generateUUID(t),
+			Protocol:  "mqtt",
+			Payload:   []byte(`{"temperature": 20}`),
+			Created:   created.UnixNano(),
+		},
+		Result:      map[string]any{"temperature": float64(20)},
+		Error:       "failed to publish message",
+		Status:      re.PendingDeadLetter,
+		Attempts:    1,
+		NextRetryAt: &next,
+		CreatedAt:   created,
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/absmach/magistrala/api/http"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/re"
+	"github.com/jackc/pgtype"
+	"github.com/jmoiron/sqlx"
+)
+
+// maxExecutionsInsert bounds the rows of an executions insert, so it stays
+// below the PostgreSQL limit of 65535 parameters of a statement.
+const maxExecutionsInsert = 5000
+
+// dbExecution represents the database structure for a rule Execution.
+type dbExecution struct {
+	ID         string           `db:"id"`
+	RuleID     string           `db:"rule_id"`
+	DomainID   string           `db:"domain_id"`
+	Channel    sql.NullString   `db:"channel"`
+	Subtopic   sql.NullString   `db:"subtopic"`
+	Publisher  sql.NullString   `db:"publisher"`
+	Protocol   sql.NullString   `db:"protocol"`
+	Level      string           `db:"level"`
+	Message    sql.NullString   `db:"message"`
+	Error      sql.NullString   `db:"error"`
+	Outputs    pgtype.TextArray `db:"outputs"`
+	Duration   int64            `db:"duration"`
+	ExecutedAt time.Time        `db:"executed_at"`
+}
+
+// AddExecutions stores the executions and updates the run counters of their
+// rules in one transaction. The rules are locked against removal, and the
+// executions of the rules removed since they ran are skipped, so they do not
+// fail the batch. The counters of each rule are updated once per batch, in
+// the order of the rule IDs, so concurrent batches lock the rules in the same
+// order.
+func (repo *PostgresRepository) AddExecutions(ctx context.Context, executions []re.Execution) error {
+	if len(executions) == 0 {
+		return nil
+	}
+	q := `INSERT INTO rule_executions (id, rule_id, domain_id, channel, subtopic, publisher, protocol,
+			level, message, error, outputs, duration, executed_at)
+		VALUES (:id, :rule_id, :domain_id, :channel, :subtopic, :publisher, :protocol,
+			:level, :message, :error, :outputs, :duration, :executed_at);`
+
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	rules, err := lockRules(ctx, tx, executions)
+	if err != nil {
+		return rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+	}
+
+	dbes := make([]dbExecution, 0, len(executions))
+	stats := make(map[string]*re.ExecutionStats)
+	for _, e := range executions {
+		if _, ok := rules[e.RuleID]; !ok {
+			continue
+		}
+		dbe, err := executionToDb(e)
+		if err != nil {
+			return rollback(tx, errors.Wrap(repoerr.ErrCreateEntity, err))
+		}
+		dbes = append(dbes, dbe)
+		st, ok := stats[e.RuleID]
+		if !ok {
+			st = &re.ExecutionStats{}
+			stats[e.RuleID] = st
+		}
+		switch e.Failed() {
+		case true:
+			st.FailureCount++
+		default:
+			st.SuccessCount++
+		}
+		if st.LastRunAt == nil || e.ExecutedAt.After(*st.LastRunAt) {
+			st.LastRunAt = &e.ExecutedAt
+		}
+	}
+
+	for start := 0; start < len(dbes); start += maxExecutionsInsert {
+		end := min(start+maxExecutionsInsert, len(dbes))
+		if _, err := tx.NamedExecContext(ctx, q, dbes[start:end]); err != nil {
+			return rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+		}
+	}
+
+	uq := `UPDATE rules
+		SET success_count = success_count + $2, failure_count = failure_count + $3,
+			last_run_at = GREATEST(last_run_at, $4)
+		WHERE id = $1;`
+	ids := slices.Sorted(maps.Keys(stats))
+	for _, id := range ids {
+		st := stats[id]
+		if _, err := tx.ExecContext(ctx, uq, id, st.SuccessCount, st.FailureCount, *st.LastRunAt); err != nil {
+			return rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+// lockRules locks the existing rules of the executions against removal until
+// the transaction ends, in the order of their IDs, and returns their IDs.
+func lockRules(ctx context.Context, tx *sqlx.Tx, executions []re.Execution) (map[string]struct{}, error) {
+	ids := make(map[string]struct{})
+	for _, e := range executions {
+		ids[e.RuleID] = struct{}{}
+	}
+	q := `SELECT id FROM rules WHERE id = ANY($1) ORDER BY id FOR KEY SHARE;`
+	rows, err := tx.QueryxContext(ctx, q, slices.Sorted(maps.Keys(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make(map[string]struct{})
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		rules[id] = struct{}{}
+	}
+
+	return rules, rows.Err()
+}
+
+func (repo *PostgresRepository) ListExecutions(ctx context.Context, pm re.ExecutionPageMeta) (re.ExecutionsPage, error) {
+	conditions := []string{"rule_id = :rule_id"}
+	if pm.Level != "" {
+		conditions = append(conditions, "level = :level")
+	}
+	where := fmt.Sprintf("WHERE %s", strings.Join(conditions, " AND "))
+
+	dir := api.DescDir
+	if pm.Dir == api.AscDir {
+		dir = api.AscDir
+	}
+
+	q := fmt.Sprintf(`
+		SELECT id, rule_id, domain_id, channel, subtopic, publisher, protocol, level, message, error,
+			outputs, duration, executed_at
+		FROM rule_executions %s
+		ORDER BY executed_at %s, id %s
+		LIMIT :limit OFFSET :offset;
+	`, where, dir, dir)
+	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return re.ExecutionsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	executions := []re.Execution{}
+	for rows.Next() {
+		var dbe dbExecution
+		if err := rows.StructScan(&dbe); err != nil {
+			return re.ExecutionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		executions = append(executions, dbToExecution(dbe))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM rule_executions %s;`, where)
+	total, err := postgres.Total(ctx, repo.DB, cq, pm)
+	if err != nil {
+		return re.ExecutionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return re.ExecutionsPage{
+		Total:      total,
+		Offset:     pm.Offset,
+		Limit:      pm.Limit,
+		Executions: executions,
+	}, nil
+}
+
+func (repo *PostgresRepository) RemoveExecutions(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM rule_executions WHERE executed_at < $1;`
+	if _, err := repo.DB.ExecContext(ctx, q, before); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func executionToDb(e re.Execution) (dbExecution, error) {
+	var outputs pgtype.TextArray
+	if err := outputs.Set(e.Outputs); err != nil {
+		return dbExecution{}, err
+	}
+
+	return dbExecution{
+		ID:         e.ID,
+		RuleID:     e.RuleID,
+		DomainID:   e.DomainID,
+		Channel:    toNullString(e.Channel),
+		Subtopic:   toNullString(e.Subtopic),
+		Publisher:  toNullString(e.Publisher),
+		Protocol:   toNullString(e.Protocol),
+		Level:      e.Level,
+		Message:    toNullString(e.Message),
+		Error:      toNullString(e.Error),
+		Outputs:    outputs,
+		Duration:   int64(e.Duration),
+		ExecutedAt: e.ExecutedAt,
+	}, nil
+}
+
+func dbToExecution(dbe dbExecution) re.Execution {
+	var outputs []string
+	for _, e := range dbe.Outputs.Elements {
+		outputs = append(outputs, e.String)
+	}
+
+	return re.Execution{
+		ID:         dbe.ID,
+		RuleID:     dbe.RuleID,
+		DomainID:   dbe.DomainID,
+		Channel:    fromNullString(dbe.Channel),
+		Subtopic:   fromNullString(dbe.Subtopic),
+		Publisher:  fromNullString(dbe.Publisher),
+		Protocol:   fromNullString(dbe.Protocol),
+		Level:      dbe.Level,
+		Message:    fromNullString(dbe.Message),
+		Error:      fromNullString(dbe.Error),
+		Outputs:    outputs,
+		Duration:   time.Duration(dbe.Duration),
+		ExecutedAt: dbe.ExecutedAt,
+	}
+}
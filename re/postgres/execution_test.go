@@ -0,0 +1,361 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddExecutions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	later := now.Add(time.Minute)
+	id := generateUUID(t)
+
+	cases := []struct {
+		desc       string
+		executions []re.Execution
+		stats      re.ExecutionStats
+		err        error
+	}{
+		{
+			desc: "add successful execution",
+			executions: []re.Execution{{
+				ID:         id,
+				RuleID:     rule.ID,
+				DomainID:   rule.DomainID,
+				Channel:    rule.InputChannel,
+				Level:      slog.LevelInfo.String(),
+				Message:    "rule processed successfully",
+				Outputs:    []string{"channels"},
+				Duration:   time.Millisecond,
+				ExecutedAt: now,
+			}},
+			stats: re.ExecutionStats{SuccessCount: 1, LastRunAt: &now},
+		},
+		{
+			desc: "add failed execution",
+			executions: []re.Execution{{
+				ID:         generateUUID(t),
+				RuleID:     rule.ID,
+				DomainID:   rule.DomainID,
+				Level:      slog.LevelError.String(),
+				Error:      "failed to run rule logic",
+				ExecutedAt: now.Add(-time.Minute),
+			}},
+			stats: re.ExecutionStats{SuccessCount: 1, FailureCount: 1, LastRunAt: &now},
+		},
+		{
+			desc: "add batch of executions",
+			executions: []re.Execution{
+				{
+					ID:         generateUUID(t),
+					RuleID:     rule.ID,
+					DomainID:   rule.DomainID,
+					Level:      slog.LevelInfo.String(),
+					ExecutedAt: now.Add(time.Minute),
+				},
+				{
+					ID:         generateUUID(t),
+					RuleID:     rule.ID,
+					DomainID:   rule.DomainID,
+					Level:      slog.LevelInfo.String(),
+					ExecutedAt: now,
+				},
+				{
+					ID:         generateUUID(t),
+					RuleID:     rule.ID,
+					DomainID:   rule.DomainID,
+					Level:      slog.LevelError.String(),
+					Error:      "failed to run rule logic",
+					ExecutedAt: now,
+				},
+			},
+			stats: re.ExecutionStats{SuccessCount: 3, FailureCount: 2, LastRunAt: &later},
+		},
+		{
+			desc:  "add empty batch of executions",
+			stats: re.ExecutionStats{SuccessCount: 3, FailureCount: 2, LastRunAt: &later},
+		},
+		{
+			desc: "add execution of non-existing rule",
+			executions: []re.Execution{{
+				ID:         generateUUID(t),
+				RuleID:     generateUUID(t),
+				DomainID:   rule.DomainID,
+				Level:      slog.LevelInfo.String(),
+				ExecutedAt: now,
+			}},
+			stats: re.ExecutionStats{SuccessCount: 3, FailureCount: 2, LastRunAt: &later},
+		},
+		{
+			desc: "add execution with existing id",
+			executions: []re.Execution{{
+				ID:         id,
+				RuleID:     rule.ID,
+				DomainID:   rule.DomainID,
+				Level:      slog.LevelInfo.String(),
+				ExecutedAt: now,
+			}},
+			stats: re.ExecutionStats{SuccessCount: 3, FailureCount: 2, LastRunAt: &later},
+			err:   repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.AddExecutions(context.Background(), tc.executions)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			r, err := repo.ViewRule(context.Background(), rule.ID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, &tc.stats, r.Stats, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.stats, r.Stats))
+		})
+	}
+}
+
+func TestAddExecutionsOfRemovedRule(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	removed := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	executions := []re.Execution{
+		{
+			ID:         generateUUID(t),
+			RuleID:     rule.ID,
+			DomainID:   rule.DomainID,
+			Level:      slog.LevelInfo.String(),
+			ExecutedAt: now,
+		},
+		{
+			ID:         generateUUID(t),
+			RuleID:     removed.ID,
+			DomainID:   removed.DomainID,
+			Level:      slog.LevelInfo.String(),
+			ExecutedAt: now,
+		},
+	}
+
+	// The rule is removed while its executions are buffered.
+	err := repo.RemoveRule(context.Background(), removed.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove rule unexpected error: %s", err))
+
+	err = repo.AddExecutions(context.Background(), executions)
+	assert.Nil(t, err, fmt.Sprintf("add executions unexpected error: %s", err))
+	r, err := repo.ViewRule(context.Background(), rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("view rule unexpected error: %s", err))
+	assert.Equal(t, &re.ExecutionStats{SuccessCount: 1, LastRunAt: &now}, r.Stats)
+
+	page, err := repo.ListExecutions(context.Background(), re.ExecutionPageMeta{RuleID: rule.ID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list executions unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total)
+	page, err = repo.ListExecutions(context.Background(), re.ExecutionPageMeta{RuleID: removed.ID, Limit: 10})
+	assert.Nil(t, err, fmt.Sprintf("list executions unexpected error: %s", err))
+	assert.Equal(t, uint64(0), page.Total)
+}
+
+func TestListExecutions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	num := 20
+	var executions []re.Execution
+	for i := range num {
+		e := re.Execution{
+			ID:         generateUUID(t),
+			RuleID:     rule.ID,
+			DomainID:   rule.DomainID,
+			Channel:    rule.InputChannel,
+			Subtopic:   "temperature",
+			Publisher:  generateUUID(t),
+			Protocol:   "mqtt",
+			Level:      slog.LevelInfo.String(),
+			Message:    "rule processed successfully",
+			Outputs:    []string{"channels", "email"},
+			Duration:   time.Duration(i) * time.Millisecond,
+			ExecutedAt: now.Add(time.Duration(i) * time.Second),
+		}
+		if i%4 == 0 {
+			e.Level = slog.LevelError.String()
+			e.Message = ""
+			e.Error = "failed to handle rule output"
+		}
+		err := repo.AddExecutions(context.Background(), []re.Execution{e})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		executions = append(executions, e)
+	}
+	// Executions are listed from the newest by default.
+	desc := make([]re.Execution, num)
+	for i, e := range executions {
+		desc[num-1-i] = e
+	}
+	var failed []re.Execution
+	for _, e := range desc {
+		if e.Failed() {
+			failed = append(failed, e)
+		}
+	}
+
+	cases := []struct {
+		desc     string
+		pm       re.ExecutionPageMeta
+		response re.ExecutionsPage
+		err      error
+	}{
+		{
+			desc: "list executions successfully",
+			pm:   re.ExecutionPageMeta{RuleID: rule.ID, Limit: 5},
+			response: re.ExecutionsPage{
+				Total:      uint64(num),
+				Limit:      5,
+				Executions: desc[:5],
+			},
+		},
+		{
+			desc: "list executions with offset",
+			pm:   re.ExecutionPageMeta{RuleID: rule.ID, Offset: 15, Limit: 10},
+			response: re.ExecutionsPage{
+				Total:      uint64(num),
+				Offset:     15,
+				Limit:      10,
+				Executions: desc[15:],
+			},
+		},
+		{
+			desc: "list executions in ascending order",
+			pm:   re.ExecutionPageMeta{RuleID: rule.ID, Limit: 3, Dir: ascDir},
+			response: re.ExecutionsPage{
+				Total:      uint64(num),
+				Limit:      3,
+				Executions: executions[:3],
+			},
+		},
+		{
+			desc: "list failed executions",
+			pm:   re.ExecutionPageMeta{RuleID: rule.ID, Limit: 10, Level: slog.LevelError.String()},
+			response: re.ExecutionsPage{
+				Total:      uint64(len(failed)),
+				Limit:      10,
+				Executions: failed,
+			},
+		},
+		{
+			desc: "list executions of non-existing rule",
+			pm:   re.ExecutionPageMeta{RuleID: generateUUID(t), Limit: 10},
+			response: re.ExecutionsPage{
+				Limit:      10,
+				Executions: []re.Execution{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListExecutions(context.Background(), tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+		})
+	}
+}
+
+func TestRemoveExecutions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := range 10 {
+		e := re.Execution{
+			ID:         generateUUID(t),
+			RuleID:     rule.ID,
+			DomainID:   rule.DomainID,
+			Level:      slog.LevelInfo.String(),
+			ExecutedAt: now.Add(-time.Duration(i) * time.Hour),
+		}
+		err := repo.AddExecutions(context.Background(), []re.Execution{e})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		before time.Time
+		total  uint64
+	}{
+		{
+			desc:   "remove executions older than retention",
+			before: now.Add(-5*time.Hour - time.Minute),
+			total:  6,
+		},
+		{
+			desc:   "remove executions with nothing to remove",
+			before: now.Add(-24 * time.Hour),
+			total:  6,
+		},
+		{
+			desc:   "remove all executions",
+			before: now.Add(time.Minute),
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.RemoveExecutions(context.Background(), tc.before)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			page, err := repo.ListExecutions(context.Background(), re.ExecutionPageMeta{RuleID: rule.ID, Limit: 100})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+		})
+	}
+}
+
+func addRule(t *testing.T, repo re.Repository) re.Rule {
+	rule := re.Rule{
+		ID:           generateUUID(t),
+		Name:         namegen.Generate(),
+		DomainID:     generateUUID(t),
+		InputChannel: generateUUID(t),
+		Logic: re.Script{
+			Type:  re.LuaType,
+			Value: "return true",
+		},
+		Status:    re.EnabledStatus,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: generateUUID(t),
+	}
+	rule, err := repo.AddRule(context.Background(), rule)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return rule
+}
@@ -127,6 +127,124 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 						WHERE jsonb_typeof(r.outputs) = 'array'`,
 				},
 			},
+			{
+				Id: "rules_06",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rule_executions (
+						id          VARCHAR(36) PRIMARY KEY,
+						rule_id     VARCHAR(36) NOT NULL REFERENCES rules(id) ON DELETE CASCADE,
+						domain_id   VARCHAR(36) NOT NULL,
+						channel     VARCHAR(36),
+						subtopic    TEXT,
+						publisher   VARCHAR(36),
+						protocol    VARCHAR(36),
+						level       VARCHAR(16) NOT NULL,
+						message     TEXT,
+						error       TEXT,
+						outputs     TEXT[],
+						duration    BIGINT NOT NULL DEFAULT 0,
+						executed_at TIMESTAMP NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_rule_executions_rule_id ON rule_executions (rule_id, executed_at DESC)`,
+					`CREATE INDEX IF NOT EXISTS idx_rule_executions_executed_at ON rule_executions (executed_at)`,
+					`ALTER TABLE rules
+						ADD COLUMN success_count BIGINT NOT NULL DEFAULT 0,
+						ADD COLUMN failure_count BIGINT NOT NULL DEFAULT 0,
+						ADD COLUMN last_run_at   TIMESTAMP`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rule_executions`,
+					`ALTER TABLE rules
+						DROP COLUMN success_count,
+						DROP COLUMN failure_count,
+						DROP COLUMN last_run_at`,
+				},
+			},
+			{
+				Id: "rules_07",
+				Up: []string{
+					`ALTER TABLE rules
+						ADD COLUMN cron     TEXT NOT NULL DEFAULT '',
+						ADD COLUMN timezone TEXT NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					`ALTER TABLE rules
+						DROP COLUMN cron,
+						DROP COLUMN timezone`,
+				},
+			},
+			{
+				Id: "rules_08",
+				Up: []string{
+					`ALTER TABLE rules ADD COLUMN steps JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE rules DROP COLUMN steps`,
+				},
+			},
+			{
+				Id: "rules_09",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rule_versions (
+						rule_id          VARCHAR(36) NOT NULL REFERENCES rules(id) ON DELETE CASCADE,
+						version          BIGINT NOT NULL,
+						domain_id        VARCHAR(36) NOT NULL,
+						input_channel    VARCHAR(36),
+						input_topic      TEXT,
+						logic_type       SMALLINT NOT NULL DEFAULT 0 CHECK (logic_type >= 0),
+						logic_value      BYTEA,
+						outputs          JSONB,
+						steps            JSONB,
+						start_datetime   TIMESTAMP,
+						time             TIMESTAMP,
+						recurring        SMALLINT,
+						recurring_period SMALLINT,
+						cron             TEXT NOT NULL DEFAULT '',
+						timezone         TEXT NOT NULL DEFAULT '',
+						restored_from    BIGINT NOT NULL DEFAULT 0,
+						created_at       TIMESTAMP NOT NULL,
+						created_by       VARCHAR(254),
+						PRIMARY KEY (rule_id, version)
+					)`,
+					`ALTER TABLE rules ADD COLUMN version BIGINT NOT NULL DEFAULT 0`,
+					`INSERT INTO rule_versions (rule_id, version, domain_id, input_channel, input_topic, logic_type, logic_value,
+						outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by)
+					SELECT id, 1, domain_id, input_channel, input_topic, logic_type, logic_value, outputs, steps,
+						start_datetime, time, recurring, recurring_period, cron, timezone,
+						COALESCE(updated_at, created_at, NOW()), COALESCE(updated_by, created_by)
+					FROM rules`,
+					`UPDATE rules SET version = 1`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rule_versions`,
+					`ALTER TABLE rules DROP COLUMN version`,
+				},
+			},
+			{
+				Id: "rules_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rule_dead_letters (
+						id            VARCHAR(36) PRIMARY KEY,
+						rule_id       VARCHAR(36) NOT NULL REFERENCES rules(id) ON DELETE CASCADE,
+						domain_id     VARCHAR(36) NOT NULL,
+						output_type   TEXT NOT NULL,
+						output        JSONB NOT NULL,
+						message       JSONB NOT NULL,
+						result        JSONB,
+						error         TEXT NOT NULL DEFAULT '',
+						status        SMALLINT NOT NULL DEFAULT 0 CHECK (status >= 0),
+						attempts      INTEGER NOT NULL DEFAULT 1,
+						next_retry_at TIMESTAMP,
+						created_at    TIMESTAMP NOT NULL,
+						updated_at    TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_rule_dead_letters_rule_id ON rule_dead_letters (rule_id, created_at DESC)`,
+					`CREATE INDEX IF NOT EXISTS idx_rule_dead_letters_next_retry_at ON rule_dead_letters (next_retry_at) WHERE status IN (0, 3)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rule_dead_letters`,
+				},
+			},
 		},
 	}
 
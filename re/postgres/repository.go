@@ -15,6 +15,7 @@ import (
 	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
 	"github.com/absmach/magistrala/pkg/postgres"
 	"github.com/absmach/magistrala/re"
+	"github.com/jmoiron/sqlx"
 )
 
 type PostgresRepository struct {
@@ -30,32 +31,43 @@ func NewRepository(db postgres.Database) re.Repository {
 func (repo *PostgresRepository) AddRule(ctx context.Context, r re.Rule) (re.Rule, error) {
 	q := `
 	INSERT INTO rules (id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status)
+		outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status)
 	VALUES (:id, :name, :domain_id, :tags, :metadata, :input_channel, :input_topic, :logic_type, :logic_value,
-		:outputs, :start_datetime, :time, :recurring, :recurring_period, :created_at, :created_by, :updated_at, :updated_by, :status)
+		:outputs, :steps, :start_datetime, :time, :recurring, :recurring_period, :cron, :timezone, :created_at, :created_by, :updated_at, :updated_by, :status)
 	RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+		outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;
 `
 	dbr, err := ruleToDb(r)
 	if err != nil {
 		return re.Rule{}, err
 	}
-	row, err := repo.DB.NamedQueryContext(ctx, q, dbr)
+	tx, err := repo.DB.BeginTxx(ctx, nil)
 	if err != nil {
 		return re.Rule{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
-	defer row.Close()
+	row, err := sqlx.NamedQueryContext(ctx, tx, q, dbr)
+	if err != nil {
+		return re.Rule{}, rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+	}
 
 	var dbRule dbRule
 	if row.Next() {
 		if err := row.StructScan(&dbRule); err != nil {
-			return re.Rule{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+			row.Close()
+			return re.Rule{}, rollback(tx, errors.Wrap(repoerr.ErrCreateEntity, err))
 		}
 	}
+	row.Close()
 
 	rule, err := dbToRule(dbRule)
 	if err != nil {
-		return re.Rule{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+		return re.Rule{}, rollback(tx, errors.Wrap(repoerr.ErrCreateEntity, err))
+	}
+	if rule.Version, err = addVersion(ctx, tx, rule.ID, r.CreatedBy, r.CreatedAt, 0); err != nil {
+		return re.Rule{}, rollback(tx, postgres.HandleError(repoerr.ErrCreateEntity, err))
+	}
+	if err := tx.Commit(); err != nil {
+		return re.Rule{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
 	}
 
 	return rule, nil
@@ -63,8 +75,9 @@ func (repo *PostgresRepository) AddRule(ctx context.Context, r re.Rule) (re.Rule
 
 func (repo *PostgresRepository) ViewRule(ctx context.Context, id string) (re.Rule, error) {
 	q := `
-		SELECT id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value, outputs,
-			start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status
+		SELECT id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value, outputs, steps,
+			start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version,
+			success_count, failure_count, last_run_at
 		FROM rules
 		WHERE id = $1;
 	`
@@ -80,6 +93,7 @@ func (repo *PostgresRepository) ViewRule(ctx context.Context, id string) (re.Rul
 	if err != nil {
 		return re.Rule{}, errors.Wrap(repoerr.ErrViewEntity, err)
 	}
+	ret.Stats = dbToStats(dbr)
 
 	return ret, nil
 }
@@ -89,7 +103,7 @@ func (repo *PostgresRepository) UpdateRuleStatus(ctx context.Context, r re.Rule)
 	SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 	WHERE id = :id
 	RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;`
+			outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;`
 
 	return repo.update(ctx, r, q)
 }
@@ -111,6 +125,12 @@ func (repo *PostgresRepository) UpdateRule(ctx context.Context, r re.Rule) (re.R
 	if r.Logic.Value != "" {
 		query = append(query, "logic_type = :logic_type,")
 		query = append(query, "logic_value = :logic_value,")
+		query = append(query, "steps = NULL,")
+	}
+	// Steps replace the rule logic and outputs.
+	if len(r.Steps) > 0 {
+		query = append(query, "steps = :steps,")
+		query = append(query, "logic_value = '', outputs = NULL,")
 	}
 
 	if len(query) > 0 {
@@ -121,17 +141,31 @@ func (repo *PostgresRepository) UpdateRule(ctx context.Context, r re.Rule) (re.R
 		UPDATE rules
 		SET %s updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
 		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;
 	`, upq)
 
-	return repo.update(ctx, r, q)
+	return repo.updateVersioned(ctx, r, q, 0)
+}
+
+func (repo *PostgresRepository) RestoreRule(ctx context.Context, r re.Rule, version uint64) (re.Rule, error) {
+	q := `
+		UPDATE rules
+		SET input_channel = :input_channel, input_topic = :input_topic, logic_type = :logic_type, logic_value = :logic_value,
+			outputs = :outputs, steps = :steps, start_datetime = :start_datetime, time = :time, recurring = :recurring,
+			recurring_period = :recurring_period, cron = :cron, timezone = :timezone, updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id
+		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
+			outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;
+	`
+
+	return repo.updateVersioned(ctx, r, q, version)
 }
 
 func (repo *PostgresRepository) UpdateRuleTags(ctx context.Context, r re.Rule) (re.Rule, error) {
 	q := `UPDATE rules SET tags = :tags, updated_at = :updated_at, updated_by = :updated_by
 	WHERE id = :id AND status = :status
 	RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-		outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;`
+		outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;`
 	r.Status = re.EnabledStatus
 
 	return repo.update(ctx, r, q)
@@ -141,11 +175,11 @@ func (repo *PostgresRepository) UpdateRuleSchedule(ctx context.Context, r re.Rul
 	q := `
 		UPDATE rules
 		SET start_datetime = :start_datetime, time = :time, recurring = :recurring,
-			recurring_period = :recurring_period, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
+			recurring_period = :recurring_period, cron = :cron, timezone = :timezone, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
 		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;
 	`
-	return repo.update(ctx, r, q)
+	return repo.updateVersioned(ctx, r, q, 0)
 }
 
 func (repo *PostgresRepository) update(ctx context.Context, r re.Rule, query string) (re.Rule, error) {
@@ -173,6 +207,54 @@ func (repo *PostgresRepository) update(ctx context.Context, r re.Rule, query str
 	return rule, nil
 }
 
+// updateVersioned updates the rule and stores its definition as a new version
+// in the same transaction. The rule is locked, so concurrent updates are
+// versioned in the same order they're applied.
+func (repo *PostgresRepository) updateVersioned(ctx context.Context, r re.Rule, query string, restoredFrom uint64) (re.Rule, error) {
+	dbr, err := ruleToDb(r)
+	if err != nil {
+		return re.Rule{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return re.Rule{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	var id string
+	if err := tx.QueryRowxContext(ctx, `SELECT id FROM rules WHERE id = $1 FOR UPDATE;`, r.ID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return re.Rule{}, rollback(tx, repoerr.ErrNotFound)
+		}
+		return re.Rule{}, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+	}
+
+	row, err := sqlx.NamedQueryContext(ctx, tx, query, dbr)
+	if err != nil {
+		return re.Rule{}, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+	}
+	if !row.Next() {
+		row.Close()
+		return re.Rule{}, rollback(tx, repoerr.ErrNotFound)
+	}
+	var dbRule dbRule
+	err = row.StructScan(&dbRule)
+	row.Close()
+	if err != nil {
+		return re.Rule{}, rollback(tx, errors.Wrap(repoerr.ErrUpdateEntity, err))
+	}
+	rule, err := dbToRule(dbRule)
+	if err != nil {
+		return re.Rule{}, rollback(tx, errors.Wrap(repoerr.ErrUpdateEntity, err))
+	}
+	if rule.Version, err = addVersion(ctx, tx, rule.ID, r.UpdatedBy, r.UpdatedAt, restoredFrom); err != nil {
+		return re.Rule{}, rollback(tx, postgres.HandleError(repoerr.ErrUpdateEntity, err))
+	}
+	if err := tx.Commit(); err != nil {
+		return re.Rule{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return rule, nil
+}
+
 func (repo *PostgresRepository) RemoveRule(ctx context.Context, id string) error {
 	q := `
 	DELETE FROM rules
@@ -201,8 +283,8 @@ func (repo *PostgresRepository) ListAllRules(ctx context.Context, pm re.PageMeta
 	pgData := rulesPageData(pm)
 
 	q := fmt.Sprintf(`
-		SELECT id, name, domain_id, tags, input_channel, input_topic, logic_type, logic_value, outputs,
-			start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status
+		SELECT id, name, domain_id, tags, input_channel, input_topic, logic_type, logic_value, outputs, steps,
+			start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version
 		FROM rules r %s %s %s;
 	`, pq, orderClause, pgData)
 	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
@@ -245,7 +327,7 @@ func (repo *PostgresRepository) UpdateRuleDue(ctx context.Context, id string, du
 		UPDATE rules
 		SET time = :time, updated_at = :updated_at WHERE id = :id
 		RETURNING id, name, domain_id, tags, metadata, input_channel, input_topic, logic_type, logic_value,
-			outputs, start_datetime, time, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			outputs, steps, start_datetime, time, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, version;
 	`
 	dbr := dbRule{
 		ID:        id,
@@ -340,3 +422,11 @@ func pageRulesQueryConditions(pm re.PageMeta) []string {
 	}
 	return query
 }
+
+func rollback(tx *sqlx.Tx, err error) error {
+	if rbErr := tx.Rollback(); rbErr != nil {
+		return errors.Wrap(err, errors.Wrap(errors.ErrRollbackTx, rbErr))
+	}
+
+	return err
+}
@@ -133,6 +133,40 @@ func TestAddRule(t *testing.T) {
 		Metadata:  re.Metadata{},
 	}
 
+	pipelineRule := re.Rule{
+		ID:           generateUUID(t),
+		Name:         namegen.Generate(),
+		DomainID:     generateUUID(t),
+		InputChannel: generateUUID(t),
+		Steps: []re.Step{
+			{
+				Name:  "parse",
+				Logic: re.Script{Type: re.LuaType, Value: "return message.payload.temperature"},
+				Branches: []re.Branch{
+					{
+						Name:      "alert",
+						Condition: &re.Script{Type: re.LuaType, Value: "return result > 40"},
+						Outputs:   re.Outputs{&outputs.ChannelPublisher{Channel: generateUUID(t), Topic: "alerts"}},
+						Stop:      true,
+					},
+				},
+			},
+			{
+				Name:  "round",
+				Logic: re.Script{Type: re.LuaType, Value: "return math.floor(result)"},
+				Branches: []re.Branch{
+					{Outputs: re.Outputs{&outputs.SenML{}}},
+				},
+			},
+		},
+		Status:    re.EnabledStatus,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: generateUUID(t),
+		UpdatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		UpdatedBy: generateUUID(t),
+		Metadata:  re.Metadata{},
+	}
+
 	cases := []struct {
 		desc string
 		rule re.Rule
@@ -164,6 +198,12 @@ func TestAddRule(t *testing.T) {
 			resp: outputsRule,
 			err:  nil,
 		},
+		{
+			desc: "rule with pipeline",
+			rule: pipelineRule,
+			resp: pipelineRule,
+			err:  nil,
+		},
 		{
 			desc: "invalid metadata",
 			rule: re.Rule{
@@ -194,6 +234,8 @@ func TestAddRule(t *testing.T) {
 			addedRule, err := repo.AddRule(context.Background(), tc.rule)
 			if err == nil {
 				tc.resp.ID = addedRule.ID
+				// The rule is stored as its first version.
+				tc.resp.Version = 1
 				assert.Equal(t, tc.resp, addedRule, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.resp, addedRule))
 			}
 		})
@@ -229,6 +271,7 @@ func TestViewRule(t *testing.T) {
 	}
 	rule, err := repo.AddRule(context.Background(), rule)
 	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	rule.Stats = &re.ExecutionStats{}
 
 	cases := []struct {
 		desc string
@@ -339,6 +382,7 @@ func TestUpdateRule(t *testing.T) {
 				Metadata: map[string]any{
 					"updated": "metadata",
 				},
+				Version: 2,
 			},
 			err: nil,
 		},
@@ -26,15 +26,22 @@ type dbRule struct {
 	LogicType       re.ScriptType      `db:"logic_type"`
 	LogicValue      string             `db:"logic_value"`
 	Outputs         []byte             `db:"outputs"`
+	Steps           []byte             `db:"steps"`
 	StartDateTime   sql.NullTime       `db:"start_datetime"`
 	Time            sql.NullTime       `db:"time"`
 	Recurring       schedule.Recurring `db:"recurring"`
 	RecurringPeriod uint               `db:"recurring_period"`
+	Cron            string             `db:"cron"`
+	Timezone        string             `db:"timezone"`
 	Status          re.Status          `db:"status"`
+	Version         uint64             `db:"version"`
 	CreatedAt       time.Time          `db:"created_at"`
 	CreatedBy       string             `db:"created_by"`
 	UpdatedAt       time.Time          `db:"updated_at"`
 	UpdatedBy       string             `db:"updated_by"`
+	SuccessCount    uint64             `db:"success_count"`
+	FailureCount    uint64             `db:"failure_count"`
+	LastRunAt       sql.NullTime       `db:"last_run_at"`
 }
 
 func ruleToDb(r re.Rule) (dbRule, error) {
@@ -64,6 +71,12 @@ func ruleToDb(r re.Rule) (dbRule, error) {
 	if err != nil {
 		return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
 	}
+	var steps []byte
+	if len(r.Steps) > 0 {
+		if steps, err = json.Marshal(r.Steps); err != nil {
+			return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
 
 	return dbRule{
 		ID:              r.ID,
@@ -76,11 +89,15 @@ func ruleToDb(r re.Rule) (dbRule, error) {
 		LogicType:       r.Logic.Type,
 		LogicValue:      r.Logic.Value,
 		Outputs:         outputs,
+		Steps:           steps,
 		StartDateTime:   start,
 		Time:            t,
 		Recurring:       r.Schedule.Recurring,
 		RecurringPeriod: r.Schedule.RecurringPeriod,
+		Cron:            r.Schedule.Cron,
+		Timezone:        r.Schedule.Timezone,
 		Status:          r.Status,
+		Version:         r.Version,
 		CreatedAt:       r.CreatedAt,
 		CreatedBy:       r.CreatedBy,
 		UpdatedAt:       r.UpdatedAt,
@@ -108,6 +125,13 @@ func dbToRule(dto dbRule) (re.Rule, error) {
 		}
 	}
 
+	var steps []re.Step
+	if dto.Steps != nil {
+		if err := json.Unmarshal(dto.Steps, &steps); err != nil {
+			return re.Rule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
 	return re.Rule{
 		ID:           dto.ID,
 		Name:         dto.Name,
@@ -121,13 +145,17 @@ func dbToRule(dto dbRule) (re.Rule, error) {
 			Value: dto.LogicValue,
 		},
 		Outputs: outputs,
+		Steps:   steps,
 		Schedule: schedule.Schedule{
 			StartDateTime:   dto.StartDateTime.Time,
 			Time:            dto.Time.Time,
 			Recurring:       dto.Recurring,
 			RecurringPeriod: dto.RecurringPeriod,
+			Cron:            dto.Cron,
+			Timezone:        dto.Timezone,
 		},
 		Status:    dto.Status,
+		Version:   dto.Version,
 		CreatedAt: dto.CreatedAt,
 		CreatedBy: dto.CreatedBy,
 		UpdatedAt: dto.UpdatedAt,
@@ -135,6 +163,18 @@ func dbToRule(dto dbRule) (re.Rule, error) {
 	}, nil
 }
 
+func dbToStats(dto dbRule) *re.ExecutionStats {
+	stats := &re.ExecutionStats{
+		SuccessCount: dto.SuccessCount,
+		FailureCount: dto.FailureCount,
+	}
+	if dto.LastRunAt.Valid {
+		stats.LastRunAt = &dto.LastRunAt.Time
+	}
+
+	return stats
+}
+
 func toNullString(value string) sql.NullString {
 	if value == "" {
 		return sql.NullString{Valid: false}
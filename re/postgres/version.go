@@ -0,0 +1,198 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/postgres"
+	"github.com/absmach/magistrala/pkg/schedule"
+	"github.com/absmach/magistrala/re"
+	"github.com/jmoiron/sqlx"
+)
+
+const versionColumns = `rule_id, version, domain_id, input_channel, input_topic, logic_type, logic_value, outputs, steps,
+	start_datetime, time, recurring, recurring_period, cron, timezone, restored_from, created_at, created_by`
+
+// dbVersion represents the database structure for a RuleVersion.
+type dbVersion struct {
+	RuleID          string             `db:"rule_id"`
+	Version         uint64             `db:"version"`
+	DomainID        string             `db:"domain_id"`
+	InputChannel    string             `db:"input_channel"`
+	InputTopic      sql.NullString     `db:"input_topic"`
+	LogicType       re.ScriptType      `db:"logic_type"`
+	LogicValue      string             `db:"logic_value"`
+	Outputs         []byte             `db:"outputs"`
+	Steps           []byte             `db:"steps"`
+	StartDateTime   sql.NullTime       `db:"start_datetime"`
+	Time            sql.NullTime       `db:"time"`
+	Recurring       schedule.Recurring `db:"recurring"`
+	RecurringPeriod uint               `db:"recurring_period"`
+	Cron            string             `db:"cron"`
+	Timezone        string             `db:"timezone"`
+	RestoredFrom    uint64             `db:"restored_from"`
+	CreatedAt       time.Time          `db:"created_at"`
+	CreatedBy       string             `db:"created_by"`
+}
+
+// addVersion stores the current definition of the rule as its next version
+// and sets it as the current version of the rule. It must be called in the
+// transaction which writes the rule, after the rule is written.
+func addVersion(ctx context.Context, tx *sqlx.Tx, ruleID, author string, at time.Time, restoredFrom uint64) (uint64, error) {
+	q := `
+	WITH v AS (
+		INSERT INTO rule_versions (` + versionColumns + `)
+		SELECT id, version + 1, domain_id, input_channel, input_topic, logic_type, logic_value, outputs, steps,
+			start_datetime, time, recurring, recurring_period, cron, timezone, $2, $3, $4
+		FROM rules WHERE id = $1
+		RETURNING rule_id, version
+	)
+	UPDATE rules SET version = v.version FROM v WHERE rules.id = v.rule_id
+	RETURNING rules.version;
+	`
+	var version uint64
+	if err := tx.QueryRowxContext(ctx, q, ruleID, restoredFrom, at, author).Scan(&version); err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (repo *PostgresRepository) ViewRuleVersion(ctx context.Context, ruleID string, version uint64) (re.RuleVersion, error) {
+	q := `SELECT ` + versionColumns + ` FROM rule_versions
+		WHERE rule_id = $1 AND ($2::BIGINT = 0 OR version = $2::BIGINT)
+		ORDER BY version DESC
+		LIMIT 1;`
+	row := repo.DB.QueryRowxContext(ctx, q, ruleID, version)
+	if err := row.Err(); err != nil {
+		return re.RuleVersion{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	var dbv dbVersion
+	if err := row.StructScan(&dbv); err != nil {
+		if err == sql.ErrNoRows {
+			return re.RuleVersion{}, repoerr.ErrNotFound
+		}
+		return re.RuleVersion{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	ret, err := dbToVersion(dbv)
+	if err != nil {
+		return re.RuleVersion{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return ret, nil
+}
+
+func (repo *PostgresRepository) ListRuleVersions(ctx context.Context, pm re.VersionPageMeta) (re.VersionsPage, error) {
+	q := `SELECT ` + versionColumns + ` FROM rule_versions
+		WHERE rule_id = :rule_id
+		ORDER BY version DESC
+		LIMIT :limit OFFSET :offset;`
+	rows, err := repo.DB.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return re.VersionsPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	versions := []re.RuleVersion{}
+	for rows.Next() {
+		var dbv dbVersion
+		if err := rows.StructScan(&dbv); err != nil {
+			return re.VersionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		v, err := dbToVersion(dbv)
+		if err != nil {
+			return re.VersionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		versions = append(versions, v)
+	}
+
+	cq := `SELECT COUNT(*) FROM rule_versions WHERE rule_id = :rule_id;`
+	total, err := postgres.Total(ctx, repo.DB, cq, pm)
+	if err != nil {
+		return re.VersionsPage{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return re.VersionsPage{
+		Total:    total,
+		Offset:   pm.Offset,
+		Limit:    pm.Limit,
+		Versions: versions,
+	}, nil
+}
+
+// versionToDb converts the version using the columns shared with the rule.
+func versionToDb(v re.RuleVersion) (dbVersion, error) {
+	dbr, err := ruleToDb(re.Rule{
+		InputChannel: v.InputChannel,
+		InputTopic:   v.InputTopic,
+		Logic:        v.Logic,
+		Outputs:      v.Outputs,
+		Steps:        v.Steps,
+		Schedule:     v.Schedule,
+	})
+	if err != nil {
+		return dbVersion{}, err
+	}
+
+	return dbVersion{
+		RuleID:          v.RuleID,
+		Version:         v.Version,
+		DomainID:        v.DomainID,
+		InputChannel:    dbr.InputChannel,
+		InputTopic:      dbr.InputTopic,
+		LogicType:       dbr.LogicType,
+		LogicValue:      dbr.LogicValue,
+		Outputs:         dbr.Outputs,
+		Steps:           dbr.Steps,
+		StartDateTime:   dbr.StartDateTime,
+		Time:            dbr.Time,
+		Recurring:       dbr.Recurring,
+		RecurringPeriod: dbr.RecurringPeriod,
+		Cron:            dbr.Cron,
+		Timezone:        dbr.Timezone,
+		RestoredFrom:    v.RestoredFrom,
+		CreatedAt:       v.CreatedAt,
+		CreatedBy:       v.CreatedBy,
+	}, nil
+}
+
+func dbToVersion(dbv dbVersion) (re.RuleVersion, error) {
+	r, err := dbToRule(dbRule{
+		InputChannel:    dbv.InputChannel,
+		InputTopic:      dbv.InputTopic,
+		LogicType:       dbv.LogicType,
+		LogicValue:      dbv.LogicValue,
+		Outputs:         dbv.Outputs,
+		Steps:           dbv.Steps,
+		StartDateTime:   dbv.StartDateTime,
+		Time:            dbv.Time,
+		Recurring:       dbv.Recurring,
+		RecurringPeriod: dbv.RecurringPeriod,
+		Cron:            dbv.Cron,
+		Timezone:        dbv.Timezone,
+	})
+	if err != nil {
+		return re.RuleVersion{}, err
+	}
+
+	return re.RuleVersion{
+		RuleID:       dbv.RuleID,
+		Version:      dbv.Version,
+		DomainID:     dbv.DomainID,
+		InputChannel: r.InputChannel,
+		InputTopic:   r.InputTopic,
+		Logic:        r.Logic,
+		Outputs:      r.Outputs,
+		Steps:        r.Steps,
+		Schedule:     r.Schedule,
+		RestoredFrom: dbv.RestoredFrom,
+		CreatedAt:    dbv.CreatedAt,
+		CreatedBy:    dbv.CreatedBy,
+	}, nil
+}
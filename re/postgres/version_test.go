@@ -0,0 +1,333 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/pkg/schedule"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleVersioning(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	updatedBy := generateUUID(t)
+	sch := schedule.Schedule{
+		StartDateTime: now,
+		Time:          now,
+		Recurring:     schedule.Cron,
+		Cron:          "*/15 * * * *",
+		Timezone:      "Europe/Berlin",
+	}
+
+	cases := []struct {
+		desc    string
+		write   func() (re.Rule, error)
+		version re.RuleVersion
+		err     error
+	}{
+		{
+			desc: "update rule",
+			write: func() (re.Rule, error) {
+				return repo.UpdateRule(context.Background(), re.Rule{
+					ID:           rule.ID,
+					InputChannel: rule.InputChannel,
+					InputTopic:   "temperature",
+					Logic:        re.Script{Type: re.LuaType, Value: "return false"},
+					UpdatedAt:    now,
+					UpdatedBy:    updatedBy,
+				})
+			},
+			version: re.RuleVersion{
+				Version:      2,
+				InputChannel: rule.InputChannel,
+				InputTopic:   "temperature",
+				Logic:        re.Script{Type: re.LuaType, Value: "return false"},
+				CreatedAt:    now,
+				CreatedBy:    updatedBy,
+			},
+		},
+		{
+			desc: "update rule schedule",
+			write: func() (re.Rule, error) {
+				return repo.UpdateRuleSchedule(context.Background(), re.Rule{
+					ID:        rule.ID,
+					Schedule:  sch,
+					UpdatedAt: now.Add(time.Minute),
+					UpdatedBy: updatedBy,
+				})
+			},
+			version: re.RuleVersion{
+				Version:      3,
+				InputChannel: rule.InputChannel,
+				InputTopic:   "temperature",
+				Logic:        re.Script{Type: re.LuaType, Value: "return false"},
+				Schedule:     sch,
+				CreatedAt:    now.Add(time.Minute),
+				CreatedBy:    updatedBy,
+			},
+		},
+		{
+			desc: "restore rule",
+			write: func() (re.Rule, error) {
+				return repo.RestoreRule(context.Background(), re.Rule{
+					ID:           rule.ID,
+					InputChannel: rule.InputChannel,
+					Logic:        rule.Logic,
+					UpdatedAt:    now.Add(2 * time.Minute),
+					UpdatedBy:    updatedBy,
+				}, 1)
+			},
+			version: re.RuleVersion{
+				Version:      4,
+				InputChannel: rule.InputChannel,
+				Logic:        rule.Logic,
+				RestoredFrom: 1,
+				CreatedAt:    now.Add(2 * time.Minute),
+				CreatedBy:    updatedBy,
+			},
+		},
+		{
+			desc: "update non-existing rule",
+			write: func() (re.Rule, error) {
+				return repo.UpdateRule(context.Background(), re.Rule{
+					ID:        generateUUID(t),
+					Logic:     rule.Logic,
+					UpdatedAt: now,
+				})
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	v, err := repo.ViewRuleVersion(context.Background(), rule.ID, 1)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(1), rule.Version, "expected added rule to be the first version")
+	assert.Equal(t, rule.Logic, v.Logic, "expected first version to store the added rule")
+	assert.Equal(t, rule.CreatedBy, v.CreatedBy, "expected first version to be created by the rule creator")
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := tc.write()
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.version.Version, r.Version, fmt.Sprintf("%s: expected rule version %d got %d\n", tc.desc, tc.version.Version, r.Version))
+			tc.version.RuleID = rule.ID
+			tc.version.DomainID = rule.DomainID
+			v, err := repo.ViewRuleVersion(context.Background(), rule.ID, 0)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.version, v, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.version, v))
+		})
+	}
+}
+
+func TestViewRuleVersion(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	versions := addVersions(t, repo, rule, 3)
+
+	cases := []struct {
+		desc     string
+		ruleID   string
+		version  uint64
+		response re.RuleVersion
+		err      error
+	}{
+		{
+			desc:     "view version successfully",
+			ruleID:   rule.ID,
+			version:  2,
+			response: versions[1],
+		},
+		{
+			desc:     "view latest version",
+			ruleID:   rule.ID,
+			response: versions[2],
+		},
+		{
+			desc:    "view non-existing version",
+			ruleID:  rule.ID,
+			version: 4,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:   "view version of non-existing rule",
+			ruleID: generateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			v, err := repo.ViewRuleVersion(context.Background(), tc.ruleID, tc.version)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, v, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, v))
+		})
+	}
+}
+
+func TestListRuleVersions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	num := 10
+	versions := addVersions(t, repo, rule, num)
+	// Versions are listed from the newest.
+	desc := make([]re.RuleVersion, num)
+	for i, v := range versions {
+		desc[num-1-i] = v
+	}
+
+	cases := []struct {
+		desc     string
+		pm       re.VersionPageMeta
+		response re.VersionsPage
+	}{
+		{
+			desc: "list versions successfully",
+			pm:   re.VersionPageMeta{RuleID: rule.ID, Limit: 5},
+			response: re.VersionsPage{
+				Total:    uint64(num),
+				Limit:    5,
+				Versions: desc[:5],
+			},
+		},
+		{
+			desc: "list versions with offset",
+			pm:   re.VersionPageMeta{RuleID: rule.ID, Offset: 8, Limit: 5},
+			response: re.VersionsPage{
+				Total:    uint64(num),
+				Offset:   8,
+				Limit:    5,
+				Versions: desc[8:],
+			},
+		},
+		{
+			desc: "list versions of non-existing rule",
+			pm:   re.VersionPageMeta{RuleID: generateUUID(t), Limit: 10},
+			response: re.VersionsPage{
+				Limit:    10,
+				Versions: []re.RuleVersion{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.ListRuleVersions(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.response, page, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, page))
+		})
+	}
+}
+
+func TestRestoreRule(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		assert.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+	rule := addRule(t, repo)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	cases := []struct {
+		desc string
+		rule re.Rule
+		err  error
+	}{
+		{
+			desc: "restore rule successfully",
+			rule: re.Rule{
+				ID:           rule.ID,
+				InputChannel: generateUUID(t),
+				InputTopic:   "humidity",
+				Logic:        re.Script{Type: re.LuaType, Value: "return message.payload.humidity > 80"},
+				Schedule: schedule.Schedule{
+					StartDateTime:   now,
+					Time:            now,
+					Recurring:       schedule.Daily,
+					RecurringPeriod: 1,
+				},
+				UpdatedAt: now,
+				UpdatedBy: generateUUID(t),
+			},
+		},
+		{
+			desc: "restore non-existing rule",
+			rule: re.Rule{
+				ID:        generateUUID(t),
+				Logic:     rule.Logic,
+				UpdatedAt: now,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := repo.RestoreRule(context.Background(), tc.rule, 1)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err != nil {
+				return
+			}
+			assert.Equal(t, rule.Name, r.Name)
+			assert.Equal(t, tc.rule.InputChannel, r.InputChannel)
+			assert.Equal(t, tc.rule.InputTopic, r.InputTopic)
+			assert.Equal(t, tc.rule.Logic, r.Logic)
+			assert.Equal(t, tc.rule.Schedule, r.Schedule)
+			assert.Equal(t, tc.rule.UpdatedBy, r.UpdatedBy)
+		})
+	}
+}
+
+// addVersions updates the rule, so it has the given number of versions, and
+// returns the versions starting from the first one.
+func addVersions(t *testing.T, repo re.Repository, rule re.Rule, num int) []re.RuleVersion {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for i := 1; i < num; i++ {
+		r := re.Rule{
+			ID:           rule.ID,
+			InputChannel: rule.InputChannel,
+			Logic:        re.Script{Type: re.LuaType, Value: fmt.Sprintf("return message.payload.temperature > %d", i)},
+			UpdatedAt:    now.Add(time.Duration(i) * time.Second),
+			UpdatedBy:    rule.CreatedBy,
+		}
+		_, err := repo.UpdateRule(context.Background(), r)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	var versions []re.RuleVersion
+	for i := range num {
+		v, err := repo.ViewRuleVersion(context.Background(), rule.ID, uint64(i+1))
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		versions = append(versions, v)
+	}
+
+	return versions
+}
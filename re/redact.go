@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/magistrala/re/outputs"
+)
+
+// Redact returns a copy of the rule with the output credentials masked,
+// so they are not exposed by the API.
+func (r Rule) Redact() Rule {
+	r.Outputs = redactOutputs(r.Outputs)
+	r.Steps = redactSteps(r.Steps)
+
+	return r
+}
+
+// Redact returns a copy of the rule version with the output credentials masked.
+func (v RuleVersion) Redact() RuleVersion {
+	v.Outputs = redactOutputs(v.Outputs)
+	v.Steps = redactSteps(v.Steps)
+
+	return v
+}
+
+func redactSteps(steps []Step) []Step {
+	if steps == nil {
+		return nil
+	}
+	ret := make([]Step, len(steps))
+	for i, s := range steps {
+		branches := make([]Branch, len(s.Branches))
+		for j, b := range s.Branches {
+			b.Outputs = redactOutputs(b.Outputs)
+			branches[j] = b
+		}
+		if s.Branches == nil {
+			branches = nil
+		}
+		s.Branches = branches
+		ret[i] = s
+	}
+
+	return ret
+}
+
+func redactOutputs(outs Outputs) Outputs {
+	if outs == nil {
+		return nil
+	}
+	ret := make(Outputs, len(outs))
+	for i, o := range outs {
+		ret[i] = redactOutput(o)
+	}
+
+	return ret
+}
+
+// redactOutput returns a copy of the output with its credentials masked.
+// Outputs are copied since the rules are shared with the cache.
+func redactOutput(o Runnable) Runnable {
+	switch o := o.(type) {
+	case *outputs.Webhook:
+		headers := redactHeaders(o.Headers)
+		if o.Secret != "" || headers != nil {
+			c := *o
+			if o.Secret != "" {
+				c.Secret = outputs.Redacted
+			}
+			if headers != nil {
+				c.Headers = headers
+			}
+			return &c
+		}
+	case *outputs.Slack:
+		if o.Token != "" {
+			c := *o
+			c.Token = outputs.Redacted
+			return &c
+		}
+	case *outputs.Postgres:
+		if o.Password != "" {
+			c := *o
+			c.Password = outputs.Redacted
+			return &c
+		}
+	}
+
+	return o
+}
+
+// redactHeaders returns a copy of the headers with the credential values
+// masked, or nil if there are none.
+func redactHeaders(headers map[string]string) map[string]string {
+	var ret map[string]string
+	for k := range headers {
+		if !sensitiveHeader(k) {
+			continue
+		}
+		if ret == nil {
+			ret = maps.Clone(headers)
+		}
+		ret[k] = outputs.Redacted
+	}
+
+	return ret
+}
+
+// sensitiveHeader reports whether the header usually carries credentials.
+func sensitiveHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization", "Cookie":
+		return true
+	}
+	name = strings.ToLower(name)
+
+	return strings.Contains(name, "key") || strings.Contains(name, "token") || strings.Contains(name, "secret")
+}
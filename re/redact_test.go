@@ -0,0 +1,157 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactRule(t *testing.T) {
+	webhook := &outputs.Webhook{Method: "POST", URL: "http://example.com", Secret: "secret"}
+	headers := map[string]string{
+		"Authorization":       "Bearer token",
+		"Proxy-Authorization": "Basic credentials",
+		"cookie":              "session=id",
+		"X-Api-Key":           "key",
+		"X-Auth-Token":        "token",
+		"X-Client-Secret":     "secret",
+		"Content-Type":        "application/json",
+	}
+	rule := re.Rule{
+		Outputs: re.Outputs{
+			webhook,
+			&outputs.Webhook{Method: "POST", URL: "http://example.com"},
+			&outputs.Slack{Token: "token", ChannelID: "C123"},
+			&outputs.Postgres{Host: "localhost", User: "user", Password: "password"},
+			&outputs.Webhook{Method: "POST", URL: "http://example.com", Headers: headers},
+		},
+		Steps: []re.Step{
+			{Branches: []re.Branch{{Outputs: re.Outputs{webhook}}}},
+		},
+	}
+
+	redacted := rule.Redact()
+	assert.Equal(t, outputs.Redacted, redacted.Outputs[0].(*outputs.Webhook).Secret, "expected secret to be masked")
+	assert.Empty(t, redacted.Outputs[1].(*outputs.Webhook).Secret, "expected empty secret to stay empty")
+	assert.Equal(t, outputs.Redacted, redacted.Outputs[2].(*outputs.Slack).Token, "expected Slack token to be masked")
+	assert.Equal(t, outputs.Redacted, redacted.Outputs[3].(*outputs.Postgres).Password, "expected Postgres password to be masked")
+	assert.Equal(t, outputs.Redacted, redacted.Steps[0].Branches[0].Outputs[0].(*outputs.Webhook).Secret, "expected branch secret to be masked")
+	assert.Equal(t, "secret", webhook.Secret, "expected rule outputs not to be modified")
+	assert.Equal(t, "secret", rule.Steps[0].Branches[0].Outputs[0].(*outputs.Webhook).Secret, "expected rule steps not to be modified")
+	redactedHeaders := redacted.Outputs[4].(*outputs.Webhook).Headers
+	for k := range headers {
+		if k == "Content-Type" {
+			continue
+		}
+		assert.Equal(t, outputs.Redacted, redactedHeaders[k], fmt.Sprintf("expected %s header to be masked", k))
+	}
+	assert.Equal(t, "application/json", redactedHeaders["Content-Type"], "expected Content-Type header not to be masked")
+	assert.Equal(t, "Bearer token", headers["Authorization"], "expected rule headers not to be modified")
+
+	data, err := json.Marshal(redacted)
+	require.Nil(t, err, fmt.Sprintf("unexpected error encoding rule: %s", err))
+	assert.NotContains(t, string(data), `"secret":"secret"`, "expected secret not to be encoded")
+	assert.NotContains(t, string(data), `"token":"token"`, "expected Slack token not to be encoded")
+	assert.NotContains(t, string(data), `"password":"password"`, "expected Postgres password not to be encoded")
+	assert.NotContains(t, string(data), "Bearer token", "expected Authorization header not to be encoded")
+}
+
+func TestWebhookLegacyRetries(t *testing.T) {
+	cases := []struct {
+		desc   string
+		data   string
+		policy *outputs.RetryPolicy
+	}{
+		{
+			desc: "webhook without retries",
+			data: `{"type": "webhook", "url": "http://example.com"}`,
+		},
+		{
+			desc:   "webhook with legacy retries",
+			data:   `{"type": "webhook", "url": "http://example.com", "retries": 3, "backoff": "2s"}`,
+			policy: &outputs.RetryPolicy{Retries: 3, Backoff: 2e9},
+		},
+		{
+			desc:   "webhook with legacy retries and retry policy",
+			data:   `{"type": "webhook", "url": "http://example.com", "retries": 3, "retry": {"retries": 1, "backoff": "1m"}}`,
+			policy: &outputs.RetryPolicy{Retries: 1, Backoff: 60e9},
+		},
+	}
+
+	for _, tc := range cases {
+		var w outputs.Webhook
+		err := json.Unmarshal([]byte(tc.data), &w)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.policy, w.RetryPolicy(), fmt.Sprintf("%s: unexpected retry policy", tc.desc))
+	}
+}
+
+func TestMaskedCredentials(t *testing.T) {
+	cases := []struct {
+		desc   string
+		output any
+		data   string
+	}{
+		{
+			desc:   "webhook with masked secret",
+			output: &outputs.Webhook{},
+			data:   `{"type": "webhook", "url": "http://example.com", "secret": "` + outputs.Redacted + `"}`,
+		},
+		{
+			desc:   "webhook with masked header",
+			output: &outputs.Webhook{},
+			data:   `{"type": "webhook", "url": "http://example.com", "headers": {"Authorization": "` + outputs.Redacted + `"}}`,
+		},
+		{
+			desc:   "Slack output with masked token",
+			output: &outputs.Slack{},
+			data:   `{"type": "slack", "token": "` + outputs.Redacted + `", "channel_id": "C123"}`,
+		},
+		{
+			desc:   "Postgres output with masked password",
+			output: &outputs.Postgres{},
+			data:   `{"type": "save_remote_pg", "host": "localhost", "password": "` + outputs.Redacted + `"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		err := json.Unmarshal([]byte(tc.data), tc.output)
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected error decoding masked credentials", tc.desc))
+	}
+}
+
+func TestWebhookPrivateURL(t *testing.T) {
+	cases := []struct {
+		desc    string
+		url     string
+		private bool
+		err     bool
+	}{
+		{desc: "public host", url: "http://example.com"},
+		{desc: "localhost", url: "http://localhost:8080", err: true},
+		{desc: "loopback address", url: "http://127.0.0.1", err: true},
+		{desc: "private address", url: "https://10.0.0.1/hook", err: true},
+		{desc: "link-local address", url: "http://169.254.169.254/latest/meta-data", err: true},
+		{desc: "IPv6 loopback address", url: "http://[::1]:8080", err: true},
+		{desc: "private address allowed", url: "http://10.0.0.1", private: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.private {
+				allowPrivateWebhooks(t)
+			}
+			var w outputs.Webhook
+			err := json.Unmarshal([]byte(`{"type": "webhook", "url": "`+tc.url+`"}`), &w)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+		})
+	}
+}
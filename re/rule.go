@@ -12,6 +12,7 @@ import (
 	"github.com/absmach/magistrala/pkg/errors"
 	"github.com/absmach/magistrala/pkg/messaging"
 	"github.com/absmach/magistrala/pkg/schedule"
+	"github.com/absmach/magistrala/pkg/ticker"
 	"github.com/absmach/magistrala/re/outputs"
 )
 
@@ -41,6 +42,7 @@ var outputRegistry = map[outputs.OutputType]func() Runnable{
 	outputs.ChannelsType:     func() Runnable { return &outputs.ChannelPublisher{} },
 	outputs.SaveSenMLType:    func() Runnable { return &outputs.SenML{} },
 	outputs.SlackType:        func() Runnable { return &outputs.Slack{} },
+	outputs.WebhookType:      func() Runnable { return &outputs.Webhook{} },
 }
 
 type Rule struct {
@@ -53,12 +55,15 @@ type Rule struct {
 	InputTopic   string            `json:"input_topic"`
 	Logic        Script            `json:"logic"`
 	Outputs      Outputs           `json:"outputs,omitempty"`
+	Steps        []Step            `json:"steps,omitempty"`
 	Schedule     schedule.Schedule `json:"schedule,omitempty"`
 	Status       Status            `json:"status"`
+	Version      uint64            `json:"version,omitempty"`
 	CreatedAt    time.Time         `json:"created_at"`
 	CreatedBy    string            `json:"created_by"`
 	UpdatedAt    time.Time         `json:"updated_at"`
 	UpdatedBy    string            `json:"updated_by"`
+	Stats        *ExecutionStats   `json:"stats,omitempty"`
 }
 
 // EventEncode converts a Rule struct to map[string]any at event producer.
@@ -104,6 +109,10 @@ func (r Rule) EventEncode() (map[string]any, error) {
 		m["input_topic"] = r.InputTopic
 	}
 
+	if r.Version != 0 {
+		m["version"] = r.Version
+	}
+
 	if r.Logic.Value != "" {
 		m["logic"] = map[string]any{
 			"type":  r.Logic.Type,
@@ -114,6 +123,38 @@ func (r Rule) EventEncode() (map[string]any, error) {
 	return m, nil
 }
 
+// Step is a step of the rule pipeline. The step logic receives the result of
+// the previous step, and the step result is routed to the outputs of the first
+// branch whose condition it matches.
+type Step struct {
+	Name     string   `json:"name,omitempty"`
+	Logic    Script   `json:"logic"`
+	Branches []Branch `json:"branches,omitempty"`
+}
+
+// Branch is a set of outputs of a pipeline step. The condition script receives
+// the step result and the branch is taken if it returns a value other than nil
+// or false. A branch without a condition is always taken.
+type Branch struct {
+	Name      string  `json:"name,omitempty"`
+	Condition *Script `json:"condition,omitempty"`
+	Outputs   Outputs `json:"outputs,omitempty"`
+	// Stop ends the pipeline once the branch is taken.
+	Stop bool `json:"stop,omitempty"`
+}
+
+// allOutputs returns the outputs of the rule, including the outputs of the pipeline branches.
+func (r Rule) allOutputs() []Runnable {
+	outs := append([]Runnable{}, r.Outputs...)
+	for _, s := range r.Steps {
+		for _, b := range s.Branches {
+			outs = append(outs, b.Outputs...)
+		}
+	}
+
+	return outs
+}
+
 type Outputs []Runnable
 
 func (o *Outputs) UnmarshalJSON(data []byte) error {
@@ -124,23 +165,10 @@ func (o *Outputs) UnmarshalJSON(data []byte) error {
 
 	var runnables []Runnable
 	for _, raw := range rawList {
-		var meta struct {
-			Type outputs.OutputType `json:"type"`
-		}
-		if err := json.Unmarshal(raw, &meta); err != nil {
-			return err
-		}
-
-		factory, ok := outputRegistry[meta.Type]
-		if !ok {
-			return errors.New("unknown output type: " + meta.Type.String())
-		}
-
-		instance := factory()
-		if err := json.Unmarshal(raw, instance); err != nil {
+		instance, err := decodeOutput(raw)
+		if err != nil {
 			return err
 		}
-
 		runnables = append(runnables, instance)
 	}
 	v := Outputs(runnables)
@@ -148,6 +176,44 @@ func (o *Outputs) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// decodeOutput decodes the output of the type it is serialized with.
+func decodeOutput(raw json.RawMessage) (Runnable, error) {
+	var meta struct {
+		Type outputs.OutputType `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+
+	factory, ok := outputRegistry[meta.Type]
+	if !ok {
+		return nil, errors.New("unknown output type: " + meta.Type.String())
+	}
+
+	instance := factory()
+	if err := json.Unmarshal(raw, instance); err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// outputType returns the type name an output is serialized with.
+func outputType(o Runnable) string {
+	var meta struct {
+		Type string `json:"type"`
+	}
+	data, err := json.Marshal(o)
+	if err != nil {
+		return "unknown"
+	}
+	if err := json.Unmarshal(data, &meta); err != nil || meta.Type == "" {
+		return "unknown"
+	}
+
+	return meta.Type
+}
+
 type Runnable interface {
 	Run(ctx context.Context, msg *messaging.Message, val any) error
 }
@@ -224,6 +290,36 @@ type Page struct {
 	Rules  []Rule `json:"rules"`
 }
 
+// Renderer is implemented by outputs that can produce the payload they
+// would deliver without actually delivering it.
+type Renderer interface {
+	Render(msg *messaging.Message, val any) (any, error)
+}
+
+// OutputResult contains the payload rendered for a single rule output.
+type OutputResult struct {
+	Type    string `json:"type"`
+	Payload any    `json:"payload,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// StepResult contains the result of a single pipeline step and the branch it took.
+type StepResult struct {
+	Step   int    `json:"step"`
+	Name   string `json:"name,omitempty"`
+	Result any    `json:"result"`
+	Branch *int   `json:"branch,omitempty"`
+}
+
+// TestResult is the outcome of running a rule against a sample message
+// without invoking its outputs.
+type TestResult struct {
+	Result  any            `json:"result"`
+	Steps   []StepResult   `json:"steps,omitempty"`
+	Outputs []OutputResult `json:"outputs,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
 type Service interface {
 	messaging.MessageHandler
 	AddRule(ctx context.Context, session authn.Session, r Rule) (Rule, error)
@@ -235,8 +331,27 @@ type Service interface {
 	RemoveRule(ctx context.Context, session authn.Session, id string) error
 	EnableRule(ctx context.Context, session authn.Session, id string) (Rule, error)
 	DisableRule(ctx context.Context, session authn.Session, id string) (Rule, error)
+	TestRule(ctx context.Context, session authn.Session, r Rule, msg *messaging.Message) (TestResult, error)
+	ListExecutions(ctx context.Context, session authn.Session, pm ExecutionPageMeta) (ExecutionsPage, error)
+	// ListRuleVersions lists the versions of the rule, from the newest.
+	ListRuleVersions(ctx context.Context, session authn.Session, pm VersionPageMeta) (VersionsPage, error)
+	// DiffRuleVersions returns the changes between the two versions of the rule. The latest
+	// version is used if to is 0, and the version preceding it if from is 0.
+	DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (VersionDiff, error)
+	// RestoreRuleVersion restores the rule definition of the version, which creates a new version.
+	RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (Rule, error)
+	// ListDeadLetters lists the failed output runs of the rule, from the newest.
+	ListDeadLetters(ctx context.Context, session authn.Session, pm DeadLetterPageMeta) (DeadLettersPage, error)
+	ViewDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (DeadLetter, error)
+	// ReplayDeadLetter runs the failed output again and returns the updated dead letter.
+	ReplayDeadLetter(ctx context.Context, session authn.Session, ruleID, id string) (DeadLetter, error)
 
 	StartScheduler(ctx context.Context) error
+	// StartExecutionsWriter saves the buffered rule executions in batches, once a batch is full
+	// or on each tick. The buffered executions are saved before it returns.
+	StartExecutionsWriter(ctx context.Context, tck ticker.Ticker) error
+	// StartDeadLetterRetries retries the pending dead letters on each tick, as set by their output retry policies.
+	StartDeadLetterRetries(ctx context.Context, tck ticker.Ticker) error
 }
 
 type Repository interface {
@@ -249,4 +364,27 @@ type Repository interface {
 	UpdateRuleStatus(ctx context.Context, r Rule) (Rule, error)
 	ListAllRules(ctx context.Context, pm PageMeta) (Page, error)
 	UpdateRuleDue(ctx context.Context, id string, due time.Time) (Rule, error)
+	// AddExecutions stores the executions and adds them to the run counters of their rules.
+	AddExecutions(ctx context.Context, executions []Execution) error
+	ListExecutions(ctx context.Context, pm ExecutionPageMeta) (ExecutionsPage, error)
+	RemoveExecutions(ctx context.Context, before time.Time) error
+	// ViewRuleVersion returns the version of the rule, or the latest version if the version is 0.
+	ViewRuleVersion(ctx context.Context, ruleID string, version uint64) (RuleVersion, error)
+	ListRuleVersions(ctx context.Context, pm VersionPageMeta) (VersionsPage, error)
+	// RestoreRule replaces the rule definition and schedule, storing it as a
+	// new version restored from the given version.
+	RestoreRule(ctx context.Context, r Rule, version uint64) (Rule, error)
+	AddDeadLetter(ctx context.Context, dl DeadLetter) error
+	ViewDeadLetter(ctx context.Context, ruleID, id string) (DeadLetter, error)
+	ListDeadLetters(ctx context.Context, pm DeadLetterPageMeta) (DeadLettersPage, error)
+	// UpdateDeadLetter updates the status, attempts, error and the next retry of the dead letter.
+	UpdateDeadLetter(ctx context.Context, dl DeadLetter) error
+	// ClaimDeadLetters returns up to limit pending dead letters due for retry, marking them
+	// as retrying until the given time, so they are not claimed again meanwhile.
+	ClaimDeadLetters(ctx context.Context, due, until time.Time, limit uint64) ([]DeadLetter, error)
+	// ClaimDeadLetter marks the dead letter as retrying until the given time, unless it is
+	// delivered or already being retried. It returns ErrNotFound if it can't be claimed.
+	ClaimDeadLetter(ctx context.Context, ruleID, id string, now, until time.Time) (DeadLetter, error)
+	// RemoveDeadLetters removes the delivered and failed dead letters last updated before the given time.
+	RemoveDeadLetters(ctx context.Context, before time.Time) error
 }
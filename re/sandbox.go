@@ -0,0 +1,426 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path"
+	"slices"
+	gostrings "strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	"github.com/traefik/yaegi/stdlib"
+	lua "github.com/yuin/gopher-lua"
+	luaast "github.com/yuin/gopher-lua/ast"
+	"github.com/yuin/gopher-lua/parse"
+	"gopkg.in/yaml.v3"
+)
+
+const defScriptTimeout = 5 * time.Second
+
+var (
+	ErrGoroutinesNotAllowed = errors.New("goroutines are not allowed in Go scripts")
+	ErrPanicNotAllowed      = errors.New("panic is not allowed in Go scripts")
+	// ErrModuleNotAllowed indicates that the script uses a module or a package which is not allowed in the domain.
+	ErrModuleNotAllowed = errors.New("module is not allowed in rule scripts")
+	// ErrScriptTimeout indicates that the script didn't finish in the sandbox time limit.
+	ErrScriptTimeout = errors.New("script run time limit exceeded")
+	// ErrScriptInstructions indicates that the Lua script exceeded the sandbox instruction limit.
+	ErrScriptInstructions = errors.New("script instruction limit exceeded")
+)
+
+// DefaultModules are the modules and packages rule scripts can use, unless configured otherwise.
+// Modules with access to the file system, databases and network are not allowed by default.
+var DefaultModules = Modules{
+	Lua: []string{"argparse", "base64", "bit", "crypto", "json", "regexp", "strings", "time", "yaml"},
+	Go: []string{
+		"bytes", "encoding/base64", "encoding/binary", "encoding/hex", "encoding/json", "errors", "fmt",
+		"maps", "math", "math/bits", "regexp", "slices", "sort", "strconv", "strings", "time", "unicode", "unicode/utf8",
+	},
+}
+
+// enginePackages are the Go packages of the rules engine, which are always allowed.
+var enginePackages = []string{"messaging", "state", "window"}
+
+// Modules is an allow-list of the Lua modules and Go packages scripts can use.
+type Modules struct {
+	Lua []string `yaml:"lua"`
+	Go  []string `yaml:"go"`
+}
+
+// AllModules returns all the Lua modules and Go standard library packages, which
+// scripts could use before the allow-lists were introduced. It keeps the existing
+// rules running until the modules they need are allowed explicitly.
+func AllModules() Modules {
+	var m Modules
+	for name := range luaModules {
+		m.Lua = append(m.Lua, name)
+	}
+	for key := range stdlib.Symbols {
+		if pkg := path.Dir(key); !slices.Contains(m.Go, pkg) {
+			m.Go = append(m.Go, pkg)
+		}
+	}
+	slices.Sort(m.Lua)
+	slices.Sort(m.Go)
+
+	return m
+}
+
+// Limits are the resources available to a single run of a rule script. Memory
+// allocations are not limited, apart from the size of the Lua stacks.
+type Limits struct {
+	// Timeout is the maximum run time of the rule logic. Scripts are
+	// interrupted once it's exceeded, including infinite loops.
+	Timeout time.Duration `yaml:"timeout"`
+	// LuaCallStackSize is the maximum call depth of Lua scripts.
+	LuaCallStackSize int `yaml:"lua_call_stack_size"`
+	// LuaStackSize is the maximum number of values on the Lua data stack.
+	// The stack doesn't grow, so it caps the Lua stack memory.
+	LuaStackSize int `yaml:"lua_stack_size"`
+	// LuaInstructions is the maximum number of VM instructions a Lua script
+	// runs, regardless of the run time. Zero doesn't limit the instructions.
+	LuaInstructions uint64 `yaml:"lua_instructions"`
+}
+
+// Sandbox limits the resources and the modules available to rule scripts.
+type Sandbox struct {
+	// Limits are the default resource limits.
+	Limits
+	// Modules is the default allow-list of modules.
+	Modules *Modules
+	// Domains overrides the default allow-list for specific domains.
+	Domains map[string]Modules
+	// DomainLimits overrides the default limits for specific domains.
+	// Zero values of the domain limits keep the default ones.
+	DomainLimits map[string]Limits
+}
+
+func (s Sandbox) withDefaults() Sandbox {
+	if s.Timeout <= 0 {
+		s.Timeout = defScriptTimeout
+	}
+	if s.LuaCallStackSize <= 0 {
+		s.LuaCallStackSize = lua.CallStackSize
+	}
+	if s.LuaStackSize <= 0 {
+		s.LuaStackSize = lua.RegistrySize
+	}
+	if s.Modules == nil {
+		s.Modules = &DefaultModules
+	}
+
+	return s
+}
+
+// limits returns the resource limits of the domain.
+func (s Sandbox) limits(domainID string) Limits {
+	ret := s.Limits
+	l, ok := s.DomainLimits[domainID]
+	if !ok {
+		return ret
+	}
+	if l.Timeout > 0 {
+		ret.Timeout = l.Timeout
+	}
+	if l.LuaCallStackSize > 0 {
+		ret.LuaCallStackSize = l.LuaCallStackSize
+	}
+	if l.LuaStackSize > 0 {
+		ret.LuaStackSize = l.LuaStackSize
+	}
+	if l.LuaInstructions > 0 {
+		ret.LuaInstructions = l.LuaInstructions
+	}
+
+	return ret
+}
+
+// modules returns the allow-list of the domain.
+func (s Sandbox) modules(domainID string) Modules {
+	if m, ok := s.Domains[domainID]; ok {
+		return m
+	}
+	if s.Modules == nil {
+		return DefaultModules
+	}
+
+	return *s.Modules
+}
+
+type sandboxFile struct {
+	Default *Modules           `yaml:"default"`
+	Domains map[string]Modules `yaml:"domains"`
+	Limits  map[string]Limits  `yaml:"limits"`
+}
+
+// ReadSandboxFile reads the allow-lists and the domain limits from the YAML
+// file. The file contains the default allow-list, the allow-lists of specific
+// domains and the limits of specific domains:
+//
+//	default:
+//	  lua: [json, strings]
+//	  go: [fmt, strings]
+//	domains:
+//	  <domain_id>:
+//	    lua: [json, strings, http_client]
+//	    go: [fmt, strings, net/http]
+//	limits:
+//	  <domain_id>:
+//	    timeout: 1s
+//	    lua_instructions: 1000000
+//
+// The default limits are not read from the file, so they are left empty.
+func ReadSandboxFile(path string) (Sandbox, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Sandbox{}, err
+	}
+	var f sandboxFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return Sandbox{}, err
+	}
+
+	return Sandbox{Modules: f.Default, Domains: f.Domains, DomainLimits: f.Limits}, nil
+}
+
+// validateLogic checks the script against the sandbox rules of the domain. Syntax
+// errors are not checked here since they're reported when the script is run.
+func (re *re) validateLogic(domainID string, s Script) error {
+	modules := re.sandbox.modules(domainID)
+	var err error
+	switch s.Type {
+	case GoType:
+		err = checkGo(s.Value, modules.Go)
+	default:
+		err = checkLua(s.Value, modules.Lua)
+	}
+	if err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
+// runContext returns the context of a single script run limited by the sandbox
+// limits of the domain. Lua runs are limited by the number of instructions too.
+func (re *re) runContext(ctx context.Context, domainID string, typ ScriptType) (context.Context, context.CancelFunc) {
+	limits := re.sandbox.limits(domainID)
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	if typ == LuaType && limits.LuaInstructions > 0 {
+		ctx = newInstructionsContext(ctx, limits.LuaInstructions)
+	}
+
+	return ctx, cancel
+}
+
+// instructionsContext is canceled once the Lua script runs the maximum number
+// of instructions. The Lua VM checks the context before each instruction, so
+// each check is counted as an instruction.
+type instructionsContext struct {
+	context.Context
+	max      uint64
+	count    atomic.Uint64
+	once     sync.Once
+	exceeded chan struct{}
+}
+
+func newInstructionsContext(ctx context.Context, maxInstructions uint64) *instructionsContext {
+	return &instructionsContext{Context: ctx, max: maxInstructions, exceeded: make(chan struct{})}
+}
+
+func (c *instructionsContext) Done() <-chan struct{} {
+	if c.count.Add(1) > c.max {
+		c.once.Do(func() { close(c.exceeded) })
+		return c.exceeded
+	}
+
+	return c.Context.Done()
+}
+
+func (c *instructionsContext) Err() error {
+	select {
+	case <-c.exceeded:
+		return ErrScriptInstructions
+	default:
+		return c.Context.Err()
+	}
+}
+
+// scriptError replaces context errors with a sandbox error.
+func scriptError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if errors.Contains(ctx.Err(), ErrScriptInstructions) {
+		return ErrScriptInstructions
+	}
+
+	return errors.Wrap(ErrScriptTimeout, ctx.Err())
+}
+
+// checkGo checks that the Go script doesn't start goroutines, panic or import packages
+// which are not allowed. Scripts can be a file, declarations without a package
+// clause or statements, so they are parsed the same way the interpreter does.
+func checkGo(script string, packages []string) error {
+	file := parseGo(script)
+	if file == nil {
+		return nil
+	}
+
+	for _, imp := range file.Imports {
+		path := gostrings.Trim(imp.Path.Value, "\"`")
+		if !slices.Contains(packages, path) && !slices.Contains(enginePackages, path) {
+			return errors.Wrap(ErrModuleNotAllowed, fmt.Errorf("package %q", path))
+		}
+	}
+	var err error
+	goast.Inspect(file, func(n goast.Node) bool {
+		if err != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *goast.GoStmt:
+			err = ErrGoroutinesNotAllowed
+		case *goast.CallExpr:
+			if id, ok := n.Fun.(*goast.Ident); ok && id.Name == "panic" {
+				err = ErrPanicNotAllowed
+			}
+		}
+		return err == nil
+	})
+
+	return err
+}
+
+// parseGo parses the script as a file, with or without the package clause,
+// or as the function body. It returns nil if the script is not valid Go.
+func parseGo(script string) *goast.File {
+	fset := token.NewFileSet()
+	for _, src := range []string{script, "package main\n" + script, "package main\nfunc _() {\n" + script + "\n}"} {
+		if f, err := goparser.ParseFile(fset, "", src, goparser.SkipObjectResolution); err == nil {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// hasGlobals reports whether the script declares package-level variables.
+func hasGlobals(file *goast.File) bool {
+	for _, d := range file.Decls {
+		if gd, ok := d.(*goast.GenDecl); ok && gd.Tok == token.VAR {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkLua checks that the Lua script doesn't require modules which are not allowed.
+// Modules required by a computed name are not preloaded, so they fail at run time.
+func checkLua(script string, modules []string) error {
+	chunk, err := parse.Parse(gostrings.NewReader(script), luaChunkName)
+	if err != nil {
+		return nil
+	}
+	for _, name := range luaRequires(chunk) {
+		builtin := slices.ContainsFunc(luaLibs, func(lib luaLib) bool { return lib.name == name })
+		if !builtin && !slices.Contains(modules, name) {
+			return errors.Wrap(ErrModuleNotAllowed, fmt.Errorf("module %q", name))
+		}
+	}
+
+	return nil
+}
+
+// luaRequires returns the names of the modules required by a constant name.
+func luaRequires(stmts []luaast.Stmt) []string {
+	var names []string
+	var exprs func(...luaast.Expr)
+	var block func([]luaast.Stmt)
+	exprs = func(es ...luaast.Expr) {
+		for _, e := range es {
+			switch e := e.(type) {
+			case *luaast.FuncCallExpr:
+				if id, ok := e.Func.(*luaast.IdentExpr); ok && id.Value == "require" && len(e.Args) > 0 {
+					if s, ok := e.Args[0].(*luaast.StringExpr); ok {
+						names = append(names, s.Value)
+					}
+				}
+				exprs(e.Func, e.Receiver)
+				exprs(e.Args...)
+			case *luaast.AttrGetExpr:
+				exprs(e.Object, e.Key)
+			case *luaast.TableExpr:
+				for _, f := range e.Fields {
+					exprs(f.Key, f.Value)
+				}
+			case *luaast.LogicalOpExpr:
+				exprs(e.Lhs, e.Rhs)
+			case *luaast.RelationalOpExpr:
+				exprs(e.Lhs, e.Rhs)
+			case *luaast.StringConcatOpExpr:
+				exprs(e.Lhs, e.Rhs)
+			case *luaast.ArithmeticOpExpr:
+				exprs(e.Lhs, e.Rhs)
+			case *luaast.UnaryMinusOpExpr:
+				exprs(e.Expr)
+			case *luaast.UnaryNotOpExpr:
+				exprs(e.Expr)
+			case *luaast.UnaryLenOpExpr:
+				exprs(e.Expr)
+			case *luaast.FunctionExpr:
+				block(e.Stmts)
+			}
+		}
+	}
+	block = func(stmts []luaast.Stmt) {
+		for _, s := range stmts {
+			switch s := s.(type) {
+			case *luaast.AssignStmt:
+				exprs(s.Lhs...)
+				exprs(s.Rhs...)
+			case *luaast.LocalAssignStmt:
+				exprs(s.Exprs...)
+			case *luaast.FuncCallStmt:
+				exprs(s.Expr)
+			case *luaast.DoBlockStmt:
+				block(s.Stmts)
+			case *luaast.WhileStmt:
+				exprs(s.Condition)
+				block(s.Stmts)
+			case *luaast.RepeatStmt:
+				exprs(s.Condition)
+				block(s.Stmts)
+			case *luaast.IfStmt:
+				exprs(s.Condition)
+				block(s.Then)
+				block(s.Else)
+			case *luaast.NumberForStmt:
+				exprs(s.Init, s.Limit, s.Step)
+				block(s.Stmts)
+			case *luaast.GenericForStmt:
+				exprs(s.Exprs...)
+				block(s.Stmts)
+			case *luaast.FuncDefStmt:
+				exprs(s.Func)
+			case *luaast.ReturnStmt:
+				exprs(s.Exprs...)
+			}
+		}
+	}
+	block(stmts)
+
+	return names
+}
@@ -0,0 +1,273 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/re"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddRuleSandbox(t *testing.T) {
+	otherDomainID := testsutil.GenerateUUID(t)
+	sandbox := re.Sandbox{
+		Domains: map[string]re.Modules{
+			otherDomainID: {Lua: []string{"json", "ioutil"}, Go: []string{"fmt", "os"}},
+		},
+	}
+	svc, repo, _ := newCachingService(t, nil, re.Config{Sandbox: sandbox}, make(chan pkglog.RunInfo, 1))
+
+	cases := []struct {
+		desc     string
+		domainID string
+		logic    re.Script
+		err      error
+	}{
+		{
+			desc:     "add rule with Lua script requiring allowed module",
+			domainID: domainID,
+			logic:    re.Script{Type: re.LuaType, Value: `local json = require("json"); return json.encode(message.payload)`},
+		},
+		{
+			desc:     "add rule with Lua script requiring module not allowed",
+			domainID: domainID,
+			logic:    re.Script{Type: re.LuaType, Value: `local ioutil = require("ioutil"); return ioutil.read_file("/etc/passwd")`},
+			err:      re.ErrModuleNotAllowed,
+		},
+		{
+			desc:     "add rule with Lua script requiring module not allowed in a function",
+			domainID: domainID,
+			logic:    re.Script{Type: re.LuaType, Value: `local function fetch() return require "http_client" end; return fetch() ~= nil`},
+			err:      re.ErrModuleNotAllowed,
+		},
+		{
+			desc:     "add rule with Lua script requiring module allowed in the domain",
+			domainID: otherDomainID,
+			logic:    re.Script{Type: re.LuaType, Value: `local ioutil = require("ioutil"); return ioutil.read_file("/tmp/data")`},
+		},
+		{
+			desc:     "add rule with Go script importing allowed package",
+			domainID: domainID,
+			logic:    re.Script{Type: re.GoType, Value: "package main\n\nimport \"strings\"\n\nfunc logicFunction() any { return strings.ToUpper(\"ok\") }"},
+		},
+		{
+			desc:     "add rule with Go script importing package not allowed",
+			domainID: domainID,
+			logic:    re.Script{Type: re.GoType, Value: "import \"os\"\n\nfunc logicFunction() any { return os.Getenv(\"HOME\") }"},
+			err:      re.ErrModuleNotAllowed,
+		},
+		{
+			desc:     "add rule with Go script importing package allowed in the domain",
+			domainID: otherDomainID,
+			logic:    re.Script{Type: re.GoType, Value: "import \"os\"\n\nfunc logicFunction() any { return os.Getenv(\"HOME\") }"},
+		},
+		{
+			desc:     "add rule with Go script calling panic in a closure",
+			domainID: otherDomainID,
+			logic:    re.Script{Type: re.GoType, Value: "func logicFunction() any { f := func() { panic(1) }; f(); return nil }"},
+			err:      re.ErrPanicNotAllowed,
+		},
+		{
+			desc:     "add rule with Go script mentioning goroutines in a string",
+			domainID: domainID,
+			logic:    re.Script{Type: re.GoType, Value: "func logicFunction() any { return \"go func() {}\" }"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{Name: "sandbox", Logic: tc.logic}
+			repoCall := repo.On("AddRule", mock.Anything, mock.Anything).Return(rule, nil)
+			_, err := svc.AddRule(context.Background(), authn.Session{UserID: userID, DomainID: tc.domainID}, rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				assert.True(t, errors.Contains(err, svcerr.ErrMalformedEntity), fmt.Sprintf("%s: expected malformed entity error got %s\n", tc.desc, err))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestTestRuleSandbox(t *testing.T) {
+	limitedDomainID := testsutil.GenerateUUID(t)
+	sandbox := re.Sandbox{
+		Limits: re.Limits{Timeout: 100 * time.Millisecond},
+		DomainLimits: map[string]re.Limits{
+			limitedDomainID: {LuaInstructions: 1000, LuaCallStackSize: 10},
+		},
+	}
+	svc, _, _ := newCachingService(t, nil, re.Config{Sandbox: sandbox}, make(chan pkglog.RunInfo, 1))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc     string
+		domainID string
+		logic    re.Script
+		res      any
+		err      string
+	}{
+		{
+			desc:  "test Lua rule with infinite loop",
+			logic: re.Script{Type: re.LuaType, Value: "while true do end"},
+			err:   re.ErrScriptTimeout.Error(),
+		},
+		{
+			desc:  "test Go rule with infinite loop",
+			logic: re.Script{Type: re.GoType, Value: "func logicFunction() any { for {} }"},
+			err:   re.ErrScriptTimeout.Error(),
+		},
+		{
+			desc:  "test Lua rule with restricted standard libraries",
+			logic: re.Script{Type: re.LuaType, Value: "return io == nil and debug == nil and os.execute == nil and require('os').remove == nil and dofile == nil and os.time() > 0"},
+			res:   true,
+		},
+		{
+			desc:  "test Lua rule requiring module not allowed by computed name",
+			logic: re.Script{Type: re.LuaType, Value: `local name = "io" .. "util"; return require(name) ~= nil`},
+			err:   "module ioutil not found",
+		},
+		{
+			desc:  "test Lua rule with deep recursion",
+			logic: re.Script{Type: re.LuaType, Value: "local function f(n) return 1 + f(n + 1) end; return f(1)"},
+			err:   "stack overflow",
+		},
+		{
+			desc:     "test Lua rule with infinite loop in domain with instruction limit",
+			domainID: limitedDomainID,
+			logic:    re.Script{Type: re.LuaType, Value: "while true do end"},
+			err:      re.ErrScriptInstructions.Error(),
+		},
+		{
+			desc:     "test Lua rule within instruction limit",
+			domainID: limitedDomainID,
+			logic:    re.Script{Type: re.LuaType, Value: "local sum = 0; for i = 1, 10 do sum = sum + i end; return sum"},
+			res:      float64(55),
+		},
+		{
+			desc:     "test Lua rule with recursion in domain with call stack limit",
+			domainID: limitedDomainID,
+			logic:    re.Script{Type: re.LuaType, Value: "local function f(n) if n == 0 then return 0 end return 1 + f(n - 1) end; return f(20)"},
+			err:      "stack overflow",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			session := session
+			if tc.domainID != "" {
+				session.DomainID = tc.domainID
+			}
+			start := time.Now()
+			res, err := svc.TestRule(context.Background(), session, re.Rule{Logic: tc.logic}, &messaging.Message{})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Less(t, time.Since(start), 2*time.Second, fmt.Sprintf("%s: expected script to be interrupted", tc.desc))
+			if tc.err != "" {
+				assert.Contains(t, res.Error, tc.err, fmt.Sprintf("%s: expected script error %q got %q", tc.desc, tc.err, res.Error))
+				return
+			}
+			assert.Empty(t, res.Error, fmt.Sprintf("%s: unexpected script error %s", tc.desc, res.Error))
+			assert.Equal(t, tc.res, res.Result)
+		})
+	}
+}
+
+func TestHandleSandboxTimeout(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	svc, repo, _ := newCachingService(t, nil, re.Config{Sandbox: re.Sandbox{Limits: re.Limits{Timeout: 100 * time.Millisecond}}}, ri)
+	rule := re.Rule{
+		ID:           testsutil.GenerateUUID(t),
+		DomainID:     domainID,
+		InputChannel: inputChannel,
+		Status:       re.EnabledStatus,
+		Logic:        re.Script{Type: re.GoType, Value: "func logicFunction() any { for {} }"},
+	}
+	repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+	repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// Interrupted programs are not reused, so each run times out on its own.
+	for range 2 {
+		err := svc.Handle(&messaging.Message{Domain: domainID, Channel: inputChannel})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		select {
+		case info := <-ri:
+			assert.Equal(t, slog.LevelError, info.Level)
+			assert.Contains(t, info.Message, re.ErrScriptTimeout.Error())
+		case <-time.After(2 * time.Second):
+			t.Fatal("rule run was not interrupted")
+		}
+	}
+}
+
+func TestReadSandboxFile(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	data := "default:\n  lua: [json]\n  go: [fmt]\ndomains:\n  " + domainID + ":\n    lua: [json, http_client]\nlimits:\n  " + domainID + ":\n    timeout: 1s\n    lua_instructions: 1000\n"
+	err := os.WriteFile(valid, []byte(data), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	err = os.WriteFile(invalid, []byte("default: [json"), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc    string
+		path    string
+		sandbox re.Sandbox
+		err     bool
+	}{
+		{
+			desc: "read valid file",
+			path: valid,
+			sandbox: re.Sandbox{
+				Modules:      &re.Modules{Lua: []string{"json"}, Go: []string{"fmt"}},
+				Domains:      map[string]re.Modules{domainID: {Lua: []string{"json", "http_client"}}},
+				DomainLimits: map[string]re.Limits{domainID: {Timeout: time.Second, LuaInstructions: 1000}},
+			},
+		},
+		{
+			desc: "read invalid file",
+			path: invalid,
+			err:  true,
+		},
+		{
+			desc: "read missing file",
+			path: filepath.Join(dir, "missing.yaml"),
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sandbox, err := re.ReadSandboxFile(tc.path)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			assert.Equal(t, tc.sandbox, sandbox)
+		})
+	}
+}
+
+func TestAllModules(t *testing.T) {
+	all := re.AllModules()
+	for _, m := range []string{"db", "filepath", "http_client", "ioutil", "storage"} {
+		assert.Contains(t, all.Lua, m, fmt.Sprintf("expected Lua module %s", m))
+	}
+	for _, m := range re.DefaultModules.Lua {
+		assert.Contains(t, all.Lua, m, fmt.Sprintf("expected Lua module %s", m))
+	}
+	for _, m := range append([]string{"os", "net/http"}, re.DefaultModules.Go...) {
+		assert.Contains(t, all.Go, m, fmt.Sprintf("expected Go package %s", m))
+	}
+}
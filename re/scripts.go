@@ -0,0 +1,105 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/ristretto/v2"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// compiledScript is a compiled version of the rule logic. Lua prototypes can be
+// shared between runs, while Go programs are pooled since each run needs its own.
+// Go programs with package-level variables are evaluated for each run instead.
+type compiledScript struct {
+	lua    *lua.FunctionProto
+	script string
+	golang sync.Pool
+}
+
+// scriptCache caches compiled scripts by the rule ID and the script version.
+type scriptCache struct {
+	cache   *ristretto.Cache[string, *compiledScript]
+	metrics Metrics
+}
+
+func newScriptCache(cfg Config) (*scriptCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config[string, *compiledScript]{
+		NumCounters: 10 * cfg.ScriptsCacheSize,
+		MaxCost:     cfg.ScriptsCacheSize,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &scriptCache{cache: cache, metrics: cfg.Metrics}, nil
+}
+
+// lua returns the compiled Lua script of the rule.
+func (sc *scriptCache) lua(ruleID string, s Script) (*lua.FunctionProto, error) {
+	key := scriptKey(ruleID, s)
+	if cs, ok := sc.cache.Get(key); ok {
+		sc.hit()
+		return cs.lua, nil
+	}
+	sc.miss()
+	proto, err := compileLua(s.Value)
+	if err != nil {
+		return nil, err
+	}
+	sc.cache.Set(key, &compiledScript{lua: proto}, 1)
+
+	return proto, nil
+}
+
+// golang returns a Go program of the rule script with the given packages available. The
+// program must be returned with the release function once the run is done.
+func (sc *scriptCache) golang(ctx context.Context, ruleID string, s Script, packages []string) (*goProgram, func(), error) {
+	key := scriptKey(ruleID, s)
+	cs, ok := sc.cache.Get(key)
+	if ok {
+		sc.hit()
+		if p, ok := cs.golang.Get().(*goProgram); ok {
+			return p, cs.release(p), nil
+		}
+	} else {
+		sc.miss()
+		cs = &compiledScript{script: s.Value}
+		sc.cache.Set(key, cs, 1)
+	}
+	p, err := compileGo(ctx, cs.script, packages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return p, cs.release(p), nil
+}
+
+// release returns the program to the pool, unless it was stopped or has package-level variables.
+func (cs *compiledScript) release(p *goProgram) func() {
+	return func() {
+		if !p.stopped && !p.globals {
+			cs.golang.Put(p)
+		}
+	}
+}
+
+func (sc *scriptCache) hit() {
+	sc.metrics.Cache.With("cache", "scripts", "result", "hit").Add(1)
+}
+
+func (sc *scriptCache) miss() {
+	sc.metrics.Cache.With("cache", "scripts", "result", "miss").Add(1)
+}
+
+// scriptKey identifies the script version, so updated rules are recompiled.
+// Pipeline scripts of the same rule are told apart by their content.
+func scriptKey(ruleID string, s Script) string {
+	return fmt.Sprintf("%s:%d:%x", ruleID, s.Type, sha256.Sum256([]byte(s.Value)))
+}
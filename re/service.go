@@ -15,17 +15,21 @@ import (
 	svcerr "github.com/absmach/magistrala/pkg/errors/service"
 	pkglog "github.com/absmach/magistrala/pkg/logger"
 	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/pkg/schedule"
 	"github.com/absmach/magistrala/pkg/ticker"
 )
 
-var (
-	ErrGoroutinesNotAllowed = errors.New("goroutines are not allowed in Go scripts")
-	ErrPanicNotAllowed      = errors.New("panic is not allowed in Go scripts")
-)
-
 type re struct {
 	repo       Repository
+	state      StateStore
+	index      *RuleIndex
+	scripts    *scriptCache
+	pool       *workerPool
+	metrics    Metrics
+	sandbox    Sandbox
 	runInfo    chan pkglog.RunInfo
+	executions chan Execution
+	batch      int
 	idp        magistrala.IDProvider
 	rePubSub   messaging.PubSub
 	writersPub messaging.Publisher
@@ -35,11 +39,25 @@ type re struct {
 	readers    grpcReadersV1.ReadersServiceClient
 }
 
-func NewService(repo Repository, runInfo chan pkglog.RunInfo, idp magistrala.IDProvider, rePubSub messaging.PubSub, writersPub, alarmsPub messaging.Publisher, tck ticker.Ticker, emailer emailer.Emailer, readers grpcReadersV1.ReadersServiceClient) (Service, error) {
+func NewService(repo Repository, state StateStore, index *RuleIndex, runInfo chan pkglog.RunInfo, idp magistrala.IDProvider, rePubSub messaging.PubSub, writersPub, alarmsPub messaging.Publisher, tck ticker.Ticker, emailer emailer.Emailer, readers grpcReadersV1.ReadersServiceClient, cfg Config) (Service, error) {
+	cfg = cfg.withDefaults()
+	scripts, err := newScriptCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &re{
 		repo:       repo,
+		state:      state,
+		index:      index,
+		scripts:    scripts,
+		pool:       newWorkerPool(cfg),
+		metrics:    cfg.Metrics,
+		sandbox:    cfg.Sandbox,
 		idp:        idp,
 		runInfo:    runInfo,
+		executions: make(chan Execution, cfg.ExecutionsBuffer),
+		batch:      cfg.ExecutionsBatch,
 		rePubSub:   rePubSub,
 		writersPub: writersPub,
 		alarmsPub:  alarmsPub,
@@ -50,11 +68,8 @@ func NewService(repo Repository, runInfo chan pkglog.RunInfo, idp magistrala.IDP
 }
 
 func (re *re) AddRule(ctx context.Context, session authn.Session, r Rule) (retRule Rule, retErr error) {
-	if r.Logic.Type == GoType && goKeywordRegex.MatchString(r.Logic.Value) {
-		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrGoroutinesNotAllowed)
-	}
-	if r.Logic.Type == GoType && panicRegex.MatchString(r.Logic.Value) {
-		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrPanicNotAllowed)
+	if err := re.validateRule(session.DomainID, r); err != nil {
+		return Rule{}, err
 	}
 
 	id, err := re.idp.ID()
@@ -71,12 +86,13 @@ func (re *re) AddRule(ctx context.Context, session authn.Session, r Rule) (retRu
 	if !r.Schedule.StartDateTime.IsZero() {
 		r.Schedule.StartDateTime = now
 	}
-	r.Schedule.Time = r.Schedule.StartDateTime
+	r.Schedule.Time = r.Schedule.FirstDue()
 
 	rule, err := re.repo.AddRule(ctx, r)
 	if err != nil {
 		return Rule{}, errors.Wrap(svcerr.ErrCreateEntity, err)
 	}
+	re.index.Invalidate(session.DomainID)
 
 	defer func() {
 		if retErr != nil {
@@ -99,11 +115,8 @@ func (re *re) ViewRule(ctx context.Context, session authn.Session, id string, wi
 }
 
 func (re *re) UpdateRule(ctx context.Context, session authn.Session, r Rule) (Rule, error) {
-	if r.Logic.Type == GoType && goKeywordRegex.MatchString(r.Logic.Value) {
-		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrGoroutinesNotAllowed)
-	}
-	if r.Logic.Type == GoType && panicRegex.MatchString(r.Logic.Value) {
-		return Rule{}, errors.Wrap(svcerr.ErrMalformedEntity, ErrPanicNotAllowed)
+	if err := re.validateRule(session.DomainID, r); err != nil {
+		return Rule{}, err
 	}
 
 	r.UpdatedAt = time.Now().UTC()
@@ -112,6 +125,7 @@ func (re *re) UpdateRule(ctx context.Context, session authn.Session, r Rule) (Ru
 	if err != nil {
 		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	re.index.Invalidate(session.DomainID)
 
 	return rule, nil
 }
@@ -130,10 +144,15 @@ func (re *re) UpdateRuleTags(ctx context.Context, session authn.Session, r Rule)
 func (re *re) UpdateRuleSchedule(ctx context.Context, session authn.Session, r Rule) (Rule, error) {
 	r.UpdatedAt = time.Now().UTC()
 	r.UpdatedBy = session.UserID
+	// Cron schedules are due at the times matching the expression.
+	if r.Schedule.Recurring == schedule.Cron {
+		r.Schedule.Time = r.Schedule.FirstDue()
+	}
 	rule, err := re.repo.UpdateRuleSchedule(ctx, r)
 	if err != nil {
 		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	re.index.Invalidate(session.DomainID)
 
 	return rule, nil
 }
@@ -151,6 +170,10 @@ func (re *re) RemoveRule(ctx context.Context, session authn.Session, id string)
 	if err := re.repo.RemoveRule(ctx, id); err != nil {
 		return errors.Wrap(svcerr.ErrRemoveEntity, err)
 	}
+	re.index.Invalidate(session.DomainID)
+	if err := re.state.Clear(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
 
 	return nil
 }
@@ -170,6 +193,7 @@ func (re *re) EnableRule(ctx context.Context, session authn.Session, id string)
 	if err != nil {
 		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	re.index.Invalidate(session.DomainID)
 	return rule, nil
 }
 
@@ -188,6 +212,85 @@ func (re *re) DisableRule(ctx context.Context, session authn.Session, id string)
 	if err != nil {
 		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+	re.index.Invalidate(session.DomainID)
+	return rule, nil
+}
+
+func (re *re) ListExecutions(ctx context.Context, session authn.Session, pm ExecutionPageMeta) (ExecutionsPage, error) {
+	page, err := re.repo.ListExecutions(ctx, pm)
+	if err != nil {
+		return ExecutionsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (re *re) ListRuleVersions(ctx context.Context, session authn.Session, pm VersionPageMeta) (VersionsPage, error) {
+	page, err := re.repo.ListRuleVersions(ctx, pm)
+	if err != nil {
+		return VersionsPage{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (re *re) DiffRuleVersions(ctx context.Context, session authn.Session, ruleID string, from, to uint64) (VersionDiff, error) {
+	newer, err := re.repo.ViewRuleVersion(ctx, ruleID, to)
+	if err != nil {
+		return VersionDiff{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Compare with the previous version by default.
+	if from == 0 {
+		from = newer.Version - 1
+	}
+	older := RuleVersion{RuleID: ruleID}
+	if from > 0 {
+		if older, err = re.repo.ViewRuleVersion(ctx, ruleID, from); err != nil {
+			return VersionDiff{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+	}
+
+	return VersionDiff{
+		RuleID:  ruleID,
+		From:    older.Version,
+		To:      newer.Version,
+		Changes: diffVersions(older.Redact(), newer.Redact()),
+	}, nil
+}
+
+func (re *re) RestoreRuleVersion(ctx context.Context, session authn.Session, ruleID string, version uint64) (Rule, error) {
+	v, err := re.repo.ViewRuleVersion(ctx, ruleID, version)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// The sandbox rules may have changed since the version was created.
+	if err := re.validateRule(session.DomainID, Rule{Logic: v.Logic, Outputs: v.Outputs, Steps: v.Steps}); err != nil {
+		return Rule{}, err
+	}
+
+	now := time.Now().UTC()
+	r := Rule{
+		ID:           ruleID,
+		InputChannel: v.InputChannel,
+		InputTopic:   v.InputTopic,
+		Logic:        v.Logic,
+		Outputs:      v.Outputs,
+		Steps:        v.Steps,
+		Schedule:     v.Schedule,
+		UpdatedAt:    now,
+		UpdatedBy:    session.UserID,
+	}
+	// Restored recurring schedules are due from now on, instead of catching up the missed runs.
+	if r.Schedule.Recurring != schedule.None && r.Schedule.Time.Before(now) {
+		r.Schedule.StartDateTime = now
+		r.Schedule.Time = r.Schedule.FirstDue()
+	}
+	rule, err := re.repo.RestoreRule(ctx, r, v.Version)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	re.index.Invalidate(session.DomainID)
+
 	return rule, nil
 }
 
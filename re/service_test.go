@@ -4,13 +4,21 @@
 package re_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/0x6flab/namegenerator"
+	"github.com/absmach/magistrala/alarms"
 	"github.com/absmach/magistrala/internal/testsutil"
 	"github.com/absmach/magistrala/pkg/authn"
 	emocks "github.com/absmach/magistrala/pkg/emailer/mocks"
@@ -27,6 +35,7 @@ import (
 	"github.com/absmach/magistrala/re"
 	"github.com/absmach/magistrala/re/mocks"
 	"github.com/absmach/magistrala/re/outputs"
+	"github.com/absmach/magistrala/re/state"
 	readmocks "github.com/absmach/magistrala/readers/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -68,7 +77,7 @@ func newService(t *testing.T, runInfo chan pkglog.RunInfo) (re.Service, *mocks.R
 	readersSvc := new(readmocks.ReadersServiceClient)
 	e := new(emocks.Emailer)
 	policy := new(policymocks.Service)
-	svc, err := re.NewService(repo, runInfo, idProvider, pubsub, pubsub, pubsub, mockTicker, e, readersSvc)
+	svc, err := re.NewService(repo, state.NewMemory(), nil, runInfo, idProvider, pubsub, pubsub, pubsub, mockTicker, e, readersSvc, re.Config{})
 	if err != nil {
 		t.Fatalf("Failed to create service: %v", err)
 	}
@@ -233,7 +242,7 @@ func TestAddRuleWithoutRoleProvisioning(t *testing.T) {
 	readersSvc := new(readmocks.ReadersServiceClient)
 	e := new(emocks.Emailer)
 
-	svc, err := re.NewService(repo, make(chan pkglog.RunInfo), idProvider, pubsub, pubsub, pubsub, mockTicker, e, readersSvc)
+	svc, err := re.NewService(repo, state.NewMemory(), nil, make(chan pkglog.RunInfo), idProvider, pubsub, pubsub, pubsub, mockTicker, e, readersSvc, re.Config{})
 	if err != nil {
 		t.Fatalf("Failed to create service: %v", err)
 	}
@@ -1571,6 +1580,7 @@ func TestHandle(t *testing.T) {
 			})
 			repoCall1 := pubmocks.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(tc.publishErr).Maybe()
 			repoCall2 := emailer.On("SendEmailNotification", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			dlCall := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 			err = svc.Handle(tc.message)
 			assert.Nil(t, err)
@@ -1582,6 +1592,406 @@ func TestHandle(t *testing.T) {
 			repoCall.Unset()
 			repoCall1.Unset()
 			repoCall2.Unset()
+			dlCall.Unset()
+		})
+	}
+}
+
+// allowPrivateWebhooks allows the webhooks to the test servers, which
+// listen on the loopback address.
+func allowPrivateWebhooks(t *testing.T) {
+	outputs.AllowPrivateWebhooks(true)
+	t.Cleanup(func() { outputs.AllowPrivateWebhooks(false) })
+}
+
+func TestHandleWebhookOutput(t *testing.T) {
+	allowPrivateWebhooks(t)
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo, 10))
+	secret := "webhook-secret"
+
+	cases := []struct {
+		desc       string
+		status     int
+		deadLetter bool
+	}{
+		{
+			desc:   "send webhook successfully",
+			status: http.StatusOK,
+		},
+		{
+			desc:       "send webhook with server error",
+			status:     http.StatusServiceUnavailable,
+			deadLetter: true,
+		},
+		{
+			desc:       "send webhook with client error",
+			status:     http.StatusBadRequest,
+			deadLetter: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			done := make(chan struct{}, 1)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, `{"value": 25.5}`, string(body))
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				timestamp := r.Header.Get(outputs.TimestampHeader)
+				assert.Equal(t, "sha256="+outputs.Sign(secret, timestamp, body), r.Header.Get(outputs.SignatureHeader))
+
+				w.WriteHeader(tc.status)
+				done <- struct{}{}
+			}))
+			defer ts.Close()
+
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return {temperature = message.payload.temperature}",
+				},
+				Outputs: re.Outputs{
+					&outputs.Webhook{
+						Method: http.MethodPost,
+						URL:    ts.URL,
+						Body:   `{"value": {{.Result.temperature}}}`,
+						Secret: secret,
+						Retryable: outputs.Retryable{
+							Retry: &outputs.RetryPolicy{Retries: 1, Backoff: time.Minute},
+						},
+					},
+				},
+			}
+			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			saved := make(chan re.DeadLetter, 1)
+			dlCall := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				saved <- args.Get(1).(re.DeadLetter)
+			}).Maybe()
+
+			err := svc.Handle(&messaging.Message{
+				Channel: inputChannel,
+				Payload: []byte(`{"temperature": 25.5}`),
+			})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatalf("%s: webhook was not sent", tc.desc)
+			}
+			// Failed requests are retried by the dead-letter queue, not within the run.
+			select {
+			case dl := <-saved:
+				assert.True(t, tc.deadLetter, fmt.Sprintf("%s: unexpected dead letter", tc.desc))
+				assert.Contains(t, dl.Error, strconv.Itoa(tc.status))
+				assert.NotContains(t, string(dl.Output), secret, fmt.Sprintf("%s: expected secret to be masked in dead letter", tc.desc))
+				assert.Contains(t, string(dl.Output), outputs.Redacted, fmt.Sprintf("%s: expected secret to be masked in dead letter", tc.desc))
+			case <-time.After(100 * time.Millisecond):
+				assert.False(t, tc.deadLetter, fmt.Sprintf("%s: expected dead letter", tc.desc))
+			}
+			select {
+			case <-done:
+				t.Fatalf("%s: webhook was sent more than once", tc.desc)
+			default:
+			}
+
+			repoCall.Unset()
+			dlCall.Unset()
+		})
+	}
+}
+
+func TestHandleAlarmAutoClear(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	// nolint:dogsled
+	svc, repo, pubsub, _, _, _ := newService(t, ri)
+	logic := `if message.payload.temperature > 30 then
+		return {measurement = "temperature", value = tostring(message.payload.temperature), cause = "high temperature", severity = 50}
+	end
+	return false`
+
+	// The cases run in order on the same rule, since a clear depends on the previous evaluation.
+	ruleID := testsutil.GenerateUUID(t)
+	cases := []struct {
+		desc      string
+		logic     string
+		autoClear bool
+		payload   string
+		published bool
+		status    alarms.Status
+	}{
+		{
+			desc:      "keep alarms while the condition has not held",
+			logic:     logic,
+			autoClear: true,
+			payload:   `{"temperature": 20}`,
+		},
+		{
+			desc:      "raise alarm while the condition holds",
+			logic:     logic,
+			autoClear: true,
+			payload:   `{"temperature": 35}`,
+			published: true,
+			status:    alarms.ActiveStatus,
+		},
+		{
+			desc:      "keep alarms on nil logic result",
+			logic:     "return nil",
+			autoClear: true,
+			payload:   `{"temperature": 20}`,
+		},
+		{
+			desc:      "clear alarms once the condition returns to normal",
+			logic:     logic,
+			autoClear: true,
+			payload:   `{"temperature": 20}`,
+			published: true,
+			status:    alarms.ClearedStatus,
+		},
+		{
+			desc:      "keep alarms while the condition stays normal",
+			logic:     logic,
+			autoClear: true,
+			payload:   `{"temperature": 20}`,
+		},
+		{
+			desc:      "raise alarm once the condition holds again",
+			logic:     logic,
+			autoClear: true,
+			payload:   `{"temperature": 35}`,
+			published: true,
+			status:    alarms.ActiveStatus,
+		},
+		{
+			desc:    "keep alarms without auto-clear",
+			logic:   logic,
+			payload: `{"temperature": 20}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           ruleID,
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        re.Script{Type: re.LuaType, Value: tc.logic},
+				Outputs:      re.Outputs{&outputs.Alarm{AutoClear: tc.autoClear}},
+			}
+			published := make(chan *messaging.Message, 1)
+			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			pubsubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { published <- args.Get(2).(*messaging.Message) }).
+				Return(nil).Maybe()
+
+			err := svc.Handle(&messaging.Message{Domain: domainID, Channel: inputChannel, Publisher: "client", Payload: []byte(tc.payload)})
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			select {
+			case info := <-ri:
+				assert.NotEqual(t, slog.LevelError, info.Level, fmt.Sprintf("%s: unexpected run info %s", tc.desc, info.Message))
+			case <-time.After(2 * time.Second):
+				t.Fatalf("%s: rule was not processed", tc.desc)
+			}
+
+			switch {
+			case tc.published:
+				msg := <-published
+				var alarm alarms.Alarm
+				err := gob.NewDecoder(bytes.NewReader(msg.Payload)).Decode(&alarm)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.status, alarm.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, alarm.Status))
+				assert.Equal(t, rule.ID, alarm.RuleID, fmt.Sprintf("%s: expected rule %s got %s", tc.desc, rule.ID, alarm.RuleID))
+			default:
+				assert.Empty(t, published, fmt.Sprintf("%s: unexpected alarm published", tc.desc))
+			}
+
+			repoCall.Unset()
+			pubsubCall.Unset()
+		})
+	}
+}
+
+func TestTestRule(t *testing.T) {
+	// nolint:dogsled
+	svc, _, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+
+	cases := []struct {
+		desc      string
+		rule      re.Rule
+		message   *messaging.Message
+		res       re.TestResult
+		scriptErr bool
+		err       error
+	}{
+		{
+			desc: "test Lua rule with rendered outputs",
+			rule: re.Rule{
+				Name:         namegen.Generate(),
+				InputChannel: inputChannel,
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return {temperature = message.payload.temperature}",
+				},
+				Outputs: re.Outputs{
+					&outputs.Email{
+						To:      []string{"test@example.com"},
+						Subject: "Temperature",
+						Content: "Temperature is {{.Result.temperature}}",
+					},
+					&outputs.Postgres{
+						Table:   "temperature",
+						Mapping: `{"value": {{.Result.temperature}}}`,
+					},
+				},
+			},
+			message: &messaging.Message{
+				Payload: []byte(`{"temperature": 25.5}`),
+			},
+			res: re.TestResult{
+				Result: map[string]any{"temperature": 25.5},
+				Outputs: []re.OutputResult{
+					{
+						Type: outputs.EmailType.String(),
+						Payload: map[string]any{
+							"to":      []string{"test@example.com"},
+							"subject": "Temperature",
+							"content": "Temperature is 25.5",
+						},
+					},
+					{
+						Type:    outputs.SaveRemotePgType.String(),
+						Payload: map[string]any{"value": 25.5},
+					},
+				},
+			},
+		},
+		{
+			desc: "test Lua rule with rendered webhook output",
+			rule: re.Rule{
+				Name: namegen.Generate(),
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return {temperature = message.payload.temperature}",
+				},
+				Outputs: re.Outputs{
+					&outputs.Webhook{
+						Method:  http.MethodPost,
+						URL:     "https://example.com/hooks/temperature",
+						Headers: map[string]string{"Authorization": "Bearer token"},
+						Body:    `{"value": {{.Result.temperature}}}`,
+					},
+				},
+			},
+			message: &messaging.Message{
+				Payload: []byte(`{"temperature": 25.5}`),
+			},
+			res: re.TestResult{
+				Result: map[string]any{"temperature": 25.5},
+				Outputs: []re.OutputResult{
+					{
+						Type: outputs.WebhookType.String(),
+						Payload: map[string]any{
+							"method":  http.MethodPost,
+							"url":     "https://example.com/hooks/temperature",
+							"headers": map[string]string{"Authorization": "Bearer token"},
+							"body":    json.RawMessage(`{"value": 25.5}`),
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "test Go rule returning false",
+			rule: re.Rule{
+				Name: namegen.Generate(),
+				Logic: re.Script{
+					Type: re.GoType,
+					Value: `package main
+
+func logicFunction() any {
+	return false
+}`,
+				},
+				Outputs: re.Outputs{
+					&outputs.Email{Content: "{{.Result}}"},
+				},
+			},
+			message: &messaging.Message{
+				Payload: []byte(`{"temperature": 25.5}`),
+			},
+			res: re.TestResult{
+				Result: false,
+			},
+		},
+		{
+			desc: "test rule with invalid output template",
+			rule: re.Rule{
+				Name: namegen.Generate(),
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "return true",
+				},
+				Outputs: re.Outputs{
+					&outputs.Postgres{Mapping: "not JSON"},
+				},
+			},
+			message: &messaging.Message{},
+			res: re.TestResult{
+				Result: true,
+				Outputs: []re.OutputResult{
+					{
+						Type:  outputs.SaveRemotePgType.String(),
+						Error: "invalid character 'o' in literal null (expecting 'u')",
+					},
+				},
+			},
+		},
+		{
+			desc: "test rule with invalid Lua syntax",
+			rule: re.Rule{
+				Name: namegen.Generate(),
+				Logic: re.Script{
+					Type:  re.LuaType,
+					Value: "invalid lua syntax {{{",
+				},
+			},
+			message:   &messaging.Message{},
+			scriptErr: true,
+		},
+		{
+			desc: "test Go rule with goroutine",
+			rule: re.Rule{
+				Name: namegen.Generate(),
+				Logic: re.Script{
+					Type:  re.GoType,
+					Value: "go func() {}()",
+				},
+			},
+			message: &messaging.Message{},
+			err:     svcerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := svc.TestRule(context.Background(), session, tc.rule, tc.message)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err != nil {
+				return
+			}
+			if tc.scriptErr {
+				assert.NotEmpty(t, res.Error, fmt.Sprintf("%s: expected script error", tc.desc))
+				return
+			}
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			assert.Equal(t, domainID, tc.message.Domain, fmt.Sprintf("%s: expected message domain %s got %s\n", tc.desc, domainID, tc.message.Domain))
 		})
 	}
 }
@@ -1777,6 +2187,7 @@ func TestStartScheduler(t *testing.T) {
 
 			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(page, tc.listErr)
 			repoCall2 := repo.On("UpdateRuleDue", mock.Anything, mock.Anything, mock.Anything).Return(re.Rule{}, tc.updateDueErr)
+			dlCall := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
 			tickChan := make(chan time.Time, 1)
 			tickCall := ticker.On("Tick").Return((<-chan time.Time)(tickChan))
 			tickCall1 := ticker.On("Stop").Return()
@@ -1815,6 +2226,7 @@ func TestStartScheduler(t *testing.T) {
 
 			repoCall.Unset()
 			repoCall2.Unset()
+			dlCall.Unset()
 			tickCall.Unset()
 			tickCall1.Unset()
 		})
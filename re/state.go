@@ -0,0 +1,244 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/pkg/messaging"
+)
+
+const (
+	// raisedPrefix prefixes the key which marks the sources for which the
+	// rule logic held on the last evaluation. The keys of the rule logic
+	// start with the source scope, so they don't collide with it.
+	raisedPrefix = "#raised:"
+	// WindowRetention is the maximum age of the samples kept in a window.
+	WindowRetention = 24 * time.Hour
+	// MaxWindowSamples is the maximum number of samples kept in a window.
+	MaxWindowSamples = 10000
+)
+
+var (
+	// ErrStateNotNumber indicates an increment of a non-numeric state value.
+	ErrStateNotNumber = errors.New("state value is not a number")
+	// ErrInvalidWindow indicates invalid window size.
+	ErrInvalidWindow = errors.New("window size must be a positive duration or samples count")
+)
+
+// Sample is a single value recorded in a window.
+type Sample struct {
+	Value float64   `json:"value"`
+	Time  time.Time `json:"time"`
+}
+
+// StateStore stores the key/value state and the window samples of rules.
+// Keys are scoped to the rule, so different rules can use the same keys.
+type StateStore interface {
+	// Get returns the JSON encoded value stored under the key,
+	// or nil if the key doesn't exist or has expired.
+	Get(ctx context.Context, ruleID, key string) ([]byte, error)
+
+	// Set stores the JSON encoded value under the key. Zero TTL means no expiration.
+	Set(ctx context.Context, ruleID, key string, value []byte, ttl time.Duration) error
+
+	// Incr increments the number stored under the key by delta and returns
+	// the new value. Missing keys are treated as zero, and the TTL is set
+	// only when the key has no expiration, so counters expire TTL after
+	// they were created.
+	Incr(ctx context.Context, ruleID, key string, delta float64, ttl time.Duration) (float64, error)
+
+	// Delete removes the key.
+	Delete(ctx context.Context, ruleID, key string) error
+
+	// AddSample appends the sample to the window stored under the key and removes
+	// the samples older than retention, keeping at most limit latest samples.
+	AddSample(ctx context.Context, ruleID, key string, s Sample, retention time.Duration, limit int) error
+
+	// Samples returns the samples of the window stored under the key, oldest first.
+	Samples(ctx context.Context, ruleID, key string) ([]Sample, error)
+
+	// Clear removes the whole state of the rule.
+	Clear(ctx context.Context, ruleID string) error
+}
+
+// ruleState is the state of a single rule exposed to the rule logic. Keys are
+// scoped to the message client and subtopic, so the same rule keeps separate
+// state for each device and topic it receives messages from.
+type ruleState struct {
+	store  StateStore
+	ruleID string
+	scope  string
+	// dryRun state reads the stored values, but doesn't persist changes.
+	dryRun bool
+}
+
+func newRuleState(store StateStore, ruleID string, msg *messaging.Message, dryRun bool) *ruleState {
+	return &ruleState{
+		store:  store,
+		ruleID: ruleID,
+		scope:  msg.ClientIdentity() + ":" + msg.Subtopic + ":",
+		dryRun: dryRun,
+	}
+}
+
+func (s *ruleState) get(ctx context.Context, key string) (any, error) {
+	data, err := s.store.Get(ctx, s.ruleID, s.scope+key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (s *ruleState) set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if s.dryRun {
+		return nil
+	}
+
+	return s.store.Set(ctx, s.ruleID, s.scope+key, data, ttl)
+}
+
+// raised returns whether the rule logic held on the last evaluation for the source.
+func (s *ruleState) raised(ctx context.Context) (bool, error) {
+	data, err := s.store.Get(ctx, s.ruleID, raisedPrefix+s.scope)
+	if err != nil {
+		return false, err
+	}
+
+	return data != nil, nil
+}
+
+// setRaised records whether the rule logic held on the evaluation for the source.
+func (s *ruleState) setRaised(ctx context.Context, raised bool) error {
+	if s.dryRun {
+		return nil
+	}
+	if !raised {
+		return s.store.Delete(ctx, s.ruleID, raisedPrefix+s.scope)
+	}
+
+	return s.store.Set(ctx, s.ruleID, raisedPrefix+s.scope, []byte("true"), 0)
+}
+
+func (s *ruleState) incr(ctx context.Context, key string, delta float64, ttl time.Duration) (float64, error) {
+	if !s.dryRun {
+		return s.store.Incr(ctx, s.ruleID, s.scope+key, delta, ttl)
+	}
+	v, err := s.get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return delta, nil
+	case float64:
+		return v + delta, nil
+	default:
+		return 0, ErrStateNotNumber
+	}
+}
+
+func (s *ruleState) delete(ctx context.Context, key string) error {
+	if s.dryRun {
+		return nil
+	}
+
+	return s.store.Delete(ctx, s.ruleID, s.scope+key)
+}
+
+func (s *ruleState) addSample(ctx context.Context, key string, value float64) error {
+	if s.dryRun {
+		return nil
+	}
+	sample := Sample{Value: value, Time: time.Now().UTC()}
+
+	return s.store.AddSample(ctx, s.ruleID, s.scope+key, sample, WindowRetention, MaxWindowSamples)
+}
+
+// window returns the samples of the window of the given size. The size is
+// either a duration (or a duration string), selecting the samples recorded
+// within it, or a number, selecting the given number of the latest samples.
+func (s *ruleState) window(ctx context.Context, key string, size any) ([]Sample, error) {
+	var d time.Duration
+	var n int
+	switch size := size.(type) {
+	case time.Duration:
+		d = size
+	case string:
+		v, err := time.ParseDuration(size)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidWindow, err)
+		}
+		d = v
+	case int:
+		n = size
+	case float64:
+		if size != math.Trunc(size) {
+			return nil, ErrInvalidWindow
+		}
+		n = int(size)
+	default:
+		return nil, errors.Wrap(ErrInvalidWindow, fmt.Errorf("unsupported size type %T", size))
+	}
+	if d <= 0 && n <= 0 {
+		return nil, ErrInvalidWindow
+	}
+
+	samples, err := s.store.Samples(ctx, s.ruleID, s.scope+key)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		return samples[max(len(samples)-n, 0):], nil
+	}
+	since := time.Now().UTC().Add(-d)
+	for i, sample := range samples {
+		if sample.Time.After(since) {
+			return samples[i:], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// aggregate applies the aggregation function to the samples. It returns false
+// for empty samples, except for the count, which is zero in that case.
+func aggregate(fn string, samples []Sample) (float64, bool) {
+	if fn == "count" {
+		return float64(len(samples)), true
+	}
+	if len(samples) == 0 {
+		return 0, false
+	}
+	ret := samples[0].Value
+	for _, s := range samples[1:] {
+		switch fn {
+		case "min":
+			ret = min(ret, s.Value)
+		case "max":
+			ret = max(ret, s.Value)
+		case "avg":
+			ret += s.Value
+		}
+	}
+	if fn == "avg" {
+		ret /= float64(len(samples))
+	}
+
+	return ret, true
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package state contains the in-memory and Redis implementations of the
+// rules engine state store.
+package state
@@ -0,0 +1,195 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/absmach/magistrala/re"
+)
+
+// Expired keys and windows are removed when accessed, and all of them at most once per sweepInterval.
+const sweepInterval = time.Minute
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+type memory struct {
+	mu        sync.Mutex
+	values    map[string]map[string]entry
+	windows   map[string]map[string][]re.Sample
+	lastSweep time.Time
+}
+
+// NewMemory returns a state store that keeps the state in memory. The state
+// is lost on restart and is not shared between multiple service instances.
+func NewMemory() re.StateStore {
+	return &memory{
+		values:    make(map[string]map[string]entry),
+		windows:   make(map[string]map[string][]re.Sample),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *memory) Get(_ context.Context, ruleID, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.values[ruleID][key]
+	if !ok {
+		return nil, nil
+	}
+	if e.expired(time.Now()) {
+		delete(m.values[ruleID], key)
+		return nil, nil
+	}
+
+	return e.value, nil
+}
+
+func (m *memory) Set(_ context.Context, ruleID, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+	e := entry{value: value}
+	if ttl > 0 {
+		e.expiresAt = now.Add(ttl)
+	}
+	m.set(ruleID, key, e)
+
+	return nil
+}
+
+func (m *memory) Incr(_ context.Context, ruleID, key string, delta float64, ttl time.Duration) (float64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+	e, ok := m.values[ruleID][key]
+	if !ok || e.expired(now) {
+		e = entry{value: []byte("0")}
+	}
+	v, err := strconv.ParseFloat(string(e.value), 64)
+	if err != nil {
+		return 0, re.ErrStateNotNumber
+	}
+	v += delta
+	e.value = []byte(strconv.FormatFloat(v, 'f', -1, 64))
+	if e.expiresAt.IsZero() && ttl > 0 {
+		e.expiresAt = now.Add(ttl)
+	}
+	m.set(ruleID, key, e)
+
+	return v, nil
+}
+
+func (m *memory) Delete(_ context.Context, ruleID, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values[ruleID], key)
+
+	return nil
+}
+
+func (m *memory) AddSample(_ context.Context, ruleID, key string, s re.Sample, retention time.Duration, limit int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(time.Now())
+	if _, ok := m.windows[ruleID]; !ok {
+		m.windows[ruleID] = make(map[string][]re.Sample)
+	}
+	samples := append(m.windows[ruleID][key], s)
+	samples = trim(samples, s.Time.Add(-retention), limit)
+	m.windows[ruleID][key] = samples
+
+	return nil
+}
+
+func (m *memory) Samples(_ context.Context, ruleID, key string) ([]re.Sample, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	samples := trim(m.windows[ruleID][key], time.Now().Add(-re.WindowRetention), re.MaxWindowSamples)
+	if len(samples) == 0 {
+		delete(m.windows[ruleID], key)
+		return nil, nil
+	}
+	m.windows[ruleID][key] = samples
+
+	return append([]re.Sample(nil), samples...), nil
+}
+
+func (m *memory) Clear(_ context.Context, ruleID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.values, ruleID)
+	delete(m.windows, ruleID)
+
+	return nil
+}
+
+func (m *memory) set(ruleID, key string, e entry) {
+	if _, ok := m.values[ruleID]; !ok {
+		m.values[ruleID] = make(map[string]entry)
+	}
+	m.values[ruleID][key] = e
+}
+
+// sweep removes expired keys, so keys that are never accessed again don't pile up.
+func (m *memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+	for ruleID, values := range m.values {
+		for key, e := range values {
+			if e.expired(now) {
+				delete(values, key)
+			}
+		}
+		if len(values) == 0 {
+			delete(m.values, ruleID)
+		}
+	}
+	since := now.Add(-re.WindowRetention)
+	for ruleID, windows := range m.windows {
+		for key, samples := range windows {
+			if samples[len(samples)-1].Time.Before(since) {
+				delete(windows, key)
+			}
+		}
+		if len(windows) == 0 {
+			delete(m.windows, ruleID)
+		}
+	}
+}
+
+// trim removes the samples recorded before since and keeps at most limit latest samples.
+func trim(samples []re.Sample, since time.Time, limit int) []re.Sample {
+	i := 0
+	for i < len(samples) && samples[i].Time.Before(since) {
+		i++
+	}
+	samples = samples[i:]
+	if len(samples) > limit {
+		samples = samples[len(samples)-limit:]
+	}
+
+	return samples
+}
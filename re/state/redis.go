@@ -0,0 +1,149 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	"github.com/absmach/magistrala/re"
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "re:state"
+
+type redisStore struct {
+	client *redis.Client
+}
+
+// NewRedis returns a state store that keeps the state in Redis, so
+// it's persisted and shared between multiple service instances.
+func NewRedis(client *redis.Client) re.StateStore {
+	return &redisStore{client: client}
+}
+
+func (rs *redisStore) Get(ctx context.Context, ruleID, key string) ([]byte, error) {
+	v, err := rs.client.Get(ctx, valueKey(ruleID, key)).Bytes()
+	switch {
+	case err == redis.Nil:
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return v, nil
+}
+
+func (rs *redisStore) Set(ctx context.Context, ruleID, key string, value []byte, ttl time.Duration) error {
+	if err := rs.client.Set(ctx, valueKey(ruleID, key), value, ttl).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rs *redisStore) Incr(ctx context.Context, ruleID, key string, delta float64, ttl time.Duration) (float64, error) {
+	k := valueKey(ruleID, key)
+	var incr *redis.FloatCmd
+	_, err := rs.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		incr = p.IncrByFloat(ctx, k, delta)
+		if ttl > 0 {
+			p.ExpireNX(ctx, k, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "not a valid float") {
+			return 0, re.ErrStateNotNumber
+		}
+		return 0, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return incr.Val(), nil
+}
+
+func (rs *redisStore) Delete(ctx context.Context, ruleID, key string) error {
+	if err := rs.client.Del(ctx, valueKey(ruleID, key)).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+// AddSample stores window samples in a sorted set scored by the sample time.
+func (rs *redisStore) AddSample(ctx context.Context, ruleID, key string, s re.Sample, retention time.Duration, limit int) error {
+	k := windowKey(ruleID, key)
+	// The time is a part of the member, so the same values don't overwrite each other.
+	member := strconv.FormatInt(s.Time.UnixNano(), 10) + ":" + strconv.FormatFloat(s.Value, 'f', -1, 64)
+	since := s.Time.Add(-retention).UnixNano()
+	_, err := rs.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.ZAdd(ctx, k, redis.Z{Score: float64(s.Time.UnixNano()), Member: member})
+		p.ZRemRangeByScore(ctx, k, "-inf", fmt.Sprintf("(%d", since))
+		p.ZRemRangeByRank(ctx, k, 0, int64(-limit-1))
+		p.Expire(ctx, k, retention)
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rs *redisStore) Samples(ctx context.Context, ruleID, key string) ([]re.Sample, error) {
+	since := time.Now().Add(-re.WindowRetention).UnixNano()
+	members, err := rs.client.ZRangeByScore(ctx, windowKey(ruleID, key), &redis.ZRangeBy{
+		Min: strconv.FormatInt(since, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	var samples []re.Sample
+	for _, m := range members {
+		ts, val, ok := strings.Cut(m, ":")
+		if !ok {
+			continue
+		}
+		ns, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		v, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		samples = append(samples, re.Sample{Value: v, Time: time.Unix(0, ns).UTC()})
+	}
+
+	return samples, nil
+}
+
+func (rs *redisStore) Clear(ctx context.Context, ruleID string) error {
+	iter := rs.client.Scan(ctx, 0, fmt.Sprintf("%s:%s:*", keyPrefix, ruleID), 0).Iterator()
+	for iter.Next(ctx) {
+		if err := rs.client.Del(ctx, iter.Val()).Err(); err != nil {
+			return errors.Wrap(repoerr.ErrRemoveEntity, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func valueKey(ruleID, key string) string {
+	return fmt.Sprintf("%s:%s:value:%s", keyPrefix, ruleID, key)
+}
+
+func windowKey(ruleID, key string) string {
+	return fmt.Sprintf("%s:%s:window:%s", keyPrefix, ruleID, key)
+}
@@ -0,0 +1,22 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package state_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	storeClient *redis.Client
+	storeURL    string
+)
+
+func TestMain(m *testing.M) {
+	code := testsutil.RunRedisTest(m, &storeClient, &storeURL)
+	os.Exit(code)
+}
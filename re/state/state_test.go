@@ -0,0 +1,278 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package state_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/errors"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStores() map[string]re.StateStore {
+	return map[string]re.StateStore{
+		"memory": state.NewMemory(),
+		"redis":  state.NewRedis(storeClient),
+	}
+}
+
+func TestSetGet(t *testing.T) {
+	for name, store := range newStores() {
+		ruleID := testsutil.GenerateUUID(t)
+
+		cases := []struct {
+			desc  string
+			key   string
+			value []byte
+			ttl   time.Duration
+			wait  time.Duration
+			res   []byte
+		}{
+			{
+				desc:  "set and get value",
+				key:   "value",
+				value: []byte(`{"temperature":20}`),
+				res:   []byte(`{"temperature":20}`),
+			},
+			{
+				desc:  "set and get value before it expires",
+				key:   "ttl",
+				value: []byte(`"on"`),
+				ttl:   time.Minute,
+				res:   []byte(`"on"`),
+			},
+			{
+				desc:  "set and get expired value",
+				key:   "expired",
+				value: []byte(`true`),
+				ttl:   10 * time.Millisecond,
+				wait:  20 * time.Millisecond,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s: %s", name, tc.desc), func(t *testing.T) {
+				err := store.Set(context.Background(), ruleID, tc.key, tc.value, tc.ttl)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				time.Sleep(tc.wait)
+				res, err := store.Get(context.Background(), ruleID, tc.key)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.res, res))
+			})
+		}
+
+		t.Run(fmt.Sprintf("%s: get value of another rule", name), func(t *testing.T) {
+			res, err := store.Get(context.Background(), testsutil.GenerateUUID(t), "value")
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func TestIncr(t *testing.T) {
+	for name, store := range newStores() {
+		ruleID := testsutil.GenerateUUID(t)
+		err := store.Set(context.Background(), ruleID, "text", []byte(`"text"`), 0)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		cases := []struct {
+			desc  string
+			key   string
+			delta float64
+			ttl   time.Duration
+			res   float64
+			err   error
+		}{
+			{
+				desc:  "increment missing key",
+				key:   "counter",
+				delta: 1,
+				ttl:   time.Minute,
+				res:   1,
+			},
+			{
+				desc:  "increment existing key",
+				key:   "counter",
+				delta: 2.5,
+				ttl:   time.Minute,
+				res:   3.5,
+			},
+			{
+				desc:  "decrement existing key",
+				key:   "counter",
+				delta: -0.5,
+				res:   3,
+			},
+			{
+				desc:  "increment non-numeric value",
+				key:   "text",
+				delta: 1,
+				err:   re.ErrStateNotNumber,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s: %s", name, tc.desc), func(t *testing.T) {
+				res, err := store.Incr(context.Background(), ruleID, tc.key, tc.delta, tc.ttl)
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+				assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			})
+		}
+
+		t.Run(fmt.Sprintf("%s: get incremented value", name), func(t *testing.T) {
+			res, err := store.Get(context.Background(), ruleID, "counter")
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Equal(t, []byte("3"), res)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for name, store := range newStores() {
+		ruleID := testsutil.GenerateUUID(t)
+		err := store.Set(context.Background(), ruleID, "value", []byte(`1`), 0)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+		cases := []struct {
+			desc string
+			key  string
+		}{
+			{
+				desc: "delete existing key",
+				key:  "value",
+			},
+			{
+				desc: "delete missing key",
+				key:  "missing",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s: %s", name, tc.desc), func(t *testing.T) {
+				err := store.Delete(context.Background(), ruleID, tc.key)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				res, err := store.Get(context.Background(), ruleID, tc.key)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				assert.Nil(t, res)
+			})
+		}
+	}
+}
+
+func TestSamples(t *testing.T) {
+	for name, store := range newStores() {
+		ruleID := testsutil.GenerateUUID(t)
+		now := time.Now().UTC()
+
+		cases := []struct {
+			desc      string
+			key       string
+			samples   []re.Sample
+			retention time.Duration
+			limit     int
+			res       []re.Sample
+		}{
+			{
+				desc: "add samples",
+				key:  "temperature",
+				samples: []re.Sample{
+					{Value: 20, Time: now.Add(-2 * time.Second)},
+					{Value: 20, Time: now.Add(-time.Second)},
+					{Value: 22.5, Time: now},
+				},
+				retention: time.Hour,
+				limit:     10,
+				res: []re.Sample{
+					{Value: 20, Time: now.Add(-2 * time.Second)},
+					{Value: 20, Time: now.Add(-time.Second)},
+					{Value: 22.5, Time: now},
+				},
+			},
+			{
+				desc: "add samples over the limit",
+				key:  "limited",
+				samples: []re.Sample{
+					{Value: 1, Time: now.Add(-2 * time.Second)},
+					{Value: 2, Time: now.Add(-time.Second)},
+					{Value: 3, Time: now},
+				},
+				retention: time.Hour,
+				limit:     2,
+				res: []re.Sample{
+					{Value: 2, Time: now.Add(-time.Second)},
+					{Value: 3, Time: now},
+				},
+			},
+			{
+				desc: "add samples older than retention",
+				key:  "retained",
+				samples: []re.Sample{
+					{Value: 1, Time: now.Add(-2 * time.Hour)},
+					{Value: 2, Time: now.Add(-time.Minute)},
+					{Value: 3, Time: now},
+				},
+				retention: time.Hour,
+				limit:     10,
+				res: []re.Sample{
+					{Value: 2, Time: now.Add(-time.Minute)},
+					{Value: 3, Time: now},
+				},
+			},
+			{
+				desc: "get samples of missing window",
+				key:  "missing",
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(fmt.Sprintf("%s: %s", name, tc.desc), func(t *testing.T) {
+				for _, s := range tc.samples {
+					err := store.AddSample(context.Background(), ruleID, tc.key, s, tc.retention, tc.limit)
+					assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				}
+				res, err := store.Samples(context.Background(), ruleID, tc.key)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+				assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			})
+		}
+	}
+}
+
+func TestClear(t *testing.T) {
+	for name, store := range newStores() {
+		ruleID := testsutil.GenerateUUID(t)
+		otherID := testsutil.GenerateUUID(t)
+		for _, id := range []string{ruleID, otherID} {
+			err := store.Set(context.Background(), id, "value", []byte(`1`), 0)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			err = store.AddSample(context.Background(), id, "window", re.Sample{Value: 1, Time: time.Now().UTC()}, time.Hour, 10)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}
+
+		t.Run(fmt.Sprintf("%s: clear rule state", name), func(t *testing.T) {
+			err := store.Clear(context.Background(), ruleID)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+			value, err := store.Get(context.Background(), ruleID, "value")
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Nil(t, value)
+			samples, err := store.Samples(context.Background(), ruleID, "window")
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Empty(t, samples)
+
+			value, err = store.Get(context.Background(), otherID, "value")
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Equal(t, []byte(`1`), value)
+			samples, err = store.Samples(context.Background(), otherID, "window")
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			assert.Len(t, samples, 1)
+		})
+	}
+}
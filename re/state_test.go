@@ -0,0 +1,166 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/internal/testsutil"
+	"github.com/absmach/magistrala/pkg/authn"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	"github.com/absmach/magistrala/pkg/messaging"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandleWithState(t *testing.T) {
+	ri := make(chan pkglog.RunInfo, 10)
+	svc, repo, pubsub, _, _, _ := newService(t, ri)
+
+	cases := []struct {
+		desc  string
+		logic re.Script
+		res   map[string]any
+	}{
+		{
+			desc: "handle messages with Lua state and windows",
+			logic: re.Script{
+				Type: re.LuaType,
+				Value: `
+					local n = state.incr("count")
+					local prev = state.get("last")
+					state.set("last", message.payload.temperature, "1h")
+					window.add("temperature", message.payload.temperature)
+					return {
+						count = n,
+						previous = prev,
+						min = window.min("temperature", "1h"),
+						max = window.max("temperature", 10),
+						avg = window.avg("temperature", 2),
+						samples = window.count("temperature", "1h"),
+						missing = window.avg("missing", 10) == nil
+					}`,
+			},
+			res: map[string]any{
+				"count":    float64(3),
+				"previous": float64(30),
+				"min":      float64(20),
+				"max":      float64(30),
+				"avg":      float64(27.5),
+				"samples":  float64(3),
+				"missing":  true,
+			},
+		},
+		{
+			desc: "handle messages with Go state and windows",
+			logic: re.Script{
+				Type: re.GoType,
+				Value: `package main
+
+import (
+	"time"
+
+	"state"
+	"window"
+)
+
+func logicFunction() any {
+	n, err := state.Incr("count", 1, time.Hour)
+	if err != nil {
+		return err.Error()
+	}
+	if err := window.Add("value", n*10); err != nil {
+		return err.Error()
+	}
+	avg, _ := window.Avg("value", time.Hour)
+	count, _ := window.Count("value", 2)
+	return map[string]any{"count": n, "avg": avg, "samples": count}
+}`,
+			},
+			res: map[string]any{
+				"count":   float64(3),
+				"avg":     float64(20),
+				"samples": float64(2),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule := re.Rule{
+				ID:           testsutil.GenerateUUID(t),
+				DomainID:     domainID,
+				InputChannel: inputChannel,
+				Status:       re.EnabledStatus,
+				Logic:        tc.logic,
+				Outputs: re.Outputs{
+					&outputs.ChannelPublisher{Channel: "output.channel"},
+				},
+			}
+			published := make(chan *messaging.Message, 1)
+			repoCall := repo.On("ListAllRules", mock.Anything, mock.Anything).Return(re.Page{Rules: []re.Rule{rule}}, nil)
+			dlCall := repo.On("AddDeadLetter", mock.Anything, mock.Anything).Return(nil).Maybe()
+			pubCall := pubsub.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				published <- args.Get(2).(*messaging.Message)
+			})
+
+			var res map[string]any
+			for _, temp := range []int{20, 30, 25} {
+				err := svc.Handle(&messaging.Message{
+					Domain:    domainID,
+					Channel:   inputChannel,
+					Publisher: "sensor",
+					Payload:   fmt.Appendf(nil, `{"temperature": %d}`, temp),
+				})
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				select {
+				case msg := <-published:
+					res = map[string]any{}
+					err := json.Unmarshal(msg.Payload, &res)
+					assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				case <-time.After(time.Second):
+					t.Fatalf("%s: message was not published", tc.desc)
+				}
+				<-ri
+			}
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+
+			repoCall.Unset()
+			dlCall.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestTestRuleWithState(t *testing.T) {
+	// nolint:dogsled
+	svc, _, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	rule := re.Rule{
+		ID: testsutil.GenerateUUID(t),
+		Logic: re.Script{
+			Type:  re.LuaType,
+			Value: `window.add("value", 1); return {count = state.incr("count", 2), samples = window.count("value", 10)}`,
+		},
+	}
+
+	// State changes are not persisted in test runs, so each run sees the same state.
+	for range 2 {
+		res, err := svc.TestRule(context.Background(), session, rule, &messaging.Message{})
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		assert.Equal(t, map[string]any{"count": float64(2), "samples": float64(0)}, res.Result)
+	}
+
+	res, err := svc.TestRule(context.Background(), session, re.Rule{
+		Logic: re.Script{Type: re.LuaType, Value: `return window.avg("value", "not a duration")`},
+	}, &messaging.Message{})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Contains(t, res.Error, re.ErrInvalidWindow.Error())
+}
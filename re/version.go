@@ -0,0 +1,193 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	gostrings "strings"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/schedule"
+)
+
+// maxDiffCells limits the size of line diffs, so large changes are shown as replaced.
+const maxDiffCells = 1 << 20
+
+// RuleVersion is an immutable snapshot of the rule definition. A version
+// is stored each time the rule is created, updated or restored.
+type RuleVersion struct {
+	RuleID       string            `json:"rule_id"`
+	Version      uint64            `json:"version"`
+	DomainID     string            `json:"domain_id"`
+	InputChannel string            `json:"input_channel"`
+	InputTopic   string            `json:"input_topic"`
+	Logic        Script            `json:"logic"`
+	Outputs      Outputs           `json:"outputs,omitempty"`
+	Steps        []Step            `json:"steps,omitempty"`
+	Schedule     schedule.Schedule `json:"schedule"`
+	// RestoredFrom is the version the rule was restored from, if any.
+	RestoredFrom uint64    `json:"restored_from,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by"`
+}
+
+// VersionPageMeta contains page metadata for listing rule versions.
+type VersionPageMeta struct {
+	Offset uint64 `json:"offset"  db:"offset"`
+	Limit  uint64 `json:"limit"   db:"limit"`
+	RuleID string `json:"rule_id" db:"rule_id"`
+}
+
+type VersionsPage struct {
+	Offset   uint64        `json:"offset"`
+	Limit    uint64        `json:"limit"`
+	Total    uint64        `json:"total"`
+	Versions []RuleVersion `json:"versions"`
+}
+
+// FieldChange is a change of a single rule field between two versions. Scripts
+// and structured fields, such as outputs and steps, are also diffed line by
+// line, where lines start with "-" if removed, "+" if added and " " if unchanged.
+type FieldChange struct {
+	Field string   `json:"field"`
+	From  any      `json:"from"`
+	To    any      `json:"to"`
+	Diff  []string `json:"diff,omitempty"`
+}
+
+// VersionDiff contains the changes between two rule versions.
+type VersionDiff struct {
+	RuleID  string        `json:"rule_id"`
+	From    uint64        `json:"from"`
+	To      uint64        `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// diffVersions returns the changes of the versioned rule fields.
+func diffVersions(from, to RuleVersion) []FieldChange {
+	fields := []struct {
+		name     string
+		from, to any
+		lines    bool
+	}{
+		{name: "input_channel", from: from.InputChannel, to: to.InputChannel},
+		{name: "input_topic", from: from.InputTopic, to: to.InputTopic},
+		{name: "logic.type", from: from.Logic.Type, to: to.Logic.Type},
+		{name: "logic.value", from: from.Logic.Value, to: to.Logic.Value, lines: true},
+		{name: "outputs", from: jsonValue(from.Outputs), to: jsonValue(to.Outputs), lines: true},
+		{name: "steps", from: jsonValue(from.Steps), to: jsonValue(to.Steps), lines: true},
+		{name: "schedule", from: jsonValue(from.Schedule), to: jsonValue(to.Schedule), lines: true},
+	}
+
+	changes := []FieldChange{}
+	for _, f := range fields {
+		if reflect.DeepEqual(f.from, f.to) {
+			continue
+		}
+		c := FieldChange{Field: f.name, From: f.from, To: f.to}
+		if f.lines {
+			c.Diff = diffLines(diffText(f.from), diffText(f.to))
+		}
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// jsonValue returns the generic JSON representation of the value, so the
+// versions can be compared regardless of the Go types of their fields.
+func jsonValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var ret any
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil
+	}
+
+	return ret
+}
+
+// diffText returns the text the field is diffed by line.
+func diffText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// diffLines returns the line diff of the texts, based on their longest common subsequence.
+func diffLines(a, b string) []string {
+	x, y := splitLines(a), splitLines(b)
+	n, m := len(x), len(y)
+	if n*m > maxDiffCells {
+		var ret []string
+		for _, l := range x {
+			ret = append(ret, "-"+l)
+		}
+		for _, l := range y {
+			ret = append(ret, "+"+l)
+		}
+		return ret
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+				continue
+			}
+			lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+		}
+	}
+
+	ret := make([]string, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case x[i] == y[j]:
+			ret = append(ret, " "+x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ret = append(ret, "-"+x[i])
+			i++
+		default:
+			ret = append(ret, "+"+y[j])
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ret = append(ret, "-"+x[i])
+	}
+	for ; j < m; j++ {
+		ret = append(ret, "+"+y[j])
+	}
+
+	return ret
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return gostrings.Split(gostrings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,243 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package re_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/magistrala/pkg/authn"
+	"github.com/absmach/magistrala/pkg/errors"
+	repoerr "github.com/absmach/magistrala/pkg/errors/repository"
+	svcerr "github.com/absmach/magistrala/pkg/errors/service"
+	pkglog "github.com/absmach/magistrala/pkg/logger"
+	pkgSch "github.com/absmach/magistrala/pkg/schedule"
+	"github.com/absmach/magistrala/re"
+	"github.com/absmach/magistrala/re/outputs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListRuleVersions(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	versions := []re.RuleVersion{
+		{RuleID: ruleID, Version: 2, Logic: luaScript("return 2"), CreatedBy: userID},
+		{RuleID: ruleID, Version: 1, Logic: luaScript("return 1"), CreatedBy: userID},
+	}
+
+	cases := []struct {
+		desc     string
+		pm       re.VersionPageMeta
+		repoResp re.VersionsPage
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "list rule versions successfully",
+			pm:       re.VersionPageMeta{RuleID: ruleID, Limit: 10},
+			repoResp: re.VersionsPage{Total: 2, Limit: 10, Versions: versions},
+		},
+		{
+			desc:    "list rule versions with repo error",
+			pm:      re.VersionPageMeta{RuleID: ruleID, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("ListRuleVersions", mock.Anything, tc.pm).Return(tc.repoResp, tc.repoErr)
+			page, err := svc.ListRuleVersions(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.repoResp, page)
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDiffRuleVersions(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	v1 := re.RuleVersion{
+		RuleID:       ruleID,
+		Version:      1,
+		InputChannel: inputChannel,
+		Logic:        luaScript("local t = message.payload.temperature\nreturn t > 30"),
+		Outputs:      re.Outputs{&outputs.ChannelPublisher{Channel: "alerts"}},
+	}
+	v2 := v1
+	v2.Version = 2
+	v2.InputTopic = "temperature"
+	v2.Logic = luaScript("local t = message.payload.temperature\nreturn t > 35")
+
+	cases := []struct {
+		desc    string
+		from    uint64
+		to      uint64
+		older   re.RuleVersion
+		newer   re.RuleVersion
+		repoErr error
+		diff    re.VersionDiff
+		err     error
+	}{
+		{
+			desc:  "diff latest version with the previous version",
+			older: v1,
+			newer: v2,
+			diff: re.VersionDiff{
+				RuleID: ruleID,
+				From:   1,
+				To:     2,
+				Changes: []re.FieldChange{
+					{Field: "input_topic", From: "", To: "temperature"},
+					{
+						Field: "logic.value",
+						From:  v1.Logic.Value,
+						To:    v2.Logic.Value,
+						Diff:  []string{" local t = message.payload.temperature", "-return t > 30", "+return t > 35"},
+					},
+				},
+			},
+		},
+		{
+			desc:  "diff first version",
+			to:    1,
+			newer: v1,
+			diff: re.VersionDiff{
+				RuleID: ruleID,
+				To:     1,
+				Changes: []re.FieldChange{
+					{Field: "input_channel", From: "", To: inputChannel},
+					{
+						Field: "logic.value",
+						From:  "",
+						To:    v1.Logic.Value,
+						Diff:  []string{"+local t = message.payload.temperature", "+return t > 30"},
+					},
+					{
+						Field: "outputs",
+						From:  nil,
+						To:    []any{map[string]any{"type": "channels", "channel": "alerts", "topic": ""}},
+						Diff:  []string{"+[", "+  {", "+    \"channel\": \"alerts\",", "+    \"topic\": \"\",", "+    \"type\": \"channels\"", "+  }", "+]"},
+					},
+				},
+			},
+		},
+		{
+			desc:  "diff same versions",
+			from:  2,
+			to:    2,
+			older: v2,
+			newer: v2,
+			diff:  re.VersionDiff{RuleID: ruleID, From: 2, To: 2, Changes: []re.FieldChange{}},
+		},
+		{
+			desc:    "diff non-existing version",
+			to:      3,
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			newerCall := repo.On("ViewRuleVersion", mock.Anything, ruleID, tc.to).Return(tc.newer, tc.repoErr)
+			if tc.older.Version != tc.to {
+				olderCall := repo.On("ViewRuleVersion", mock.Anything, ruleID, tc.older.Version).Return(tc.older, nil)
+				defer olderCall.Unset()
+			}
+			diff, err := svc.DiffRuleVersions(context.Background(), session, ruleID, tc.from, tc.to)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.diff, diff)
+			newerCall.Unset()
+		})
+	}
+}
+
+func TestRestoreRuleVersion(t *testing.T) {
+	// nolint:dogsled
+	svc, repo, _, _, _, _ := newService(t, make(chan pkglog.RunInfo))
+	session := authn.Session{UserID: userID, DomainID: domainID}
+	past := time.Now().UTC().Add(-24 * time.Hour)
+	version := re.RuleVersion{
+		RuleID:       ruleID,
+		Version:      1,
+		DomainID:     domainID,
+		InputChannel: inputChannel,
+		Logic:        luaScript("return message.payload.temperature > 30"),
+		Schedule:     pkgSch.Schedule{StartDateTime: past, Time: past, Recurring: pkgSch.Daily, RecurringPeriod: 1},
+	}
+
+	cases := []struct {
+		desc        string
+		version     re.RuleVersion
+		viewErr     error
+		restoreErr  error
+		restoredAt  uint64
+		err         error
+		scheduledIn bool
+	}{
+		{
+			desc:        "restore rule version successfully",
+			version:     version,
+			restoredAt:  3,
+			scheduledIn: true,
+		},
+		{
+			desc: "restore rule version with logic not allowed in the sandbox",
+			version: re.RuleVersion{
+				RuleID:  ruleID,
+				Version: 1,
+				Logic:   re.Script{Type: re.GoType, Value: `func logicFunction() any { go func() {}(); return true }`},
+			},
+			err: re.ErrGoroutinesNotAllowed,
+		},
+		{
+			desc:    "restore non-existing rule version",
+			version: re.RuleVersion{RuleID: ruleID, Version: 1},
+			viewErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:       "restore rule version with repo error",
+			version:    version,
+			restoreErr: repoerr.ErrNotFound,
+			err:        svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var restored re.Rule
+			var restoredFrom uint64
+			viewCall := repo.On("ViewRuleVersion", mock.Anything, ruleID, tc.version.Version).Return(tc.version, tc.viewErr)
+			restoreCall := repo.On("RestoreRule", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					restored = args.Get(1).(re.Rule)
+					restoredFrom = args.Get(2).(uint64)
+				}).
+				Return(re.Rule{ID: ruleID, Logic: tc.version.Logic, Version: tc.restoredAt}, tc.restoreErr)
+			rule, err := svc.RestoreRuleVersion(context.Background(), session, ruleID, tc.version.Version)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.restoredAt, rule.Version)
+				assert.Equal(t, tc.version.Logic, restored.Logic)
+				assert.Equal(t, tc.version.InputChannel, restored.InputChannel)
+				assert.Equal(t, userID, restored.UpdatedBy)
+				assert.Equal(t, tc.version.Version, restoredFrom)
+			}
+			if tc.scheduledIn {
+				assert.True(t, restored.Schedule.Time.After(past), fmt.Sprintf("%s: expected the restored schedule to be due from now on got %s", tc.desc, restored.Schedule.Time))
+			}
+			viewCall.Unset()
+			restoreCall.Unset()
+		})
+	}
+}
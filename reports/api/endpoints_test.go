@@ -138,6 +138,17 @@ func TestAddReportConfigEndpoint(t *testing.T) {
 	reportInPast := reportConfig
 	reportInPast.Schedule = scheduleInPast
 
+	cronReport := reportConfig
+	cronReport.Schedule = pkgSch.Schedule{
+		StartDateTime: future,
+		Recurring:     pkgSch.Cron,
+		Cron:          "30 8 * * mon-fri",
+		Timezone:      "Europe/Berlin",
+	}
+
+	invalidCronReport := cronReport
+	invalidCronReport.Schedule.Cron = "30 8 * *"
+
 	cases := []struct {
 		desc        string
 		cfg         reports.ReportConfig
@@ -209,6 +220,26 @@ func TestAddReportConfigEndpoint(t *testing.T) {
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrValidation,
 		},
+		{
+			desc:        "add report config with cron schedule",
+			token:       validToken,
+			domainID:    domainID,
+			authnRes:    smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID},
+			cfg:         cronReport,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			svcRes:      cronReport,
+		},
+		{
+			desc:        "add report config with invalid cron schedule",
+			token:       validToken,
+			domainID:    domainID,
+			authnRes:    smqauthn.Session{DomainUserID: auth.EncodeDomainUserID(domainID, userID), UserID: userID, DomainID: domainID},
+			cfg:         invalidCronReport,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
 		{
 			desc:        "add report config with service error",
 			token:       validToken,
@@ -196,7 +196,7 @@ func validateReportConfig(req reports.ReportConfig, skipEmailValidation bool, sk
 }
 
 func validateScheduler(sch schedule.Schedule) error {
-	if sch.Recurring != schedule.None && sch.RecurringPeriod < 1 {
+	if sch.Recurring != schedule.None && sch.Recurring != schedule.Cron && sch.RecurringPeriod < 1 {
 		return errInvalidRecurringPeriod
 	}
 	return nil
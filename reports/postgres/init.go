@@ -93,6 +93,19 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 						WHERE jsonb_typeof(rc.metrics) = 'array'`,
 				},
 			},
+			{
+				Id: "reports_04",
+				Up: []string{
+					`ALTER TABLE report_config
+						ADD COLUMN cron     TEXT NOT NULL DEFAULT '',
+						ADD COLUMN timezone TEXT NOT NULL DEFAULT ''`,
+				},
+				Down: []string{
+					`ALTER TABLE report_config
+						DROP COLUMN cron,
+						DROP COLUMN timezone`,
+				},
+			},
 		},
 	}
 
@@ -23,6 +23,8 @@ type dbReport struct {
 	Due             sql.NullTime           `db:"due"`
 	Recurring       schedule.Recurring     `db:"recurring"`
 	RecurringPeriod uint                   `db:"recurring_period"`
+	Cron            string                 `db:"cron"`
+	Timezone        string                 `db:"timezone"`
 	Status          reports.Status         `db:"status"`
 	CreatedAt       time.Time              `db:"created_at"`
 	CreatedBy       string                 `db:"created_by"`
@@ -79,6 +81,8 @@ func reportToDb(r reports.ReportConfig) (dbReport, error) {
 		Due:             t,
 		Recurring:       r.Schedule.Recurring,
 		RecurringPeriod: r.Schedule.RecurringPeriod,
+		Cron:            r.Schedule.Cron,
+		Timezone:        r.Schedule.Timezone,
 		Status:          r.Status,
 		CreatedAt:       r.CreatedAt,
 		CreatedBy:       r.CreatedBy,
@@ -125,6 +129,8 @@ func dbToReport(dto dbReport) (reports.ReportConfig, error) {
 			Time:            dto.Due.Time,
 			Recurring:       dto.Recurring,
 			RecurringPeriod: dto.RecurringPeriod,
+			Cron:            dto.Cron,
+			Timezone:        dto.Timezone,
 		},
 		Email:          &email,
 		Status:         dto.Status,
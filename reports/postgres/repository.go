@@ -35,11 +35,11 @@ func NewRepository(db postgres.Database) reports.Repository {
 func (repo *PostgresRepository) AddReportConfig(ctx context.Context, cfg reports.ReportConfig) (reports.ReportConfig, error) {
 	q := `
 		INSERT INTO report_config (id, name, description, domain_id, config, metrics,
-			email, start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, report_template)
+			email, start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, report_template)
 		VALUES (:id, :name, :description, :domain_id, :config, :metrics,
-			:email, :start_datetime, :due, :recurring, :recurring_period, :created_at, :created_by, :updated_at, :updated_by, :status, :report_template)
+			:email, :start_datetime, :due, :recurring, :recurring_period, :cron, :timezone, :created_at, :created_by, :updated_at, :updated_by, :status, :report_template)
 		RETURNING id, name, description, domain_id, config, metrics,
-			email, start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status, report_template;
+			email, start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status, report_template;
 	`
 	dbr, err := reportToDb(cfg)
 	if err != nil {
@@ -69,7 +69,7 @@ func (repo *PostgresRepository) AddReportConfig(ctx context.Context, cfg reports
 func (repo *PostgresRepository) ViewReportConfig(ctx context.Context, id string) (reports.ReportConfig, error) {
 	q := `
 		SELECT id, name, description, domain_id, config, metrics, report_template,
-			email, start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status
+			email, start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status
 		FROM report_config
 		WHERE id = $1;
 	`
@@ -96,7 +96,7 @@ func (repo *PostgresRepository) UpdateReportConfigStatus(ctx context.Context, cf
 	q := `UPDATE report_config SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 		WHERE id = :id
         RETURNING id, name, description, domain_id, metrics, email, config,
-			start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;`
+			start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status;`
 
 	dbRpt, err := reportToDb(cfg)
 	if err != nil {
@@ -159,7 +159,7 @@ func (repo *PostgresRepository) UpdateReportConfig(ctx context.Context, cfg repo
 			updated_at = :updated_at, updated_by = :updated_by
 		WHERE id = :id
 		RETURNING id, name, description, domain_id, config, metrics,
-			email, start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			email, start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status;
 		`, q)
 
 	dbr, err := reportToDb(cfg)
@@ -194,9 +194,9 @@ func (repo *PostgresRepository) UpdateReportSchedule(ctx context.Context, cfg re
 	q := `
 		UPDATE report_config
 		SET start_datetime = :start_datetime, due = :due, recurring = :recurring,
-			recurring_period = :recurring_period, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
+			recurring_period = :recurring_period, cron = :cron, timezone = :timezone, updated_at = :updated_at, updated_by = :updated_by WHERE id = :id
 		RETURNING id, name, description, domain_id, config, metrics,
-			email, start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			email, start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status;
 	`
 
 	dbr, err := reportToDb(cfg)
@@ -248,7 +248,7 @@ func (repo *PostgresRepository) RemoveReportConfig(ctx context.Context, id strin
 func (repo *PostgresRepository) ListAllReportsConfig(ctx context.Context, pm reports.PageMeta) (reports.ReportConfigPage, error) {
 	listReportsQuery := `
 		SELECT id, name, description, domain_id, metrics, email, config,
-			start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status
+			start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status
 		FROM report_config rc %s %s %s;
 	`
 
@@ -296,7 +296,7 @@ func (repo *PostgresRepository) UpdateReportDue(ctx context.Context, id string,
 		UPDATE report_config
 		SET due = :due, updated_at = :updated_at WHERE id = :id
 		RETURNING id, name, description, domain_id, config, metrics,
-			email, start_datetime, due, recurring, recurring_period, created_at, created_by, updated_at, updated_by, status;
+			email, start_datetime, due, recurring, recurring_period, cron, timezone, created_at, created_by, updated_at, updated_by, status;
 	`
 
 	dbr := dbReport{